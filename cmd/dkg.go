@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/keep-network/keep-core/config"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/gjkr"
+	chainLocal "github.com/keep-network/keep-core/pkg/chain/local"
+	"github.com/urfave/cli"
+)
+
+// DKGCommand contains the definition of the dkg command-line subcommand and
+// its own subcommands.
+var DKGCommand cli.Command
+
+const dkgDescription = `The dkg command provides tools for debugging distributed
+	key generation. The "replay" subcommand re-executes the member whose DKG
+	execution has been recorded in the transcript with the given name against
+	the recorded messages. The execution is timed with a local chain and
+	nothing is sent to the network. The command reports the outcome of the
+	recorded and replayed executions and fails if they do not match.
+	Transcripts are recorded by the client with the DKG.RecordTranscripts
+	configuration option in the given transcripts directory, encrypted with
+	the Ethereum account key file password from the config file.`
+
+func init() {
+	DKGCommand = cli.Command{
+		Name:        "dkg",
+		Usage:       `Provides tools for debugging distributed key generation.`,
+		Description: dkgDescription,
+		Subcommands: []cli.Command{
+			{
+				Name:      "replay",
+				Usage:     "Replays the DKG execution recorded in a transcript.",
+				ArgsUsage: "[transcripts directory] [transcript name]",
+				Action:    replayDKG,
+			},
+		},
+	}
+}
+
+func replayDKG(c *cli.Context) error {
+	if c.NArg() != 2 {
+		return fmt.Errorf(
+			"expected transcripts directory and transcript name arguments",
+		)
+	}
+
+	config, err := config.ReadConfig(c.GlobalString("config"))
+	if err != nil {
+		return fmt.Errorf("error reading config file: [%v]", err)
+	}
+
+	transcripts, err := newEncryptedPersistence(config, c.Args().Get(0))
+	if err != nil {
+		return err
+	}
+
+	transcript, err := gjkr.ReadTranscript(transcripts, c.Args().Get(1))
+	if err != nil {
+		return err
+	}
+
+	localChain := chainLocal.Connect(
+		transcript.GroupSize,
+		transcript.GroupSize-transcript.DishonestThreshold,
+		big.NewInt(0),
+	)
+	blockCounter, err := localChain.BlockCounter()
+	if err != nil {
+		return err
+	}
+
+	currentBlock, err := blockCounter.CurrentBlock()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf(
+		"replaying DKG of member [%v] started at block [%v] with [%v] messages\n",
+		transcript.MemberIndex,
+		transcript.StartBlockHeight,
+		len(transcript.Messages),
+	)
+
+	result, err := gjkr.Replay(
+		context.Background(),
+		transcript,
+		blockCounter,
+		currentBlock+1,
+	)
+	replayedOutcome := gjkr.NewTranscriptOutcome(result, err)
+
+	fmt.Printf("\nrecorded outcome:\n")
+	printTranscriptOutcome(transcript.Outcome)
+	fmt.Printf("\nreplayed outcome:\n")
+	printTranscriptOutcome(replayedOutcome)
+
+	if !replayedOutcome.Matches(transcript.Outcome) {
+		return fmt.Errorf("replayed outcome does not match the recorded one")
+	}
+
+	fmt.Printf("\nreplayed outcome matches the recorded one\n")
+
+	return nil
+}
+
+func printTranscriptOutcome(outcome *gjkr.TranscriptOutcome) {
+	if outcome == nil {
+		fmt.Printf("  not recorded\n")
+		return
+	}
+
+	for _, elimination := range outcome.Eliminations {
+		status := "inactive"
+		if elimination.Disqualified {
+			status = "disqualified"
+		}
+
+		fmt.Printf(
+			"  member [%v] %v: %v\n",
+			elimination.MemberID,
+			status,
+			elimination.Reason,
+		)
+	}
+
+	if len(outcome.GroupPublicKey) > 0 {
+		fmt.Printf("  group public key: 0x%x\n", outcome.GroupPublicKey)
+	}
+
+	if outcome.Error != "" {
+		fmt.Printf("  error: %v\n", outcome.Error)
+	}
+}
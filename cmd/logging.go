@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/urfave/cli"
+)
+
+// LoggingCommand contains the definition of the logging command-line
+// subcommand and its own subcommands.
+var LoggingCommand cli.Command
+
+const loggingDescription = `The logging command inspects and changes log
+	levels of a client running on the same machine without restarting it.
+	The "levels" subcommand lists all loggers along with their current
+	levels. The "set-level" subcommand sets the level of the given logger;
+	a logger name ending with "*" sets the level of all loggers with the
+	given prefix, e.g. "keep*". Levels set this way are not persisted and
+	the LOG_LEVEL environment variable applies again after a restart. The
+	client has to have diagnostics enabled.`
+
+func init() {
+	LoggingCommand = cli.Command{
+		Name:        "logging",
+		Usage:       `Changes log levels of a running client.`,
+		Description: loggingDescription,
+		Subcommands: []cli.Command{
+			{
+				Name:   "levels",
+				Usage:  "Lists loggers and their current levels.",
+				Action: logLevels,
+			},
+			{
+				Name:      "set-level",
+				Usage:     "Sets the level of the given logger.",
+				ArgsUsage: "[logger] [level]",
+				Action:    setLogLevel,
+			},
+		},
+	}
+}
+
+func logLevels(c *cli.Context) error {
+	endpoint, err := loggingEndpoint(c)
+	if err != nil {
+		return err
+	}
+
+	response, err := http.Get(endpoint)
+	if err != nil {
+		return fmt.Errorf("could not get log levels: [%v]", err)
+	}
+
+	levels, err := readLogLevels(response)
+	if err != nil {
+		return err
+	}
+
+	printLogLevels(levels)
+	return nil
+}
+
+func setLogLevel(c *cli.Context) error {
+	if c.NArg() != 2 {
+		return fmt.Errorf("logger name and level have to be provided")
+	}
+
+	endpoint, err := loggingEndpoint(c)
+	if err != nil {
+		return err
+	}
+
+	form := url.Values{
+		"logger": {c.Args().Get(0)},
+		"level":  {c.Args().Get(1)},
+	}
+
+	response, err := http.PostForm(endpoint, form)
+	if err != nil {
+		return fmt.Errorf("could not set log level: [%v]", err)
+	}
+
+	levels, err := readLogLevels(response)
+	if err != nil {
+		return err
+	}
+
+	printLogLevels(levels)
+	return nil
+}
+
+func loggingEndpoint(c *cli.Context) (string, error) {
+	return diagnosticsEndpoint(c, "logging", "change log levels")
+}
+
+func readLogLevels(response *http.Response) (map[string]string, error) {
+	defer response.Body.Close()
+
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("could not read the response: [%v]", err)
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf(
+			"client responded with [%v]: [%v]",
+			response.Status,
+			strings.TrimSpace(string(body)),
+		)
+	}
+
+	levels := make(map[string]string)
+	if err := json.Unmarshal(body, &levels); err != nil {
+		return nil, fmt.Errorf("could not parse the response: [%v]", err)
+	}
+
+	return levels, nil
+}
+
+func printLogLevels(levels map[string]string) {
+	names := make([]string, 0, len(levels))
+	for name := range levels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		fmt.Printf("%-32v %v\n", name, levels[name])
+	}
+}
@@ -0,0 +1,238 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/keep-network/keep-core/config"
+	"github.com/keep-network/keep-core/pkg/beacon"
+	"github.com/urfave/cli"
+)
+
+// MaintenanceCommand contains the definition of the maintenance command-line
+// subcommand and its own subcommands.
+var MaintenanceCommand cli.Command
+
+const maintenanceDescription = `The maintenance command controls the
+	maintenance mode of a client running on the same machine. In the
+	maintenance mode, the client stops submitting tickets for new groups and
+	lets the group selections, DKG and relay entry signing in progress
+	complete. The "enable" and "disable" subcommands switch the maintenance
+	mode for all operators run by the client or, with the --operator flag, for
+	one of them. The "status" subcommand reports, for each operator, whether
+	the client can be restarted safely and the next block at which a group
+	the operator is a member of could be selected for signing. The client has
+	to have diagnostics enabled.`
+
+var maintenanceOperatorFlag = &cli.StringFlag{
+	Name:  "operator",
+	Usage: "address of the operator to switch; all operators if not set",
+}
+
+func init() {
+	MaintenanceCommand = cli.Command{
+		Name:        "maintenance",
+		Usage:       `Drains the client before a restart.`,
+		Description: maintenanceDescription,
+		Subcommands: []cli.Command{
+			{
+				Name:   "enable",
+				Usage:  "Stops ticket submission and drains the client.",
+				Action: enableMaintenance,
+				Flags:  []cli.Flag{maintenanceOperatorFlag},
+			},
+			{
+				Name:   "disable",
+				Usage:  "Resumes ticket submission.",
+				Action: disableMaintenance,
+				Flags:  []cli.Flag{maintenanceOperatorFlag},
+			},
+			{
+				Name:   "status",
+				Usage:  "Reports whether the client can be restarted safely.",
+				Action: maintenanceStatus,
+			},
+		},
+	}
+}
+
+func enableMaintenance(c *cli.Context) error {
+	return switchMaintenance(c, true)
+}
+
+func disableMaintenance(c *cli.Context) error {
+	return switchMaintenance(c, false)
+}
+
+func switchMaintenance(c *cli.Context, enabled bool) error {
+	endpoint, err := maintenanceEndpoint(c)
+	if err != nil {
+		return err
+	}
+
+	form := url.Values{"enabled": {fmt.Sprintf("%v", enabled)}}
+	if operator := c.String(maintenanceOperatorFlag.Name); operator != "" {
+		form.Set("operator", operator)
+	}
+
+	response, err := http.PostForm(endpoint, form)
+	if err != nil {
+		return fmt.Errorf("could not switch the maintenance mode: [%v]", err)
+	}
+
+	statuses, err := readMaintenanceStatuses(response)
+	if err != nil {
+		return err
+	}
+
+	printMaintenanceStatuses(statuses)
+	return nil
+}
+
+func maintenanceStatus(c *cli.Context) error {
+	endpoint, err := maintenanceEndpoint(c)
+	if err != nil {
+		return err
+	}
+
+	response, err := http.Get(endpoint)
+	if err != nil {
+		return fmt.Errorf("could not get the maintenance status: [%v]", err)
+	}
+
+	statuses, err := readMaintenanceStatuses(response)
+	if err != nil {
+		return err
+	}
+
+	printMaintenanceStatuses(statuses)
+	return nil
+}
+
+func maintenanceEndpoint(c *cli.Context) (string, error) {
+	return diagnosticsEndpoint(c, "maintenance", "control the maintenance mode")
+}
+
+// diagnosticsEndpoint returns the URL of the given path on the diagnostics
+// server of the client running on the same machine. The purpose is used in
+// the error message if diagnostics are not configured.
+func diagnosticsEndpoint(
+	c *cli.Context,
+	path string,
+	purpose string,
+) (string, error) {
+	cfg, err := config.ReadConfig(c.GlobalString("config"))
+	if err != nil {
+		return "", fmt.Errorf("error reading config file: [%v]", err)
+	}
+
+	if cfg.Diagnostics.Port == 0 {
+		return "", fmt.Errorf(
+			"diagnostics are not configured; set the diagnostics port "+
+				"in the config file to %v",
+			purpose,
+		)
+	}
+
+	return fmt.Sprintf(
+		"http://127.0.0.1:%v/%v",
+		cfg.Diagnostics.Port,
+		path,
+	), nil
+}
+
+func readMaintenanceStatuses(
+	response *http.Response,
+) (map[string]*beacon.MaintenanceStatus, error) {
+	defer response.Body.Close()
+
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("could not read the response: [%v]", err)
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf(
+			"client responded with [%v]: [%v]",
+			response.Status,
+			strings.TrimSpace(string(body)),
+		)
+	}
+
+	statuses := make(map[string]*beacon.MaintenanceStatus)
+	if err := json.Unmarshal(body, &statuses); err != nil {
+		return nil, fmt.Errorf("could not parse the response: [%v]", err)
+	}
+
+	return statuses, nil
+}
+
+func printMaintenanceStatuses(statuses map[string]*beacon.MaintenanceStatus) {
+	operators := make([]string, 0, len(statuses))
+	for operator := range statuses {
+		operators = append(operators, operator)
+	}
+	sort.Strings(operators)
+
+	enabled, safeToRestart := true, true
+	for _, operator := range operators {
+		status := statuses[operator]
+
+		fmt.Printf("Operator [%v]\n", operator)
+		printMaintenanceStatus(status)
+		fmt.Println()
+
+		enabled = enabled && status.Enabled
+		safeToRestart = safeToRestart && status.SafeToRestart
+	}
+
+	switch {
+	case safeToRestart:
+		fmt.Printf("It is safe to restart the client.\n")
+	case !enabled:
+		fmt.Printf(
+			"It is not safe to restart the client; " +
+				"enable the maintenance mode for all operators first.\n",
+		)
+	default:
+		fmt.Printf(
+			"It is not yet safe to restart the client; " +
+				"wait for the executions in progress to complete.\n",
+		)
+	}
+}
+
+func printMaintenanceStatus(status *beacon.MaintenanceStatus) {
+	fmt.Printf("Maintenance mode enabled:        [%v]\n", status.Enabled)
+	fmt.Printf("Current block:                   [%v]\n", status.CurrentBlock)
+	fmt.Printf("Group selections in progress:    [%v]\n", status.GroupSelections)
+	fmt.Printf("Protocol executions in progress: [%v]\n", status.Protocols)
+	fmt.Printf("Member of groups:                [%v]\n", status.Groups)
+	fmt.Printf("Safe to restart:                 [%v]\n", status.SafeToRestart)
+
+	switch {
+	case status.SelectedForSigning:
+		fmt.Printf(
+			"A group of the operator is signing the relay entry requested "+
+				"at block [%v]; the entry times out after block [%v].\n",
+			status.NextSigningSelectionBlock,
+			status.RelayEntryTimeoutBlock,
+		)
+	case status.NextSigningSelectionBlock > 0:
+		fmt.Printf(
+			"A group of the operator could be selected for signing "+
+				"as early as block [%v].\n",
+			status.NextSigningSelectionBlock,
+		)
+	case status.Groups > 0:
+		fmt.Printf(
+			"None of the groups of the operator can be selected for " +
+				"signing.\n",
+		)
+	}
+}
@@ -86,6 +86,7 @@ func pingRequest(c *cli.Context) error {
 		libp2p.ProtocolBeacon,
 		firewall.Disabled,
 		retransmission.NewTimeTicker(ctx, 50*time.Millisecond),
+		libp2p.WithClientVersion(c.App.Version),
 	)
 	if err != nil {
 		return err
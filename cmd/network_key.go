@@ -0,0 +1,327 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/keep-network/keep-core/config"
+	"github.com/keep-network/keep-core/pkg/chain/ethereum"
+	"github.com/keep-network/keep-core/pkg/net/key"
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	"github.com/urfave/cli"
+)
+
+// NetworkKeyCommand contains the definition of the network-key command-line
+// subcommand and its own subcommands.
+var NetworkKeyCommand cli.Command
+
+const (
+	keyFileFlag  = "key-file"
+	validityFlag = "validity"
+)
+
+const networkKeyDescription = `The network-key command manages the network key the client
+   uses as its identity in the network. The network key is separate from the
+   operator key and is authorized to act on behalf of the operator by
+   a certificate signed with the operator key. The operator key is used only
+   to sign the certificate and is never exposed to other peers.
+
+   The certificate expires after the period set with the --validity flag and
+   has to be renewed before that. Each newly issued certificate supersedes the
+   ones issued before it by the same operator, so peers stop accepting
+   a replaced network key once they see the new one.
+
+   The network key file is read from the path configured as LibP2P.KeyFile
+   unless overridden with the --key-file flag.`
+
+const generateNetworkKeyDescription = `Generates a new network key and a certificate
+   authorizing it, and stores them in the network key file. Fails if the file
+   already exists; use the rotate subcommand to replace an existing key.`
+
+const rotateNetworkKeyDescription = `Replaces the network key stored in the network key
+   file with a newly generated one. The new key is authorized by the same
+   operator as the replaced key.
+
+   Rotating the key changes the client's peer ID. The client has to be
+   restarted to use the new key and, if the client is a bootstrap node, peers
+   referring to it by its old multiaddress have to be updated.`
+
+const renewNetworkKeyDescription = `Issues a new certificate for the network key stored
+   in the network key file, extending its validity. The network key and the
+   client's peer ID do not change. The client has to be restarted to present
+   the new certificate.`
+
+func init() {
+	keyFileFlags := []cli.Flag{
+		&cli.StringFlag{
+			Name:  keyFileFlag,
+			Usage: "path to the network key file",
+		},
+		&cli.DurationFlag{
+			Name:  validityFlag,
+			Usage: "period for which the certificate is valid",
+			Value: key.DefaultCertificateValidity,
+		},
+	}
+
+	NetworkKeyCommand = cli.Command{
+		Name:        "network-key",
+		Usage:       `Manages the client's network key`,
+		Description: networkKeyDescription,
+		Subcommands: []cli.Command{
+			{
+				Name:        "generate",
+				Usage:       `Generates a new network key`,
+				Description: generateNetworkKeyDescription,
+				Action:      generateNetworkKey,
+				Flags:       keyFileFlags,
+			},
+			{
+				Name:        "rotate",
+				Usage:       `Replaces the network key with a new one`,
+				Description: rotateNetworkKeyDescription,
+				Action:      rotateNetworkKey,
+				Flags:       keyFileFlags,
+			},
+			{
+				Name:        "renew",
+				Usage:       `Renews the certificate of the network key`,
+				Description: renewNetworkKeyDescription,
+				Action:      renewNetworkKey,
+				Flags:       keyFileFlags,
+			},
+		},
+	}
+}
+
+func generateNetworkKey(c *cli.Context) error {
+	config, keyFile, err := readNetworkKeyConfig(c)
+	if err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(keyFile); err == nil {
+		return fmt.Errorf(
+			"network key file [%v] already exists; use rotate to replace it",
+			keyFile,
+		)
+	}
+
+	operatorSigner, err := newOperatorSigner(config)
+	if err != nil {
+		return err
+	}
+
+	networkPrivateKey, _, err := key.GenerateStaticNetworkKey()
+	if err != nil {
+		return fmt.Errorf("could not generate network key: [%v]", err)
+	}
+
+	certificate, err := issueNetworkKeyCertificate(
+		operatorSigner,
+		networkPrivateKey,
+		c.Duration(validityFlag),
+		nil,
+		keyFile,
+	)
+	if err != nil {
+		return err
+	}
+
+	peerID, err := peer.IDFromPrivateKey(networkPrivateKey)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf(
+		"generated network key with peer ID [%v] for operator [%v]; "+
+			"the certificate expires at [%v]\n",
+		peerID,
+		operatorSigner.Address().Hex(),
+		certificate.ExpiresAt,
+	)
+
+	return nil
+}
+
+func rotateNetworkKey(c *cli.Context) error {
+	config, keyFile, err := readNetworkKeyConfig(c)
+	if err != nil {
+		return err
+	}
+
+	oldPrivateKey, oldCertificate, operatorSigner, err := readOperatorNetworkKey(
+		config,
+		keyFile,
+	)
+	if err != nil {
+		return err
+	}
+
+	oldPeerID, err := peer.IDFromPrivateKey(oldPrivateKey)
+	if err != nil {
+		return err
+	}
+
+	newPrivateKey, _, err := key.GenerateStaticNetworkKey()
+	if err != nil {
+		return fmt.Errorf("could not generate network key: [%v]", err)
+	}
+
+	if _, err := issueNetworkKeyCertificate(
+		operatorSigner,
+		newPrivateKey,
+		c.Duration(validityFlag),
+		oldCertificate,
+		keyFile,
+	); err != nil {
+		return err
+	}
+
+	newPeerID, err := peer.IDFromPrivateKey(newPrivateKey)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf(
+		"rotated network key for operator [%v]; peer ID changed from [%v] "+
+			"to [%v]; restart the client to use the new key\n",
+		operatorSigner.Address().Hex(),
+		oldPeerID,
+		newPeerID,
+	)
+
+	return nil
+}
+
+func renewNetworkKey(c *cli.Context) error {
+	config, keyFile, err := readNetworkKeyConfig(c)
+	if err != nil {
+		return err
+	}
+
+	privateKey, oldCertificate, operatorSigner, err := readOperatorNetworkKey(
+		config,
+		keyFile,
+	)
+	if err != nil {
+		return err
+	}
+
+	certificate, err := issueNetworkKeyCertificate(
+		operatorSigner,
+		privateKey,
+		c.Duration(validityFlag),
+		oldCertificate,
+		keyFile,
+	)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf(
+		"renewed network key certificate for operator [%v]; the certificate "+
+			"expires at [%v]; restart the client to use the new certificate\n",
+		operatorSigner.Address().Hex(),
+		certificate.ExpiresAt,
+	)
+
+	return nil
+}
+
+// readOperatorNetworkKey reads the network key file and creates the signer of
+// the operator the network key belongs to.
+func readOperatorNetworkKey(
+	config *config.Config,
+	keyFile string,
+) (*key.NetworkPrivate, *key.Certificate, ethereum.Signer, error) {
+	privateKey, certificate, err := key.ReadNetworkKeyFile(keyFile)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	operatorSigner, err := newOperatorSigner(config)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	if certificate.OperatorAddress() != operatorSigner.Address().Hex() {
+		return nil, nil, nil, fmt.Errorf(
+			"network key file [%v] belongs to operator [%v], not [%v]",
+			keyFile,
+			certificate.OperatorAddress(),
+			operatorSigner.Address().Hex(),
+		)
+	}
+
+	return privateKey, certificate, operatorSigner, nil
+}
+
+// issueNetworkKeyCertificate authorizes the network key with the operator key
+// for the given period and stores both in the network key file. If the
+// certificate replaces a previous one, the new certificate has to supersede
+// it, otherwise peers would keep accepting the previous certificate.
+func issueNetworkKeyCertificate(
+	operatorSigner ethereum.Signer,
+	networkPrivateKey *key.NetworkPrivate,
+	validity time.Duration,
+	previousCertificate *key.Certificate,
+	keyFile string,
+) (*key.Certificate, error) {
+	if validity <= 0 {
+		return nil, fmt.Errorf("invalid certificate validity [%v]", validity)
+	}
+
+	certificate, err := key.IssueCertificate(
+		operatorSigner.OperatorPublicKey(),
+		networkPrivateKey.GetPublic().(*key.NetworkPublic),
+		validity,
+		operatorSigner.Sign,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if previousCertificate != nil &&
+		!certificate.Supersedes(previousCertificate) {
+		return nil, fmt.Errorf(
+			"new certificate does not supersede the previous one; " +
+				"check the system clock",
+		)
+	}
+
+	if err := key.WriteNetworkKeyFile(
+		keyFile,
+		networkPrivateKey,
+		certificate,
+	); err != nil {
+		return nil, fmt.Errorf(
+			"could not write network key file [%v]: [%v]",
+			keyFile,
+			err,
+		)
+	}
+
+	return certificate, nil
+}
+
+func readNetworkKeyConfig(c *cli.Context) (*config.Config, string, error) {
+	config, err := config.ReadConfig(c.GlobalString("config"))
+	if err != nil {
+		return nil, "", fmt.Errorf("error reading config file: %v", err)
+	}
+
+	keyFile := c.String(keyFileFlag)
+	if keyFile == "" {
+		keyFile = config.LibP2P.KeyFile
+	}
+	if keyFile == "" {
+		return nil, "", fmt.Errorf(
+			"network key file path is not set; configure LibP2P.KeyFile " +
+				"or use the --key-file flag",
+		)
+	}
+
+	return config, keyFile, nil
+}
@@ -51,7 +51,10 @@ func relayRequest(c *cli.Context) error {
 		return fmt.Errorf("error reading config file: [%v]", err)
 	}
 
-	utility, err := ethereum.ConnectUtility(cfg.Ethereum)
+	utility, err := ethereum.ConnectUtility(
+		cfg.Ethereum,
+		cfg.Registry.StartBlock,
+	)
 	if err != nil {
 		return fmt.Errorf("error connecting to Ethereum node: [%v]", err)
 	}
@@ -91,7 +94,10 @@ func genesis(c *cli.Context) error {
 		return fmt.Errorf("error reading config file: [%v]", err)
 	}
 
-	utility, err := ethereum.ConnectUtility(cfg.Ethereum)
+	utility, err := ethereum.ConnectUtility(
+		cfg.Ethereum,
+		cfg.Registry.StartBlock,
+	)
 	if err != nil {
 		return fmt.Errorf("error connecting to Ethereum node: [%v]", err)
 	}
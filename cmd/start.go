@@ -5,24 +5,36 @@ import (
 	"fmt"
 	"math/big"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
 	"time"
 
 	"github.com/keep-network/keep-core/pkg/diagnostics"
+	"github.com/keep-network/keep-core/pkg/health"
 	"github.com/keep-network/keep-core/pkg/metrics"
 	"github.com/keep-network/keep-core/pkg/net"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ipfs/go-log"
 	"github.com/keep-network/keep-common/pkg/chain/ethereum/ethutil"
 	"github.com/keep-network/keep-common/pkg/persistence"
 	"github.com/keep-network/keep-core/config"
 	"github.com/keep-network/keep-core/pkg/beacon"
+	"github.com/keep-network/keep-core/pkg/beacon/relay"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/audit"
 	"github.com/keep-network/keep-core/pkg/chain"
 	"github.com/keep-network/keep-core/pkg/chain/ethereum"
+	"github.com/keep-network/keep-core/pkg/chain/watchdog"
 	"github.com/keep-network/keep-core/pkg/firewall"
 	"github.com/keep-network/keep-core/pkg/net/key"
 	"github.com/keep-network/keep-core/pkg/net/libp2p"
 	"github.com/keep-network/keep-core/pkg/net/retransmission"
 	"github.com/keep-network/keep-core/pkg/operator"
+	"github.com/keep-network/keep-core/pkg/tracing"
 	"github.com/urfave/cli"
 
 	pm2io "github.com/keymetrics/pm2-io-apm-go"
@@ -54,6 +66,14 @@ var defaultBalanceAlertThreshold = big.NewInt(500000000000000000) // 0.5 ether
 // check should be triggered.
 const defaultBalanceMonitoringTick = 10 * time.Minute
 
+// minimumStakeCheckPeriod determines how often the readiness check queries
+// the chain for the minimum stake of operators.
+const minimumStakeCheckPeriod = 1 * time.Minute
+
+// certificateRenewalPeriod determines how long before the expiry of the
+// network key certificate the client starts warning it should be renewed.
+const certificateRenewalPeriod = 30 * 24 * time.Hour
+
 func init() {
 	StartCommand =
 		cli.Command{
@@ -117,17 +137,15 @@ func Start(c *cli.Context) error {
 		config.LibP2P.Port = c.Int(portFlag)
 	}
 
-	ethereumKey, err := ethutil.DecryptKeyFile(
-		config.Ethereum.Account.KeyFile,
-		config.Ethereum.Account.KeyFilePassword,
-	)
+	operatorSigners, err := newOperatorSigners(config)
 	if err != nil {
-		return fmt.Errorf(
-			"failed to read key file [%s]: [%v]",
-			config.Ethereum.Account.KeyFile,
-			err,
-		)
+		return err
 	}
+	// The first operator is the operator of the Ethereum account or the
+	// external signer. Its address is used for the client-wide services and
+	// its certificate is presented to remote peers.
+	operatorSigner := operatorSigners[0]
+	operatorAddress := operatorSigner.Address().Hex()
 
 	err = startPM2()
 	if err != nil {
@@ -135,10 +153,21 @@ func Start(c *cli.Context) error {
 		err = nil
 	}
 
-	chainProvider, err := ethereum.Connect(config.Ethereum)
+	// The client context is cancelled once the client shuts down. Pending
+	// transactions of the operators are monitored until then.
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	chainProviders, err := ethereum.ConnectWithSigners(
+		ctx,
+		config.Ethereum,
+		config.Registry.StartBlock,
+		operatorSigners,
+	)
 	if err != nil {
 		return fmt.Errorf("error connecting to Ethereum node: [%v]", err)
 	}
+	chainProvider := chainProviders[0]
 
 	blockCounter, err := chainProvider.BlockCounter()
 	if err != nil {
@@ -149,32 +178,59 @@ func Start(c *cli.Context) error {
 	if err != nil {
 		return fmt.Errorf("error obtaining stake monitor handle [%v]", err)
 	}
-	if c.Int(waitForStakeFlag) != 0 {
-		err = waitForStake(stakeMonitor, ethereumKey.Address.Hex(), c.Int(waitForStakeFlag))
+	for _, signer := range operatorSigners {
+		address := signer.Address().Hex()
+
+		if c.Int(waitForStakeFlag) != 0 {
+			err = waitForStake(stakeMonitor, address, c.Int(waitForStakeFlag))
+			if err != nil {
+				return err
+			}
+		}
+		hasMinimumStake, err := stakeMonitor.HasMinimumStake(address)
 		if err != nil {
-			return err
+			return fmt.Errorf("could not check the stake [%v]", err)
+		}
+		if !hasMinimumStake {
+			return fmt.Errorf(
+				"no minimum KEEP stake or operator [%v] is not authorized "+
+					"to use it; please make sure the operator address in "+
+					"the configuration is correct and it has KEEP tokens "+
+					"delegated and the operator contract has been authorized "+
+					"to operate on the stake",
+				address,
+			)
 		}
 	}
-	hasMinimumStake, err := stakeMonitor.HasMinimumStake(
-		ethereumKey.Address.Hex(),
+
+	if err := initializeAudit(config); err != nil {
+		return err
+	}
+	defer closeAudit()
+
+	// The beacon stops handling chain events as soon as the shutdown context
+	// is cancelled on SIGTERM or SIGINT. The network and the remaining
+	// services keep working with the client context until in-flight protocol
+	// executions complete or the shutdown timeout expires.
+	shutdownCtx, cancelShutdownCtx := contextWithShutdownSignal()
+	defer cancelShutdownCtx()
+
+	networkPrivateKey, networkKeyCertificate, err := loadNetworkKey(
+		config,
+		operatorSigner.Address(),
 	)
 	if err != nil {
-		return fmt.Errorf("could not check the stake [%v]", err)
-	}
-	if !hasMinimumStake {
-		return fmt.Errorf(
-			"no minimum KEEP stake or operator is not authorized to use it; " +
-				"please make sure the operator address in the configuration " +
-				"is correct and it has KEEP tokens delegated and the operator " +
-				"contract has been authorized to operate on the stake",
-		)
+		return err
 	}
 
-	ctx := context.Background()
-
-	networkPrivateKey, _ := key.OperatorKeyToNetworkKey(
-		operator.EthereumKeyToOperatorKey(ethereumKey),
+	operatorCertificates, err := issueOperatorCertificates(
+		networkPrivateKey,
+		operatorSigners[1:],
 	)
+	if err != nil {
+		return err
+	}
+
 	netProvider, err := libp2p.Connect(
 		ctx,
 		config.LibP2P,
@@ -182,6 +238,10 @@ func Start(c *cli.Context) error {
 		libp2p.ProtocolBeacon,
 		firewall.MinimumStakePolicy(stakeMonitor),
 		retransmission.NewTicker(blockCounter.WatchBlocks(ctx)),
+		libp2p.WithCertificate(networkKeyCertificate),
+		libp2p.WithOperatorCertificates(operatorCertificates...),
+		libp2p.WithDataDir(config.Storage.DataDir),
+		libp2p.WithClientVersion(c.App.Version),
 	)
 	if err != nil {
 		return err
@@ -189,38 +249,550 @@ func Start(c *cli.Context) error {
 
 	nodeHeader(netProvider.ConnectionManager().AddrStrings(), config.LibP2P.Port)
 
-	handle, err := persistence.NewDiskHandle(config.Storage.DataDir)
-	if err != nil {
-		return fmt.Errorf("failed while creating a storage disk handler: [%v]", err)
+	if err := initializeTracing(config, netProvider.ID().String()); err != nil {
+		return err
 	}
-	persistence := persistence.NewEncryptedPersistence(
-		handle,
-		config.Ethereum.Account.KeyFilePassword,
+	defer tracing.Close()
+
+	operatorNetProviders, err := newOperatorNetProviders(
+		netProvider,
+		operatorCertificates,
 	)
+	if err != nil {
+		return err
+	}
+
+	beacons := make(map[string]*beacon.Beacon, len(operatorSigners))
+	operatorChainProviders := make(map[string]chain.Handle, len(operatorSigners))
+	for i, signer := range operatorSigners {
+		address := signer.Address().Hex()
+
+		persistence, err := newOperatorPersistence(config, i, address)
+		if err != nil {
+			return err
+		}
+
+		var nodeOptions []relay.NodeOption
+		if config.DKG.RecordTranscripts {
+			transcriptsDir := filepath.Join(
+				operatorDataDir(config, i, address),
+				"transcripts",
+			)
+			if err := os.MkdirAll(transcriptsDir, 0700); err != nil {
+				return fmt.Errorf(
+					"could not create transcripts directory [%v]: [%v]",
+					transcriptsDir,
+					err,
+				)
+			}
+			transcripts, err := newEncryptedPersistence(config, transcriptsDir)
+			if err != nil {
+				return err
+			}
+			logger.Warningf(
+				"recording DKG transcripts of operator [%v] in [%v]",
+				address,
+				transcriptsDir,
+			)
+			nodeOptions = append(
+				nodeOptions,
+				relay.WithDKGTranscripts(transcripts),
+			)
+		}
+
+		randomBeacon, err := beacon.Initialize(
+			shutdownCtx,
+			address,
+			chainProviders[i],
+			operatorNetProviders[i],
+			persistence,
+			nodeOptions...,
+		)
+		if err != nil {
+			return fmt.Errorf(
+				"error initializing beacon of operator [%v]: [%v]",
+				address,
+				err,
+			)
+		}
 
-	err = beacon.Initialize(
+		beacons[address] = randomBeacon
+		operatorChainProviders[address] = chainProviders[i]
+	}
+
+	chainWatchdog := initializeChainWatchdog(
 		ctx,
-		ethereumKey.Address.Hex(),
+		config,
 		chainProvider,
+		blockCounter,
+		beacons,
+	)
+
+	initializeMetrics(
+		ctx,
+		config,
 		netProvider,
-		persistence,
+		stakeMonitor,
+		operatorAddress,
+		beacons,
+		chainWatchdog,
 	)
-	if err != nil {
-		return fmt.Errorf("error initializing beacon: [%v]", err)
+	initializeDiagnostics(ctx, config, netProvider, chainProvider, beacons)
+	initializeHealth(
+		ctx,
+		config,
+		netProvider,
+		blockCounter,
+		stakeMonitor,
+		operatorChainProviders,
+		beacons,
+		chainWatchdog,
+	)
+	for i, signer := range operatorSigners {
+		initializeBalanceMonitoring(
+			ctx,
+			chainProviders[i],
+			config,
+			signer.Address().Hex(),
+		)
 	}
+	operatorContractUpgrades := initializeContractRegistryMonitoring(
+		chainProvider,
+		config,
+	)
 
-	initializeMetrics(ctx, config, netProvider, stakeMonitor, ethereumKey.Address.Hex())
-	initializeDiagnostics(ctx, config, netProvider)
-	initializeBalanceMonitoring(ctx, chainProvider, config, ethereumKey.Address.Hex())
+	var stopReason error
+	select {
+	case <-shutdownCtx.Done():
+		logger.Infof("shutting down the client")
+	case operatorContract := <-operatorContractUpgrades:
+		stopReason = fmt.Errorf(
+			"operator contract [%v] has been approved in the registry; "+
+				"restart the client to switch to it",
+			operatorContract,
+		)
+		logger.Warningf("stopping the client: [%v]", stopReason)
+		cancelShutdownCtx()
+	}
+
+	shutdownTimeout := time.Duration(config.Shutdown.Timeout) * time.Second
+	logger.Infof(
+		"waiting up to [%v] for in-flight protocol executions to complete; "+
+			"executions not completed by then are abandoned",
+		shutdownTimeout,
+	)
+	for address, randomBeacon := range beacons {
+		for _, execution := range randomBeacon.InFlightExecutions() {
+			logger.Infof(
+				"operator [%v] waits for [%v] to complete",
+				address,
+				execution,
+			)
+		}
+	}
+
+	timeoutCtx, cancelTimeoutCtx := context.WithTimeout(
+		context.Background(),
+		shutdownTimeout,
+	)
+	defer cancelTimeoutCtx()
+
+	shutdownErr := shutdownBeacons(timeoutCtx, beacons)
+	if shutdownErr != nil {
+		logger.Warningf(
+			"abandoned in-flight protocol executions; operators taking part "+
+				"in an abandoned DKG are going to be marked as inactive or "+
+				"disqualified by other group members: [%v]",
+			shutdownErr,
+		)
+	}
+
+	// The network provider closes its connections and saves its state once
+	// the client context is cancelled.
+	cancelCtx()
 
 	select {
-	case <-ctx.Done():
+	case <-libp2p.Closed(netProvider):
+	case <-timeoutCtx.Done():
+		logger.Warningf(
+			"network provider has not been closed before the shutdown timeout",
+		)
+	}
+
+	if shutdownErr != nil {
+		return fmt.Errorf(
+			"client did not shut down gracefully: [%v]",
+			shutdownErr,
+		)
+	}
+
+	logger.Infof("client shut down gracefully")
+
+	return stopReason
+}
+
+// contextWithShutdownSignal returns a context which is cancelled once the
+// process receives SIGTERM or SIGINT. Signals are handled only once so that
+// a second signal terminates the process immediately.
+func contextWithShutdownSignal() (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGTERM, syscall.SIGINT)
+
+	go func() {
+		defer signal.Stop(signals)
+
+		select {
+		case receivedSignal := <-signals:
+			logger.Infof("received [%v] signal", receivedSignal)
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	return ctx, cancel
+}
+
+// shutdownBeacons shuts down beacons of all operators concurrently. It
+// returns an error if any of them did not shut down gracefully.
+func shutdownBeacons(
+	ctx context.Context,
+	beacons map[string]*beacon.Beacon,
+) error {
+	var (
+		wg       sync.WaitGroup
+		mutex    sync.Mutex
+		failures []string
+	)
+
+	for address, randomBeacon := range beacons {
+		wg.Add(1)
+		go func(address string, randomBeacon *beacon.Beacon) {
+			defer wg.Done()
+
+			if err := randomBeacon.Shutdown(ctx); err != nil {
+				mutex.Lock()
+				failures = append(
+					failures,
+					fmt.Sprintf("operator [%v]: [%v]", address, err),
+				)
+				mutex.Unlock()
+			}
+		}(address, randomBeacon)
+	}
+
+	wg.Wait()
+
+	if len(failures) > 0 {
+		sort.Strings(failures)
+		return fmt.Errorf("%v", strings.Join(failures, "; "))
+	}
+
+	return nil
+}
+
+// newOperatorSigners creates signers of all operators run by the client. The
+// first one is the signer of the Ethereum account or the external signer. It
+// is followed by the signers of the additional operators from the
+// configuration, in the configured order.
+func newOperatorSigners(config *config.Config) ([]ethereum.Signer, error) {
+	operatorSigner, err := newOperatorSigner(config)
+	if err != nil {
+		return nil, err
+	}
+
+	signers := []ethereum.Signer{operatorSigner}
+	addresses := map[common.Address]bool{operatorSigner.Address(): true}
+
+	for _, operator := range config.Operators {
+		signer, err := newSigner(
+			operator.HSM,
+			operator.ExternalSigner,
+			operator.KeyFile,
+			config.Ethereum.Account.KeyFilePassword,
+		)
 		if err != nil {
-			return err
+			return nil, err
+		}
+
+		if addresses[signer.Address()] {
+			return nil, fmt.Errorf(
+				"operator [%v] is configured more than once",
+				signer.Address().Hex(),
+			)
+		}
+		addresses[signer.Address()] = true
+
+		signers = append(signers, signer)
+	}
+
+	if len(signers) > 1 {
+		logger.Infof("running [%v] operators", len(signers))
+	}
+
+	return signers, nil
+}
+
+// issueOperatorCertificates authorizes the network key to act on behalf of
+// each of the additional operators with a certificate issued on startup. The
+// certificates are presented to remote peers during the connection handshake.
+func issueOperatorCertificates(
+	networkPrivateKey *key.NetworkPrivate,
+	additionalOperatorSigners []ethereum.Signer,
+) ([]*key.Certificate, error) {
+	networkPublicKey := key.Libp2pKeyToNetworkKey(networkPrivateKey.GetPublic())
+
+	certificates := make([]*key.Certificate, len(additionalOperatorSigners))
+	for i, signer := range additionalOperatorSigners {
+		certificate, err := key.IssueCertificate(
+			signer.OperatorPublicKey(),
+			networkPublicKey,
+			key.DefaultCertificateValidity,
+			signer.Sign,
+		)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"could not authorize the network key for operator [%v]: [%v]",
+				signer.Address().Hex(),
+				err,
+			)
+		}
+
+		certificates[i] = certificate
+	}
+
+	return certificates, nil
+}
+
+// newOperatorNetProviders returns the network provider of each operator.
+// The first operator uses the network provider directly. Each additional
+// operator uses a view of the network provider attaching the operator's
+// certificate to the messages it sends.
+func newOperatorNetProviders(
+	netProvider net.Provider,
+	operatorCertificates []*key.Certificate,
+) ([]net.Provider, error) {
+	netProviders := []net.Provider{netProvider}
+	for _, certificate := range operatorCertificates {
+		operatorNetProvider, err := libp2p.OperatorProvider(
+			netProvider,
+			certificate,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		netProviders = append(netProviders, operatorNetProvider)
+	}
+
+	return netProviders, nil
+}
+
+// newOperatorPersistence creates the handle persisting groups of the operator
+// with the given index. The first operator keeps its data directly in the
+// storage directory. Each additional operator keeps its data in a separate
+// directory named after the operator address.
+func newOperatorPersistence(
+	config *config.Config,
+	index int,
+	address string,
+) (persistence.Handle, error) {
+	dataDir := operatorDataDir(config, index, address)
+	if index > 0 {
+		if err := os.MkdirAll(dataDir, 0700); err != nil {
+			return nil, fmt.Errorf(
+				"could not create storage directory [%v]: [%v]",
+				dataDir,
+				err,
+			)
+		}
+	}
+
+	return newEncryptedPersistence(config, dataDir)
+}
+
+// newEncryptedPersistence creates the handle persisting data in the given
+// existing directory encrypted with the password of the Ethereum account key
+// file.
+func newEncryptedPersistence(
+	config *config.Config,
+	dir string,
+) (persistence.Handle, error) {
+	handle, err := persistence.NewDiskHandle(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed while creating a storage disk handler: [%v]", err)
+	}
+
+	return persistence.NewEncryptedPersistence(
+		handle,
+		config.Ethereum.Account.KeyFilePassword,
+	), nil
+}
+
+// operatorDataDir returns the storage directory of the operator with the
+// given index. The first operator uses the storage DataDir itself.
+func operatorDataDir(config *config.Config, index int, address string) string {
+	if index == 0 {
+		return config.Storage.DataDir
+	}
+
+	return filepath.Join(config.Storage.DataDir, "operators", address)
+}
+
+// newOperatorSigner creates the signer for the operator key. If the external
+// signer or the HSM is configured, all signing is delegated to it. Otherwise,
+// the operator key is read from the Ethereum account key file.
+func newOperatorSigner(config *config.Config) (ethereum.Signer, error) {
+	return newSigner(
+		config.HSM,
+		config.ExternalSigner,
+		config.Ethereum.Account.KeyFile,
+		config.Ethereum.Account.KeyFilePassword,
+	)
+}
+
+// newSigner creates the signer of an operator whose key is held by the HSM,
+// if configured, by the external signer, if configured, or otherwise read
+// from the given key file.
+func newSigner(
+	hsm config.HSM,
+	externalSigner config.ExternalSigner,
+	keyFile string,
+	keyFilePassword string,
+) (ethereum.Signer, error) {
+	if hsm.IsConfigured() {
+		signer, err := ethereum.NewPKCS11Signer(
+			hsm.Module,
+			hsm.TokenLabel,
+			hsm.KeyLabel,
+			hsm.PIN,
+		)
+		if err != nil {
+			return nil, err
 		}
 
-		return fmt.Errorf("uh-oh, we went boom boom for no reason")
+		logger.Infof(
+			"using key [%v] of HSM token [%v] for operator [%v]",
+			hsm.KeyLabel,
+			hsm.TokenLabel,
+			signer.Address().Hex(),
+		)
+
+		return signer, nil
+	}
+
+	if externalSigner.IsConfigured() {
+		logger.Infof(
+			"using external signer [%v] for operator [%v]",
+			externalSigner.URL,
+			externalSigner.Address,
+		)
+
+		return ethereum.NewExternalSigner(
+			externalSigner.URL,
+			common.HexToAddress(externalSigner.Address),
+		)
 	}
+
+	ethereumKey, err := ethutil.DecryptKeyFile(keyFile, keyFilePassword)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to read key file [%s]: [%v]",
+			keyFile,
+			err,
+		)
+	}
+
+	return ethereum.NewKeySigner(ethereumKey), nil
+}
+
+// loadNetworkKey loads the network key used for the client's libp2p identity.
+// If the network key file is configured, the network key is read from it
+// along with the certificate authorizing the key to act on behalf of the
+// operator. Otherwise, the network key is derived from the operator key read
+// from the Ethereum account key file so the key file has to belong to the
+// operator account; there is no certificate in this case. The network key
+// file is required if the operator key is held by the external signer or the
+// HSM, so that the operator key never has to be stored on disk.
+func loadNetworkKey(
+	config *config.Config,
+	operatorAddress common.Address,
+) (*key.NetworkPrivate, *key.Certificate, error) {
+	if keyFile := config.LibP2P.KeyFile; keyFile != "" {
+		networkPrivateKey, certificate, err := key.ReadNetworkKeyFile(keyFile)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if certificate.OperatorAddress() != operatorAddress.Hex() {
+			return nil, nil, fmt.Errorf(
+				"network key [%s] is authorized by operator [%v], not [%v]",
+				keyFile,
+				certificate.OperatorAddress(),
+				operatorAddress.Hex(),
+			)
+		}
+
+		if certificate.IsExpired(time.Now()) {
+			return nil, nil, fmt.Errorf(
+				"certificate of network key [%s] expired at [%v]; use the "+
+					"network-key renew command to renew it",
+				keyFile,
+				certificate.ExpiresAt,
+			)
+		}
+		if certificate.IsExpired(time.Now().Add(certificateRenewalPeriod)) {
+			logger.Warningf(
+				"certificate of network key [%s] expires at [%v]; use the "+
+					"network-key renew command to renew it",
+				keyFile,
+				certificate.ExpiresAt,
+			)
+		}
+
+		return networkPrivateKey, certificate, nil
+	}
+
+	if config.ExternalSigner.IsConfigured() || config.HSM.IsConfigured() {
+		return nil, nil, fmt.Errorf(
+			"network key file is required when the operator key is held by " +
+				"the external signer or the HSM; use the network-key command " +
+				"to generate a network key and set it in LibP2P.KeyFile",
+		)
+	}
+
+	logger.Warningf(
+		"network key file is not configured; network key is derived from " +
+			"the operator key which exposes the operator key to other peers; " +
+			"use the network-key command to generate a separate network key",
+	)
+
+	ethereumKey, err := ethutil.DecryptKeyFile(
+		config.Ethereum.Account.KeyFile,
+		config.Ethereum.Account.KeyFilePassword,
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf(
+			"failed to read key file [%s] for the network key: [%v]",
+			config.Ethereum.Account.KeyFile,
+			err,
+		)
+	}
+
+	if ethereumKey.Address != operatorAddress {
+		return nil, nil, fmt.Errorf(
+			"key file [%s] does not belong to operator [%v]",
+			config.Ethereum.Account.KeyFile,
+			operatorAddress.Hex(),
+		)
+	}
+
+	networkPrivateKey, _ := key.OperatorKeyToNetworkKey(
+		operator.EthereumKeyToOperatorKey(ethereumKey),
+	)
+
+	return networkPrivateKey, nil, nil
 }
 
 func waitForStake(stakeMonitor chain.StakeMonitor, address string, timeout int) error {
@@ -246,6 +818,8 @@ func initializeMetrics(
 	netProvider net.Provider,
 	stakeMonitor chain.StakeMonitor,
 	ethereumAddress string,
+	beacons map[string]*beacon.Beacon,
+	chainWatchdog *watchdog.Watchdog,
 ) {
 	registry, isConfigured := metrics.Initialize(
 		config.Metrics.Port,
@@ -282,12 +856,31 @@ func initializeMetrics(
 		ethereumAddress,
 		time.Duration(config.Metrics.EthereumMetricsTick)*time.Second,
 	)
+
+	for address, randomBeacon := range beacons {
+		metrics.ObserveOperator(
+			ctx,
+			registry,
+			address,
+			randomBeacon,
+			time.Duration(config.Metrics.NetworkMetricsTick)*time.Second,
+		)
+	}
+
+	metrics.ObserveChainWatchdog(
+		ctx,
+		registry,
+		chainWatchdog,
+		time.Duration(config.Watchdog.ExpectedBlockTime)*time.Second,
+	)
 }
 
 func initializeDiagnostics(
 	ctx context.Context,
 	config *config.Config,
 	netProvider net.Provider,
+	chainProvider chain.Handle,
+	beacons map[string]*beacon.Beacon,
 ) {
 	registry, isConfigured := diagnostics.Initialize(
 		config.Diagnostics.Port,
@@ -304,6 +897,168 @@ func initializeDiagnostics(
 
 	diagnostics.RegisterConnectedPeersSource(registry, netProvider)
 	diagnostics.RegisterClientInfoSource(registry, netProvider)
+	diagnostics.RegisterPeerReputationSource(registry, netProvider)
+	diagnostics.RegisterPendingTransactionsSource(registry, chainProvider)
+	diagnostics.RegisterMaintenanceSource(registry, beacons)
+	diagnostics.RegisterLoggingSource(registry)
+}
+
+// initializeAudit opens the protocol audit log in the `audit` directory under
+// the storage directory.
+func initializeAudit(config *config.Config) error {
+	dir := filepath.Join(config.Storage.DataDir, "audit")
+
+	err := audit.Initialize(
+		dir,
+		int64(config.Audit.MaxFileSize)*1024*1024,
+		config.Audit.MaxFiles,
+	)
+	if err != nil {
+		return fmt.Errorf("could not initialize audit log: [%v]", err)
+	}
+
+	logger.Infof("writing protocol audit log to [%v]", dir)
+
+	return nil
+}
+
+func closeAudit() {
+	if err := audit.Close(); err != nil {
+		logger.Errorf("could not close audit log: [%v]", err)
+	}
+}
+
+// initializeTracing enables exporting spans of protocol executions if the
+// tracing endpoint is configured. The network identifier of the client
+// identifies it in the collector.
+func initializeTracing(config *config.Config, instance string) error {
+	if config.Tracing.Endpoint == "" {
+		return nil
+	}
+
+	err := tracing.Initialize(config.Tracing.Endpoint, "keep-client", instance)
+	if err != nil {
+		return fmt.Errorf("could not initialize tracing: [%v]", err)
+	}
+
+	logger.Infof("exporting traces to [%v]", config.Tracing.Endpoint)
+
+	return nil
+}
+
+// initializeHealth exposes the liveness and readiness endpoints on the
+// metrics and diagnostics ports. There are no liveness checks, so the client
+// is considered alive as long as it serves the endpoint; problems with the
+// Ethereum node, like missing blocks or failing event subscriptions, are not
+// fixed by restarting the client. Readiness checks detect the client is not
+// fully operational, e.g. it does not observe new blocks or it is not
+// connected to enough peers.
+func initializeHealth(
+	ctx context.Context,
+	config *config.Config,
+	netProvider net.Provider,
+	blockCounter chain.BlockCounter,
+	stakeMonitor chain.StakeMonitor,
+	chainProviders map[string]chain.Handle,
+	beacons map[string]*beacon.Beacon,
+	chainWatchdog *watchdog.Watchdog,
+) {
+	if config.Metrics.Port == 0 && config.Diagnostics.Port == 0 {
+		logger.Infof(
+			"health endpoints are not served; " +
+				"neither metrics nor diagnostics are configured",
+		)
+		return
+	}
+
+	operators := make([]string, 0, len(beacons))
+	for operator := range beacons {
+		operators = append(operators, operator)
+	}
+	sort.Strings(operators)
+
+	registry := health.NewRegistry()
+
+	registry.RegisterReadinessCheck(
+		"block_counter",
+		health.BlockCounterCheck(
+			ctx,
+			blockCounter,
+			time.Duration(config.Health.MaxBlockAge)*time.Second,
+		),
+	)
+	registry.RegisterReadinessCheck(
+		"event_subscriptions",
+		health.SubscriptionsCheck(chainProviders),
+	)
+	registry.RegisterReadinessCheck(
+		"chain_stall",
+		health.ChainStallCheck(chainWatchdog),
+	)
+	registry.RegisterReadinessCheck(
+		"minimum_stake",
+		health.Cached(
+			health.MinimumStakeCheck(stakeMonitor, operators),
+			minimumStakeCheckPeriod,
+		),
+	)
+	registry.RegisterReadinessCheck(
+		"connected_peers",
+		health.ConnectedPeersCheck(
+			netProvider,
+			config.Health.MinConnectedPeers,
+		),
+	)
+	registry.RegisterReadinessCheck(
+		"connected_bootstraps",
+		health.ConnectedBootstrapsCheck(netProvider, config.LibP2P.Peers),
+	)
+	registry.RegisterReadinessCheck(
+		"group_registry",
+		health.GroupRegistryCheck(beacons),
+	)
+
+	registry.EnableEndpoints()
+
+	logger.Infof("enabled health endpoints")
+}
+
+// initializeChainWatchdog starts watching new blocks of the block counter
+// shared by all operators. When the chain stalls, the watchdog logs in-flight
+// protocol executions of all operators put at risk and renews the
+// subscription to new blocks until the stall ends.
+func initializeChainWatchdog(
+	ctx context.Context,
+	config *config.Config,
+	chainProvider chain.Handle,
+	blockCounter chain.BlockCounter,
+	beacons map[string]*beacon.Beacon,
+) *watchdog.Watchdog {
+	atRisk := func() []string {
+		var executions []string
+		for address, randomBeacon := range beacons {
+			for _, execution := range randomBeacon.InFlightExecutions() {
+				executions = append(
+					executions,
+					fmt.Sprintf("operator [%v]: %v", address, execution),
+				)
+			}
+		}
+		sort.Strings(executions)
+
+		return executions
+	}
+
+	chainWatchdog := watchdog.New(
+		blockCounter,
+		time.Duration(config.Watchdog.ExpectedBlockTime)*time.Second,
+		config.Watchdog.StallBlocks,
+		chainProvider.ResubscribeBlocks,
+		atRisk,
+	)
+	chainWatchdog.Start(ctx)
+
+	return chainWatchdog
 }
 
 func initializeBalanceMonitoring(
@@ -337,3 +1092,63 @@ func initializeBalanceMonitoring(
 		alertThreshold,
 	)
 }
+
+// initializeContractRegistryMonitoring watches for operator contracts approved
+// in the KeepRegistry. With the warn upgrade policy, a warning is logged for
+// each newly approved operator contract. With the switch upgrade policy, the
+// address of the newly approved operator contract is sent to the returned
+// channel and the client is expected to stop so that it works with the new
+// contract once restarted. The returned channel never receives anything if
+// the registry address is not configured.
+func initializeContractRegistryMonitoring(
+	chainProvider chain.Handle,
+	cfg *config.Config,
+) <-chan string {
+	contractRegistry, err := chainProvider.ContractRegistry()
+	if err != nil {
+		logger.Infof("operator contract upgrades are not monitored: [%v]", err)
+		return nil
+	}
+
+	upgradePolicy := cfg.Registry.UpgradePolicy
+	_, pinned := cfg.Ethereum.ContractAddresses["KeepRandomBeaconOperator"]
+	if upgradePolicy == config.UpgradePolicySwitch && pinned {
+		logger.Warningf(
+			"operator contract address is set in the configuration and " +
+				"takes precedence over the registry; operator contract " +
+				"upgrades will only be reported",
+		)
+		upgradePolicy = config.UpgradePolicyWarn
+	}
+
+	upgrades := make(chan string, 1)
+
+	_, err = contractRegistry.OnOperatorContractApproved(
+		func(operatorContract string) {
+			logger.Warningf(
+				"new operator contract [%v] has been approved in the "+
+					"registry; client works with operator contract [%v]",
+				operatorContract,
+				contractRegistry.OperatorContract(),
+			)
+
+			if upgradePolicy == config.UpgradePolicySwitch {
+				select {
+				case upgrades <- operatorContract:
+				default:
+				}
+			}
+		},
+	)
+	if err != nil {
+		logger.Errorf("could not monitor operator contract upgrades: [%v]", err)
+		return nil
+	}
+
+	logger.Infof(
+		"monitoring operator contract upgrades with the [%v] policy",
+		upgradePolicy,
+	)
+
+	return upgrades
+}
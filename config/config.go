@@ -7,6 +7,7 @@ import (
 	"syscall"
 
 	"github.com/BurntSushi/toml"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/keep-network/keep-common/pkg/chain/ethereum"
 	"github.com/keep-network/keep-core/pkg/net/libp2p"
 	"golang.org/x/crypto/ssh/terminal"
@@ -17,13 +18,110 @@ import (
 // It's just the name of the environment variable.
 const passwordEnvVariable = "KEEP_ETHEREUM_PASSWORD"
 
+// #nosec G101 (look for hardcoded credentials)
+// This line doesn't contain any credentials.
+// It's just the name of the environment variable.
+const hsmPINEnvVariable = "KEEP_HSM_PIN"
+
 // Config is the top level config structure.
 type Config struct {
-	Ethereum    ethereum.Config
-	LibP2P      libp2p.Config
-	Storage     Storage
-	Metrics     Metrics
-	Diagnostics Diagnostics
+	Ethereum       ethereum.Config
+	ExternalSigner ExternalSigner
+	HSM            HSM
+	Operators      []Operator
+	Registry       Registry
+	LibP2P         libp2p.Config
+	Storage        Storage
+	Metrics        Metrics
+	Diagnostics    Diagnostics
+	Health         Health
+	Watchdog       Watchdog
+	Audit          Audit
+	DKG            DKG
+	Tracing        Tracing
+	Shutdown       Shutdown
+}
+
+// ExternalSigner stores configuration of an external, Clef-compatible signer
+// holding the operator key. When configured, chain transactions and messages
+// are signed by the external signer instead of the key from the Ethereum
+// account key file.
+type ExternalSigner struct {
+	// URL of the external signer JSON-RPC endpoint, e.g. a Clef IPC path or
+	// "http://127.0.0.1:8550".
+	URL string
+	// Address of the operator account managed by the external signer.
+	Address string
+}
+
+// IsConfigured returns true if the external signer has been configured.
+func (es ExternalSigner) IsConfigured() bool {
+	return es.URL != ""
+}
+
+// HSM stores configuration of a PKCS#11 token, e.g. a hardware security
+// module, holding the operator key. When configured, chain transactions and
+// messages are signed by the token and the private key never leaves it.
+type HSM struct {
+	// Module is the path to the PKCS#11 module of the token, e.g.
+	// "/usr/lib/softhsm/libsofthsm2.so".
+	Module string
+	// TokenLabel is the label of the token holding the operator key.
+	TokenLabel string
+	// KeyLabel is the label of the operator's secp256k1 key pair on the
+	// token. The operator address is derived from its public key.
+	KeyLabel string
+	// PIN of the token user. The KEEP_HSM_PIN environment variable, if set,
+	// takes precedence over this value.
+	PIN string
+}
+
+// IsConfigured returns true if the PKCS#11 token has been configured.
+func (h HSM) IsConfigured() bool {
+	return h.Module != ""
+}
+
+// Operator stores configuration of an additional operator run by the client
+// along with the operator of the Ethereum account or the external signer. All
+// operators share the Ethereum connection and the network key. Exactly one of
+// the key file, the external signer and the HSM has to be configured.
+type Operator struct {
+	// KeyFile is the path to the Ethereum account key file of the operator.
+	// It has to be encrypted with the same password as the key file of the
+	// Ethereum account.
+	KeyFile string
+	// ExternalSigner holding the operator key, if any.
+	ExternalSigner ExternalSigner
+	// HSM holding the operator key, if any. The KEEP_HSM_PIN environment
+	// variable applies only to the HSM of the Ethereum account operator, so
+	// the PIN has to be set here.
+	HSM HSM
+}
+
+const (
+	// UpgradePolicyWarn makes the client log a warning when a new operator
+	// contract gets approved in the KeepRegistry and keep working with the
+	// current one.
+	UpgradePolicyWarn = "warn"
+	// UpgradePolicySwitch makes the client stop when a new operator contract
+	// gets approved in the KeepRegistry so that, once restarted, it works
+	// with the new contract. The client does not restart itself; it relies
+	// on an external supervisor to be started again.
+	UpgradePolicySwitch = "switch"
+)
+
+// Registry stores configuration of contract address discovery through the
+// KeepRegistry contract. Discovery is enabled by configuring the KeepRegistry
+// address in Ethereum.ContractAddresses.
+type Registry struct {
+	// UpgradePolicy determines how the client reacts when a new operator
+	// contract gets approved in the KeepRegistry; either "warn" (default)
+	// or "switch".
+	UpgradePolicy string
+	// StartBlock is the block from which KeepRegistry events are looked up
+	// when resolving contract addresses, usually the block in which the
+	// registry was deployed. Defaults to the genesis block.
+	StartBlock uint64
 }
 
 // Storage stores meta-info about keeping data on disk
@@ -43,6 +141,99 @@ type Diagnostics struct {
 	Port int
 }
 
+const (
+	// DefaultMaxBlockAge is the default number of seconds without a new
+	// block after which the client is considered not alive.
+	DefaultMaxBlockAge = 300
+	// DefaultMinConnectedPeers is the default number of connected peers
+	// below which the client is considered not ready.
+	DefaultMinConnectedPeers = 1
+)
+
+// Health stores configuration of the liveness and readiness checks served
+// on the `/healthz` and `/readyz` endpoints of the metrics and diagnostics
+// ports.
+type Health struct {
+	// MaxBlockAge is the number of seconds without a new block after which
+	// the client is considered not ready.
+	MaxBlockAge int
+	// MinConnectedPeers is the number of connected peers below which the
+	// client is considered not ready.
+	MinConnectedPeers int
+}
+
+const (
+	// DefaultExpectedBlockTime is the default number of seconds expected
+	// between two consecutive blocks.
+	DefaultExpectedBlockTime = 15
+	// DefaultStallBlocks is the default number of expected block times
+	// without a new block after which the chain is considered stalled.
+	DefaultStallBlocks = 10
+)
+
+// Watchdog stores configuration of the detection of chain stalls, i.e.
+// periods in which the client does not observe new blocks.
+type Watchdog struct {
+	// ExpectedBlockTime is the number of seconds expected between two
+	// consecutive blocks.
+	ExpectedBlockTime int
+	// StallBlocks is the number of expected block times without a new block
+	// after which the chain is considered stalled.
+	StallBlocks int
+}
+
+const (
+	// DefaultAuditMaxFileSize is the default size in megabytes of the audit
+	// log file after which the file is rotated.
+	DefaultAuditMaxFileSize = 10
+	// DefaultAuditMaxFiles is the default number of audit log files kept,
+	// including the current one.
+	DefaultAuditMaxFiles = 5
+)
+
+// Audit stores configuration of the protocol audit log written to the
+// `audit` directory under Storage.DataDir.
+type Audit struct {
+	// MaxFileSize is the size in megabytes of the audit log file after which
+	// the file is rotated.
+	MaxFileSize int
+	// MaxFiles is the number of audit log files kept, including the current
+	// one. The oldest file is removed on rotation once the number is reached.
+	MaxFiles int
+}
+
+// DKG stores configuration of the distributed key generation.
+type DKG struct {
+	// RecordTranscripts enables recording transcripts of DKG executions in
+	// the `transcripts` directory under Storage.DataDir. Transcripts contain
+	// secret material, are encrypted with the Ethereum account key file
+	// password and are meant for debugging only.
+	RecordTranscripts bool
+}
+
+// Tracing stores configuration of the distributed tracing of protocol
+// executions.
+type Tracing struct {
+	// Endpoint is the URL of the collector accepting spans in the
+	// OpenTelemetry protocol over HTTP with JSON encoding, e.g.
+	// http://localhost:4318/v1/traces. Tracing is disabled if empty.
+	Endpoint string
+}
+
+// DefaultShutdownTimeout is the default number of seconds the client waits
+// for in-flight protocol executions to complete when shutting down. It lets
+// a DKG started just before the shutdown complete on mainnet unless the
+// publication of its result is delayed.
+const DefaultShutdownTimeout = 1200
+
+// Shutdown stores configuration of the graceful shutdown of the client.
+type Shutdown struct {
+	// Timeout is the number of seconds the client waits for in-flight DKG
+	// and relay entry signing executions to complete once it receives
+	// SIGTERM or SIGINT. Executions not completed by then are abandoned.
+	Timeout int
+}
+
 var (
 	// KeepOpts contains global application settings
 	KeepOpts Config
@@ -84,10 +275,143 @@ func ReadConfig(filePath string) (*Config, error) {
 		return nil, fmt.Errorf("missing value for port; see node section in config file or use --port flag")
 	}
 
+	if err := validateExternalSigner(config.ExternalSigner); err != nil {
+		return nil, err
+	}
+
+	if envPIN := os.Getenv(hsmPINEnvVariable); envPIN != "" {
+		config.HSM.PIN = envPIN
+	}
+
+	if config.HSM.IsConfigured() {
+		if config.ExternalSigner.IsConfigured() {
+			return nil, fmt.Errorf(
+				"external signer and HSM cannot be configured at the same time",
+			)
+		}
+
+		if err := validateHSM(config.HSM); err != nil {
+			return nil, err
+		}
+	}
+
+	for i, operator := range config.Operators {
+		configured := 0
+		for _, isConfigured := range []bool{
+			operator.KeyFile != "",
+			operator.ExternalSigner.IsConfigured(),
+			operator.HSM.IsConfigured(),
+		} {
+			if isConfigured {
+				configured++
+			}
+		}
+		if configured != 1 {
+			return nil, fmt.Errorf(
+				"operator [%v] must have exactly one of key file, external "+
+					"signer and HSM configured",
+				i,
+			)
+		}
+
+		if err := validateExternalSigner(operator.ExternalSigner); err != nil {
+			return nil, fmt.Errorf("invalid operator [%v]: [%v]", i, err)
+		}
+
+		if operator.HSM.IsConfigured() {
+			if err := validateHSM(operator.HSM); err != nil {
+				return nil, fmt.Errorf("invalid operator [%v]: [%v]", i, err)
+			}
+		}
+	}
+
+	switch config.Registry.UpgradePolicy {
+	case "":
+		config.Registry.UpgradePolicy = UpgradePolicyWarn
+	case UpgradePolicyWarn, UpgradePolicySwitch:
+	default:
+		return nil, fmt.Errorf(
+			"invalid registry upgrade policy [%v]; expected [%v] or [%v]",
+			config.Registry.UpgradePolicy,
+			UpgradePolicyWarn,
+			UpgradePolicySwitch,
+		)
+	}
+
 	if config.Storage.DataDir == "" {
 		return nil, fmt.Errorf("missing value for storage directory data")
 	}
 
+	switch {
+	case config.Health.MaxBlockAge == 0:
+		config.Health.MaxBlockAge = DefaultMaxBlockAge
+	case config.Health.MaxBlockAge < 0:
+		return nil, fmt.Errorf(
+			"invalid maximum block age [%v]; must not be negative",
+			config.Health.MaxBlockAge,
+		)
+	}
+
+	switch {
+	case config.Health.MinConnectedPeers == 0:
+		config.Health.MinConnectedPeers = DefaultMinConnectedPeers
+	case config.Health.MinConnectedPeers < 0:
+		return nil, fmt.Errorf(
+			"invalid minimum connected peers [%v]; must not be negative",
+			config.Health.MinConnectedPeers,
+		)
+	}
+
+	switch {
+	case config.Watchdog.ExpectedBlockTime == 0:
+		config.Watchdog.ExpectedBlockTime = DefaultExpectedBlockTime
+	case config.Watchdog.ExpectedBlockTime < 0:
+		return nil, fmt.Errorf(
+			"invalid expected block time [%v]; must not be negative",
+			config.Watchdog.ExpectedBlockTime,
+		)
+	}
+
+	switch {
+	case config.Watchdog.StallBlocks == 0:
+		config.Watchdog.StallBlocks = DefaultStallBlocks
+	case config.Watchdog.StallBlocks < 0:
+		return nil, fmt.Errorf(
+			"invalid number of stall blocks [%v]; must not be negative",
+			config.Watchdog.StallBlocks,
+		)
+	}
+
+	switch {
+	case config.Audit.MaxFileSize == 0:
+		config.Audit.MaxFileSize = DefaultAuditMaxFileSize
+	case config.Audit.MaxFileSize < 0:
+		return nil, fmt.Errorf(
+			"invalid maximum audit log file size [%v]; must not be negative",
+			config.Audit.MaxFileSize,
+		)
+	}
+
+	switch {
+	case config.Audit.MaxFiles == 0:
+		config.Audit.MaxFiles = DefaultAuditMaxFiles
+	case config.Audit.MaxFiles < 0:
+		return nil, fmt.Errorf(
+			"invalid maximum number of audit log files [%v]; must not be negative",
+			config.Audit.MaxFiles,
+		)
+	}
+
+	switch {
+	case config.Shutdown.Timeout == 0:
+		config.Shutdown.Timeout = DefaultShutdownTimeout
+	case config.Shutdown.Timeout < 0:
+		return nil, fmt.Errorf(
+			"invalid shutdown timeout [%v]; must not be negative",
+			config.Shutdown.Timeout,
+		)
+	}
+
 	return config, nil
 }
 
@@ -108,6 +432,37 @@ func ReadEthereumConfig(filePath string) (ethereum.Config, error) {
 	return config.Ethereum, nil
 }
 
+// validateExternalSigner checks the operator address of the external signer,
+// if the external signer is configured.
+func validateExternalSigner(externalSigner ExternalSigner) error {
+	if externalSigner.IsConfigured() &&
+		!common.IsHexAddress(externalSigner.Address) {
+		return fmt.Errorf(
+			"missing or invalid operator address for the external signer",
+		)
+	}
+
+	return nil
+}
+
+// validateHSM checks the token and key labels and the PIN of the configured
+// HSM are set.
+func validateHSM(hsm HSM) error {
+	if hsm.TokenLabel == "" || hsm.KeyLabel == "" {
+		return fmt.Errorf("missing token or key label for the HSM")
+	}
+
+	if hsm.PIN == "" {
+		return fmt.Errorf(
+			"missing HSM PIN; set in the config file or set environment "+
+				"variable %v to the PIN",
+			hsmPINEnvVariable,
+		)
+	}
+
+	return nil
+}
+
 // ReadPassword prompts a user to enter a password.   The read password uses
 // the system password reading call that helps to prevent key loggers from
 // capturing the password.
@@ -41,10 +41,66 @@ func TestReadConfig(t *testing.T) {
 				"KeepRandomBeaconOperator": "0xcf64c2a367341170cb4e09cf8c0ed137d8473ceb",
 			},
 		},
+		"Operators": {
+			readValueFunc: func(c *Config) interface{} { return c.Operators },
+			expectedValue: []Operator{
+				{KeyFile: "/tmp/UTC--2020-06-02T10-14-51.380447000Z--0f3c24e7d8ed4c7e0d2c4ac4f4a1f2b0e7d26a41"},
+				{
+					ExternalSigner: ExternalSigner{
+						URL:     "http://127.0.0.1:8550",
+						Address: "0x4b8b9f6c1e1a5b0c6a3a2f9e8d7c6b5a49382716",
+					},
+				},
+			},
+		},
+		"Registry.UpgradePolicy": {
+			readValueFunc: func(c *Config) interface{} { return c.Registry.UpgradePolicy },
+			expectedValue: UpgradePolicyWarn,
+		},
+		"Registry.StartBlock": {
+			readValueFunc: func(c *Config) interface{} { return c.Registry.StartBlock },
+			expectedValue: uint64(8500000),
+		},
 		"Storage.DataDir": {
 			readValueFunc: func(c *Config) interface{} { return c.Storage.DataDir },
 			expectedValue: "/my/secure/location",
 		},
+		"Health.MaxBlockAge": {
+			readValueFunc: func(c *Config) interface{} { return c.Health.MaxBlockAge },
+			expectedValue: DefaultMaxBlockAge,
+		},
+		"Health.MinConnectedPeers": {
+			readValueFunc: func(c *Config) interface{} { return c.Health.MinConnectedPeers },
+			expectedValue: DefaultMinConnectedPeers,
+		},
+		"Watchdog.ExpectedBlockTime": {
+			readValueFunc: func(c *Config) interface{} { return c.Watchdog.ExpectedBlockTime },
+			expectedValue: DefaultExpectedBlockTime,
+		},
+		"Watchdog.StallBlocks": {
+			readValueFunc: func(c *Config) interface{} { return c.Watchdog.StallBlocks },
+			expectedValue: DefaultStallBlocks,
+		},
+		"Audit.MaxFileSize": {
+			readValueFunc: func(c *Config) interface{} { return c.Audit.MaxFileSize },
+			expectedValue: DefaultAuditMaxFileSize,
+		},
+		"Audit.MaxFiles": {
+			readValueFunc: func(c *Config) interface{} { return c.Audit.MaxFiles },
+			expectedValue: DefaultAuditMaxFiles,
+		},
+		"DKG.RecordTranscripts": {
+			readValueFunc: func(c *Config) interface{} { return c.DKG.RecordTranscripts },
+			expectedValue: false,
+		},
+		"Tracing.Endpoint": {
+			readValueFunc: func(c *Config) interface{} { return c.Tracing.Endpoint },
+			expectedValue: "",
+		},
+		"Shutdown.Timeout": {
+			readValueFunc: func(c *Config) interface{} { return c.Shutdown.Timeout },
+			expectedValue: DefaultShutdownTimeout,
+		},
 		"Ethereum.MaxGasPrice": {
 			readValueFunc: func(c *Config) interface{} { return c.Ethereum.MaxGasPrice.Int },
 			expectedValue: big.NewInt(140000000000),
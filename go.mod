@@ -18,11 +18,13 @@ require (
 	github.com/google/gofuzz v1.1.0
 	github.com/ipfs/go-datastore v0.4.4
 	github.com/ipfs/go-log v1.0.4
+	github.com/ipfs/go-log/v2 v2.1.1
 	github.com/keep-network/go-libp2p-bootstrap v0.0.0-20200423153828-ed815bc50aec
 	github.com/keep-network/keep-common v1.3.0
 	github.com/keymetrics/pm2-io-apm-go v0.0.1
 	github.com/libp2p/go-addr-util v0.0.2
 	github.com/libp2p/go-libp2p v0.10.3
+	github.com/libp2p/go-libp2p-circuit v0.3.1
 	github.com/libp2p/go-libp2p-connmgr v0.2.4
 	github.com/libp2p/go-libp2p-core v0.6.1
 	github.com/libp2p/go-libp2p-kad-dht v0.8.3
@@ -31,9 +33,12 @@ require (
 	github.com/libp2p/go-libp2p-routing v0.1.0 // indirect
 	github.com/libp2p/go-libp2p-secio v0.2.2
 	github.com/mattn/go-isatty v0.0.7 // indirect
+	github.com/miekg/pkcs11 v1.0.3
 	github.com/multiformats/go-multiaddr v0.2.2
 	github.com/pborman/uuid v1.2.0
 	github.com/urfave/cli v1.22.1
 	go.opencensus.io/exporter/zipkin v0.0.0-00010101000000-000000000000 // indirect
+	go.uber.org/zap v1.15.0
 	golang.org/x/crypto v0.0.0-20200423211502-4bdfaf469ed5
+	golang.org/x/tools v0.0.0-20191216052735-49a3e744a425
 )
@@ -8,8 +8,8 @@ import (
 	"time"
 
 	"github.com/ipfs/go-log"
-	"github.com/keep-network/keep-common/pkg/logging"
 	"github.com/keep-network/keep-core/cmd"
+	"github.com/keep-network/keep-core/pkg/logging"
 	"github.com/urfave/cli"
 )
 
@@ -32,7 +32,7 @@ func main() {
 		revision = "unknown"
 	}
 
-	err := logging.Configure(os.Getenv("LOG_LEVEL"))
+	err := logging.Configure(os.Getenv("LOG_LEVEL"), os.Getenv("LOG_FORMAT"))
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "failed to configure logging: [%v]\n", err)
 	}
@@ -62,6 +62,10 @@ func main() {
 		cmd.RelayCommand,
 		cmd.PingCommand,
 		cmd.EthereumCommand,
+		cmd.NetworkKeyCommand,
+		cmd.MaintenanceCommand,
+		cmd.DKGCommand,
+		cmd.LoggingCommand,
 	}
 
 	cli.AppHelpTemplate = fmt.Sprintf(`%s
@@ -69,6 +73,8 @@ ENVIRONMENT VARIABLES:
    KEEP_ETHEREUM_PASSWORD    keep client password
    LOG_LEVEL                 space-delimited set of log level directives; set to
                              "help" for help
+   LOG_FORMAT                log output format: "color" (default), "text" or
+                             "json"
 
 `, cli.AppHelpTemplate)
 
@@ -3,6 +3,7 @@ package beacon
 import (
 	"context"
 	"encoding/hex"
+	"fmt"
 	"sync"
 	"time"
 
@@ -16,37 +17,104 @@ import (
 	"github.com/keep-network/keep-core/pkg/beacon/relay/registry"
 	"github.com/keep-network/keep-core/pkg/chain"
 	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/tracing"
 )
 
 var logger = log.Logger("keep-beacon")
 
+// Beacon is the random beacon client initialized with Initialize.
+type Beacon struct {
+	node          *relay.Node
+	groupRegistry *registry.Groups
+	relayChain    relaychain.Interface
+	blockCounter  chain.BlockCounter
+
+	pendingGroupSelections *event.GroupSelectionTrack
+	maintenance            *maintenance
+
+	// unsubscribed is closed once the beacon stopped handling chain events.
+	unsubscribed chan struct{}
+	// registryUpdates tracks in-flight updates of the persisted group
+	// registry.
+	registryUpdates sync.WaitGroup
+}
+
+// Shutdown lets in-flight DKG and relay entry signing executions complete
+// and waits for in-flight group registry updates to be persisted. Executions
+// which do not complete before the context is done are abandoned and an error
+// is returned. Shutdown should be called once the context the beacon has been
+// initialized with is done so that no new executions are started.
+func (b *Beacon) Shutdown(ctx context.Context) error {
+	err := b.node.Shutdown(ctx)
+
+	select {
+	case <-b.unsubscribed:
+		b.registryUpdates.Wait()
+	case <-ctx.Done():
+	}
+
+	return err
+}
+
+// GroupCount returns the number of registered groups the beacon is a member
+// of.
+func (b *Beacon) GroupCount() int {
+	return b.node.GroupCount()
+}
+
+// GroupSelectionsCount returns the number of group selections in which the
+// beacon submits tickets or waits for the selection result.
+func (b *Beacon) GroupSelectionsCount() int {
+	return b.pendingGroupSelections.Count()
+}
+
+// GroupLoadErrors returns the errors of loading group memberships from the
+// persistent storage when the beacon has been initialized.
+func (b *Beacon) GroupLoadErrors() []error {
+	return b.groupRegistry.LoadErrors()
+}
+
+// InFlightProtocols returns the number of DKG and relay entry signing
+// executions in progress.
+func (b *Beacon) InFlightProtocols() int {
+	return b.node.InFlightProtocols()
+}
+
+// InFlightExecutions returns descriptions of DKG and relay entry signing
+// executions in progress.
+func (b *Beacon) InFlightExecutions() []string {
+	return b.node.InFlightExecutions()
+}
+
 // Initialize kicks off the random beacon by initializing internal state,
 // ensuring preconditions like staking are met, and then kicking off the
 // internal random beacon implementation. Returns an error if this failed,
-// otherwise enters a blocked loop.
+// otherwise the beacon handles chain events until the context is done. The
+// node options are applied to the relay node of the beacon.
 func Initialize(
 	ctx context.Context,
 	stakingID string,
 	chainHandle chain.Handle,
 	netProvider net.Provider,
 	persistence persistence.Handle,
-) error {
+	nodeOptions ...relay.NodeOption,
+) (*Beacon, error) {
 	relayChain := chainHandle.ThresholdRelay()
 	chainConfig := relayChain.GetConfig()
 
 	stakeMonitor, err := chainHandle.StakeMonitor()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	staker, err := stakeMonitor.StakerFor(stakingID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	blockCounter, err := chainHandle.BlockCounter()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	signing := chainHandle.Signing()
@@ -60,6 +128,7 @@ func Initialize(
 		blockCounter,
 		chainConfig,
 		groupRegistry,
+		nodeOptions...,
 	)
 
 	pendingGroupSelections := &event.GroupSelectionTrack{
@@ -67,6 +136,16 @@ func Initialize(
 		Mutex: &sync.Mutex{},
 	}
 
+	beacon := &Beacon{
+		node:                   &node,
+		groupRegistry:          groupRegistry,
+		relayChain:             relayChain,
+		blockCounter:           blockCounter,
+		pendingGroupSelections: pendingGroupSelections,
+		maintenance:            &maintenance{},
+		unsubscribed:           make(chan struct{}),
+	}
+
 	pendingRelayRequests := &event.RelayRequestTrack{
 		Data:  make(map[string]bool),
 		Mutex: &sync.Mutex{},
@@ -74,7 +153,7 @@ func Initialize(
 
 	node.ResumeSigningIfEligible(relayChain, signing)
 
-	_ = relayChain.OnRelayEntryRequested(func(request *event.Request) {
+	relayEntryRequestedSubscription := relayChain.OnRelayEntryRequested(func(request *event.Request) {
 		onConfirmed := func() {
 			if node.IsInGroup(request.GroupPublicKey) {
 				go func() {
@@ -130,7 +209,7 @@ func Initialize(
 		)
 	})
 
-	_ = relayChain.OnGroupSelectionStarted(func(event *event.GroupSelectionStart) {
+	groupSelectionStartedSubscription := relayChain.OnGroupSelectionStarted(func(event *event.GroupSelectionStart) {
 		onGroupSelected := func(group *groupselection.Result) {
 			for index, staker := range group.SelectedStakers {
 				logger.Infof(
@@ -165,6 +244,21 @@ func Initialize(
 				event.BlockNumber,
 			)
 
+			_, span := tracing.Start(
+				tracing.WithTraceID(
+					ctx,
+					tracing.NewTraceID(
+						tracing.GroupCreation,
+						event.NewEntry.Bytes(),
+					),
+				),
+				"group-selection",
+				map[string]interface{}{
+					"operator":    fmt.Sprintf("0x%x", staker.Address()),
+					"start_block": event.BlockNumber,
+				},
+			)
+
 			err := groupselection.CandidateToNewGroup(
 				relayChain,
 				blockCounter,
@@ -172,24 +266,44 @@ func Initialize(
 				staker,
 				event.NewEntry,
 				event.BlockNumber,
+				beacon.maintenance.isEnabled,
 				onGroupSelected,
 			)
+			span.End(err)
 			if err != nil {
 				logger.Errorf("Tickets submission failed: [%v]", err)
 			}
 		}()
 	})
 
-	_ = relayChain.OnGroupRegistered(func(registration *event.GroupRegistration) {
-		logger.Infof(
-			"new group with public key [0x%x] registered on-chain at block [%v]",
-			registration.GroupPublicKey,
-			registration.BlockNumber,
-		)
-		go groupRegistry.UnregisterStaleGroups(registration.GroupPublicKey)
-	})
+	groupRegisteredSubscription := relayChain.OnGroupRegistered(
+		func(registration *event.GroupRegistration) {
+			logger.Infof(
+				"new group with public key [0x%x] registered on-chain at block [%v]",
+				registration.GroupPublicKey,
+				registration.BlockNumber,
+			)
+
+			beacon.registryUpdates.Add(1)
+			go func() {
+				defer beacon.registryUpdates.Done()
+				groupRegistry.UnregisterStaleGroups(registration.GroupPublicKey)
+			}()
+		},
+	)
+
+	go func() {
+		<-ctx.Done()
+
+		relayEntryRequestedSubscription.Unsubscribe()
+		groupSelectionStartedSubscription.Unsubscribe()
+		groupRegisteredSubscription.Unsubscribe()
+		close(beacon.unsubscribed)
+
+		logger.Infof("stopped handling chain events")
+	}()
 
-	return nil
+	return beacon, nil
 }
 
 // Before we start relay entry signing process we need to confirm the current
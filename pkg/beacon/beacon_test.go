@@ -228,6 +228,10 @@ func (mrc *mockRelayChain) SubmitRelayEntry(
 	panic("not implemented")
 }
 
+func (mrc *mockRelayChain) SimulateRelayEntry(entry []byte) error {
+	panic("not implemented")
+}
+
 func (mrc *mockRelayChain) OnRelayEntrySubmitted(
 	func(entry *event.EntrySubmitted),
 ) subscription.EventSubscription {
@@ -0,0 +1,219 @@
+package beacon
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+)
+
+// maintenance holds the maintenance mode switch of the beacon. In the
+// maintenance mode, the beacon does not submit tickets to candidate to new
+// groups so that, once the protocol executions in progress complete, the
+// client can be restarted without losing group seats or signing rewards.
+type maintenance struct {
+	mutex   sync.RWMutex
+	enabled bool
+}
+
+func (m *maintenance) setEnabled(enabled bool) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.enabled = enabled
+}
+
+func (m *maintenance) isEnabled() bool {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	return m.enabled
+}
+
+// MaintenanceStatus describes the progress of draining the beacon before
+// a restart.
+type MaintenanceStatus struct {
+	// Enabled is true if the beacon is in the maintenance mode.
+	Enabled bool `json:"enabled"`
+	// CurrentBlock is the block at which the status has been determined.
+	CurrentBlock uint64 `json:"current_block"`
+	// GroupSelections is the number of group selections in which the beacon
+	// submits tickets or waits for the selection result.
+	GroupSelections int `json:"group_selections"`
+	// Protocols is the number of DKG and relay entry signing executions in
+	// progress.
+	Protocols int `json:"protocols"`
+	// Groups is the number of registered groups the beacon is a member of.
+	Groups int `json:"groups"`
+	// SelectedForSigning is true if a group the beacon is a member of has
+	// been selected to sign the relay entry currently in progress.
+	SelectedForSigning bool `json:"selected_for_signing"`
+	// NextSigningSelectionBlock is the earliest block at which a group the
+	// beacon is a member of may be selected to sign a relay entry. It is
+	// the start block of the current relay request if such a group has been
+	// already selected. It is zero if the beacon is not a member of any
+	// group which may be selected.
+	NextSigningSelectionBlock uint64 `json:"next_signing_selection_block"`
+	// RelayEntryTimeoutBlock is the last block at which the relay entry in
+	// progress can be submitted before it can be reported as timed out. It
+	// is zero if no relay entry is in progress.
+	RelayEntryTimeoutBlock uint64 `json:"relay_entry_timeout_block"`
+	// SafeToRestart is true if the beacon is in the maintenance mode and
+	// restarting the client does not interrupt any group selection or
+	// protocol execution.
+	SafeToRestart bool `json:"safe_to_restart"`
+}
+
+// EnableMaintenance puts the beacon into the maintenance mode. The beacon
+// stops submitting tickets to candidate to new groups but lets group
+// selections, DKG and relay entry signing executions in progress complete.
+func (b *Beacon) EnableMaintenance() {
+	b.maintenance.setEnabled(true)
+	logger.Infof("maintenance mode enabled; ticket submission paused")
+}
+
+// DisableMaintenance takes the beacon out of the maintenance mode and
+// resumes ticket submission for subsequent group selections.
+func (b *Beacon) DisableMaintenance() {
+	b.maintenance.setEnabled(false)
+	logger.Infof("maintenance mode disabled; ticket submission resumed")
+}
+
+// MaintenanceStatus determines whether the beacon is drained and can be
+// restarted safely.
+func (b *Beacon) MaintenanceStatus() (*MaintenanceStatus, error) {
+	currentBlock, err := b.blockCounter.CurrentBlock()
+	if err != nil {
+		return nil, fmt.Errorf("could not get the current block: [%v]", err)
+	}
+
+	status := &MaintenanceStatus{
+		Enabled:         b.maintenance.isEnabled(),
+		CurrentBlock:    currentBlock,
+		GroupSelections: b.GroupSelectionsCount(),
+		Protocols:       b.InFlightProtocols(),
+		Groups:          b.GroupCount(),
+	}
+
+	entryInProgress, err := b.relayChain.IsEntryInProgress()
+	if err != nil {
+		return nil, fmt.Errorf(
+			"could not check if an entry is in progress: [%v]",
+			err,
+		)
+	}
+
+	var request *relayRequest
+	if entryInProgress {
+		groupPublicKey, err := b.relayChain.CurrentRequestGroupPublicKey()
+		if err != nil {
+			return nil, fmt.Errorf(
+				"could not get the group of the current request: [%v]",
+				err,
+			)
+		}
+
+		startBlock, err := b.relayChain.CurrentRequestStartBlock()
+		if err != nil {
+			return nil, fmt.Errorf(
+				"could not get the start block of the current request: [%v]",
+				err,
+			)
+		}
+
+		request = &relayRequest{
+			startBlock:     startBlock.Uint64(),
+			groupPublicKey: groupPublicKey,
+		}
+
+		status.RelayEntryTimeoutBlock = request.startBlock +
+			b.relayChain.GetConfig().RelayEntryTimeout
+	}
+
+	status.SelectedForSigning, status.NextSigningSelectionBlock =
+		nextSigningSelection(
+			currentBlock,
+			b.selectableGroups(),
+			request,
+			b.relayChain.GetConfig().RelayEntryTimeout,
+		)
+
+	status.SafeToRestart = status.Enabled &&
+		status.GroupSelections == 0 &&
+		status.Protocols == 0 &&
+		!status.SelectedForSigning
+
+	return status, nil
+}
+
+// selectableGroups returns public keys of registered groups the beacon is
+// a member of which may be selected to sign a relay entry. Stale groups are
+// never selected. Groups whose staleness could not be checked are assumed
+// to be selectable.
+func (b *Beacon) selectableGroups() [][]byte {
+	selectable := make([][]byte, 0)
+	for _, groupPublicKey := range b.groupRegistry.GroupPublicKeys() {
+		isStale, err := b.relayChain.IsStaleGroup(groupPublicKey)
+		if err != nil {
+			logger.Warningf(
+				"could not check if group [%x] is stale: [%v]",
+				groupPublicKey,
+				err,
+			)
+		} else if isStale {
+			continue
+		}
+
+		selectable = append(selectable, groupPublicKey)
+	}
+
+	return selectable
+}
+
+// relayRequest describes the relay request in progress.
+type relayRequest struct {
+	startBlock     uint64
+	groupPublicKey []byte
+}
+
+// nextSigningSelection determines whether one of the given groups has been
+// selected to sign the relay entry of the request in progress and the
+// earliest block at which one of the groups may be selected to sign a relay
+// entry. The request is nil if no relay entry is in progress.
+//
+// The chain accepts a new relay request only once the entry in progress is
+// submitted or reported as timed out, which is possible after the relay
+// entry timeout passes. A group which timed out is terminated when the
+// timeout is reported and another group is selected right away to sign the
+// same request.
+//
+// If a group has been selected and has not timed out yet, the start block
+// of the request is returned. If none of the groups may be selected, zero
+// is returned.
+func nextSigningSelection(
+	currentBlock uint64,
+	groups [][]byte,
+	request *relayRequest,
+	relayEntryTimeout uint64,
+) (bool, uint64) {
+	if request != nil {
+		timedOut := currentBlock > request.startBlock+relayEntryTimeout
+
+		selectable := make([][]byte, 0, len(groups))
+		for _, group := range groups {
+			if bytes.Equal(group, request.groupPublicKey) {
+				if !timedOut {
+					return true, request.startBlock
+				}
+				continue
+			}
+			selectable = append(selectable, group)
+		}
+		groups = selectable
+	}
+
+	if len(groups) == 0 {
+		return false, 0
+	}
+
+	return false, currentBlock + 1
+}
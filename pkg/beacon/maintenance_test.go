@@ -0,0 +1,211 @@
+package beacon
+
+import (
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/keep-network/keep-core/pkg/beacon/relay"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/registry"
+	chainLocal "github.com/keep-network/keep-core/pkg/chain/local"
+	netLocal "github.com/keep-network/keep-core/pkg/net/local"
+)
+
+func TestMaintenanceStatus(t *testing.T) {
+	var tests = map[string]struct {
+		enabled               bool
+		groupSelections       []string
+		expectedSafeToRestart bool
+	}{
+		"maintenance disabled": {
+			enabled:               false,
+			expectedSafeToRestart: false,
+		},
+		"maintenance enabled": {
+			enabled:               true,
+			expectedSafeToRestart: true,
+		},
+		"maintenance enabled with group selection in progress": {
+			enabled:               true,
+			groupSelections:       []string{"ff01"},
+			expectedSafeToRestart: false,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			beacon := newTestBeacon(t)
+
+			if test.enabled {
+				beacon.EnableMaintenance()
+			}
+			for _, seed := range test.groupSelections {
+				beacon.pendingGroupSelections.Add(seed)
+			}
+
+			status, err := beacon.MaintenanceStatus()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if status.Enabled != test.enabled {
+				t.Errorf(
+					"unexpected maintenance mode\nexpected: [%v]\nactual:   [%v]",
+					test.enabled,
+					status.Enabled,
+				)
+			}
+			if status.GroupSelections != len(test.groupSelections) {
+				t.Errorf(
+					"unexpected number of group selections\n"+
+						"expected: [%v]\nactual:   [%v]",
+					len(test.groupSelections),
+					status.GroupSelections,
+				)
+			}
+			if status.SafeToRestart != test.expectedSafeToRestart {
+				t.Errorf(
+					"unexpected safe to restart\nexpected: [%v]\nactual:   [%v]",
+					test.expectedSafeToRestart,
+					status.SafeToRestart,
+				)
+			}
+		})
+	}
+}
+
+func TestNextSigningSelection(t *testing.T) {
+	ourGroup := []byte{0x01}
+	otherGroup := []byte{0x02}
+
+	var tests = map[string]struct {
+		groups                     [][]byte
+		request                    *relayRequest
+		expectedSelected           bool
+		expectedNextSelectionBlock uint64
+	}{
+		"no groups": {
+			groups:                     [][]byte{},
+			expectedSelected:           false,
+			expectedNextSelectionBlock: 0,
+		},
+		"no entry in progress": {
+			groups:                     [][]byte{ourGroup},
+			expectedSelected:           false,
+			expectedNextSelectionBlock: 101,
+		},
+		"our group signing": {
+			groups:                     [][]byte{ourGroup, otherGroup},
+			request:                    &relayRequest{95, ourGroup},
+			expectedSelected:           true,
+			expectedNextSelectionBlock: 95,
+		},
+		"other group signing": {
+			groups:                     [][]byte{ourGroup},
+			request:                    &relayRequest{90, otherGroup},
+			expectedSelected:           false,
+			expectedNextSelectionBlock: 101,
+		},
+		"our group timed out": {
+			groups:                     [][]byte{ourGroup},
+			request:                    &relayRequest{80, ourGroup},
+			expectedSelected:           false,
+			expectedNextSelectionBlock: 0,
+		},
+		"our group timed out with other groups remaining": {
+			groups:                     [][]byte{ourGroup, otherGroup},
+			request:                    &relayRequest{80, ourGroup},
+			expectedSelected:           false,
+			expectedNextSelectionBlock: 101,
+		},
+		"our group signing at the timeout block": {
+			groups:                     [][]byte{ourGroup},
+			request:                    &relayRequest{90, ourGroup},
+			expectedSelected:           true,
+			expectedNextSelectionBlock: 90,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			currentBlock := uint64(100)
+			relayEntryTimeout := uint64(10)
+
+			selected, nextSelectionBlock := nextSigningSelection(
+				currentBlock,
+				test.groups,
+				test.request,
+				relayEntryTimeout,
+			)
+
+			if selected != test.expectedSelected {
+				t.Errorf(
+					"unexpected selected for signing\n"+
+						"expected: [%v]\nactual:   [%v]",
+					test.expectedSelected,
+					selected,
+				)
+			}
+			if nextSelectionBlock != test.expectedNextSelectionBlock {
+				t.Errorf(
+					"unexpected next signing selection block\n"+
+						"expected: [%v]\nactual:   [%v]",
+					test.expectedNextSelectionBlock,
+					nextSelectionBlock,
+				)
+			}
+		})
+	}
+}
+
+func TestMaintenancePausesTicketSubmission(t *testing.T) {
+	beacon := newTestBeacon(t)
+
+	if beacon.maintenance.isEnabled() {
+		t.Fatal("expected ticket submission to not be paused")
+	}
+
+	beacon.EnableMaintenance()
+	if !beacon.maintenance.isEnabled() {
+		t.Fatal("expected ticket submission to be paused")
+	}
+
+	beacon.DisableMaintenance()
+	if beacon.maintenance.isEnabled() {
+		t.Fatal("expected ticket submission to be resumed")
+	}
+}
+
+func newTestBeacon(t *testing.T) *Beacon {
+	localChain := chainLocal.Connect(5, 3, big.NewInt(200))
+	blockCounter, err := localChain.BlockCounter()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	relayChain := localChain.ThresholdRelay()
+
+	groupRegistry := registry.NewGroupRegistry(relayChain, nil)
+
+	node := relay.NewNode(
+		nil,
+		netLocal.Connect(),
+		blockCounter,
+		relayChain.GetConfig(),
+		groupRegistry,
+	)
+
+	return &Beacon{
+		node:          &node,
+		groupRegistry: groupRegistry,
+		relayChain:    relayChain,
+		blockCounter:  blockCounter,
+		pendingGroupSelections: &event.GroupSelectionTrack{
+			Data:  make(map[string]bool),
+			Mutex: &sync.Mutex{},
+		},
+		maintenance:  &maintenance{},
+		unsubscribed: make(chan struct{}),
+	}
+}
@@ -0,0 +1,152 @@
+// Package audit contains the append-only audit log of protocol decisions made
+// by the client: tickets submitted, members marked as inactive or
+// disqualified, accusations raised and resolved, DKG results signed and
+// submitted, signature shares accepted and rejected, relay entries submitted
+// and relay entry timeouts reported.
+//
+// Each record names the operator on behalf of which the decision has been made
+// so that decisions of operators run by the same client can be told apart.
+//
+// Records are written as JSON lines to a file rotated once it reaches the
+// configured size. Until the log is initialized, records are discarded.
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/ipfs/go-log"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+)
+
+var logger = log.Logger("keep-audit")
+
+// Events recorded in the audit log.
+const (
+	TicketSubmitted            = "ticket_submitted"
+	TicketSubmissionFailed     = "ticket_submission_failed"
+	MemberInactive             = "member_inactive"
+	MemberDisqualified         = "member_disqualified"
+	AccusationRaised           = "accusation_raised"
+	AccusationResolved         = "accusation_resolved"
+	DKGResultSigned            = "dkg_result_signed"
+	DKGResultSignatureAccepted = "dkg_result_signature_accepted"
+	DKGResultSignatureRejected = "dkg_result_signature_rejected"
+	DKGResultSubmitted         = "dkg_result_submitted"
+	SignatureShareAccepted     = "signature_share_accepted"
+	SignatureShareRejected     = "signature_share_rejected"
+	RelayEntrySubmitted        = "relay_entry_submitted"
+	RelayEntryTimedOut         = "relay_entry_timed_out"
+	RelayEntryTimeoutReported  = "relay_entry_timeout_reported"
+)
+
+// Record is a single protocol decision recorded in the audit log.
+type Record struct {
+	Time  time.Time `json:"time"`
+	Event string    `json:"event"`
+	// Block is the block at which the decision has been made.
+	Block uint64 `json:"block"`
+	// Operator is the address of the operator on behalf of which the
+	// decision has been made.
+	Operator string `json:"operator,omitempty"`
+	// Channel is the name of the broadcast channel of the protocol. It is
+	// empty for decisions not made within a group, e.g. ticket submissions.
+	Channel string `json:"channel,omitempty"`
+	// Member is the index of the group member of this client which made the
+	// decision. It is zero for decisions not made by a group member.
+	Member  group.MemberIndex      `json:"member,omitempty"`
+	Details map[string]interface{} `json:"details,omitempty"`
+}
+
+type contextKey struct{}
+
+// WithOperator returns a copy of the context carrying the address of the
+// operator executing the protocol. Decisions made within the protocol are
+// recorded on behalf of that operator.
+func WithOperator(ctx context.Context, operator string) context.Context {
+	return context.WithValue(ctx, contextKey{}, operator)
+}
+
+// OperatorFrom returns the operator address carried by the context or an
+// empty string if the context carries none.
+func OperatorFrom(ctx context.Context) string {
+	operator, _ := ctx.Value(contextKey{}).(string)
+	return operator
+}
+
+var (
+	writerMutex sync.Mutex
+	writer      *rotatingFile
+)
+
+// Initialize opens the audit log in the given directory. The log file is
+// rotated once it exceeds maxFileSize bytes and at most maxFiles files,
+// including the current one, are kept.
+func Initialize(dir string, maxFileSize int64, maxFiles int) error {
+	file, err := openRotatingFile(dir, maxFileSize, maxFiles)
+	if err != nil {
+		return err
+	}
+
+	writerMutex.Lock()
+	defer writerMutex.Unlock()
+
+	if writer != nil {
+		if err := writer.close(); err != nil {
+			logger.Warningf("could not close previous audit log: [%v]", err)
+		}
+	}
+	writer = file
+
+	return nil
+}
+
+// Close closes the audit log. Records logged afterwards are discarded.
+func Close() error {
+	writerMutex.Lock()
+	defer writerMutex.Unlock()
+
+	if writer == nil {
+		return nil
+	}
+
+	err := writer.close()
+	writer = nil
+
+	return err
+}
+
+// Log appends the record to the audit log. Failures are logged and do not
+// interrupt the protocol.
+func Log(record *Record) {
+	writerMutex.Lock()
+	defer writerMutex.Unlock()
+
+	if writer == nil {
+		return
+	}
+
+	if record.Time.IsZero() {
+		record.Time = time.Now().UTC()
+	}
+
+	line, err := json.Marshal(record)
+	if err != nil {
+		logger.Errorf(
+			"could not marshal audit record [%v]: [%v]",
+			record.Event,
+			err,
+		)
+		return
+	}
+
+	if err := writer.write(append(line, '\n')); err != nil {
+		logger.Errorf(
+			"could not write audit record [%v]: [%v]",
+			record.Event,
+			err,
+		)
+	}
+}
@@ -0,0 +1,252 @@
+package audit
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+)
+
+func TestLogNotInitialized(t *testing.T) {
+	dir := newTestDir(t)
+	defer os.RemoveAll(dir)
+
+	Log(&Record{Event: TicketSubmitted, Block: 1})
+
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 0 {
+		t.Fatalf("expected no audit log files; has [%v]", len(files))
+	}
+}
+
+func TestLog(t *testing.T) {
+	dir := newTestDir(t)
+	defer os.RemoveAll(dir)
+
+	if err := Initialize(dir, 1024*1024, 2); err != nil {
+		t.Fatal(err)
+	}
+
+	Log(&Record{
+		Event:    MemberDisqualified,
+		Block:    10,
+		Operator: "0xA",
+		Channel:  "channel",
+		Member:   2,
+		Details:  map[string]interface{}{"reason": "misbehaved"},
+	})
+	Log(&Record{Event: TicketSubmitted, Block: 11})
+
+	if err := Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Records logged after the log has been closed are discarded.
+	Log(&Record{Event: TicketSubmitted, Block: 12})
+
+	records := readTestRecords(t, filepath.Join(dir, fileName))
+	if len(records) != 2 {
+		t.Fatalf("unexpected number of records [%v]", len(records))
+	}
+
+	if records[0].Time.IsZero() {
+		t.Errorf("expected record time to be set")
+	}
+
+	expectedRecords := []Record{
+		{
+			Time:     records[0].Time,
+			Event:    MemberDisqualified,
+			Block:    10,
+			Operator: "0xA",
+			Channel:  "channel",
+			Member:   2,
+			Details:  map[string]interface{}{"reason": "misbehaved"},
+		},
+		{
+			Time:  records[1].Time,
+			Event: TicketSubmitted,
+			Block: 11,
+		},
+	}
+	if !reflect.DeepEqual(expectedRecords, records) {
+		t.Errorf(
+			"unexpected records\nexpected: %v\nactual:   %v\n",
+			expectedRecords,
+			records,
+		)
+	}
+}
+
+func TestLogRotation(t *testing.T) {
+	dir := newTestDir(t)
+	defer os.RemoveAll(dir)
+
+	recordTime := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	line, err := json.Marshal(
+		&Record{Time: recordTime, Event: TicketSubmitted, Block: 1},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Each file fits exactly two records.
+	maxFileSize := int64(2 * (len(line) + 1))
+	if err := Initialize(dir, maxFileSize, 3); err != nil {
+		t.Fatal(err)
+	}
+	defer Close()
+
+	for block := uint64(1); block <= 9; block++ {
+		Log(&Record{Time: recordTime, Event: TicketSubmitted, Block: block})
+	}
+
+	expectedBlocks := map[string][]uint64{
+		fileName:        {9},
+		fileName + ".1": {7, 8},
+		fileName + ".2": {5, 6},
+	}
+
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != len(expectedBlocks) {
+		t.Fatalf("unexpected number of audit log files [%v]", len(files))
+	}
+
+	for file, expected := range expectedBlocks {
+		blocks := []uint64{}
+		for _, record := range readTestRecords(t, filepath.Join(dir, file)) {
+			blocks = append(blocks, record.Block)
+		}
+
+		if !reflect.DeepEqual(expected, blocks) {
+			t.Errorf(
+				"unexpected blocks in file [%v]\nexpected: %v\nactual:   %v\n",
+				file,
+				expected,
+				blocks,
+			)
+		}
+	}
+}
+
+func TestOperatorFrom(t *testing.T) {
+	if operator := OperatorFrom(context.Background()); operator != "" {
+		t.Errorf("unexpected operator [%v]", operator)
+	}
+
+	ctx := WithOperator(context.Background(), "0xA")
+	if operator := OperatorFrom(ctx); operator != "0xA" {
+		t.Errorf("unexpected operator\nexpected: [0xA]\nactual:   [%v]", operator)
+	}
+}
+
+func TestEliminationRecorder(t *testing.T) {
+	dir := newTestDir(t)
+	defer os.RemoveAll(dir)
+
+	if err := Initialize(dir, 1024*1024, 1); err != nil {
+		t.Fatal(err)
+	}
+	defer Close()
+
+	dkgGroup := group.NewDkgGroup(2, 5)
+	// Eliminations made before the recorder has been created are not
+	// recorded.
+	dkgGroup.MarkMemberAsInactive(5, "no message received")
+
+	recorder := NewEliminationRecorder("0xA", "channel", 1, dkgGroup)
+
+	dkgGroup.MarkMemberAsInactive(4, "no message received")
+	recorder.Record(10)
+	dkgGroup.MarkMemberAsDisqualified(3, "sent invalid message")
+	recorder.Record(12)
+	recorder.Record(14)
+
+	records := readTestRecords(t, filepath.Join(dir, fileName))
+
+	expectedRecords := []Record{
+		{
+			Event:    MemberInactive,
+			Block:    10,
+			Operator: "0xA",
+			Channel:  "channel",
+			Member:   1,
+			Details: map[string]interface{}{
+				"eliminated_member": float64(4),
+				"reason":            "no message received",
+			},
+		},
+		{
+			Event:    MemberDisqualified,
+			Block:    12,
+			Operator: "0xA",
+			Channel:  "channel",
+			Member:   1,
+			Details: map[string]interface{}{
+				"eliminated_member": float64(3),
+				"reason":            "sent invalid message",
+			},
+		},
+	}
+	if len(records) != len(expectedRecords) {
+		t.Fatalf("unexpected number of records [%v]", len(records))
+	}
+	for i := range records {
+		records[i].Time = expectedRecords[i].Time
+	}
+
+	if !reflect.DeepEqual(expectedRecords, records) {
+		t.Errorf(
+			"unexpected records\nexpected: %v\nactual:   %v\n",
+			expectedRecords,
+			records,
+		)
+	}
+}
+
+func newTestDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "audit")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return dir
+}
+
+func readTestRecords(t *testing.T, path string) []Record {
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	records := []Record{}
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		record := Record{}
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			t.Fatal(err)
+		}
+		records = append(records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	return records
+}
@@ -0,0 +1,61 @@
+package audit
+
+import (
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+)
+
+// EliminationRecorder records members marked as inactive or disqualified in
+// the group during the protocol execution, each of them once.
+type EliminationRecorder struct {
+	operator    string
+	channel     string
+	memberIndex group.MemberIndex
+	group       *group.Group
+
+	recorded int
+}
+
+// NewEliminationRecorder creates a recorder of eliminations made by the given
+// member of the operator in the given group. Eliminations made before the
+// recorder has been created are not recorded.
+func NewEliminationRecorder(
+	operator string,
+	channel string,
+	memberIndex group.MemberIndex,
+	dkgGroup *group.Group,
+) *EliminationRecorder {
+	return &EliminationRecorder{
+		operator:    operator,
+		channel:     channel,
+		memberIndex: memberIndex,
+		group:       dkgGroup,
+		recorded:    len(dkgGroup.Eliminations()),
+	}
+}
+
+// Record logs all eliminations made since the previous call as decided at the
+// given block.
+func (er *EliminationRecorder) Record(block uint64) {
+	eliminations := er.group.Eliminations()
+
+	for _, elimination := range eliminations[er.recorded:] {
+		event := MemberInactive
+		if elimination.Disqualified {
+			event = MemberDisqualified
+		}
+
+		Log(&Record{
+			Event:    event,
+			Block:    block,
+			Operator: er.operator,
+			Channel:  er.channel,
+			Member:   er.memberIndex,
+			Details: map[string]interface{}{
+				"eliminated_member": elimination.MemberID,
+				"reason":            elimination.Reason,
+			},
+		})
+	}
+
+	er.recorded = len(eliminations)
+}
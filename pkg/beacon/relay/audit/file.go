@@ -0,0 +1,131 @@
+package audit
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// fileName is the name of the current audit log file. Rotated files have
+// the number of the rotation appended, starting with 1 for the most recent.
+const fileName = "audit.log"
+
+// rotatingFile is an append-only file which is rotated once it exceeds the
+// maximum size.
+type rotatingFile struct {
+	dir         string
+	maxFileSize int64
+	maxFiles    int
+
+	file *os.File
+	size int64
+}
+
+func openRotatingFile(
+	dir string,
+	maxFileSize int64,
+	maxFiles int,
+) (*rotatingFile, error) {
+	if maxFileSize <= 0 {
+		return nil, fmt.Errorf(
+			"invalid maximum audit log file size [%v]",
+			maxFileSize,
+		)
+	}
+	if maxFiles < 1 {
+		return nil, fmt.Errorf(
+			"invalid maximum number of audit log files [%v]",
+			maxFiles,
+		)
+	}
+
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf(
+			"could not create audit log directory [%v]: [%v]",
+			dir,
+			err,
+		)
+	}
+
+	rf := &rotatingFile{
+		dir:         dir,
+		maxFileSize: maxFileSize,
+		maxFiles:    maxFiles,
+	}
+
+	if err := rf.open(); err != nil {
+		return nil, err
+	}
+
+	return rf, nil
+}
+
+func (rf *rotatingFile) path(rotation int) string {
+	if rotation == 0 {
+		return filepath.Join(rf.dir, fileName)
+	}
+
+	return filepath.Join(rf.dir, fmt.Sprintf("%v.%v", fileName, rotation))
+}
+
+func (rf *rotatingFile) open() error {
+	file, err := os.OpenFile(
+		rf.path(0),
+		os.O_APPEND|os.O_CREATE|os.O_WRONLY,
+		0600,
+	)
+	if err != nil {
+		return fmt.Errorf("could not open audit log file: [%v]", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("could not read audit log file info: [%v]", err)
+	}
+
+	rf.file = file
+	rf.size = info.Size()
+
+	return nil
+}
+
+func (rf *rotatingFile) write(line []byte) error {
+	if rf.size > 0 && rf.size+int64(len(line)) > rf.maxFileSize {
+		if err := rf.rotate(); err != nil {
+			return err
+		}
+	}
+
+	written, err := rf.file.Write(line)
+	rf.size += int64(written)
+
+	return err
+}
+
+// rotate closes the current file and shifts it along with previously
+// rotated files by one rotation. The oldest file is removed once the maximum
+// number of files is reached.
+func (rf *rotatingFile) rotate() error {
+	if err := rf.file.Close(); err != nil {
+		return fmt.Errorf("could not close audit log file: [%v]", err)
+	}
+
+	oldest := rf.path(rf.maxFiles - 1)
+	if err := os.Remove(oldest); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("could not remove oldest audit log file: [%v]", err)
+	}
+
+	for rotation := rf.maxFiles - 2; rotation >= 0; rotation-- {
+		err := os.Rename(rf.path(rotation), rf.path(rotation+1))
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("could not rotate audit log file: [%v]", err)
+		}
+	}
+
+	return rf.open()
+}
+
+func (rf *rotatingFile) close() error {
+	return rf.file.Close()
+}
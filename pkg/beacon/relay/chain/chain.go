@@ -5,7 +5,6 @@ import (
 
 	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
 	"github.com/keep-network/keep-core/pkg/gen/async"
-	"github.com/keep-network/keep-core/pkg/operator"
 	"github.com/keep-network/keep-core/pkg/subscription"
 )
 
@@ -25,6 +24,10 @@ type RelayEntryInterface interface {
 	// the entry has been successfully submitted to the on-chain, or failed if
 	// the entry submission failed.
 	SubmitRelayEntry(entry []byte) *async.EventEntrySubmittedPromise
+	// SimulateRelayEntry checks whether the given entry would be accepted by
+	// the chain without submitting it. It returns an error if the entry
+	// submission would fail.
+	SimulateRelayEntry(entry []byte) error
 	// OnRelayEntrySubmitted is a callback that is invoked when an on-chain
 	// notification of a new, valid relay entry is seen.
 	OnRelayEntrySubmitted(
@@ -126,9 +129,6 @@ type DistributedKeyGenerationInterface interface {
 type Interface interface {
 	// GetConfig returns the expected configuration of the threshold relay.
 	GetConfig() *Config
-	// GetKeys returns the key pair used to attest for messages being sent to
-	// the chain.
-	GetKeys() (*operator.PrivateKey, *operator.PublicKey)
 	// MinimumStake returns the current on-chain value representing the minimum
 	// necessary amount of KEEP a client must lock up to participate in the
 	// threshold relay. This value can change over time according to the minimum
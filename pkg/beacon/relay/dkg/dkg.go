@@ -2,6 +2,7 @@ package dkg
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"math/big"
 
@@ -13,13 +14,18 @@ import (
 	"github.com/keep-network/keep-core/pkg/beacon/relay/gjkr"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
 	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/logging"
 	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/tracing"
 )
 
 var logger = log.Logger("keep-dkg")
 
-// ExecuteDKG runs the full distributed key generation lifecycle.
+// ExecuteDKG runs the full distributed key generation lifecycle. The
+// execution is abandoned with an error once the given context is done.
+// The GJKR options are applied to the execution of the GJKR protocol.
 func ExecuteDKG(
+	parentCtx context.Context,
 	seed *big.Int,
 	index uint8, // starts with 0
 	groupSize int,
@@ -30,14 +36,36 @@ func ExecuteDKG(
 	relayChain relayChain.Interface,
 	signing chain.Signing,
 	channel net.BroadcastChannel,
-) (*ThresholdSigner, error) {
+	reputation net.Reputation,
+	gjkrOptions ...gjkr.ExecuteOption,
+) (signer *ThresholdSigner, err error) {
 	// The staker index should begin with 1
 	playerIndex := group.MemberIndex(index + 1)
 
+	parentCtx, span := tracing.Start(parentCtx, "dkg", map[string]interface{}{
+		"member":      playerIndex,
+		"channel":     channel.Name(),
+		"start_block": startBlockHeight,
+	})
+	defer func() { span.End(err) }()
+
+	parentCtx = logging.WithFields(parentCtx, logging.Fields{
+		logging.MemberField:  playerIndex,
+		logging.ChannelField: channel.Name(),
+	})
+	dkgLogger := logging.ForContext(parentCtx, logger)
+
 	gjkr.RegisterUnmarshallers(channel)
 	dkgResult.RegisterUnmarshallers(channel)
 
+	ctx, cancelCtx := context.WithCancel(parentCtx)
+	defer cancelCtx()
+
+	memberPeers := newMemberPeers(membershipValidator)
+	channel.Recv(ctx, memberPeers.record)
+
 	gjkrResult, gjkrEndBlockHeight, err := gjkr.Execute(
+		ctx,
 		playerIndex,
 		groupSize,
 		blockCounter,
@@ -46,6 +74,7 @@ func ExecuteDKG(
 		seed,
 		membershipValidator,
 		startBlockHeight,
+		gjkrOptions...,
 	)
 	if err != nil {
 		return nil, fmt.Errorf(
@@ -55,6 +84,13 @@ func ExecuteDKG(
 		)
 	}
 
+	// Members disqualified during GJKR lower their network reputation.
+	memberPeers.report(
+		reputation,
+		gjkrResult.Group.DisqualifiedMemberIDs(),
+		net.Disqualification,
+	)
+
 	startPublicationBlockHeight := gjkrEndBlockHeight
 
 	dkgResultChannel := make(chan *event.DKGResultSubmission)
@@ -66,6 +102,7 @@ func ExecuteDKG(
 	defer dkgResultSubscription.Unsubscribe()
 
 	err = dkgResult.Publish(
+		ctx,
 		playerIndex,
 		gjkrResult.Group,
 		membershipValidator,
@@ -83,12 +120,19 @@ func ExecuteDKG(
 		// chain for the result published by any other group member and based
 		// on that, we decide whether we should stay in the final group
 		// or drop our membership.
-		logger.Warningf(
-			"[member:%v] DKG result publication process failed [%v]",
-			playerIndex,
+		dkgLogger.Warningf(
+			"DKG result publication process failed [%v]",
 			err,
 		)
 
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf(
+				"[member:%v] DKG result publication abandoned [%v]",
+				playerIndex,
+				ctx.Err(),
+			)
+		}
+
 		if err := decideMemberFate(
 			playerIndex,
 			gjkrResult,
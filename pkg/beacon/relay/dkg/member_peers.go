@@ -0,0 +1,63 @@
+package dkg
+
+import (
+	"sync"
+
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+	"github.com/keep-network/keep-core/pkg/net"
+)
+
+// memberPeers tracks transport identifiers of group members based on the
+// protocol messages they sent, so that members misbehaving in the protocol
+// can be reported to the network reputation.
+type memberPeers struct {
+	membershipValidator group.MembershipValidator
+
+	mutex sync.Mutex
+	peers map[group.MemberIndex]net.TransportIdentifier
+}
+
+func newMemberPeers(membershipValidator group.MembershipValidator) *memberPeers {
+	return &memberPeers{
+		membershipValidator: membershipValidator,
+		peers:               make(map[group.MemberIndex]net.TransportIdentifier),
+	}
+}
+
+// record stores the transport identifier of the message sender if the
+// message is a protocol message sent by a valid group member.
+func (mp *memberPeers) record(message net.Message) {
+	protocolMessage, ok := message.Payload().(group.ProtocolMessage)
+	if !ok {
+		return
+	}
+
+	if !mp.membershipValidator.IsValidMembership(
+		protocolMessage.SenderID(),
+		message.SenderPublicKey(),
+	) {
+		return
+	}
+
+	mp.mutex.Lock()
+	defer mp.mutex.Unlock()
+
+	mp.peers[protocolMessage.SenderID()] = message.TransportSenderID()
+}
+
+// report reports the misbehavior of the given members whose transport
+// identifiers are known.
+func (mp *memberPeers) report(
+	reputation net.Reputation,
+	memberIDs []group.MemberIndex,
+	misbehavior net.Misbehavior,
+) {
+	mp.mutex.Lock()
+	defer mp.mutex.Unlock()
+
+	for _, memberID := range memberIDs {
+		if peer, ok := mp.peers[memberID]; ok {
+			reputation.ReportMisbehavior(peer, misbehavior)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package result
+
+import (
+	"fmt"
+
+	"github.com/keep-network/keep-core/pkg/beacon/relay/audit"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+)
+
+// auditor records decisions made by the member during the DKG result
+// publication in the audit log.
+type auditor struct {
+	operator    string
+	channel     string
+	memberIndex group.MemberIndex
+
+	eliminations *audit.EliminationRecorder
+}
+
+func newAuditor(
+	operator string,
+	channel string,
+	memberIndex group.MemberIndex,
+	dkgGroup *group.Group,
+) *auditor {
+	return &auditor{
+		operator:    operator,
+		channel:     channel,
+		memberIndex: memberIndex,
+		eliminations: audit.NewEliminationRecorder(
+			operator,
+			channel,
+			memberIndex,
+			dkgGroup,
+		),
+	}
+}
+
+// auditState records decisions made by the member when the given state has
+// been initiated. The result submission is recorded by the submitting member
+// once the result is accepted by the chain.
+func (a *auditor) auditState(initiatedState signingState, block uint64) {
+	a.eliminations.Record(block)
+
+	switch s := initiatedState.(type) {
+	case *resultSigningState:
+		a.log(audit.DKGResultSigned, block, map[string]interface{}{
+			"result_hash": fmt.Sprintf("0x%x", s.member.preferredDKGResultHash),
+		})
+	case *signaturesVerificationState:
+		for _, message := range s.signatureMessages {
+			event := audit.DKGResultSignatureRejected
+			if _, ok := s.validSignatures[message.senderIndex]; ok {
+				event = audit.DKGResultSignatureAccepted
+			}
+
+			a.log(event, block, map[string]interface{}{
+				"sender":      message.senderIndex,
+				"result_hash": fmt.Sprintf("0x%x", message.resultHash),
+			})
+		}
+	}
+}
+
+func (a *auditor) log(
+	event string,
+	block uint64,
+	details map[string]interface{},
+) {
+	audit.Log(&audit.Record{
+		Event:    event,
+		Block:    block,
+		Operator: a.operator,
+		Channel:  a.channel,
+		Member:   a.memberIndex,
+		Details:  details,
+	})
+}
@@ -83,11 +83,11 @@ func TestConvertResult(t *testing.T) {
 	}
 	for _, test := range tests {
 		for _, disqualifiedMember := range test.disqualifiedMemberIDs {
-			test.gjkrResult.Group.MarkMemberAsDisqualified(disqualifiedMember)
+			test.gjkrResult.Group.MarkMemberAsDisqualified(disqualifiedMember, "misbehaved")
 		}
 
 		for _, inactiveMember := range test.inactiveMemberIDs {
-			test.gjkrResult.Group.MarkMemberAsInactive(inactiveMember)
+			test.gjkrResult.Group.MarkMemberAsInactive(inactiveMember, "inactive")
 		}
 
 		convertedResult := convertGjkrResult(test.gjkrResult)
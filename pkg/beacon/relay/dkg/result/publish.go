@@ -1,14 +1,17 @@
 package result
 
 import (
+	"context"
 	"fmt"
 
+	"github.com/keep-network/keep-core/pkg/beacon/relay/audit"
 	relayChain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/gjkr"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/state"
 	"github.com/keep-network/keep-core/pkg/chain"
 	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/tracing"
 )
 
 // RegisterUnmarshallers initializes the given broadcast channel to be able to
@@ -27,6 +30,7 @@ func RegisterUnmarshallers(channel net.BroadcastChannel) {
 // our own result and added to the list of votes. Finally, we submit the result
 // along with everyone's votes.
 func Publish(
+	ctx context.Context,
 	memberIndex group.MemberIndex,
 	dkgGroup *group.Group,
 	membershipValidator group.MembershipValidator,
@@ -36,12 +40,26 @@ func Publish(
 	signing chain.Signing,
 	blockCounter chain.BlockCounter,
 	startBlockHeight uint64,
-) error {
+) (err error) {
+	ctx, span := tracing.Start(
+		ctx,
+		"dkg-result-publication",
+		map[string]interface{}{
+			"member":      memberIndex,
+			"channel":     channel.Name(),
+			"start_block": startBlockHeight,
+		},
+	)
+	defer func() { span.End(err) }()
+
+	operator := audit.OperatorFrom(ctx)
+
 	initialState := &resultSigningState{
 		channel:                 channel,
 		relayChain:              relayChain,
 		signing:                 signing,
 		blockCounter:            blockCounter,
+		operator:                operator,
 		member:                  NewSigningMember(memberIndex, dkgGroup, membershipValidator),
 		result:                  convertGjkrResult(result),
 		signatureMessages:       make([]*DKGResultHashSignatureMessage, 0),
@@ -49,8 +67,16 @@ func Publish(
 	}
 
 	stateMachine := state.NewMachine(channel, blockCounter, initialState)
+	stateMachine.OnStateInitiated(newAuditor(
+		operator,
+		channel.Name(),
+		memberIndex,
+		dkgGroup,
+	).auditState)
+	endStateSpans := state.TraceStates(ctx, stateMachine)
 
-	lastState, _, err := stateMachine.Execute(startBlockHeight)
+	lastState, _, err := stateMachine.Execute(ctx, startBlockHeight)
+	endStateSpans(err)
 	if err != nil {
 		return err
 	}
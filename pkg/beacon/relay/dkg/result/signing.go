@@ -3,7 +3,9 @@ package result
 import (
 	"fmt"
 
+	"github.com/ipfs/go-log"
 	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/logging"
 
 	relayChain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
@@ -42,6 +44,14 @@ func NewSigningMember(
 	}
 }
 
+// logger returns the package logger annotated with the member index.
+func (sm *SigningMember) logger() *log.ZapEventLogger {
+	return logging.WithLogFields(
+		logger,
+		logging.Fields{logging.MemberField: sm.index},
+	)
+}
+
 // SignDKGResult calculates hash of DKG result and member's signature over this
 // hash. It packs the hash and signature into a broadcast message.
 //
@@ -117,9 +127,8 @@ func (sm *SigningMember) VerifyDKGResultSignatures(
 
 		// Check if sender sent multiple messages.
 		if duplicatedMessagesFromSender(message.senderIndex) {
-			logger.Infof(
-				"[member: %v] received multiple messages from sender: [%d]",
-				sm.index,
+			sm.logger().Infof(
+				"received multiple messages from sender: [%d]",
 				message.senderIndex,
 			)
 			continue
@@ -128,9 +137,8 @@ func (sm *SigningMember) VerifyDKGResultSignatures(
 		// Sender's preferred DKG result hash doesn't match current member's
 		// preferred DKG result hash.
 		if message.resultHash != sm.preferredDKGResultHash {
-			logger.Infof(
-				"[member: %v] signature from sender [%d] supports result different than preferred",
-				sm.index,
+			sm.logger().Infof(
+				"signature from sender [%d] supports result different than preferred",
 				message.senderIndex,
 			)
 			continue
@@ -143,18 +151,16 @@ func (sm *SigningMember) VerifyDKGResultSignatures(
 			message.publicKey,
 		)
 		if err != nil {
-			logger.Infof(
-				"[member: %v] verification of signature from sender [%d] failed: [%v]",
-				sm.index,
+			sm.logger().Infof(
+				"verification of signature from sender [%d] failed: [%v]",
 				message.senderIndex,
 				err,
 			)
 			continue
 		}
 		if !ok {
-			logger.Infof(
-				"[member: %v] sender [%d] provided invalid signature",
-				sm.index,
+			sm.logger().Infof(
+				"sender [%d] provided invalid signature",
 				message.senderIndex,
 			)
 			continue
@@ -35,6 +35,8 @@ type resultSigningState struct {
 	relayChain   relayChain.Interface
 	signing      chain.Signing
 	blockCounter chain.BlockCounter
+	// Address of the operator of the member, recorded in the audit log.
+	operator string
 
 	member *SigningMember
 
@@ -104,6 +106,7 @@ func (rss *resultSigningState) Next() signingState {
 		relayChain:        rss.relayChain,
 		signing:           rss.signing,
 		blockCounter:      rss.blockCounter,
+		operator:          rss.operator,
 		member:            rss.member,
 		result:            rss.result,
 		signatureMessages: rss.signatureMessages,
@@ -129,6 +132,8 @@ type signaturesVerificationState struct {
 	relayChain   relayChain.Interface
 	signing      chain.Signing
 	blockCounter chain.BlockCounter
+	// Address of the operator of the member, recorded in the audit log.
+	operator string
 
 	member *SigningMember
 
@@ -170,9 +175,13 @@ func (svs *signaturesVerificationState) Next() signingState {
 		channel:      svs.channel,
 		relayChain:   svs.relayChain,
 		blockCounter: svs.blockCounter,
-		member:       NewSubmittingMember(svs.member.index),
-		result:       svs.result,
-		signatures:   svs.validSignatures,
+		member: NewSubmittingMember(
+			svs.member.index,
+			svs.operator,
+			svs.channel.Name(),
+		),
+		result:     svs.result,
+		signatures: svs.validSignatures,
 		submissionStartBlockHeight: svs.verificationStartBlockHeight +
 			svs.DelayBlocks() +
 			svs.ActiveBlocks(),
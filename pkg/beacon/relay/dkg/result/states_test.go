@@ -104,6 +104,9 @@ func (msm *mockSignatureMessage) Type() string {
 func (msm *mockSignatureMessage) SenderPublicKey() []byte {
 	return msm.senderPublicKey
 }
+func (msm *mockSignatureMessage) SenderCapabilities() *net.PeerCapabilities {
+	panic("not implemented")
+}
 func (msm *mockSignatureMessage) Seqno() uint64 {
 	panic("not implemented")
 }
@@ -3,10 +3,13 @@ package result
 import (
 	"fmt"
 
+	"github.com/ipfs/go-log"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/audit"
 	relayChain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
 	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/logging"
 )
 
 // SubmittingMember represents a member submitting a DKG result to the
@@ -15,17 +18,34 @@ import (
 type SubmittingMember struct {
 	// Represents the member's position for submission.
 	index group.MemberIndex
+	// Address of the operator of the member, recorded in the audit log.
+	operator string
+	// Name of the broadcast channel of the group, recorded in the audit log.
+	channelName string
 }
 
 // NewSubmittingMember creates a member to execute submitting the DKG result hash.
 func NewSubmittingMember(
 	memberIndex group.MemberIndex,
+	operator string,
+	channelName string,
 ) *SubmittingMember {
 	return &SubmittingMember{
-		index: memberIndex,
+		index:       memberIndex,
+		operator:    operator,
+		channelName: channelName,
 	}
 }
 
+// logger returns the package logger annotated with the member index and the
+// channel name.
+func (sm *SubmittingMember) logger() *log.ZapEventLogger {
+	return logging.WithLogFields(logger, logging.Fields{
+		logging.MemberField:  sm.index,
+		logging.ChannelField: sm.channelName,
+	})
+}
+
 // SubmitDKGResult sends a result, which contains the group public key and
 // signatures, to the chain.
 //
@@ -118,10 +138,9 @@ func (sm *SubmittingMember) SubmitDKGResult(
 			subscription.Unsubscribe()
 			close(onSubmittedResultChan)
 
-			logger.Infof(
-				"[member:%v] submitting DKG result with public key [0x%x] and "+
+			sm.logger().Infof(
+				"submitting DKG result with public key [0x%x] and "+
 					"[%v] supporting member signatures at block [%v]",
-				sm.index,
 				result.GroupPublicKey,
 				len(signatures),
 				blockNumber,
@@ -135,13 +154,28 @@ func (sm *SubmittingMember) SubmitDKGResult(
 					dkgResultPublishedEvent *event.DKGResultSubmission,
 					err error,
 				) {
+					if err == nil {
+						audit.Log(&audit.Record{
+							Event:    audit.DKGResultSubmitted,
+							Block:    dkgResultPublishedEvent.BlockNumber,
+							Operator: sm.operator,
+							Channel:  sm.channelName,
+							Member:   sm.index,
+							Details: map[string]interface{}{
+								"group_public_key": fmt.Sprintf(
+									"0x%x",
+									result.GroupPublicKey,
+								),
+								"signatures": len(signatures),
+							},
+						})
+					}
 					errorChannel <- err
 				})
 			return <-errorChannel
 		case blockNumber := <-onSubmittedResultChan:
-			logger.Infof(
-				"[member:%v] leaving; DKG result submitted by other member at block [%v]",
-				sm.index,
+			sm.logger().Infof(
+				"leaving; DKG result submitted by other member at block [%v]",
 				blockNumber,
 			)
 			// A result has been submitted by other member. Leave without
@@ -163,9 +197,8 @@ func (sm *SubmittingMember) waitForSubmissionEligibility(
 	blockWaitTime := (uint64(sm.index) - 1) * blockStep
 
 	eligibleBlockHeight := startBlockHeight + blockWaitTime
-	logger.Infof(
-		"[member:%v] waiting for block [%v] to submit",
-		sm.index,
+	sm.logger().Infof(
+		"waiting for block [%v] to submit",
 		eligibleBlockHeight,
 	)
 
@@ -8,12 +8,15 @@ import (
 
 	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
 	"github.com/ipfs/go-log"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/audit"
 	relayChain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/dkg"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
 	"github.com/keep-network/keep-core/pkg/bls"
 	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/logging"
 	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/tracing"
 )
 
 var logger = log.Logger("keep-entry")
@@ -30,17 +33,40 @@ func RegisterUnmarshallers(channel net.BroadcastChannel) {
 
 // SignAndSubmit triggers the threshold signature process for the
 // previous relay entry and publishes the signature to the chain as
-// a new relay entry.
+// a new relay entry. The signing is abandoned with an error once the
+// given context is done.
 func SignAndSubmit(
+	parentCtx context.Context,
 	blockCounter chain.BlockCounter,
 	channel net.BroadcastChannel,
+	reputation net.Reputation,
 	relayChain relayChain.Interface,
 	previousEntryBytes []byte,
 	honestThreshold int,
 	signer *dkg.ThresholdSigner,
 	startBlockHeight uint64,
-) error {
-	ctx, cancelCtx := context.WithCancel(context.Background())
+) (err error) {
+	parentCtx, span := tracing.Start(
+		parentCtx,
+		"relay-entry-signing",
+		map[string]interface{}{
+			"member":      signer.MemberID(),
+			"channel":     channel.Name(),
+			"start_block": startBlockHeight,
+		},
+	)
+	defer func() { span.End(err) }()
+
+	groupPublicKey := signer.GroupPublicKeyBytes()
+	parentCtx = logging.WithFields(parentCtx, logging.Fields{
+		logging.MemberField:  signer.MemberID(),
+		logging.GroupField:   fmt.Sprintf("0x%x", groupPublicKey),
+		logging.ChannelField: channel.Name(),
+	})
+	signingLogger := logging.ForContext(parentCtx, logger)
+	operator := audit.OperatorFrom(parentCtx)
+
+	ctx, cancelCtx := context.WithCancel(parentCtx)
 	defer cancelCtx()
 
 	relayEntrySubmittedChannel := make(chan uint64)
@@ -79,6 +105,16 @@ func SignAndSubmit(
 		signer.MemberID(): selfShare,
 	}
 
+	_, collectionSpan := tracing.Start(
+		ctx,
+		"signature-share-collection",
+		nil,
+	)
+	defer func() {
+		collectionSpan.SetAttribute("valid_shares", len(receivedValidShares))
+		collectionSpan.End(err)
+	}()
+
 	// Run the message loop until the number of received and valid signature
 	// shares is equal to the honest threshold. Message loop will be also
 	// terminated if an other member submits the result or the relay entry
@@ -97,41 +133,87 @@ func SignAndSubmit(
 				previousEntry,
 			)
 			if err != nil {
-				logger.Warningf(
-					"[member:%v] rejecting signature share from "+
+				signingLogger.Warningf(
+					"rejecting signature share from "+
 						"member [%v]: [%v]",
-					signer.MemberID(),
 					message.senderID,
 					err,
 				)
+				audit.Log(&audit.Record{
+					Event:    audit.SignatureShareRejected,
+					Block:    currentBlock(blockCounter),
+					Operator: operator,
+					Channel:  channel.Name(),
+					Member:   signer.MemberID(),
+					Details: map[string]interface{}{
+						"sender": message.senderID,
+						"reason": err.Error(),
+					},
+				})
+				reputation.ReportMisbehavior(
+					netMessage.TransportSenderID(),
+					net.InvalidSignatureShare,
+				)
 				continue
 			}
 
-			logger.Debugf(
-				"[member:%v] accepting signature share from member [%v]",
-				signer.MemberID(),
+			signingLogger.Debugf(
+				"accepting signature share from member [%v]",
 				message.senderID,
 			)
+			audit.Log(&audit.Record{
+				Event:    audit.SignatureShareAccepted,
+				Block:    currentBlock(blockCounter),
+				Operator: operator,
+				Channel:  channel.Name(),
+				Member:   signer.MemberID(),
+				Details: map[string]interface{}{
+					"sender": message.senderID,
+				},
+			})
 
 			receivedValidShares[message.senderID] = share
 		case blockNumber := <-relayEntrySubmittedChannel:
-			logger.Infof(
-				"[member:%v] leaving message loop; "+
+			signingLogger.Infof(
+				"leaving message loop; "+
 					"relay entry submitted by other member at block [%v]",
-				signer.MemberID(),
 				blockNumber,
 			)
 			return nil
 		case blockNumber := <-relayEntryTimeoutChannel:
+			audit.Log(&audit.Record{
+				Event:    audit.RelayEntryTimedOut,
+				Block:    blockNumber,
+				Operator: operator,
+				Channel:  channel.Name(),
+				Member:   signer.MemberID(),
+				Details: map[string]interface{}{
+					"valid_shares": len(receivedValidShares),
+				},
+			})
 			return fmt.Errorf(
 				"relay entry timed out at block [%v]; received [%v] valid signature shares",
 				blockNumber,
 				len(receivedValidShares),
 			)
+		case <-ctx.Done():
+			return fmt.Errorf(
+				"relay entry signing abandoned; received [%v] valid signature shares: [%v]",
+				len(receivedValidShares),
+				ctx.Err(),
+			)
 		}
 	}
 
-	signature, err := completeSignature(signer, receivedValidShares, honestThreshold)
+	collectionSpan.SetAttribute("valid_shares", len(receivedValidShares))
+	collectionSpan.End(nil)
+
+	signature, err := completeSignature(
+		ctx,
+		signer,
+		receivedValidShares,
+		honestThreshold,
+	)
 	if err != nil {
 		return err
 	}
@@ -139,6 +221,8 @@ func SignAndSubmit(
 	submitter := &relayEntrySubmitter{
 		chain:        relayChain,
 		blockCounter: blockCounter,
+		operator:     operator,
+		channelName:  channel.Name(),
 		index:        signer.MemberID(),
 	}
 
@@ -147,13 +231,29 @@ func SignAndSubmit(
 	// timeout signal appeared while executing the message loop. There is
 	// still a possibility those signals appear in the future so the submitter
 	// must be aware of them and break the execution if they occur.
-	return submitter.submitRelayEntry(
+	_, submissionSpan := tracing.Start(ctx, "relay-entry-submission", nil)
+	err = submitter.submitRelayEntry(
 		signature.Marshal(),
 		signer.GroupPublicKeyBytes(),
 		startBlockHeight,
 		relayEntrySubmittedChannel,
 		relayEntryTimeoutChannel,
 	)
+	submissionSpan.End(err)
+
+	return err
+}
+
+// currentBlock returns the current block to be recorded in the audit log or
+// zero if the block could not be determined.
+func currentBlock(blockCounter chain.BlockCounter) uint64 {
+	block, err := blockCounter.CurrentBlock()
+	if err != nil {
+		logger.Warningf("could not get the current block: [%v]", err)
+		return 0
+	}
+
+	return block
 }
 
 func broadcastShare(
@@ -168,9 +268,8 @@ func broadcastShare(
 	}
 
 	if err := channel.Send(ctx, message); err != nil {
-		logger.Errorf(
-			"[member:%v] could not send signature share: [%v]",
-			memberID,
+		logging.ForContext(ctx, logger).Errorf(
+			"could not send signature share: [%v]",
 			err,
 		)
 	}
@@ -206,6 +305,7 @@ func extractAndValidateShare(
 }
 
 func completeSignature(
+	ctx context.Context,
 	signer *dkg.ThresholdSigner,
 	shares map[group.MemberIndex]*bn256.G1,
 	honestThreshold int,
@@ -216,9 +316,8 @@ func completeSignature(
 		signatureShares = append(signatureShares, signatureShare)
 	}
 
-	logger.Infof(
-		"[member:%v] restoring signature from [%v] shares",
-		signer.MemberID(),
+	logging.ForContext(ctx, logger).Infof(
+		"restoring signature from [%v] shares",
 		len(signatureShares),
 	)
 
@@ -3,19 +3,35 @@ package entry
 import (
 	"fmt"
 
+	"github.com/ipfs/go-log"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/audit"
 	relayChain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
 	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/logging"
 )
 
 type relayEntrySubmitter struct {
 	chain        relayChain.Interface
 	blockCounter chain.BlockCounter
+	// Address of the operator of the member, recorded in the audit log.
+	operator string
+	// Name of the broadcast channel of the group, recorded in the audit log.
+	channelName string
 
 	index group.MemberIndex
 }
 
+// logger returns the package logger annotated with the member index and the
+// channel name.
+func (res *relayEntrySubmitter) logger() *log.ZapEventLogger {
+	return logging.WithLogFields(logger, logging.Fields{
+		logging.MemberField:  res.index,
+		logging.ChannelField: res.channelName,
+	})
+}
+
 // submitRelayEntry submits the provided relay entry data to the chain.
 // Group members tries to submit in the order specified by their indexes.
 // Group member with index 1 tries to submit as the first one, group member 2
@@ -31,9 +47,6 @@ func (res *relayEntrySubmitter) submitRelayEntry(
 ) error {
 	config := res.chain.GetConfig()
 
-	// TODO: we should eventually check if entry has been already submitted
-	// but we may skip this check for V1.
-
 	// Wait until the current member is eligible to submit the entry.
 	eligibleToSubmitWaiter, err := res.waitForSubmissionEligibility(
 		startBlockHeight,
@@ -46,14 +59,26 @@ func (res *relayEntrySubmitter) submitRelayEntry(
 	for {
 		select {
 		case blockNumber := <-eligibleToSubmitWaiter:
-			// Member becomes eligible to submit the result.
+			// Member becomes eligible to submit the result. Before the
+			// submission, make sure the entry is still awaited and would be
+			// accepted so no gas is burned on a reverted transaction.
+			shouldSubmit, err := res.shouldSubmitRelayEntry(
+				newEntry,
+				startBlockHeight,
+			)
+			if err != nil {
+				return err
+			}
+			if !shouldSubmit {
+				return nil
+			}
+
 			errorChannel := make(chan error)
 			defer close(errorChannel)
 
-			logger.Infof(
-				"[member:%v] submitting relay entry [0x%x] on behalf of group "+
+			res.logger().Infof(
+				"submitting relay entry [0x%x] on behalf of group "+
 					"[0x%x] at block [%v]",
-				res.index,
 				newEntry,
 				groupPublicKey,
 				blockNumber,
@@ -62,12 +87,22 @@ func (res *relayEntrySubmitter) submitRelayEntry(
 			res.chain.SubmitRelayEntry(newEntry).OnComplete(
 				func(entry *event.EntrySubmitted, err error) {
 					if err == nil {
-						logger.Infof(
-							"[member:%v] successfully submitted "+
+						res.logger().Infof(
+							"successfully submitted "+
 								"relay entry at block: [%v]",
-							res.index,
 							entry.BlockNumber,
 						)
+						audit.Log(&audit.Record{
+							Event:    audit.RelayEntrySubmitted,
+							Block:    entry.BlockNumber,
+							Operator: res.operator,
+							Channel:  res.channelName,
+							Member:   res.index,
+							Details: map[string]interface{}{
+								"entry":            fmt.Sprintf("0x%x", newEntry),
+								"group_public_key": fmt.Sprintf("0x%x", groupPublicKey),
+							},
+						})
 					}
 					errorChannel <- err
 				})
@@ -77,11 +112,10 @@ func (res *relayEntrySubmitter) submitRelayEntry(
 			if entryErr != nil {
 				isEntryInProgress, err := res.chain.IsEntryInProgress()
 				if err != nil {
-					logger.Errorf(
-						"[member:%v] could not check entry status after "+
+					res.logger().Errorf(
+						"could not check entry status after "+
 							"relay entry submission error: [%v]; "+
 							"original error will be returned",
-						res.index,
 						err,
 					)
 					return entryErr
@@ -91,9 +125,8 @@ func (res *relayEntrySubmitter) submitRelayEntry(
 				// meantime or because something wrong happened with
 				// our transaction.
 				if !isEntryInProgress {
-					logger.Infof(
-						"[member:%v] relay entry already submitted",
-						res.index,
+					res.logger().Infof(
+						"relay entry already submitted",
 					)
 					return nil
 				}
@@ -101,14 +134,20 @@ func (res *relayEntrySubmitter) submitRelayEntry(
 
 			return entryErr
 		case blockNumber := <-relayEntrySubmittedChannel:
-			logger.Infof(
-				"[member:%v] leaving submitter; "+
+			res.logger().Infof(
+				"leaving submitter; "+
 					"relay entry submitted by other member at block [%v]",
-				res.index,
 				blockNumber,
 			)
 			return nil
 		case blockNumber := <-relayEntryTimeoutChannel:
+			audit.Log(&audit.Record{
+				Event:    audit.RelayEntryTimedOut,
+				Block:    blockNumber,
+				Operator: res.operator,
+				Channel:  res.channelName,
+				Member:   res.index,
+			})
 			return fmt.Errorf(
 				"relay entry timed out at block [%v]",
 				blockNumber,
@@ -117,6 +156,62 @@ func (res *relayEntrySubmitter) submitRelayEntry(
 	}
 }
 
+// shouldSubmitRelayEntry checks the on-chain state right before the relay
+// entry submission. It returns false if the entry for the request started at
+// the given block has been already submitted by another member. It returns an
+// error if the entry is still awaited but the submission would fail.
+func (res *relayEntrySubmitter) shouldSubmitRelayEntry(
+	newEntry []byte,
+	startBlockHeight uint64,
+) (bool, error) {
+	isEntryInProgress, err := res.chain.IsEntryInProgress()
+	if err != nil {
+		return false, fmt.Errorf(
+			"could not check if relay entry is in progress: [%v]",
+			err,
+		)
+	}
+	if !isEntryInProgress {
+		res.logger().Infof(
+			"relay entry already submitted; skipping submission",
+		)
+		return false, nil
+	}
+
+	currentRequestStartBlock, err := res.chain.CurrentRequestStartBlock()
+	if err != nil {
+		return false, fmt.Errorf(
+			"could not get current request start block: [%v]",
+			err,
+		)
+	}
+	if currentRequestStartBlock.Uint64() != startBlockHeight {
+		res.logger().Infof(
+			"relay entry for request started at block [%v] "+
+				"already submitted; current request started at block [%v]; "+
+				"skipping submission",
+			startBlockHeight,
+			currentRequestStartBlock,
+		)
+		return false, nil
+	}
+
+	if err := res.chain.SimulateRelayEntry(newEntry); err != nil {
+		// Another member could submit the entry in the meantime.
+		isEntryInProgress, checkErr := res.chain.IsEntryInProgress()
+		if checkErr == nil && !isEntryInProgress {
+			res.logger().Infof(
+				"relay entry already submitted; skipping submission",
+			)
+			return false, nil
+		}
+
+		return false, fmt.Errorf("relay entry submission would fail: [%v]", err)
+	}
+
+	return true, nil
+}
+
 // waitForSubmissionEligibility waits until the current member is eligible to
 // submit entry to the blockchain. First member is eligible to submit straight
 // away, each following member is eligible after pre-defined block step.
@@ -128,9 +223,8 @@ func (res *relayEntrySubmitter) waitForSubmissionEligibility(
 	blockWaitTime := (uint64(res.index) - 1) * blockStep
 
 	eligibleBlockHeight := startBlockHeight + blockWaitTime
-	logger.Infof(
-		"[member:%v] waiting for block [%v] to submit",
-		res.index,
+	res.logger().Infof(
+		"waiting for block [%v] to submit",
 		eligibleBlockHeight,
 	)
 
@@ -0,0 +1,128 @@
+package entry
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	relayChain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
+	"github.com/keep-network/keep-core/pkg/chain/local"
+	"github.com/keep-network/keep-core/pkg/gen/async"
+)
+
+func TestSubmitRelayEntry(t *testing.T) {
+	var tests = map[string]struct {
+		isEntryInProgress        bool
+		currentRequestStartBlock uint64
+		simulationError          error
+		expectedSubmissions      int
+		expectedError            error
+	}{
+		"entry awaited": {
+			isEntryInProgress:        true,
+			currentRequestStartBlock: 1,
+			expectedSubmissions:      1,
+		},
+		"entry already submitted": {
+			isEntryInProgress:        false,
+			currentRequestStartBlock: 0,
+			expectedSubmissions:      0,
+		},
+		"entry for another request in progress": {
+			isEntryInProgress:        true,
+			currentRequestStartBlock: 5,
+			expectedSubmissions:      0,
+		},
+		"entry would be rejected": {
+			isEntryInProgress:        true,
+			currentRequestStartBlock: 1,
+			simulationError:          fmt.Errorf("execution reverted"),
+			expectedSubmissions:      0,
+			expectedError: fmt.Errorf(
+				"relay entry submission would fail: [execution reverted]",
+			),
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			blockCounter, err := local.BlockCounter()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			chain := &mockRelayEntryChain{
+				isEntryInProgress:        test.isEntryInProgress,
+				currentRequestStartBlock: test.currentRequestStartBlock,
+				simulationError:          test.simulationError,
+			}
+
+			submitter := &relayEntrySubmitter{
+				chain:        chain,
+				blockCounter: blockCounter,
+				index:        1,
+			}
+
+			err = submitter.submitRelayEntry(
+				[]byte{0x01},
+				[]byte{0x02},
+				1,
+				make(chan uint64),
+				make(chan uint64),
+			)
+
+			if fmt.Sprint(test.expectedError) != fmt.Sprint(err) {
+				t.Errorf(
+					"unexpected error\nexpected: [%v]\nactual:   [%v]",
+					test.expectedError,
+					err,
+				)
+			}
+			if chain.submissions != test.expectedSubmissions {
+				t.Errorf(
+					"unexpected number of submissions\n"+
+						"expected: [%v]\nactual:   [%v]",
+					test.expectedSubmissions,
+					chain.submissions,
+				)
+			}
+		})
+	}
+}
+
+type mockRelayEntryChain struct {
+	relayChain.Interface
+
+	isEntryInProgress        bool
+	currentRequestStartBlock uint64
+	simulationError          error
+
+	submissions int
+}
+
+func (mrec *mockRelayEntryChain) GetConfig() *relayChain.Config {
+	return &relayChain.Config{ResultPublicationBlockStep: 1}
+}
+
+func (mrec *mockRelayEntryChain) IsEntryInProgress() (bool, error) {
+	return mrec.isEntryInProgress, nil
+}
+
+func (mrec *mockRelayEntryChain) CurrentRequestStartBlock() (*big.Int, error) {
+	return new(big.Int).SetUint64(mrec.currentRequestStartBlock), nil
+}
+
+func (mrec *mockRelayEntryChain) SimulateRelayEntry(entry []byte) error {
+	return mrec.simulationError
+}
+
+func (mrec *mockRelayEntryChain) SubmitRelayEntry(
+	entry []byte,
+) *async.EventEntrySubmittedPromise {
+	mrec.submissions++
+
+	promise := &async.EventEntrySubmittedPromise{}
+	go promise.Fulfill(&event.EntrySubmitted{BlockNumber: 1})
+	return promise
+}
@@ -38,6 +38,14 @@ func (gst *GroupSelectionTrack) Remove(entry string) {
 	delete(gst.Data, entry)
 }
 
+// Count returns the number of group selections currently tracked.
+func (gst *GroupSelectionTrack) Count() int {
+	gst.Mutex.Lock()
+	defer gst.Mutex.Unlock()
+
+	return len(gst.Data)
+}
+
 // RelayRequestTrack is used to track requests for new entries after RelayEntryRequested
 // event is received. It is used to ensure that the process execution
 // is not duplicated, i.e. when the client receives the same event multiple times.
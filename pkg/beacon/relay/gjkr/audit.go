@@ -0,0 +1,122 @@
+package gjkr
+
+import (
+	"github.com/keep-network/keep-core/pkg/beacon/relay/audit"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+	"github.com/keep-network/keep-core/pkg/net/ephemeral"
+)
+
+// auditor records decisions made by the member during the key generation in
+// the audit log.
+type auditor struct {
+	operator    string
+	channel     string
+	memberIndex group.MemberIndex
+	group       *group.Group
+
+	eliminations *audit.EliminationRecorder
+}
+
+func newAuditor(
+	operator string,
+	channel string,
+	memberIndex group.MemberIndex,
+	dkgGroup *group.Group,
+) *auditor {
+	return &auditor{
+		operator:    operator,
+		channel:     channel,
+		memberIndex: memberIndex,
+		group:       dkgGroup,
+		eliminations: audit.NewEliminationRecorder(
+			operator,
+			channel,
+			memberIndex,
+			dkgGroup,
+		),
+	}
+}
+
+// auditState records decisions made by the member when the given state has
+// been initiated. All protocol decisions of the member are made during the
+// initiation of states.
+func (a *auditor) auditState(initiatedState keyGenerationState, block uint64) {
+	a.eliminations.Record(block)
+
+	switch s := initiatedState.(type) {
+	case *commitmentsVerificationState:
+		if s.accusationsMessage != nil {
+			a.auditAccusationsRaised(s.accusationsMessage.accusedMembersKeys, block)
+		}
+	case *sharesJustificationState:
+		for _, message := range s.previousPhaseAccusationsMessages {
+			a.auditAccusationsResolved(
+				message.senderID,
+				message.accusedMembersKeys,
+				block,
+			)
+		}
+	case *pointsValidationState:
+		if s.accusationsMessage != nil {
+			a.auditAccusationsRaised(s.accusationsMessage.accusedMembersKeys, block)
+		}
+	case *pointsJustificationState:
+		for _, message := range s.previousPhaseMessages {
+			a.auditAccusationsResolved(
+				message.senderID,
+				message.accusedMembersKeys,
+				block,
+			)
+		}
+	}
+}
+
+func (a *auditor) auditAccusationsRaised(
+	accusedMembersKeys map[group.MemberIndex]*ephemeral.PrivateKey,
+	block uint64,
+) {
+	for accusedID := range accusedMembersKeys {
+		audit.Log(&audit.Record{
+			Event:    audit.AccusationRaised,
+			Block:    block,
+			Operator: a.operator,
+			Channel:  a.channel,
+			Member:   a.memberIndex,
+			Details: map[string]interface{}{
+				"accused": accusedID,
+			},
+		})
+	}
+}
+
+// auditAccusationsResolved records accusations raised by another member along
+// with the members disqualified as a result of resolving them.
+func (a *auditor) auditAccusationsResolved(
+	accuserID group.MemberIndex,
+	accusedMembersKeys map[group.MemberIndex]*ephemeral.PrivateKey,
+	block uint64,
+) {
+	for accusedID := range accusedMembersKeys {
+		// Member indexes are collected as integers as a slice of
+		// group.MemberIndex is marshaled to JSON as a base64 string.
+		eliminated := []int{}
+		for _, memberID := range []group.MemberIndex{accuserID, accusedID} {
+			if !a.group.IsOperating(memberID) {
+				eliminated = append(eliminated, int(memberID))
+			}
+		}
+
+		audit.Log(&audit.Record{
+			Event:    audit.AccusationResolved,
+			Block:    block,
+			Operator: a.operator,
+			Channel:  a.channel,
+			Member:   a.memberIndex,
+			Details: map[string]interface{}{
+				"accuser":    accuserID,
+				"accused":    accusedID,
+				"eliminated": eliminated,
+			},
+		})
+	}
+}
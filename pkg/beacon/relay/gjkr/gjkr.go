@@ -1,15 +1,20 @@
 package gjkr
 
 import (
+	"context"
 	"fmt"
 	"math/big"
 
 	"github.com/ipfs/go-log"
+	"github.com/keep-network/keep-common/pkg/persistence"
 
+	"github.com/keep-network/keep-core/pkg/beacon/relay/audit"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/state"
 	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/logging"
 	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/tracing"
 )
 
 var logger = log.Logger("keep-gjkr")
@@ -48,14 +53,50 @@ func RegisterUnmarshallers(channel net.BroadcastChannel) {
 	})
 }
 
+// ExecuteOption is an optional setting of the protocol execution.
+type ExecuteOption func(*executeOptions)
+
+type executeOptions struct {
+	transcripts           persistence.Handle
+	randomness            randomSource
+	stateMachineObservers []func(stateMachine *state.Machine)
+}
+
+// WithTranscriptRecording records the transcript of the execution with the
+// given persistence handle once the execution is over. The transcript
+// contains secret material, so the handle should encrypt it.
+func WithTranscriptRecording(handle persistence.Handle) ExecuteOption {
+	return func(options *executeOptions) {
+		options.transcripts = handle
+	}
+}
+
+func withRandomSource(randomness randomSource) ExecuteOption {
+	return func(options *executeOptions) {
+		options.randomness = randomness
+	}
+}
+
+func withStateMachineObserver(
+	observer func(stateMachine *state.Machine),
+) ExecuteOption {
+	return func(options *executeOptions) {
+		options.stateMachineObservers = append(
+			options.stateMachineObservers,
+			observer,
+		)
+	}
+}
+
 // Execute runs the GJKR distributed key generation  protocol, given a
 // broadcast channel to mediate with, a block counter used for time tracking,
 // a player index to use in the group, dishonest threshold, and block height
 // when DKG protocol should start.
 // If the generation is successful, it returns a threshold group member which
-// can participate in the signing group; if the generation fails, it returns an
-// error.
+// can participate in the signing group; if the generation fails or the
+// context is done before it completes, it returns an error.
 func Execute(
+	ctx context.Context,
 	memberIndex group.MemberIndex,
 	groupSize int,
 	blockCounter chain.BlockCounter,
@@ -64,8 +105,20 @@ func Execute(
 	seed *big.Int,
 	membershipValidator group.MembershipValidator,
 	startBlockHeight uint64,
+	options ...ExecuteOption,
 ) (*Result, uint64, error) {
-	logger.Debugf("[member:%v] initializing member", memberIndex)
+	ctx = logging.WithFields(ctx, logging.Fields{
+		logging.MemberField:  memberIndex,
+		logging.ChannelField: channel.Name(),
+	})
+	memberLogger := logging.ForContext(ctx, logger)
+
+	memberLogger.Debugf("initializing member")
+
+	executeOptions := &executeOptions{}
+	for _, option := range options {
+		option(executeOptions)
+	}
 
 	member, err := NewMember(
 		memberIndex,
@@ -78,14 +131,79 @@ func Execute(
 		return nil, 0, fmt.Errorf("cannot create a new member: [%v]", err)
 	}
 
+	member.contextLogger = memberLogger
+
+	if executeOptions.randomness != nil {
+		member.randomness = executeOptions.randomness
+	}
+
+	var recorder *transcriptRecorder
+	if executeOptions.transcripts != nil {
+		recorder = newTranscriptRecorder(
+			channel.Name(),
+			memberIndex,
+			groupSize,
+			dishonestThreshold,
+			seed,
+			startBlockHeight,
+			member.randomSource(),
+			membershipValidator,
+			blockCounter,
+			memberLogger,
+		)
+		member.randomness = recorder
+		member.membershipValidator = recorder
+	}
+
 	initialState := &ephemeralKeyPairGenerationState{
 		channel: channel,
 		member:  member.InitializeEphemeralKeysGeneration(),
 	}
 
 	stateMachine := state.NewMachine(channel, blockCounter, initialState)
+	stateMachine.OnStateInitiated(newAuditor(
+		audit.OperatorFrom(ctx),
+		channel.Name(),
+		memberIndex,
+		member.group,
+	).auditState)
+
+	if recorder != nil {
+		recorder.observe(stateMachine)
+	}
+	for _, observer := range executeOptions.stateMachineObservers {
+		observer(stateMachine)
+	}
+
+	ctx, span := tracing.Start(ctx, "gjkr", map[string]interface{}{
+		"member":      memberIndex,
+		"channel":     channel.Name(),
+		"start_block": startBlockHeight,
+	})
+	endStateSpans := state.TraceStates(ctx, stateMachine)
 
-	lastState, endBlockHeight, err := stateMachine.Execute(startBlockHeight)
+	result, endBlockHeight, err := executeStateMachine(
+		ctx,
+		stateMachine,
+		startBlockHeight,
+	)
+
+	endStateSpans(err)
+	span.End(err)
+
+	if recorder != nil {
+		recorder.save(executeOptions.transcripts, result, err)
+	}
+
+	return result, endBlockHeight, err
+}
+
+func executeStateMachine(
+	ctx context.Context,
+	stateMachine *state.Machine,
+	startBlockHeight uint64,
+) (*Result, uint64, error) {
+	lastState, endBlockHeight, err := stateMachine.Execute(ctx, startBlockHeight)
 	if err != nil {
 		return nil, 0, err
 	}
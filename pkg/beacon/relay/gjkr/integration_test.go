@@ -6,6 +6,7 @@ import (
 	"math/big"
 	"sync"
 	"testing"
+	"time"
 
 	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
 	"github.com/keep-network/keep-core/pkg/altbn128"
@@ -14,6 +15,7 @@ import (
 	"github.com/keep-network/keep-core/pkg/internal/dkgtest"
 	"github.com/keep-network/keep-core/pkg/net"
 	"github.com/keep-network/keep-core/pkg/net/ephemeral"
+	"github.com/keep-network/keep-core/pkg/net/local"
 )
 
 func TestExecute_HappyPath(t *testing.T) {
@@ -40,6 +42,94 @@ func TestExecute_HappyPath(t *testing.T) {
 	dkgtest.AssertValidGroupPublicKey(t, result)
 }
 
+func TestExecute_UnderNetworkConditions(t *testing.T) {
+	t.Parallel()
+
+	groupSize := 5
+	honestThreshold := 3
+	seed := dkgtest.RandomSeed(t)
+
+	interceptor := func(msg net.TaggedMarshaler) net.TaggedMarshaler {
+		return msg
+	}
+
+	linkModel := local.LinkModel{
+		Default: local.Link{
+			Latency:       local.UniformLatency(10*time.Millisecond, 100*time.Millisecond),
+			DropRate:      0.2,
+			DuplicateRate: 0.1,
+			ReorderRate:   0.1,
+		},
+		Seed: 4182,
+	}
+
+	result, err := dkgtest.RunTestUnderNetworkConditions(
+		groupSize,
+		honestThreshold,
+		seed,
+		interceptor,
+		dkgtest.SameLinkModel(linkModel),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Messages are retransmitted, so it is very unlikely that all copies of
+	// a message get dropped. The order in which members send messages is not
+	// deterministic though, so neither is the outcome of the simulation, and
+	// a member could still be marked as misbehaving. The protocol has to
+	// succeed anyway.
+	dkgtest.AssertDkgResultPublished(t, result)
+	dkgtest.AssertMinimumSuccessfulSignersCount(t, result, honestThreshold)
+	dkgtest.AssertSamePublicKey(t, result)
+	dkgtest.AssertValidGroupPublicKey(t, result)
+}
+
+func TestExecute_PartitionedMember(t *testing.T) {
+	t.Parallel()
+
+	groupSize := 5
+	honestThreshold := 3
+	seed := dkgtest.RandomSeed(t)
+
+	interceptor := func(msg net.TaggedMarshaler) net.TaggedMarshaler {
+		return msg
+	}
+
+	// Member 5 does not receive any messages from other members during the
+	// whole protocol execution and the other members do not receive any
+	// messages from member 5.
+	partitionedMember := group.MemberIndex(5)
+	linkModels := func(
+		memberIndex group.MemberIndex,
+		memberIDs []string,
+	) local.LinkModel {
+		partition := local.Partition{Start: 0, End: time.Hour}
+		if memberIndex != partitionedMember {
+			partition.Peers = []string{memberIDs[partitionedMember-1]}
+		}
+
+		return local.LinkModel{Partitions: []local.Partition{partition}}
+	}
+
+	result, err := dkgtest.RunTestUnderNetworkConditions(
+		groupSize,
+		honestThreshold,
+		seed,
+		interceptor,
+		linkModels,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dkgtest.AssertDkgResultPublished(t, result)
+	dkgtest.AssertSuccessfulSigners(t, result, []group.MemberIndex{1, 2, 3, 4}...)
+	dkgtest.AssertSamePublicKey(t, result)
+	dkgtest.AssertMisbehavingMembers(t, result, partitionedMember)
+	dkgtest.AssertValidGroupPublicKey(t, result)
+}
+
 func TestExecute_IA_member1_phase1(t *testing.T) {
 	t.Parallel()
 
@@ -4,7 +4,9 @@ import (
 	"math/big"
 
 	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"github.com/ipfs/go-log"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+	"github.com/keep-network/keep-core/pkg/logging"
 	"github.com/keep-network/keep-core/pkg/net/ephemeral"
 )
 
@@ -25,6 +27,36 @@ type memberCore struct {
 
 	// Cryptographic protocol parameters, the same for all members in the group.
 	protocolParameters *protocolParameters
+
+	// Source of the random inputs of this member to the protocol.
+	randomness randomSource
+
+	// Logger annotated with fields of the protocol execution context.
+	contextLogger *log.ZapEventLogger
+}
+
+// randomSource returns the source of the random inputs of this member. It
+// defaults to a cryptographically secure one if none has been set.
+func (mc *memberCore) randomSource() randomSource {
+	if mc.randomness == nil {
+		return &cryptoRandomSource{}
+	}
+
+	return mc.randomness
+}
+
+// logger returns the logger of this member. It defaults to the package logger
+// annotated with the member index if the member has no execution context
+// logger set.
+func (mc *memberCore) logger() *log.ZapEventLogger {
+	if mc.contextLogger == nil {
+		return logging.WithLogFields(
+			logger,
+			logging.Fields{logging.MemberField: mc.ID},
+		)
+	}
+
+	return mc.contextLogger
 }
 
 // LocalMember represents one member in a threshold group, prior to the
@@ -238,6 +270,8 @@ func NewMember(
 			membershipValidator,
 			newDkgEvidenceLog(),
 			newProtocolParameters(seed),
+			&cryptoRandomSource{},
+			nil,
 		},
 	}, nil
 }
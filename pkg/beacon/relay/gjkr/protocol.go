@@ -38,7 +38,9 @@ func (em *EphemeralKeyPairGeneratingMember) GenerateEphemeralKeyPair() (
 			continue
 		}
 
-		ephemeralKeyPair, err := ephemeral.GenerateKeyPair()
+		ephemeralKeyPair, err := em.randomSource().generateEphemeralKeyPair(
+			member,
+		)
 		if err != nil {
 			return nil, err
 		}
@@ -70,13 +72,15 @@ func (sm *SymmetricKeyGeneratingMember) GenerateSymmetricKeys(
 		otherMember := ephemeralPubKeyMessage.senderID
 
 		if !sm.isValidEphemeralPublicKeyMessage(ephemeralPubKeyMessage) {
-			logger.Warningf(
-				"[member:%v] member [%v] disqualified because of "+
+			sm.logger().Warningf(
+				"member [%v] disqualified because of "+
 					"sending invalid ephemeral public key message",
-				sm.ID,
 				otherMember,
 			)
-			sm.group.MarkMemberAsDisqualified(otherMember)
+			sm.group.MarkMemberAsDisqualified(
+				otherMember,
+				"sent invalid ephemeral public key message",
+			)
 			continue
 		}
 
@@ -130,10 +134,9 @@ func (sm *SymmetricKeyGeneratingMember) isValidEphemeralPublicKeyMessage(
 		}
 
 		if _, ok := message.ephemeralPublicKeys[memberID]; !ok {
-			logger.Warningf(
-				"[member:%v] ephemeral public key message from member [%v] "+
+			sm.logger().Warningf(
+				"ephemeral public key message from member [%v] "+
 					"does not contain public key for member [%v]",
-				sm.ID,
 				message.senderID,
 				memberID,
 			)
@@ -161,14 +164,14 @@ func (cm *CommittingMember) CalculateMembersSharesAndCommitments() (
 	error,
 ) {
 	polynomialDegree := cm.group.DishonestThreshold()
-	coefficientsA, err := generatePolynomial(polynomialDegree)
+	coefficientsA, err := cm.randomSource().generatePolynomial(polynomialDegree)
 	if err != nil {
 		return nil, nil, fmt.Errorf(
 			"could not generate shares polynomial [%v]",
 			err,
 		)
 	}
-	coefficientsB, err := generatePolynomial(polynomialDegree)
+	coefficientsB, err := cm.randomSource().generatePolynomial(polynomialDegree)
 	if err != nil {
 		return nil, nil, fmt.Errorf(
 			"could not generate hiding polynomial [%v]",
@@ -327,13 +330,15 @@ func (cvm *CommitmentsVerifyingMember) VerifyReceivedSharesAndCommitmentsMessage
 	accusedMembersKeys := make(map[group.MemberIndex]*ephemeral.PrivateKey)
 	for _, commitmentsMessage := range commitmentsMessages {
 		if !cvm.isValidMemberCommitmentsMessage(commitmentsMessage) {
-			logger.Warningf(
-				"[member:%v] member [%v] disqualified because of "+
+			cvm.logger().Warningf(
+				"member [%v] disqualified because of "+
 					"sending invalid member commitments message",
-				cvm.ID,
 				commitmentsMessage.senderID,
 			)
-			cvm.group.MarkMemberAsDisqualified(commitmentsMessage.senderID)
+			cvm.group.MarkMemberAsDisqualified(
+				commitmentsMessage.senderID,
+				"sent invalid member commitments message",
+			)
 			continue
 		}
 
@@ -347,13 +352,15 @@ func (cvm *CommitmentsVerifyingMember) VerifyReceivedSharesAndCommitmentsMessage
 				sharesMessageFound = true
 
 				if !cvm.isValidPeerSharesMessage(sharesMessage) {
-					logger.Warningf(
-						"[member:%v] member [%v] disqualified because of "+
+					cvm.logger().Warningf(
+						"member [%v] disqualified because of "+
 							"sending invalid peer shares message",
-						cvm.ID,
 						sharesMessage.senderID,
 					)
-					cvm.group.MarkMemberAsDisqualified(sharesMessage.senderID)
+					cvm.group.MarkMemberAsDisqualified(
+						sharesMessage.senderID,
+						"sent invalid peer shares message",
+					)
 					break
 				}
 
@@ -385,13 +392,15 @@ func (cvm *CommitmentsVerifyingMember) VerifyReceivedSharesAndCommitmentsMessage
 					symmetricKey,
 				)
 				if err != nil {
-					logger.Warningf(
-						"[member:%v] member [%v] disqualified because "+
+					cvm.logger().Warningf(
+						"member [%v] disqualified because "+
 							"could not decrypt shares received from them",
-						cvm.ID,
 						sharesMessage.senderID,
 					)
-					cvm.group.MarkMemberAsDisqualified(sharesMessage.senderID)
+					cvm.group.MarkMemberAsDisqualified(
+						sharesMessage.senderID,
+						"sent shares which could not be decrypted",
+					)
 					accusedMembersKeys[sharesMessage.senderID] =
 						cvm.ephemeralKeyPairs[sharesMessage.senderID].PrivateKey
 					break
@@ -403,13 +412,15 @@ func (cvm *CommitmentsVerifyingMember) VerifyReceivedSharesAndCommitmentsMessage
 					commitmentsMessage.commitments, // C_j
 					cvm.ID,                         // i
 				) {
-					logger.Warningf(
-						"[member:%v] shares from member [%v] invalid against "+
+					cvm.logger().Warningf(
+						"shares from member [%v] invalid against "+
 							"commitments; disqualifying and accusing the member",
-						cvm.ID,
 						commitmentsMessage.senderID,
 					)
-					cvm.group.MarkMemberAsDisqualified(commitmentsMessage.senderID)
+					cvm.group.MarkMemberAsDisqualified(
+						commitmentsMessage.senderID,
+						"sent shares invalid against commitments",
+					)
 					accusedMembersKeys[commitmentsMessage.senderID] =
 						cvm.ephemeralKeyPairs[commitmentsMessage.senderID].PrivateKey
 					break
@@ -443,10 +454,9 @@ func (cvm *CommitmentsVerifyingMember) isValidMemberCommitmentsMessage(
 	// constant coefficient. It implicates the same count of commitments.
 	expectedCommitmentsCount := cvm.group.DishonestThreshold() + 1
 	if len(message.commitments) != expectedCommitmentsCount {
-		logger.Warningf(
-			"[member:%v] member [%v] sent a message with a wrong number "+
+		cvm.logger().Warningf(
+			"member [%v] sent a message with a wrong number "+
 				"of commitments: [%v] instead of expected [%v]",
-			cvm.ID,
 			message.senderID,
 			len(message.commitments),
 			expectedCommitmentsCount,
@@ -469,10 +479,9 @@ func (cvm *CommitmentsVerifyingMember) isValidPeerSharesMessage(
 		}
 
 		if _, ok := message.shares[memberID]; !ok {
-			logger.Warningf(
-				"[member:%v] peer shares message from member [%v] does not "+
+			cvm.logger().Warningf(
+				"peer shares message from member [%v] does not "+
 					"contain shares for member [%v]",
-				cvm.ID,
 				message.senderID,
 				memberID,
 			)
@@ -567,7 +576,10 @@ func (sjm *SharesJustifyingMember) ResolveSecretSharesAccusationsMessages(
 				// or the accussed member ID is not valid.
 				// Mark the accuser as disqualified immediately,
 				// as each member consider itself as a honest participant.
-				sjm.group.MarkMemberAsDisqualified(accuserID)
+				sjm.group.MarkMemberAsDisqualified(
+					accuserID,
+					fmt.Sprintf("accused member [%v] considered honest or not valid", accusedID),
+				)
 				sjm.discardReceivedShares(accuserID)
 				continue
 			}
@@ -596,13 +608,15 @@ func (sjm *SharesJustifyingMember) ResolveSecretSharesAccusationsMessages(
 			}
 
 			if !accuserPublicKey.IsKeyMatching(revealedAccuserPrivateKey) {
-				logger.Warningf(
-					"[member:%v] member [%v] disqualified because of "+
+				sjm.logger().Warningf(
+					"member [%v] disqualified because of "+
 						"revealing private key not matching the public key",
-					sjm.ID,
 					accuserID,
 				)
-				sjm.group.MarkMemberAsDisqualified(accuserID)
+				sjm.group.MarkMemberAsDisqualified(
+					accuserID,
+					"revealed private key not matching the public key",
+				)
 				sjm.discardReceivedShares(accuserID)
 				continue
 			}
@@ -632,15 +646,17 @@ func (sjm *SharesJustifyingMember) ResolveSecretSharesAccusationsMessages(
 				accuserID,
 			)
 			if accusedPublicKey == nil {
-				logger.Warningf(
-					"[member:%v] member [%v] disqualified because could not "+
+				sjm.logger().Warningf(
+					"member [%v] disqualified because could not "+
 						"recover symmetric key; accused member [%v] is already "+
 						"marked as inactive or disqualified ",
-					sjm.ID,
 					accuserID,
 					accusedID,
 				)
-				sjm.group.MarkMemberAsDisqualified(accuserID)
+				sjm.group.MarkMemberAsDisqualified(
+					accuserID,
+					fmt.Sprintf("accused member [%v] already marked as inactive or disqualified", accusedID),
+				)
 				sjm.discardReceivedShares(accuserID)
 				continue
 			}
@@ -655,15 +671,17 @@ func (sjm *SharesJustifyingMember) ResolveSecretSharesAccusationsMessages(
 			// accusation.
 			accusedSharesMessage := sjm.evidenceLog.peerSharesMessage(accusedID)
 			if accusedSharesMessage == nil {
-				logger.Warningf(
-					"[member:%v] member [%v] disqualified because could not "+
+				sjm.logger().Warningf(
+					"member [%v] disqualified because could not "+
 						"get peer shares message from evidence log; "+
 						"accused member [%v] is already marked as inactive",
-					sjm.ID,
 					accuserID,
 					accusedID,
 				)
-				sjm.group.MarkMemberAsDisqualified(accuserID)
+				sjm.group.MarkMemberAsDisqualified(
+					accuserID,
+					fmt.Sprintf("accused member [%v] which did not send peer shares", accusedID),
+				)
 				sjm.discardReceivedShares(accuserID)
 				continue
 			}
@@ -679,14 +697,16 @@ func (sjm *SharesJustifyingMember) ResolveSecretSharesAccusationsMessages(
 				symmetricKey,
 			)
 			if err != nil {
-				logger.Warningf(
-					"[member:%v] member [%v] disqualified because of sending "+
+				sjm.logger().Warningf(
+					"member [%v] disqualified because of sending "+
 						"to member [%v] shares that could not be decrypted",
-					sjm.ID,
 					accusedID,
 					accuserID,
 				)
-				sjm.group.MarkMemberAsDisqualified(accusedID)
+				sjm.group.MarkMemberAsDisqualified(
+					accusedID,
+					fmt.Sprintf("sent to member [%v] shares which could not be decrypted", accuserID),
+				)
 				sjm.discardReceivedShares(accusedID)
 				continue
 			}
@@ -696,24 +716,28 @@ func (sjm *SharesJustifyingMember) ResolveSecretSharesAccusationsMessages(
 				sjm.receivedPeerCommitments[accusedID], // C_m
 				accuserID,                              // j
 			) {
-				logger.Warningf(
-					"[member:%v] member [%v] disqualified because of "+
+				sjm.logger().Warningf(
+					"member [%v] disqualified because of "+
 						"false accusation against member [%v] ",
-					sjm.ID,
 					accuserID,
 					accusedID,
 				)
-				sjm.group.MarkMemberAsDisqualified(accuserID)
+				sjm.group.MarkMemberAsDisqualified(
+					accuserID,
+					fmt.Sprintf("false accusation against member [%v]", accusedID),
+				)
 				sjm.discardReceivedShares(accuserID)
 			} else {
-				logger.Warningf(
-					"[member:%v] member [%v] disqualified because of "+
+				sjm.logger().Warningf(
+					"member [%v] disqualified because of "+
 						"confirmed misbehaviour against member [%v] ",
-					sjm.ID,
 					accusedID,
 					accuserID,
 				)
-				sjm.group.MarkMemberAsDisqualified(accusedID)
+				sjm.group.MarkMemberAsDisqualified(
+					accusedID,
+					fmt.Sprintf("confirmed misbehaviour against member [%v]", accuserID),
+				)
 				sjm.discardReceivedShares(accusedID)
 			}
 		}
@@ -846,13 +870,15 @@ func (sm *SharingMember) VerifyPublicKeySharePoints(
 	// where: j is sender's ID, i is current member ID, T is dishonest threshold.
 	for _, message := range messages {
 		if !sm.isValidMemberPublicKeySharePointsMessage(message) {
-			logger.Warningf(
-				"[member:%v] member [%v] disqualified because of "+
+			sm.logger().Warningf(
+				"member [%v] disqualified because of "+
 					"sending invalid member public key share points message",
-				sm.ID,
 				message.senderID,
 			)
-			sm.group.MarkMemberAsDisqualified(message.senderID)
+			sm.group.MarkMemberAsDisqualified(
+				message.senderID,
+				"sent invalid member public key share points message",
+			)
 			continue
 		}
 
@@ -861,13 +887,15 @@ func (sm *SharingMember) VerifyPublicKeySharePoints(
 			sm.receivedQualifiedSharesS[message.senderID],
 			message.publicKeySharePoints,
 		) {
-			logger.Warningf(
-				"[member:%v] member [%v] disqualified because of "+
+			sm.logger().Warningf(
+				"member [%v] disqualified because of "+
 					"invalid public key share points",
-				sm.ID,
 				message.senderID,
 			)
-			sm.group.MarkMemberAsDisqualified(message.senderID)
+			sm.group.MarkMemberAsDisqualified(
+				message.senderID,
+				"sent invalid public key share points",
+			)
 			accusedMembersKeys[message.senderID] = sm.ephemeralKeyPairs[message.senderID].PrivateKey
 			continue
 		}
@@ -893,10 +921,9 @@ func (sm *SharingMember) isValidMemberPublicKeySharePointsMessage(
 	// public key share points.
 	expectedPointsCount := sm.group.DishonestThreshold() + 1
 	if len(message.publicKeySharePoints) != expectedPointsCount {
-		logger.Warningf(
-			"[member:%v] member [%v] sent a message with a wrong number "+
+		sm.logger().Warningf(
+			"member [%v] sent a message with a wrong number "+
 				"of public key share points: [%v] instead of expected [%v]",
-			sm.ID,
 			message.senderID,
 			len(message.publicKeySharePoints),
 			expectedPointsCount,
@@ -1004,7 +1031,10 @@ func (pjm *PointsJustifyingMember) ResolvePublicKeySharePointsAccusationsMessage
 				// or the accussed member ID is not valid.
 				// Mark the accuser as disqualified immediately,
 				// as each member consider itself as a honest participant.
-				pjm.group.MarkMemberAsDisqualified(accuserID)
+				pjm.group.MarkMemberAsDisqualified(
+					accuserID,
+					fmt.Sprintf("accused member [%v] considered honest or not valid", accusedID),
+				)
 				continue
 			}
 
@@ -1034,13 +1064,15 @@ func (pjm *PointsJustifyingMember) ResolvePublicKeySharePointsAccusationsMessage
 			}
 
 			if !accuserPublicKey.IsKeyMatching(revealedAccuserPrivateKey) {
-				logger.Warningf(
-					"[member:%v] member [%v] disqualified because of "+
+				pjm.logger().Warningf(
+					"member [%v] disqualified because of "+
 						"revealing private key not matching the public key",
-					pjm.ID,
 					accuserID,
 				)
-				pjm.group.MarkMemberAsDisqualified(accuserID)
+				pjm.group.MarkMemberAsDisqualified(
+					accuserID,
+					"revealed private key not matching the public key",
+				)
 				continue
 			}
 
@@ -1069,15 +1101,17 @@ func (pjm *PointsJustifyingMember) ResolvePublicKeySharePointsAccusationsMessage
 				accuserID,
 			)
 			if accusedPublicKey == nil {
-				logger.Warningf(
-					"[member:%v] member [%v] disqualified because could not "+
+				pjm.logger().Warningf(
+					"member [%v] disqualified because could not "+
 						"recover symmetric key; accused member [%v] is already "+
 						"marked as inactive or disqualified ",
-					pjm.ID,
 					accuserID,
 					accusedID,
 				)
-				pjm.group.MarkMemberAsDisqualified(accuserID)
+				pjm.group.MarkMemberAsDisqualified(
+					accuserID,
+					fmt.Sprintf("accused member [%v] already marked as inactive or disqualified", accusedID),
+				)
 				continue
 			}
 			recoveredSymmetricKey := revealedAccuserPrivateKey.Ecdh(accusedPublicKey)
@@ -1091,15 +1125,17 @@ func (pjm *PointsJustifyingMember) ResolvePublicKeySharePointsAccusationsMessage
 			// accusation.
 			accusedSharesMessage := evidenceLog.peerSharesMessage(accusedID)
 			if accusedSharesMessage == nil {
-				logger.Warningf(
-					"[member:%v] member [%v] disqualified because could not "+
+				pjm.logger().Warningf(
+					"member [%v] disqualified because could not "+
 						"get peer shares message from evidence log; "+
 						"accused member [%v] is already marked as inactive",
-					pjm.ID,
 					accuserID,
 					accusedID,
 				)
-				pjm.group.MarkMemberAsDisqualified(accuserID)
+				pjm.group.MarkMemberAsDisqualified(
+					accuserID,
+					fmt.Sprintf("accused member [%v] which did not send peer shares", accusedID),
+				)
 				continue
 			}
 
@@ -1116,17 +1152,22 @@ func (pjm *PointsJustifyingMember) ResolvePublicKeySharePointsAccusationsMessage
 				recoveredSymmetricKey,
 			)
 			if err != nil {
-				logger.Warningf(
-					"[member:%v] member [%v] disqualified because of sending "+
+				pjm.logger().Warningf(
+					"member [%v] disqualified because of sending "+
 						"shares that could not be decrypted; "+
 						"member [%v] disqualified because did not complain "+
 						"about invalid shares earlier",
-					pjm.ID,
 					accusedID,
 					accuserID,
 				)
-				pjm.group.MarkMemberAsDisqualified(accuserID)
-				pjm.group.MarkMemberAsDisqualified(accusedID)
+				pjm.group.MarkMemberAsDisqualified(
+					accuserID,
+					fmt.Sprintf("did not complain about invalid shares of member [%v] earlier", accusedID),
+				)
+				pjm.group.MarkMemberAsDisqualified(
+					accusedID,
+					fmt.Sprintf("sent to member [%v] shares which could not be decrypted", accuserID),
+				)
 				continue
 			}
 
@@ -1135,23 +1176,27 @@ func (pjm *PointsJustifyingMember) ResolvePublicKeySharePointsAccusationsMessage
 				shareS,
 				pjm.receivedValidPeerPublicKeySharePoints[accusedID],
 			) {
-				logger.Warningf(
-					"[member:%v] member [%v] disqualified because of "+
+				pjm.logger().Warningf(
+					"member [%v] disqualified because of "+
 						"false accusation against member [%v] ",
-					pjm.ID,
 					accuserID,
 					accusedID,
 				)
-				pjm.group.MarkMemberAsDisqualified(accuserID)
+				pjm.group.MarkMemberAsDisqualified(
+					accuserID,
+					fmt.Sprintf("false accusation against member [%v]", accusedID),
+				)
 			} else {
-				logger.Warningf(
-					"[member:%v] member [%v] disqualified because of "+
+				pjm.logger().Warningf(
+					"member [%v] disqualified because of "+
 						"confirmed misbehaviour against member [%v] ",
-					pjm.ID,
 					accusedID,
 					accuserID,
 				)
-				pjm.group.MarkMemberAsDisqualified(accusedID)
+				pjm.group.MarkMemberAsDisqualified(
+					accusedID,
+					fmt.Sprintf("confirmed misbehaviour against member [%v]", accuserID),
+				)
 			}
 		}
 	}
@@ -1246,13 +1291,15 @@ func (rm *ReconstructingMember) ReconstructMisbehavedIndividualKeys(
 		// Validate received message. If message is invalid, sender should
 		// be considered as misbehaving and marked as disqualified.
 		if !rm.isValidMisbehavedEphemeralKeysMessage(message) {
-			logger.Warningf(
-				"[member:%v] member [%v] disqualified because of "+
+			rm.logger().Warningf(
+				"member [%v] disqualified because of "+
 					"sending invalid misbehaved ephemeral keys message",
-				rm.ID,
 				message.senderID,
 			)
-			rm.group.MarkMemberAsDisqualified(message.senderID)
+			rm.group.MarkMemberAsDisqualified(
+				message.senderID,
+				"sent invalid misbehaved ephemeral keys message",
+			)
 		}
 	}
 
@@ -1345,7 +1392,10 @@ func (rm *ReconstructingMember) recoverMisbehavedShares(
 				// Mark the revealing member as disqualified immediately,
 				// as each member consider itself as a honest participant.
 				// Continue as there is no sense to recover own shares.
-				rm.group.MarkMemberAsDisqualified(revealingMemberID)
+				rm.group.MarkMemberAsDisqualified(
+					revealingMemberID,
+					fmt.Sprintf("revealed private key of member [%v] considered honest", misbehavedMemberID),
+				)
 				continue
 			}
 
@@ -1383,13 +1433,15 @@ func (rm *ReconstructingMember) recoverMisbehavedShares(
 			}
 
 			if !revealingMemberPublicKey.IsKeyMatching(revealedPrivateKey) {
-				logger.Warningf(
-					"[member:%v] member [%v] disqualified because of "+
+				rm.logger().Warningf(
+					"member [%v] disqualified because of "+
 						"revealing private key not matching the public key",
-					rm.ID,
 					revealingMemberID,
 				)
-				rm.group.MarkMemberAsDisqualified(revealingMemberID)
+				rm.group.MarkMemberAsDisqualified(
+					revealingMemberID,
+					"revealed private key not matching the public key",
+				)
 				continue
 			}
 
@@ -1422,15 +1474,17 @@ func (rm *ReconstructingMember) recoverMisbehavedShares(
 				revealingMemberID,
 			)
 			if misbehavedMemberPublicKey == nil {
-				logger.Warningf(
-					"[member:%v] member [%v] disqualified because could not "+
+				rm.logger().Warningf(
+					"member [%v] disqualified because could not "+
 						"recover symmetric key; misbehaved member [%v] is "+
 						"already marked as inactive or disqualified in phase 2",
-					rm.ID,
 					revealingMemberID,
 					misbehavedMemberID,
 				)
-				rm.group.MarkMemberAsDisqualified(revealingMemberID)
+				rm.group.MarkMemberAsDisqualified(
+					revealingMemberID,
+					fmt.Sprintf("revealed private key of member [%v] already marked as inactive or disqualified", misbehavedMemberID),
+				)
 				continue
 			}
 			recoveredSymmetricKey := revealedPrivateKey.Ecdh(misbehavedMemberPublicKey)
@@ -1445,13 +1499,15 @@ func (rm *ReconstructingMember) recoverMisbehavedShares(
 			// disqualified in phase 4 does not belong to QUAL set.
 			misbehavedMemberSharesMessage := rm.evidenceLog.peerSharesMessage(misbehavedMemberID)
 			if misbehavedMemberSharesMessage == nil {
-				logger.Warningf(
-					"[member:%v] member [%v] disqualified because of revealing "+
+				rm.logger().Warningf(
+					"member [%v] disqualified because of revealing "+
 						"private key of a member which did not provide shares in phase 3",
-					rm.ID,
 					revealingMemberID,
 				)
-				rm.group.MarkMemberAsDisqualified(revealingMemberID)
+				rm.group.MarkMemberAsDisqualified(
+					revealingMemberID,
+					fmt.Sprintf("revealed private key of member [%v] which did not send peer shares", misbehavedMemberID),
+				)
 				continue
 			}
 
@@ -1466,15 +1522,17 @@ func (rm *ReconstructingMember) recoverMisbehavedShares(
 				recoveredSymmetricKey,
 			)
 			if err != nil {
-				logger.Warningf(
-					"[member:%v] member [%v] disqualified because of not "+
+				rm.logger().Warningf(
+					"member [%v] disqualified because of not "+
 						"reporting protocol violation in phase 3 by member [%v] - "+
 						"shares can not be decrypted",
-					rm.ID,
 					revealingMemberID,
 					misbehavedMemberID,
 				)
-				rm.group.MarkMemberAsDisqualified(revealingMemberID)
+				rm.group.MarkMemberAsDisqualified(
+					revealingMemberID,
+					fmt.Sprintf("did not report undecryptable shares of member [%v]", misbehavedMemberID),
+				)
 				continue
 			}
 
@@ -1492,15 +1550,17 @@ func (rm *ReconstructingMember) recoverMisbehavedShares(
 				// key has been revealed as disqualified earlier, in phase 5.
 				// Not reporting misbehavior is also a protocol violation, so we
 				// disqualify the revealing member.
-				logger.Warningf(
-					"[member:%v] member [%v] disqualified because of not "+
+				rm.logger().Warningf(
+					"member [%v] disqualified because of not "+
 						"reporting protocol violation in phase 3 by member [%v] - "+
 						"shares are inconsistent",
-					rm.ID,
 					revealingMemberID,
 					misbehavedMemberID,
 				)
-				rm.group.MarkMemberAsDisqualified(revealingMemberID)
+				rm.group.MarkMemberAsDisqualified(
+					revealingMemberID,
+					fmt.Sprintf("did not report inconsistent shares of member [%v]", misbehavedMemberID),
+				)
 			}
 		}
 	}
@@ -1524,10 +1584,9 @@ func (rm *ReconstructingMember) isValidMisbehavedEphemeralKeysMessage(
 		}
 
 		if !isKeyForMemberRevealed {
-			logger.Warningf(
-				"[member:%v] member [%v] sent message which does not "+
+			rm.logger().Warningf(
+				"member [%v] sent message which does not "+
 					"reveal private key of inactive/disqualified QUAL member [%v]",
-				rm.ID,
 				message.senderID,
 				memberForReconstruction,
 			)
@@ -1537,10 +1596,9 @@ func (rm *ReconstructingMember) isValidMisbehavedEphemeralKeysMessage(
 
 	for memberID := range message.privateKeys {
 		if rm.group.IsOperating(memberID) {
-			logger.Warningf(
-				"[member:%v] member [%v] sent message which reveals "+
+			rm.logger().Warningf(
+				"member [%v] sent message which reveals "+
 					"private key of an operating member [%v]",
-				rm.ID,
 				message.senderID,
 				memberID,
 			)
@@ -1708,9 +1766,8 @@ func (cm *CombiningMember) CombineGroupPublicKey() {
 // from given group member.
 func (cm *CombiningMember) ComputeGroupPublicKeyShares() {
 	go func() {
-		logger.Infof(
-			"[member:%v] starting computation of group public key shares",
-			cm.ID,
+		cm.logger().Infof(
+			"starting computation of group public key shares",
 		)
 
 		groupPublicKeyShares := make(map[group.MemberIndex]*bn256.G2)
@@ -1753,9 +1810,8 @@ func (cm *CombiningMember) ComputeGroupPublicKeyShares() {
 			groupPublicKeyShares[operatingMemberID] = sum
 		}
 
-		logger.Infof(
-			"[member:%v] completed computation of group public key shares",
-			cm.ID,
+		cm.logger().Infof(
+			"completed computation of group public key shares",
 		)
 
 		cm.groupPublicKeySharesChannel <- groupPublicKeyShares
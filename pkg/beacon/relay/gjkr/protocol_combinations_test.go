@@ -166,7 +166,7 @@ func TestCombineGroupPublicKeyShares_WithReconstruction(t *testing.T) {
 	// Simulate that member 3 didn't send their public key share points,
 	// became inactive at the beginning of phase 8 and their shares have
 	// been revealed in phase 11.
-	member.group.MarkMemberAsInactive(3)
+	member.group.MarkMemberAsInactive(3, "inactive")
 	delete(member.receivedValidPeerPublicKeySharePoints, 3)
 	member.revealedMisbehavedMembersShares = []*misbehavedShares{{
 		misbehavedMemberID: 3,
@@ -24,9 +24,9 @@ func TestRevealMisbehavedMembersKeys(t *testing.T) {
 	disqualifiedSharingMember1 := group.MemberIndex(2)
 	disqualifiedSharingMember2 := group.MemberIndex(3)
 	disqualifiedNotSharingMember := group.MemberIndex(6)
-	firstMember.group.MarkMemberAsDisqualified(disqualifiedSharingMember1)
-	firstMember.group.MarkMemberAsDisqualified(disqualifiedSharingMember2)
-	firstMember.group.MarkMemberAsDisqualified(disqualifiedNotSharingMember)
+	firstMember.group.MarkMemberAsDisqualified(disqualifiedSharingMember1, "misbehaved")
+	firstMember.group.MarkMemberAsDisqualified(disqualifiedSharingMember2, "misbehaved")
+	firstMember.group.MarkMemberAsDisqualified(disqualifiedNotSharingMember, "misbehaved")
 
 	// Simulate a case where member is disqualified in Phase 5.
 	delete(firstMember.receivedQualifiedSharesS, disqualifiedNotSharingMember)
@@ -163,7 +163,7 @@ func generateMisbehavedEphemeralKeysMessages(
 	var misbehavedEphemeralKeysMessages []*MisbehavedEphemeralKeysMessage
 	for _, otherMember := range otherMembers {
 		for _, disqualifiedMember := range disqualifiedMembers {
-			otherMember.group.MarkMemberAsDisqualified(disqualifiedMember.ID)
+			otherMember.group.MarkMemberAsDisqualified(disqualifiedMember.ID, "misbehaved")
 			delete(otherMember.receivedValidPeerPublicKeySharePoints, disqualifiedMember.ID)
 		}
 		misbehavedEphemeralKeysMessage, err := otherMember.RevealMisbehavedMembersKeys()
@@ -348,8 +348,8 @@ func TestReconstructMisbehavedIndividualKeys(t *testing.T) {
 
 	// Disqualified members must be also disqualified
 	// from the recovering member's perspective
-	member1.group.MarkMemberAsDisqualified(member5.ID)
-	member1.group.MarkMemberAsDisqualified(member6.ID)
+	member1.group.MarkMemberAsDisqualified(member5.ID, "misbehaved")
+	member1.group.MarkMemberAsDisqualified(member6.ID, "misbehaved")
 
 	var misbehavedEphemeralKeysMessages []*MisbehavedEphemeralKeysMessage
 	for _, otherMember := range otherMembers {
@@ -0,0 +1,37 @@
+package gjkr
+
+import (
+	"math/big"
+
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+	"github.com/keep-network/keep-core/pkg/net/ephemeral"
+)
+
+// randomSource provides the random inputs of a member to the protocol. It
+// lets the member's random inputs be recorded and replayed later.
+type randomSource interface {
+	// generateEphemeralKeyPair generates the ephemeral key pair of the member
+	// for the given other group member.
+	generateEphemeralKeyPair(
+		otherMemberID group.MemberIndex,
+	) (*ephemeral.KeyPair, error)
+	// generatePolynomial generates the coefficients of a random polynomial of
+	// the given degree.
+	generatePolynomial(degree int) ([]*big.Int, error)
+}
+
+// cryptoRandomSource is the random source backed by a cryptographically
+// secure random number generator.
+type cryptoRandomSource struct{}
+
+func (crs *cryptoRandomSource) generateEphemeralKeyPair(
+	otherMemberID group.MemberIndex,
+) (*ephemeral.KeyPair, error) {
+	return ephemeral.GenerateKeyPair()
+}
+
+func (crs *cryptoRandomSource) generatePolynomial(
+	degree int,
+) ([]*big.Int, error) {
+	return generatePolynomial(degree)
+}
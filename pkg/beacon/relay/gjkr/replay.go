@@ -0,0 +1,310 @@
+package gjkr
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/state"
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/net/ephemeral"
+)
+
+// Replay re-executes the protocol for the member whose execution has been
+// recorded in the transcript. The member uses its recorded random inputs and
+// membership checks, and each protocol state receives the messages it has
+// received during the recorded execution. Nothing is sent to the network.
+//
+// The execution is timed with the given block counter and starts at the
+// given block.
+func Replay(
+	ctx context.Context,
+	transcript *Transcript,
+	blockCounter chain.BlockCounter,
+	startBlockHeight uint64,
+) (*Result, error) {
+	channel := newReplayChannel(transcript.ChannelName)
+	RegisterUnmarshallers(channel)
+
+	messages := make(map[int][]net.Message)
+	for _, recorded := range transcript.Messages {
+		message, err := channel.unmarshal(recorded)
+		if err != nil {
+			return nil, err
+		}
+
+		messages[recorded.State] = append(messages[recorded.State], message)
+	}
+
+	states := 0
+	deliverMessages := func(initiatedState state.State, blockHeight uint64) {
+		stateMessages := messages[states]
+		states++
+
+		// States with no active blocks end right after they are initiated
+		// and ignore the messages they receive.
+		if initiatedState.ActiveBlocks() == 0 {
+			return
+		}
+
+		channel.deliver(stateMessages)
+	}
+
+	result, _, err := Execute(
+		ctx,
+		transcript.MemberIndex,
+		transcript.GroupSize,
+		blockCounter,
+		channel,
+		transcript.DishonestThreshold,
+		transcript.Seed,
+		newReplayMembershipValidator(transcript.Memberships),
+		startBlockHeight,
+		withRandomSource(newReplayRandomSource(transcript)),
+		withStateMachineObserver(func(stateMachine *state.Machine) {
+			stateMachine.OnStateInitiated(deliverMessages)
+		}),
+	)
+
+	return result, err
+}
+
+type replayHandler struct {
+	ctx     context.Context
+	handler func(m net.Message)
+}
+
+// replayChannel is a broadcast channel delivering messages recorded in
+// a transcript. Messages sent to the channel are discarded.
+type replayChannel struct {
+	name string
+
+	mutex        sync.Mutex
+	unmarshalers map[string]func() net.TaggedUnmarshaler
+	handlers     []*replayHandler
+}
+
+func newReplayChannel(name string) *replayChannel {
+	return &replayChannel{
+		name:         name,
+		unmarshalers: make(map[string]func() net.TaggedUnmarshaler),
+	}
+}
+
+func (rc *replayChannel) Name() string {
+	return rc.name
+}
+
+func (rc *replayChannel) Send(
+	ctx context.Context,
+	m net.TaggedMarshaler,
+	strategy ...net.RetransmissionStrategy,
+) error {
+	return nil
+}
+
+func (rc *replayChannel) Recv(ctx context.Context, handler func(m net.Message)) {
+	rc.mutex.Lock()
+	defer rc.mutex.Unlock()
+
+	rc.handlers = append(rc.handlers, &replayHandler{ctx, handler})
+}
+
+func (rc *replayChannel) SetUnmarshaler(
+	unmarshaler func() net.TaggedUnmarshaler,
+) {
+	rc.mutex.Lock()
+	defer rc.mutex.Unlock()
+
+	rc.unmarshalers[unmarshaler().Type()] = unmarshaler
+}
+
+func (rc *replayChannel) SetFilter(filter net.BroadcastChannelFilter) error {
+	return nil
+}
+
+func (rc *replayChannel) RequestMissedMessages(
+	ctx context.Context,
+	filter net.MissedMessagesFilter,
+) error {
+	return nil
+}
+
+func (rc *replayChannel) Close() error {
+	return nil
+}
+
+func (rc *replayChannel) unmarshal(
+	recorded *TranscriptMessage,
+) (net.Message, error) {
+	rc.mutex.Lock()
+	unmarshaler, ok := rc.unmarshalers[recorded.Type]
+	rc.mutex.Unlock()
+
+	if !ok {
+		return nil, fmt.Errorf(
+			"no unmarshaler for recorded message of type [%v]",
+			recorded.Type,
+		)
+	}
+
+	payload := unmarshaler()
+	if err := payload.Unmarshal(recorded.Payload); err != nil {
+		return nil, fmt.Errorf(
+			"could not unmarshal recorded message of type [%v]: [%v]",
+			recorded.Type,
+			err,
+		)
+	}
+
+	return &replayMessage{
+		senderPublicKey: recorded.SenderPublicKey,
+		payload:         payload,
+		messageType:     recorded.Type,
+	}, nil
+}
+
+// deliver asynchronously passes the messages to handlers registered at the
+// moment of the call, for as long as their contexts are not done.
+func (rc *replayChannel) deliver(messages []net.Message) {
+	rc.mutex.Lock()
+	handlers := make([]*replayHandler, 0, len(rc.handlers))
+	for _, handler := range rc.handlers {
+		if handler.ctx.Err() == nil {
+			handlers = append(handlers, handler)
+		}
+	}
+	rc.handlers = handlers
+	rc.mutex.Unlock()
+
+	go func() {
+		for _, message := range messages {
+			for _, handler := range handlers {
+				if handler.ctx.Err() == nil {
+					handler.handler(message)
+				}
+			}
+		}
+	}()
+}
+
+// replayMessage is a message recorded in a transcript.
+type replayMessage struct {
+	senderPublicKey []byte
+	payload         interface{}
+	messageType     string
+}
+
+func (rm *replayMessage) TransportSenderID() net.TransportIdentifier {
+	return nil
+}
+
+func (rm *replayMessage) SenderPublicKey() []byte {
+	return rm.senderPublicKey
+}
+
+func (rm *replayMessage) SenderCapabilities() *net.PeerCapabilities {
+	return nil
+}
+
+func (rm *replayMessage) Payload() interface{} {
+	return rm.payload
+}
+
+func (rm *replayMessage) Type() string {
+	return rm.messageType
+}
+
+func (rm *replayMessage) Seqno() uint64 {
+	return 0
+}
+
+// replayMembershipValidator confirms memberships the way they have been
+// confirmed during the recorded execution. Memberships which have not been
+// checked during the recorded execution are considered invalid.
+type replayMembershipValidator struct {
+	memberships []*TranscriptMembership
+}
+
+func newReplayMembershipValidator(
+	memberships []*TranscriptMembership,
+) *replayMembershipValidator {
+	return &replayMembershipValidator{memberships}
+}
+
+func (rmv *replayMembershipValidator) IsInGroup(
+	publicKey *ecdsa.PublicKey,
+) bool {
+	return true
+}
+
+func (rmv *replayMembershipValidator) IsValidMembership(
+	memberID group.MemberIndex,
+	publicKey []byte,
+) bool {
+	for _, membership := range rmv.memberships {
+		if membership.MemberID == memberID &&
+			bytes.Equal(membership.PublicKey, publicKey) {
+			return membership.Valid
+		}
+	}
+
+	return false
+}
+
+// replayRandomSource provides the random inputs recorded in a transcript.
+type replayRandomSource struct {
+	mutex                sync.Mutex
+	ephemeralPrivateKeys map[group.MemberIndex][]byte
+	polynomials          [][]*big.Int
+}
+
+func newReplayRandomSource(transcript *Transcript) *replayRandomSource {
+	return &replayRandomSource{
+		ephemeralPrivateKeys: transcript.EphemeralPrivateKeys,
+		polynomials:          transcript.Polynomials,
+	}
+}
+
+func (rrs *replayRandomSource) generateEphemeralKeyPair(
+	otherMemberID group.MemberIndex,
+) (*ephemeral.KeyPair, error) {
+	privateKey, ok := rrs.ephemeralPrivateKeys[otherMemberID]
+	if !ok {
+		return nil, fmt.Errorf(
+			"no ephemeral private key recorded for member [%v]",
+			otherMemberID,
+		)
+	}
+
+	return ephemeral.UnmarshalKeyPair(privateKey), nil
+}
+
+func (rrs *replayRandomSource) generatePolynomial(
+	degree int,
+) ([]*big.Int, error) {
+	rrs.mutex.Lock()
+	defer rrs.mutex.Unlock()
+
+	if len(rrs.polynomials) == 0 {
+		return nil, fmt.Errorf("no more polynomials recorded")
+	}
+
+	coefficients := rrs.polynomials[0]
+	rrs.polynomials = rrs.polynomials[1:]
+
+	if len(coefficients) != degree+1 {
+		return nil, fmt.Errorf(
+			"recorded polynomial has degree [%v] instead of [%v]",
+			len(coefficients)-1,
+			degree,
+		)
+	}
+
+	return coefficients, nil
+}
@@ -221,6 +221,9 @@ type commitmentsVerificationState struct {
 	previousPhaseSharesMessages      []*PeerSharesMessage
 	previousPhaseCommitmentsMessages []*MemberCommitmentsMessage
 
+	// Accusations sent by the member in this phase.
+	accusationsMessage *SecretSharesAccusationsMessage
+
 	phaseAccusationsMessages []*SecretSharesAccusationsMessage
 }
 
@@ -245,6 +248,7 @@ func (cvs *commitmentsVerificationState) Initiate(ctx context.Context) error {
 		return err
 	}
 
+	cvs.accusationsMessage = accusationsMsg
 	if err := cvs.channel.Send(ctx, accusationsMsg); err != nil {
 		return err
 	}
@@ -433,6 +437,9 @@ type pointsValidationState struct {
 
 	previousPhaseMessages []*MemberPublicKeySharePointsMessage
 
+	// Accusations sent by the member in this phase.
+	accusationsMessage *PointsAccusationsMessage
+
 	phaseMessages []*PointsAccusationsMessage
 }
 
@@ -453,6 +460,7 @@ func (pvs *pointsValidationState) Initiate(ctx context.Context) error {
 		return err
 	}
 
+	pvs.accusationsMessage = accusationMsg
 	if err := pvs.channel.Send(ctx, accusationMsg); err != nil {
 		return err
 	}
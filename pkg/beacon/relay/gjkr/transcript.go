@@ -0,0 +1,397 @@
+package gjkr
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"sort"
+	"sync"
+
+	"github.com/ipfs/go-log"
+	"github.com/keep-network/keep-common/pkg/persistence"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/state"
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/net/ephemeral"
+)
+
+// Transcript is the record of a single member's execution of the protocol.
+// It contains the parameters of the execution, the random inputs of the
+// member, the membership checks it has performed, and every message received
+// on the broadcast channel. Replaying the transcript reproduces the member's
+// execution.
+//
+// Transcript contains the member's ephemeral private keys and polynomial
+// coefficients from which its share of the group private key can be derived,
+// so it must be stored as securely as the share itself. Transcripts are
+// stored with a persistence handle which should encrypt them, just like the
+// handle storing group memberships does.
+type Transcript struct {
+	ChannelName        string
+	MemberIndex        group.MemberIndex
+	GroupSize          int
+	DishonestThreshold int
+	Seed               *big.Int
+	StartBlockHeight   uint64
+
+	// EphemeralPrivateKeys are the marshaled ephemeral private keys the member
+	// has generated for each other group member.
+	EphemeralPrivateKeys map[group.MemberIndex][]byte
+	// Polynomials are the coefficients of the polynomials the member has
+	// generated, in the order of generation.
+	Polynomials [][]*big.Int
+
+	Memberships []*TranscriptMembership
+	Messages    []*TranscriptMessage
+
+	Outcome *TranscriptOutcome
+}
+
+// TranscriptMembership is the result of a membership check of a message
+// sender performed by the member.
+type TranscriptMembership struct {
+	MemberID  group.MemberIndex
+	PublicKey []byte
+	Valid     bool
+}
+
+// TranscriptMessage is a message received by the member on the broadcast
+// channel.
+type TranscriptMessage struct {
+	// State is the ordinal number of the protocol state which received the
+	// message, starting from 0 for the first state.
+	State     int
+	StateName string
+	// Block is the block at which the message has been received.
+	Block           uint64
+	Type            string
+	SenderPublicKey []byte
+	Payload         []byte
+}
+
+// TranscriptOutcome is the outcome of the member's execution of the
+// protocol.
+type TranscriptOutcome struct {
+	// Eliminations are the members marked as inactive or disqualified by the
+	// member, ordered by member index.
+	Eliminations   []group.Elimination
+	GroupPublicKey []byte
+	Error          string
+}
+
+// NewTranscriptOutcome creates the outcome of an execution which ended with
+// the given result and error.
+func NewTranscriptOutcome(result *Result, err error) *TranscriptOutcome {
+	outcome := &TranscriptOutcome{}
+
+	if err != nil {
+		outcome.Error = err.Error()
+	}
+
+	if result != nil {
+		if eliminations := result.Group.Eliminations(); len(eliminations) > 0 {
+			outcome.Eliminations = eliminations
+		}
+		sort.SliceStable(outcome.Eliminations, func(i, j int) bool {
+			return outcome.Eliminations[i].MemberID <
+				outcome.Eliminations[j].MemberID
+		})
+
+		if groupPublicKey, err := result.GroupPublicKeyBytes(); err == nil {
+			outcome.GroupPublicKey = groupPublicKey
+		}
+	}
+
+	return outcome
+}
+
+// Matches returns true if both outcomes have the same eliminations, group
+// public key and error.
+func (to *TranscriptOutcome) Matches(other *TranscriptOutcome) bool {
+	return reflect.DeepEqual(to, other)
+}
+
+// transcriptsDirectory is the directory of the persistence handle in which
+// transcripts are stored.
+const transcriptsDirectory = "dkg"
+
+// ReadTranscript reads the transcript with the given file name stored with
+// the given persistence handle.
+func ReadTranscript(
+	handle persistence.Handle,
+	fileName string,
+) (*Transcript, error) {
+	dataChannel, errorChannel := handle.ReadAll()
+
+	// Both channels are drained as we don't know in what order the handle
+	// writes to them.
+	var readErrors []error
+	var errorsWaitGroup sync.WaitGroup
+	errorsWaitGroup.Add(1)
+	go func() {
+		defer errorsWaitGroup.Done()
+		for err := range errorChannel {
+			readErrors = append(readErrors, err)
+		}
+	}()
+
+	var descriptor persistence.DataDescriptor
+	for dataDescriptor := range dataChannel {
+		if dataDescriptor.Directory() == transcriptsDirectory &&
+			dataDescriptor.Name() == fileName {
+			descriptor = dataDescriptor
+		}
+	}
+	errorsWaitGroup.Wait()
+
+	if descriptor == nil {
+		if len(readErrors) > 0 {
+			return nil, fmt.Errorf(
+				"could not read transcripts: [%v]",
+				readErrors[0],
+			)
+		}
+		return nil, fmt.Errorf("transcript [%v] not found", fileName)
+	}
+
+	content, err := descriptor.Content()
+	if err != nil {
+		return nil, fmt.Errorf(
+			"could not read transcript [%v]: [%v]",
+			fileName,
+			err,
+		)
+	}
+
+	transcript := &Transcript{}
+	if err := json.Unmarshal(content, transcript); err != nil {
+		return nil, fmt.Errorf("could not unmarshal transcript: [%v]", err)
+	}
+
+	return transcript, nil
+}
+
+// TranscriptFileName returns the name of the file in which the transcript of
+// the given member's execution starting at the given block is stored.
+func TranscriptFileName(
+	startBlockHeight uint64,
+	memberIndex group.MemberIndex,
+) string {
+	return fmt.Sprintf("dkg-%v-member-%v.json", startBlockHeight, memberIndex)
+}
+
+func (t *Transcript) save(handle persistence.Handle) error {
+	content, err := json.Marshal(t)
+	if err != nil {
+		return fmt.Errorf("could not marshal transcript: [%v]", err)
+	}
+
+	err = handle.Save(
+		content,
+		transcriptsDirectory,
+		TranscriptFileName(t.StartBlockHeight, t.MemberIndex),
+	)
+	if err != nil {
+		return fmt.Errorf("could not save transcript: [%v]", err)
+	}
+
+	return nil
+}
+
+// transcriptRecorder records the transcript of the member's execution. It
+// wraps the member's random source and membership validator to record their
+// results and observes the state machine to record received messages.
+type transcriptRecorder struct {
+	mutex      sync.Mutex
+	transcript *Transcript
+
+	randomness          randomSource
+	membershipValidator group.MembershipValidator
+	blockCounter        chain.BlockCounter
+	logger              *log.ZapEventLogger
+
+	states int
+}
+
+func newTranscriptRecorder(
+	channelName string,
+	memberIndex group.MemberIndex,
+	groupSize int,
+	dishonestThreshold int,
+	seed *big.Int,
+	startBlockHeight uint64,
+	randomness randomSource,
+	membershipValidator group.MembershipValidator,
+	blockCounter chain.BlockCounter,
+	recorderLogger *log.ZapEventLogger,
+) *transcriptRecorder {
+	return &transcriptRecorder{
+		transcript: &Transcript{
+			ChannelName:          channelName,
+			MemberIndex:          memberIndex,
+			GroupSize:            groupSize,
+			DishonestThreshold:   dishonestThreshold,
+			Seed:                 seed,
+			StartBlockHeight:     startBlockHeight,
+			EphemeralPrivateKeys: make(map[group.MemberIndex][]byte),
+		},
+		randomness:          randomness,
+		membershipValidator: membershipValidator,
+		blockCounter:        blockCounter,
+		logger:              recorderLogger,
+	}
+}
+
+func (tr *transcriptRecorder) generateEphemeralKeyPair(
+	otherMemberID group.MemberIndex,
+) (*ephemeral.KeyPair, error) {
+	keyPair, err := tr.randomness.generateEphemeralKeyPair(otherMemberID)
+	if err != nil {
+		return nil, err
+	}
+
+	tr.mutex.Lock()
+	defer tr.mutex.Unlock()
+
+	tr.transcript.EphemeralPrivateKeys[otherMemberID] =
+		keyPair.PrivateKey.Marshal()
+
+	return keyPair, nil
+}
+
+func (tr *transcriptRecorder) generatePolynomial(
+	degree int,
+) ([]*big.Int, error) {
+	coefficients, err := tr.randomness.generatePolynomial(degree)
+	if err != nil {
+		return nil, err
+	}
+
+	tr.mutex.Lock()
+	defer tr.mutex.Unlock()
+
+	tr.transcript.Polynomials = append(tr.transcript.Polynomials, coefficients)
+
+	return coefficients, nil
+}
+
+func (tr *transcriptRecorder) IsInGroup(publicKey *ecdsa.PublicKey) bool {
+	return tr.membershipValidator.IsInGroup(publicKey)
+}
+
+func (tr *transcriptRecorder) IsValidMembership(
+	memberID group.MemberIndex,
+	publicKey []byte,
+) bool {
+	valid := tr.membershipValidator.IsValidMembership(memberID, publicKey)
+
+	tr.mutex.Lock()
+	defer tr.mutex.Unlock()
+
+	for _, membership := range tr.transcript.Memberships {
+		if membership.MemberID == memberID &&
+			bytes.Equal(membership.PublicKey, publicKey) {
+			return valid
+		}
+	}
+
+	tr.transcript.Memberships = append(
+		tr.transcript.Memberships,
+		&TranscriptMembership{memberID, publicKey, valid},
+	)
+
+	return valid
+}
+
+func (tr *transcriptRecorder) observe(stateMachine *state.Machine) {
+	stateMachine.OnStateInitiated(tr.stateInitiated)
+	stateMachine.OnMessageReceived(tr.messageReceived)
+}
+
+func (tr *transcriptRecorder) stateInitiated(
+	initiatedState state.State,
+	blockHeight uint64,
+) {
+	tr.mutex.Lock()
+	defer tr.mutex.Unlock()
+
+	tr.states++
+}
+
+func (tr *transcriptRecorder) messageReceived(
+	receivingState state.State,
+	message net.Message,
+) {
+	payload, ok := message.Payload().(net.TaggedMarshaler)
+	if !ok {
+		tr.logger.Warningf(
+			"could not record message of type [%v]",
+			message.Type(),
+		)
+		return
+	}
+
+	marshaled, err := payload.Marshal()
+	if err != nil {
+		tr.logger.Warningf(
+			"could not marshal message of type [%v]: [%v]",
+			message.Type(),
+			err,
+		)
+		return
+	}
+
+	block, err := tr.blockCounter.CurrentBlock()
+	if err != nil {
+		tr.logger.Warningf(
+			"could not read block of message of type [%v]: [%v]",
+			message.Type(),
+			err,
+		)
+	}
+
+	tr.mutex.Lock()
+	defer tr.mutex.Unlock()
+
+	tr.transcript.Messages = append(tr.transcript.Messages, &TranscriptMessage{
+		State:           tr.states - 1,
+		StateName:       fmt.Sprintf("%T", receivingState),
+		Block:           block,
+		Type:            payload.Type(),
+		SenderPublicKey: message.SenderPublicKey(),
+		Payload:         marshaled,
+	})
+}
+
+// save stores the transcript along with the outcome of the execution with
+// the given persistence handle.
+func (tr *transcriptRecorder) save(
+	handle persistence.Handle,
+	result *Result,
+	err error,
+) {
+	tr.mutex.Lock()
+	defer tr.mutex.Unlock()
+
+	tr.transcript.Outcome = NewTranscriptOutcome(result, err)
+
+	if err := tr.transcript.save(handle); err != nil {
+		tr.logger.Errorf(
+			"could not save DKG transcript: [%v]",
+			err,
+		)
+		return
+	}
+
+	tr.logger.Infof(
+		"saved DKG transcript [%v]",
+		TranscriptFileName(
+			tr.transcript.StartBlockHeight,
+			tr.transcript.MemberIndex,
+		),
+	)
+}
@@ -0,0 +1,278 @@
+package gjkr_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/keep-network/keep-common/pkg/persistence"
+	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/gjkr"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+	chainLocal "github.com/keep-network/keep-core/pkg/chain/local"
+	"github.com/keep-network/keep-core/pkg/internal/dkgtest"
+	"github.com/keep-network/keep-core/pkg/internal/interception"
+	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/net/key"
+	netLocal "github.com/keep-network/keep-core/pkg/net/local"
+	"github.com/keep-network/keep-core/pkg/operator"
+)
+
+func TestReplayTranscript(t *testing.T) {
+	t.Parallel()
+
+	groupSize := 5
+	honestThreshold := 3
+	seed := dkgtest.RandomSeed(t)
+
+	transcriptsDir, err := ioutil.TempDir("", "transcripts")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(transcriptsDir)
+
+	transcripts := newTestTranscripts(t, transcriptsDir, "password")
+
+	privateKey, publicKey, err := operator.GenerateKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, networkPublicKey := key.OperatorKeyToNetworkKey(privateKey, publicKey)
+
+	// Member 5 is marked as inactive by all other members.
+	network := interception.NewNetwork(
+		netLocal.ConnectWithKey(networkPublicKey),
+		func(msg net.TaggedMarshaler) net.TaggedMarshaler {
+			publicKeyMessage, ok := msg.(*gjkr.EphemeralPublicKeyMessage)
+			if ok && publicKeyMessage.SenderID() == group.MemberIndex(5) {
+				return nil
+			}
+			return msg
+		},
+	)
+
+	localChain := chainLocal.ConnectWithKey(
+		groupSize,
+		honestThreshold,
+		big.NewInt(20),
+		privateKey,
+	)
+	blockCounter, err := localChain.BlockCounter()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	address := localChain.Signing().PublicKeyBytesToAddress(
+		key.Marshal(networkPublicKey),
+	)
+	selectedStakers := make([]relaychain.StakerAddress, groupSize)
+	for i := range selectedStakers {
+		selectedStakers[i] = address
+	}
+	membershipValidator := group.NewStakersMembershipValidator(
+		selectedStakers,
+		localChain.Signing(),
+	)
+
+	currentBlockHeight, err := blockCounter.CurrentBlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+	startBlockHeight := currentBlockHeight + 3
+
+	results := make(map[group.MemberIndex]*gjkr.Result)
+	var resultsMutex sync.Mutex
+	var wg sync.WaitGroup
+
+	for i := 1; i <= groupSize; i++ {
+		memberIndex := group.MemberIndex(i)
+
+		channel, err := network.BroadcastChannelFor(
+			fmt.Sprintf("transcript-test-%v", seed),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer channel.Close()
+		gjkr.RegisterUnmarshallers(channel)
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			result, _, err := gjkr.Execute(
+				context.Background(),
+				memberIndex,
+				groupSize,
+				blockCounter,
+				channel,
+				groupSize-honestThreshold,
+				seed,
+				membershipValidator,
+				startBlockHeight,
+				gjkr.WithTranscriptRecording(transcripts),
+			)
+			if err != nil {
+				t.Errorf("member [%v] failed: [%v]", memberIndex, err)
+				return
+			}
+
+			resultsMutex.Lock()
+			results[memberIndex] = result
+			resultsMutex.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	result, ok := results[group.MemberIndex(1)]
+	if !ok {
+		t.Fatal("expected a result of member 1")
+	}
+	expectedOutcome := gjkr.NewTranscriptOutcome(result, nil)
+
+	if len(expectedOutcome.Eliminations) != 1 ||
+		expectedOutcome.Eliminations[0].MemberID != group.MemberIndex(5) {
+		t.Fatalf(
+			"expected member 5 to be eliminated; has: [%v]",
+			expectedOutcome.Eliminations,
+		)
+	}
+
+	transcriptName := gjkr.TranscriptFileName(
+		startBlockHeight,
+		group.MemberIndex(1),
+	)
+
+	transcript, err := gjkr.ReadTranscript(transcripts, transcriptName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertNoPlaintextSecrets(t, transcriptsDir, transcript)
+
+	_, err = gjkr.ReadTranscript(
+		newTestTranscripts(t, transcriptsDir, "other password"),
+		transcriptName,
+	)
+	if err == nil {
+		t.Errorf("expected an error reading transcript with another password")
+	}
+
+	if !transcript.Outcome.Matches(expectedOutcome) {
+		t.Errorf(
+			"unexpected recorded outcome\nexpected: %+v\nactual:   %+v",
+			expectedOutcome,
+			transcript.Outcome,
+		)
+	}
+
+	currentBlockHeight, err = blockCounter.CurrentBlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	replayedResult, err := gjkr.Replay(
+		context.Background(),
+		transcript,
+		blockCounter,
+		currentBlockHeight+1,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	replayedOutcome := gjkr.NewTranscriptOutcome(replayedResult, nil)
+	if !replayedOutcome.Matches(expectedOutcome) {
+		t.Errorf(
+			"unexpected replayed outcome\nexpected: %+v\nactual:   %+v",
+			expectedOutcome,
+			replayedOutcome,
+		)
+	}
+
+	if replayedResult.GroupPrivateKeyShare.Cmp(result.GroupPrivateKeyShare) != 0 {
+		t.Errorf("unexpected replayed group private key share")
+	}
+}
+
+func newTestTranscripts(
+	t *testing.T,
+	dir string,
+	password string,
+) persistence.Handle {
+	handle, err := persistence.NewDiskHandle(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return persistence.NewEncryptedPersistence(handle, password)
+}
+
+// assertNoPlaintextSecrets checks that none of the files in the given
+// directory contain the secret material of the given transcript: ephemeral
+// private keys and polynomial coefficients, in any of the encodings the
+// transcript could use.
+func assertNoPlaintextSecrets(
+	t *testing.T,
+	dir string,
+	transcript *gjkr.Transcript,
+) {
+	if len(transcript.EphemeralPrivateKeys) == 0 ||
+		len(transcript.Polynomials) == 0 {
+		t.Fatal("expected transcript to contain secret material")
+	}
+
+	var secrets [][]byte
+	for _, privateKey := range transcript.EphemeralPrivateKeys {
+		secrets = append(
+			secrets,
+			privateKey,
+			[]byte(base64.StdEncoding.EncodeToString(privateKey)),
+			[]byte(fmt.Sprintf("%x", privateKey)),
+		)
+	}
+	for _, polynomial := range transcript.Polynomials {
+		for _, coefficient := range polynomial {
+			secrets = append(secrets, []byte(coefficient.String()))
+		}
+	}
+
+	files := 0
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		files++
+
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		if bytes.Contains(content, []byte("EphemeralPrivateKeys")) {
+			t.Errorf("transcript [%v] is stored in plaintext", path)
+		}
+		for _, secret := range secrets {
+			if bytes.Contains(content, secret) {
+				t.Errorf("secret material found in plaintext in [%v]", path)
+				return nil
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if files == 0 {
+		t.Fatal("expected transcripts to be stored")
+	}
+}
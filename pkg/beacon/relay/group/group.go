@@ -11,6 +11,17 @@ type Group struct {
 	inactiveMemberIDs []MemberIndex
 	// All member IDs in this group.
 	memberIDs []MemberIndex
+	// Eliminations of members in the order they were marked as inactive or
+	// disqualified.
+	eliminations []Elimination
+}
+
+// Elimination describes why a member has been marked as inactive or
+// disqualified.
+type Elimination struct {
+	MemberID     MemberIndex
+	Disqualified bool
+	Reason       string
 }
 
 // NewDkgGroup creates a new Group with the provided dishonest threshold, member
@@ -26,6 +37,7 @@ func NewDkgGroup(dishonestThreshold int, size int) *Group {
 		disqualifiedMemberIDs: []MemberIndex{},
 		inactiveMemberIDs:     []MemberIndex{},
 		memberIDs:             memberIDs,
+		eliminations:          []Elimination{},
 	}
 }
 
@@ -73,21 +85,40 @@ func (g *Group) OperatingMemberIDs() []MemberIndex {
 	return operatingMembers
 }
 
+// Eliminations returns eliminations of all members marked as inactive or
+// disqualified so far, in the order they were marked.
+func (g *Group) Eliminations() []Elimination {
+	eliminations := make([]Elimination, len(g.eliminations))
+	copy(eliminations, g.eliminations)
+	return eliminations
+}
+
 // MarkMemberAsDisqualified adds the member with the given ID to the list of
-// disqualified members. If the member is not a part of the group, is already
-// disqualified or marked as inactive, method does nothing.
-func (g *Group) MarkMemberAsDisqualified(memberID MemberIndex) {
+// disqualified members, recording the reason of the disqualification. If the
+// member is not a part of the group, is already disqualified or marked as
+// inactive, method does nothing.
+func (g *Group) MarkMemberAsDisqualified(memberID MemberIndex, reason string) {
 	if g.IsOperating(memberID) {
 		g.disqualifiedMemberIDs = append(g.disqualifiedMemberIDs, memberID)
+		g.eliminations = append(g.eliminations, Elimination{
+			MemberID:     memberID,
+			Disqualified: true,
+			Reason:       reason,
+		})
 	}
 }
 
 // MarkMemberAsInactive adds the member with the given ID to the list of
-// inactive members. If the member is not a part of the group, is already
-// disqualified or marked as inactive, method does nothing.
-func (g *Group) MarkMemberAsInactive(memberID MemberIndex) {
+// inactive members, recording the reason of the inactivity. If the member is
+// not a part of the group, is already disqualified or marked as inactive,
+// method does nothing.
+func (g *Group) MarkMemberAsInactive(memberID MemberIndex, reason string) {
 	if g.IsOperating(memberID) {
 		g.inactiveMemberIDs = append(g.inactiveMemberIDs, memberID)
+		g.eliminations = append(g.eliminations, Elimination{
+			MemberID: memberID,
+			Reason:   reason,
+		})
 	}
 }
 
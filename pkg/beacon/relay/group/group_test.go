@@ -15,7 +15,7 @@ func TestMarkMemberAsDisqualified(t *testing.T) {
 		"mark member as disqualified": {
 			initialMembers: []MemberIndex{19, 11, 31, 33},
 			updateFunc: func(g *Group) {
-				g.MarkMemberAsDisqualified(19)
+				g.MarkMemberAsDisqualified(19, "misbehaved")
 			},
 			expectedDisqualifiedMembers: []MemberIndex{19},
 			expectedInactiveMembers:     []MemberIndex{},
@@ -23,8 +23,8 @@ func TestMarkMemberAsDisqualified(t *testing.T) {
 		"mark member as disqualified twice": {
 			initialMembers: []MemberIndex{19, 11, 31, 33},
 			updateFunc: func(g *Group) {
-				g.MarkMemberAsDisqualified(11)
-				g.MarkMemberAsDisqualified(11)
+				g.MarkMemberAsDisqualified(11, "misbehaved")
+				g.MarkMemberAsDisqualified(11, "misbehaved")
 			},
 			expectedDisqualifiedMembers: []MemberIndex{11},
 			expectedInactiveMembers:     []MemberIndex{},
@@ -32,7 +32,7 @@ func TestMarkMemberAsDisqualified(t *testing.T) {
 		"mark member from out of the group as disqualified": {
 			initialMembers: []MemberIndex{19, 11, 31, 33},
 			updateFunc: func(g *Group) {
-				g.MarkMemberAsDisqualified(88)
+				g.MarkMemberAsDisqualified(88, "misbehaved")
 			},
 			expectedDisqualifiedMembers: []MemberIndex{},
 			expectedInactiveMembers:     []MemberIndex{},
@@ -40,9 +40,9 @@ func TestMarkMemberAsDisqualified(t *testing.T) {
 		"mark all members as disqualified": {
 			initialMembers: []MemberIndex{11, 12, 13},
 			updateFunc: func(g *Group) {
-				g.MarkMemberAsDisqualified(11)
-				g.MarkMemberAsDisqualified(13)
-				g.MarkMemberAsDisqualified(12)
+				g.MarkMemberAsDisqualified(11, "misbehaved")
+				g.MarkMemberAsDisqualified(13, "misbehaved")
+				g.MarkMemberAsDisqualified(12, "misbehaved")
 			},
 			expectedDisqualifiedMembers: []MemberIndex{11, 13, 12},
 			expectedInactiveMembers:     []MemberIndex{},
@@ -50,7 +50,7 @@ func TestMarkMemberAsDisqualified(t *testing.T) {
 		"mark member as inactive": {
 			initialMembers: []MemberIndex{19, 11, 31, 33},
 			updateFunc: func(g *Group) {
-				g.MarkMemberAsInactive(31)
+				g.MarkMemberAsInactive(31, "inactive")
 			},
 			expectedDisqualifiedMembers: []MemberIndex{},
 			expectedInactiveMembers:     []MemberIndex{31},
@@ -58,8 +58,8 @@ func TestMarkMemberAsDisqualified(t *testing.T) {
 		"mark member as inactive twice": {
 			initialMembers: []MemberIndex{19, 11, 31, 33},
 			updateFunc: func(g *Group) {
-				g.MarkMemberAsInactive(33)
-				g.MarkMemberAsInactive(33)
+				g.MarkMemberAsInactive(33, "inactive")
+				g.MarkMemberAsInactive(33, "inactive")
 			},
 			expectedDisqualifiedMembers: []MemberIndex{},
 			expectedInactiveMembers:     []MemberIndex{33},
@@ -67,7 +67,7 @@ func TestMarkMemberAsDisqualified(t *testing.T) {
 		"mark member from out of the group as inactive": {
 			initialMembers: []MemberIndex{19, 11, 31, 33},
 			updateFunc: func(g *Group) {
-				g.MarkMemberAsInactive(99)
+				g.MarkMemberAsInactive(99, "inactive")
 			},
 			expectedDisqualifiedMembers: []MemberIndex{},
 			expectedInactiveMembers:     []MemberIndex{},
@@ -75,10 +75,10 @@ func TestMarkMemberAsDisqualified(t *testing.T) {
 		"mark all members as inactive": {
 			initialMembers: []MemberIndex{19, 18, 17, 16},
 			updateFunc: func(g *Group) {
-				g.MarkMemberAsInactive(17)
-				g.MarkMemberAsInactive(19)
-				g.MarkMemberAsInactive(16)
-				g.MarkMemberAsInactive(18)
+				g.MarkMemberAsInactive(17, "inactive")
+				g.MarkMemberAsInactive(19, "inactive")
+				g.MarkMemberAsInactive(16, "inactive")
+				g.MarkMemberAsInactive(18, "inactive")
 			},
 			expectedDisqualifiedMembers: []MemberIndex{},
 			expectedInactiveMembers:     []MemberIndex{17, 19, 16, 18},
@@ -131,7 +131,7 @@ func TestIsDisqualified(t *testing.T) {
 		t.Errorf("member should not be disqualified at this point")
 	}
 
-	group.MarkMemberAsDisqualified(19)
+	group.MarkMemberAsDisqualified(19, "misbehaved")
 
 	if !group.isDisqualified(19) {
 		t.Errorf("member should be disqualified at this point")
@@ -147,7 +147,7 @@ func TestIsInactive(t *testing.T) {
 		t.Errorf("member should ne be inactive at this point")
 	}
 
-	group.MarkMemberAsInactive(31)
+	group.MarkMemberAsInactive(31, "inactive")
 
 	if !group.isInactive(31) {
 		t.Errorf("member should be inactive at this point")
@@ -167,38 +167,38 @@ func TestOperatingMembers(t *testing.T) {
 		"one member disqualified": {
 			initialMembers: []MemberIndex{99, 98, 12, 33, 44},
 			updateFunc: func(g *Group) {
-				g.MarkMemberAsDisqualified(98)
+				g.MarkMemberAsDisqualified(98, "misbehaved")
 			},
 			expectedOperatingMembers: []MemberIndex{99, 12, 33, 44},
 		},
 		"one member inactive": {
 			initialMembers: []MemberIndex{38, 19, 39, 22, 11},
 			updateFunc: func(g *Group) {
-				g.MarkMemberAsInactive(11)
+				g.MarkMemberAsInactive(11, "inactive")
 			},
 			expectedOperatingMembers: []MemberIndex{38, 19, 39, 22},
 		},
 		"one member disqualified and one member inactive": {
 			initialMembers: []MemberIndex{19, 11, 31, 33},
 			updateFunc: func(g *Group) {
-				g.MarkMemberAsDisqualified(19)
-				g.MarkMemberAsInactive(33)
+				g.MarkMemberAsDisqualified(19, "misbehaved")
+				g.MarkMemberAsInactive(33, "inactive")
 			},
 			expectedOperatingMembers: []MemberIndex{11, 31},
 		},
 		"all but one inactive": {
 			initialMembers: []MemberIndex{28, 19, 29},
 			updateFunc: func(g *Group) {
-				g.MarkMemberAsDisqualified(19)
-				g.MarkMemberAsDisqualified(29)
+				g.MarkMemberAsDisqualified(19, "misbehaved")
+				g.MarkMemberAsDisqualified(29, "misbehaved")
 			},
 			expectedOperatingMembers: []MemberIndex{28},
 		},
 		"all but one disqualified": {
 			initialMembers: []MemberIndex{92, 11, 20},
 			updateFunc: func(g *Group) {
-				g.MarkMemberAsDisqualified(92)
-				g.MarkMemberAsDisqualified(11)
+				g.MarkMemberAsDisqualified(92, "misbehaved")
+				g.MarkMemberAsDisqualified(11, "misbehaved")
 			},
 			expectedOperatingMembers: []MemberIndex{20},
 		},
@@ -228,3 +228,29 @@ func TestOperatingMembers(t *testing.T) {
 		})
 	}
 }
+
+func TestEliminations(t *testing.T) {
+	group := NewDkgGroup(2, 4)
+
+	group.MarkMemberAsInactive(3, "no message received")
+	group.MarkMemberAsDisqualified(1, "sent invalid message")
+	// Members already eliminated are not eliminated again.
+	group.MarkMemberAsDisqualified(3, "sent invalid message")
+	group.MarkMemberAsInactive(1, "no message received")
+	// Members out of the group are not eliminated.
+	group.MarkMemberAsDisqualified(5, "sent invalid message")
+
+	expectedEliminations := []Elimination{
+		{MemberID: 3, Disqualified: false, Reason: "no message received"},
+		{MemberID: 1, Disqualified: true, Reason: "sent invalid message"},
+	}
+
+	eliminations := group.Eliminations()
+	if !reflect.DeepEqual(expectedEliminations, eliminations) {
+		t.Fatalf(
+			"unexpected eliminations\nexpected: %v\nactual:   %v\n",
+			expectedEliminations,
+			eliminations,
+		)
+	}
+}
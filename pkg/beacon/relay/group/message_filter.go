@@ -2,6 +2,7 @@ package group
 
 import (
 	"github.com/ipfs/go-log"
+	"github.com/keep-network/keep-core/pkg/logging"
 )
 
 var logger = log.Logger("keep-message-filter")
@@ -78,12 +79,17 @@ func (mf *InactiveMemberFilter) FlushInactiveMembers() {
 
 	for _, operatingMemberID := range mf.group.OperatingMemberIDs() {
 		if !isActive(operatingMemberID) {
-			logger.Warningf(
-				"[member:%v] marking member [%v] as inactive",
-				mf.selfMemberID,
+			logging.WithLogFields(
+				logger,
+				logging.Fields{logging.MemberField: mf.selfMemberID},
+			).Warningf(
+				"marking member [%v] as inactive",
 				operatingMemberID,
 			)
-			mf.group.MarkMemberAsInactive(operatingMemberID)
+			mf.group.MarkMemberAsInactive(
+				operatingMemberID,
+				"no message received in the phase",
+			)
 		}
 	}
 }
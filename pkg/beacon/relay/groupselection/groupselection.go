@@ -59,6 +59,10 @@ type Result struct {
 // After the last round, there is a 12 blocks mining lag allowing all
 // outstanding ticket submissions to have a higher chance of being
 // mined before the deadline.
+//
+// No tickets are submitted in rounds starting when submissionPaused returns
+// true. If the submission is paused before the first round, the staker does
+// not candidate to the new group at all.
 func CandidateToNewGroup(
 	relayChain relaychain.Interface,
 	blockCounter chain.BlockCounter,
@@ -66,8 +70,16 @@ func CandidateToNewGroup(
 	staker chain.Staker,
 	newEntry *big.Int,
 	startBlockHeight uint64,
+	submissionPaused func() bool,
 	onGroupSelected func(*Result),
 ) error {
+	if submissionPaused() {
+		logger.Infof(
+			"ticket submission is paused; not candidating to the new group",
+		)
+		return nil
+	}
+
 	availableStake, err := staker.Stake()
 	if err != nil {
 		return err
@@ -96,6 +108,7 @@ func CandidateToNewGroup(
 		blockCounter,
 		chainConfig,
 		startBlockHeight,
+		submissionPaused,
 	)
 	if err != nil {
 		logger.Errorf("ticket submission terminated with error: [%v]", err)
@@ -140,6 +153,7 @@ func submitTickets(
 	blockCounter chain.BlockCounter,
 	chainConfig *relaychain.Config,
 	startBlockHeight uint64,
+	submissionPaused func() bool,
 ) error {
 	rounds, err := calculateRoundsCount(chainConfig.TicketSubmissionTimeout)
 	if err != nil {
@@ -164,6 +178,15 @@ func submitTickets(
 			return err
 		}
 
+		if submissionPaused() {
+			logger.Infof(
+				"ticket submission paused at round [%v]; "+
+					"remaining tickets will not be submitted",
+				roundIndex,
+			)
+			return nil
+		}
+
 		candidateTickets, err := roundCandidateTickets(
 			relayChain,
 			tickets,
@@ -182,7 +205,7 @@ func submitTickets(
 			len(candidateTickets),
 		)
 
-		submitTicketsOnChain(candidateTickets, relayChain)
+		submitTicketsOnChain(candidateTickets, relayChain, roundStartBlock)
 	}
 
 	return nil
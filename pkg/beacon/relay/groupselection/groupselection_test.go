@@ -18,6 +18,7 @@ func TestSubmitTickets(t *testing.T) {
 	var tests = map[string]struct {
 		groupSize                int
 		tickets                  []*ticket
+		submissionPaused         bool
 		expectedSubmittedTickets []uint64
 	}{
 		// Client has the same number of tickets as the group size.
@@ -54,6 +55,17 @@ func TestSubmitTickets(t *testing.T) {
 			},
 			expectedSubmittedTickets: []uint64{1001, 1002},
 		},
+		// Ticket submission is paused, e.g. because the client is in
+		// maintenance mode. No tickets should be submitted to the chain.
+		"submission paused": {
+			groupSize: 4,
+			tickets: []*ticket{
+				newTestTicket(1, 1001),
+				newTestTicket(2, 1002),
+			},
+			submissionPaused:         true,
+			expectedSubmittedTickets: []uint64{},
+		},
 	}
 
 	for testName, test := range tests {
@@ -78,6 +90,7 @@ func TestSubmitTickets(t *testing.T) {
 				blockCounter,
 				chainConfig,
 				0, // start block height
+				func() bool { return test.submissionPaused },
 			)
 			if err != nil {
 				t.Fatal(err)
@@ -1,15 +1,20 @@
 package groupselection
 
 import (
+	"fmt"
 	"math/big"
 
+	"github.com/keep-network/keep-core/pkg/beacon/relay/audit"
 	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
 )
 
-// submitTicketsOnChain submits tickets to the chain.
+// submitTicketsOnChain submits tickets to the chain in the submission round
+// started at the given block.
 func submitTicketsOnChain(
 	tickets []*ticket,
 	relayChain relaychain.GroupSelectionInterface,
+	roundStartBlock uint64,
 ) {
 	for _, ticket := range tickets {
 		chainTicket, err := toChainTicket(ticket)
@@ -21,12 +26,35 @@ func submitTicketsOnChain(
 			continue
 		}
 
-		relayChain.SubmitTicket(chainTicket).OnFailure(
-			func(err error) {
-				logger.Errorf(
-					"ticket submission failed: [%v]",
-					err,
-				)
+		operator := fmt.Sprintf("0x%x", ticket.proof.stakerValue)
+		details := map[string]interface{}{
+			"ticket":               fmt.Sprintf("0x%x", ticket.value),
+			"virtual_staker_index": ticket.proof.virtualStakerIndex,
+		}
+
+		relayChain.SubmitTicket(chainTicket).OnComplete(
+			func(submission *event.GroupTicketSubmission, err error) {
+				if err != nil {
+					logger.Errorf(
+						"ticket submission failed: [%v]",
+						err,
+					)
+					details["reason"] = err.Error()
+					audit.Log(&audit.Record{
+						Event:    audit.TicketSubmissionFailed,
+						Block:    roundStartBlock,
+						Operator: operator,
+						Details:  details,
+					})
+					return
+				}
+
+				audit.Log(&audit.Record{
+					Event:    audit.TicketSubmitted,
+					Block:    submission.BlockNumber,
+					Operator: operator,
+					Details:  details,
+				})
 			},
 		)
 	}
@@ -35,7 +35,7 @@ func TestSubmitTicketsOnChain(t *testing.T) {
 		},
 	}
 
-	submitTicketsOnChain(tickets, mockInterface)
+	submitTicketsOnChain(tickets, mockInterface, 100)
 
 	if len(tickets) != len(submittedTickets) {
 		t.Errorf(
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"math/big"
 	"testing"
+	"time"
 
 	"github.com/keep-network/keep-core/pkg/beacon/relay/entry"
 
@@ -18,6 +19,7 @@ import (
 	"github.com/keep-network/keep-core/pkg/internal/dkgtest"
 	"github.com/keep-network/keep-core/pkg/internal/entrytest"
 	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/net/local"
 )
 
 const groupSize = 10
@@ -63,6 +65,67 @@ func TestAllMembersSigning(t *testing.T) {
 	}
 }
 
+// Success: all members of the signing group participate in signing over
+// a lossy network with latency, duplicates and reordering.
+func TestAllMembersSigningUnderNetworkConditions(t *testing.T) {
+	t.Parallel()
+
+	interceptor := func(msg net.TaggedMarshaler) net.TaggedMarshaler {
+		return msg
+	}
+
+	linkModel := local.LinkModel{
+		Default: local.Link{
+			Latency:       local.UniformLatency(10*time.Millisecond, 100*time.Millisecond),
+			DropRate:      0.2,
+			DuplicateRate: 0.1,
+			ReorderRate:   0.1,
+		},
+	}
+
+	dkgResult, err := dkgtest.RunTestUnderNetworkConditions(
+		groupSize,
+		honestThreshold,
+		dkgtest.RandomSeed(t),
+		interceptor,
+		dkgtest.SameLinkModel(linkModel),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dkgtest.AssertDkgResultPublished(t, dkgResult)
+	dkgtest.AssertSamePublicKey(t, dkgResult)
+
+	signingResult, err := entrytest.RunTestUnderNetworkConditions(
+		dkgResult.GetSigners(),
+		honestThreshold,
+		interceptor,
+		previousEntry(),
+		dkgtest.SameLinkModel(linkModel),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entrytest.AssertEntryPublished(t, signingResult)
+	entrytest.AssertNoSignerFailures(t, signingResult)
+
+	groupPublicKey, err := getFirstGroupPublicKey(dkgResult)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	newEntry, err := signingResult.EntryValue()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bls.VerifyG1(groupPublicKey, previousEntryG1(), newEntry) {
+		t.Errorf("threshold signature failed BLS verification")
+	}
+}
+
 // Success: honest threshold of the signing group members participate in
 // signing.
 func TestHonestThresholdMembersSigning(t *testing.T) {
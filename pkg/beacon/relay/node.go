@@ -2,23 +2,30 @@ package relay
 
 import (
 	"bytes"
+	"context"
 	"encoding/hex"
 	"fmt"
 	"math/big"
+	"sort"
 	"sync"
 
 	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"github.com/keep-network/keep-common/pkg/persistence"
 	"github.com/keep-network/keep-core/pkg/altbn128"
 
+	"github.com/keep-network/keep-core/pkg/beacon/relay/audit"
 	relayChain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
 
 	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/dkg"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/gjkr"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/groupselection"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/registry"
 	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/logging"
 	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/tracing"
 )
 
 // Node represents the current state of a relay node.
@@ -35,6 +42,218 @@ type Node struct {
 	chainConfig  *relaychain.Config
 
 	groupRegistry *registry.Groups
+	groupChannels *groupChannels
+	protocols     *protocols
+
+	// Persistence handle with which DKG transcripts are recorded.
+	// Transcripts are not recorded if it is nil.
+	dkgTranscripts persistence.Handle
+}
+
+// groupChannels holds broadcast channels of groups this node is a member of.
+// A single reference to the channel of a group is kept for as long as the
+// group is registered and it is released once the group gets archived.
+type groupChannels struct {
+	netProvider net.Provider
+
+	mutex    sync.Mutex
+	channels map[string]net.BroadcastChannel
+}
+
+func newGroupChannels(netProvider net.Provider) *groupChannels {
+	return &groupChannels{
+		netProvider: netProvider,
+		channels:    make(map[string]net.BroadcastChannel),
+	}
+}
+
+func (gc *groupChannels) get(name string) (net.BroadcastChannel, error) {
+	gc.mutex.Lock()
+	defer gc.mutex.Unlock()
+
+	if channel, ok := gc.channels[name]; ok {
+		return channel, nil
+	}
+
+	channel, err := gc.netProvider.BroadcastChannelFor(name)
+	if err != nil {
+		return nil, err
+	}
+
+	gc.channels[name] = channel
+
+	return channel, nil
+}
+
+func (gc *groupChannels) release(name string) {
+	gc.mutex.Lock()
+	defer gc.mutex.Unlock()
+
+	channel, ok := gc.channels[name]
+	if !ok {
+		return
+	}
+
+	delete(gc.channels, name)
+
+	if err := channel.Close(); err != nil {
+		logger.Warningf("could not close group channel [%v]: [%v]", name, err)
+		return
+	}
+
+	logger.Infof("closed channel [%v] of archived group", name)
+}
+
+func (gc *groupChannels) releaseAll() {
+	gc.mutex.Lock()
+	defer gc.mutex.Unlock()
+
+	for name, channel := range gc.channels {
+		delete(gc.channels, name)
+
+		if err := channel.Close(); err != nil {
+			logger.Warningf("could not close group channel [%v]: [%v]", name, err)
+		}
+	}
+}
+
+// protocols tracks DKG and relay entry signing executions of this node so
+// that the node can let them complete before it shuts down. Executions are
+// abandoned by cancelling the context they run with.
+type protocols struct {
+	ctx     context.Context
+	abandon context.CancelFunc
+
+	mutex      sync.Mutex
+	stopped    bool
+	inFlight   int
+	executions map[string]int
+	wg         sync.WaitGroup
+}
+
+func newProtocols() *protocols {
+	ctx, abandon := context.WithCancel(context.Background())
+
+	return &protocols{
+		ctx:        ctx,
+		abandon:    abandon,
+		executions: make(map[string]int),
+	}
+}
+
+// start registers a new protocol execution described by the given execution
+// name. It returns false if the node is shutting down and the execution must
+// not be started.
+func (p *protocols) start(execution string) bool {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.stopped {
+		return false
+	}
+
+	p.inFlight++
+	p.executions[execution]++
+	p.wg.Add(1)
+
+	return true
+}
+
+// count returns the number of in-flight protocol executions.
+func (p *protocols) count() int {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return p.inFlight
+}
+
+// list returns sorted names of in-flight protocol executions.
+func (p *protocols) list() []string {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	executions := make([]string, 0, len(p.executions))
+	for execution := range p.executions {
+		executions = append(executions, execution)
+	}
+	sort.Strings(executions)
+
+	return executions
+}
+
+// done marks a protocol execution registered with start as completed.
+func (p *protocols) done(execution string) {
+	p.mutex.Lock()
+	p.inFlight--
+	p.executions[execution]--
+	if p.executions[execution] <= 0 {
+		delete(p.executions, execution)
+	}
+	p.mutex.Unlock()
+
+	p.wg.Done()
+}
+
+// stop prevents new protocol executions from starting and waits for the
+// in-flight ones to complete. If they do not complete before the context is
+// done, they are abandoned and an error is returned.
+func (p *protocols) stop(ctx context.Context) error {
+	p.mutex.Lock()
+	p.stopped = true
+	p.mutex.Unlock()
+
+	completed := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(completed)
+	}()
+
+	select {
+	case <-completed:
+		p.abandon()
+		return nil
+	case <-ctx.Done():
+		p.abandon()
+
+		p.mutex.Lock()
+		defer p.mutex.Unlock()
+
+		return fmt.Errorf(
+			"abandoned [%v] in-flight protocol executions: [%v]",
+			p.inFlight,
+			ctx.Err(),
+		)
+	}
+}
+
+// Shutdown stops the node from starting new DKG and relay entry signing
+// executions and waits for the in-flight ones to complete. Executions which
+// do not complete before the context is done are abandoned and an error is
+// returned. Broadcast channels of groups are closed in either case.
+func (n *Node) Shutdown(ctx context.Context) error {
+	err := n.protocols.stop(ctx)
+
+	n.groupChannels.releaseAll()
+
+	return err
+}
+
+// InFlightProtocols returns the number of DKG and relay entry signing
+// executions currently in progress.
+func (n *Node) InFlightProtocols() int {
+	return n.protocols.count()
+}
+
+// InFlightExecutions returns descriptions of DKG and relay entry signing
+// executions currently in progress.
+func (n *Node) InFlightExecutions() []string {
+	return n.protocols.list()
+}
+
+// GroupCount returns the number of registered groups this node is a member
+// of.
+func (n *Node) GroupCount() int {
+	return n.groupRegistry.GroupCount()
 }
 
 // IsInGroup checks if this node is a member of the group which was selected to
@@ -80,9 +299,24 @@ func (n *Node) JoinGroupIfEligible(
 	channelName := newEntry.Text(16)
 
 	if len(indexes) > 0 {
+		execution := fmt.Sprintf(
+			"DKG for group selection seed [0x%v] starting at block [%v]",
+			channelName,
+			dkgStartBlockHeight,
+		)
+
+		if !n.protocols.start(execution) {
+			logger.Warningf(
+				"not joining group [%v]; the node is shutting down",
+				channelName,
+			)
+			return
+		}
+
 		broadcastChannel, err := n.netProvider.BroadcastChannelFor(channelName)
 		if err != nil {
 			logger.Errorf("failed to get broadcast channel: [%v]", err)
+			n.protocols.done(execution)
 			return
 		}
 
@@ -100,12 +334,56 @@ func (n *Node) JoinGroupIfEligible(
 			)
 		}
 
+		// The DKG channel is no longer needed once all members controlled by
+		// this node completed DKG and published the result.
+		var dkgWaitGroup sync.WaitGroup
+		dkgWaitGroup.Add(len(indexes))
+		go func() {
+			defer n.protocols.done(execution)
+
+			dkgWaitGroup.Wait()
+
+			if err := broadcastChannel.Close(); err != nil {
+				logger.Warningf(
+					"could not close DKG channel [%v]: [%v]",
+					broadcastChannel.Name(),
+					err,
+				)
+			}
+		}()
+
+		// All members of the group trace the DKG in the same trace as the
+		// group selection.
+		dkgCtx := audit.WithOperator(
+			tracing.WithTraceID(
+				n.protocols.ctx,
+				tracing.NewTraceID(tracing.GroupCreation, newEntry.Bytes()),
+			),
+			n.auditOperator(),
+		)
+
 		for _, index := range indexes {
 			// capture player index for goroutine
 			playerIndex := index
 
+			var gjkrOptions []gjkr.ExecuteOption
+			if n.dkgTranscripts != nil {
+				gjkrOptions = append(
+					gjkrOptions,
+					gjkr.WithTranscriptRecording(n.dkgTranscripts),
+				)
+			}
+
+			memberLogger := logging.WithLogFields(logger, logging.Fields{
+				logging.MemberField:  group.MemberIndex(playerIndex + 1),
+				logging.ChannelField: broadcastChannel.Name(),
+			})
+
 			go func() {
+				defer dkgWaitGroup.Done()
+
 				signer, err := dkg.ExecuteDKG(
+					dkgCtx,
 					newEntry,
 					playerIndex,
 					n.chainConfig.GroupSize,
@@ -116,9 +394,11 @@ func (n *Node) JoinGroupIfEligible(
 					relayChain,
 					signing,
 					broadcastChannel,
+					n.netProvider.Reputation(),
+					gjkrOptions...,
 				)
 				if err != nil {
-					logger.Errorf("failed to execute dkg: [%v]", err)
+					memberLogger.Errorf("failed to execute dkg: [%v]", err)
 					return
 				}
 
@@ -128,15 +408,19 @@ func (n *Node) JoinGroupIfEligible(
 					signer.GroupPublicKeyBytesCompressed(),
 				)
 
+				groupLogger := logging.WithLogFields(memberLogger, logging.Fields{
+					logging.GroupField: fmt.Sprintf(
+						"0x%x",
+						signer.GroupPublicKeyBytes(),
+					),
+				})
+
 				err = n.groupRegistry.RegisterGroup(signer, channelName)
 				if err != nil {
-					logger.Errorf("failed to register a group: [%v]", err)
+					groupLogger.Errorf("failed to register a group: [%v]", err)
 				}
 
-				logger.Infof(
-					"[member:%v] ready to operate in the group",
-					signer.MemberID(),
-				)
+				groupLogger.Infof("ready to operate in the group")
 			}()
 		}
 	}
@@ -144,6 +428,16 @@ func (n *Node) JoinGroupIfEligible(
 	return
 }
 
+// auditOperator returns the address of the operator of this node as recorded
+// in the audit log.
+func (n *Node) auditOperator() string {
+	if n.Staker == nil {
+		return ""
+	}
+
+	return fmt.Sprintf("0x%x", n.Staker.Address())
+}
+
 // ForwardSignatureShares enables the ability to forward signature shares
 // messages to other nodes even if this node is not a part of the group which
 // signs the relay entry.
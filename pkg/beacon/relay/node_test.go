@@ -0,0 +1,93 @@
+package relay
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestProtocolsStopWaitsForInFlightExecutions(t *testing.T) {
+	protocols := newProtocols()
+
+	if !protocols.start("execution") {
+		t.Fatal("expected protocol execution to start")
+	}
+
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		protocols.done("execution")
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	if err := protocols.stop(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if protocols.start("execution") {
+		t.Errorf("expected protocol execution to not start after stop")
+	}
+}
+
+func TestProtocolsStopAbandonsInFlightExecutions(t *testing.T) {
+	protocols := newProtocols()
+
+	if !protocols.start("execution") {
+		t.Fatal("expected protocol execution to start")
+	}
+
+	ctx, cancel := context.WithTimeout(
+		context.Background(),
+		100*time.Millisecond,
+	)
+	defer cancel()
+
+	err := protocols.stop(ctx)
+
+	expectedError := "abandoned [1] in-flight protocol executions: " +
+		"[context deadline exceeded]"
+	if err == nil || err.Error() != expectedError {
+		t.Fatalf(
+			"unexpected error\nexpected: [%v]\nactual:   [%v]",
+			expectedError,
+			err,
+		)
+	}
+
+	select {
+	case <-protocols.ctx.Done():
+	default:
+		t.Errorf("expected in-flight executions to be abandoned")
+	}
+}
+
+func TestProtocolsListInFlightExecutions(t *testing.T) {
+	protocols := newProtocols()
+
+	protocols.start("signing")
+	protocols.start("DKG")
+	protocols.start("DKG")
+
+	protocols.done("DKG")
+
+	expectedExecutions := []string{"DKG", "signing"}
+	if executions := protocols.list(); !reflect.DeepEqual(
+		expectedExecutions,
+		executions,
+	) {
+		t.Errorf(
+			"unexpected executions\nexpected: [%v]\nactual:   [%v]",
+			expectedExecutions,
+			executions,
+		)
+	}
+
+	protocols.done("DKG")
+	protocols.done("signing")
+
+	if executions := protocols.list(); len(executions) != 0 {
+		t.Errorf("unexpected executions: [%v]", executions)
+	}
+}
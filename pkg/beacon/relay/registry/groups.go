@@ -23,6 +23,11 @@ type Groups struct {
 	relayChain relaychain.GroupRegistrationInterface
 
 	storage storage
+	// loadErrors are the errors of loading memberships from the storage
+	// with LoadExistingGroups.
+	loadErrors []error
+
+	groupArchivedHandlers []func(channelName string)
 }
 
 // Membership represents a member of a group
@@ -78,6 +83,58 @@ func (g *Groups) GetGroup(groupPublicKey []byte) []*Membership {
 	return g.myGroups[groupKeyToString(groupPublicKey)]
 }
 
+// GroupCount returns the number of registered groups the client is
+// a member of.
+func (g *Groups) GroupCount() int {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	return len(g.myGroups)
+}
+
+// GroupPublicKeys returns public keys of registered groups the client is
+// a member of.
+func (g *Groups) GroupPublicKeys() [][]byte {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	publicKeys := make([][]byte, 0, len(g.myGroups))
+	for publicKey := range g.myGroups {
+		publicKeyBytes, err := groupKeyFromString(publicKey)
+		if err != nil {
+			logger.Errorf(
+				"error occurred while decoding public key into bytes: [%v]",
+				err,
+			)
+			continue
+		}
+
+		publicKeys = append(publicKeys, publicKeyBytes)
+	}
+
+	return publicKeys
+}
+
+// LoadErrors returns the errors of loading memberships from the storage
+// with LoadExistingGroups. Memberships which could not be loaded are not
+// registered.
+func (g *Groups) LoadErrors() []error {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	return append([]error{}, g.loadErrors...)
+}
+
+// OnGroupArchived registers a handler called with the broadcast channel name
+// of every group archived by UnregisterStaleGroups. The handler is called
+// synchronously and must not call back into the registry.
+func (g *Groups) OnGroupArchived(handler func(channelName string)) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	g.groupArchivedHandlers = append(g.groupArchivedHandlers, handler)
+}
+
 // UnregisterStaleGroups lookup for groups that have been marked as stale
 // on-chain. A stale group is a group that has expired and a certain time passed
 // after the group expiration. This guarantees the group will not be selected to
@@ -138,6 +195,10 @@ func (g *Groups) UnregisterStaleGroups(latestGroupPublicKey []byte) {
 				)
 
 				delete(g.myGroups, publicKey)
+
+				for _, handler := range g.groupArchivedHandlers {
+					handler(memberships[0].ChannelName)
+				}
 			}
 		}
 	}
@@ -173,12 +234,14 @@ func (g *Groups) LoadExistingGroups() {
 		wg.Done()
 	}()
 
+	var loadErrors []error
 	go func() {
 		for err := range errorsChannel {
 			logger.Errorf(
 				"could not load membership from disk: [%v]",
 				err,
 			)
+			loadErrors = append(loadErrors, err)
 		}
 
 		wg.Done()
@@ -186,6 +249,10 @@ func (g *Groups) LoadExistingGroups() {
 
 	wg.Wait()
 
+	g.mutex.Lock()
+	g.loadErrors = loadErrors
+	g.mutex.Unlock()
+
 	g.printMemberships()
 }
 
@@ -97,6 +97,10 @@ func TestLoadGroup(t *testing.T) {
 		)
 	}
 
+	if len(gr.LoadErrors()) != 0 {
+		t.Errorf("unexpected load errors: [%v]", gr.LoadErrors())
+	}
+
 	expectedMembership1 := &Membership{
 		Signer:      signer1,
 		ChannelName: channelName1,
@@ -116,6 +120,29 @@ func TestLoadGroup(t *testing.T) {
 	}
 }
 
+func TestLoadGroupWithCorruptedMembership(t *testing.T) {
+	chain := chainLocal.Connect(5, 3, big.NewInt(200)).ThresholdRelay()
+	gr := NewGroupRegistry(chain, &corruptedPersistenceHandleMock{})
+
+	gr.LoadExistingGroups()
+
+	if gr.GroupCount() != 0 {
+		t.Errorf(
+			"unexpected number of groups\nexpected: [%v]\nactual:   [%v]",
+			0,
+			gr.GroupCount(),
+		)
+	}
+
+	if len(gr.LoadErrors()) != 1 {
+		t.Errorf(
+			"unexpected number of load errors\nexpected: [%v]\nactual:   [%v]",
+			1,
+			len(gr.LoadErrors()),
+		)
+	}
+}
+
 func TestUnregisterStaleGroups(t *testing.T) {
 	mockChain := &mockGroupRegistrationInterface{
 		groupsToRemove: [][]byte{},
@@ -124,8 +151,13 @@ func TestUnregisterStaleGroups(t *testing.T) {
 
 	gr := NewGroupRegistry(mockChain, persistenceMock)
 
+	var archivedChannels []string
+	gr.OnGroupArchived(func(channelName string) {
+		archivedChannels = append(archivedChannels, channelName)
+	})
+
 	gr.RegisterGroup(signer1, channelName1)
-	gr.RegisterGroup(signer2, channelName1)
+	gr.RegisterGroup(signer2, channelName2)
 	gr.RegisterGroup(signer3, channelName1)
 
 	mockChain.markAsStale(signer2.GroupPublicKeyBytes())
@@ -145,6 +177,13 @@ func TestUnregisterStaleGroups(t *testing.T) {
 		persistenceMock.archivedGroups[0] != hex.EncodeToString(signer2.GroupPublicKeyBytesCompressed()) {
 		t.Fatalf("Group2 was expected to be archived")
 	}
+	if !reflect.DeepEqual([]string{channelName2}, archivedChannels) {
+		t.Fatalf(
+			"Unexpected archived group channels\nExpected: [%v]\nActual:   [%v]",
+			[]string{channelName2},
+			archivedChannels,
+		)
+	}
 
 	group3 := gr.GetGroup(signer3.GroupPublicKeyBytes())
 	if group3 == nil {
@@ -262,6 +301,25 @@ func (phm *persistenceHandleMock) Archive(directory string) error {
 	return nil
 }
 
+type corruptedPersistenceHandleMock struct {
+	persistenceHandleMock
+}
+
+func (cphm *corruptedPersistenceHandleMock) ReadAll() (
+	<-chan persistence.DataDescriptor,
+	<-chan error,
+) {
+	outputData := make(chan persistence.DataDescriptor, 1)
+	outputErrors := make(chan error)
+
+	outputData <- &testDataDescriptor{"1", "dir", []byte{0x01, 0x02}}
+
+	close(outputData)
+	close(outputErrors)
+
+	return outputData, outputErrors
+}
+
 type testDataDescriptor struct {
 	name      string
 	directory string
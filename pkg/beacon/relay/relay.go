@@ -1,7 +1,12 @@
 package relay
 
 import (
+	"fmt"
+	"sync"
+
 	"github.com/ipfs/go-log"
+	"github.com/keep-network/keep-common/pkg/persistence"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/audit"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
 
 	relayChain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
@@ -10,7 +15,9 @@ import (
 
 	"github.com/keep-network/keep-core/pkg/beacon/relay/registry"
 	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/logging"
 	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/tracing"
 )
 
 var logger = log.Logger("keep-relay")
@@ -25,13 +32,41 @@ func NewNode(
 	blockCounter chain.BlockCounter,
 	chainConfig *relayChain.Config,
 	groupRegistry *registry.Groups,
+	options ...NodeOption,
 ) Node {
+	groupChannels := newGroupChannels(netProvider)
+	groupRegistry.OnGroupArchived(groupChannels.release)
+
+	nodeOptions := &nodeOptions{}
+	for _, option := range options {
+		option(nodeOptions)
+	}
+
 	return Node{
-		Staker:        staker,
-		netProvider:   netProvider,
-		blockCounter:  blockCounter,
-		chainConfig:   chainConfig,
-		groupRegistry: groupRegistry,
+		Staker:         staker,
+		netProvider:    netProvider,
+		blockCounter:   blockCounter,
+		chainConfig:    chainConfig,
+		groupRegistry:  groupRegistry,
+		groupChannels:  groupChannels,
+		protocols:      newProtocols(),
+		dkgTranscripts: nodeOptions.dkgTranscripts,
+	}
+}
+
+// NodeOption is an optional setting of the node.
+type NodeOption func(*nodeOptions)
+
+type nodeOptions struct {
+	dkgTranscripts persistence.Handle
+}
+
+// WithDKGTranscripts records transcripts of DKG executions of the node with
+// the given persistence handle. Transcripts contain secret material, so the
+// handle should encrypt them.
+func WithDKGTranscripts(handle persistence.Handle) NodeOption {
+	return func(options *nodeOptions) {
+		options.dkgTranscripts = handle
 	}
 }
 
@@ -71,7 +106,16 @@ func (n *Node) MonitorRelayEntry(
 			err = relayChain.ReportRelayEntryTimeout()
 			if err != nil {
 				logger.Errorf("could not report a relay entry timeout: [%v]", err)
+				return
 			}
+			audit.Log(&audit.Record{
+				Event:    audit.RelayEntryTimeoutReported,
+				Block:    blockNumber,
+				Operator: n.auditOperator(),
+				Details: map[string]interface{}{
+					"request_block_number": relayRequestBlockNumber,
+				},
+			})
 			return
 		case entry := <-onEntrySubmittedChannel:
 			logger.Infof(
@@ -103,9 +147,27 @@ func (n *Node) GenerateRelayEntry(
 		return
 	}
 
-	channel, err := n.netProvider.BroadcastChannelFor(memberships[0].ChannelName)
+	execution := fmt.Sprintf(
+		"relay entry signing for group [0x%x] starting at block [%v]",
+		groupPublicKey,
+		startBlockHeight,
+	)
+
+	groupLogger := logging.WithLogFields(logger, logging.Fields{
+		logging.GroupField: fmt.Sprintf("0x%x", groupPublicKey),
+	})
+
+	if !n.protocols.start(execution) {
+		groupLogger.Warningf(
+			"not generating relay entry; the node is shutting down",
+		)
+		return
+	}
+
+	channel, err := n.groupChannels.get(memberships[0].ChannelName)
 	if err != nil {
-		logger.Errorf("could not create broadcast channel: [%v]", err)
+		groupLogger.Errorf("could not create broadcast channel: [%v]", err)
+		n.protocols.done(execution)
 		return
 	}
 
@@ -113,7 +175,8 @@ func (n *Node) GenerateRelayEntry(
 
 	groupMembers, err := relayChain.GetGroupMembers(groupPublicKey)
 	if err != nil {
-		logger.Errorf("could not get group members: [%v]", err)
+		groupLogger.Errorf("could not get group members: [%v]", err)
+		n.protocols.done(execution)
 		return
 	}
 
@@ -124,18 +187,40 @@ func (n *Node) GenerateRelayEntry(
 
 	err = channel.SetFilter(membershipValidator.IsInGroup)
 	if err != nil {
-		logger.Errorf(
+		groupLogger.Errorf(
 			"could not set filter for channel [%v]: [%v]",
 			channel.Name(),
 			err,
 		)
 	}
 
+	// The execution is complete once all members controlled by this node
+	// completed signing.
+	var signingWaitGroup sync.WaitGroup
+	signingWaitGroup.Add(len(memberships))
+	go func() {
+		signingWaitGroup.Wait()
+		n.protocols.done(execution)
+	}()
+
+	// All members of the group trace the signing in the same trace.
+	signingCtx := audit.WithOperator(
+		tracing.WithTraceID(
+			n.protocols.ctx,
+			tracing.NewTraceID(tracing.RelayEntry, previousEntry),
+		),
+		n.auditOperator(),
+	)
+
 	for _, member := range memberships {
 		go func(member *registry.Membership) {
-			err = entry.SignAndSubmit(
+			defer signingWaitGroup.Done()
+
+			err := entry.SignAndSubmit(
+				signingCtx,
 				n.blockCounter,
 				channel,
+				n.netProvider.Reputation(),
 				relayChain,
 				previousEntry,
 				n.chainConfig.HonestThreshold,
@@ -143,7 +228,9 @@ func (n *Node) GenerateRelayEntry(
 				startBlockHeight,
 			)
 			if err != nil {
-				logger.Errorf(
+				logging.WithLogFields(groupLogger, logging.Fields{
+					logging.MemberField: member.Signer.MemberID(),
+				}).Errorf(
 					"error creating threshold signature: [%v]",
 					err,
 				)
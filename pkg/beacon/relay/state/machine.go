@@ -3,8 +3,11 @@ package state
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/logging"
 	"github.com/keep-network/keep-core/pkg/net"
 )
 
@@ -22,6 +25,9 @@ type Machine struct {
 	channel      net.BroadcastChannel
 	blockCounter chain.BlockCounter
 	initialState State // first state from which execution starts
+
+	stateInitiatedHandlers  []func(initiatedState State, blockHeight uint64)
+	messageReceivedHandlers []func(receivingState State, message net.Message)
 }
 
 // NewMachine returns a new state machine. It requires a broadcast channel and
@@ -38,104 +44,213 @@ func NewMachine(
 	}
 }
 
+// OnStateInitiated registers a handler called each time a state has been
+// initiated, along with the block at which the state has been initiated. The
+// handler is called before the state receives any message. It must be
+// registered before the machine is executed.
+func (m *Machine) OnStateInitiated(
+	handler func(initiatedState State, blockHeight uint64),
+) {
+	m.stateInitiatedHandlers = append(m.stateInitiatedHandlers, handler)
+}
+
+// OnMessageReceived registers a handler called with each message received by
+// the machine, along with the state the message is passed to. The handler is
+// called before the state receives the message. It must be registered before
+// the machine is executed.
+func (m *Machine) OnMessageReceived(
+	handler func(receivingState State, message net.Message),
+) {
+	m.messageReceivedHandlers = append(m.messageReceivedHandlers, handler)
+}
+
 // Execute state machine starting with initial state up to finalization. It
-// requires the broadcast channel to be pre-initialized.
-func (m *Machine) Execute(startBlockHeight uint64) (State, uint64, error) {
+// requires the broadcast channel to be pre-initialized. The execution is
+// abandoned with an error once the given context is done. Log messages of
+// the machine and contexts passed to states carry the member index, channel
+// name and the current state as logging fields.
+func (m *Machine) Execute(
+	ctx context.Context,
+	startBlockHeight uint64,
+) (State, uint64, error) {
+	ctx = logging.WithFields(ctx, logging.Fields{
+		logging.MemberField:  m.initialState.MemberIndex(),
+		logging.ChannelField: m.channel.Name(),
+	})
+
 	recvChan := make(chan net.Message, receiveBuffer)
 	handler := func(msg net.Message) {
 		recvChan <- msg
 	}
 
+	// Missed messages are requested since the beginning of the previous
+	// state as messages of the current state may be sent by members which
+	// entered it earlier. For the first state, all messages the channel
+	// has received before the execution started are requested.
+	previousStateStart := time.Time{}
+	currentStateStart := time.Now()
+
 	currentState := m.initialState
-	ctx, cancelCtx := context.WithCancel(context.Background())
-	m.channel.Recv(ctx, handler)
+	stateCtx, cancelStateCtx := context.WithCancel(withStateFields(ctx, currentState))
+	m.channel.Recv(stateCtx, handler)
 
-	logger.Infof(
-		"[member:%v,channel:%s] waiting for block %v to start execution",
-		currentState.MemberIndex(),
-		m.channel.Name()[:5],
+	logging.ForContext(ctx, logger).Infof(
+		"waiting for block [%v] to start execution",
 		startBlockHeight,
 	)
-	err := m.blockCounter.WaitForBlockHeight(startBlockHeight)
+	startBlockWaiter, err := m.blockCounter.BlockHeightWaiter(startBlockHeight)
 	if err != nil {
+		cancelStateCtx()
 		return nil, 0, fmt.Errorf("failed to wait for the execution start block")
 	}
+	select {
+	case <-startBlockWaiter:
+	case <-ctx.Done():
+		cancelStateCtx()
+		return nil, 0, fmt.Errorf(
+			"execution cancelled before the start block: [%v]",
+			ctx.Err(),
+		)
+	}
 
 	lastStateEndBlockHeight := startBlockHeight
 
-	blockWaiter, err := stateTransition(
-		ctx,
+	blockWaiter, catchUpWaiter, err := stateTransition(
+		stateCtx,
 		currentState,
 		lastStateEndBlockHeight,
 		m.blockCounter,
-		m.channel.Name()[:5],
 	)
 	if err != nil {
-		cancelCtx()
+		cancelStateCtx()
 		return nil, 0, err
 	}
+	m.notifyStateInitiated(currentState, lastStateEndBlockHeight)
 
 	for {
 		select {
 		case msg := <-recvChan:
+			for _, handler := range m.messageReceivedHandlers {
+				handler(currentState, msg)
+			}
+
 			err := currentState.Receive(msg)
 			if err != nil {
-				logger.Errorf(
-					"[member:%v,channel:%s, state: %T] failed to receive a message: [%v]",
-					currentState.MemberIndex(),
-					m.channel.Name()[:5],
-					currentState,
+				logging.ForContext(stateCtx, logger).Errorf(
+					"failed to receive a message: [%v]",
 					err,
 				)
 			}
 
+		case <-catchUpWaiter:
+			catchUpWaiter = nil
+			go m.requestMissedMessages(stateCtx, previousStateStart)
+
+		case <-ctx.Done():
+			cancelStateCtx()
+			return nil, 0, fmt.Errorf(
+				"execution cancelled at state [%T]: [%v]",
+				currentState,
+				ctx.Err(),
+			)
+
 		case lastStateEndBlockHeight := <-blockWaiter:
-			cancelCtx()
+			cancelStateCtx()
 			nextState := currentState.Next()
 			if nextState == nil {
-				logger.Infof(
-					"[member:%v,channel:%s,state:%T] reached final state at block: [%v]",
-					currentState.MemberIndex(),
-					m.channel.Name()[:5],
-					currentState,
+				logging.ForContext(stateCtx, logger).Infof(
+					"reached final state at block [%v]",
 					lastStateEndBlockHeight,
 				)
 				return currentState, lastStateEndBlockHeight, nil
 			}
 
 			currentState = nextState
-			ctx, cancelCtx = context.WithCancel(context.Background())
-			m.channel.Recv(ctx, handler)
+			previousStateStart = currentStateStart
+			currentStateStart = time.Now()
+			stateCtx, cancelStateCtx = context.WithCancel(
+				withStateFields(ctx, currentState),
+			)
+			m.channel.Recv(stateCtx, handler)
 
-			blockWaiter, err = stateTransition(
-				ctx,
+			blockWaiter, catchUpWaiter, err = stateTransition(
+				stateCtx,
 				currentState,
 				lastStateEndBlockHeight,
 				m.blockCounter,
-				m.channel.Name()[:5],
 			)
 			if err != nil {
-				cancelCtx()
+				cancelStateCtx()
 				return nil, 0, err
 			}
+			m.notifyStateInitiated(currentState, lastStateEndBlockHeight)
 
 			continue
 		}
 	}
 }
 
+func (m *Machine) notifyStateInitiated(
+	initiatedState State,
+	lastStateEndBlockHeight uint64,
+) {
+	for _, handler := range m.stateInitiatedHandlers {
+		handler(
+			initiatedState,
+			lastStateEndBlockHeight+initiatedState.DelayBlocks(),
+		)
+	}
+}
+
+// requestMissedMessages requests messages the current state might have
+// missed, e.g. because they arrived before the state started receiving
+// messages or while the client was disconnected. Messages already received
+// by the state are filtered out by the channel.
+func (m *Machine) requestMissedMessages(
+	ctx context.Context,
+	since time.Time,
+) {
+	err := m.channel.RequestMissedMessages(
+		ctx,
+		net.MissedMessagesFilter{Since: since},
+	)
+	if err != nil && ctx.Err() == nil {
+		logging.ForContext(ctx, logger).Warningf(
+			"could not request missed messages: [%v]",
+			err,
+		)
+	}
+}
+
+// withStateFields returns a copy of the context with the name of the given
+// state as a logging field.
+func withStateFields(ctx context.Context, currentState State) context.Context {
+	return logging.WithFields(ctx, logging.Fields{
+		logging.StateField: stateName(currentState),
+	})
+}
+
+// stateName returns the name of the state's type, e.g.
+// `gjkr.ephemeralKeyPairGenerationState`.
+func stateName(currentState State) string {
+	return strings.TrimPrefix(fmt.Sprintf("%T", currentState), "*")
+}
+
+// stateTransition initiates the current state and returns a channel which
+// receives the block at which the state ends and a channel which receives
+// the block in the middle of the state's active period, at which missed
+// messages should be requested. The latter is nil if the state has no
+// active blocks.
 func stateTransition(
 	ctx context.Context,
 	currentState State,
 	lastStateEndBlockHeight uint64,
 	blockCounter chain.BlockCounter,
-	channelName string,
-) (<-chan uint64, error) {
-	logger.Infof(
-		"[member:%v,channel:%s,state:%T] transitioning to a new state at block: [%v]",
-		currentState.MemberIndex(),
-		channelName,
-		currentState,
+) (<-chan uint64, <-chan uint64, error) {
+	stateLogger := logging.ForContext(ctx, logger)
+
+	stateLogger.Infof(
+		"transitioning to a new state at block [%v]",
 		lastStateEndBlockHeight,
 	)
 
@@ -146,7 +261,7 @@ func stateTransition(
 	initiateDelay := lastStateEndBlockHeight + currentState.DelayBlocks()
 	err := blockCounter.WaitForBlockHeight(initiateDelay)
 	if err != nil {
-		return nil, fmt.Errorf(
+		return nil, nil, fmt.Errorf(
 			"failed to wait [%v] blocks entering state [%T]: [%v]",
 			currentState.DelayBlocks(),
 			currentState,
@@ -156,26 +271,35 @@ func stateTransition(
 
 	err = currentState.Initiate(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to initiate new state [%v]", err)
+		return nil, nil, fmt.Errorf("failed to initiate new state [%v]", err)
 	}
 
 	blockWaiter, err := blockCounter.BlockHeightWaiter(
 		initiateDelay + currentState.ActiveBlocks(),
 	)
 	if err != nil {
-		return nil, fmt.Errorf(
+		return nil, nil, fmt.Errorf(
 			"failed to initialize block height waiter at state [%T]: [%v]",
 			currentState,
 			err,
 		)
 	}
 
-	logger.Infof(
-		"[member:%v,channel:%s,state:%T] transitioned to new state",
-		currentState.MemberIndex(),
-		channelName,
-		currentState,
-	)
+	var catchUpWaiter <-chan uint64
+	if currentState.ActiveBlocks() > 0 {
+		catchUpWaiter, err = blockCounter.BlockHeightWaiter(
+			initiateDelay + currentState.ActiveBlocks()/2,
+		)
+		if err != nil {
+			return nil, nil, fmt.Errorf(
+				"failed to initialize catch-up block height waiter at state [%T]: [%v]",
+				currentState,
+				err,
+			)
+		}
+	}
+
+	stateLogger.Infof("transitioned to new state")
 
-	return blockWaiter, nil
+	return blockWaiter, catchUpWaiter, nil
 }
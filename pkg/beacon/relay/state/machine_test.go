@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"math/big"
 	"reflect"
+	"sort"
 	"testing"
 
 	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
@@ -56,7 +57,30 @@ func TestExecute(t *testing.T) {
 
 	stateMachine := NewMachine(channel, blockCounter, initialState)
 
-	finalState, endBlockHeight, err := stateMachine.Execute(1)
+	initiatedStates := []string{}
+	stateMachine.OnStateInitiated(func(initiatedState State, blockHeight uint64) {
+		initiatedStates = append(
+			initiatedStates,
+			fmt.Sprintf("%T-%v", initiatedState, blockHeight),
+		)
+	})
+
+	receivedMessages := []string{}
+	stateMachine.OnMessageReceived(func(receivingState State, message net.Message) {
+		receivedMessages = append(
+			receivedMessages,
+			fmt.Sprintf(
+				"%T-%v",
+				receivingState,
+				message.Payload().(*TestMessage).content,
+			),
+		)
+	})
+
+	finalState, endBlockHeight, err := stateMachine.Execute(
+		context.Background(),
+		1,
+	)
 	if err != nil {
 		t.Errorf("unexpected error [%v]", err)
 	}
@@ -75,7 +99,12 @@ func TestExecute(t *testing.T) {
 			"1-state.testState1-receive-message_1",
 		},
 		3: []string{"1-state.testState2-initiate"},
-		4: []string{"1-state.testState2-receive-message_2"},
+		// Messages received by the previous state are redelivered to the
+		// current one in the middle of its active period.
+		4: []string{
+			"1-state.testState2-receive-message_1",
+			"1-state.testState2-receive-message_2",
+		},
 		6: []string{
 			"1-state.testState3-initiate",
 			"1-state.testState4-initiate",
@@ -88,9 +117,87 @@ func TestExecute(t *testing.T) {
 		},
 	}
 
+	// Messages received in the same block may be handled in any order.
+	for _, entries := range testLog {
+		sort.Strings(entries)
+	}
+
 	if !reflect.DeepEqual(expectedTestLog, testLog) {
 		t.Errorf("\nexpected: %v\nactual:   %v\n", expectedTestLog, testLog)
 	}
+
+	expectedInitiatedStates := []string{
+		"state.testState1-1",
+		"*state.testState2-3",
+		"*state.testState3-6",
+		"*state.testState4-6",
+		"*state.testState5-8",
+	}
+
+	if !reflect.DeepEqual(expectedInitiatedStates, initiatedStates) {
+		t.Errorf(
+			"unexpected initiated states\nexpected: %v\nactual:   %v\n",
+			expectedInitiatedStates,
+			initiatedStates,
+		)
+	}
+
+	// Messages received in the same block may be handled in any order.
+	sort.Strings(receivedMessages)
+	expectedReceivedMessages := []string{
+		"*state.testState2-message_1",
+		"*state.testState2-message_2",
+		"*state.testState4-message_3",
+		"state.testState1-message_1",
+	}
+
+	if !reflect.DeepEqual(expectedReceivedMessages, receivedMessages) {
+		t.Errorf(
+			"unexpected received messages\nexpected: %v\nactual:   %v\n",
+			expectedReceivedMessages,
+			receivedMessages,
+		)
+	}
+}
+
+func TestExecuteCancelled(t *testing.T) {
+	testLog = make(map[uint64][]string)
+
+	localChain := chainLocal.Connect(10, 5, big.NewInt(200))
+	blockCounter, _ = localChain.BlockCounter()
+	provider := netLocal.Connect()
+	channel, err := provider.BroadcastChannelFor("cancellation_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func(blockCounter chain.BlockCounter) {
+		blockCounter.WaitForBlockHeight(2)
+		cancel()
+	}(blockCounter)
+
+	initialState := testState1{
+		memberIndex: group.MemberIndex(1),
+		channel:     channel,
+	}
+
+	stateMachine := NewMachine(channel, blockCounter, initialState)
+
+	finalState, _, err := stateMachine.Execute(ctx, 1)
+
+	expectedError := "execution cancelled at state [state.testState1]: " +
+		"[context canceled]"
+	if err == nil || err.Error() != expectedError {
+		t.Fatalf(
+			"unexpected error\nexpected: [%v]\nactual:   [%v]",
+			expectedError,
+			err,
+		)
+	}
+	if finalState != nil {
+		t.Errorf("unexpected final state [%v]", finalState)
+	}
 }
 
 func addToTestLog(testState State, functionName string) {
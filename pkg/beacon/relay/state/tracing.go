@@ -0,0 +1,58 @@
+package state
+
+import (
+	"context"
+	"sync"
+
+	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/tracing"
+)
+
+// TraceStates records a span for each state executed by the machine as
+// a child of the span in the given context. The span of a state ends once the
+// next state is initiated and the span of the last state ends once the
+// returned function is called with the error the execution ended with. It
+// must be called before the machine is executed.
+func TraceStates(ctx context.Context, machine *Machine) func(err error) {
+	var (
+		mutex            sync.Mutex
+		currentSpan      *tracing.Span
+		receivedMessages int
+	)
+
+	endCurrentSpan := func(err error) {
+		currentSpan.SetAttribute("received_messages", receivedMessages)
+		currentSpan.End(err)
+	}
+
+	machine.OnStateInitiated(func(initiatedState State, blockHeight uint64) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		endCurrentSpan(nil)
+
+		_, currentSpan = tracing.Start(
+			ctx,
+			stateName(initiatedState),
+			map[string]interface{}{
+				"member":      initiatedState.MemberIndex(),
+				"start_block": blockHeight,
+			},
+		)
+		receivedMessages = 0
+	})
+
+	machine.OnMessageReceived(func(receivingState State, message net.Message) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		receivedMessages++
+	})
+
+	return func(err error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		endCurrentSpan(err)
+	}
+}
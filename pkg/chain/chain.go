@@ -8,6 +8,7 @@ import (
 
 	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
 	"github.com/keep-network/keep-core/pkg/gen/async"
+	"github.com/keep-network/keep-core/pkg/subscription"
 )
 
 // BlockCounter is an interface that provides the ability to wait for a certain
@@ -67,6 +68,48 @@ type BalanceMonitor interface {
 	)
 }
 
+// ContractRegistry is an interface that provides the ability to monitor
+// operator contract upgrades approved in the on-chain contract registry.
+type ContractRegistry interface {
+	// OperatorContract returns the address of the operator contract the client
+	// works with.
+	OperatorContract() string
+	// OnOperatorContractApproved registers a callback that is invoked when
+	// an operator contract other than the one the client works with gets
+	// approved in the registry.
+	OnOperatorContractApproved(
+		handler func(operatorContract string),
+	) (subscription.EventSubscription, error)
+}
+
+// SubscriptionFailure describes a failure of a chain event subscription.
+type SubscriptionFailure struct {
+	// Event is the name of the event the subscription has been created for.
+	Event string
+	// Error describes why the subscription failed.
+	Error string
+	// Time is when the subscription failed.
+	Time time.Time
+}
+
+// PendingTransaction describes a transaction submitted to the chain by the
+// client which has not been mined yet.
+type PendingTransaction struct {
+	// Hash of the most recently submitted version of the transaction.
+	Hash   string
+	Method string
+	Nonce  uint64
+	// GasPrice of the most recently submitted version of the transaction.
+	GasPrice         *big.Int
+	SubmittedAtBlock uint64
+	// Resubmissions is the number of times the transaction has been replaced
+	// with a transaction having a higher gas price.
+	Resubmissions int
+	// Cancelled is true if the transaction is no longer useful and has been
+	// replaced with a transaction doing nothing.
+	Cancelled bool
+}
+
 // Signing is an interface that provides ability to sign and verify
 // signatures using operator's key associated with the chain.
 type Signing interface {
@@ -109,6 +152,19 @@ type Handle interface {
 	BlockCounter() (BlockCounter, error)
 	StakeMonitor() (StakeMonitor, error)
 	BalanceMonitor() (BalanceMonitor, error)
+	ContractRegistry() (ContractRegistry, error)
+	// PendingTransactions returns transactions submitted by the client which
+	// have not been mined yet.
+	PendingTransactions() []PendingTransaction
+	// SubscriptionFailures returns the most recent failure of each chain
+	// event subscription which failed within the given period. Failed
+	// subscriptions are renewed automatically.
+	SubscriptionFailures(period time.Duration) []SubscriptionFailure
+	// ResubscribeBlocks renews the subscription to new blocks of the block
+	// counter over a new connection to the chain. Goroutines waiting for or
+	// watching blocks of the block counter keep working with the renewed
+	// subscription.
+	ResubscribeBlocks() error
 	ThresholdRelay() relaychain.Interface
 	Signing() Signing
 }
@@ -0,0 +1,165 @@
+package ethereum
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	goethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/keep-network/keep-common/pkg/chain/ethereum/ethutil"
+)
+
+// dialTimeout is the timeout for establishing a new connection to the
+// Ethereum node when the subscription to new blocks is renewed.
+const dialTimeout = 30 * time.Second
+
+type newHeadSubscriber interface {
+	SubscribeNewHead(
+		ctx context.Context,
+		ch chan<- *types.Header,
+	) (goethereum.Subscription, error)
+}
+
+type dialedNewHeadSubscriber interface {
+	newHeadSubscriber
+	Close()
+}
+
+// blockSubscriptionClient is the client given to the block counter. It
+// routes subscriptions to new blocks through the most recently dialed
+// connection to the Ethereum node while all other requests go through the
+// client it wraps.
+//
+// When the subscription to new blocks silently stops delivering new blocks,
+// the block counter does not notice it and all goroutines waiting for blocks
+// are blocked forever. Renewing the subscription interrupts the current one
+// with an error. The block counter then subscribes again, this time over a
+// new connection, and keeps delivering blocks to its existing waiters.
+type blockSubscriptionClient struct {
+	ethutil.EthereumClient
+
+	dial func(ctx context.Context) (dialedNewHeadSubscriber, error)
+
+	mutex        sync.Mutex
+	subscriber   newHeadSubscriber
+	dialed       dialedNewHeadSubscriber
+	subscription *interruptibleSubscription
+}
+
+func newBlockSubscriptionClient(
+	url string,
+	client ethutil.EthereumClient,
+) *blockSubscriptionClient {
+	return &blockSubscriptionClient{
+		EthereumClient: client,
+		dial: func(ctx context.Context) (dialedNewHeadSubscriber, error) {
+			return ethclient.DialContext(ctx, url)
+		},
+		subscriber: client,
+	}
+}
+
+// SubscribeNewHead subscribes to new blocks over the most recently dialed
+// connection to the Ethereum node.
+func (bsc *blockSubscriptionClient) SubscribeNewHead(
+	ctx context.Context,
+	ch chan<- *types.Header,
+) (goethereum.Subscription, error) {
+	bsc.mutex.Lock()
+	defer bsc.mutex.Unlock()
+
+	subscription, err := bsc.subscriber.SubscribeNewHead(ctx, ch)
+	if err != nil {
+		return nil, err
+	}
+
+	bsc.subscription = newInterruptibleSubscription(subscription)
+
+	return bsc.subscription, nil
+}
+
+// resubscribe dials a new connection to the Ethereum node and interrupts the
+// current subscription to new blocks so that the block counter subscribes
+// again over the new connection. The connection dialed on the previous call
+// is closed.
+func (bsc *blockSubscriptionClient) resubscribe() error {
+	ctx, cancel := context.WithTimeout(context.Background(), dialTimeout)
+	defer cancel()
+
+	dialed, err := bsc.dial(ctx)
+	if err != nil {
+		return fmt.Errorf("could not connect to Ethereum node: [%v]", err)
+	}
+
+	bsc.mutex.Lock()
+	previouslyDialed := bsc.dialed
+	subscription := bsc.subscription
+	bsc.subscriber = dialed
+	bsc.dialed = dialed
+	bsc.subscription = nil
+	bsc.mutex.Unlock()
+
+	if subscription != nil {
+		subscription.interrupt(
+			fmt.Errorf("subscription to new blocks is being renewed"),
+		)
+	}
+
+	if previouslyDialed != nil {
+		previouslyDialed.Close()
+	}
+
+	return nil
+}
+
+// interruptibleSubscription is a subscription whose error channel can be
+// fed with an error by the client, in addition to errors of the underlying
+// subscription.
+type interruptibleSubscription struct {
+	goethereum.Subscription
+
+	err          chan error
+	unsubscribed chan struct{}
+	once         sync.Once
+}
+
+func newInterruptibleSubscription(
+	subscription goethereum.Subscription,
+) *interruptibleSubscription {
+	is := &interruptibleSubscription{
+		Subscription: subscription,
+		err:          make(chan error, 1),
+		unsubscribed: make(chan struct{}),
+	}
+
+	go func() {
+		select {
+		case err, ok := <-subscription.Err():
+			if ok {
+				is.interrupt(err)
+			}
+		case <-is.unsubscribed:
+		}
+	}()
+
+	return is
+}
+
+func (is *interruptibleSubscription) Err() <-chan error {
+	return is.err
+}
+
+func (is *interruptibleSubscription) Unsubscribe() {
+	is.once.Do(func() { close(is.unsubscribed) })
+	is.Subscription.Unsubscribe()
+}
+
+func (is *interruptibleSubscription) interrupt(err error) {
+	select {
+	case is.err <- err:
+	default: // the subscription has been already interrupted
+	}
+}
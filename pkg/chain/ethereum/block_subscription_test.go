@@ -0,0 +1,156 @@
+package ethereum
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	goethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestBlockSubscriptionClientResubscribe(t *testing.T) {
+	initial := newTestNewHeadSubscriber()
+	dialed := []*testNewHeadSubscriber{}
+
+	client := &blockSubscriptionClient{
+		dial: func(ctx context.Context) (dialedNewHeadSubscriber, error) {
+			subscriber := newTestNewHeadSubscriber()
+			dialed = append(dialed, subscriber)
+			return subscriber, nil
+		},
+		subscriber: initial,
+	}
+
+	headers := make(chan *types.Header)
+
+	subscription, err := client.SubscribeNewHead(context.Background(), headers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if initial.subscriptions != 1 {
+		t.Fatalf("expected subscription over the initial connection")
+	}
+
+	if err := client.resubscribe(); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case err := <-subscription.Err():
+		if err == nil {
+			t.Errorf("expected subscription interruption error")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the subscription to be interrupted")
+	}
+	subscription.Unsubscribe()
+
+	if _, err := client.SubscribeNewHead(context.Background(), headers); err != nil {
+		t.Fatal(err)
+	}
+	if dialed[0].subscriptions != 1 {
+		t.Fatalf("expected subscription over the dialed connection")
+	}
+
+	if err := client.resubscribe(); err != nil {
+		t.Fatal(err)
+	}
+
+	if !dialed[0].closed {
+		t.Errorf("expected the previously dialed connection to be closed")
+	}
+	if dialed[1].closed {
+		t.Errorf("expected the recently dialed connection to stay open")
+	}
+	if initial.closed {
+		t.Errorf("expected the initial connection to stay open")
+	}
+}
+
+func TestBlockSubscriptionClientResubscribeFailure(t *testing.T) {
+	initial := newTestNewHeadSubscriber()
+
+	client := &blockSubscriptionClient{
+		dial: func(ctx context.Context) (dialedNewHeadSubscriber, error) {
+			return nil, fmt.Errorf("connection refused")
+		},
+		subscriber: initial,
+	}
+
+	subscription, err := client.SubscribeNewHead(
+		context.Background(),
+		make(chan *types.Header),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedError := "could not connect to Ethereum node: [connection refused]"
+	err = client.resubscribe()
+	if err == nil || err.Error() != expectedError {
+		t.Fatalf(
+			"unexpected error\nexpected: [%v]\nactual:   [%v]",
+			expectedError,
+			err,
+		)
+	}
+
+	select {
+	case err := <-subscription.Err():
+		t.Errorf("unexpected subscription interruption: [%v]", err)
+	default:
+	}
+}
+
+func TestInterruptibleSubscriptionForwardsErrors(t *testing.T) {
+	underlying := newTestSubscription()
+	subscription := newInterruptibleSubscription(underlying)
+
+	underlying.err <- fmt.Errorf("connection lost")
+
+	select {
+	case err := <-subscription.Err():
+		if err == nil || err.Error() != "connection lost" {
+			t.Errorf("unexpected error: [%v]", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the error to be forwarded")
+	}
+}
+
+type testNewHeadSubscriber struct {
+	subscriptions int
+	closed        bool
+}
+
+func newTestNewHeadSubscriber() *testNewHeadSubscriber {
+	return &testNewHeadSubscriber{}
+}
+
+func (tnhs *testNewHeadSubscriber) SubscribeNewHead(
+	ctx context.Context,
+	ch chan<- *types.Header,
+) (goethereum.Subscription, error) {
+	tnhs.subscriptions++
+	return newTestSubscription(), nil
+}
+
+func (tnhs *testNewHeadSubscriber) Close() {
+	tnhs.closed = true
+}
+
+type testSubscription struct {
+	err chan error
+}
+
+func newTestSubscription() *testSubscription {
+	return &testSubscription{err: make(chan error, 1)}
+}
+
+func (ts *testSubscription) Err() <-chan error {
+	return ts.err
+}
+
+func (ts *testSubscription) Unsubscribe() {}
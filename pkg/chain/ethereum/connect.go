@@ -1,6 +1,7 @@
 package ethereum
 
 import (
+	"context"
 	"fmt"
 	"math/big"
 	"sync"
@@ -8,7 +9,6 @@ import (
 
 	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
 
-	"github.com/ethereum/go-ethereum/accounts/keystore"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/rpc"
@@ -40,10 +40,16 @@ type ethereumChain struct {
 	clientRPC                        *rpc.Client
 	clientWS                         *rpc.Client
 	keepRandomBeaconOperatorContract *contract.KeepRandomBeaconOperator
+	keepRandomBeaconOperatorAddress  common.Address
 	stakingContract                  *contract.TokenStaking
-	accountKey                       *keystore.Key
+	keepRegistryContract             *contract.KeepRegistry
+	registryStartBlock               uint64
+	signer                           Signer
 	blockCounter                     *blockcounter.EthereumBlockCounter
+	blockSubscription                *blockSubscriptionClient
 	chainConfig                      *relaychain.Config
+	transactionManager               *transactionManager
+	subscriptions                    *subscriptionMonitor
 
 	// transactionMutex allows interested parties to forcibly serialize
 	// transaction submission.
@@ -66,7 +72,11 @@ type ethereumUtilityChain struct {
 	keepRandomBeaconServiceContract *contract.KeepRandomBeaconService
 }
 
-func connect(config ethereum.Config) (*ethereumChain, error) {
+func connect(
+	config ethereum.Config,
+	registryStartBlock uint64,
+	signer Signer,
+) (*ethereumChain, error) {
 	client, clientWS, clientRPC, err := ethutil.ConnectClients(config.URL, config.URLRPC)
 	if err != nil {
 		return nil, fmt.Errorf(
@@ -76,34 +86,80 @@ func connect(config ethereum.Config) (*ethereumChain, error) {
 		)
 	}
 
-	return connectWithClient(config, client, clientWS, clientRPC)
+	return connectWithClient(
+		config,
+		registryStartBlock,
+		signer,
+		client,
+		clientWS,
+		clientRPC,
+	)
 }
 
 func connectWithClient(
 	config ethereum.Config,
+	registryStartBlock uint64,
+	signer Signer,
 	client *ethclient.Client,
 	clientWS *rpc.Client,
 	clientRPC *rpc.Client,
 ) (*ethereumChain, error) {
-	pv := &ethereumChain{
-		config:           config,
-		client:           addClientWrappers(config, client),
-		clientRPC:        clientRPC,
-		clientWS:         clientWS,
-		transactionMutex: &sync.Mutex{},
-	}
-
-	blockCounter, err := blockcounter.CreateBlockCounter(pv.client)
+	wrappedClient := addClientWrappers(config, client)
+	blockSubscription := newBlockSubscriptionClient(config.URL, wrappedClient)
 
+	blockCounter, err := blockcounter.CreateBlockCounter(blockSubscription)
 	if err != nil {
 		return nil, fmt.Errorf(
 			"failed to create Ethereum blockcounter: [%v]",
 			err,
 		)
 	}
-	pv.blockCounter = blockCounter
 
-	if pv.accountKey == nil {
+	return connectOperator(
+		context.Background(),
+		config,
+		registryStartBlock,
+		signer,
+		wrappedClient,
+		clientWS,
+		clientRPC,
+		blockCounter,
+		blockSubscription,
+	)
+}
+
+// connectOperator attaches to the contracts on behalf of the operator whose
+// transactions are signed by the given signer. If the signer is nil, the key
+// from the account key file referenced by the configuration is used. The
+// client and the block counter may be shared with other operators while the
+// nonce and the transaction manager are specific to the operator. Registry
+// events are looked up from the given registry start block. The transaction
+// manager stops monitoring pending transactions once the context is done.
+func connectOperator(
+	ctx context.Context,
+	config ethereum.Config,
+	registryStartBlock uint64,
+	signer Signer,
+	client ethutil.EthereumClient,
+	clientWS *rpc.Client,
+	clientRPC *rpc.Client,
+	blockCounter *blockcounter.EthereumBlockCounter,
+	blockSubscription *blockSubscriptionClient,
+) (*ethereumChain, error) {
+	pv := &ethereumChain{
+		config:             config,
+		registryStartBlock: registryStartBlock,
+		client:             client,
+		clientRPC:          clientRPC,
+		clientWS:           clientWS,
+		signer:             signer,
+		blockCounter:       blockCounter,
+		blockSubscription:  blockSubscription,
+		subscriptions:      newSubscriptionMonitor(),
+		transactionMutex:   &sync.Mutex{},
+	}
+
+	if pv.signer == nil {
 		key, err := ethutil.DecryptKeyFile(
 			config.Account.KeyFile,
 			config.Account.KeyFilePassword,
@@ -115,7 +171,7 @@ func connectWithClient(
 				err,
 			)
 		}
-		pv.accountKey = key
+		pv.signer = NewKeySigner(key)
 	}
 
 	checkInterval := DefaultMiningCheckInterval
@@ -131,30 +187,80 @@ func connectWithClient(
 	logger.Infof("using [%v] wei max gas price", maxGasPrice)
 	miningWaiter := ethutil.NewMiningWaiter(pv.client, checkInterval, maxGasPrice)
 
-	address, err := addressForContract(config, "KeepRandomBeaconOperator")
+	nonceManager := ethutil.NewNonceManager(
+		pv.signer.Address(),
+		pv.client,
+	)
+
+	var registry keepRegistry
+	if _, configured := config.ContractAddresses[keepRegistryContractName]; configured {
+		address, err := addressForContract(config, keepRegistryContractName)
+		if err != nil {
+			return nil, fmt.Errorf("error resolving KeepRegistry contract: [%v]", err)
+		}
+
+		keepRegistryContract, err :=
+			contract.NewKeepRegistry(
+				*address,
+				pv.signer.Address(),
+				pv.signer.SignTransaction,
+				pv.client,
+				nonceManager,
+				miningWaiter,
+				pv.transactionMutex,
+			)
+		if err != nil {
+			return nil, fmt.Errorf("error attaching to KeepRegistry contract: [%v]", err)
+		}
+		pv.keepRegistryContract = keepRegistryContract
+		registry = keepRegistryContract
+	}
+
+	isOperatorContract, err := newOperatorContractTypeCheck(pv.client)
+	if err != nil {
+		return nil, err
+	}
+
+	address, err := resolveOperatorContract(
+		config,
+		registry,
+		registryStartBlock,
+		isOperatorContract,
+	)
 	if err != nil {
 		return nil, fmt.Errorf("error resolving KeepRandomBeaconOperator contract: [%v]", err)
 	}
 
-	nonceManager := ethutil.NewNonceManager(
-		pv.accountKey.Address,
+	// Resubmissions of protocol transactions are handled by the transaction
+	// manager. The mining waiter of the operator contract is given no margin
+	// for gas price increase so that it never resubmits them on its own.
+	operatorMiningWaiter := ethutil.NewMiningWaiter(
 		pv.client,
+		checkInterval,
+		big.NewInt(0),
 	)
 
 	keepRandomBeaconOperatorContract, err :=
 		contract.NewKeepRandomBeaconOperator(
 			*address,
-			pv.accountKey,
+			pv.signer.Address(),
+			pv.signer.SignTransaction,
 			pv.client,
 			nonceManager,
-			miningWaiter,
+			operatorMiningWaiter,
 			pv.transactionMutex,
 		)
 	if err != nil {
 		return nil, fmt.Errorf("error attaching to KeepRandomBeaconOperator contract: [%v]", err)
 	}
 	pv.keepRandomBeaconOperatorContract = keepRandomBeaconOperatorContract
+	pv.keepRandomBeaconOperatorAddress = *address
 
+	// TokenStaking can not be resolved from the registry. The registry
+	// records operator contracts and the upgraders of service contracts
+	// only; the staking contract queries the registry for approved operator
+	// contracts but is not registered in it, and operator contracts do not
+	// expose the address of the staking contract they use.
 	address, err = addressForContract(config, "TokenStaking")
 	if err != nil {
 		return nil, fmt.Errorf("error resolving TokenStaking contract: [%v]", err)
@@ -163,7 +269,8 @@ func connectWithClient(
 	stakingContract, err :=
 		contract.NewTokenStaking(
 			*address,
-			pv.accountKey,
+			pv.signer.Address(),
+			pv.signer.SignTransaction,
 			pv.client,
 			nonceManager,
 			miningWaiter,
@@ -180,6 +287,18 @@ func connectWithClient(
 	}
 	pv.chainConfig = chainConfig
 
+	pv.transactionManager = newTransactionManager(
+		pv.client,
+		pv.signer,
+		DefaultResubmissionBlocks,
+		maxGasPrice,
+		keepRandomBeaconOperatorContract.GasPriceCeiling,
+	)
+	go pv.transactionManager.monitor(
+		ctx,
+		blockCounter.WatchBlocks(ctx),
+	)
+
 	return pv, nil
 }
 
@@ -215,7 +334,13 @@ func addClientWrappers(
 // non- standard client interactions. Note: for other things to work correctly
 // the configuration will need to reference a websocket, "ws://", or local IPC
 // connection.
-func ConnectUtility(config ethereum.Config) (chain.Utility, error) {
+//
+// The service contract address, if not configured explicitly, is resolved
+// from the KeepRegistry events emitted since the registry start block.
+func ConnectUtility(
+	config ethereum.Config,
+	registryStartBlock uint64,
+) (chain.Utility, error) {
 	client, clientWS, clientRPC, err := ethutil.ConnectClients(config.URL, config.URLRPC)
 	if err != nil {
 		return nil, fmt.Errorf(
@@ -225,7 +350,14 @@ func ConnectUtility(config ethereum.Config) (chain.Utility, error) {
 		)
 	}
 
-	base, err := connectWithClient(config, client, clientWS, clientRPC)
+	base, err := connectWithClient(
+		config,
+		registryStartBlock,
+		nil,
+		client,
+		clientWS,
+		clientRPC,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -241,20 +373,36 @@ func ConnectUtility(config ethereum.Config) (chain.Utility, error) {
 
 	miningWaiter := ethutil.NewMiningWaiter(client, checkInterval, maxGasPrice)
 
-	address, err := addressForContract(config, "KeepRandomBeaconService")
+	var registry keepRegistry
+	if base.keepRegistryContract != nil {
+		registry = base.keepRegistryContract
+	}
+
+	isServiceContract, err := newServiceContractTypeCheck(base.client)
+	if err != nil {
+		return nil, err
+	}
+
+	address, err := resolveServiceContract(
+		config,
+		registry,
+		base.registryStartBlock,
+		isServiceContract,
+	)
 	if err != nil {
 		return nil, fmt.Errorf("error resolving KeepRandomBeaconService contract: [%v]", err)
 	}
 
 	nonceManager := ethutil.NewNonceManager(
-		base.accountKey.Address,
+		base.signer.Address(),
 		base.client,
 	)
 
 	keepRandomBeaconServiceContract, err :=
 		contract.NewKeepRandomBeaconService(
 			*address,
-			base.accountKey,
+			base.signer.Address(),
+			base.signer.SignTransaction,
 			base.client,
 			nonceManager,
 			miningWaiter,
@@ -274,8 +422,100 @@ func ConnectUtility(config ethereum.Config) (chain.Utility, error) {
 // standard handle to the chain interface. Note: for other things to work
 // correctly the configuration will need to reference a websocket, "ws://", or
 // local IPC connection.
-func Connect(config ethereum.Config) (chain.Handle, error) {
-	return connect(config)
+//
+// Contract addresses not configured explicitly are resolved from the
+// KeepRegistry events emitted since the registry start block.
+func Connect(
+	config ethereum.Config,
+	registryStartBlock uint64,
+) (chain.Handle, error) {
+	return connect(config, registryStartBlock, nil)
+}
+
+// ConnectWithSigner makes the network connection to the Ethereum network and
+// returns a standard handle to the chain interface. All transactions and
+// messages are signed with the provided signer instead of the key from the
+// account key file referenced by the configuration. Note: for other things to
+// work correctly the configuration will need to reference a websocket,
+// "ws://", or local IPC connection.
+//
+// Contract addresses not configured explicitly are resolved from the
+// KeepRegistry events emitted since the registry start block.
+func ConnectWithSigner(
+	config ethereum.Config,
+	registryStartBlock uint64,
+	signer Signer,
+) (chain.Handle, error) {
+	return connect(config, registryStartBlock, signer)
+}
+
+// ConnectWithSigners makes a single network connection to the Ethereum
+// network and returns a standard handle to the chain interface for each of
+// the provided signers, in the same order. The handles share the connection,
+// the request rate limits and the block counter, but each of them signs
+// transactions and messages with its own signer and manages its own nonces
+// and pending transactions. Note: for other things to work correctly the
+// configuration will need to reference a websocket, "ws://", or local IPC
+// connection.
+//
+// Each handle resolves contract addresses not configured explicitly from the
+// KeepRegistry events emitted since the registry start block. Pending
+// transactions of the handles are monitored until the context is done.
+func ConnectWithSigners(
+	ctx context.Context,
+	config ethereum.Config,
+	registryStartBlock uint64,
+	signers []Signer,
+) ([]chain.Handle, error) {
+	client, clientWS, clientRPC, err := ethutil.ConnectClients(config.URL, config.URLRPC)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"error connecting to Ethereum server: %s [%v]",
+			config.URL,
+			err,
+		)
+	}
+
+	wrappedClient := addClientWrappers(config, client)
+	blockSubscription := newBlockSubscriptionClient(config.URL, wrappedClient)
+
+	blockCounter, err := blockcounter.CreateBlockCounter(blockSubscription)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to create Ethereum blockcounter: [%v]",
+			err,
+		)
+	}
+
+	handles := make([]chain.Handle, len(signers))
+	for i, signer := range signers {
+		if signer == nil {
+			return nil, fmt.Errorf("signer [%v] is not set", i)
+		}
+
+		handle, err := connectOperator(
+			ctx,
+			config,
+			registryStartBlock,
+			signer,
+			wrappedClient,
+			clientWS,
+			clientRPC,
+			blockCounter,
+			blockSubscription,
+		)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"could not connect operator [%v]: [%v]",
+				signer.Address().Hex(),
+				err,
+			)
+		}
+
+		handles[i] = handle
+	}
+
+	return handles, nil
 }
 
 func addressForContract(config ethereum.Config, contractName string) (*common.Address, error) {
@@ -9,13 +9,13 @@ import (
 	"github.com/ipfs/go-log"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/keep-network/keep-common/pkg/chain/ethereum/ethutil"
 	relayChain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
 	"github.com/keep-network/keep-core/pkg/chain"
 	"github.com/keep-network/keep-core/pkg/gen/async"
-	"github.com/keep-network/keep-core/pkg/operator"
 	"github.com/keep-network/keep-core/pkg/subscription"
 )
 
@@ -26,12 +26,8 @@ func (ec *ethereumChain) ThresholdRelay() relayChain.Interface {
 	return ec
 }
 
-func (ec *ethereumChain) GetKeys() (*operator.PrivateKey, *operator.PublicKey) {
-	return operator.EthereumKeyToOperatorKey(ec.accountKey)
-}
-
 func (ec *ethereumChain) Signing() chain.Signing {
-	return ethutil.NewSigner(ec.accountKey.PrivateKey)
+	return ec.signer
 }
 
 func (ec *ethereumChain) GetConfig() *relayChain.Config {
@@ -65,14 +61,22 @@ func (ec *ethereumChain) SubmitTicket(ticket *relayChain.Ticket) *async.EventGro
 
 	ticketBytes := ec.packTicket(ticket)
 
-	_, err := ec.keepRandomBeaconOperatorContract.SubmitTicket(
+	transaction, err := ec.keepRandomBeaconOperatorContract.SubmitTicket(
 		ticketBytes,
 		ethutil.TransactionOptions{
 			GasLimit: 250000,
+			GasPrice: ec.transactionManager.gasPrice(),
 		},
 	)
 	if err != nil {
 		failPromise(err)
+	} else {
+		// Tickets are accepted only while the group selection is in progress.
+		ec.trackTransaction(transaction, "submitTicket", func() (bool, error) {
+			isGroupSelectionPossible, err :=
+				ec.keepRandomBeaconOperatorContract.IsGroupSelectionPossible()
+			return !isGroupSelectionPossible, err
+		})
 	}
 
 	// TODO: fulfill when submitted
@@ -196,22 +200,38 @@ func (ec *ethereumChain) SubmitRelayEntry(
 		logger.Errorf("failed to estimate gas [%v]", err)
 	}
 
+	requestStartBlock, err := ec.CurrentRequestStartBlock()
+	if err != nil {
+		logger.Errorf("failed to get current request start block [%v]", err)
+	}
+
 	gasEstimateWithMargin := float64(gasEstimate) * float64(1.2) // 20% more than original
-	_, err = ec.keepRandomBeaconOperatorContract.RelayEntry(
+	transaction, err := ec.keepRandomBeaconOperatorContract.RelayEntry(
 		entry,
 		ethutil.TransactionOptions{
 			GasLimit: uint64(gasEstimateWithMargin),
+			GasPrice: ec.transactionManager.gasPrice(),
 		},
 	)
 	if err != nil {
 		subscription.Unsubscribe()
 		close(generatedEntry)
 		failPromise(err)
+	} else {
+		ec.trackTransaction(
+			transaction,
+			"relayEntry",
+			ec.isRequestInProgress(requestStartBlock),
+		)
 	}
 
 	return relayEntryPromise
 }
 
+func (ec *ethereumChain) SimulateRelayEntry(entry []byte) error {
+	return ec.keepRandomBeaconOperatorContract.CallRelayEntry(entry, nil)
+}
+
 func (ec *ethereumChain) OnRelayEntrySubmitted(
 	handle func(entry *event.EntrySubmitted),
 ) subscription.EventSubscription {
@@ -222,9 +242,12 @@ func (ec *ethereumChain) OnRelayEntrySubmitted(
 			})
 		},
 		func(err error) error {
-			return fmt.Errorf(
-				"watch relay entry generated failed with [%v]",
-				err,
+			return ec.subscriptions.failed(
+				"RelayEntrySubmitted",
+				fmt.Errorf(
+					"watch relay entry generated failed with [%v]",
+					err,
+				),
 			)
 		},
 	)
@@ -252,9 +275,12 @@ func (ec *ethereumChain) OnRelayEntryRequested(
 			})
 		},
 		func(err error) error {
-			return fmt.Errorf(
-				"watch relay entry requested failed with [%v]",
-				err,
+			return ec.subscriptions.failed(
+				"RelayEntryRequested",
+				fmt.Errorf(
+					"watch relay entry requested failed with [%v]",
+					err,
+				),
 			)
 		},
 	)
@@ -279,9 +305,12 @@ func (ec *ethereumChain) OnGroupSelectionStarted(
 			})
 		},
 		func(err error) error {
-			return fmt.Errorf(
-				"watch group selection started failed with [%v]",
-				err,
+			return ec.subscriptions.failed(
+				"GroupSelectionStarted",
+				fmt.Errorf(
+					"watch group selection started failed with [%v]",
+					err,
+				),
 			)
 		},
 	)
@@ -308,7 +337,10 @@ func (ec *ethereumChain) OnGroupRegistered(
 			})
 		},
 		func(err error) error {
-			return fmt.Errorf("entry of group key failed with: [%v]", err)
+			return ec.subscriptions.failed(
+				"GroupRegistered",
+				fmt.Errorf("entry of group key failed with: [%v]", err),
+			)
 		},
 	)
 	if err != nil {
@@ -363,9 +395,12 @@ func (ec *ethereumChain) OnDKGResultSubmitted(
 			})
 		},
 		func(err error) error {
-			return fmt.Errorf(
-				"watch DKG result published failed with: [%v]",
-				err,
+			return ec.subscriptions.failed(
+				"DKGResultSubmitted",
+				fmt.Errorf(
+					"watch DKG result published failed with: [%v]",
+					err,
+				),
 			)
 		},
 	)
@@ -377,14 +412,56 @@ func (ec *ethereumChain) OnDKGResultSubmitted(
 }
 
 func (ec *ethereumChain) ReportRelayEntryTimeout() error {
-	_, err := ec.keepRandomBeaconOperatorContract.ReportRelayEntryTimeout()
+	requestStartBlock, err := ec.CurrentRequestStartBlock()
+	if err != nil {
+		logger.Errorf("failed to get current request start block [%v]", err)
+	}
+
+	transaction, err := ec.keepRandomBeaconOperatorContract.ReportRelayEntryTimeout(
+		ethutil.TransactionOptions{
+			GasPrice: ec.transactionManager.gasPrice(),
+		},
+	)
 	if err != nil {
 		return err
 	}
 
+	ec.trackTransaction(
+		transaction,
+		"reportRelayEntryTimeout",
+		ec.isRequestInProgress(requestStartBlock),
+	)
+
 	return nil
 }
 
+// isRequestInProgress returns a function checking whether the relay request
+// started at the given block is still in progress. Relay entry and relay
+// entry timeout report transactions are useful only until the request they
+// refer to is completed. If the start block is unknown, the returned
+// function always reports the request is in progress.
+func (ec *ethereumChain) isRequestInProgress(
+	requestStartBlock *big.Int,
+) func() (bool, error) {
+	return func() (bool, error) {
+		if requestStartBlock == nil {
+			return true, nil
+		}
+
+		isEntryInProgress, err := ec.IsEntryInProgress()
+		if err != nil || !isEntryInProgress {
+			return false, err
+		}
+
+		currentRequestStartBlock, err := ec.CurrentRequestStartBlock()
+		if err != nil {
+			return false, err
+		}
+
+		return currentRequestStartBlock.Cmp(requestStartBlock) == 0, nil
+	}
+}
+
 func (ec *ethereumChain) IsEntryInProgress() (bool, error) {
 	return ec.keepRandomBeaconOperatorContract.IsEntryInProgress()
 }
@@ -466,21 +543,76 @@ func (ec *ethereumChain) SubmitDKGResult(
 		return resultPublicationPromise
 	}
 
-	if _, err = ec.keepRandomBeaconOperatorContract.SubmitDkgResult(
+	transaction, err := ec.keepRandomBeaconOperatorContract.SubmitDkgResult(
 		big.NewInt(int64(participantIndex)),
 		result.GroupPublicKey,
 		result.Misbehaved,
 		signaturesOnChainFormat,
 		membersIndicesOnChainFormat,
-	); err != nil {
+		ethutil.TransactionOptions{
+			GasPrice: ec.transactionManager.gasPrice(),
+		},
+	)
+	if err != nil {
 		subscription.Unsubscribe()
 		close(publishedResult)
 		failPromise(err)
+	} else {
+		// Only one DKG result is accepted for the group; the transaction is
+		// no longer useful once the group is registered.
+		ec.trackTransaction(transaction, "submitDkgResult", func() (bool, error) {
+			isGroupRegistered, err := ec.IsGroupRegistered(result.GroupPublicKey)
+			return !isGroupRegistered, err
+		})
 	}
 
 	return resultPublicationPromise
 }
 
+// trackTransaction hands the submitted protocol transaction over to the
+// transaction manager which makes sure it gets mined or cancels it once it is
+// no longer useful.
+func (ec *ethereumChain) trackTransaction(
+	transaction *types.Transaction,
+	method string,
+	isUseful func() (bool, error),
+) {
+	currentBlock, err := ec.blockCounter.CurrentBlock()
+	if err != nil {
+		logger.Warningf(
+			"failed to get current block; tracking transaction [%v] "+
+				"from the next block: [%v]",
+			transaction.Hash().Hex(),
+			err,
+		)
+		ec.transactionManager.trackFromNextBlock(method, transaction, isUseful)
+		return
+	}
+
+	ec.transactionManager.track(method, transaction, currentBlock, isUseful)
+}
+
+// SubscriptionFailures returns the most recent failure of each chain event
+// subscription which failed within the given period.
+func (ec *ethereumChain) SubscriptionFailures(
+	period time.Duration,
+) []chain.SubscriptionFailure {
+	return ec.subscriptions.recentFailures(period)
+}
+
+// ResubscribeBlocks renews the subscription to new blocks of the block
+// counter over a new connection to the Ethereum node. The block counter is
+// shared by all operators of the client, so is the subscription.
+func (ec *ethereumChain) ResubscribeBlocks() error {
+	return ec.blockSubscription.resubscribe()
+}
+
+// PendingTransactions returns protocol transactions submitted by the client
+// which have not been mined yet.
+func (ec *ethereumChain) PendingTransactions() []chain.PendingTransaction {
+	return ec.transactionManager.pendingTransactions()
+}
+
 // convertSignaturesToChainFormat converts signatures map to two slices. First
 // slice contains indices of members from the map, second slice is a slice of
 // concatenated signatures. Signatures and member indices are returned in the
@@ -524,7 +656,7 @@ func (ec *ethereumChain) CalculateDKGResultHash(
 }
 
 func (ec *ethereumChain) Address() common.Address {
-	return ec.accountKey.Address
+	return ec.signer.Address()
 }
 
 func (ec *ethereumChain) WeiBalanceOf(address common.Address) (*big.Int, error) {
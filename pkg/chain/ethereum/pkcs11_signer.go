@@ -0,0 +1,342 @@
+package ethereum
+
+import (
+	"bytes"
+	"encoding/asn1"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/keep-network/keep-core/pkg/operator"
+	"github.com/miekg/pkcs11"
+)
+
+// secp256k1Parameters is the DER encoding of the secp256k1 curve object
+// identifier (1.3.132.0.10), as stored in the CKA_EC_PARAMS attribute of EC
+// keys on the curve.
+var secp256k1Parameters = []byte{0x06, 0x05, 0x2b, 0x81, 0x04, 0x00, 0x0a}
+
+// pkcs11Signer is a Signer backed by an operator key held by a PKCS#11 token,
+// e.g. a hardware security module. The private key never leaves the token;
+// the token computes ECDSA signatures over hashes provided by the client.
+type pkcs11Signer struct {
+	publicKeySigning
+
+	address common.Address
+
+	context    *pkcs11.Ctx
+	privateKey pkcs11.ObjectHandle
+
+	// PKCS#11 sessions must not be used concurrently, so all signing
+	// operations are serialized.
+	sessionMutex sync.Mutex
+	session      pkcs11.SessionHandle
+}
+
+// NewPKCS11Signer loads the given PKCS#11 module, logs in to the token with
+// the given label and creates a Signer for the key pair with the given label
+// stored on that token. The key pair has to be a secp256k1 EC key pair; the
+// operator address is derived from its public key.
+func NewPKCS11Signer(
+	module string,
+	tokenLabel string,
+	keyLabel string,
+	pin string,
+) (Signer, error) {
+	context := pkcs11.New(module)
+	if context == nil {
+		return nil, fmt.Errorf("could not load PKCS#11 module [%v]", module)
+	}
+
+	if err := context.Initialize(); err != nil {
+		context.Destroy()
+		return nil, fmt.Errorf(
+			"could not initialize PKCS#11 module [%v]: [%v]",
+			module,
+			err,
+		)
+	}
+
+	signer, err := newPKCS11Signer(context, tokenLabel, keyLabel, pin)
+	if err != nil {
+		context.Finalize()
+		context.Destroy()
+		return nil, err
+	}
+
+	return signer, nil
+}
+
+func newPKCS11Signer(
+	context *pkcs11.Ctx,
+	tokenLabel string,
+	keyLabel string,
+	pin string,
+) (*pkcs11Signer, error) {
+	slot, err := findTokenSlot(context, tokenLabel)
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := context.OpenSession(slot, pkcs11.CKF_SERIAL_SESSION)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"could not open session with token [%v]: [%v]",
+			tokenLabel,
+			err,
+		)
+	}
+
+	if err := context.Login(session, pkcs11.CKU_USER, pin); err != nil {
+		context.CloseSession(session)
+		return nil, fmt.Errorf(
+			"could not log in to token [%v]: [%v]",
+			tokenLabel,
+			err,
+		)
+	}
+
+	privateKey, err := findKeyObject(
+		context,
+		session,
+		pkcs11.CKO_PRIVATE_KEY,
+		keyLabel,
+	)
+	if err != nil {
+		context.CloseSession(session)
+		return nil, err
+	}
+
+	publicKeyObject, err := findKeyObject(
+		context,
+		session,
+		pkcs11.CKO_PUBLIC_KEY,
+		keyLabel,
+	)
+	if err != nil {
+		context.CloseSession(session)
+		return nil, err
+	}
+
+	attributes, err := context.GetAttributeValue(
+		session,
+		publicKeyObject,
+		[]*pkcs11.Attribute{
+			pkcs11.NewAttribute(pkcs11.CKA_EC_PARAMS, nil),
+			pkcs11.NewAttribute(pkcs11.CKA_EC_POINT, nil),
+		},
+	)
+	if err != nil {
+		context.CloseSession(session)
+		return nil, fmt.Errorf(
+			"could not read public key [%v]: [%v]",
+			keyLabel,
+			err,
+		)
+	}
+
+	publicKey, err := unmarshalECPoint(attributes[0].Value, attributes[1].Value)
+	if err != nil {
+		context.CloseSession(session)
+		return nil, fmt.Errorf("invalid public key [%v]: [%v]", keyLabel, err)
+	}
+
+	return &pkcs11Signer{
+		publicKeySigning: publicKeySigning{publicKey: publicKey},
+		address:          crypto.PubkeyToAddress(*publicKey),
+		context:          context,
+		privateKey:       privateKey,
+		session:          session,
+	}, nil
+}
+
+// findTokenSlot returns the slot holding the token with the given label.
+func findTokenSlot(context *pkcs11.Ctx, tokenLabel string) (uint, error) {
+	slots, err := context.GetSlotList(true)
+	if err != nil {
+		return 0, fmt.Errorf("could not list PKCS#11 slots: [%v]", err)
+	}
+
+	for _, slot := range slots {
+		tokenInfo, err := context.GetTokenInfo(slot)
+		if err != nil {
+			return 0, fmt.Errorf(
+				"could not read token info of slot [%v]: [%v]",
+				slot,
+				err,
+			)
+		}
+
+		if tokenInfo.Label == tokenLabel {
+			return slot, nil
+		}
+	}
+
+	return 0, fmt.Errorf("token [%v] not found", tokenLabel)
+}
+
+// findKeyObject returns the EC key object of the given class with the given
+// label. Exactly one such object must exist on the token.
+func findKeyObject(
+	context *pkcs11.Ctx,
+	session pkcs11.SessionHandle,
+	class uint,
+	label string,
+) (pkcs11.ObjectHandle, error) {
+	template := []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_CLASS, class),
+		pkcs11.NewAttribute(pkcs11.CKA_KEY_TYPE, pkcs11.CKK_EC),
+		pkcs11.NewAttribute(pkcs11.CKA_LABEL, label),
+	}
+
+	if err := context.FindObjectsInit(session, template); err != nil {
+		return 0, fmt.Errorf("could not look up key [%v]: [%v]", label, err)
+	}
+
+	objects, _, err := context.FindObjects(session, 2)
+	if finalErr := context.FindObjectsFinal(session); err == nil {
+		err = finalErr
+	}
+	if err != nil {
+		return 0, fmt.Errorf("could not look up key [%v]: [%v]", label, err)
+	}
+
+	switch len(objects) {
+	case 0:
+		return 0, fmt.Errorf("key [%v] not found", label)
+	case 1:
+		return objects[0], nil
+	default:
+		return 0, fmt.Errorf("more than one key labelled [%v]", label)
+	}
+}
+
+// unmarshalECPoint unmarshals the secp256k1 public key from the CKA_EC_PARAMS
+// and CKA_EC_POINT attributes of a PKCS#11 public key object. The point is
+// expected to be a DER-encoded octet string holding the uncompressed point;
+// the raw uncompressed point, returned by some tokens, is accepted as well.
+func unmarshalECPoint(
+	parameters []byte,
+	point []byte,
+) (*operator.PublicKey, error) {
+	if !bytes.Equal(parameters, secp256k1Parameters) {
+		return nil, fmt.Errorf("key is not a secp256k1 key")
+	}
+
+	var encodedPoint []byte
+	if rest, err := asn1.Unmarshal(point, &encodedPoint); err != nil ||
+		len(rest) != 0 {
+		encodedPoint = point
+	}
+
+	return crypto.UnmarshalPubkey(encodedPoint)
+}
+
+func (ps *pkcs11Signer) Address() common.Address {
+	return ps.address
+}
+
+func (ps *pkcs11Signer) OperatorPublicKey() *operator.PublicKey {
+	return ps.publicKey
+}
+
+func (ps *pkcs11Signer) SignTransaction(
+	signer types.Signer,
+	address common.Address,
+	transaction *types.Transaction,
+) (*types.Transaction, error) {
+	if address != ps.address {
+		return nil, fmt.Errorf("not authorized to sign for [%v]", address.Hex())
+	}
+
+	signature, err := ps.signHash(signer.Hash(transaction).Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	return transaction.WithSignature(signer, signature)
+}
+
+// Sign signs the provided message using Ethereum-specific format, the same
+// as the in-memory key signer does.
+func (ps *pkcs11Signer) Sign(message []byte) ([]byte, error) {
+	signature, err := ps.signHash(accounts.TextHash(message))
+	if err != nil {
+		return nil, err
+	}
+
+	// Conform with the on-chain signature validation code accepting
+	// v={27, 28}.
+	signature[crypto.SignatureLength-1] += 27
+
+	return signature, nil
+}
+
+// signHash asks the token to sign the given hash and returns the signature
+// in the [R || S || V] format with V in {0, 1}.
+func (ps *pkcs11Signer) signHash(hash []byte) ([]byte, error) {
+	ps.sessionMutex.Lock()
+	defer ps.sessionMutex.Unlock()
+
+	err := ps.context.SignInit(
+		ps.session,
+		[]*pkcs11.Mechanism{pkcs11.NewMechanism(pkcs11.CKM_ECDSA, nil)},
+		ps.privateKey,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("could not initialize token signing: [%v]", err)
+	}
+
+	rawSignature, err := ps.context.Sign(ps.session, hash)
+	if err != nil {
+		return nil, fmt.Errorf("token failed to sign: [%v]", err)
+	}
+
+	return toRecoverableSignature(rawSignature, hash, ps.publicKey)
+}
+
+// toRecoverableSignature converts the [R || S] ECDSA signature computed by
+// the token into the [R || S || V] format with V in {0, 1}. Tokens do not
+// compute the recovery id and do not normalize S, so S is brought to the
+// lower half of the curve order, as Ethereum requires, and V is found by
+// recovering the public key.
+func toRecoverableSignature(
+	rawSignature []byte,
+	hash []byte,
+	publicKey *operator.PublicKey,
+) ([]byte, error) {
+	if len(rawSignature) != 64 {
+		return nil, fmt.Errorf(
+			"token returned signature of invalid length [%v]",
+			len(rawSignature),
+		)
+	}
+
+	curveOrder := crypto.S256().Params().N
+	r := new(big.Int).SetBytes(rawSignature[:32])
+	s := new(big.Int).SetBytes(rawSignature[32:])
+	if s.Cmp(new(big.Int).Rsh(curveOrder, 1)) > 0 {
+		s.Sub(curveOrder, s)
+	}
+
+	signature := make([]byte, crypto.SignatureLength)
+	copy(signature[:32], math.PaddedBigBytes(r, 32))
+	copy(signature[32:64], math.PaddedBigBytes(s, 32))
+
+	expectedPublicKey := crypto.FromECDSAPub(publicKey)
+	for recoveryID := byte(0); recoveryID < 2; recoveryID++ {
+		signature[crypto.RecoveryIDOffset] = recoveryID
+
+		recoveredPublicKey, err := crypto.Ecrecover(hash, signature)
+		if err == nil && bytes.Equal(recoveredPublicKey, expectedPublicKey) {
+			return signature, nil
+		}
+	}
+
+	return nil, fmt.Errorf("token signature does not match the operator key")
+}
@@ -0,0 +1,225 @@
+package ethereum
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"encoding/asn1"
+	"math/big"
+	"os"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestToRecoverableSignature(t *testing.T) {
+	privateKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherPrivateKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hash := crypto.Keccak256([]byte("Tokens do not compute recovery ids"))
+
+	r, s, err := ecdsa.Sign(rand.Reader, privateKey, hash)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	curveOrder := crypto.S256().Params().N
+	halfCurveOrder := new(big.Int).Rsh(curveOrder, 1)
+	lowS, highS := s, new(big.Int).Sub(curveOrder, s)
+	if s.Cmp(halfCurveOrder) > 0 {
+		lowS, highS = highS, s
+	}
+
+	var tests = map[string]struct {
+		s             *big.Int
+		publicKey     *ecdsa.PublicKey
+		expectedError bool
+	}{
+		"low S": {
+			s:         lowS,
+			publicKey: &privateKey.PublicKey,
+		},
+		"high S": {
+			s:         highS,
+			publicKey: &privateKey.PublicKey,
+		},
+		"other public key": {
+			s:             lowS,
+			publicKey:     &otherPrivateKey.PublicKey,
+			expectedError: true,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			rawSignature := append(
+				math.PaddedBigBytes(r, 32),
+				math.PaddedBigBytes(test.s, 32)...,
+			)
+
+			signature, err := toRecoverableSignature(
+				rawSignature,
+				hash,
+				test.publicKey,
+			)
+			if test.expectedError {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if new(big.Int).SetBytes(signature[32:64]).Cmp(lowS) != 0 {
+				t.Errorf("expected S in the lower half of the curve order")
+			}
+
+			recoveredPublicKey, err := crypto.SigToPub(hash, signature)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if crypto.PubkeyToAddress(*recoveredPublicKey) !=
+				crypto.PubkeyToAddress(privateKey.PublicKey) {
+				t.Errorf("recovered public key does not match the signer")
+			}
+		})
+	}
+}
+
+func TestUnmarshalECPoint(t *testing.T) {
+	privateKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	point := crypto.FromECDSAPub(&privateKey.PublicKey)
+	encodedPoint, err := asn1.Marshal(point)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tests = map[string]struct {
+		parameters    []byte
+		point         []byte
+		expectedError bool
+	}{
+		"DER-encoded point": {
+			parameters: secp256k1Parameters,
+			point:      encodedPoint,
+		},
+		"raw point": {
+			parameters: secp256k1Parameters,
+			point:      point,
+		},
+		"other curve": {
+			// prime256v1 (1.2.840.10045.3.1.7)
+			parameters: []byte{
+				0x06, 0x08, 0x2a, 0x86, 0x48, 0xce, 0x3d, 0x03, 0x01, 0x07,
+			},
+			point:         encodedPoint,
+			expectedError: true,
+		},
+		"malformed point": {
+			parameters:    secp256k1Parameters,
+			point:         point[:33],
+			expectedError: true,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			publicKey, err := unmarshalECPoint(test.parameters, test.point)
+			if test.expectedError {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !bytes.Equal(crypto.FromECDSAPub(publicKey), point) {
+				t.Errorf("unexpected public key")
+			}
+		})
+	}
+}
+
+// TestPKCS11SignerSignAndVerify runs against a real PKCS#11 token, e.g.
+// SoftHSM, and is skipped unless KEEP_TEST_PKCS11_MODULE points to the
+// module. The token has to be labelled `keep-test`, have the user PIN `1234`
+// and hold a secp256k1 key pair labelled `operator`:
+//
+//	softhsm2-util --init-token --free --label keep-test --pin 1234 --so-pin 1234
+//	pkcs11-tool --module $KEEP_TEST_PKCS11_MODULE --token-label keep-test \
+//	  --login --pin 1234 --keypairgen --key-type EC:secp256k1 --label operator
+func TestPKCS11SignerSignAndVerify(t *testing.T) {
+	module := os.Getenv("KEEP_TEST_PKCS11_MODULE")
+	if module == "" {
+		t.Skip("KEEP_TEST_PKCS11_MODULE is not set")
+	}
+
+	signer, err := NewPKCS11Signer(module, "keep-test", "operator", "1234")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	message := []byte("It is not the key that signs but the token")
+
+	signature, err := signer.Sign(message)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ok, err := signer.Verify(message, signature)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok {
+		t.Errorf("expected signature to be valid")
+	}
+
+	chainID := big.NewInt(1101)
+	transactionSigner := types.NewEIP155Signer(chainID)
+	transaction := types.NewTransaction(
+		1,
+		common.HexToAddress("0x1"),
+		big.NewInt(0),
+		21000,
+		big.NewInt(1),
+		nil,
+	)
+
+	signedTransaction, err := signer.SignTransaction(
+		transactionSigner,
+		signer.Address(),
+		transaction,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sender, err := types.Sender(transactionSigner, signedTransaction)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sender != signer.Address() {
+		t.Errorf(
+			"unexpected transaction sender\nexpected: [%v]\nactual:   [%v]",
+			signer.Address().Hex(),
+			sender.Hex(),
+		)
+	}
+}
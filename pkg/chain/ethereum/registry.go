@@ -0,0 +1,399 @@
+package ethereum
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	goethereum "github.com/ethereum/go-ethereum"
+	ethereumabi "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/keep-network/keep-common/pkg/chain/ethereum"
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/chain/gen/abi"
+	"github.com/keep-network/keep-core/pkg/subscription"
+)
+
+const (
+	keepRegistryContractName             = "KeepRegistry"
+	keepRandomBeaconOperatorContractName = "KeepRandomBeaconOperator"
+	keepRandomBeaconServiceContractName  = "KeepRandomBeaconService"
+)
+
+// keepRegistry is the part of the KeepRegistry contract used to discover
+// operator and service contract addresses.
+type keepRegistry interface {
+	PastOperatorContractApprovedEvents(
+		startBlock uint64,
+		endBlock *uint64,
+	) ([]*abi.KeepRegistryOperatorContractApproved, error)
+
+	PastOperatorContractUpgraderUpdatedEvents(
+		startBlock uint64,
+		endBlock *uint64,
+	) ([]*abi.KeepRegistryOperatorContractUpgraderUpdated, error)
+
+	IsApprovedOperatorContract(operatorContract common.Address) (bool, error)
+}
+
+// contractTypeCheck tells whether the contract deployed at the given address
+// is of the expected type. The KeepRegistry is shared by all Keep
+// applications, so operator and service contracts it records are not
+// necessarily random beacon contracts.
+type contractTypeCheck func(address common.Address) (bool, error)
+
+// newContractTypeCheck returns a contractTypeCheck accepting contracts which
+// respond to all of the given view methods of the contract ABI with output
+// decodable as specified by the ABI. The methods must take no arguments.
+// Contracts behind a proxy are checked through the proxy, so the methods
+// should be specific to the expected contract type.
+func newContractTypeCheck(
+	caller bind.ContractCaller,
+	contractABI string,
+	methods ...string,
+) (contractTypeCheck, error) {
+	parsedABI, err := ethereumabi.JSON(strings.NewReader(contractABI))
+	if err != nil {
+		return nil, fmt.Errorf("could not parse contract ABI: [%v]", err)
+	}
+
+	for _, method := range methods {
+		if _, ok := parsedABI.Methods[method]; !ok {
+			return nil, fmt.Errorf("no method [%v] in contract ABI", method)
+		}
+	}
+
+	return func(address common.Address) (bool, error) {
+		for _, method := range methods {
+			input, err := parsedABI.Pack(method)
+			if err != nil {
+				return false, err
+			}
+
+			output, err := caller.CallContract(
+				context.Background(),
+				goethereum.CallMsg{To: &address, Data: input},
+				nil,
+			)
+			if err != nil {
+				// An error reported by the node itself, e.g. a revert of a
+				// call to a missing method, means the contract does not
+				// implement the method. Other errors, e.g. connection
+				// failures, do not tell anything about the contract.
+				if _, ok := err.(rpc.Error); ok {
+					return false, nil
+				}
+				return false, fmt.Errorf(
+					"could not call [%v] of contract [%v]: [%v]",
+					method,
+					address.Hex(),
+					err,
+				)
+			}
+
+			_, err = parsedABI.Methods[method].Outputs.UnpackValues(output)
+			if err != nil {
+				return false, nil
+			}
+		}
+
+		return true, nil
+	}, nil
+}
+
+// newOperatorContractTypeCheck returns a contractTypeCheck accepting
+// KeepRandomBeaconOperator contracts.
+func newOperatorContractTypeCheck(
+	caller bind.ContractCaller,
+) (contractTypeCheck, error) {
+	return newContractTypeCheck(
+		caller,
+		abi.KeepRandomBeaconOperatorABI,
+		"groupSize",
+		"groupThreshold",
+		"relayEntryTimeout",
+	)
+}
+
+// newServiceContractTypeCheck returns a contractTypeCheck accepting
+// KeepRandomBeaconService contracts.
+func newServiceContractTypeCheck(
+	caller bind.ContractCaller,
+) (contractTypeCheck, error) {
+	return newContractTypeCheck(
+		caller,
+		abi.KeepRandomBeaconServiceImplV1ABI,
+		"dkgFeePool",
+		"requestSubsidyFeePool",
+		"entryFeeBreakdown",
+	)
+}
+
+// resolveOperatorContract returns the address of the KeepRandomBeaconOperator
+// contract. The address from the configuration takes precedence; if it is not
+// configured, the most recently approved operator contract of the expected
+// type is fetched from the registry events emitted since the start block.
+// A nil registry means the registry address is not configured.
+func resolveOperatorContract(
+	config ethereum.Config,
+	registry keepRegistry,
+	startBlock uint64,
+	isOperatorContract contractTypeCheck,
+) (*common.Address, error) {
+	_, configured := config.ContractAddresses[keepRandomBeaconOperatorContractName]
+	if configured || registry == nil {
+		address, err := addressForContract(
+			config,
+			keepRandomBeaconOperatorContractName,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if registry != nil {
+			approved, err := registry.IsApprovedOperatorContract(*address)
+			if err != nil {
+				return nil, fmt.Errorf(
+					"could not check operator contract approval: [%v]",
+					err,
+				)
+			}
+			if !approved {
+				logger.Warningf(
+					"configured operator contract [%v] is not approved "+
+						"in the registry",
+					address.Hex(),
+				)
+			}
+		}
+
+		return address, nil
+	}
+
+	events, err := registry.PastOperatorContractApprovedEvents(startBlock, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Operator contracts approved later supersede those approved earlier.
+	// Disabled operator contracts and operator contracts of other
+	// applications are skipped.
+	for i := len(events) - 1; i >= 0; i-- {
+		address := events[i].OperatorContract
+
+		approved, err := registry.IsApprovedOperatorContract(address)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"could not check operator contract approval: [%v]",
+				err,
+			)
+		}
+		if !approved {
+			continue
+		}
+
+		isExpectedType, err := isOperatorContract(address)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"could not check operator contract type: [%v]",
+				err,
+			)
+		}
+		if !isExpectedType {
+			logger.Debugf(
+				"skipping operator contract [%v] which is not a [%v] contract",
+				address.Hex(),
+				keepRandomBeaconOperatorContractName,
+			)
+			continue
+		}
+
+		logger.Infof(
+			"resolved operator contract [%v] from the registry",
+			address.Hex(),
+		)
+		return &address, nil
+	}
+
+	return nil, fmt.Errorf("no approved operator contract in the registry")
+}
+
+// resolveServiceContract returns the address of the KeepRandomBeaconService
+// contract. The address from the configuration takes precedence; if it is not
+// configured, the service contract of the expected type most recently
+// registered for operator contract upgrades is fetched from the registry
+// events emitted since the start block. A nil registry means the registry
+// address is not configured.
+func resolveServiceContract(
+	config ethereum.Config,
+	registry keepRegistry,
+	startBlock uint64,
+	isServiceContract contractTypeCheck,
+) (*common.Address, error) {
+	_, configured := config.ContractAddresses[keepRandomBeaconServiceContractName]
+	if configured || registry == nil {
+		return addressForContract(config, keepRandomBeaconServiceContractName)
+	}
+
+	events, err := registry.PastOperatorContractUpgraderUpdatedEvents(
+		startBlock,
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := len(events) - 1; i >= 0; i-- {
+		if events[i].Upgrader == (common.Address{}) {
+			continue
+		}
+
+		address := events[i].ServiceContract
+
+		isExpectedType, err := isServiceContract(address)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"could not check service contract type: [%v]",
+				err,
+			)
+		}
+		if !isExpectedType {
+			logger.Debugf(
+				"skipping service contract [%v] which is not a [%v] contract",
+				address.Hex(),
+				keepRandomBeaconServiceContractName,
+			)
+			continue
+		}
+
+		logger.Infof(
+			"resolved service contract [%v] from the registry",
+			address.Hex(),
+		)
+		return &address, nil
+	}
+
+	return nil, fmt.Errorf("no service contract in the registry")
+}
+
+// ContractRegistry returns a handle to the KeepRegistry contract allowing to
+// monitor operator contract upgrades. It fails if the registry address is not
+// configured.
+func (ec *ethereumChain) ContractRegistry() (chain.ContractRegistry, error) {
+	if ec.keepRegistryContract == nil {
+		return nil, fmt.Errorf(
+			"no address information for [%v] in configuration",
+			keepRegistryContractName,
+		)
+	}
+
+	return &contractRegistry{ec}, nil
+}
+
+type contractRegistry struct {
+	chain *ethereumChain
+}
+
+func (cr *contractRegistry) OperatorContract() string {
+	return cr.chain.keepRandomBeaconOperatorAddress.Hex()
+}
+
+// OnOperatorContractApproved calls the handler with the address of each
+// operator contract approved in the registry which may replace the operator
+// contract the client uses. Operator contracts of other applications and
+// operator contracts no longer approved by the time the event is received
+// are skipped, as they are when the operator contract is resolved.
+func (cr *contractRegistry) OnOperatorContractApproved(
+	handler func(operatorContract string),
+) (subscription.EventSubscription, error) {
+	currentOperatorContract := cr.chain.keepRandomBeaconOperatorAddress
+
+	isOperatorContract, err := newOperatorContractTypeCheck(cr.chain.client)
+	if err != nil {
+		return nil, err
+	}
+
+	return cr.chain.keepRegistryContract.WatchOperatorContractApproved(
+		func(operatorContract common.Address, blockNumber uint64) {
+			isUpgrade, err := isOperatorContractUpgrade(
+				cr.chain.keepRegistryContract,
+				isOperatorContract,
+				currentOperatorContract,
+				operatorContract,
+			)
+			if err != nil {
+				logger.Errorf(
+					"could not check approved operator contract [%v]: [%v]",
+					operatorContract.Hex(),
+					err,
+				)
+				return
+			}
+			if !isUpgrade {
+				return
+			}
+
+			handler(operatorContract.Hex())
+		},
+		func(err error) error {
+			return cr.chain.subscriptions.failed(
+				"OperatorContractApproved",
+				fmt.Errorf(
+					"watch operator contract approved failed with: [%v]",
+					err,
+				),
+			)
+		},
+	)
+}
+
+// isOperatorContractUpgrade tells whether the approved operator contract
+// may replace the current operator contract. It has to be a different
+// contract of the expected type which is still approved in the registry.
+func isOperatorContractUpgrade(
+	registry keepRegistry,
+	isOperatorContract contractTypeCheck,
+	currentOperatorContract common.Address,
+	approvedOperatorContract common.Address,
+) (bool, error) {
+	if approvedOperatorContract == currentOperatorContract {
+		return false, nil
+	}
+
+	approved, err := registry.IsApprovedOperatorContract(
+		approvedOperatorContract,
+	)
+	if err != nil {
+		return false, fmt.Errorf(
+			"could not check operator contract approval: [%v]",
+			err,
+		)
+	}
+	if !approved {
+		logger.Debugf(
+			"skipping operator contract [%v] which is no longer approved",
+			approvedOperatorContract.Hex(),
+		)
+		return false, nil
+	}
+
+	isExpectedType, err := isOperatorContract(approvedOperatorContract)
+	if err != nil {
+		return false, fmt.Errorf(
+			"could not check operator contract type: [%v]",
+			err,
+		)
+	}
+	if !isExpectedType {
+		logger.Debugf(
+			"skipping operator contract [%v] which is not a [%v] contract",
+			approvedOperatorContract.Hex(),
+			keepRandomBeaconOperatorContractName,
+		)
+		return false, nil
+	}
+
+	return true, nil
+}
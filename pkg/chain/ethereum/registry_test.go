@@ -0,0 +1,429 @@
+package ethereum
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"reflect"
+	"testing"
+
+	goethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/keep-network/keep-common/pkg/chain/ethereum"
+	"github.com/keep-network/keep-core/pkg/chain/gen/abi"
+)
+
+var (
+	operatorContract1 = common.HexToAddress("0x0b185C37E1C9D01437c800a8B60fA0845742c271")
+	operatorContract2 = common.HexToAddress("0x1895e4A71d0956553cf80f2ccac69642a2f1bFF4")
+	serviceContract1  = common.HexToAddress("0x2ae1F3A1ED9d0DD1f4b1fEb2C0f8C2f6d1C6b8A2")
+	serviceContract2  = common.HexToAddress("0x3bA3CCd0b2E1F9B2d7bC5A2aC1d3b6Fd4E9F1C73")
+	upgrader          = common.HexToAddress("0x4F2b6D6e4D0a3B1C9a6c3B2e1F0D9C8B7A6E5D4C")
+)
+
+const registryStartBlock = 8500000
+
+func TestResolveOperatorContract(t *testing.T) {
+	configuredAddress := "0x5C8A2b3F7a2D7A9b1e2F3c4D5e6F7a8B9c0D1e2F"
+
+	var tests = map[string]struct {
+		contractAddresses map[string]string
+		registry          *mockKeepRegistry
+		expectedAddress   *common.Address
+		expectedError     error
+	}{
+		"configured address without registry": {
+			contractAddresses: map[string]string{
+				"KeepRandomBeaconOperator": configuredAddress,
+			},
+			expectedAddress: toAddress(configuredAddress),
+		},
+		"configured address takes precedence over registry": {
+			contractAddresses: map[string]string{
+				"KeepRandomBeaconOperator": configuredAddress,
+			},
+			registry: &mockKeepRegistry{
+				approved: []common.Address{operatorContract1},
+			},
+			expectedAddress: toAddress(configuredAddress),
+		},
+		"no configured address and no registry": {
+			contractAddresses: map[string]string{},
+			expectedError: fmt.Errorf(
+				"no address information for [KeepRandomBeaconOperator] " +
+					"in configuration",
+			),
+		},
+		"most recently approved operator contract": {
+			contractAddresses: map[string]string{},
+			registry: &mockKeepRegistry{
+				approved: []common.Address{
+					operatorContract1,
+					operatorContract2,
+				},
+			},
+			expectedAddress: &operatorContract2,
+		},
+		"operator contract of another type is skipped": {
+			contractAddresses: map[string]string{},
+			registry: &mockKeepRegistry{
+				approved: []common.Address{
+					operatorContract1,
+					operatorContract2,
+				},
+				otherType: map[common.Address]bool{
+					operatorContract2: true,
+				},
+			},
+			expectedAddress: &operatorContract1,
+		},
+		"disabled operator contract is skipped": {
+			contractAddresses: map[string]string{},
+			registry: &mockKeepRegistry{
+				approved: []common.Address{
+					operatorContract1,
+					operatorContract2,
+				},
+				disabled: map[common.Address]bool{
+					operatorContract2: true,
+				},
+			},
+			expectedAddress: &operatorContract1,
+		},
+		"no approved operator contract": {
+			contractAddresses: map[string]string{},
+			registry:          &mockKeepRegistry{},
+			expectedError: fmt.Errorf(
+				"no approved operator contract in the registry",
+			),
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			config := ethereum.Config{
+				ContractAddresses: test.contractAddresses,
+			}
+
+			var registry keepRegistry
+			if test.registry != nil {
+				registry = test.registry
+			}
+
+			address, err := resolveOperatorContract(
+				config,
+				registry,
+				registryStartBlock,
+				test.registry.isExpectedType,
+			)
+			if !reflect.DeepEqual(test.expectedAddress, address) {
+				t.Fatalf(
+					"unexpected contract address\nexpected: [%v]\nactual:   [%v]",
+					test.expectedAddress,
+					address,
+				)
+			}
+			if !reflect.DeepEqual(test.expectedError, err) {
+				t.Fatalf(
+					"unexpected error\nexpected: [%v]\nactual:   [%v]",
+					test.expectedError,
+					err,
+				)
+			}
+		})
+	}
+}
+
+func TestResolveServiceContract(t *testing.T) {
+	var tests = map[string]struct {
+		registry        *mockKeepRegistry
+		expectedAddress *common.Address
+		expectedError   error
+	}{
+		"most recently registered service contract": {
+			registry: &mockKeepRegistry{
+				upgraders: []*abi.KeepRegistryOperatorContractUpgraderUpdated{
+					{ServiceContract: serviceContract1, Upgrader: upgrader},
+					{ServiceContract: serviceContract2, Upgrader: upgrader},
+				},
+			},
+			expectedAddress: &serviceContract2,
+		},
+		"service contract of another type is skipped": {
+			registry: &mockKeepRegistry{
+				upgraders: []*abi.KeepRegistryOperatorContractUpgraderUpdated{
+					{ServiceContract: serviceContract1, Upgrader: upgrader},
+					{ServiceContract: serviceContract2, Upgrader: upgrader},
+				},
+				otherType: map[common.Address]bool{
+					serviceContract2: true,
+				},
+			},
+			expectedAddress: &serviceContract1,
+		},
+		"service contract with removed upgrader is skipped": {
+			registry: &mockKeepRegistry{
+				upgraders: []*abi.KeepRegistryOperatorContractUpgraderUpdated{
+					{ServiceContract: serviceContract1, Upgrader: upgrader},
+					{ServiceContract: serviceContract2},
+				},
+			},
+			expectedAddress: &serviceContract1,
+		},
+		"no service contract": {
+			registry: &mockKeepRegistry{},
+			expectedError: fmt.Errorf(
+				"no service contract in the registry",
+			),
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			config := ethereum.Config{
+				ContractAddresses: map[string]string{},
+			}
+
+			address, err := resolveServiceContract(
+				config,
+				test.registry,
+				registryStartBlock,
+				test.registry.isExpectedType,
+			)
+			if !reflect.DeepEqual(test.expectedAddress, address) {
+				t.Fatalf(
+					"unexpected contract address\nexpected: [%v]\nactual:   [%v]",
+					test.expectedAddress,
+					address,
+				)
+			}
+			if !reflect.DeepEqual(test.expectedError, err) {
+				t.Fatalf(
+					"unexpected error\nexpected: [%v]\nactual:   [%v]",
+					test.expectedError,
+					err,
+				)
+			}
+		})
+	}
+}
+
+func TestIsOperatorContractUpgrade(t *testing.T) {
+	var tests = map[string]struct {
+		registry          *mockKeepRegistry
+		approved          common.Address
+		expectedIsUpgrade bool
+	}{
+		"approved operator contract": {
+			registry: &mockKeepRegistry{
+				approved: []common.Address{operatorContract1, operatorContract2},
+			},
+			approved:          operatorContract2,
+			expectedIsUpgrade: true,
+		},
+		"current operator contract": {
+			registry: &mockKeepRegistry{
+				approved: []common.Address{operatorContract1},
+			},
+			approved:          operatorContract1,
+			expectedIsUpgrade: false,
+		},
+		"operator contract of another application": {
+			registry: &mockKeepRegistry{
+				approved: []common.Address{operatorContract1, operatorContract2},
+				otherType: map[common.Address]bool{
+					operatorContract2: true,
+				},
+			},
+			approved:          operatorContract2,
+			expectedIsUpgrade: false,
+		},
+		"operator contract no longer approved": {
+			registry: &mockKeepRegistry{
+				approved: []common.Address{operatorContract1, operatorContract2},
+				disabled: map[common.Address]bool{
+					operatorContract2: true,
+				},
+			},
+			approved:          operatorContract2,
+			expectedIsUpgrade: false,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			isUpgrade, err := isOperatorContractUpgrade(
+				test.registry,
+				test.registry.isExpectedType,
+				operatorContract1,
+				test.approved,
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if isUpgrade != test.expectedIsUpgrade {
+				t.Fatalf(
+					"unexpected upgrade\nexpected: [%v]\nactual:   [%v]",
+					test.expectedIsUpgrade,
+					isUpgrade,
+				)
+			}
+		})
+	}
+}
+
+func toAddress(address string) *common.Address {
+	commonAddress := common.HexToAddress(address)
+	return &commonAddress
+}
+
+// mockKeepRegistry holds events emitted at registryStartBlock and fails
+// lookups of events from any other block.
+type mockKeepRegistry struct {
+	approved  []common.Address
+	disabled  map[common.Address]bool
+	otherType map[common.Address]bool
+	upgraders []*abi.KeepRegistryOperatorContractUpgraderUpdated
+}
+
+func (mkr *mockKeepRegistry) PastOperatorContractApprovedEvents(
+	startBlock uint64,
+	endBlock *uint64,
+) ([]*abi.KeepRegistryOperatorContractApproved, error) {
+	if startBlock != registryStartBlock {
+		return nil, fmt.Errorf("unexpected start block [%v]", startBlock)
+	}
+
+	events := make([]*abi.KeepRegistryOperatorContractApproved, 0)
+	for _, operatorContract := range mkr.approved {
+		events = append(
+			events,
+			&abi.KeepRegistryOperatorContractApproved{
+				OperatorContract: operatorContract,
+			},
+		)
+	}
+	return events, nil
+}
+
+func (mkr *mockKeepRegistry) PastOperatorContractUpgraderUpdatedEvents(
+	startBlock uint64,
+	endBlock *uint64,
+) ([]*abi.KeepRegistryOperatorContractUpgraderUpdated, error) {
+	if startBlock != registryStartBlock {
+		return nil, fmt.Errorf("unexpected start block [%v]", startBlock)
+	}
+
+	return mkr.upgraders, nil
+}
+
+func (mkr *mockKeepRegistry) IsApprovedOperatorContract(
+	operatorContract common.Address,
+) (bool, error) {
+	for _, approved := range mkr.approved {
+		if approved == operatorContract {
+			return !mkr.disabled[operatorContract], nil
+		}
+	}
+	return false, nil
+}
+
+func (mkr *mockKeepRegistry) isExpectedType(
+	address common.Address,
+) (bool, error) {
+	return mkr == nil || !mkr.otherType[address], nil
+}
+
+func TestContractTypeCheck(t *testing.T) {
+	groupSizeOutput := math.PaddedBigBytes(big.NewInt(64), 32)
+
+	var tests = map[string]struct {
+		output         []byte
+		err            error
+		expectedResult bool
+		expectedError  bool
+	}{
+		"methods implemented": {
+			output:         groupSizeOutput,
+			expectedResult: true,
+		},
+		"call reverted": {
+			err:            &mockRPCError{"execution reverted"},
+			expectedResult: false,
+		},
+		"empty output": {
+			output:         []byte{},
+			expectedResult: false,
+		},
+		"connection failure": {
+			err:           fmt.Errorf("connection refused"),
+			expectedError: true,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			isOperatorContract, err := newOperatorContractTypeCheck(
+				&mockContractCaller{test.output, test.err},
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			result, err := isOperatorContract(operatorContract1)
+			if test.expectedError {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if result != test.expectedResult {
+				t.Errorf(
+					"unexpected result\nexpected: [%v]\nactual:   [%v]",
+					test.expectedResult,
+					result,
+				)
+			}
+		})
+	}
+}
+
+type mockContractCaller struct {
+	output []byte
+	err    error
+}
+
+func (mcc *mockContractCaller) CodeAt(
+	ctx context.Context,
+	contract common.Address,
+	blockNumber *big.Int,
+) ([]byte, error) {
+	panic("not implemented")
+}
+
+func (mcc *mockContractCaller) CallContract(
+	ctx context.Context,
+	call goethereum.CallMsg,
+	blockNumber *big.Int,
+) ([]byte, error) {
+	return mcc.output, mcc.err
+}
+
+// mockRPCError is an error reported by the Ethereum node.
+type mockRPCError struct {
+	message string
+}
+
+func (mre *mockRPCError) Error() string {
+	return mre.message
+}
+
+func (mre *mockRPCError) ErrorCode() int {
+	return -32000
+}
@@ -0,0 +1,290 @@
+package ethereum
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/external"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/keep-network/keep-common/pkg/chain/ethereum/ethutil"
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/operator"
+)
+
+// publicKeyProbe is the message signed by the external signer on startup in
+// order to recover the operator's public key. External signers do not expose
+// public keys directly, only addresses of the accounts they manage.
+var publicKeyProbe = []byte("keep-client operator public key")
+
+// Signer represents the operator key used by the client to authorize
+// transactions and sign messages. The key itself may live outside of the
+// client process, e.g. in an external signer, so Signer exposes only the
+// operations the client needs and never the private key.
+type Signer interface {
+	chain.Signing
+
+	// Address returns the address of the operator account.
+	Address() common.Address
+
+	// OperatorPublicKey returns the public key of the operator account.
+	OperatorPublicKey() *operator.PublicKey
+
+	// SignTransaction signs the transaction on behalf of the given account.
+	// It conforms to bind.SignerFn so it can be used directly by contract
+	// bindings. An error is returned if the account is not the operator
+	// account.
+	SignTransaction(
+		signer types.Signer,
+		address common.Address,
+		transaction *types.Transaction,
+	) (*types.Transaction, error)
+}
+
+// keySigner is a Signer backed by an operator key decrypted from a key file
+// and held in memory.
+type keySigner struct {
+	*ethutil.EthereumSigner
+
+	key *keystore.Key
+}
+
+// NewKeySigner creates a Signer for the provided decrypted key.
+func NewKeySigner(key *keystore.Key) Signer {
+	return &keySigner{
+		EthereumSigner: ethutil.NewSigner(key.PrivateKey),
+		key:            key,
+	}
+}
+
+func (ks *keySigner) Address() common.Address {
+	return ks.key.Address
+}
+
+func (ks *keySigner) OperatorPublicKey() *operator.PublicKey {
+	_, publicKey := operator.EthereumKeyToOperatorKey(ks.key)
+	return publicKey
+}
+
+func (ks *keySigner) SignTransaction(
+	signer types.Signer,
+	address common.Address,
+	transaction *types.Transaction,
+) (*types.Transaction, error) {
+	if address != ks.key.Address {
+		return nil, fmt.Errorf("not authorized to sign for [%v]", address.Hex())
+	}
+
+	signature, err := crypto.Sign(
+		signer.Hash(transaction).Bytes(),
+		ks.key.PrivateKey,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return transaction.WithSignature(signer, signature)
+}
+
+// externalSigner is a Signer delegating all signing operations to an
+// external, Clef-compatible signer reachable over JSON-RPC. The operator's
+// private key never enters the client process.
+type externalSigner struct {
+	publicKeySigning
+
+	signer  *external.ExternalSigner
+	account accounts.Account
+}
+
+// NewExternalSigner connects to the Clef-compatible signer available at the
+// given endpoint and creates a Signer for the given operator address. The
+// external signer must manage the account with that address. The operator's
+// public key is recovered from a probe signature requested from the signer,
+// so the signer must be configured to approve it.
+func NewExternalSigner(endpoint string, address common.Address) (Signer, error) {
+	signer, err := external.NewExternalSigner(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"could not connect to external signer [%v]: [%v]",
+			endpoint,
+			err,
+		)
+	}
+
+	// Listing accounts refreshes the signer's account cache, which is what
+	// the subsequent lookup is performed against.
+	signer.Accounts()
+
+	account := accounts.Account{Address: address}
+	if !signer.Contains(account) {
+		return nil, fmt.Errorf(
+			"external signer [%v] does not manage account [%v]",
+			endpoint,
+			address.Hex(),
+		)
+	}
+
+	es := &externalSigner{
+		signer:  signer,
+		account: account,
+	}
+
+	signature, err := es.Sign(publicKeyProbe)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"could not sign public key probe with external signer: [%v]",
+			err,
+		)
+	}
+
+	publicKey, err := recoverPublicKey(publicKeyProbe, signature)
+	if err != nil {
+		return nil, fmt.Errorf("could not recover operator public key: [%v]", err)
+	}
+
+	if crypto.PubkeyToAddress(*publicKey) != address {
+		return nil, fmt.Errorf(
+			"external signer signed public key probe with a key other than "+
+				"the one of account [%v]",
+			address.Hex(),
+		)
+	}
+
+	es.publicKey = publicKey
+
+	return es, nil
+}
+
+func (es *externalSigner) Address() common.Address {
+	return es.account.Address
+}
+
+func (es *externalSigner) OperatorPublicKey() *operator.PublicKey {
+	return es.publicKey
+}
+
+func (es *externalSigner) SignTransaction(
+	signer types.Signer,
+	address common.Address,
+	transaction *types.Transaction,
+) (*types.Transaction, error) {
+	if address != es.account.Address {
+		return nil, fmt.Errorf("not authorized to sign for [%v]", address.Hex())
+	}
+
+	signedTransaction, err := es.signer.SignTx(es.account, transaction, nil)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"external signer failed to sign transaction: [%v]",
+			err,
+		)
+	}
+
+	// The external signer may have applied its own replay protection and
+	// modified transaction fields if it was configured to do so. The nonce
+	// and gas price are managed by the client and must be preserved since
+	// they are used to resubmit transactions.
+	if signedTransaction.Nonce() != transaction.Nonce() ||
+		signedTransaction.GasPrice().Cmp(transaction.GasPrice()) != 0 ||
+		!bytes.Equal(signedTransaction.Data(), transaction.Data()) {
+		return nil, fmt.Errorf(
+			"external signer modified the transaction being signed",
+		)
+	}
+
+	return signedTransaction, nil
+}
+
+// Sign signs the provided message using Ethereum-specific format. Messages
+// are passed to the external signer as text/plain data so the signer applies
+// the same Ethereum prefix as the in-memory key signer does.
+func (es *externalSigner) Sign(message []byte) ([]byte, error) {
+	signature, err := es.signer.SignText(es.account, message)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(signature) != ethutil.SignatureSize {
+		return nil, fmt.Errorf(
+			"external signer returned signature of invalid length [%v]",
+			len(signature),
+		)
+	}
+
+	return signature, nil
+}
+
+// publicKeySigning implements the parts of chain.Signing which require only
+// the public key of the operator. It is used by signers which do not hold
+// the private key in the client process.
+type publicKeySigning struct {
+	publicKey *operator.PublicKey
+}
+
+func (pks *publicKeySigning) PublicKey() []byte {
+	return elliptic.Marshal(pks.publicKey.Curve, pks.publicKey.X, pks.publicKey.Y)
+}
+
+func (pks *publicKeySigning) Verify(message []byte, signature []byte) (bool, error) {
+	return pks.VerifyWithPublicKey(message, signature, pks.PublicKey())
+}
+
+func (pks *publicKeySigning) VerifyWithPublicKey(
+	message []byte,
+	signature []byte,
+	publicKey []byte,
+) (bool, error) {
+	recoveredPublicKey, err := recoverPublicKey(message, signature)
+	if err != nil {
+		// Malformed signatures are simply invalid, consistently with the
+		// in-memory key signer.
+		return false, nil
+	}
+
+	return bytes.Equal(
+		elliptic.Marshal(
+			recoveredPublicKey.Curve,
+			recoveredPublicKey.X,
+			recoveredPublicKey.Y,
+		),
+		publicKey,
+	), nil
+}
+
+func (pks *publicKeySigning) PublicKeyToAddress(publicKey ecdsa.PublicKey) []byte {
+	return crypto.PubkeyToAddress(publicKey).Bytes()
+}
+
+func (pks *publicKeySigning) PublicKeyBytesToAddress(publicKey []byte) []byte {
+	// Does the same as crypto.PubkeyToAddress but directly on public key bytes.
+	return crypto.Keccak256(publicKey[1:])[12:]
+}
+
+// recoverPublicKey recovers the public key from the Ethereum-specific
+// signature over the given message.
+func recoverPublicKey(message []byte, signature []byte) (*ecdsa.PublicKey, error) {
+	if len(signature) != ethutil.SignatureSize {
+		return nil, fmt.Errorf(
+			"signature has invalid length [%v]",
+			len(signature),
+		)
+	}
+
+	// Ethereum-specific signatures have the recovery id in {27, 28}
+	// while go-ethereum/crypto expects it to be in {0, 1}.
+	recoverableSignature := make([]byte, ethutil.SignatureSize)
+	copy(recoverableSignature, signature)
+	if recoverableSignature[ethutil.SignatureSize-1] >= 27 {
+		recoverableSignature[ethutil.SignatureSize-1] -= 27
+	}
+
+	return crypto.SigToPub(
+		accounts.TextHash(message),
+		recoverableSignature,
+	)
+}
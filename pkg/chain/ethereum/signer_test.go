@@ -0,0 +1,255 @@
+package ethereum
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/keep-network/keep-common/pkg/chain/ethereum/ethutil"
+)
+
+func TestExternalSignerSignAndVerify(t *testing.T) {
+	signerKey, endpoint, stop := startMockExternalSigner(t)
+	defer stop()
+
+	signer, err := NewExternalSigner(endpoint, signerKey.Address)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	message := []byte("He that breaks a thing to find out what it is")
+
+	signature, err := signer.Sign(message)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Signatures from the external signer must be the same as signatures
+	// calculated by the in-memory key signer.
+	keySigner := NewKeySigner(signerKey)
+	expectedSignature, err := keySigner.Sign(message)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(signature, expectedSignature) {
+		t.Errorf(
+			"unexpected signature\nexpected: [%x]\nactual:   [%x]",
+			expectedSignature,
+			signature,
+		)
+	}
+
+	ok, err := signer.Verify(message, signature)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok {
+		t.Errorf("expected signature to be valid")
+	}
+
+	ok, err = keySigner.Verify(message, signature)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok {
+		t.Errorf("expected signature to be valid for the key signer")
+	}
+
+	ok, err = signer.Verify([]byte("has left the path of wisdom"), signature)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok {
+		t.Errorf("expected signature to be invalid for another message")
+	}
+
+	if !bytes.Equal(signer.PublicKey(), keySigner.PublicKey()) {
+		t.Errorf(
+			"unexpected public key\nexpected: [%x]\nactual:   [%x]",
+			keySigner.PublicKey(),
+			signer.PublicKey(),
+		)
+	}
+}
+
+func TestExternalSignerSignTransaction(t *testing.T) {
+	signerKey, endpoint, stop := startMockExternalSigner(t)
+	defer stop()
+
+	signer, err := NewExternalSigner(endpoint, signerKey.Address)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	transaction := types.NewTransaction(
+		5,
+		common.HexToAddress("0xcf64c2a367341170cb4e09cf8c0ed137d8473ceb"),
+		big.NewInt(0),
+		250000,
+		big.NewInt(20000000000),
+		[]byte{0x01, 0x02},
+	)
+
+	signedTransaction, err := signer.SignTransaction(
+		types.HomesteadSigner{},
+		signerKey.Address,
+		transaction,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sender, err := types.Sender(
+		types.NewEIP155Signer(signedTransaction.ChainId()),
+		signedTransaction,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sender != signerKey.Address {
+		t.Errorf(
+			"unexpected transaction sender\nexpected: [%v]\nactual:   [%v]",
+			signerKey.Address.Hex(),
+			sender.Hex(),
+		)
+	}
+
+	_, err = signer.SignTransaction(
+		types.HomesteadSigner{},
+		common.HexToAddress("0x1895e4A71d0956553cf80f2ccac69642a2f1bFF4"),
+		transaction,
+	)
+	if err == nil {
+		t.Errorf("expected error when signing for another account")
+	}
+}
+
+func TestExternalSignerUnknownAccount(t *testing.T) {
+	_, endpoint, stop := startMockExternalSigner(t)
+	defer stop()
+
+	unknownAddress := common.HexToAddress(
+		"0x1895e4A71d0956553cf80f2ccac69642a2f1bFF4",
+	)
+
+	_, err := NewExternalSigner(endpoint, unknownAddress)
+
+	expectedError := fmt.Errorf(
+		"external signer [%v] does not manage account [%v]",
+		endpoint,
+		unknownAddress.Hex(),
+	)
+	if err == nil || err.Error() != expectedError.Error() {
+		t.Errorf(
+			"unexpected error\nexpected: [%v]\nactual:   [%v]",
+			expectedError,
+			err,
+		)
+	}
+}
+
+// startMockExternalSigner starts a JSON-RPC server exposing the subset of
+// the Clef external API used by the client. It returns the key managed by
+// the signer, the signer's endpoint, and a function stopping the server.
+func startMockExternalSigner(t *testing.T) (*keystore.Key, string, func()) {
+	privateKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := rpc.NewServer()
+	err = server.RegisterName("account", &mockExternalSigner{privateKey})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	httpServer := httptest.NewServer(server)
+	stop := func() {
+		httpServer.Close()
+		server.Stop()
+	}
+
+	return &keystore.Key{
+		Address:    crypto.PubkeyToAddress(privateKey.PublicKey),
+		PrivateKey: privateKey,
+	}, httpServer.URL, stop
+}
+
+type mockExternalSigner struct {
+	privateKey *ecdsa.PrivateKey
+}
+
+type mockTransactionArgs struct {
+	From     common.MixedcaseAddress  `json:"from"`
+	To       *common.MixedcaseAddress `json:"to"`
+	Gas      hexutil.Uint64           `json:"gas"`
+	GasPrice hexutil.Big              `json:"gasPrice"`
+	Value    hexutil.Big              `json:"value"`
+	Nonce    hexutil.Uint64           `json:"nonce"`
+	Data     *hexutil.Bytes           `json:"data"`
+}
+
+type mockSignTransactionResult struct {
+	Raw hexutil.Bytes      `json:"raw"`
+	Tx  *types.Transaction `json:"tx"`
+}
+
+func (mes *mockExternalSigner) Version() string {
+	return "6.0.0"
+}
+
+func (mes *mockExternalSigner) List() []common.Address {
+	return []common.Address{crypto.PubkeyToAddress(mes.privateKey.PublicKey)}
+}
+
+func (mes *mockExternalSigner) SignData(
+	contentType string,
+	address common.MixedcaseAddress,
+	data hexutil.Bytes,
+) (hexutil.Bytes, error) {
+	if contentType != accounts.MimetypeTextPlain {
+		return nil, fmt.Errorf("unsupported content type [%v]", contentType)
+	}
+
+	return ethutil.NewSigner(mes.privateKey).Sign(data)
+}
+
+func (mes *mockExternalSigner) SignTransaction(
+	args mockTransactionArgs,
+) (*mockSignTransactionResult, error) {
+	transaction := types.NewTransaction(
+		uint64(args.Nonce),
+		args.To.Address(),
+		args.Value.ToInt(),
+		uint64(args.Gas),
+		args.GasPrice.ToInt(),
+		*args.Data,
+	)
+
+	signedTransaction, err := types.SignTx(
+		transaction,
+		types.NewEIP155Signer(big.NewInt(1101)),
+		mes.privateKey,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := rlp.EncodeToBytes(signedTransaction)
+	if err != nil {
+		return nil, err
+	}
+
+	return &mockSignTransactionResult{raw, signedTransaction}, nil
+}
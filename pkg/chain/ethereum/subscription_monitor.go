@@ -0,0 +1,55 @@
+package ethereum
+
+import (
+	"sync"
+	"time"
+
+	"github.com/keep-network/keep-core/pkg/chain"
+)
+
+// subscriptionMonitor records failures of chain event subscriptions. Failed
+// subscriptions are renewed by the contract bindings after a retry delay,
+// so a failure is reported only for some time after it happened.
+type subscriptionMonitor struct {
+	mutex    sync.Mutex
+	failures map[string]chain.SubscriptionFailure
+}
+
+func newSubscriptionMonitor() *subscriptionMonitor {
+	return &subscriptionMonitor{
+		failures: make(map[string]chain.SubscriptionFailure),
+	}
+}
+
+// failed records the failure of the subscription to the given event and
+// returns the error so that it can be passed back to the contract bindings.
+func (sm *subscriptionMonitor) failed(event string, err error) error {
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+
+	sm.failures[event] = chain.SubscriptionFailure{
+		Event: event,
+		Error: err.Error(),
+		Time:  time.Now(),
+	}
+
+	return err
+}
+
+// recentFailures returns the most recent failure of each subscription which
+// failed within the given period.
+func (sm *subscriptionMonitor) recentFailures(
+	period time.Duration,
+) []chain.SubscriptionFailure {
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+
+	failures := make([]chain.SubscriptionFailure, 0)
+	for _, failure := range sm.failures {
+		if time.Since(failure.Time) <= period {
+			failures = append(failures, failure)
+		}
+	}
+
+	return failures
+}
@@ -0,0 +1,443 @@
+package ethereum
+
+import (
+	"context"
+	"math/big"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/keep-network/keep-core/pkg/chain"
+)
+
+// DefaultResubmissionBlocks is the default number of blocks a protocol
+// transaction is given to be mined. If the transaction is not mined within
+// this number of blocks, it is replaced with a transaction with a higher gas
+// price.
+var DefaultResubmissionBlocks uint64 = 3
+
+// cancellationGasLimit is the gas limit of a transaction replacing a protocol
+// transaction which is no longer useful. The replacement is a plain transfer
+// of zero ether from the operator to itself.
+const cancellationGasLimit = 21000
+
+// transactionBackend is the part of the Ethereum client used by the
+// transaction manager.
+type transactionBackend interface {
+	SuggestGasPrice(ctx context.Context) (*big.Int, error)
+	SendTransaction(ctx context.Context, transaction *types.Transaction) error
+	TransactionReceipt(
+		ctx context.Context,
+		transactionHash common.Hash,
+	) (*types.Receipt, error)
+}
+
+// transactionManager tracks protocol transactions submitted to the operator
+// contract until they are mined. A transaction which is not mined within
+// the given number of blocks is replaced with a transaction having the same
+// nonce and a higher gas price so that it does not block transactions with
+// higher nonces submitted after it. A transaction which is no longer useful,
+// for example because another group member already submitted the relay
+// entry, is cancelled by replacing it with a plain transfer.
+//
+// The gas price of submitted and replaced transactions never exceeds the
+// lower of the maximum gas price from the configuration and the gas price
+// ceiling of the operator contract since the operator is not reimbursed
+// above the ceiling.
+type transactionManager struct {
+	backend            transactionBackend
+	signer             Signer
+	resubmissionBlocks uint64
+	maxGasPrice        *big.Int
+	gasPriceCeiling    func() (*big.Int, error)
+
+	// pendingMutex guards the pending set and modifications of pending
+	// transactions. Pending transactions are modified by the monitoring
+	// goroutine only, so it reads them without holding the mutex and does
+	// not hold it while making chain requests.
+	pendingMutex sync.Mutex
+	pending      map[uint64]*pendingTransaction
+}
+
+type pendingTransaction struct {
+	method string
+	// isUseful reports whether the transaction still needs to be mined.
+	// It may be nil if the transaction is always useful.
+	isUseful func() (bool, error)
+
+	// transaction is the most recently submitted version of the transaction.
+	transaction *types.Transaction
+	// hashes holds hashes of all submitted versions of the transaction; any
+	// of them may get mined.
+	hashes           []common.Hash
+	submittedAtBlock uint64
+	// submittedAtBlockUnknown is set when the block at which the transaction
+	// was submitted could not be determined; the block of the first check of
+	// pending transactions is used instead.
+	submittedAtBlockUnknown bool
+	resubmissions           int
+	cancelled               bool
+}
+
+func newTransactionManager(
+	backend transactionBackend,
+	signer Signer,
+	resubmissionBlocks uint64,
+	maxGasPrice *big.Int,
+	gasPriceCeiling func() (*big.Int, error),
+) *transactionManager {
+	return &transactionManager{
+		backend:            backend,
+		signer:             signer,
+		resubmissionBlocks: resubmissionBlocks,
+		maxGasPrice:        maxGasPrice,
+		gasPriceCeiling:    gasPriceCeiling,
+		pending:            make(map[uint64]*pendingTransaction),
+	}
+}
+
+// monitor checks pending transactions every time a new block is mined. It
+// returns when the context is done or the blocks channel is closed.
+func (tm *transactionManager) monitor(
+	ctx context.Context,
+	blocks <-chan uint64,
+) {
+	for {
+		select {
+		case block, ok := <-blocks:
+			if !ok {
+				return
+			}
+			tm.checkPending(block)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// gasPriceLimit returns the maximum gas price the client is willing to pay
+// for a protocol transaction.
+func (tm *transactionManager) gasPriceLimit() *big.Int {
+	ceiling, err := tm.gasPriceCeiling()
+	if err != nil {
+		logger.Warningf("could not get gas price ceiling: [%v]", err)
+		return tm.maxGasPrice
+	}
+
+	if ceiling.Sign() > 0 && ceiling.Cmp(tm.maxGasPrice) < 0 {
+		return ceiling
+	}
+
+	return tm.maxGasPrice
+}
+
+// gasPrice returns the gas price a new protocol transaction should be
+// submitted with; it is the price suggested by the Ethereum client capped at
+// the gas price limit. It returns nil if the price could not be determined
+// and the default one should be used.
+func (tm *transactionManager) gasPrice() *big.Int {
+	suggestedGasPrice, err := tm.backend.SuggestGasPrice(context.TODO())
+	if err != nil {
+		logger.Warningf("could not get suggested gas price: [%v]", err)
+		return nil
+	}
+
+	if limit := tm.gasPriceLimit(); suggestedGasPrice.Cmp(limit) > 0 {
+		return limit
+	}
+
+	return suggestedGasPrice
+}
+
+// track starts tracking the submitted transaction until it is mined.
+func (tm *transactionManager) track(
+	method string,
+	transaction *types.Transaction,
+	currentBlock uint64,
+	isUseful func() (bool, error),
+) {
+	tm.add(&pendingTransaction{
+		method:           method,
+		isUseful:         isUseful,
+		transaction:      transaction,
+		hashes:           []common.Hash{transaction.Hash()},
+		submittedAtBlock: currentBlock,
+	})
+}
+
+// trackFromNextBlock starts tracking the submitted transaction until it is
+// mined when the block at which it was submitted is not known. The
+// transaction is given the full number of blocks to be mined counting from
+// the next check of pending transactions.
+func (tm *transactionManager) trackFromNextBlock(
+	method string,
+	transaction *types.Transaction,
+	isUseful func() (bool, error),
+) {
+	tm.add(&pendingTransaction{
+		method:                  method,
+		isUseful:                isUseful,
+		transaction:             transaction,
+		hashes:                  []common.Hash{transaction.Hash()},
+		submittedAtBlockUnknown: true,
+	})
+}
+
+func (tm *transactionManager) add(pending *pendingTransaction) {
+	tm.pendingMutex.Lock()
+	defer tm.pendingMutex.Unlock()
+
+	tm.pending[pending.transaction.Nonce()] = pending
+}
+
+// remove stops tracking the pending transaction unless it has already been
+// replaced in the pending set by another transaction with the same nonce.
+func (tm *transactionManager) remove(
+	nonce uint64,
+	pending *pendingTransaction,
+) {
+	tm.pendingMutex.Lock()
+	defer tm.pendingMutex.Unlock()
+
+	if tm.pending[nonce] == pending {
+		delete(tm.pending, nonce)
+	}
+}
+
+func (tm *transactionManager) checkPending(currentBlock uint64) {
+	// Checking and replacing transactions requires chain requests which are
+	// made on a copy of the pending set so that tracking new transactions
+	// and reading the pending set are not blocked by them.
+	tm.pendingMutex.Lock()
+	pendingSet := make(map[uint64]*pendingTransaction, len(tm.pending))
+	for nonce, pending := range tm.pending {
+		pendingSet[nonce] = pending
+	}
+	tm.pendingMutex.Unlock()
+
+	for nonce, pending := range pendingSet {
+		if tm.isMined(pending) {
+			tm.remove(nonce, pending)
+			continue
+		}
+
+		if pending.submittedAtBlockUnknown {
+			tm.pendingMutex.Lock()
+			pending.submittedAtBlock = currentBlock
+			pending.submittedAtBlockUnknown = false
+			tm.pendingMutex.Unlock()
+		}
+
+		if !pending.cancelled && pending.isUseful != nil {
+			useful, err := pending.isUseful()
+			if err != nil {
+				logger.Warningf(
+					"could not check if transaction [%v] is still useful: [%v]",
+					pending.transaction.Hash().Hex(),
+					err,
+				)
+			} else if !useful {
+				tm.cancel(nonce, pending, currentBlock)
+				continue
+			}
+		}
+
+		if currentBlock-pending.submittedAtBlock >= tm.resubmissionBlocks {
+			tm.resubmit(nonce, pending, currentBlock)
+		}
+	}
+}
+
+func (tm *transactionManager) isMined(pending *pendingTransaction) bool {
+	for _, hash := range pending.hashes {
+		receipt, _ := tm.backend.TransactionReceipt(context.TODO(), hash)
+		if receipt != nil {
+			logger.Infof(
+				"transaction [%v] [%v] mined with status [%v] at block [%v]",
+				pending.method,
+				hash.Hex(),
+				receipt.Status,
+				receipt.BlockNumber,
+			)
+			return true
+		}
+	}
+
+	return false
+}
+
+func (tm *transactionManager) resubmit(
+	nonce uint64,
+	pending *pendingTransaction,
+	currentBlock uint64,
+) {
+	gasPrice, ok := tm.bumpedGasPrice(pending.transaction.GasPrice())
+	if !ok {
+		return
+	}
+
+	logger.Infof(
+		"transaction [%v] [%v] not mined for [%v] blocks; "+
+			"resubmitting with gas price [%v]",
+		pending.method,
+		pending.transaction.Hash().Hex(),
+		currentBlock-pending.submittedAtBlock,
+		gasPrice,
+	)
+
+	replacement := types.NewTransaction(
+		nonce,
+		*pending.transaction.To(),
+		pending.transaction.Value(),
+		pending.transaction.Gas(),
+		gasPrice,
+		pending.transaction.Data(),
+	)
+
+	tm.replace(nonce, pending, replacement, currentBlock)
+}
+
+func (tm *transactionManager) cancel(
+	nonce uint64,
+	pending *pendingTransaction,
+	currentBlock uint64,
+) {
+	gasPrice, ok := tm.bumpedGasPrice(pending.transaction.GasPrice())
+	if !ok {
+		logger.Warningf(
+			"transaction [%v] [%v] is no longer useful but it can not be "+
+				"cancelled with a higher gas price",
+			pending.method,
+			pending.transaction.Hash().Hex(),
+		)
+		tm.pendingMutex.Lock()
+		pending.cancelled = true
+		tm.pendingMutex.Unlock()
+		return
+	}
+
+	logger.Infof(
+		"transaction [%v] [%v] is no longer useful; cancelling",
+		pending.method,
+		pending.transaction.Hash().Hex(),
+	)
+
+	cancellation := types.NewTransaction(
+		nonce,
+		tm.signer.Address(),
+		big.NewInt(0),
+		cancellationGasLimit,
+		gasPrice,
+		nil,
+	)
+
+	if tm.replace(nonce, pending, cancellation, currentBlock) {
+		tm.pendingMutex.Lock()
+		pending.cancelled = true
+		tm.pendingMutex.Unlock()
+	}
+}
+
+// bumpedGasPrice returns the gas price increased by 20% and capped at the gas
+// price limit. The second returned value is false if the given gas price
+// already reached the limit.
+func (tm *transactionManager) bumpedGasPrice(gasPrice *big.Int) (*big.Int, bool) {
+	limit := tm.gasPriceLimit()
+	if gasPrice.Cmp(limit) >= 0 {
+		return nil, false
+	}
+
+	twentyPercent := new(big.Int).Div(gasPrice, big.NewInt(5))
+	bumpedGasPrice := new(big.Int).Add(gasPrice, twentyPercent)
+	if bumpedGasPrice.Cmp(limit) > 0 {
+		bumpedGasPrice = limit
+	}
+
+	return bumpedGasPrice, true
+}
+
+// replace signs and submits the replacement of the pending transaction. It
+// returns true if the replacement has been submitted.
+func (tm *transactionManager) replace(
+	nonce uint64,
+	pending *pendingTransaction,
+	replacement *types.Transaction,
+	currentBlock uint64,
+) bool {
+	signedReplacement, err := tm.signer.SignTransaction(
+		types.HomesteadSigner{},
+		tm.signer.Address(),
+		replacement,
+	)
+	if err != nil {
+		logger.Errorf(
+			"could not sign replacement of transaction [%v]: [%v]",
+			pending.transaction.Hash().Hex(),
+			err,
+		)
+		return false
+	}
+
+	err = tm.backend.SendTransaction(context.TODO(), signedReplacement)
+	if err != nil {
+		// Nonce too low means a transaction with this nonce has been mined
+		// in the meantime.
+		if strings.Contains(err.Error(), "nonce too low") {
+			tm.remove(nonce, pending)
+			return false
+		}
+
+		logger.Warningf(
+			"could not submit replacement of transaction [%v]: [%v]",
+			pending.transaction.Hash().Hex(),
+			err,
+		)
+		return false
+	}
+
+	logger.Infof(
+		"replaced transaction [%v] with [%v]",
+		pending.transaction.Hash().Hex(),
+		signedReplacement.Hash().Hex(),
+	)
+
+	tm.pendingMutex.Lock()
+	pending.transaction = signedReplacement
+	pending.hashes = append(pending.hashes, signedReplacement.Hash())
+	pending.submittedAtBlock = currentBlock
+	pending.resubmissions++
+	tm.pendingMutex.Unlock()
+
+	return true
+}
+
+// pendingTransactions returns a snapshot of transactions which have not been
+// mined yet, ordered by nonce.
+func (tm *transactionManager) pendingTransactions() []chain.PendingTransaction {
+	tm.pendingMutex.Lock()
+	defer tm.pendingMutex.Unlock()
+
+	pendingTransactions := make([]chain.PendingTransaction, 0, len(tm.pending))
+	for nonce, pending := range tm.pending {
+		pendingTransactions = append(
+			pendingTransactions,
+			chain.PendingTransaction{
+				Hash:             pending.transaction.Hash().Hex(),
+				Method:           pending.method,
+				Nonce:            nonce,
+				GasPrice:         new(big.Int).Set(pending.transaction.GasPrice()),
+				SubmittedAtBlock: pending.submittedAtBlock,
+				Resubmissions:    pending.resubmissions,
+				Cancelled:        pending.cancelled,
+			},
+		)
+	}
+
+	sort.Slice(pendingTransactions, func(i, j int) bool {
+		return pendingTransactions[i].Nonce < pendingTransactions[j].Nonce
+	})
+
+	return pendingTransactions
+}
@@ -0,0 +1,346 @@
+package ethereum
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var operatorContractAddress = common.HexToAddress(
+	"0x0b185C37E1C9D01437c800a8B60fA0845742c271",
+)
+
+func TestTransactionManagerResubmitsNotMinedTransaction(t *testing.T) {
+	manager, backend, signer := newTestTransactionManager(t, big.NewInt(1000))
+
+	transaction := submitTestTransaction(t, signer, backend, 5, big.NewInt(100))
+	manager.track("relayEntry", transaction, 10, nil)
+
+	// not enough blocks passed yet
+	manager.checkPending(12)
+	if len(backend.sent) != 1 {
+		t.Fatalf("unexpected number of submitted transactions: [%v]", len(backend.sent))
+	}
+
+	manager.checkPending(13)
+	if len(backend.sent) != 2 {
+		t.Fatalf("unexpected number of submitted transactions: [%v]", len(backend.sent))
+	}
+
+	replacement := backend.sent[1]
+	if replacement.Nonce() != transaction.Nonce() {
+		t.Errorf(
+			"unexpected nonce\nexpected: [%v]\nactual:   [%v]",
+			transaction.Nonce(),
+			replacement.Nonce(),
+		)
+	}
+	if replacement.GasPrice().Cmp(big.NewInt(120)) != 0 {
+		t.Errorf(
+			"unexpected gas price\nexpected: [%v]\nactual:   [%v]",
+			120,
+			replacement.GasPrice(),
+		)
+	}
+	if string(replacement.Data()) != string(transaction.Data()) {
+		t.Errorf("replacement has different data than the original transaction")
+	}
+
+	pending := manager.pendingTransactions()
+	if len(pending) != 1 {
+		t.Fatalf("unexpected number of pending transactions: [%v]", len(pending))
+	}
+	if pending[0].Hash != replacement.Hash().Hex() {
+		t.Errorf(
+			"unexpected pending transaction hash\nexpected: [%v]\nactual:   [%v]",
+			replacement.Hash().Hex(),
+			pending[0].Hash,
+		)
+	}
+	if pending[0].Resubmissions != 1 {
+		t.Errorf("unexpected number of resubmissions: [%v]", pending[0].Resubmissions)
+	}
+
+	// the original transaction gets mined
+	backend.mine(transaction)
+	manager.checkPending(14)
+
+	if len(manager.pendingTransactions()) != 0 {
+		t.Errorf("expected no pending transactions")
+	}
+}
+
+func TestTransactionManagerRespectsGasPriceCeiling(t *testing.T) {
+	manager, backend, signer := newTestTransactionManager(t, big.NewInt(110))
+
+	transaction := submitTestTransaction(t, signer, backend, 5, big.NewInt(100))
+	manager.track("submitTicket", transaction, 10, nil)
+
+	manager.checkPending(13)
+	if len(backend.sent) != 2 {
+		t.Fatalf("unexpected number of submitted transactions: [%v]", len(backend.sent))
+	}
+	if backend.sent[1].GasPrice().Cmp(big.NewInt(110)) != 0 {
+		t.Errorf(
+			"unexpected gas price\nexpected: [%v]\nactual:   [%v]",
+			110,
+			backend.sent[1].GasPrice(),
+		)
+	}
+
+	// gas price ceiling reached, no more resubmissions
+	manager.checkPending(16)
+	if len(backend.sent) != 2 {
+		t.Fatalf("unexpected number of submitted transactions: [%v]", len(backend.sent))
+	}
+
+	if gasPrice := manager.gasPrice(); gasPrice.Cmp(big.NewInt(110)) != 0 {
+		t.Errorf(
+			"unexpected gas price for a new transaction\n"+
+				"expected: [%v]\nactual:   [%v]",
+			110,
+			gasPrice,
+		)
+	}
+}
+
+func TestTransactionManagerCancelsUselessTransaction(t *testing.T) {
+	manager, backend, signer := newTestTransactionManager(t, big.NewInt(1000))
+
+	useful := true
+	isUseful := func() (bool, error) { return useful, nil }
+
+	transaction := submitTestTransaction(t, signer, backend, 5, big.NewInt(100))
+	manager.track("relayEntry", transaction, 10, isUseful)
+
+	manager.checkPending(11)
+	if len(backend.sent) != 1 {
+		t.Fatalf("unexpected number of submitted transactions: [%v]", len(backend.sent))
+	}
+
+	useful = false
+	manager.checkPending(12)
+	if len(backend.sent) != 2 {
+		t.Fatalf("unexpected number of submitted transactions: [%v]", len(backend.sent))
+	}
+
+	cancellation := backend.sent[1]
+	if cancellation.Nonce() != transaction.Nonce() {
+		t.Errorf(
+			"unexpected nonce\nexpected: [%v]\nactual:   [%v]",
+			transaction.Nonce(),
+			cancellation.Nonce(),
+		)
+	}
+	if *cancellation.To() != signer.Address() {
+		t.Errorf("cancellation should be a transfer to the operator")
+	}
+	if len(cancellation.Data()) != 0 || cancellation.Value().Sign() != 0 {
+		t.Errorf("cancellation should transfer nothing")
+	}
+	if cancellation.GasPrice().Cmp(transaction.GasPrice()) <= 0 {
+		t.Errorf("cancellation should have a higher gas price")
+	}
+
+	pending := manager.pendingTransactions()
+	if len(pending) != 1 || !pending[0].Cancelled {
+		t.Fatalf("expected cancelled pending transaction")
+	}
+
+	backend.mine(cancellation)
+	manager.checkPending(13)
+
+	if len(manager.pendingTransactions()) != 0 {
+		t.Errorf("expected no pending transactions")
+	}
+}
+
+func TestTransactionManagerTracksFromNextBlock(t *testing.T) {
+	manager, backend, signer := newTestTransactionManager(t, big.NewInt(1000))
+
+	transaction := submitTestTransaction(t, signer, backend, 5, big.NewInt(100))
+	manager.trackFromNextBlock("relayEntry", transaction, nil)
+
+	// the first check determines the submission block
+	manager.checkPending(20)
+	manager.checkPending(22)
+	if len(backend.sent) != 1 {
+		t.Fatalf("unexpected number of submitted transactions: [%v]", len(backend.sent))
+	}
+
+	manager.checkPending(23)
+	if len(backend.sent) != 2 {
+		t.Fatalf("unexpected number of submitted transactions: [%v]", len(backend.sent))
+	}
+}
+
+func TestTransactionManagerDoesNotBlockWhileChecking(t *testing.T) {
+	manager, backend, signer := newTestTransactionManager(t, big.NewInt(1000))
+
+	transaction := submitTestTransaction(t, signer, backend, 5, big.NewInt(100))
+	manager.track("relayEntry", transaction, 10, nil)
+
+	nextTransaction := submitTestTransaction(t, signer, backend, 6, big.NewInt(100))
+
+	// Tracking new transactions and reading the pending set while a chain
+	// request is in progress must not block.
+	done := make(chan struct{})
+	backend.onReceipt = func() {
+		backend.onReceipt = nil
+		manager.track("submitTicket", nextTransaction, 11, nil)
+		manager.pendingTransactions()
+		close(done)
+	}
+
+	checked := make(chan struct{})
+	go func() {
+		manager.checkPending(11)
+		close(checked)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("pending set locked while checking transactions")
+	}
+
+	<-checked
+
+	if len(manager.pendingTransactions()) != 2 {
+		t.Errorf("expected both transactions to be pending")
+	}
+}
+
+func TestTransactionManagerStopsMonitoringWhenContextIsDone(t *testing.T) {
+	manager, _, _ := newTestTransactionManager(t, big.NewInt(1000))
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+
+	// The block counter never closes the channels of its watchers.
+	blocks := make(chan uint64)
+
+	stopped := make(chan struct{})
+	go func() {
+		manager.monitor(ctx, blocks)
+		close(stopped)
+	}()
+
+	blocks <- 10
+	cancelCtx()
+
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("transaction manager still monitors pending transactions")
+	}
+}
+
+func newTestTransactionManager(
+	t *testing.T,
+	gasPriceCeiling *big.Int,
+) (*transactionManager, *mockTransactionBackend, Signer) {
+	privateKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signer := NewKeySigner(&keystore.Key{
+		Address:    crypto.PubkeyToAddress(privateKey.PublicKey),
+		PrivateKey: privateKey,
+	})
+
+	backend := &mockTransactionBackend{
+		suggestedGasPrice: big.NewInt(500),
+		mined:             make(map[common.Hash]bool),
+	}
+
+	manager := newTransactionManager(
+		backend,
+		signer,
+		3,
+		big.NewInt(1000),
+		func() (*big.Int, error) { return gasPriceCeiling, nil },
+	)
+
+	return manager, backend, signer
+}
+
+func submitTestTransaction(
+	t *testing.T,
+	signer Signer,
+	backend *mockTransactionBackend,
+	nonce uint64,
+	gasPrice *big.Int,
+) *types.Transaction {
+	transaction, err := signer.SignTransaction(
+		types.HomesteadSigner{},
+		signer.Address(),
+		types.NewTransaction(
+			nonce,
+			operatorContractAddress,
+			big.NewInt(0),
+			250000,
+			gasPrice,
+			[]byte{0x01, 0x02, 0x03},
+		),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := backend.SendTransaction(context.Background(), transaction); err != nil {
+		t.Fatal(err)
+	}
+
+	return transaction
+}
+
+type mockTransactionBackend struct {
+	suggestedGasPrice *big.Int
+	sent              []*types.Transaction
+	mined             map[common.Hash]bool
+	onReceipt         func()
+}
+
+func (mtb *mockTransactionBackend) mine(transaction *types.Transaction) {
+	mtb.mined[transaction.Hash()] = true
+}
+
+func (mtb *mockTransactionBackend) SuggestGasPrice(
+	ctx context.Context,
+) (*big.Int, error) {
+	return mtb.suggestedGasPrice, nil
+}
+
+func (mtb *mockTransactionBackend) SendTransaction(
+	ctx context.Context,
+	transaction *types.Transaction,
+) error {
+	mtb.sent = append(mtb.sent, transaction)
+	return nil
+}
+
+func (mtb *mockTransactionBackend) TransactionReceipt(
+	ctx context.Context,
+	transactionHash common.Hash,
+) (*types.Receipt, error) {
+	if mtb.onReceipt != nil {
+		mtb.onReceipt()
+	}
+
+	if !mtb.mined[transactionHash] {
+		return nil, fmt.Errorf("not found")
+	}
+
+	return &types.Receipt{
+		Status:      types.ReceiptStatusSuccessful,
+		BlockNumber: big.NewInt(1),
+	}, nil
+}
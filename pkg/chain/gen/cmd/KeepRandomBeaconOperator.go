@@ -8,6 +8,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -1537,7 +1538,8 @@ func initializeKeepRandomBeaconOperator(c *cli.Context) (*contract.KeepRandomBea
 
 	return contract.NewKeepRandomBeaconOperator(
 		address,
-		key,
+		key.Address,
+		bind.NewKeyedTransactor(key.PrivateKey).Signer,
 		client,
 		ethutil.NewNonceManager(key.Address, client),
 		miningWaiter,
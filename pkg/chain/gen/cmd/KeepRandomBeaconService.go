@@ -9,6 +9,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -874,7 +875,8 @@ func initializeKeepRandomBeaconService(c *cli.Context) (*contract.KeepRandomBeac
 
 	return contract.NewKeepRandomBeaconService(
 		address,
-		key,
+		key.Address,
+		bind.NewKeyedTransactor(key.PrivateKey).Signer,
 		client,
 		ethutil.NewNonceManager(key.Address, client),
 		miningWaiter,
@@ -0,0 +1,958 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated command and any manual changes will be lost.
+
+package cmd
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/keep-network/keep-common/pkg/chain/ethereum/ethutil"
+	"github.com/keep-network/keep-common/pkg/cmd"
+	"github.com/keep-network/keep-core/config"
+	"github.com/keep-network/keep-core/pkg/chain/gen/contract"
+
+	"github.com/urfave/cli"
+)
+
+var KeepRegistryCommand cli.Command
+
+var keepRegistryDescription = `The keep-registry command allows calling the KeepRegistry contract on an
+	Ethereum network. It has subcommands corresponding to each contract method,
+	which respectively each take parameters based on the contract method's
+	parameters.
+
+	Subcommands will submit a non-mutating call to the network and output the
+	result.
+
+	All subcommands can be called against a specific block by passing the
+	-b/--block flag.
+
+	All subcommands can be used to investigate the result of a previous
+	transaction that called that same method by passing the -t/--transaction
+	flag with the transaction hash.
+
+	Subcommands for mutating methods may be submitted as a mutating transaction
+	by passing the -s/--submit flag. In this mode, this command will terminate
+	successfully once the transaction has been submitted, but will not wait for
+	the transaction to be included in a block. They return the transaction hash.
+
+	Calls that require ether to be paid will get 0 ether by default, which can
+	be changed by passing the -v/--value flag.`
+
+func init() {
+	AvailableCommands = append(AvailableCommands, cli.Command{
+		Name:        "keep-registry",
+		Usage:       `Provides access to the KeepRegistry contract.`,
+		Description: keepRegistryDescription,
+		Subcommands: []cli.Command{{
+			Name:      "is-new-operator-contract",
+			Usage:     "Calls the constant method isNewOperatorContract on the KeepRegistry contract.",
+			ArgsUsage: "[operatorContract] ",
+			Action:    krIsNewOperatorContract,
+			Before:    cmd.ArgCountChecker(1),
+			Flags:     cmd.ConstFlags,
+		}, {
+			Name:      "operator-contract-upgraders",
+			Usage:     "Calls the constant method operatorContractUpgraders on the KeepRegistry contract.",
+			ArgsUsage: "[arg0] ",
+			Action:    krOperatorContractUpgraders,
+			Before:    cmd.ArgCountChecker(1),
+			Flags:     cmd.ConstFlags,
+		}, {
+			Name:      "panic-buttons",
+			Usage:     "Calls the constant method panicButtons on the KeepRegistry contract.",
+			ArgsUsage: "[arg0] ",
+			Action:    krPanicButtons,
+			Before:    cmd.ArgCountChecker(1),
+			Flags:     cmd.ConstFlags,
+		}, {
+			Name:      "service-contract-upgrader-for",
+			Usage:     "Calls the constant method serviceContractUpgraderFor on the KeepRegistry contract.",
+			ArgsUsage: "[_operatorContract] ",
+			Action:    krServiceContractUpgraderFor,
+			Before:    cmd.ArgCountChecker(1),
+			Flags:     cmd.ConstFlags,
+		}, {
+			Name:      "service-contract-upgraders",
+			Usage:     "Calls the constant method serviceContractUpgraders on the KeepRegistry contract.",
+			ArgsUsage: "[arg0] ",
+			Action:    krServiceContractUpgraders,
+			Before:    cmd.ArgCountChecker(1),
+			Flags:     cmd.ConstFlags,
+		}, {
+			Name:      "default-panic-button",
+			Usage:     "Calls the constant method defaultPanicButton on the KeepRegistry contract.",
+			ArgsUsage: "",
+			Action:    krDefaultPanicButton,
+			Before:    cmd.ArgCountChecker(0),
+			Flags:     cmd.ConstFlags,
+		}, {
+			Name:      "governance",
+			Usage:     "Calls the constant method governance on the KeepRegistry contract.",
+			ArgsUsage: "",
+			Action:    krGovernance,
+			Before:    cmd.ArgCountChecker(0),
+			Flags:     cmd.ConstFlags,
+		}, {
+			Name:      "operator-contract-upgrader-for",
+			Usage:     "Calls the constant method operatorContractUpgraderFor on the KeepRegistry contract.",
+			ArgsUsage: "[_serviceContract] ",
+			Action:    krOperatorContractUpgraderFor,
+			Before:    cmd.ArgCountChecker(1),
+			Flags:     cmd.ConstFlags,
+		}, {
+			Name:      "registry-keeper",
+			Usage:     "Calls the constant method registryKeeper on the KeepRegistry contract.",
+			ArgsUsage: "",
+			Action:    krRegistryKeeper,
+			Before:    cmd.ArgCountChecker(0),
+			Flags:     cmd.ConstFlags,
+		}, {
+			Name:      "is-approved-operator-contract",
+			Usage:     "Calls the constant method isApprovedOperatorContract on the KeepRegistry contract.",
+			ArgsUsage: "[operatorContract] ",
+			Action:    krIsApprovedOperatorContract,
+			Before:    cmd.ArgCountChecker(1),
+			Flags:     cmd.ConstFlags,
+		}, {
+			Name:      "operator-contracts",
+			Usage:     "Calls the constant method operatorContracts on the KeepRegistry contract.",
+			ArgsUsage: "[arg0] ",
+			Action:    krOperatorContracts,
+			Before:    cmd.ArgCountChecker(1),
+			Flags:     cmd.ConstFlags,
+		}, {
+			Name:      "disable-operator-contract-panic-button",
+			Usage:     "Calls the method disableOperatorContractPanicButton on the KeepRegistry contract.",
+			ArgsUsage: "[_operatorContract] ",
+			Action:    krDisableOperatorContractPanicButton,
+			Before:    cli.BeforeFunc(cmd.NonConstArgsChecker.AndThen(cmd.ArgCountChecker(1))),
+			Flags:     cmd.NonConstFlags,
+		}, {
+			Name:      "approve-operator-contract",
+			Usage:     "Calls the method approveOperatorContract on the KeepRegistry contract.",
+			ArgsUsage: "[operatorContract] ",
+			Action:    krApproveOperatorContract,
+			Before:    cli.BeforeFunc(cmd.NonConstArgsChecker.AndThen(cmd.ArgCountChecker(1))),
+			Flags:     cmd.NonConstFlags,
+		}, {
+			Name:      "set-governance",
+			Usage:     "Calls the method setGovernance on the KeepRegistry contract.",
+			ArgsUsage: "[_governance] ",
+			Action:    krSetGovernance,
+			Before:    cli.BeforeFunc(cmd.NonConstArgsChecker.AndThen(cmd.ArgCountChecker(1))),
+			Flags:     cmd.NonConstFlags,
+		}, {
+			Name:      "set-operator-contract-upgrader",
+			Usage:     "Calls the method setOperatorContractUpgrader on the KeepRegistry contract.",
+			ArgsUsage: "[_serviceContract] [_operatorContractUpgrader] ",
+			Action:    krSetOperatorContractUpgrader,
+			Before:    cli.BeforeFunc(cmd.NonConstArgsChecker.AndThen(cmd.ArgCountChecker(2))),
+			Flags:     cmd.NonConstFlags,
+		}, {
+			Name:      "set-service-contract-upgrader",
+			Usage:     "Calls the method setServiceContractUpgrader on the KeepRegistry contract.",
+			ArgsUsage: "[_operatorContract] [_serviceContractUpgrader] ",
+			Action:    krSetServiceContractUpgrader,
+			Before:    cli.BeforeFunc(cmd.NonConstArgsChecker.AndThen(cmd.ArgCountChecker(2))),
+			Flags:     cmd.NonConstFlags,
+		}, {
+			Name:      "disable-operator-contract",
+			Usage:     "Calls the method disableOperatorContract on the KeepRegistry contract.",
+			ArgsUsage: "[operatorContract] ",
+			Action:    krDisableOperatorContract,
+			Before:    cli.BeforeFunc(cmd.NonConstArgsChecker.AndThen(cmd.ArgCountChecker(1))),
+			Flags:     cmd.NonConstFlags,
+		}, {
+			Name:      "set-default-panic-button",
+			Usage:     "Calls the method setDefaultPanicButton on the KeepRegistry contract.",
+			ArgsUsage: "[_panicButton] ",
+			Action:    krSetDefaultPanicButton,
+			Before:    cli.BeforeFunc(cmd.NonConstArgsChecker.AndThen(cmd.ArgCountChecker(1))),
+			Flags:     cmd.NonConstFlags,
+		}, {
+			Name:      "set-operator-contract-panic-button",
+			Usage:     "Calls the method setOperatorContractPanicButton on the KeepRegistry contract.",
+			ArgsUsage: "[_operatorContract] [_panicButton] ",
+			Action:    krSetOperatorContractPanicButton,
+			Before:    cli.BeforeFunc(cmd.NonConstArgsChecker.AndThen(cmd.ArgCountChecker(2))),
+			Flags:     cmd.NonConstFlags,
+		}, {
+			Name:      "set-registry-keeper",
+			Usage:     "Calls the method setRegistryKeeper on the KeepRegistry contract.",
+			ArgsUsage: "[_registryKeeper] ",
+			Action:    krSetRegistryKeeper,
+			Before:    cli.BeforeFunc(cmd.NonConstArgsChecker.AndThen(cmd.ArgCountChecker(1))),
+			Flags:     cmd.NonConstFlags,
+		}},
+	})
+}
+
+/// ------------------- Const methods -------------------
+
+func krIsNewOperatorContract(c *cli.Context) error {
+	contract, err := initializeKeepRegistry(c)
+	if err != nil {
+		return err
+	}
+	operatorContract, err := ethutil.AddressFromHex(c.Args()[0])
+	if err != nil {
+		return fmt.Errorf(
+			"couldn't parse parameter operatorContract, a address, from passed value %v",
+			c.Args()[0],
+		)
+	}
+
+	result, err := contract.IsNewOperatorContractAtBlock(
+		operatorContract,
+
+		cmd.BlockFlagValue.Uint,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	cmd.PrintOutput(result)
+
+	return nil
+}
+
+func krOperatorContractUpgraders(c *cli.Context) error {
+	contract, err := initializeKeepRegistry(c)
+	if err != nil {
+		return err
+	}
+	arg0, err := ethutil.AddressFromHex(c.Args()[0])
+	if err != nil {
+		return fmt.Errorf(
+			"couldn't parse parameter arg0, a address, from passed value %v",
+			c.Args()[0],
+		)
+	}
+
+	result, err := contract.OperatorContractUpgradersAtBlock(
+		arg0,
+
+		cmd.BlockFlagValue.Uint,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	cmd.PrintOutput(result)
+
+	return nil
+}
+
+func krPanicButtons(c *cli.Context) error {
+	contract, err := initializeKeepRegistry(c)
+	if err != nil {
+		return err
+	}
+	arg0, err := ethutil.AddressFromHex(c.Args()[0])
+	if err != nil {
+		return fmt.Errorf(
+			"couldn't parse parameter arg0, a address, from passed value %v",
+			c.Args()[0],
+		)
+	}
+
+	result, err := contract.PanicButtonsAtBlock(
+		arg0,
+
+		cmd.BlockFlagValue.Uint,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	cmd.PrintOutput(result)
+
+	return nil
+}
+
+func krServiceContractUpgraderFor(c *cli.Context) error {
+	contract, err := initializeKeepRegistry(c)
+	if err != nil {
+		return err
+	}
+	_operatorContract, err := ethutil.AddressFromHex(c.Args()[0])
+	if err != nil {
+		return fmt.Errorf(
+			"couldn't parse parameter _operatorContract, a address, from passed value %v",
+			c.Args()[0],
+		)
+	}
+
+	result, err := contract.ServiceContractUpgraderForAtBlock(
+		_operatorContract,
+
+		cmd.BlockFlagValue.Uint,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	cmd.PrintOutput(result)
+
+	return nil
+}
+
+func krServiceContractUpgraders(c *cli.Context) error {
+	contract, err := initializeKeepRegistry(c)
+	if err != nil {
+		return err
+	}
+	arg0, err := ethutil.AddressFromHex(c.Args()[0])
+	if err != nil {
+		return fmt.Errorf(
+			"couldn't parse parameter arg0, a address, from passed value %v",
+			c.Args()[0],
+		)
+	}
+
+	result, err := contract.ServiceContractUpgradersAtBlock(
+		arg0,
+
+		cmd.BlockFlagValue.Uint,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	cmd.PrintOutput(result)
+
+	return nil
+}
+
+func krDefaultPanicButton(c *cli.Context) error {
+	contract, err := initializeKeepRegistry(c)
+	if err != nil {
+		return err
+	}
+
+	result, err := contract.DefaultPanicButtonAtBlock(
+
+		cmd.BlockFlagValue.Uint,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	cmd.PrintOutput(result)
+
+	return nil
+}
+
+func krGovernance(c *cli.Context) error {
+	contract, err := initializeKeepRegistry(c)
+	if err != nil {
+		return err
+	}
+
+	result, err := contract.GovernanceAtBlock(
+
+		cmd.BlockFlagValue.Uint,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	cmd.PrintOutput(result)
+
+	return nil
+}
+
+func krOperatorContractUpgraderFor(c *cli.Context) error {
+	contract, err := initializeKeepRegistry(c)
+	if err != nil {
+		return err
+	}
+	_serviceContract, err := ethutil.AddressFromHex(c.Args()[0])
+	if err != nil {
+		return fmt.Errorf(
+			"couldn't parse parameter _serviceContract, a address, from passed value %v",
+			c.Args()[0],
+		)
+	}
+
+	result, err := contract.OperatorContractUpgraderForAtBlock(
+		_serviceContract,
+
+		cmd.BlockFlagValue.Uint,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	cmd.PrintOutput(result)
+
+	return nil
+}
+
+func krRegistryKeeper(c *cli.Context) error {
+	contract, err := initializeKeepRegistry(c)
+	if err != nil {
+		return err
+	}
+
+	result, err := contract.RegistryKeeperAtBlock(
+
+		cmd.BlockFlagValue.Uint,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	cmd.PrintOutput(result)
+
+	return nil
+}
+
+func krIsApprovedOperatorContract(c *cli.Context) error {
+	contract, err := initializeKeepRegistry(c)
+	if err != nil {
+		return err
+	}
+	operatorContract, err := ethutil.AddressFromHex(c.Args()[0])
+	if err != nil {
+		return fmt.Errorf(
+			"couldn't parse parameter operatorContract, a address, from passed value %v",
+			c.Args()[0],
+		)
+	}
+
+	result, err := contract.IsApprovedOperatorContractAtBlock(
+		operatorContract,
+
+		cmd.BlockFlagValue.Uint,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	cmd.PrintOutput(result)
+
+	return nil
+}
+
+func krOperatorContracts(c *cli.Context) error {
+	contract, err := initializeKeepRegistry(c)
+	if err != nil {
+		return err
+	}
+	arg0, err := ethutil.AddressFromHex(c.Args()[0])
+	if err != nil {
+		return fmt.Errorf(
+			"couldn't parse parameter arg0, a address, from passed value %v",
+			c.Args()[0],
+		)
+	}
+
+	result, err := contract.OperatorContractsAtBlock(
+		arg0,
+
+		cmd.BlockFlagValue.Uint,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	cmd.PrintOutput(result)
+
+	return nil
+}
+
+/// ------------------- Non-const methods -------------------
+
+func krDisableOperatorContractPanicButton(c *cli.Context) error {
+	contract, err := initializeKeepRegistry(c)
+	if err != nil {
+		return err
+	}
+
+	_operatorContract, err := ethutil.AddressFromHex(c.Args()[0])
+	if err != nil {
+		return fmt.Errorf(
+			"couldn't parse parameter _operatorContract, a address, from passed value %v",
+			c.Args()[0],
+		)
+	}
+
+	var (
+		transaction *types.Transaction
+	)
+
+	if c.Bool(cmd.SubmitFlag) {
+		// Do a regular submission. Take payable into account.
+		transaction, err = contract.DisableOperatorContractPanicButton(
+			_operatorContract,
+		)
+		if err != nil {
+			return err
+		}
+
+		cmd.PrintOutput(transaction.Hash)
+	} else {
+		// Do a call.
+		err = contract.CallDisableOperatorContractPanicButton(
+			_operatorContract,
+			cmd.BlockFlagValue.Uint,
+		)
+		if err != nil {
+			return err
+		}
+
+		cmd.PrintOutput(nil)
+	}
+
+	return nil
+}
+
+func krApproveOperatorContract(c *cli.Context) error {
+	contract, err := initializeKeepRegistry(c)
+	if err != nil {
+		return err
+	}
+
+	operatorContract, err := ethutil.AddressFromHex(c.Args()[0])
+	if err != nil {
+		return fmt.Errorf(
+			"couldn't parse parameter operatorContract, a address, from passed value %v",
+			c.Args()[0],
+		)
+	}
+
+	var (
+		transaction *types.Transaction
+	)
+
+	if c.Bool(cmd.SubmitFlag) {
+		// Do a regular submission. Take payable into account.
+		transaction, err = contract.ApproveOperatorContract(
+			operatorContract,
+		)
+		if err != nil {
+			return err
+		}
+
+		cmd.PrintOutput(transaction.Hash)
+	} else {
+		// Do a call.
+		err = contract.CallApproveOperatorContract(
+			operatorContract,
+			cmd.BlockFlagValue.Uint,
+		)
+		if err != nil {
+			return err
+		}
+
+		cmd.PrintOutput(nil)
+	}
+
+	return nil
+}
+
+func krSetGovernance(c *cli.Context) error {
+	contract, err := initializeKeepRegistry(c)
+	if err != nil {
+		return err
+	}
+
+	_governance, err := ethutil.AddressFromHex(c.Args()[0])
+	if err != nil {
+		return fmt.Errorf(
+			"couldn't parse parameter _governance, a address, from passed value %v",
+			c.Args()[0],
+		)
+	}
+
+	var (
+		transaction *types.Transaction
+	)
+
+	if c.Bool(cmd.SubmitFlag) {
+		// Do a regular submission. Take payable into account.
+		transaction, err = contract.SetGovernance(
+			_governance,
+		)
+		if err != nil {
+			return err
+		}
+
+		cmd.PrintOutput(transaction.Hash)
+	} else {
+		// Do a call.
+		err = contract.CallSetGovernance(
+			_governance,
+			cmd.BlockFlagValue.Uint,
+		)
+		if err != nil {
+			return err
+		}
+
+		cmd.PrintOutput(nil)
+	}
+
+	return nil
+}
+
+func krSetOperatorContractUpgrader(c *cli.Context) error {
+	contract, err := initializeKeepRegistry(c)
+	if err != nil {
+		return err
+	}
+
+	_serviceContract, err := ethutil.AddressFromHex(c.Args()[0])
+	if err != nil {
+		return fmt.Errorf(
+			"couldn't parse parameter _serviceContract, a address, from passed value %v",
+			c.Args()[0],
+		)
+	}
+
+	_operatorContractUpgrader, err := ethutil.AddressFromHex(c.Args()[1])
+	if err != nil {
+		return fmt.Errorf(
+			"couldn't parse parameter _operatorContractUpgrader, a address, from passed value %v",
+			c.Args()[1],
+		)
+	}
+
+	var (
+		transaction *types.Transaction
+	)
+
+	if c.Bool(cmd.SubmitFlag) {
+		// Do a regular submission. Take payable into account.
+		transaction, err = contract.SetOperatorContractUpgrader(
+			_serviceContract,
+			_operatorContractUpgrader,
+		)
+		if err != nil {
+			return err
+		}
+
+		cmd.PrintOutput(transaction.Hash)
+	} else {
+		// Do a call.
+		err = contract.CallSetOperatorContractUpgrader(
+			_serviceContract,
+			_operatorContractUpgrader,
+			cmd.BlockFlagValue.Uint,
+		)
+		if err != nil {
+			return err
+		}
+
+		cmd.PrintOutput(nil)
+	}
+
+	return nil
+}
+
+func krSetServiceContractUpgrader(c *cli.Context) error {
+	contract, err := initializeKeepRegistry(c)
+	if err != nil {
+		return err
+	}
+
+	_operatorContract, err := ethutil.AddressFromHex(c.Args()[0])
+	if err != nil {
+		return fmt.Errorf(
+			"couldn't parse parameter _operatorContract, a address, from passed value %v",
+			c.Args()[0],
+		)
+	}
+
+	_serviceContractUpgrader, err := ethutil.AddressFromHex(c.Args()[1])
+	if err != nil {
+		return fmt.Errorf(
+			"couldn't parse parameter _serviceContractUpgrader, a address, from passed value %v",
+			c.Args()[1],
+		)
+	}
+
+	var (
+		transaction *types.Transaction
+	)
+
+	if c.Bool(cmd.SubmitFlag) {
+		// Do a regular submission. Take payable into account.
+		transaction, err = contract.SetServiceContractUpgrader(
+			_operatorContract,
+			_serviceContractUpgrader,
+		)
+		if err != nil {
+			return err
+		}
+
+		cmd.PrintOutput(transaction.Hash)
+	} else {
+		// Do a call.
+		err = contract.CallSetServiceContractUpgrader(
+			_operatorContract,
+			_serviceContractUpgrader,
+			cmd.BlockFlagValue.Uint,
+		)
+		if err != nil {
+			return err
+		}
+
+		cmd.PrintOutput(nil)
+	}
+
+	return nil
+}
+
+func krDisableOperatorContract(c *cli.Context) error {
+	contract, err := initializeKeepRegistry(c)
+	if err != nil {
+		return err
+	}
+
+	operatorContract, err := ethutil.AddressFromHex(c.Args()[0])
+	if err != nil {
+		return fmt.Errorf(
+			"couldn't parse parameter operatorContract, a address, from passed value %v",
+			c.Args()[0],
+		)
+	}
+
+	var (
+		transaction *types.Transaction
+	)
+
+	if c.Bool(cmd.SubmitFlag) {
+		// Do a regular submission. Take payable into account.
+		transaction, err = contract.DisableOperatorContract(
+			operatorContract,
+		)
+		if err != nil {
+			return err
+		}
+
+		cmd.PrintOutput(transaction.Hash)
+	} else {
+		// Do a call.
+		err = contract.CallDisableOperatorContract(
+			operatorContract,
+			cmd.BlockFlagValue.Uint,
+		)
+		if err != nil {
+			return err
+		}
+
+		cmd.PrintOutput(nil)
+	}
+
+	return nil
+}
+
+func krSetDefaultPanicButton(c *cli.Context) error {
+	contract, err := initializeKeepRegistry(c)
+	if err != nil {
+		return err
+	}
+
+	_panicButton, err := ethutil.AddressFromHex(c.Args()[0])
+	if err != nil {
+		return fmt.Errorf(
+			"couldn't parse parameter _panicButton, a address, from passed value %v",
+			c.Args()[0],
+		)
+	}
+
+	var (
+		transaction *types.Transaction
+	)
+
+	if c.Bool(cmd.SubmitFlag) {
+		// Do a regular submission. Take payable into account.
+		transaction, err = contract.SetDefaultPanicButton(
+			_panicButton,
+		)
+		if err != nil {
+			return err
+		}
+
+		cmd.PrintOutput(transaction.Hash)
+	} else {
+		// Do a call.
+		err = contract.CallSetDefaultPanicButton(
+			_panicButton,
+			cmd.BlockFlagValue.Uint,
+		)
+		if err != nil {
+			return err
+		}
+
+		cmd.PrintOutput(nil)
+	}
+
+	return nil
+}
+
+func krSetOperatorContractPanicButton(c *cli.Context) error {
+	contract, err := initializeKeepRegistry(c)
+	if err != nil {
+		return err
+	}
+
+	_operatorContract, err := ethutil.AddressFromHex(c.Args()[0])
+	if err != nil {
+		return fmt.Errorf(
+			"couldn't parse parameter _operatorContract, a address, from passed value %v",
+			c.Args()[0],
+		)
+	}
+
+	_panicButton, err := ethutil.AddressFromHex(c.Args()[1])
+	if err != nil {
+		return fmt.Errorf(
+			"couldn't parse parameter _panicButton, a address, from passed value %v",
+			c.Args()[1],
+		)
+	}
+
+	var (
+		transaction *types.Transaction
+	)
+
+	if c.Bool(cmd.SubmitFlag) {
+		// Do a regular submission. Take payable into account.
+		transaction, err = contract.SetOperatorContractPanicButton(
+			_operatorContract,
+			_panicButton,
+		)
+		if err != nil {
+			return err
+		}
+
+		cmd.PrintOutput(transaction.Hash)
+	} else {
+		// Do a call.
+		err = contract.CallSetOperatorContractPanicButton(
+			_operatorContract,
+			_panicButton,
+			cmd.BlockFlagValue.Uint,
+		)
+		if err != nil {
+			return err
+		}
+
+		cmd.PrintOutput(nil)
+	}
+
+	return nil
+}
+
+func krSetRegistryKeeper(c *cli.Context) error {
+	contract, err := initializeKeepRegistry(c)
+	if err != nil {
+		return err
+	}
+
+	_registryKeeper, err := ethutil.AddressFromHex(c.Args()[0])
+	if err != nil {
+		return fmt.Errorf(
+			"couldn't parse parameter _registryKeeper, a address, from passed value %v",
+			c.Args()[0],
+		)
+	}
+
+	var (
+		transaction *types.Transaction
+	)
+
+	if c.Bool(cmd.SubmitFlag) {
+		// Do a regular submission. Take payable into account.
+		transaction, err = contract.SetRegistryKeeper(
+			_registryKeeper,
+		)
+		if err != nil {
+			return err
+		}
+
+		cmd.PrintOutput(transaction.Hash)
+	} else {
+		// Do a call.
+		err = contract.CallSetRegistryKeeper(
+			_registryKeeper,
+			cmd.BlockFlagValue.Uint,
+		)
+		if err != nil {
+			return err
+		}
+
+		cmd.PrintOutput(nil)
+	}
+
+	return nil
+}
+
+/// ------------------- Initialization -------------------
+
+func initializeKeepRegistry(c *cli.Context) (*contract.KeepRegistry, error) {
+	config, err := config.ReadEthereumConfig(c.GlobalString("config"))
+	if err != nil {
+		return nil, fmt.Errorf("error reading Ethereum config from file: [%v]", err)
+	}
+
+	client, _, _, err := ethutil.ConnectClients(config.URL, config.URLRPC)
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to Ethereum node: [%v]", err)
+	}
+
+	key, err := ethutil.DecryptKeyFile(
+		config.Account.KeyFile,
+		config.Account.KeyFilePassword,
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to read KeyFile: %s: [%v]",
+			config.Account.KeyFile,
+			err,
+		)
+	}
+
+	checkInterval := cmd.DefaultMiningCheckInterval
+	maxGasPrice := cmd.DefaultMaxGasPrice
+	if config.MiningCheckInterval != 0 {
+		checkInterval = time.Duration(config.MiningCheckInterval) * time.Second
+	}
+	if config.MaxGasPrice != nil {
+		maxGasPrice = config.MaxGasPrice.Int
+	}
+
+	miningWaiter := ethutil.NewMiningWaiter(client, checkInterval, maxGasPrice)
+
+	address := common.HexToAddress(config.ContractAddresses["KeepRegistry"])
+
+	return contract.NewKeepRegistry(
+		address,
+		key.Address,
+		bind.NewKeyedTransactor(key.PrivateKey).Signer,
+		client,
+		ethutil.NewNonceManager(key.Address, client),
+		miningWaiter,
+		&sync.Mutex{},
+	)
+}
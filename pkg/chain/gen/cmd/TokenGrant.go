@@ -8,6 +8,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -1316,7 +1317,8 @@ func initializeTokenGrant(c *cli.Context) (*contract.TokenGrant, error) {
 
 	return contract.NewTokenGrant(
 		address,
-		key,
+		key.Address,
+		bind.NewKeyedTransactor(key.PrivateKey).Signer,
 		client,
 		ethutil.NewNonceManager(key.Address, client),
 		miningWaiter,
@@ -8,6 +8,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -1389,7 +1390,8 @@ func initializeTokenStaking(c *cli.Context) (*contract.TokenStaking, error) {
 
 	return contract.NewTokenStaking(
 		address,
-		key,
+		key.Address,
+		bind.NewKeyedTransactor(key.PrivateKey).Signer,
 		client,
 		ethutil.NewNonceManager(key.Address, client),
 		miningWaiter,
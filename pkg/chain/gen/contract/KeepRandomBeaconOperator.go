@@ -12,7 +12,6 @@ import (
 
 	ethereumabi "github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
-	"github.com/ethereum/go-ethereum/accounts/keystore"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 
@@ -45,19 +44,21 @@ type KeepRandomBeaconOperator struct {
 
 func NewKeepRandomBeaconOperator(
 	contractAddress common.Address,
-	accountKey *keystore.Key,
+	accountAddress common.Address,
+	signer bind.SignerFn,
 	backend bind.ContractBackend,
 	nonceManager *ethutil.NonceManager,
 	miningWaiter *ethutil.MiningWaiter,
 	transactionMutex *sync.Mutex,
 ) (*KeepRandomBeaconOperator, error) {
 	callerOptions := &bind.CallOpts{
-		From: accountKey.Address,
+		From: accountAddress,
 	}
 
-	transactorOptions := bind.NewKeyedTransactor(
-		accountKey.PrivateKey,
-	)
+	transactorOptions := &bind.TransactOpts{
+		From:   accountAddress,
+		Signer: signer,
+	}
 
 	randomBeaconContract, err := abi.NewKeepRandomBeaconOperator(
 		contractAddress,
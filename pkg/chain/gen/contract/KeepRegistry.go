@@ -0,0 +1,3085 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package contract
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	ethereumabi "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/ipfs/go-log"
+
+	"github.com/keep-network/keep-common/pkg/chain/ethereum/ethutil"
+	"github.com/keep-network/keep-common/pkg/subscription"
+	"github.com/keep-network/keep-core/pkg/chain/gen/abi"
+)
+
+// Create a package-level logger for this contract. The logger exists at
+// package level so that the logger is registered at startup and can be
+// included or excluded from logging at startup by name.
+var krLogger = log.Logger("keep-contract-KeepRegistry")
+
+type KeepRegistry struct {
+	contract          *abi.KeepRegistry
+	contractAddress   common.Address
+	contractABI       *ethereumabi.ABI
+	caller            bind.ContractCaller
+	transactor        bind.ContractTransactor
+	callerOptions     *bind.CallOpts
+	transactorOptions *bind.TransactOpts
+	errorResolver     *ethutil.ErrorResolver
+	nonceManager      *ethutil.NonceManager
+	miningWaiter      *ethutil.MiningWaiter
+
+	transactionMutex *sync.Mutex
+}
+
+func NewKeepRegistry(
+	contractAddress common.Address,
+	accountAddress common.Address,
+	signer bind.SignerFn,
+	backend bind.ContractBackend,
+	nonceManager *ethutil.NonceManager,
+	miningWaiter *ethutil.MiningWaiter,
+	transactionMutex *sync.Mutex,
+) (*KeepRegistry, error) {
+	callerOptions := &bind.CallOpts{
+		From: accountAddress,
+	}
+
+	transactorOptions := &bind.TransactOpts{
+		From:   accountAddress,
+		Signer: signer,
+	}
+
+	randomBeaconContract, err := abi.NewKeepRegistry(
+		contractAddress,
+		backend,
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to instantiate contract at address: %s [%v]",
+			contractAddress.String(),
+			err,
+		)
+	}
+
+	contractABI, err := ethereumabi.JSON(strings.NewReader(abi.KeepRegistryABI))
+	if err != nil {
+		return nil, fmt.Errorf("failed to instantiate ABI: [%v]", err)
+	}
+
+	return &KeepRegistry{
+		contract:          randomBeaconContract,
+		contractAddress:   contractAddress,
+		contractABI:       &contractABI,
+		caller:            backend,
+		transactor:        backend,
+		callerOptions:     callerOptions,
+		transactorOptions: transactorOptions,
+		errorResolver:     ethutil.NewErrorResolver(backend, &contractABI, &contractAddress),
+		nonceManager:      nonceManager,
+		miningWaiter:      miningWaiter,
+		transactionMutex:  transactionMutex,
+	}, nil
+}
+
+// ----- Non-const Methods ------
+
+// Transaction submission.
+func (kr *KeepRegistry) DisableOperatorContractPanicButton(
+	_operatorContract common.Address,
+
+	transactionOptions ...ethutil.TransactionOptions,
+) (*types.Transaction, error) {
+	krLogger.Debug(
+		"submitting transaction disableOperatorContractPanicButton",
+		"params: ",
+		fmt.Sprint(
+			_operatorContract,
+		),
+	)
+
+	kr.transactionMutex.Lock()
+	defer kr.transactionMutex.Unlock()
+
+	// create a copy
+	transactorOptions := new(bind.TransactOpts)
+	*transactorOptions = *kr.transactorOptions
+
+	if len(transactionOptions) > 1 {
+		return nil, fmt.Errorf(
+			"could not process multiple transaction options sets",
+		)
+	} else if len(transactionOptions) > 0 {
+		transactionOptions[0].Apply(transactorOptions)
+	}
+
+	nonce, err := kr.nonceManager.CurrentNonce()
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve account nonce: %v", err)
+	}
+
+	transactorOptions.Nonce = new(big.Int).SetUint64(nonce)
+
+	transaction, err := kr.contract.DisableOperatorContractPanicButton(
+		transactorOptions,
+		_operatorContract,
+	)
+	if err != nil {
+		return transaction, kr.errorResolver.ResolveError(
+			err,
+			kr.transactorOptions.From,
+			nil,
+			"disableOperatorContractPanicButton",
+			_operatorContract,
+		)
+	}
+
+	krLogger.Infof(
+		"submitted transaction disableOperatorContractPanicButton with id: [%v] and nonce [%v]",
+		transaction.Hash().Hex(),
+		transaction.Nonce(),
+	)
+
+	go kr.miningWaiter.ForceMining(
+		transaction,
+		func(newGasPrice *big.Int) (*types.Transaction, error) {
+			transactorOptions.GasLimit = transaction.Gas()
+			transactorOptions.GasPrice = newGasPrice
+
+			transaction, err := kr.contract.DisableOperatorContractPanicButton(
+				transactorOptions,
+				_operatorContract,
+			)
+			if err != nil {
+				return transaction, kr.errorResolver.ResolveError(
+					err,
+					kr.transactorOptions.From,
+					nil,
+					"disableOperatorContractPanicButton",
+					_operatorContract,
+				)
+			}
+
+			krLogger.Infof(
+				"submitted transaction disableOperatorContractPanicButton with id: [%v] and nonce [%v]",
+				transaction.Hash().Hex(),
+				transaction.Nonce(),
+			)
+
+			return transaction, nil
+		},
+	)
+
+	kr.nonceManager.IncrementNonce()
+
+	return transaction, err
+}
+
+// Non-mutating call, not a transaction submission.
+func (kr *KeepRegistry) CallDisableOperatorContractPanicButton(
+	_operatorContract common.Address,
+	blockNumber *big.Int,
+) error {
+	var result interface{} = nil
+
+	err := ethutil.CallAtBlock(
+		kr.transactorOptions.From,
+		blockNumber, nil,
+		kr.contractABI,
+		kr.caller,
+		kr.errorResolver,
+		kr.contractAddress,
+		"disableOperatorContractPanicButton",
+		&result,
+		_operatorContract,
+	)
+
+	return err
+}
+
+func (kr *KeepRegistry) DisableOperatorContractPanicButtonGasEstimate(
+	_operatorContract common.Address,
+) (uint64, error) {
+	var result uint64
+
+	result, err := ethutil.EstimateGas(
+		kr.callerOptions.From,
+		kr.contractAddress,
+		"disableOperatorContractPanicButton",
+		kr.contractABI,
+		kr.transactor,
+		_operatorContract,
+	)
+
+	return result, err
+}
+
+// Transaction submission.
+func (kr *KeepRegistry) ApproveOperatorContract(
+	operatorContract common.Address,
+
+	transactionOptions ...ethutil.TransactionOptions,
+) (*types.Transaction, error) {
+	krLogger.Debug(
+		"submitting transaction approveOperatorContract",
+		"params: ",
+		fmt.Sprint(
+			operatorContract,
+		),
+	)
+
+	kr.transactionMutex.Lock()
+	defer kr.transactionMutex.Unlock()
+
+	// create a copy
+	transactorOptions := new(bind.TransactOpts)
+	*transactorOptions = *kr.transactorOptions
+
+	if len(transactionOptions) > 1 {
+		return nil, fmt.Errorf(
+			"could not process multiple transaction options sets",
+		)
+	} else if len(transactionOptions) > 0 {
+		transactionOptions[0].Apply(transactorOptions)
+	}
+
+	nonce, err := kr.nonceManager.CurrentNonce()
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve account nonce: %v", err)
+	}
+
+	transactorOptions.Nonce = new(big.Int).SetUint64(nonce)
+
+	transaction, err := kr.contract.ApproveOperatorContract(
+		transactorOptions,
+		operatorContract,
+	)
+	if err != nil {
+		return transaction, kr.errorResolver.ResolveError(
+			err,
+			kr.transactorOptions.From,
+			nil,
+			"approveOperatorContract",
+			operatorContract,
+		)
+	}
+
+	krLogger.Infof(
+		"submitted transaction approveOperatorContract with id: [%v] and nonce [%v]",
+		transaction.Hash().Hex(),
+		transaction.Nonce(),
+	)
+
+	go kr.miningWaiter.ForceMining(
+		transaction,
+		func(newGasPrice *big.Int) (*types.Transaction, error) {
+			transactorOptions.GasLimit = transaction.Gas()
+			transactorOptions.GasPrice = newGasPrice
+
+			transaction, err := kr.contract.ApproveOperatorContract(
+				transactorOptions,
+				operatorContract,
+			)
+			if err != nil {
+				return transaction, kr.errorResolver.ResolveError(
+					err,
+					kr.transactorOptions.From,
+					nil,
+					"approveOperatorContract",
+					operatorContract,
+				)
+			}
+
+			krLogger.Infof(
+				"submitted transaction approveOperatorContract with id: [%v] and nonce [%v]",
+				transaction.Hash().Hex(),
+				transaction.Nonce(),
+			)
+
+			return transaction, nil
+		},
+	)
+
+	kr.nonceManager.IncrementNonce()
+
+	return transaction, err
+}
+
+// Non-mutating call, not a transaction submission.
+func (kr *KeepRegistry) CallApproveOperatorContract(
+	operatorContract common.Address,
+	blockNumber *big.Int,
+) error {
+	var result interface{} = nil
+
+	err := ethutil.CallAtBlock(
+		kr.transactorOptions.From,
+		blockNumber, nil,
+		kr.contractABI,
+		kr.caller,
+		kr.errorResolver,
+		kr.contractAddress,
+		"approveOperatorContract",
+		&result,
+		operatorContract,
+	)
+
+	return err
+}
+
+func (kr *KeepRegistry) ApproveOperatorContractGasEstimate(
+	operatorContract common.Address,
+) (uint64, error) {
+	var result uint64
+
+	result, err := ethutil.EstimateGas(
+		kr.callerOptions.From,
+		kr.contractAddress,
+		"approveOperatorContract",
+		kr.contractABI,
+		kr.transactor,
+		operatorContract,
+	)
+
+	return result, err
+}
+
+// Transaction submission.
+func (kr *KeepRegistry) SetGovernance(
+	_governance common.Address,
+
+	transactionOptions ...ethutil.TransactionOptions,
+) (*types.Transaction, error) {
+	krLogger.Debug(
+		"submitting transaction setGovernance",
+		"params: ",
+		fmt.Sprint(
+			_governance,
+		),
+	)
+
+	kr.transactionMutex.Lock()
+	defer kr.transactionMutex.Unlock()
+
+	// create a copy
+	transactorOptions := new(bind.TransactOpts)
+	*transactorOptions = *kr.transactorOptions
+
+	if len(transactionOptions) > 1 {
+		return nil, fmt.Errorf(
+			"could not process multiple transaction options sets",
+		)
+	} else if len(transactionOptions) > 0 {
+		transactionOptions[0].Apply(transactorOptions)
+	}
+
+	nonce, err := kr.nonceManager.CurrentNonce()
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve account nonce: %v", err)
+	}
+
+	transactorOptions.Nonce = new(big.Int).SetUint64(nonce)
+
+	transaction, err := kr.contract.SetGovernance(
+		transactorOptions,
+		_governance,
+	)
+	if err != nil {
+		return transaction, kr.errorResolver.ResolveError(
+			err,
+			kr.transactorOptions.From,
+			nil,
+			"setGovernance",
+			_governance,
+		)
+	}
+
+	krLogger.Infof(
+		"submitted transaction setGovernance with id: [%v] and nonce [%v]",
+		transaction.Hash().Hex(),
+		transaction.Nonce(),
+	)
+
+	go kr.miningWaiter.ForceMining(
+		transaction,
+		func(newGasPrice *big.Int) (*types.Transaction, error) {
+			transactorOptions.GasLimit = transaction.Gas()
+			transactorOptions.GasPrice = newGasPrice
+
+			transaction, err := kr.contract.SetGovernance(
+				transactorOptions,
+				_governance,
+			)
+			if err != nil {
+				return transaction, kr.errorResolver.ResolveError(
+					err,
+					kr.transactorOptions.From,
+					nil,
+					"setGovernance",
+					_governance,
+				)
+			}
+
+			krLogger.Infof(
+				"submitted transaction setGovernance with id: [%v] and nonce [%v]",
+				transaction.Hash().Hex(),
+				transaction.Nonce(),
+			)
+
+			return transaction, nil
+		},
+	)
+
+	kr.nonceManager.IncrementNonce()
+
+	return transaction, err
+}
+
+// Non-mutating call, not a transaction submission.
+func (kr *KeepRegistry) CallSetGovernance(
+	_governance common.Address,
+	blockNumber *big.Int,
+) error {
+	var result interface{} = nil
+
+	err := ethutil.CallAtBlock(
+		kr.transactorOptions.From,
+		blockNumber, nil,
+		kr.contractABI,
+		kr.caller,
+		kr.errorResolver,
+		kr.contractAddress,
+		"setGovernance",
+		&result,
+		_governance,
+	)
+
+	return err
+}
+
+func (kr *KeepRegistry) SetGovernanceGasEstimate(
+	_governance common.Address,
+) (uint64, error) {
+	var result uint64
+
+	result, err := ethutil.EstimateGas(
+		kr.callerOptions.From,
+		kr.contractAddress,
+		"setGovernance",
+		kr.contractABI,
+		kr.transactor,
+		_governance,
+	)
+
+	return result, err
+}
+
+// Transaction submission.
+func (kr *KeepRegistry) SetOperatorContractUpgrader(
+	_serviceContract common.Address,
+	_operatorContractUpgrader common.Address,
+
+	transactionOptions ...ethutil.TransactionOptions,
+) (*types.Transaction, error) {
+	krLogger.Debug(
+		"submitting transaction setOperatorContractUpgrader",
+		"params: ",
+		fmt.Sprint(
+			_serviceContract,
+			_operatorContractUpgrader,
+		),
+	)
+
+	kr.transactionMutex.Lock()
+	defer kr.transactionMutex.Unlock()
+
+	// create a copy
+	transactorOptions := new(bind.TransactOpts)
+	*transactorOptions = *kr.transactorOptions
+
+	if len(transactionOptions) > 1 {
+		return nil, fmt.Errorf(
+			"could not process multiple transaction options sets",
+		)
+	} else if len(transactionOptions) > 0 {
+		transactionOptions[0].Apply(transactorOptions)
+	}
+
+	nonce, err := kr.nonceManager.CurrentNonce()
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve account nonce: %v", err)
+	}
+
+	transactorOptions.Nonce = new(big.Int).SetUint64(nonce)
+
+	transaction, err := kr.contract.SetOperatorContractUpgrader(
+		transactorOptions,
+		_serviceContract,
+		_operatorContractUpgrader,
+	)
+	if err != nil {
+		return transaction, kr.errorResolver.ResolveError(
+			err,
+			kr.transactorOptions.From,
+			nil,
+			"setOperatorContractUpgrader",
+			_serviceContract,
+			_operatorContractUpgrader,
+		)
+	}
+
+	krLogger.Infof(
+		"submitted transaction setOperatorContractUpgrader with id: [%v] and nonce [%v]",
+		transaction.Hash().Hex(),
+		transaction.Nonce(),
+	)
+
+	go kr.miningWaiter.ForceMining(
+		transaction,
+		func(newGasPrice *big.Int) (*types.Transaction, error) {
+			transactorOptions.GasLimit = transaction.Gas()
+			transactorOptions.GasPrice = newGasPrice
+
+			transaction, err := kr.contract.SetOperatorContractUpgrader(
+				transactorOptions,
+				_serviceContract,
+				_operatorContractUpgrader,
+			)
+			if err != nil {
+				return transaction, kr.errorResolver.ResolveError(
+					err,
+					kr.transactorOptions.From,
+					nil,
+					"setOperatorContractUpgrader",
+					_serviceContract,
+					_operatorContractUpgrader,
+				)
+			}
+
+			krLogger.Infof(
+				"submitted transaction setOperatorContractUpgrader with id: [%v] and nonce [%v]",
+				transaction.Hash().Hex(),
+				transaction.Nonce(),
+			)
+
+			return transaction, nil
+		},
+	)
+
+	kr.nonceManager.IncrementNonce()
+
+	return transaction, err
+}
+
+// Non-mutating call, not a transaction submission.
+func (kr *KeepRegistry) CallSetOperatorContractUpgrader(
+	_serviceContract common.Address,
+	_operatorContractUpgrader common.Address,
+	blockNumber *big.Int,
+) error {
+	var result interface{} = nil
+
+	err := ethutil.CallAtBlock(
+		kr.transactorOptions.From,
+		blockNumber, nil,
+		kr.contractABI,
+		kr.caller,
+		kr.errorResolver,
+		kr.contractAddress,
+		"setOperatorContractUpgrader",
+		&result,
+		_serviceContract,
+		_operatorContractUpgrader,
+	)
+
+	return err
+}
+
+func (kr *KeepRegistry) SetOperatorContractUpgraderGasEstimate(
+	_serviceContract common.Address,
+	_operatorContractUpgrader common.Address,
+) (uint64, error) {
+	var result uint64
+
+	result, err := ethutil.EstimateGas(
+		kr.callerOptions.From,
+		kr.contractAddress,
+		"setOperatorContractUpgrader",
+		kr.contractABI,
+		kr.transactor,
+		_serviceContract,
+		_operatorContractUpgrader,
+	)
+
+	return result, err
+}
+
+// Transaction submission.
+func (kr *KeepRegistry) SetServiceContractUpgrader(
+	_operatorContract common.Address,
+	_serviceContractUpgrader common.Address,
+
+	transactionOptions ...ethutil.TransactionOptions,
+) (*types.Transaction, error) {
+	krLogger.Debug(
+		"submitting transaction setServiceContractUpgrader",
+		"params: ",
+		fmt.Sprint(
+			_operatorContract,
+			_serviceContractUpgrader,
+		),
+	)
+
+	kr.transactionMutex.Lock()
+	defer kr.transactionMutex.Unlock()
+
+	// create a copy
+	transactorOptions := new(bind.TransactOpts)
+	*transactorOptions = *kr.transactorOptions
+
+	if len(transactionOptions) > 1 {
+		return nil, fmt.Errorf(
+			"could not process multiple transaction options sets",
+		)
+	} else if len(transactionOptions) > 0 {
+		transactionOptions[0].Apply(transactorOptions)
+	}
+
+	nonce, err := kr.nonceManager.CurrentNonce()
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve account nonce: %v", err)
+	}
+
+	transactorOptions.Nonce = new(big.Int).SetUint64(nonce)
+
+	transaction, err := kr.contract.SetServiceContractUpgrader(
+		transactorOptions,
+		_operatorContract,
+		_serviceContractUpgrader,
+	)
+	if err != nil {
+		return transaction, kr.errorResolver.ResolveError(
+			err,
+			kr.transactorOptions.From,
+			nil,
+			"setServiceContractUpgrader",
+			_operatorContract,
+			_serviceContractUpgrader,
+		)
+	}
+
+	krLogger.Infof(
+		"submitted transaction setServiceContractUpgrader with id: [%v] and nonce [%v]",
+		transaction.Hash().Hex(),
+		transaction.Nonce(),
+	)
+
+	go kr.miningWaiter.ForceMining(
+		transaction,
+		func(newGasPrice *big.Int) (*types.Transaction, error) {
+			transactorOptions.GasLimit = transaction.Gas()
+			transactorOptions.GasPrice = newGasPrice
+
+			transaction, err := kr.contract.SetServiceContractUpgrader(
+				transactorOptions,
+				_operatorContract,
+				_serviceContractUpgrader,
+			)
+			if err != nil {
+				return transaction, kr.errorResolver.ResolveError(
+					err,
+					kr.transactorOptions.From,
+					nil,
+					"setServiceContractUpgrader",
+					_operatorContract,
+					_serviceContractUpgrader,
+				)
+			}
+
+			krLogger.Infof(
+				"submitted transaction setServiceContractUpgrader with id: [%v] and nonce [%v]",
+				transaction.Hash().Hex(),
+				transaction.Nonce(),
+			)
+
+			return transaction, nil
+		},
+	)
+
+	kr.nonceManager.IncrementNonce()
+
+	return transaction, err
+}
+
+// Non-mutating call, not a transaction submission.
+func (kr *KeepRegistry) CallSetServiceContractUpgrader(
+	_operatorContract common.Address,
+	_serviceContractUpgrader common.Address,
+	blockNumber *big.Int,
+) error {
+	var result interface{} = nil
+
+	err := ethutil.CallAtBlock(
+		kr.transactorOptions.From,
+		blockNumber, nil,
+		kr.contractABI,
+		kr.caller,
+		kr.errorResolver,
+		kr.contractAddress,
+		"setServiceContractUpgrader",
+		&result,
+		_operatorContract,
+		_serviceContractUpgrader,
+	)
+
+	return err
+}
+
+func (kr *KeepRegistry) SetServiceContractUpgraderGasEstimate(
+	_operatorContract common.Address,
+	_serviceContractUpgrader common.Address,
+) (uint64, error) {
+	var result uint64
+
+	result, err := ethutil.EstimateGas(
+		kr.callerOptions.From,
+		kr.contractAddress,
+		"setServiceContractUpgrader",
+		kr.contractABI,
+		kr.transactor,
+		_operatorContract,
+		_serviceContractUpgrader,
+	)
+
+	return result, err
+}
+
+// Transaction submission.
+func (kr *KeepRegistry) DisableOperatorContract(
+	operatorContract common.Address,
+
+	transactionOptions ...ethutil.TransactionOptions,
+) (*types.Transaction, error) {
+	krLogger.Debug(
+		"submitting transaction disableOperatorContract",
+		"params: ",
+		fmt.Sprint(
+			operatorContract,
+		),
+	)
+
+	kr.transactionMutex.Lock()
+	defer kr.transactionMutex.Unlock()
+
+	// create a copy
+	transactorOptions := new(bind.TransactOpts)
+	*transactorOptions = *kr.transactorOptions
+
+	if len(transactionOptions) > 1 {
+		return nil, fmt.Errorf(
+			"could not process multiple transaction options sets",
+		)
+	} else if len(transactionOptions) > 0 {
+		transactionOptions[0].Apply(transactorOptions)
+	}
+
+	nonce, err := kr.nonceManager.CurrentNonce()
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve account nonce: %v", err)
+	}
+
+	transactorOptions.Nonce = new(big.Int).SetUint64(nonce)
+
+	transaction, err := kr.contract.DisableOperatorContract(
+		transactorOptions,
+		operatorContract,
+	)
+	if err != nil {
+		return transaction, kr.errorResolver.ResolveError(
+			err,
+			kr.transactorOptions.From,
+			nil,
+			"disableOperatorContract",
+			operatorContract,
+		)
+	}
+
+	krLogger.Infof(
+		"submitted transaction disableOperatorContract with id: [%v] and nonce [%v]",
+		transaction.Hash().Hex(),
+		transaction.Nonce(),
+	)
+
+	go kr.miningWaiter.ForceMining(
+		transaction,
+		func(newGasPrice *big.Int) (*types.Transaction, error) {
+			transactorOptions.GasLimit = transaction.Gas()
+			transactorOptions.GasPrice = newGasPrice
+
+			transaction, err := kr.contract.DisableOperatorContract(
+				transactorOptions,
+				operatorContract,
+			)
+			if err != nil {
+				return transaction, kr.errorResolver.ResolveError(
+					err,
+					kr.transactorOptions.From,
+					nil,
+					"disableOperatorContract",
+					operatorContract,
+				)
+			}
+
+			krLogger.Infof(
+				"submitted transaction disableOperatorContract with id: [%v] and nonce [%v]",
+				transaction.Hash().Hex(),
+				transaction.Nonce(),
+			)
+
+			return transaction, nil
+		},
+	)
+
+	kr.nonceManager.IncrementNonce()
+
+	return transaction, err
+}
+
+// Non-mutating call, not a transaction submission.
+func (kr *KeepRegistry) CallDisableOperatorContract(
+	operatorContract common.Address,
+	blockNumber *big.Int,
+) error {
+	var result interface{} = nil
+
+	err := ethutil.CallAtBlock(
+		kr.transactorOptions.From,
+		blockNumber, nil,
+		kr.contractABI,
+		kr.caller,
+		kr.errorResolver,
+		kr.contractAddress,
+		"disableOperatorContract",
+		&result,
+		operatorContract,
+	)
+
+	return err
+}
+
+func (kr *KeepRegistry) DisableOperatorContractGasEstimate(
+	operatorContract common.Address,
+) (uint64, error) {
+	var result uint64
+
+	result, err := ethutil.EstimateGas(
+		kr.callerOptions.From,
+		kr.contractAddress,
+		"disableOperatorContract",
+		kr.contractABI,
+		kr.transactor,
+		operatorContract,
+	)
+
+	return result, err
+}
+
+// Transaction submission.
+func (kr *KeepRegistry) SetDefaultPanicButton(
+	_panicButton common.Address,
+
+	transactionOptions ...ethutil.TransactionOptions,
+) (*types.Transaction, error) {
+	krLogger.Debug(
+		"submitting transaction setDefaultPanicButton",
+		"params: ",
+		fmt.Sprint(
+			_panicButton,
+		),
+	)
+
+	kr.transactionMutex.Lock()
+	defer kr.transactionMutex.Unlock()
+
+	// create a copy
+	transactorOptions := new(bind.TransactOpts)
+	*transactorOptions = *kr.transactorOptions
+
+	if len(transactionOptions) > 1 {
+		return nil, fmt.Errorf(
+			"could not process multiple transaction options sets",
+		)
+	} else if len(transactionOptions) > 0 {
+		transactionOptions[0].Apply(transactorOptions)
+	}
+
+	nonce, err := kr.nonceManager.CurrentNonce()
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve account nonce: %v", err)
+	}
+
+	transactorOptions.Nonce = new(big.Int).SetUint64(nonce)
+
+	transaction, err := kr.contract.SetDefaultPanicButton(
+		transactorOptions,
+		_panicButton,
+	)
+	if err != nil {
+		return transaction, kr.errorResolver.ResolveError(
+			err,
+			kr.transactorOptions.From,
+			nil,
+			"setDefaultPanicButton",
+			_panicButton,
+		)
+	}
+
+	krLogger.Infof(
+		"submitted transaction setDefaultPanicButton with id: [%v] and nonce [%v]",
+		transaction.Hash().Hex(),
+		transaction.Nonce(),
+	)
+
+	go kr.miningWaiter.ForceMining(
+		transaction,
+		func(newGasPrice *big.Int) (*types.Transaction, error) {
+			transactorOptions.GasLimit = transaction.Gas()
+			transactorOptions.GasPrice = newGasPrice
+
+			transaction, err := kr.contract.SetDefaultPanicButton(
+				transactorOptions,
+				_panicButton,
+			)
+			if err != nil {
+				return transaction, kr.errorResolver.ResolveError(
+					err,
+					kr.transactorOptions.From,
+					nil,
+					"setDefaultPanicButton",
+					_panicButton,
+				)
+			}
+
+			krLogger.Infof(
+				"submitted transaction setDefaultPanicButton with id: [%v] and nonce [%v]",
+				transaction.Hash().Hex(),
+				transaction.Nonce(),
+			)
+
+			return transaction, nil
+		},
+	)
+
+	kr.nonceManager.IncrementNonce()
+
+	return transaction, err
+}
+
+// Non-mutating call, not a transaction submission.
+func (kr *KeepRegistry) CallSetDefaultPanicButton(
+	_panicButton common.Address,
+	blockNumber *big.Int,
+) error {
+	var result interface{} = nil
+
+	err := ethutil.CallAtBlock(
+		kr.transactorOptions.From,
+		blockNumber, nil,
+		kr.contractABI,
+		kr.caller,
+		kr.errorResolver,
+		kr.contractAddress,
+		"setDefaultPanicButton",
+		&result,
+		_panicButton,
+	)
+
+	return err
+}
+
+func (kr *KeepRegistry) SetDefaultPanicButtonGasEstimate(
+	_panicButton common.Address,
+) (uint64, error) {
+	var result uint64
+
+	result, err := ethutil.EstimateGas(
+		kr.callerOptions.From,
+		kr.contractAddress,
+		"setDefaultPanicButton",
+		kr.contractABI,
+		kr.transactor,
+		_panicButton,
+	)
+
+	return result, err
+}
+
+// Transaction submission.
+func (kr *KeepRegistry) SetOperatorContractPanicButton(
+	_operatorContract common.Address,
+	_panicButton common.Address,
+
+	transactionOptions ...ethutil.TransactionOptions,
+) (*types.Transaction, error) {
+	krLogger.Debug(
+		"submitting transaction setOperatorContractPanicButton",
+		"params: ",
+		fmt.Sprint(
+			_operatorContract,
+			_panicButton,
+		),
+	)
+
+	kr.transactionMutex.Lock()
+	defer kr.transactionMutex.Unlock()
+
+	// create a copy
+	transactorOptions := new(bind.TransactOpts)
+	*transactorOptions = *kr.transactorOptions
+
+	if len(transactionOptions) > 1 {
+		return nil, fmt.Errorf(
+			"could not process multiple transaction options sets",
+		)
+	} else if len(transactionOptions) > 0 {
+		transactionOptions[0].Apply(transactorOptions)
+	}
+
+	nonce, err := kr.nonceManager.CurrentNonce()
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve account nonce: %v", err)
+	}
+
+	transactorOptions.Nonce = new(big.Int).SetUint64(nonce)
+
+	transaction, err := kr.contract.SetOperatorContractPanicButton(
+		transactorOptions,
+		_operatorContract,
+		_panicButton,
+	)
+	if err != nil {
+		return transaction, kr.errorResolver.ResolveError(
+			err,
+			kr.transactorOptions.From,
+			nil,
+			"setOperatorContractPanicButton",
+			_operatorContract,
+			_panicButton,
+		)
+	}
+
+	krLogger.Infof(
+		"submitted transaction setOperatorContractPanicButton with id: [%v] and nonce [%v]",
+		transaction.Hash().Hex(),
+		transaction.Nonce(),
+	)
+
+	go kr.miningWaiter.ForceMining(
+		transaction,
+		func(newGasPrice *big.Int) (*types.Transaction, error) {
+			transactorOptions.GasLimit = transaction.Gas()
+			transactorOptions.GasPrice = newGasPrice
+
+			transaction, err := kr.contract.SetOperatorContractPanicButton(
+				transactorOptions,
+				_operatorContract,
+				_panicButton,
+			)
+			if err != nil {
+				return transaction, kr.errorResolver.ResolveError(
+					err,
+					kr.transactorOptions.From,
+					nil,
+					"setOperatorContractPanicButton",
+					_operatorContract,
+					_panicButton,
+				)
+			}
+
+			krLogger.Infof(
+				"submitted transaction setOperatorContractPanicButton with id: [%v] and nonce [%v]",
+				transaction.Hash().Hex(),
+				transaction.Nonce(),
+			)
+
+			return transaction, nil
+		},
+	)
+
+	kr.nonceManager.IncrementNonce()
+
+	return transaction, err
+}
+
+// Non-mutating call, not a transaction submission.
+func (kr *KeepRegistry) CallSetOperatorContractPanicButton(
+	_operatorContract common.Address,
+	_panicButton common.Address,
+	blockNumber *big.Int,
+) error {
+	var result interface{} = nil
+
+	err := ethutil.CallAtBlock(
+		kr.transactorOptions.From,
+		blockNumber, nil,
+		kr.contractABI,
+		kr.caller,
+		kr.errorResolver,
+		kr.contractAddress,
+		"setOperatorContractPanicButton",
+		&result,
+		_operatorContract,
+		_panicButton,
+	)
+
+	return err
+}
+
+func (kr *KeepRegistry) SetOperatorContractPanicButtonGasEstimate(
+	_operatorContract common.Address,
+	_panicButton common.Address,
+) (uint64, error) {
+	var result uint64
+
+	result, err := ethutil.EstimateGas(
+		kr.callerOptions.From,
+		kr.contractAddress,
+		"setOperatorContractPanicButton",
+		kr.contractABI,
+		kr.transactor,
+		_operatorContract,
+		_panicButton,
+	)
+
+	return result, err
+}
+
+// Transaction submission.
+func (kr *KeepRegistry) SetRegistryKeeper(
+	_registryKeeper common.Address,
+
+	transactionOptions ...ethutil.TransactionOptions,
+) (*types.Transaction, error) {
+	krLogger.Debug(
+		"submitting transaction setRegistryKeeper",
+		"params: ",
+		fmt.Sprint(
+			_registryKeeper,
+		),
+	)
+
+	kr.transactionMutex.Lock()
+	defer kr.transactionMutex.Unlock()
+
+	// create a copy
+	transactorOptions := new(bind.TransactOpts)
+	*transactorOptions = *kr.transactorOptions
+
+	if len(transactionOptions) > 1 {
+		return nil, fmt.Errorf(
+			"could not process multiple transaction options sets",
+		)
+	} else if len(transactionOptions) > 0 {
+		transactionOptions[0].Apply(transactorOptions)
+	}
+
+	nonce, err := kr.nonceManager.CurrentNonce()
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve account nonce: %v", err)
+	}
+
+	transactorOptions.Nonce = new(big.Int).SetUint64(nonce)
+
+	transaction, err := kr.contract.SetRegistryKeeper(
+		transactorOptions,
+		_registryKeeper,
+	)
+	if err != nil {
+		return transaction, kr.errorResolver.ResolveError(
+			err,
+			kr.transactorOptions.From,
+			nil,
+			"setRegistryKeeper",
+			_registryKeeper,
+		)
+	}
+
+	krLogger.Infof(
+		"submitted transaction setRegistryKeeper with id: [%v] and nonce [%v]",
+		transaction.Hash().Hex(),
+		transaction.Nonce(),
+	)
+
+	go kr.miningWaiter.ForceMining(
+		transaction,
+		func(newGasPrice *big.Int) (*types.Transaction, error) {
+			transactorOptions.GasLimit = transaction.Gas()
+			transactorOptions.GasPrice = newGasPrice
+
+			transaction, err := kr.contract.SetRegistryKeeper(
+				transactorOptions,
+				_registryKeeper,
+			)
+			if err != nil {
+				return transaction, kr.errorResolver.ResolveError(
+					err,
+					kr.transactorOptions.From,
+					nil,
+					"setRegistryKeeper",
+					_registryKeeper,
+				)
+			}
+
+			krLogger.Infof(
+				"submitted transaction setRegistryKeeper with id: [%v] and nonce [%v]",
+				transaction.Hash().Hex(),
+				transaction.Nonce(),
+			)
+
+			return transaction, nil
+		},
+	)
+
+	kr.nonceManager.IncrementNonce()
+
+	return transaction, err
+}
+
+// Non-mutating call, not a transaction submission.
+func (kr *KeepRegistry) CallSetRegistryKeeper(
+	_registryKeeper common.Address,
+	blockNumber *big.Int,
+) error {
+	var result interface{} = nil
+
+	err := ethutil.CallAtBlock(
+		kr.transactorOptions.From,
+		blockNumber, nil,
+		kr.contractABI,
+		kr.caller,
+		kr.errorResolver,
+		kr.contractAddress,
+		"setRegistryKeeper",
+		&result,
+		_registryKeeper,
+	)
+
+	return err
+}
+
+func (kr *KeepRegistry) SetRegistryKeeperGasEstimate(
+	_registryKeeper common.Address,
+) (uint64, error) {
+	var result uint64
+
+	result, err := ethutil.EstimateGas(
+		kr.callerOptions.From,
+		kr.contractAddress,
+		"setRegistryKeeper",
+		kr.contractABI,
+		kr.transactor,
+		_registryKeeper,
+	)
+
+	return result, err
+}
+
+// ----- Const Methods ------
+
+func (kr *KeepRegistry) IsNewOperatorContract(
+	operatorContract common.Address,
+) (bool, error) {
+	var result bool
+	result, err := kr.contract.IsNewOperatorContract(
+		kr.callerOptions,
+		operatorContract,
+	)
+
+	if err != nil {
+		return result, kr.errorResolver.ResolveError(
+			err,
+			kr.callerOptions.From,
+			nil,
+			"isNewOperatorContract",
+			operatorContract,
+		)
+	}
+
+	return result, err
+}
+
+func (kr *KeepRegistry) IsNewOperatorContractAtBlock(
+	operatorContract common.Address,
+	blockNumber *big.Int,
+) (bool, error) {
+	var result bool
+
+	err := ethutil.CallAtBlock(
+		kr.callerOptions.From,
+		blockNumber,
+		nil,
+		kr.contractABI,
+		kr.caller,
+		kr.errorResolver,
+		kr.contractAddress,
+		"isNewOperatorContract",
+		&result,
+		operatorContract,
+	)
+
+	return result, err
+}
+
+func (kr *KeepRegistry) OperatorContractUpgraders(
+	arg0 common.Address,
+) (common.Address, error) {
+	var result common.Address
+	result, err := kr.contract.OperatorContractUpgraders(
+		kr.callerOptions,
+		arg0,
+	)
+
+	if err != nil {
+		return result, kr.errorResolver.ResolveError(
+			err,
+			kr.callerOptions.From,
+			nil,
+			"operatorContractUpgraders",
+			arg0,
+		)
+	}
+
+	return result, err
+}
+
+func (kr *KeepRegistry) OperatorContractUpgradersAtBlock(
+	arg0 common.Address,
+	blockNumber *big.Int,
+) (common.Address, error) {
+	var result common.Address
+
+	err := ethutil.CallAtBlock(
+		kr.callerOptions.From,
+		blockNumber,
+		nil,
+		kr.contractABI,
+		kr.caller,
+		kr.errorResolver,
+		kr.contractAddress,
+		"operatorContractUpgraders",
+		&result,
+		arg0,
+	)
+
+	return result, err
+}
+
+func (kr *KeepRegistry) PanicButtons(
+	arg0 common.Address,
+) (common.Address, error) {
+	var result common.Address
+	result, err := kr.contract.PanicButtons(
+		kr.callerOptions,
+		arg0,
+	)
+
+	if err != nil {
+		return result, kr.errorResolver.ResolveError(
+			err,
+			kr.callerOptions.From,
+			nil,
+			"panicButtons",
+			arg0,
+		)
+	}
+
+	return result, err
+}
+
+func (kr *KeepRegistry) PanicButtonsAtBlock(
+	arg0 common.Address,
+	blockNumber *big.Int,
+) (common.Address, error) {
+	var result common.Address
+
+	err := ethutil.CallAtBlock(
+		kr.callerOptions.From,
+		blockNumber,
+		nil,
+		kr.contractABI,
+		kr.caller,
+		kr.errorResolver,
+		kr.contractAddress,
+		"panicButtons",
+		&result,
+		arg0,
+	)
+
+	return result, err
+}
+
+func (kr *KeepRegistry) ServiceContractUpgraderFor(
+	_operatorContract common.Address,
+) (common.Address, error) {
+	var result common.Address
+	result, err := kr.contract.ServiceContractUpgraderFor(
+		kr.callerOptions,
+		_operatorContract,
+	)
+
+	if err != nil {
+		return result, kr.errorResolver.ResolveError(
+			err,
+			kr.callerOptions.From,
+			nil,
+			"serviceContractUpgraderFor",
+			_operatorContract,
+		)
+	}
+
+	return result, err
+}
+
+func (kr *KeepRegistry) ServiceContractUpgraderForAtBlock(
+	_operatorContract common.Address,
+	blockNumber *big.Int,
+) (common.Address, error) {
+	var result common.Address
+
+	err := ethutil.CallAtBlock(
+		kr.callerOptions.From,
+		blockNumber,
+		nil,
+		kr.contractABI,
+		kr.caller,
+		kr.errorResolver,
+		kr.contractAddress,
+		"serviceContractUpgraderFor",
+		&result,
+		_operatorContract,
+	)
+
+	return result, err
+}
+
+func (kr *KeepRegistry) ServiceContractUpgraders(
+	arg0 common.Address,
+) (common.Address, error) {
+	var result common.Address
+	result, err := kr.contract.ServiceContractUpgraders(
+		kr.callerOptions,
+		arg0,
+	)
+
+	if err != nil {
+		return result, kr.errorResolver.ResolveError(
+			err,
+			kr.callerOptions.From,
+			nil,
+			"serviceContractUpgraders",
+			arg0,
+		)
+	}
+
+	return result, err
+}
+
+func (kr *KeepRegistry) ServiceContractUpgradersAtBlock(
+	arg0 common.Address,
+	blockNumber *big.Int,
+) (common.Address, error) {
+	var result common.Address
+
+	err := ethutil.CallAtBlock(
+		kr.callerOptions.From,
+		blockNumber,
+		nil,
+		kr.contractABI,
+		kr.caller,
+		kr.errorResolver,
+		kr.contractAddress,
+		"serviceContractUpgraders",
+		&result,
+		arg0,
+	)
+
+	return result, err
+}
+
+func (kr *KeepRegistry) DefaultPanicButton() (common.Address, error) {
+	var result common.Address
+	result, err := kr.contract.DefaultPanicButton(
+		kr.callerOptions,
+	)
+
+	if err != nil {
+		return result, kr.errorResolver.ResolveError(
+			err,
+			kr.callerOptions.From,
+			nil,
+			"defaultPanicButton",
+		)
+	}
+
+	return result, err
+}
+
+func (kr *KeepRegistry) DefaultPanicButtonAtBlock(
+	blockNumber *big.Int,
+) (common.Address, error) {
+	var result common.Address
+
+	err := ethutil.CallAtBlock(
+		kr.callerOptions.From,
+		blockNumber,
+		nil,
+		kr.contractABI,
+		kr.caller,
+		kr.errorResolver,
+		kr.contractAddress,
+		"defaultPanicButton",
+		&result,
+	)
+
+	return result, err
+}
+
+func (kr *KeepRegistry) Governance() (common.Address, error) {
+	var result common.Address
+	result, err := kr.contract.Governance(
+		kr.callerOptions,
+	)
+
+	if err != nil {
+		return result, kr.errorResolver.ResolveError(
+			err,
+			kr.callerOptions.From,
+			nil,
+			"governance",
+		)
+	}
+
+	return result, err
+}
+
+func (kr *KeepRegistry) GovernanceAtBlock(
+	blockNumber *big.Int,
+) (common.Address, error) {
+	var result common.Address
+
+	err := ethutil.CallAtBlock(
+		kr.callerOptions.From,
+		blockNumber,
+		nil,
+		kr.contractABI,
+		kr.caller,
+		kr.errorResolver,
+		kr.contractAddress,
+		"governance",
+		&result,
+	)
+
+	return result, err
+}
+
+func (kr *KeepRegistry) OperatorContractUpgraderFor(
+	_serviceContract common.Address,
+) (common.Address, error) {
+	var result common.Address
+	result, err := kr.contract.OperatorContractUpgraderFor(
+		kr.callerOptions,
+		_serviceContract,
+	)
+
+	if err != nil {
+		return result, kr.errorResolver.ResolveError(
+			err,
+			kr.callerOptions.From,
+			nil,
+			"operatorContractUpgraderFor",
+			_serviceContract,
+		)
+	}
+
+	return result, err
+}
+
+func (kr *KeepRegistry) OperatorContractUpgraderForAtBlock(
+	_serviceContract common.Address,
+	blockNumber *big.Int,
+) (common.Address, error) {
+	var result common.Address
+
+	err := ethutil.CallAtBlock(
+		kr.callerOptions.From,
+		blockNumber,
+		nil,
+		kr.contractABI,
+		kr.caller,
+		kr.errorResolver,
+		kr.contractAddress,
+		"operatorContractUpgraderFor",
+		&result,
+		_serviceContract,
+	)
+
+	return result, err
+}
+
+func (kr *KeepRegistry) RegistryKeeper() (common.Address, error) {
+	var result common.Address
+	result, err := kr.contract.RegistryKeeper(
+		kr.callerOptions,
+	)
+
+	if err != nil {
+		return result, kr.errorResolver.ResolveError(
+			err,
+			kr.callerOptions.From,
+			nil,
+			"registryKeeper",
+		)
+	}
+
+	return result, err
+}
+
+func (kr *KeepRegistry) RegistryKeeperAtBlock(
+	blockNumber *big.Int,
+) (common.Address, error) {
+	var result common.Address
+
+	err := ethutil.CallAtBlock(
+		kr.callerOptions.From,
+		blockNumber,
+		nil,
+		kr.contractABI,
+		kr.caller,
+		kr.errorResolver,
+		kr.contractAddress,
+		"registryKeeper",
+		&result,
+	)
+
+	return result, err
+}
+
+func (kr *KeepRegistry) IsApprovedOperatorContract(
+	operatorContract common.Address,
+) (bool, error) {
+	var result bool
+	result, err := kr.contract.IsApprovedOperatorContract(
+		kr.callerOptions,
+		operatorContract,
+	)
+
+	if err != nil {
+		return result, kr.errorResolver.ResolveError(
+			err,
+			kr.callerOptions.From,
+			nil,
+			"isApprovedOperatorContract",
+			operatorContract,
+		)
+	}
+
+	return result, err
+}
+
+func (kr *KeepRegistry) IsApprovedOperatorContractAtBlock(
+	operatorContract common.Address,
+	blockNumber *big.Int,
+) (bool, error) {
+	var result bool
+
+	err := ethutil.CallAtBlock(
+		kr.callerOptions.From,
+		blockNumber,
+		nil,
+		kr.contractABI,
+		kr.caller,
+		kr.errorResolver,
+		kr.contractAddress,
+		"isApprovedOperatorContract",
+		&result,
+		operatorContract,
+	)
+
+	return result, err
+}
+
+func (kr *KeepRegistry) OperatorContracts(
+	arg0 common.Address,
+) (uint8, error) {
+	var result uint8
+	result, err := kr.contract.OperatorContracts(
+		kr.callerOptions,
+		arg0,
+	)
+
+	if err != nil {
+		return result, kr.errorResolver.ResolveError(
+			err,
+			kr.callerOptions.From,
+			nil,
+			"operatorContracts",
+			arg0,
+		)
+	}
+
+	return result, err
+}
+
+func (kr *KeepRegistry) OperatorContractsAtBlock(
+	arg0 common.Address,
+	blockNumber *big.Int,
+) (uint8, error) {
+	var result uint8
+
+	err := ethutil.CallAtBlock(
+		kr.callerOptions.From,
+		blockNumber,
+		nil,
+		kr.contractABI,
+		kr.caller,
+		kr.errorResolver,
+		kr.contractAddress,
+		"operatorContracts",
+		&result,
+		arg0,
+	)
+
+	return result, err
+}
+
+// ------ Events -------
+
+type keepRegistryOperatorContractPanicButtonUpdatedFunc func(
+	OperatorContract common.Address,
+	PanicButton common.Address,
+	blockNumber uint64,
+)
+
+func (kr *KeepRegistry) PastOperatorContractPanicButtonUpdatedEvents(
+	startBlock uint64,
+	endBlock *uint64,
+) ([]*abi.KeepRegistryOperatorContractPanicButtonUpdated, error) {
+	iterator, err := kr.contract.FilterOperatorContractPanicButtonUpdated(
+		&bind.FilterOpts{
+			Start: startBlock,
+			End:   endBlock,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"error retrieving past OperatorContractPanicButtonUpdated events: [%v]",
+			err,
+		)
+	}
+
+	events := make([]*abi.KeepRegistryOperatorContractPanicButtonUpdated, 0)
+
+	for iterator.Next() {
+		event := iterator.Event
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+func (kr *KeepRegistry) WatchOperatorContractPanicButtonUpdated(
+	success keepRegistryOperatorContractPanicButtonUpdatedFunc,
+	fail func(err error) error,
+) (subscription.EventSubscription, error) {
+	errorChan := make(chan error)
+	unsubscribeChan := make(chan struct{})
+
+	// Delay which must be preserved before a new resubscription attempt.
+	// There is no sense to resubscribe immediately after the fail of current
+	// subscription because the publisher must have some time to recover.
+	retryDelay := 5 * time.Second
+
+	watch := func() {
+		failCallback := func(err error) error {
+			fail(err)
+			errorChan <- err // trigger resubscription signal
+			return err
+		}
+
+		subscription, err := kr.subscribeOperatorContractPanicButtonUpdated(
+			success,
+			failCallback,
+		)
+		if err != nil {
+			errorChan <- err // trigger resubscription signal
+			return
+		}
+
+		// wait for unsubscription signal
+		<-unsubscribeChan
+		subscription.Unsubscribe()
+	}
+
+	// trigger the resubscriber goroutine
+	go func() {
+		go watch() // trigger first subscription
+
+		for {
+			select {
+			case <-errorChan:
+				krLogger.Warning(
+					"subscription to event OperatorContractPanicButtonUpdated terminated with error; " +
+						"resubscription attempt will be performed after the retry delay",
+				)
+				time.Sleep(retryDelay)
+				go watch()
+			case <-unsubscribeChan:
+				// shutdown the resubscriber goroutine on unsubscribe signal
+				return
+			}
+		}
+	}()
+
+	// closing the unsubscribeChan will trigger a unsubscribe signal and
+	// run unsubscription for all subscription instances
+	unsubscribeCallback := func() {
+		close(unsubscribeChan)
+	}
+
+	return subscription.NewEventSubscription(unsubscribeCallback), nil
+}
+
+func (kr *KeepRegistry) subscribeOperatorContractPanicButtonUpdated(
+	success keepRegistryOperatorContractPanicButtonUpdatedFunc,
+	fail func(err error) error,
+) (subscription.EventSubscription, error) {
+	eventChan := make(chan *abi.KeepRegistryOperatorContractPanicButtonUpdated)
+	eventSubscription, err := kr.contract.WatchOperatorContractPanicButtonUpdated(
+		nil,
+		eventChan,
+	)
+	if err != nil {
+		close(eventChan)
+		return eventSubscription, fmt.Errorf(
+			"error creating watch for OperatorContractPanicButtonUpdated events: [%v]",
+			err,
+		)
+	}
+
+	var subscriptionMutex = &sync.Mutex{}
+
+	go func() {
+		for {
+			select {
+			case event, subscribed := <-eventChan:
+				subscriptionMutex.Lock()
+				// if eventChan has been closed, it means we have unsubscribed
+				if !subscribed {
+					subscriptionMutex.Unlock()
+					return
+				}
+				success(
+					event.OperatorContract,
+					event.PanicButton,
+					event.Raw.BlockNumber,
+				)
+				subscriptionMutex.Unlock()
+			case ee := <-eventSubscription.Err():
+				fail(ee)
+				return
+			}
+		}
+	}()
+
+	unsubscribeCallback := func() {
+		subscriptionMutex.Lock()
+		defer subscriptionMutex.Unlock()
+
+		eventSubscription.Unsubscribe()
+		close(eventChan)
+	}
+
+	return subscription.NewEventSubscription(unsubscribeCallback), nil
+}
+
+type keepRegistryOperatorContractUpgraderUpdatedFunc func(
+	ServiceContract common.Address,
+	Upgrader common.Address,
+	blockNumber uint64,
+)
+
+func (kr *KeepRegistry) PastOperatorContractUpgraderUpdatedEvents(
+	startBlock uint64,
+	endBlock *uint64,
+) ([]*abi.KeepRegistryOperatorContractUpgraderUpdated, error) {
+	iterator, err := kr.contract.FilterOperatorContractUpgraderUpdated(
+		&bind.FilterOpts{
+			Start: startBlock,
+			End:   endBlock,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"error retrieving past OperatorContractUpgraderUpdated events: [%v]",
+			err,
+		)
+	}
+
+	events := make([]*abi.KeepRegistryOperatorContractUpgraderUpdated, 0)
+
+	for iterator.Next() {
+		event := iterator.Event
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+func (kr *KeepRegistry) WatchOperatorContractUpgraderUpdated(
+	success keepRegistryOperatorContractUpgraderUpdatedFunc,
+	fail func(err error) error,
+) (subscription.EventSubscription, error) {
+	errorChan := make(chan error)
+	unsubscribeChan := make(chan struct{})
+
+	// Delay which must be preserved before a new resubscription attempt.
+	// There is no sense to resubscribe immediately after the fail of current
+	// subscription because the publisher must have some time to recover.
+	retryDelay := 5 * time.Second
+
+	watch := func() {
+		failCallback := func(err error) error {
+			fail(err)
+			errorChan <- err // trigger resubscription signal
+			return err
+		}
+
+		subscription, err := kr.subscribeOperatorContractUpgraderUpdated(
+			success,
+			failCallback,
+		)
+		if err != nil {
+			errorChan <- err // trigger resubscription signal
+			return
+		}
+
+		// wait for unsubscription signal
+		<-unsubscribeChan
+		subscription.Unsubscribe()
+	}
+
+	// trigger the resubscriber goroutine
+	go func() {
+		go watch() // trigger first subscription
+
+		for {
+			select {
+			case <-errorChan:
+				krLogger.Warning(
+					"subscription to event OperatorContractUpgraderUpdated terminated with error; " +
+						"resubscription attempt will be performed after the retry delay",
+				)
+				time.Sleep(retryDelay)
+				go watch()
+			case <-unsubscribeChan:
+				// shutdown the resubscriber goroutine on unsubscribe signal
+				return
+			}
+		}
+	}()
+
+	// closing the unsubscribeChan will trigger a unsubscribe signal and
+	// run unsubscription for all subscription instances
+	unsubscribeCallback := func() {
+		close(unsubscribeChan)
+	}
+
+	return subscription.NewEventSubscription(unsubscribeCallback), nil
+}
+
+func (kr *KeepRegistry) subscribeOperatorContractUpgraderUpdated(
+	success keepRegistryOperatorContractUpgraderUpdatedFunc,
+	fail func(err error) error,
+) (subscription.EventSubscription, error) {
+	eventChan := make(chan *abi.KeepRegistryOperatorContractUpgraderUpdated)
+	eventSubscription, err := kr.contract.WatchOperatorContractUpgraderUpdated(
+		nil,
+		eventChan,
+	)
+	if err != nil {
+		close(eventChan)
+		return eventSubscription, fmt.Errorf(
+			"error creating watch for OperatorContractUpgraderUpdated events: [%v]",
+			err,
+		)
+	}
+
+	var subscriptionMutex = &sync.Mutex{}
+
+	go func() {
+		for {
+			select {
+			case event, subscribed := <-eventChan:
+				subscriptionMutex.Lock()
+				// if eventChan has been closed, it means we have unsubscribed
+				if !subscribed {
+					subscriptionMutex.Unlock()
+					return
+				}
+				success(
+					event.ServiceContract,
+					event.Upgrader,
+					event.Raw.BlockNumber,
+				)
+				subscriptionMutex.Unlock()
+			case ee := <-eventSubscription.Err():
+				fail(ee)
+				return
+			}
+		}
+	}()
+
+	unsubscribeCallback := func() {
+		subscriptionMutex.Lock()
+		defer subscriptionMutex.Unlock()
+
+		eventSubscription.Unsubscribe()
+		close(eventChan)
+	}
+
+	return subscription.NewEventSubscription(unsubscribeCallback), nil
+}
+
+type keepRegistryOperatorContractPanicButtonDisabledFunc func(
+	OperatorContract common.Address,
+	blockNumber uint64,
+)
+
+func (kr *KeepRegistry) PastOperatorContractPanicButtonDisabledEvents(
+	startBlock uint64,
+	endBlock *uint64,
+) ([]*abi.KeepRegistryOperatorContractPanicButtonDisabled, error) {
+	iterator, err := kr.contract.FilterOperatorContractPanicButtonDisabled(
+		&bind.FilterOpts{
+			Start: startBlock,
+			End:   endBlock,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"error retrieving past OperatorContractPanicButtonDisabled events: [%v]",
+			err,
+		)
+	}
+
+	events := make([]*abi.KeepRegistryOperatorContractPanicButtonDisabled, 0)
+
+	for iterator.Next() {
+		event := iterator.Event
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+func (kr *KeepRegistry) WatchOperatorContractPanicButtonDisabled(
+	success keepRegistryOperatorContractPanicButtonDisabledFunc,
+	fail func(err error) error,
+) (subscription.EventSubscription, error) {
+	errorChan := make(chan error)
+	unsubscribeChan := make(chan struct{})
+
+	// Delay which must be preserved before a new resubscription attempt.
+	// There is no sense to resubscribe immediately after the fail of current
+	// subscription because the publisher must have some time to recover.
+	retryDelay := 5 * time.Second
+
+	watch := func() {
+		failCallback := func(err error) error {
+			fail(err)
+			errorChan <- err // trigger resubscription signal
+			return err
+		}
+
+		subscription, err := kr.subscribeOperatorContractPanicButtonDisabled(
+			success,
+			failCallback,
+		)
+		if err != nil {
+			errorChan <- err // trigger resubscription signal
+			return
+		}
+
+		// wait for unsubscription signal
+		<-unsubscribeChan
+		subscription.Unsubscribe()
+	}
+
+	// trigger the resubscriber goroutine
+	go func() {
+		go watch() // trigger first subscription
+
+		for {
+			select {
+			case <-errorChan:
+				krLogger.Warning(
+					"subscription to event OperatorContractPanicButtonDisabled terminated with error; " +
+						"resubscription attempt will be performed after the retry delay",
+				)
+				time.Sleep(retryDelay)
+				go watch()
+			case <-unsubscribeChan:
+				// shutdown the resubscriber goroutine on unsubscribe signal
+				return
+			}
+		}
+	}()
+
+	// closing the unsubscribeChan will trigger a unsubscribe signal and
+	// run unsubscription for all subscription instances
+	unsubscribeCallback := func() {
+		close(unsubscribeChan)
+	}
+
+	return subscription.NewEventSubscription(unsubscribeCallback), nil
+}
+
+func (kr *KeepRegistry) subscribeOperatorContractPanicButtonDisabled(
+	success keepRegistryOperatorContractPanicButtonDisabledFunc,
+	fail func(err error) error,
+) (subscription.EventSubscription, error) {
+	eventChan := make(chan *abi.KeepRegistryOperatorContractPanicButtonDisabled)
+	eventSubscription, err := kr.contract.WatchOperatorContractPanicButtonDisabled(
+		nil,
+		eventChan,
+	)
+	if err != nil {
+		close(eventChan)
+		return eventSubscription, fmt.Errorf(
+			"error creating watch for OperatorContractPanicButtonDisabled events: [%v]",
+			err,
+		)
+	}
+
+	var subscriptionMutex = &sync.Mutex{}
+
+	go func() {
+		for {
+			select {
+			case event, subscribed := <-eventChan:
+				subscriptionMutex.Lock()
+				// if eventChan has been closed, it means we have unsubscribed
+				if !subscribed {
+					subscriptionMutex.Unlock()
+					return
+				}
+				success(
+					event.OperatorContract,
+					event.Raw.BlockNumber,
+				)
+				subscriptionMutex.Unlock()
+			case ee := <-eventSubscription.Err():
+				fail(ee)
+				return
+			}
+		}
+	}()
+
+	unsubscribeCallback := func() {
+		subscriptionMutex.Lock()
+		defer subscriptionMutex.Unlock()
+
+		eventSubscription.Unsubscribe()
+		close(eventChan)
+	}
+
+	return subscription.NewEventSubscription(unsubscribeCallback), nil
+}
+
+type keepRegistryRegistryKeeperUpdatedFunc func(
+	RegistryKeeper common.Address,
+	blockNumber uint64,
+)
+
+func (kr *KeepRegistry) PastRegistryKeeperUpdatedEvents(
+	startBlock uint64,
+	endBlock *uint64,
+) ([]*abi.KeepRegistryRegistryKeeperUpdated, error) {
+	iterator, err := kr.contract.FilterRegistryKeeperUpdated(
+		&bind.FilterOpts{
+			Start: startBlock,
+			End:   endBlock,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"error retrieving past RegistryKeeperUpdated events: [%v]",
+			err,
+		)
+	}
+
+	events := make([]*abi.KeepRegistryRegistryKeeperUpdated, 0)
+
+	for iterator.Next() {
+		event := iterator.Event
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+func (kr *KeepRegistry) WatchRegistryKeeperUpdated(
+	success keepRegistryRegistryKeeperUpdatedFunc,
+	fail func(err error) error,
+) (subscription.EventSubscription, error) {
+	errorChan := make(chan error)
+	unsubscribeChan := make(chan struct{})
+
+	// Delay which must be preserved before a new resubscription attempt.
+	// There is no sense to resubscribe immediately after the fail of current
+	// subscription because the publisher must have some time to recover.
+	retryDelay := 5 * time.Second
+
+	watch := func() {
+		failCallback := func(err error) error {
+			fail(err)
+			errorChan <- err // trigger resubscription signal
+			return err
+		}
+
+		subscription, err := kr.subscribeRegistryKeeperUpdated(
+			success,
+			failCallback,
+		)
+		if err != nil {
+			errorChan <- err // trigger resubscription signal
+			return
+		}
+
+		// wait for unsubscription signal
+		<-unsubscribeChan
+		subscription.Unsubscribe()
+	}
+
+	// trigger the resubscriber goroutine
+	go func() {
+		go watch() // trigger first subscription
+
+		for {
+			select {
+			case <-errorChan:
+				krLogger.Warning(
+					"subscription to event RegistryKeeperUpdated terminated with error; " +
+						"resubscription attempt will be performed after the retry delay",
+				)
+				time.Sleep(retryDelay)
+				go watch()
+			case <-unsubscribeChan:
+				// shutdown the resubscriber goroutine on unsubscribe signal
+				return
+			}
+		}
+	}()
+
+	// closing the unsubscribeChan will trigger a unsubscribe signal and
+	// run unsubscription for all subscription instances
+	unsubscribeCallback := func() {
+		close(unsubscribeChan)
+	}
+
+	return subscription.NewEventSubscription(unsubscribeCallback), nil
+}
+
+func (kr *KeepRegistry) subscribeRegistryKeeperUpdated(
+	success keepRegistryRegistryKeeperUpdatedFunc,
+	fail func(err error) error,
+) (subscription.EventSubscription, error) {
+	eventChan := make(chan *abi.KeepRegistryRegistryKeeperUpdated)
+	eventSubscription, err := kr.contract.WatchRegistryKeeperUpdated(
+		nil,
+		eventChan,
+	)
+	if err != nil {
+		close(eventChan)
+		return eventSubscription, fmt.Errorf(
+			"error creating watch for RegistryKeeperUpdated events: [%v]",
+			err,
+		)
+	}
+
+	var subscriptionMutex = &sync.Mutex{}
+
+	go func() {
+		for {
+			select {
+			case event, subscribed := <-eventChan:
+				subscriptionMutex.Lock()
+				// if eventChan has been closed, it means we have unsubscribed
+				if !subscribed {
+					subscriptionMutex.Unlock()
+					return
+				}
+				success(
+					event.RegistryKeeper,
+					event.Raw.BlockNumber,
+				)
+				subscriptionMutex.Unlock()
+			case ee := <-eventSubscription.Err():
+				fail(ee)
+				return
+			}
+		}
+	}()
+
+	unsubscribeCallback := func() {
+		subscriptionMutex.Lock()
+		defer subscriptionMutex.Unlock()
+
+		eventSubscription.Unsubscribe()
+		close(eventChan)
+	}
+
+	return subscription.NewEventSubscription(unsubscribeCallback), nil
+}
+
+type keepRegistryServiceContractUpgraderUpdatedFunc func(
+	OperatorContract common.Address,
+	Keeper common.Address,
+	blockNumber uint64,
+)
+
+func (kr *KeepRegistry) PastServiceContractUpgraderUpdatedEvents(
+	startBlock uint64,
+	endBlock *uint64,
+) ([]*abi.KeepRegistryServiceContractUpgraderUpdated, error) {
+	iterator, err := kr.contract.FilterServiceContractUpgraderUpdated(
+		&bind.FilterOpts{
+			Start: startBlock,
+			End:   endBlock,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"error retrieving past ServiceContractUpgraderUpdated events: [%v]",
+			err,
+		)
+	}
+
+	events := make([]*abi.KeepRegistryServiceContractUpgraderUpdated, 0)
+
+	for iterator.Next() {
+		event := iterator.Event
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+func (kr *KeepRegistry) WatchServiceContractUpgraderUpdated(
+	success keepRegistryServiceContractUpgraderUpdatedFunc,
+	fail func(err error) error,
+) (subscription.EventSubscription, error) {
+	errorChan := make(chan error)
+	unsubscribeChan := make(chan struct{})
+
+	// Delay which must be preserved before a new resubscription attempt.
+	// There is no sense to resubscribe immediately after the fail of current
+	// subscription because the publisher must have some time to recover.
+	retryDelay := 5 * time.Second
+
+	watch := func() {
+		failCallback := func(err error) error {
+			fail(err)
+			errorChan <- err // trigger resubscription signal
+			return err
+		}
+
+		subscription, err := kr.subscribeServiceContractUpgraderUpdated(
+			success,
+			failCallback,
+		)
+		if err != nil {
+			errorChan <- err // trigger resubscription signal
+			return
+		}
+
+		// wait for unsubscription signal
+		<-unsubscribeChan
+		subscription.Unsubscribe()
+	}
+
+	// trigger the resubscriber goroutine
+	go func() {
+		go watch() // trigger first subscription
+
+		for {
+			select {
+			case <-errorChan:
+				krLogger.Warning(
+					"subscription to event ServiceContractUpgraderUpdated terminated with error; " +
+						"resubscription attempt will be performed after the retry delay",
+				)
+				time.Sleep(retryDelay)
+				go watch()
+			case <-unsubscribeChan:
+				// shutdown the resubscriber goroutine on unsubscribe signal
+				return
+			}
+		}
+	}()
+
+	// closing the unsubscribeChan will trigger a unsubscribe signal and
+	// run unsubscription for all subscription instances
+	unsubscribeCallback := func() {
+		close(unsubscribeChan)
+	}
+
+	return subscription.NewEventSubscription(unsubscribeCallback), nil
+}
+
+func (kr *KeepRegistry) subscribeServiceContractUpgraderUpdated(
+	success keepRegistryServiceContractUpgraderUpdatedFunc,
+	fail func(err error) error,
+) (subscription.EventSubscription, error) {
+	eventChan := make(chan *abi.KeepRegistryServiceContractUpgraderUpdated)
+	eventSubscription, err := kr.contract.WatchServiceContractUpgraderUpdated(
+		nil,
+		eventChan,
+	)
+	if err != nil {
+		close(eventChan)
+		return eventSubscription, fmt.Errorf(
+			"error creating watch for ServiceContractUpgraderUpdated events: [%v]",
+			err,
+		)
+	}
+
+	var subscriptionMutex = &sync.Mutex{}
+
+	go func() {
+		for {
+			select {
+			case event, subscribed := <-eventChan:
+				subscriptionMutex.Lock()
+				// if eventChan has been closed, it means we have unsubscribed
+				if !subscribed {
+					subscriptionMutex.Unlock()
+					return
+				}
+				success(
+					event.OperatorContract,
+					event.Keeper,
+					event.Raw.BlockNumber,
+				)
+				subscriptionMutex.Unlock()
+			case ee := <-eventSubscription.Err():
+				fail(ee)
+				return
+			}
+		}
+	}()
+
+	unsubscribeCallback := func() {
+		subscriptionMutex.Lock()
+		defer subscriptionMutex.Unlock()
+
+		eventSubscription.Unsubscribe()
+		close(eventChan)
+	}
+
+	return subscription.NewEventSubscription(unsubscribeCallback), nil
+}
+
+type keepRegistryDefaultPanicButtonUpdatedFunc func(
+	DefaultPanicButton common.Address,
+	blockNumber uint64,
+)
+
+func (kr *KeepRegistry) PastDefaultPanicButtonUpdatedEvents(
+	startBlock uint64,
+	endBlock *uint64,
+) ([]*abi.KeepRegistryDefaultPanicButtonUpdated, error) {
+	iterator, err := kr.contract.FilterDefaultPanicButtonUpdated(
+		&bind.FilterOpts{
+			Start: startBlock,
+			End:   endBlock,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"error retrieving past DefaultPanicButtonUpdated events: [%v]",
+			err,
+		)
+	}
+
+	events := make([]*abi.KeepRegistryDefaultPanicButtonUpdated, 0)
+
+	for iterator.Next() {
+		event := iterator.Event
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+func (kr *KeepRegistry) WatchDefaultPanicButtonUpdated(
+	success keepRegistryDefaultPanicButtonUpdatedFunc,
+	fail func(err error) error,
+) (subscription.EventSubscription, error) {
+	errorChan := make(chan error)
+	unsubscribeChan := make(chan struct{})
+
+	// Delay which must be preserved before a new resubscription attempt.
+	// There is no sense to resubscribe immediately after the fail of current
+	// subscription because the publisher must have some time to recover.
+	retryDelay := 5 * time.Second
+
+	watch := func() {
+		failCallback := func(err error) error {
+			fail(err)
+			errorChan <- err // trigger resubscription signal
+			return err
+		}
+
+		subscription, err := kr.subscribeDefaultPanicButtonUpdated(
+			success,
+			failCallback,
+		)
+		if err != nil {
+			errorChan <- err // trigger resubscription signal
+			return
+		}
+
+		// wait for unsubscription signal
+		<-unsubscribeChan
+		subscription.Unsubscribe()
+	}
+
+	// trigger the resubscriber goroutine
+	go func() {
+		go watch() // trigger first subscription
+
+		for {
+			select {
+			case <-errorChan:
+				krLogger.Warning(
+					"subscription to event DefaultPanicButtonUpdated terminated with error; " +
+						"resubscription attempt will be performed after the retry delay",
+				)
+				time.Sleep(retryDelay)
+				go watch()
+			case <-unsubscribeChan:
+				// shutdown the resubscriber goroutine on unsubscribe signal
+				return
+			}
+		}
+	}()
+
+	// closing the unsubscribeChan will trigger a unsubscribe signal and
+	// run unsubscription for all subscription instances
+	unsubscribeCallback := func() {
+		close(unsubscribeChan)
+	}
+
+	return subscription.NewEventSubscription(unsubscribeCallback), nil
+}
+
+func (kr *KeepRegistry) subscribeDefaultPanicButtonUpdated(
+	success keepRegistryDefaultPanicButtonUpdatedFunc,
+	fail func(err error) error,
+) (subscription.EventSubscription, error) {
+	eventChan := make(chan *abi.KeepRegistryDefaultPanicButtonUpdated)
+	eventSubscription, err := kr.contract.WatchDefaultPanicButtonUpdated(
+		nil,
+		eventChan,
+	)
+	if err != nil {
+		close(eventChan)
+		return eventSubscription, fmt.Errorf(
+			"error creating watch for DefaultPanicButtonUpdated events: [%v]",
+			err,
+		)
+	}
+
+	var subscriptionMutex = &sync.Mutex{}
+
+	go func() {
+		for {
+			select {
+			case event, subscribed := <-eventChan:
+				subscriptionMutex.Lock()
+				// if eventChan has been closed, it means we have unsubscribed
+				if !subscribed {
+					subscriptionMutex.Unlock()
+					return
+				}
+				success(
+					event.DefaultPanicButton,
+					event.Raw.BlockNumber,
+				)
+				subscriptionMutex.Unlock()
+			case ee := <-eventSubscription.Err():
+				fail(ee)
+				return
+			}
+		}
+	}()
+
+	unsubscribeCallback := func() {
+		subscriptionMutex.Lock()
+		defer subscriptionMutex.Unlock()
+
+		eventSubscription.Unsubscribe()
+		close(eventChan)
+	}
+
+	return subscription.NewEventSubscription(unsubscribeCallback), nil
+}
+
+type keepRegistryGovernanceUpdatedFunc func(
+	Governance common.Address,
+	blockNumber uint64,
+)
+
+func (kr *KeepRegistry) PastGovernanceUpdatedEvents(
+	startBlock uint64,
+	endBlock *uint64,
+) ([]*abi.KeepRegistryGovernanceUpdated, error) {
+	iterator, err := kr.contract.FilterGovernanceUpdated(
+		&bind.FilterOpts{
+			Start: startBlock,
+			End:   endBlock,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"error retrieving past GovernanceUpdated events: [%v]",
+			err,
+		)
+	}
+
+	events := make([]*abi.KeepRegistryGovernanceUpdated, 0)
+
+	for iterator.Next() {
+		event := iterator.Event
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+func (kr *KeepRegistry) WatchGovernanceUpdated(
+	success keepRegistryGovernanceUpdatedFunc,
+	fail func(err error) error,
+) (subscription.EventSubscription, error) {
+	errorChan := make(chan error)
+	unsubscribeChan := make(chan struct{})
+
+	// Delay which must be preserved before a new resubscription attempt.
+	// There is no sense to resubscribe immediately after the fail of current
+	// subscription because the publisher must have some time to recover.
+	retryDelay := 5 * time.Second
+
+	watch := func() {
+		failCallback := func(err error) error {
+			fail(err)
+			errorChan <- err // trigger resubscription signal
+			return err
+		}
+
+		subscription, err := kr.subscribeGovernanceUpdated(
+			success,
+			failCallback,
+		)
+		if err != nil {
+			errorChan <- err // trigger resubscription signal
+			return
+		}
+
+		// wait for unsubscription signal
+		<-unsubscribeChan
+		subscription.Unsubscribe()
+	}
+
+	// trigger the resubscriber goroutine
+	go func() {
+		go watch() // trigger first subscription
+
+		for {
+			select {
+			case <-errorChan:
+				krLogger.Warning(
+					"subscription to event GovernanceUpdated terminated with error; " +
+						"resubscription attempt will be performed after the retry delay",
+				)
+				time.Sleep(retryDelay)
+				go watch()
+			case <-unsubscribeChan:
+				// shutdown the resubscriber goroutine on unsubscribe signal
+				return
+			}
+		}
+	}()
+
+	// closing the unsubscribeChan will trigger a unsubscribe signal and
+	// run unsubscription for all subscription instances
+	unsubscribeCallback := func() {
+		close(unsubscribeChan)
+	}
+
+	return subscription.NewEventSubscription(unsubscribeCallback), nil
+}
+
+func (kr *KeepRegistry) subscribeGovernanceUpdated(
+	success keepRegistryGovernanceUpdatedFunc,
+	fail func(err error) error,
+) (subscription.EventSubscription, error) {
+	eventChan := make(chan *abi.KeepRegistryGovernanceUpdated)
+	eventSubscription, err := kr.contract.WatchGovernanceUpdated(
+		nil,
+		eventChan,
+	)
+	if err != nil {
+		close(eventChan)
+		return eventSubscription, fmt.Errorf(
+			"error creating watch for GovernanceUpdated events: [%v]",
+			err,
+		)
+	}
+
+	var subscriptionMutex = &sync.Mutex{}
+
+	go func() {
+		for {
+			select {
+			case event, subscribed := <-eventChan:
+				subscriptionMutex.Lock()
+				// if eventChan has been closed, it means we have unsubscribed
+				if !subscribed {
+					subscriptionMutex.Unlock()
+					return
+				}
+				success(
+					event.Governance,
+					event.Raw.BlockNumber,
+				)
+				subscriptionMutex.Unlock()
+			case ee := <-eventSubscription.Err():
+				fail(ee)
+				return
+			}
+		}
+	}()
+
+	unsubscribeCallback := func() {
+		subscriptionMutex.Lock()
+		defer subscriptionMutex.Unlock()
+
+		eventSubscription.Unsubscribe()
+		close(eventChan)
+	}
+
+	return subscription.NewEventSubscription(unsubscribeCallback), nil
+}
+
+type keepRegistryOperatorContractApprovedFunc func(
+	OperatorContract common.Address,
+	blockNumber uint64,
+)
+
+func (kr *KeepRegistry) PastOperatorContractApprovedEvents(
+	startBlock uint64,
+	endBlock *uint64,
+) ([]*abi.KeepRegistryOperatorContractApproved, error) {
+	iterator, err := kr.contract.FilterOperatorContractApproved(
+		&bind.FilterOpts{
+			Start: startBlock,
+			End:   endBlock,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"error retrieving past OperatorContractApproved events: [%v]",
+			err,
+		)
+	}
+
+	events := make([]*abi.KeepRegistryOperatorContractApproved, 0)
+
+	for iterator.Next() {
+		event := iterator.Event
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+func (kr *KeepRegistry) WatchOperatorContractApproved(
+	success keepRegistryOperatorContractApprovedFunc,
+	fail func(err error) error,
+) (subscription.EventSubscription, error) {
+	errorChan := make(chan error)
+	unsubscribeChan := make(chan struct{})
+
+	// Delay which must be preserved before a new resubscription attempt.
+	// There is no sense to resubscribe immediately after the fail of current
+	// subscription because the publisher must have some time to recover.
+	retryDelay := 5 * time.Second
+
+	watch := func() {
+		failCallback := func(err error) error {
+			fail(err)
+			errorChan <- err // trigger resubscription signal
+			return err
+		}
+
+		subscription, err := kr.subscribeOperatorContractApproved(
+			success,
+			failCallback,
+		)
+		if err != nil {
+			errorChan <- err // trigger resubscription signal
+			return
+		}
+
+		// wait for unsubscription signal
+		<-unsubscribeChan
+		subscription.Unsubscribe()
+	}
+
+	// trigger the resubscriber goroutine
+	go func() {
+		go watch() // trigger first subscription
+
+		for {
+			select {
+			case <-errorChan:
+				krLogger.Warning(
+					"subscription to event OperatorContractApproved terminated with error; " +
+						"resubscription attempt will be performed after the retry delay",
+				)
+				time.Sleep(retryDelay)
+				go watch()
+			case <-unsubscribeChan:
+				// shutdown the resubscriber goroutine on unsubscribe signal
+				return
+			}
+		}
+	}()
+
+	// closing the unsubscribeChan will trigger a unsubscribe signal and
+	// run unsubscription for all subscription instances
+	unsubscribeCallback := func() {
+		close(unsubscribeChan)
+	}
+
+	return subscription.NewEventSubscription(unsubscribeCallback), nil
+}
+
+func (kr *KeepRegistry) subscribeOperatorContractApproved(
+	success keepRegistryOperatorContractApprovedFunc,
+	fail func(err error) error,
+) (subscription.EventSubscription, error) {
+	eventChan := make(chan *abi.KeepRegistryOperatorContractApproved)
+	eventSubscription, err := kr.contract.WatchOperatorContractApproved(
+		nil,
+		eventChan,
+	)
+	if err != nil {
+		close(eventChan)
+		return eventSubscription, fmt.Errorf(
+			"error creating watch for OperatorContractApproved events: [%v]",
+			err,
+		)
+	}
+
+	var subscriptionMutex = &sync.Mutex{}
+
+	go func() {
+		for {
+			select {
+			case event, subscribed := <-eventChan:
+				subscriptionMutex.Lock()
+				// if eventChan has been closed, it means we have unsubscribed
+				if !subscribed {
+					subscriptionMutex.Unlock()
+					return
+				}
+				success(
+					event.OperatorContract,
+					event.Raw.BlockNumber,
+				)
+				subscriptionMutex.Unlock()
+			case ee := <-eventSubscription.Err():
+				fail(ee)
+				return
+			}
+		}
+	}()
+
+	unsubscribeCallback := func() {
+		subscriptionMutex.Lock()
+		defer subscriptionMutex.Unlock()
+
+		eventSubscription.Unsubscribe()
+		close(eventChan)
+	}
+
+	return subscription.NewEventSubscription(unsubscribeCallback), nil
+}
+
+type keepRegistryOperatorContractDisabledFunc func(
+	OperatorContract common.Address,
+	blockNumber uint64,
+)
+
+func (kr *KeepRegistry) PastOperatorContractDisabledEvents(
+	startBlock uint64,
+	endBlock *uint64,
+) ([]*abi.KeepRegistryOperatorContractDisabled, error) {
+	iterator, err := kr.contract.FilterOperatorContractDisabled(
+		&bind.FilterOpts{
+			Start: startBlock,
+			End:   endBlock,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"error retrieving past OperatorContractDisabled events: [%v]",
+			err,
+		)
+	}
+
+	events := make([]*abi.KeepRegistryOperatorContractDisabled, 0)
+
+	for iterator.Next() {
+		event := iterator.Event
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+func (kr *KeepRegistry) WatchOperatorContractDisabled(
+	success keepRegistryOperatorContractDisabledFunc,
+	fail func(err error) error,
+) (subscription.EventSubscription, error) {
+	errorChan := make(chan error)
+	unsubscribeChan := make(chan struct{})
+
+	// Delay which must be preserved before a new resubscription attempt.
+	// There is no sense to resubscribe immediately after the fail of current
+	// subscription because the publisher must have some time to recover.
+	retryDelay := 5 * time.Second
+
+	watch := func() {
+		failCallback := func(err error) error {
+			fail(err)
+			errorChan <- err // trigger resubscription signal
+			return err
+		}
+
+		subscription, err := kr.subscribeOperatorContractDisabled(
+			success,
+			failCallback,
+		)
+		if err != nil {
+			errorChan <- err // trigger resubscription signal
+			return
+		}
+
+		// wait for unsubscription signal
+		<-unsubscribeChan
+		subscription.Unsubscribe()
+	}
+
+	// trigger the resubscriber goroutine
+	go func() {
+		go watch() // trigger first subscription
+
+		for {
+			select {
+			case <-errorChan:
+				krLogger.Warning(
+					"subscription to event OperatorContractDisabled terminated with error; " +
+						"resubscription attempt will be performed after the retry delay",
+				)
+				time.Sleep(retryDelay)
+				go watch()
+			case <-unsubscribeChan:
+				// shutdown the resubscriber goroutine on unsubscribe signal
+				return
+			}
+		}
+	}()
+
+	// closing the unsubscribeChan will trigger a unsubscribe signal and
+	// run unsubscription for all subscription instances
+	unsubscribeCallback := func() {
+		close(unsubscribeChan)
+	}
+
+	return subscription.NewEventSubscription(unsubscribeCallback), nil
+}
+
+func (kr *KeepRegistry) subscribeOperatorContractDisabled(
+	success keepRegistryOperatorContractDisabledFunc,
+	fail func(err error) error,
+) (subscription.EventSubscription, error) {
+	eventChan := make(chan *abi.KeepRegistryOperatorContractDisabled)
+	eventSubscription, err := kr.contract.WatchOperatorContractDisabled(
+		nil,
+		eventChan,
+	)
+	if err != nil {
+		close(eventChan)
+		return eventSubscription, fmt.Errorf(
+			"error creating watch for OperatorContractDisabled events: [%v]",
+			err,
+		)
+	}
+
+	var subscriptionMutex = &sync.Mutex{}
+
+	go func() {
+		for {
+			select {
+			case event, subscribed := <-eventChan:
+				subscriptionMutex.Lock()
+				// if eventChan has been closed, it means we have unsubscribed
+				if !subscribed {
+					subscriptionMutex.Unlock()
+					return
+				}
+				success(
+					event.OperatorContract,
+					event.Raw.BlockNumber,
+				)
+				subscriptionMutex.Unlock()
+			case ee := <-eventSubscription.Err():
+				fail(ee)
+				return
+			}
+		}
+	}()
+
+	unsubscribeCallback := func() {
+		subscriptionMutex.Lock()
+		defer subscriptionMutex.Unlock()
+
+		eventSubscription.Unsubscribe()
+		close(eventChan)
+	}
+
+	return subscription.NewEventSubscription(unsubscribeCallback), nil
+}
@@ -10,6 +10,7 @@ import (
 	"math/rand"
 	"sort"
 	"sync"
+	"time"
 
 	"github.com/ipfs/go-log"
 
@@ -20,7 +21,6 @@ import (
 	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
 	"github.com/keep-network/keep-core/pkg/chain"
 	"github.com/keep-network/keep-core/pkg/gen/async"
-	"github.com/keep-network/keep-core/pkg/operator"
 	"github.com/keep-network/keep-core/pkg/subscription"
 	"golang.org/x/crypto/sha3"
 )
@@ -47,6 +47,10 @@ type Chain interface {
 	// GetRelayEntryTimeoutReports returns an array of blocks which denote at what
 	// block a relay entry timeout occured.
 	GetRelayEntryTimeoutReports() []uint64
+
+	// StartRelayRequest simulates a relay request started at the given block.
+	// The request is in progress until a relay entry is submitted.
+	StartRelayRequest(startBlock uint64)
 }
 
 type localGroup struct {
@@ -63,6 +67,9 @@ type localChain struct {
 	lastSubmittedDKGResultSignatures map[relaychain.GroupMemberIndex][]byte
 	lastSubmittedRelayEntry          []byte
 
+	currentRequestMutex      sync.Mutex
+	currentRequestStartBlock *big.Int
+
 	handlerMutex                  sync.Mutex
 	relayEntryHandlers            map[int]func(entry *event.EntrySubmitted)
 	relayRequestHandlers          map[int]func(request *event.Request)
@@ -97,12 +104,26 @@ func (c *localChain) BalanceMonitor() (chain.BalanceMonitor, error) {
 	panic("not implemented")
 }
 
-func (c *localChain) Signing() chain.Signing {
-	return commonLocal.NewSigner(c.operatorKey)
+func (c *localChain) ContractRegistry() (chain.ContractRegistry, error) {
+	return nil, fmt.Errorf("contract registry is not supported by local chain")
+}
+
+func (c *localChain) PendingTransactions() []chain.PendingTransaction {
+	return nil
 }
 
-func (c *localChain) GetKeys() (*operator.PrivateKey, *operator.PublicKey) {
-	return c.operatorKey, &c.operatorKey.PublicKey
+func (c *localChain) SubscriptionFailures(
+	period time.Duration,
+) []chain.SubscriptionFailure {
+	return nil
+}
+
+func (c *localChain) ResubscribeBlocks() error {
+	return nil
+}
+
+func (c *localChain) Signing() chain.Signing {
+	return commonLocal.NewSigner(c.operatorKey)
 }
 
 func (c *localChain) GetConfig() *relaychain.Config {
@@ -169,6 +190,10 @@ func (c *localChain) GetSelectedParticipants() ([]relaychain.StakerAddress, erro
 	return selectedParticipants, nil
 }
 
+func (c *localChain) SimulateRelayEntry(newEntry []byte) error {
+	return nil
+}
+
 func (c *localChain) SubmitRelayEntry(newEntry []byte) *async.EventEntrySubmittedPromise {
 	c.ticketsMutex.Lock()
 	c.tickets = make([]*relaychain.Ticket, 0)
@@ -207,6 +232,10 @@ func (c *localChain) SubmitRelayEntry(newEntry []byte) *async.EventEntrySubmitte
 
 	c.lastSubmittedRelayEntry = newEntry
 
+	c.currentRequestMutex.Lock()
+	c.currentRequestStartBlock = nil
+	c.currentRequestMutex.Unlock()
+
 	return relayEntryPromise
 }
 
@@ -503,12 +532,29 @@ func (c *localChain) ReportRelayEntryTimeout() error {
 	return nil
 }
 
+func (c *localChain) StartRelayRequest(startBlock uint64) {
+	c.currentRequestMutex.Lock()
+	defer c.currentRequestMutex.Unlock()
+
+	c.currentRequestStartBlock = new(big.Int).SetUint64(startBlock)
+}
+
 func (c *localChain) IsEntryInProgress() (bool, error) {
-	panic("not implemented")
+	c.currentRequestMutex.Lock()
+	defer c.currentRequestMutex.Unlock()
+
+	return c.currentRequestStartBlock != nil, nil
 }
 
 func (c *localChain) CurrentRequestStartBlock() (*big.Int, error) {
-	panic("not implemented")
+	c.currentRequestMutex.Lock()
+	defer c.currentRequestMutex.Unlock()
+
+	if c.currentRequestStartBlock == nil {
+		return big.NewInt(0), nil
+	}
+
+	return new(big.Int).Set(c.currentRequestStartBlock), nil
 }
 
 func (c *localChain) CurrentRequestPreviousEntry() ([]byte, error) {
@@ -0,0 +1,183 @@
+// Package watchdog detects stalls of the chain, i.e. periods in which the
+// block counter does not observe new blocks for much longer than the expected
+// block time. All protocol timeouts of the client are measured in blocks, so
+// a stall leaves in-flight protocol executions waiting forever.
+package watchdog
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ipfs/go-log"
+	"github.com/keep-network/keep-core/pkg/chain"
+)
+
+var logger = log.Logger("keep-chain-watchdog")
+
+// Status is the state of the block counter as seen by the watchdog.
+type Status struct {
+	// LatestBlock is the latest block observed by the block counter.
+	LatestBlock uint64
+	// LatestBlockAge is the time elapsed since the latest block has been
+	// observed.
+	LatestBlockAge time.Duration
+	// Stalled is true if no new block has been observed for longer than the
+	// stall timeout.
+	Stalled bool
+	// Stalls is the number of stalls detected since the watchdog started.
+	Stalls int
+}
+
+// Watchdog watches new blocks of the block counter and reacts when no new
+// block is observed for the given number of expected block times. It logs
+// in-flight protocol executions put at risk by the stall and requests the
+// chain to resubscribe for new blocks until the stall ends.
+type Watchdog struct {
+	blockCounter      chain.BlockCounter
+	expectedBlockTime time.Duration
+	stallTimeout      time.Duration
+	resubscribe       func() error
+	atRisk            func() []string
+
+	mutex          sync.RWMutex
+	latestBlock    uint64
+	observedAt     time.Time
+	stalled        bool
+	stalls         int
+	resubscribedAt time.Time
+}
+
+// New creates a watchdog of the block counter. The chain is considered
+// stalled if no new block is observed for stallBlocks expected block times.
+// The resubscribe function is called when a stall is detected and then once
+// per stall timeout for as long as the stall lasts. The atRisk function
+// returns descriptions of in-flight protocol executions which rely on new
+// blocks.
+func New(
+	blockCounter chain.BlockCounter,
+	expectedBlockTime time.Duration,
+	stallBlocks int,
+	resubscribe func() error,
+	atRisk func() []string,
+) *Watchdog {
+	return &Watchdog{
+		blockCounter:      blockCounter,
+		expectedBlockTime: expectedBlockTime,
+		stallTimeout:      time.Duration(stallBlocks) * expectedBlockTime,
+		resubscribe:       resubscribe,
+		atRisk:            atRisk,
+	}
+}
+
+// Start starts watching new blocks. The watchdog works until the context is
+// done.
+func (w *Watchdog) Start(ctx context.Context) {
+	w.mutex.Lock()
+	w.observedAt = time.Now()
+	if currentBlock, err := w.blockCounter.CurrentBlock(); err == nil {
+		w.latestBlock = currentBlock
+	}
+	w.mutex.Unlock()
+
+	logger.Infof(
+		"watching new blocks; stall timeout is [%v]",
+		w.stallTimeout,
+	)
+
+	blocks := w.blockCounter.WatchBlocks(ctx)
+
+	go func() {
+		ticker := time.NewTicker(w.expectedBlockTime)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case block, ok := <-blocks:
+				if !ok {
+					return
+				}
+				w.observe(block)
+			case <-ticker.C:
+				w.check()
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// Status returns the current state of the block counter.
+func (w *Watchdog) Status() Status {
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+
+	return Status{
+		LatestBlock:    w.latestBlock,
+		LatestBlockAge: time.Since(w.observedAt),
+		Stalled:        w.stalled,
+		Stalls:         w.stalls,
+	}
+}
+
+func (w *Watchdog) observe(block uint64) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.stalled {
+		logger.Infof(
+			"chain stall ended; observed block [%v] after [%v]",
+			block,
+			time.Since(w.observedAt).Round(time.Second),
+		)
+	}
+
+	w.latestBlock = block
+	w.observedAt = time.Now()
+	w.stalled = false
+}
+
+func (w *Watchdog) check() {
+	w.mutex.Lock()
+
+	age := time.Since(w.observedAt)
+	if age <= w.stallTimeout {
+		w.mutex.Unlock()
+		return
+	}
+
+	if !w.stalled {
+		w.stalled = true
+		w.stalls++
+
+		atRisk := w.atRisk()
+		if len(atRisk) == 0 {
+			logger.Warningf(
+				"chain stalled; no new block after block [%v] for [%v]; "+
+					"no protocol executions are in progress",
+				w.latestBlock,
+				age.Round(time.Second),
+			)
+		} else {
+			logger.Warningf(
+				"chain stalled; no new block after block [%v] for [%v]; "+
+					"in-flight protocol executions at risk: [%v]",
+				w.latestBlock,
+				age.Round(time.Second),
+				strings.Join(atRisk, "; "),
+			)
+		}
+	} else if time.Since(w.resubscribedAt) < w.stallTimeout {
+		w.mutex.Unlock()
+		return
+	}
+
+	w.resubscribedAt = time.Now()
+	w.mutex.Unlock()
+
+	logger.Infof("resubscribing for new blocks")
+	if err := w.resubscribe(); err != nil {
+		logger.Errorf("could not resubscribe for new blocks: [%v]", err)
+	}
+}
@@ -0,0 +1,145 @@
+package watchdog
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+const testExpectedBlockTime = 50 * time.Millisecond
+
+func TestWatchdogDetectsStall(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	blockCounter := newTestBlockCounter(10)
+	resubscriptions := &counter{}
+
+	watchdog := New(
+		blockCounter,
+		testExpectedBlockTime,
+		4,
+		func() error {
+			resubscriptions.increment()
+			return nil
+		},
+		func() []string { return []string{"DKG"} },
+	)
+	watchdog.Start(ctx)
+
+	blockCounter.blocks <- 11
+	time.Sleep(2 * testExpectedBlockTime)
+
+	status := watchdog.Status()
+	if status.Stalled {
+		t.Fatalf("expected the chain to not be stalled")
+	}
+	if status.LatestBlock != 11 {
+		t.Errorf(
+			"unexpected latest block\nexpected: [%v]\nactual:   [%v]",
+			11,
+			status.LatestBlock,
+		)
+	}
+
+	time.Sleep(5 * testExpectedBlockTime)
+
+	status = watchdog.Status()
+	if !status.Stalled {
+		t.Fatalf("expected the chain to be stalled")
+	}
+	if status.Stalls != 1 {
+		t.Errorf(
+			"unexpected number of stalls\nexpected: [%v]\nactual:   [%v]",
+			1,
+			status.Stalls,
+		)
+	}
+	resubscriptionsAtStall := resubscriptions.get()
+	if resubscriptionsAtStall < 1 {
+		t.Errorf("expected resubscription once the stall is detected")
+	}
+
+	// Resubscription is retried once per stall timeout while the stall lasts.
+	// The exact number of retries depends on the scheduling of the watchdog
+	// ticker, so only the retry itself is checked.
+	time.Sleep(5 * testExpectedBlockTime)
+
+	if resubscriptions.get() <= resubscriptionsAtStall {
+		t.Errorf(
+			"expected resubscription to be retried while the stall lasts; "+
+				"has [%v] resubscriptions",
+			resubscriptions.get(),
+		)
+	}
+
+	blockCounter.blocks <- 12
+	time.Sleep(testExpectedBlockTime)
+
+	status = watchdog.Status()
+	if status.Stalled {
+		t.Fatalf("expected the stall to end")
+	}
+	if status.LatestBlock != 12 {
+		t.Errorf(
+			"unexpected latest block\nexpected: [%v]\nactual:   [%v]",
+			12,
+			status.LatestBlock,
+		)
+	}
+	if status.Stalls != 1 {
+		t.Errorf(
+			"unexpected number of stalls\nexpected: [%v]\nactual:   [%v]",
+			1,
+			status.Stalls,
+		)
+	}
+}
+
+type counter struct {
+	mutex sync.Mutex
+	value int
+}
+
+func (c *counter) increment() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.value++
+}
+
+func (c *counter) get() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.value
+}
+
+type testBlockCounter struct {
+	currentBlock uint64
+	blocks       chan uint64
+}
+
+func newTestBlockCounter(currentBlock uint64) *testBlockCounter {
+	return &testBlockCounter{
+		currentBlock: currentBlock,
+		blocks:       make(chan uint64),
+	}
+}
+
+func (tbc *testBlockCounter) WaitForBlockHeight(blockNumber uint64) error {
+	return nil
+}
+
+func (tbc *testBlockCounter) BlockHeightWaiter(
+	blockNumber uint64,
+) (<-chan uint64, error) {
+	return nil, nil
+}
+
+func (tbc *testBlockCounter) CurrentBlock() (uint64, error) {
+	return tbc.currentBlock, nil
+}
+
+func (tbc *testBlockCounter) WatchBlocks(ctx context.Context) <-chan uint64 {
+	return tbc.blocks
+}
@@ -2,32 +2,114 @@ package diagnostics
 
 import (
 	"encoding/json"
+	"fmt"
+	stdnet "net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
 
 	"github.com/ipfs/go-log"
-	"github.com/keep-network/keep-common/pkg/diagnostics"
+	"github.com/keep-network/keep-core/pkg/beacon"
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/logging"
 	"github.com/keep-network/keep-core/pkg/net"
 	"github.com/keep-network/keep-core/pkg/net/key"
 )
 
 var logger = log.Logger("keep-diagnostics")
 
+// Registry holds diagnostics sources exposed by the diagnostics server on
+// the `/diagnostics` path. Endpoints controlling the client are registered on
+// the mux of the diagnostics server only, so that they are not exposed by the
+// metrics server, which serves the default HTTP mux. Paths not registered on
+// the diagnostics mux are served by the default HTTP mux.
+type Registry struct {
+	sourcesMutex sync.RWMutex
+	sources      map[string]func() string
+
+	mux *http.ServeMux
+}
+
+func newRegistry() *Registry {
+	registry := &Registry{
+		sources: make(map[string]func() string),
+		mux:     http.NewServeMux(),
+	}
+
+	registry.mux.HandleFunc("/diagnostics", registry.serveDiagnostics)
+	registry.mux.Handle("/", http.DefaultServeMux)
+
+	return registry
+}
+
 // Initialize sets up the diagnostics registry and enables diagnostics server.
-func Initialize(port int) (*diagnostics.DiagnosticsRegistry, bool) {
+func Initialize(port int) (*Registry, bool) {
 	if port == 0 {
 		return nil, false
 	}
 
-	registry := diagnostics.NewRegistry()
+	registry := newRegistry()
 
-	registry.EnableServer(port)
+	server := &http.Server{
+		Addr:    ":" + strconv.Itoa(port),
+		Handler: registry.mux,
+	}
+
+	go func() {
+		if err := server.ListenAndServe(); err != http.ErrServerClosed {
+			logger.Errorf("diagnostics server error: [%v]", err)
+		}
+	}()
 
 	return registry, true
 }
 
+// RegisterSource registers the source of the diagnostics exposed under the
+// given name. The source returns a JSON document; documents which are not
+// valid JSON are left out.
+func (r *Registry) RegisterSource(name string, source func() string) {
+	r.sourcesMutex.Lock()
+	defer r.sourcesMutex.Unlock()
+
+	r.sources[name] = source
+}
+
+// HandleFunc registers the handler for the given pattern on the diagnostics
+// server only.
+func (r *Registry) HandleFunc(
+	pattern string,
+	handler func(http.ResponseWriter, *http.Request),
+) {
+	r.mux.HandleFunc(pattern, handler)
+}
+
+func (r *Registry) serveDiagnostics(
+	response http.ResponseWriter,
+	_ *http.Request,
+) {
+	r.sourcesMutex.RLock()
+	defer r.sourcesMutex.RUnlock()
+
+	diagnostics := make(map[string]interface{}, len(r.sources))
+	for name, source := range r.sources {
+		var document interface{}
+		if err := json.Unmarshal([]byte(source()), &document); err == nil {
+			diagnostics[name] = document
+		}
+	}
+
+	response.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(response).Encode(diagnostics); err != nil {
+		logger.Errorf("error on serializing diagnostics to JSON: [%v]", err)
+	}
+}
+
 // RegisterConnectedPeersSource registers the diagnostics source providing
 // information about connected peers.
 func RegisterConnectedPeersSource(
-	registry *diagnostics.DiagnosticsRegistry,
+	registry *Registry,
 	netProvider net.Provider,
 ) {
 	registry.RegisterSource("connected_peers", func() string {
@@ -37,15 +119,23 @@ func RegisterConnectedPeersSource(
 		peersList := make([]map[string]interface{}, len(connectedPeers))
 		for i := 0; i < len(connectedPeers); i++ {
 			peer := connectedPeers[i]
-			peerPublicKey, err := connectionManager.GetPeerPublicKey(peer)
+			peerAddress, err := operatorAddress(connectionManager, peer)
 			if err != nil {
-				logger.Error("error on getting peer public key: [%v]", err)
+				logger.Error("error on getting peer operator address: [%v]", err)
 				continue
 			}
 
 			peersList[i] = map[string]interface{}{
 				"network_id":       peer,
-				"ethereum_address": key.NetworkPubKeyToEthAddress(peerPublicKey),
+				"ethereum_address": peerAddress,
+			}
+
+			capabilities := connectionManager.GetPeerCapabilities(peer)
+			if capabilities != nil {
+				peersList[i]["client_version"] = capabilities.ClientVersion
+				peersList[i]["protocol_version"] = capabilities.ProtocolVersion
+				peersList[i]["message_version"] = capabilities.MessageVersion
+				peersList[i]["features"] = capabilities.Features
 			}
 		}
 
@@ -62,22 +152,23 @@ func RegisterConnectedPeersSource(
 // RegisterClientInfoSource registers the diagnostics source providing
 // information about the client itself.
 func RegisterClientInfoSource(
-	registry *diagnostics.DiagnosticsRegistry,
+	registry *Registry,
 	netProvider net.Provider,
 ) {
 	registry.RegisterSource("client_info", func() string {
 		connectionManager := netProvider.ConnectionManager()
 
 		clientID := netProvider.ID().String()
-		clientPublicKey, err := connectionManager.GetPeerPublicKey(clientID)
+		clientAddress, err := operatorAddress(connectionManager, clientID)
 		if err != nil {
-			logger.Error("error on getting client public key: [%v]", err)
+			logger.Error("error on getting client operator address: [%v]", err)
 			return ""
 		}
 
 		clientInfo := map[string]interface{}{
 			"network_id":       clientID,
-			"ethereum_address": key.NetworkPubKeyToEthAddress(clientPublicKey),
+			"ethereum_address": clientAddress,
+			"reachability":     connectionManager.Reachability(),
 		}
 
 		bytes, err := json.Marshal(clientInfo)
@@ -89,3 +180,317 @@ func RegisterClientInfoSource(
 		return string(bytes)
 	})
 }
+
+// RegisterPeerReputationSource registers the diagnostics source providing
+// reputation scores of peers which recently misbehaved.
+func RegisterPeerReputationSource(
+	registry *Registry,
+	netProvider net.Provider,
+) {
+	registry.RegisterSource("peer_reputation", func() string {
+		reputation := netProvider.Reputation()
+		scores := reputation.Scores()
+
+		peers := make([]string, 0, len(scores))
+		for peer := range scores {
+			peers = append(peers, peer)
+		}
+		sort.Strings(peers)
+
+		peersList := make([]map[string]interface{}, len(peers))
+		for i, peer := range peers {
+			peersList[i] = map[string]interface{}{
+				"network_id": peer,
+				"score":      scores[peer],
+				"banned":     reputation.IsBanned(peer),
+			}
+		}
+
+		bytes, err := json.Marshal(peersList)
+		if err != nil {
+			logger.Error("error on serializing peer reputation to JSON: [%v]", err)
+			return ""
+		}
+
+		return string(bytes)
+	})
+}
+
+// RegisterPendingTransactionsSource registers the diagnostics source providing
+// information about transactions submitted by the client which have not been
+// mined yet.
+func RegisterPendingTransactionsSource(
+	registry *Registry,
+	chainHandle chain.Handle,
+) {
+	registry.RegisterSource("pending_transactions", func() string {
+		pendingTransactions := chainHandle.PendingTransactions()
+
+		transactionsList := make([]map[string]interface{}, len(pendingTransactions))
+		for i, transaction := range pendingTransactions {
+			transactionsList[i] = map[string]interface{}{
+				"hash":               transaction.Hash,
+				"method":             transaction.Method,
+				"nonce":              transaction.Nonce,
+				"gas_price":          transaction.GasPrice.String(),
+				"submitted_at_block": transaction.SubmittedAtBlock,
+				"resubmissions":      transaction.Resubmissions,
+				"cancelled":          transaction.Cancelled,
+			}
+		}
+
+		bytes, err := json.Marshal(transactionsList)
+		if err != nil {
+			logger.Error("error on serializing pending transactions to JSON: [%v]", err)
+			return ""
+		}
+
+		return string(bytes)
+	})
+}
+
+// RegisterMaintenanceSource registers the diagnostics source providing the
+// maintenance status of beacons of all operators run by the client, keyed by
+// the operator address, and exposes the `/maintenance` endpoint controlling
+// the maintenance mode. A GET request returns the maintenance status. A POST
+// request with the `enabled` form value set to `true` or `false` switches the
+// maintenance mode and returns the updated status. The mode is switched for
+// all operators unless the `operator` form value names one of them. Since
+// the diagnostics port may be publicly reachable, the maintenance mode can be
+// switched only from the loopback interface. The endpoint is not exposed by
+// the metrics server.
+func RegisterMaintenanceSource(
+	registry *Registry,
+	beacons map[string]*beacon.Beacon,
+) {
+	registry.RegisterSource("maintenance", func() string {
+		statuses, err := maintenanceStatuses(beacons)
+		if err != nil {
+			logger.Error("error on getting maintenance status: [%v]", err)
+			return ""
+		}
+
+		bytes, err := json.Marshal(statuses)
+		if err != nil {
+			logger.Error("error on serializing maintenance status to JSON: [%v]", err)
+			return ""
+		}
+
+		return string(bytes)
+	})
+
+	registry.HandleFunc(
+		"/maintenance",
+		func(response http.ResponseWriter, request *http.Request) {
+			switch request.Method {
+			case http.MethodGet:
+			case http.MethodPost:
+				if !isLoopbackRequest(request) {
+					http.Error(
+						response,
+						"maintenance mode can be switched only from the "+
+							"loopback interface",
+						http.StatusForbidden,
+					)
+					return
+				}
+
+				enabled, err := strconv.ParseBool(request.FormValue("enabled"))
+				if err != nil {
+					http.Error(
+						response,
+						"enabled has to be set to true or false",
+						http.StatusBadRequest,
+					)
+					return
+				}
+
+				switched := beacons
+				if operator := request.FormValue("operator"); operator != "" {
+					randomBeacon, ok := findBeacon(beacons, operator)
+					if !ok {
+						http.Error(
+							response,
+							fmt.Sprintf("unknown operator [%v]", operator),
+							http.StatusNotFound,
+						)
+						return
+					}
+
+					switched = map[string]*beacon.Beacon{
+						operator: randomBeacon,
+					}
+				}
+
+				for _, randomBeacon := range switched {
+					if enabled {
+						randomBeacon.EnableMaintenance()
+					} else {
+						randomBeacon.DisableMaintenance()
+					}
+				}
+			default:
+				http.Error(
+					response,
+					"method not allowed",
+					http.StatusMethodNotAllowed,
+				)
+				return
+			}
+
+			statuses, err := maintenanceStatuses(beacons)
+			if err != nil {
+				logger.Errorf("error on getting maintenance status: [%v]", err)
+				http.Error(
+					response,
+					"could not determine the maintenance status",
+					http.StatusInternalServerError,
+				)
+				return
+			}
+
+			response.Header().Set("Content-Type", "application/json")
+			if err := json.NewEncoder(response).Encode(statuses); err != nil {
+				logger.Errorf(
+					"error on serializing maintenance status to JSON: [%v]",
+					err,
+				)
+			}
+		},
+	)
+}
+
+// RegisterLoggingSource registers the diagnostics source providing the
+// current log level of each logger and exposes the `/logging` endpoint
+// changing log levels on a running client. A GET request returns log levels
+// keyed by the logger name. A POST request with the `logger` and `level` form
+// values sets the level of the named logger and returns the updated levels.
+// The logger name may end with `*` to set the level of all loggers with the
+// given prefix. Log levels can be changed only from the loopback interface.
+// The endpoint is not exposed by the metrics server.
+func RegisterLoggingSource(registry *Registry) {
+	registry.RegisterSource("log_levels", func() string {
+		bytes, err := json.Marshal(logging.Levels())
+		if err != nil {
+			logger.Errorf("error on serializing log levels to JSON: [%v]", err)
+			return ""
+		}
+
+		return string(bytes)
+	})
+
+	registry.HandleFunc(
+		"/logging",
+		func(response http.ResponseWriter, request *http.Request) {
+			switch request.Method {
+			case http.MethodGet:
+			case http.MethodPost:
+				if !isLoopbackRequest(request) {
+					http.Error(
+						response,
+						"log levels can be changed only from the "+
+							"loopback interface",
+						http.StatusForbidden,
+					)
+					return
+				}
+
+				changed, err := logging.SetLevel(
+					request.FormValue("logger"),
+					request.FormValue("level"),
+				)
+				if err != nil {
+					http.Error(response, err.Error(), http.StatusBadRequest)
+					return
+				}
+
+				logger.Infof(
+					"log level of loggers %v set to [%v]",
+					changed,
+					request.FormValue("level"),
+				)
+			default:
+				http.Error(
+					response,
+					"method not allowed",
+					http.StatusMethodNotAllowed,
+				)
+				return
+			}
+
+			response.Header().Set("Content-Type", "application/json")
+			if err := json.NewEncoder(response).Encode(logging.Levels()); err != nil {
+				logger.Errorf("error on serializing log levels to JSON: [%v]", err)
+			}
+		},
+	)
+}
+
+func maintenanceStatuses(
+	beacons map[string]*beacon.Beacon,
+) (map[string]*beacon.MaintenanceStatus, error) {
+	statuses := make(map[string]*beacon.MaintenanceStatus, len(beacons))
+	for operator, randomBeacon := range beacons {
+		status, err := randomBeacon.MaintenanceStatus()
+		if err != nil {
+			return nil, fmt.Errorf(
+				"could not get status of operator [%v]: [%v]",
+				operator,
+				err,
+			)
+		}
+
+		statuses[operator] = status
+	}
+
+	return statuses, nil
+}
+
+// findBeacon looks up the beacon of the given operator. Operator addresses
+// are compared case-insensitively.
+func findBeacon(
+	beacons map[string]*beacon.Beacon,
+	operator string,
+) (*beacon.Beacon, bool) {
+	for address, randomBeacon := range beacons {
+		if strings.EqualFold(address, operator) {
+			return randomBeacon, true
+		}
+	}
+
+	return nil, false
+}
+
+func isLoopbackRequest(request *http.Request) bool {
+	host, _, err := stdnet.SplitHostPort(request.RemoteAddr)
+	if err != nil {
+		return false
+	}
+
+	ip := stdnet.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// operatorAddress resolves the Ethereum address of the operator the given
+// peer acts on behalf of. If the peer has no network key certificate, the
+// peer's network key is its operator key.
+func operatorAddress(
+	connectionManager net.ConnectionManager,
+	peer string,
+) (string, error) {
+	certificate, err := connectionManager.GetPeerCertificate(peer)
+	if err != nil {
+		return "", err
+	}
+
+	if certificate != nil {
+		return certificate.OperatorAddress(), nil
+	}
+
+	peerPublicKey, err := connectionManager.GetPeerPublicKey(peer)
+	if err != nil {
+		return "", err
+	}
+
+	return key.NetworkPubKeyToEthAddress(peerPublicKey), nil
+}
@@ -0,0 +1,56 @@
+package diagnostics
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRegistryEndpointsNotOnDefaultMux(t *testing.T) {
+	registry := newRegistry()
+	registry.HandleFunc(
+		"/test-endpoint",
+		func(response http.ResponseWriter, _ *http.Request) {
+			response.WriteHeader(http.StatusNoContent)
+		},
+	)
+
+	request := httptest.NewRequest(http.MethodGet, "/test-endpoint", nil)
+
+	response := httptest.NewRecorder()
+	registry.mux.ServeHTTP(response, request)
+	if response.Code != http.StatusNoContent {
+		t.Errorf("unexpected diagnostics server status [%v]", response.Code)
+	}
+
+	response = httptest.NewRecorder()
+	http.DefaultServeMux.ServeHTTP(response, request)
+	if response.Code != http.StatusNotFound {
+		t.Errorf("unexpected default mux status [%v]", response.Code)
+	}
+}
+
+func TestRegistryServesSources(t *testing.T) {
+	registry := newRegistry()
+	registry.RegisterSource("valid", func() string { return `{"ok":true}` })
+	registry.RegisterSource("invalid", func() string { return "" })
+
+	response := httptest.NewRecorder()
+	registry.mux.ServeHTTP(
+		response,
+		httptest.NewRequest(http.MethodGet, "/diagnostics", nil),
+	)
+
+	var diagnostics map[string]interface{}
+	if err := json.Unmarshal(response.Body.Bytes(), &diagnostics); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := diagnostics["valid"]; !ok {
+		t.Errorf("expected valid source to be served")
+	}
+	if _, ok := diagnostics["invalid"]; ok {
+		t.Errorf("expected invalid source to be left out")
+	}
+}
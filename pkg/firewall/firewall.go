@@ -18,7 +18,10 @@ var Disabled = &noFirewall{}
 
 type noFirewall struct{}
 
-func (nf *noFirewall) Validate(remotePeerPublicKey *ecdsa.PublicKey) error {
+func (nf *noFirewall) Validate(
+	remotePeerPublicKey *ecdsa.PublicKey,
+	certificate *key.Certificate,
+) error {
 	return nil
 }
 
@@ -37,7 +40,9 @@ const (
 var errNoMinimumStake = fmt.Errorf("remote peer has no minimum stake")
 
 // MinimumStakePolicy is a net.Firewall rule making sure the remote peer
-// has a minimum stake of KEEP.
+// has a minimum stake of KEEP. If the remote peer uses a network key separate
+// from its operator key, the policy verifies the certificate binding the
+// network key to the operator key and checks the stake of the operator.
 func MinimumStakePolicy(stakeMonitor chain.StakeMonitor) net.Firewall {
 	return &minimumStakePolicy{
 		stakeMonitor:        stakeMonitor,
@@ -54,10 +59,22 @@ type minimumStakePolicy struct {
 
 func (msp *minimumStakePolicy) Validate(
 	remotePeerPublicKey *ecdsa.PublicKey,
+	certificate *key.Certificate,
 ) error {
 	networkPublicKey := key.NetworkPublic(*remotePeerPublicKey)
 	address := key.NetworkPubKeyToEthAddress(&networkPublicKey)
 
+	if certificate != nil {
+		if err := certificate.VerifyFor(&networkPublicKey); err != nil {
+			return fmt.Errorf(
+				"invalid remote peer's network key certificate: [%v]",
+				err,
+			)
+		}
+
+		address = certificate.OperatorAddress()
+	}
+
 	// First, check in the in-memory time caches to minimize hits to ETH client.
 	// If the Keep client with the given chain address is in the positive result
 	// cache it means it has had a minimum stake the last HasMinimumStake was
@@ -6,8 +6,10 @@ import (
 	"time"
 
 	"github.com/keep-network/keep-common/pkg/cache"
+	"github.com/keep-network/keep-common/pkg/chain/ethereum/ethutil"
 	"github.com/keep-network/keep-core/pkg/chain/local"
 	"github.com/keep-network/keep-core/pkg/net/key"
+	"github.com/keep-network/keep-core/pkg/operator"
 )
 
 var minimumStake = big.NewInt(1000)
@@ -31,6 +33,7 @@ func TestHasMinimumStake(t *testing.T) {
 
 	if err := policy.Validate(
 		key.NetworkKeyToECDSAKey(remotePeerPublicKey),
+		nil,
 	); err != nil {
 		t.Fatalf("validation should pass: [%v]", err)
 	}
@@ -51,6 +54,7 @@ func TestHasNoMinimumStake(t *testing.T) {
 
 	if err := policy.Validate(
 		key.NetworkKeyToECDSAKey(remotePeerPublicKey),
+		nil,
 	); err != errNoMinimumStake {
 		t.Fatalf(
 			"unexpected validation error\nactual:   [%v]\nexpected: [%v]",
@@ -77,6 +81,7 @@ func TestCachesHasMinimumStake(t *testing.T) {
 
 	if err := policy.Validate(
 		key.NetworkKeyToECDSAKey(remotePeerPublicKey),
+		nil,
 	); err != nil {
 		t.Fatalf("validation should pass: [%v]", err)
 	}
@@ -86,6 +91,7 @@ func TestCachesHasMinimumStake(t *testing.T) {
 	// still caching the old result
 	if err := policy.Validate(
 		key.NetworkKeyToECDSAKey(remotePeerPublicKey),
+		nil,
 	); err != nil {
 		t.Fatalf("validation should pass: [%v]", err)
 	}
@@ -95,6 +101,7 @@ func TestCachesHasMinimumStake(t *testing.T) {
 	// no longer caches the previous result
 	if err := policy.Validate(
 		key.NetworkKeyToECDSAKey(remotePeerPublicKey),
+		nil,
 	); err != errNoMinimumStake {
 		t.Fatalf(
 			"unexpected validation error\nactual:   [%v]\nexpected: [%v]",
@@ -120,6 +127,7 @@ func TestCachesHasNoMinimumStake(t *testing.T) {
 
 	if err := policy.Validate(
 		key.NetworkKeyToECDSAKey(remotePeerPublicKey),
+		nil,
 	); err != errNoMinimumStake {
 		t.Fatalf(
 			"unexpected validation error\nactual:   [%v]\nexpected: [%v]",
@@ -133,6 +141,7 @@ func TestCachesHasNoMinimumStake(t *testing.T) {
 	// still caching the old result
 	if err := policy.Validate(
 		key.NetworkKeyToECDSAKey(remotePeerPublicKey),
+		nil,
 	); err != errNoMinimumStake {
 		t.Fatalf(
 			"unexpected validation error\nactual:   [%v]\nexpected: [%v]",
@@ -146,7 +155,101 @@ func TestCachesHasNoMinimumStake(t *testing.T) {
 	// no longer caches the previous result
 	if err := policy.Validate(
 		key.NetworkKeyToECDSAKey(remotePeerPublicKey),
+		nil,
 	); err != nil {
 		t.Fatalf("validation should pass: [%v]", err)
 	}
 }
+
+func TestHasMinimumStakeWithCertificate(t *testing.T) {
+	stakeMonitor := local.NewStakeMonitor(minimumStake)
+	policy := &minimumStakePolicy{
+		stakeMonitor:        stakeMonitor,
+		positiveResultCache: cache.NewTimeCache(cachingPeriod),
+		negativeResultCache: cache.NewTimeCache(cachingPeriod),
+	}
+
+	operatorPrivateKey, operatorPublicKey, err := operator.GenerateKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, remotePeerPublicKey, err := key.GenerateStaticNetworkKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certificate, err := key.IssueCertificate(
+		operatorPublicKey,
+		remotePeerPublicKey,
+		key.DefaultCertificateValidity,
+		ethutil.NewSigner(operatorPrivateKey).Sign,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Only the operator has a stake; the network key is not staked.
+	stakeMonitor.StakeTokens(certificate.OperatorAddress())
+
+	if err := policy.Validate(
+		key.NetworkKeyToECDSAKey(remotePeerPublicKey),
+		certificate,
+	); err != nil {
+		t.Fatalf("validation should pass: [%v]", err)
+	}
+
+	if err := policy.Validate(
+		key.NetworkKeyToECDSAKey(remotePeerPublicKey),
+		nil,
+	); err != errNoMinimumStake {
+		t.Fatalf(
+			"unexpected validation error\nactual:   [%v]\nexpected: [%v]",
+			err,
+			errNoMinimumStake,
+		)
+	}
+}
+
+func TestCertificateIssuedForAnotherNetworkKey(t *testing.T) {
+	stakeMonitor := local.NewStakeMonitor(minimumStake)
+	policy := &minimumStakePolicy{
+		stakeMonitor:        stakeMonitor,
+		positiveResultCache: cache.NewTimeCache(cachingPeriod),
+		negativeResultCache: cache.NewTimeCache(cachingPeriod),
+	}
+
+	operatorPrivateKey, operatorPublicKey, err := operator.GenerateKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, certifiedPublicKey, err := key.GenerateStaticNetworkKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, remotePeerPublicKey, err := key.GenerateStaticNetworkKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certificate, err := key.IssueCertificate(
+		operatorPublicKey,
+		certifiedPublicKey,
+		key.DefaultCertificateValidity,
+		ethutil.NewSigner(operatorPrivateKey).Sign,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stakeMonitor.StakeTokens(certificate.OperatorAddress())
+
+	if err := policy.Validate(
+		key.NetworkKeyToECDSAKey(remotePeerPublicKey),
+		certificate,
+	); err == nil {
+		t.Fatal("validation should fail for certificate of another key")
+	}
+}
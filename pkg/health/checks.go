@@ -0,0 +1,284 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/keep-network/keep-core/pkg/beacon"
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/chain/watchdog"
+	"github.com/keep-network/keep-core/pkg/net"
+)
+
+// SubscriptionFailurePeriod is the period for which a failed chain event
+// subscription is reported. It is longer than the delay after which failed
+// subscriptions are renewed so that repeated failures are always reported.
+const SubscriptionFailurePeriod = 1 * time.Minute
+
+// BlockCounterCheck checks whether the block counter observes new blocks.
+// The check fails if no new block has been observed for longer than the
+// given maximum age, e.g. because the connection to the Ethereum node has
+// been lost.
+func BlockCounterCheck(
+	ctx context.Context,
+	blockCounter chain.BlockCounter,
+	maxAge time.Duration,
+) Check {
+	var (
+		mutex       sync.Mutex
+		latestBlock uint64
+		observedAt  = time.Now()
+	)
+
+	if currentBlock, err := blockCounter.CurrentBlock(); err == nil {
+		latestBlock = currentBlock
+	}
+
+	go func() {
+		for block := range blockCounter.WatchBlocks(ctx) {
+			mutex.Lock()
+			latestBlock = block
+			observedAt = time.Now()
+			mutex.Unlock()
+		}
+	}()
+
+	return func() (map[string]interface{}, error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		age := time.Since(observedAt)
+		details := map[string]interface{}{
+			"latest_block":     latestBlock,
+			"latest_block_age": age.Round(time.Second).String(),
+		}
+
+		if age > maxAge {
+			return details, fmt.Errorf(
+				"no new block observed for more than [%v]",
+				maxAge,
+			)
+		}
+
+		return details, nil
+	}
+}
+
+// ChainStallCheck checks whether the chain watchdog considers the chain
+// stalled. Unlike BlockCounterCheck, the check fails as soon as no new block
+// is observed for the stall timeout of the watchdog, while the watchdog still
+// tries to recover by renewing the subscription to new blocks.
+func ChainStallCheck(chainWatchdog *watchdog.Watchdog) Check {
+	return func() (map[string]interface{}, error) {
+		status := chainWatchdog.Status()
+		details := map[string]interface{}{
+			"latest_block":     status.LatestBlock,
+			"latest_block_age": status.LatestBlockAge.Round(time.Second).String(),
+			"stalls":           status.Stalls,
+		}
+
+		if status.Stalled {
+			return details, fmt.Errorf(
+				"chain stalled at block [%v]",
+				status.LatestBlock,
+			)
+		}
+
+		return details, nil
+	}
+}
+
+// SubscriptionsCheck checks whether chain event subscriptions of all
+// operators are healthy. The check fails if any subscription failed within
+// SubscriptionFailurePeriod.
+func SubscriptionsCheck(chainHandles map[string]chain.Handle) Check {
+	return func() (map[string]interface{}, error) {
+		details := make(map[string]interface{})
+		failed := 0
+
+		for operator, chainHandle := range chainHandles {
+			failures := chainHandle.SubscriptionFailures(
+				SubscriptionFailurePeriod,
+			)
+			if len(failures) == 0 {
+				continue
+			}
+
+			sort.Slice(failures, func(i, j int) bool {
+				return failures[i].Event < failures[j].Event
+			})
+
+			failuresList := make([]map[string]interface{}, len(failures))
+			for i, failure := range failures {
+				failuresList[i] = map[string]interface{}{
+					"event": failure.Event,
+					"error": failure.Error,
+					"time":  failure.Time.Format(time.RFC3339),
+				}
+			}
+
+			details[operator] = failuresList
+			failed += len(failures)
+		}
+
+		if failed > 0 {
+			return details, fmt.Errorf(
+				"[%v] event subscriptions failed within the last [%v]",
+				failed,
+				SubscriptionFailurePeriod,
+			)
+		}
+
+		return details, nil
+	}
+}
+
+// MinimumStakeCheck checks whether all operators have the minimum stake.
+// The check fails if any operator does not have the minimum stake or the
+// stake could not be checked.
+func MinimumStakeCheck(
+	stakeMonitor chain.StakeMonitor,
+	operators []string,
+) Check {
+	return func() (map[string]interface{}, error) {
+		details := make(map[string]interface{}, len(operators))
+		var failures []string
+
+		for _, operator := range operators {
+			hasMinimumStake, err := stakeMonitor.HasMinimumStake(operator)
+			if err != nil {
+				return details, fmt.Errorf(
+					"could not check the stake of operator [%v]: [%v]",
+					operator,
+					err,
+				)
+			}
+
+			details[operator] = hasMinimumStake
+			if !hasMinimumStake {
+				failures = append(failures, operator)
+			}
+		}
+
+		if len(failures) > 0 {
+			return details, fmt.Errorf(
+				"operators [%v] do not have the minimum stake",
+				strings.Join(failures, ", "),
+			)
+		}
+
+		return details, nil
+	}
+}
+
+// ConnectedPeersCheck checks whether the client is connected to at least the
+// given number of peers.
+func ConnectedPeersCheck(netProvider net.Provider, minimum int) Check {
+	return func() (map[string]interface{}, error) {
+		connected := len(netProvider.ConnectionManager().ConnectedPeers())
+		details := map[string]interface{}{
+			"connected_peers": connected,
+			"minimum":         minimum,
+		}
+
+		if connected < minimum {
+			return details, fmt.Errorf(
+				"connected to [%v] peers; at least [%v] required",
+				connected,
+				minimum,
+			)
+		}
+
+		return details, nil
+	}
+}
+
+// ConnectedBootstrapsCheck checks whether the client is connected to at
+// least one of the given bootstrap peers. The check always passes if there
+// are no bootstrap peers, e.g. for bootstrap nodes themselves.
+func ConnectedBootstrapsCheck(
+	netProvider net.Provider,
+	bootstraps []string,
+) Check {
+	return func() (map[string]interface{}, error) {
+		connected := 0
+		for _, address := range bootstraps {
+			if netProvider.ConnectionManager().IsConnected(address) {
+				connected++
+			}
+		}
+
+		details := map[string]interface{}{
+			"connected_bootstraps": connected,
+			"bootstraps":           len(bootstraps),
+		}
+
+		if len(bootstraps) > 0 && connected == 0 {
+			return details, fmt.Errorf("not connected to any bootstrap peer")
+		}
+
+		return details, nil
+	}
+}
+
+// GroupRegistryCheck checks whether group memberships of all operators have
+// been loaded from the persistent storage without errors. Memberships which
+// could not be loaded are lost for the client until the storage is fixed.
+func GroupRegistryCheck(beacons map[string]*beacon.Beacon) Check {
+	return func() (map[string]interface{}, error) {
+		details := make(map[string]interface{}, len(beacons))
+		failed := 0
+
+		for operator, randomBeacon := range beacons {
+			loadErrors := randomBeacon.GroupLoadErrors()
+
+			errorsList := make([]string, len(loadErrors))
+			for i, err := range loadErrors {
+				errorsList[i] = err.Error()
+			}
+
+			details[operator] = map[string]interface{}{
+				"groups":      randomBeacon.GroupCount(),
+				"load_errors": errorsList,
+			}
+			failed += len(loadErrors)
+		}
+
+		if failed > 0 {
+			return details, fmt.Errorf(
+				"[%v] group memberships could not be loaded",
+				failed,
+			)
+		}
+
+		return details, nil
+	}
+}
+
+// Cached wraps the check so that it is run at most once per the given period.
+// Other calls within the period return the most recent result. It limits the
+// number of requests to the chain made by orchestrator probes.
+func Cached(check Check, period time.Duration) Check {
+	var (
+		mutex     sync.Mutex
+		checkedAt time.Time
+		details   map[string]interface{}
+		err       error
+	)
+
+	return func() (map[string]interface{}, error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		if checkedAt.IsZero() || time.Since(checkedAt) >= period {
+			details, err = check()
+			checkedAt = time.Now()
+		}
+
+		return details, err
+	}
+}
@@ -0,0 +1,156 @@
+// Package health contains the liveness and readiness checks of the client
+// served on the `/healthz` and `/readyz` endpoints for orchestrators.
+package health
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+
+	"github.com/ipfs/go-log"
+)
+
+var logger = log.Logger("keep-health")
+
+const (
+	// StatusOK is the status of a healthy component.
+	StatusOK = "ok"
+	// StatusFailing is the status of a component which is not healthy.
+	StatusFailing = "failing"
+)
+
+// Check determines the health of a single component of the client. It
+// returns details about the state of the component and an error if the
+// component is not healthy.
+type Check func() (details map[string]interface{}, err error)
+
+// CheckResult is the result of a single component check.
+type CheckResult struct {
+	Status  string                 `json:"status"`
+	Error   string                 `json:"error,omitempty"`
+	Details map[string]interface{} `json:"details,omitempty"`
+}
+
+// Report is the result of all checks of the given kind.
+type Report struct {
+	Status string                  `json:"status"`
+	Checks map[string]*CheckResult `json:"checks"`
+}
+
+// Registry holds liveness and readiness checks of the client components.
+type Registry struct {
+	mutex     sync.RWMutex
+	liveness  map[string]Check
+	readiness map[string]Check
+}
+
+// NewRegistry creates an empty checks registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		liveness:  make(map[string]Check),
+		readiness: make(map[string]Check),
+	}
+}
+
+// RegisterLivenessCheck registers the check determining whether the client
+// is alive. A failing liveness check means the client can not recover on its
+// own and should be restarted. Liveness checks are part of the readiness
+// report as well.
+func (r *Registry) RegisterLivenessCheck(name string, check Check) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.liveness[name] = check
+}
+
+// RegisterReadinessCheck registers the check determining whether the client
+// is ready to take part in the network. A failing readiness check means the
+// client is not fully operational yet or temporarily.
+func (r *Registry) RegisterReadinessCheck(name string, check Check) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.readiness[name] = check
+}
+
+// Liveness runs all liveness checks.
+func (r *Registry) Liveness() *Report {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	return runChecks(r.liveness)
+}
+
+// Readiness runs all liveness and readiness checks.
+func (r *Registry) Readiness() *Report {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	checks := make(map[string]Check, len(r.liveness)+len(r.readiness))
+	for name, check := range r.liveness {
+		checks[name] = check
+	}
+	for name, check := range r.readiness {
+		checks[name] = check
+	}
+
+	return runChecks(checks)
+}
+
+// EnableEndpoints exposes the liveness report on the `/healthz` path and the
+// readiness report on the `/readyz` path. Endpoints are served by the metrics
+// and the diagnostics servers, whichever are enabled. They respond with 200
+// if all checks pass and with 503 otherwise.
+func (r *Registry) EnableEndpoints() {
+	http.HandleFunc("/healthz", func(response http.ResponseWriter, _ *http.Request) {
+		writeReport(response, r.Liveness())
+	})
+
+	http.HandleFunc("/readyz", func(response http.ResponseWriter, _ *http.Request) {
+		writeReport(response, r.Readiness())
+	})
+}
+
+func runChecks(checks map[string]Check) *Report {
+	names := make([]string, 0, len(checks))
+	for name := range checks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	report := &Report{
+		Status: StatusOK,
+		Checks: make(map[string]*CheckResult, len(checks)),
+	}
+
+	for _, name := range names {
+		details, err := checks[name]()
+
+		result := &CheckResult{
+			Status:  StatusOK,
+			Details: details,
+		}
+		if err != nil {
+			result.Status = StatusFailing
+			result.Error = err.Error()
+			report.Status = StatusFailing
+		}
+
+		report.Checks[name] = result
+	}
+
+	return report
+}
+
+func writeReport(response http.ResponseWriter, report *Report) {
+	response.Header().Set("Content-Type", "application/json")
+
+	if report.Status != StatusOK {
+		response.WriteHeader(http.StatusServiceUnavailable)
+	}
+
+	if err := json.NewEncoder(response).Encode(report); err != nil {
+		logger.Errorf("could not write health report: [%v]", err)
+	}
+}
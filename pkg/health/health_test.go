@@ -0,0 +1,195 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	chainLocal "github.com/keep-network/keep-core/pkg/chain/local"
+)
+
+func TestRegistryReports(t *testing.T) {
+	passing := func() (map[string]interface{}, error) {
+		return map[string]interface{}{"value": 1}, nil
+	}
+	failing := func() (map[string]interface{}, error) {
+		return nil, fmt.Errorf("component is broken")
+	}
+
+	var tests = map[string]struct {
+		liveness          Check
+		readiness         Check
+		expectedLiveness  string
+		expectedReadiness string
+	}{
+		"all checks pass": {
+			liveness:          passing,
+			readiness:         passing,
+			expectedLiveness:  StatusOK,
+			expectedReadiness: StatusOK,
+		},
+		"readiness check fails": {
+			liveness:          passing,
+			readiness:         failing,
+			expectedLiveness:  StatusOK,
+			expectedReadiness: StatusFailing,
+		},
+		"liveness check fails": {
+			liveness:          failing,
+			readiness:         passing,
+			expectedLiveness:  StatusFailing,
+			expectedReadiness: StatusFailing,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			registry := NewRegistry()
+			registry.RegisterLivenessCheck("live", test.liveness)
+			registry.RegisterReadinessCheck("ready", test.readiness)
+
+			liveness := registry.Liveness()
+			if liveness.Status != test.expectedLiveness {
+				t.Errorf(
+					"unexpected liveness status\nexpected: [%v]\nactual:   [%v]",
+					test.expectedLiveness,
+					liveness.Status,
+				)
+			}
+			if len(liveness.Checks) != 1 {
+				t.Errorf(
+					"unexpected number of liveness checks\n"+
+						"expected: [%v]\nactual:   [%v]",
+					1,
+					len(liveness.Checks),
+				)
+			}
+
+			readiness := registry.Readiness()
+			if readiness.Status != test.expectedReadiness {
+				t.Errorf(
+					"unexpected readiness status\nexpected: [%v]\nactual:   [%v]",
+					test.expectedReadiness,
+					readiness.Status,
+				)
+			}
+			if len(readiness.Checks) != 2 {
+				t.Errorf(
+					"unexpected number of readiness checks\n"+
+						"expected: [%v]\nactual:   [%v]",
+					2,
+					len(readiness.Checks),
+				)
+			}
+		})
+	}
+}
+
+func TestWriteReport(t *testing.T) {
+	registry := NewRegistry()
+	registry.RegisterReadinessCheck(
+		"component",
+		func() (map[string]interface{}, error) {
+			return map[string]interface{}{"peers": 0}, fmt.Errorf("no peers")
+		},
+	)
+
+	recorder := httptest.NewRecorder()
+	writeReport(recorder, registry.Readiness())
+
+	if recorder.Code != http.StatusServiceUnavailable {
+		t.Errorf(
+			"unexpected status code\nexpected: [%v]\nactual:   [%v]",
+			http.StatusServiceUnavailable,
+			recorder.Code,
+		)
+	}
+
+	report := &Report{}
+	if err := json.Unmarshal(recorder.Body.Bytes(), report); err != nil {
+		t.Fatal(err)
+	}
+
+	result, ok := report.Checks["component"]
+	if !ok {
+		t.Fatalf("component check is missing in the report")
+	}
+	if result.Status != StatusFailing || result.Error != "no peers" {
+		t.Errorf("unexpected component check result: [%+v]", result)
+	}
+	if result.Details["peers"] != float64(0) {
+		t.Errorf("unexpected component check details: [%v]", result.Details)
+	}
+
+	recorder = httptest.NewRecorder()
+	writeReport(recorder, registry.Liveness())
+
+	if recorder.Code != http.StatusOK {
+		t.Errorf(
+			"unexpected status code\nexpected: [%v]\nactual:   [%v]",
+			http.StatusOK,
+			recorder.Code,
+		)
+	}
+}
+
+func TestBlockCounterCheck(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	blockCounter, err := chainLocal.BlockCounter()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	check := BlockCounterCheck(ctx, blockCounter, 2*time.Second)
+
+	if _, err := check(); err != nil {
+		t.Errorf("unexpected error: [%v]", err)
+	}
+
+	// No new blocks are observed once the context is done.
+	cancel()
+	time.Sleep(3 * time.Second)
+
+	if _, err := check(); err == nil {
+		t.Errorf("expected the check to fail")
+	}
+}
+
+func TestCached(t *testing.T) {
+	calls := 0
+	check := Cached(
+		func() (map[string]interface{}, error) {
+			calls++
+			return nil, nil
+		},
+		100*time.Millisecond,
+	)
+
+	check()
+	check()
+
+	if calls != 1 {
+		t.Errorf(
+			"unexpected number of calls\nexpected: [%v]\nactual:   [%v]",
+			1,
+			calls,
+		)
+	}
+
+	time.Sleep(150 * time.Millisecond)
+	check()
+
+	if calls != 2 {
+		t.Errorf(
+			"unexpected number of calls\nexpected: [%v]\nactual:   [%v]",
+			2,
+			calls,
+		)
+	}
+}
@@ -32,6 +32,23 @@ func AssertSuccessfulSignersCount(
 	}
 }
 
+// AssertMinimumSuccessfulSignersCount checks there were at least the given
+// number of successful signers. It is meant for tests in which some members
+// may legitimately fail, e.g. because of simulated network conditions.
+func AssertMinimumSuccessfulSignersCount(
+	t *testing.T,
+	testResult *Result,
+	minimumCount int,
+) {
+	if len(testResult.signers) < minimumCount {
+		t.Errorf(
+			"not enough successful signers\nexpected at least: [%v]\nactual:            [%v]",
+			minimumCount,
+			len(testResult.signers),
+		)
+	}
+}
+
 // AssertSuccessfulSigners checks which particular signers were successful.
 func AssertSuccessfulSigners(
 	t *testing.T,
@@ -12,6 +12,7 @@ import (
 	"testing"
 	"time"
 
+	commonLocal "github.com/keep-network/keep-common/pkg/chain/local"
 	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/dkg"
 	dkgResult "github.com/keep-network/keep-core/pkg/beacon/relay/dkg/result"
@@ -20,8 +21,10 @@ import (
 	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
 	chainLocal "github.com/keep-network/keep-core/pkg/chain/local"
 	"github.com/keep-network/keep-core/pkg/internal/interception"
+	"github.com/keep-network/keep-core/pkg/net"
 	"github.com/keep-network/keep-core/pkg/net/key"
 	netLocal "github.com/keep-network/keep-core/pkg/net/local"
+	"github.com/keep-network/keep-core/pkg/net/reputation"
 	"github.com/keep-network/keep-core/pkg/operator"
 )
 
@@ -54,6 +57,32 @@ func RandomSeed(t *testing.T) *big.Int {
 	return seed
 }
 
+// LinkModels returns the link model of the network provider of the member
+// with the given index. Transport IDs of providers of all members, ordered
+// by member index, let the model describe links with specific members and
+// partitions from them.
+type LinkModels func(
+	memberIndex group.MemberIndex,
+	memberIDs []string,
+) netLocal.LinkModel
+
+// SameLinkModel returns link models applying the given model to the network
+// providers of all members. If the model has a seed set, every member gets
+// a different seed derived from it so that links of members are independent
+// but the simulation is still reproducible.
+func SameLinkModel(model netLocal.LinkModel) LinkModels {
+	return func(
+		memberIndex group.MemberIndex,
+		memberIDs []string,
+	) netLocal.LinkModel {
+		memberModel := model
+		if memberModel.Seed != 0 {
+			memberModel.Seed += int64(memberIndex)
+		}
+		return memberModel
+	}
+}
+
 // RunTest executes the full DKG roundrip test for the provided group size,
 // seed, and honest threshold. The provided interception rules are applied in
 // the broadcast channel for the time of DKG execution.
@@ -63,18 +92,35 @@ func RunTest(
 	seed *big.Int,
 	rules interception.Rules,
 ) (*Result, error) {
-	privateKey, publicKey, err := operator.GenerateKeyPair()
+	return runTest(groupSize, honestThreshold, seed, rules, nil)
+}
+
+// RunTestUnderNetworkConditions executes the full DKG roundtrip test just like
+// RunTest but every member receives broadcast messages of other members
+// through a network link simulating the conditions described by the link
+// model returned for that member.
+func RunTestUnderNetworkConditions(
+	groupSize int,
+	honestThreshold int,
+	seed *big.Int,
+	rules interception.Rules,
+	linkModels LinkModels,
+) (*Result, error) {
+	return runTest(groupSize, honestThreshold, seed, rules, linkModels)
+}
+
+func runTest(
+	groupSize int,
+	honestThreshold int,
+	seed *big.Int,
+	rules interception.Rules,
+	linkModels LinkModels,
+) (*Result, error) {
+	privateKey, _, err := operator.GenerateKeyPair()
 	if err != nil {
 		return nil, err
 	}
 
-	_, networkPublicKey := key.OperatorKeyToNetworkKey(privateKey, publicKey)
-
-	network := interception.NewNetwork(
-		netLocal.ConnectWithKey(networkPublicKey),
-		rules,
-	)
-
 	chain := chainLocal.ConnectWithKey(
 		groupSize,
 		honestThreshold,
@@ -82,22 +128,67 @@ func RunTest(
 		privateKey,
 	)
 
-	address := chain.Signing().PublicKeyBytesToAddress(
-		key.Marshal(networkPublicKey),
-	)
+	// Every member has its own operator key and network provider so that
+	// messages of other members go through the member's network link and
+	// link models can single out members.
+	memberPrivateKeys := make([]*operator.PrivateKey, groupSize)
+	memberNetworkKeys := make([]*key.NetworkPublic, groupSize)
+	memberIDs := make([]string, groupSize)
+	for i := range memberPrivateKeys {
+		memberPrivateKey, memberPublicKey, err := operator.GenerateKeyPair()
+		if err != nil {
+			return nil, err
+		}
 
+		_, networkPublicKey := key.OperatorKeyToNetworkKey(
+			memberPrivateKey,
+			memberPublicKey,
+		)
+
+		memberPrivateKeys[i] = memberPrivateKey
+		memberNetworkKeys[i] = networkPublicKey
+		memberIDs[i] = netLocal.TransportID(networkPublicKey)
+	}
+
+	members := make([]*member, groupSize)
 	selectedStakers := make([]relaychain.StakerAddress, groupSize)
-	for i := range selectedStakers {
-		selectedStakers[i] = address
+	for i := range members {
+		memberIndex := group.MemberIndex(i + 1)
+
+		var provider netLocal.Provider
+		if linkModels != nil {
+			provider = netLocal.ConnectWithLinkModel(
+				memberNetworkKeys[i],
+				linkModels(memberIndex, memberIDs),
+			)
+		} else {
+			provider = netLocal.ConnectWithKey(memberNetworkKeys[i])
+		}
+
+		signing := commonLocal.NewSigner(memberPrivateKeys[i])
+
+		members[i] = &member{
+			network: interception.NewNetwork(provider, rules),
+			signing: signing,
+		}
+		selectedStakers[i] = signing.PublicKeyBytesToAddress(
+			key.Marshal(memberNetworkKeys[i]),
+		)
 	}
 
-	return executeDKG(seed, chain, network, selectedStakers)
+	return executeDKG(seed, chain, members, selectedStakers)
+}
+
+// member is the network and the signing of a single group member.
+type member struct {
+	network interception.Network
+	signing *commonLocal.Signer
 }
 
 func executeDKG(
 	seed *big.Int,
 	chain chainLocal.Chain,
-	network interception.Network,
+	members []*member,
 	selectedStakers []relaychain.StakerAddress,
 ) (*Result, error) {
 	relayConfig := chain.ThresholdRelay().GetConfig()
@@ -107,9 +198,20 @@ func executeDKG(
 		return nil, err
 	}
 
-	broadcastChannel, err := network.BroadcastChannelFor(fmt.Sprintf("dkg-test-%v", seed))
-	if err != nil {
-		return nil, err
+	broadcastChannels := make([]net.BroadcastChannel, relayConfig.GroupSize)
+	for i := range broadcastChannels {
+		broadcastChannel, err := members[i].network.BroadcastChannelFor(
+			fmt.Sprintf("dkg-test-%v", seed),
+		)
+		if err != nil {
+			return nil, err
+		}
+		defer broadcastChannel.Close()
+
+		gjkr.RegisterUnmarshallers(broadcastChannel)
+		dkgResult.RegisterUnmarshallers(broadcastChannel)
+
+		broadcastChannels[i] = broadcastChannel
 	}
 
 	resultSubmissionChan := make(chan *event.DKGResultSubmission)
@@ -136,18 +238,18 @@ func executeDKG(
 	// make sure all members are up.
 	startBlockHeight := currentBlockHeight + 3
 
-	gjkr.RegisterUnmarshallers(broadcastChannel)
-	dkgResult.RegisterUnmarshallers(broadcastChannel)
-
 	membershipValidator := group.NewStakersMembershipValidator(
 		selectedStakers,
 		chain.Signing(),
 	)
 
+	peerReputation := reputation.NewService()
+
 	for i := 0; i < relayConfig.GroupSize; i++ {
 		i := i // capture for goroutine
 		go func() {
 			signer, err := dkg.ExecuteDKG(
+				context.Background(),
 				seed,
 				uint8(i),
 				relayConfig.GroupSize,
@@ -156,8 +258,9 @@ func executeDKG(
 				startBlockHeight,
 				blockCounter,
 				chain.ThresholdRelay(),
-				chain.Signing(),
-				broadcastChannel,
+				members[i].signing,
+				broadcastChannels[i],
+				peerReputation,
 			)
 			if signer != nil {
 				signersMutex.Lock()
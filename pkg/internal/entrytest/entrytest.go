@@ -12,7 +12,9 @@ import (
 	"time"
 
 	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"github.com/keep-network/keep-core/pkg/internal/dkgtest"
 	"github.com/keep-network/keep-core/pkg/internal/interception"
+	"github.com/keep-network/keep-core/pkg/net"
 	"github.com/keep-network/keep-core/pkg/net/key"
 	"github.com/keep-network/keep-core/pkg/operator"
 
@@ -22,6 +24,7 @@ import (
 
 	chainLocal "github.com/keep-network/keep-core/pkg/chain/local"
 	netLocal "github.com/keep-network/keep-core/pkg/net/local"
+	"github.com/keep-network/keep-core/pkg/net/reputation"
 )
 
 var minimumStake = big.NewInt(20)
@@ -63,28 +66,75 @@ func RunTest(
 	rules interception.Rules,
 	previousEntry []byte,
 ) (*Result, error) {
-	privateKey, publicKey, err := operator.GenerateKeyPair()
+	return runTest(signers, threshold, rules, previousEntry, nil)
+}
+
+// RunTestUnderNetworkConditions executes the full relay entry signing
+// roundtrip test just like RunTest but every signer receives broadcast
+// messages of other signers through a network link simulating the conditions
+// described by the link model returned for that signer's member index.
+func RunTestUnderNetworkConditions(
+	signers []*dkg.ThresholdSigner,
+	threshold int,
+	rules interception.Rules,
+	previousEntry []byte,
+	linkModels dkgtest.LinkModels,
+) (*Result, error) {
+	return runTest(signers, threshold, rules, previousEntry, linkModels)
+}
+
+func runTest(
+	signers []*dkg.ThresholdSigner,
+	threshold int,
+	rules interception.Rules,
+	previousEntry []byte,
+	linkModels dkgtest.LinkModels,
+) (*Result, error) {
+	privateKey, _, err := operator.GenerateKeyPair()
 	if err != nil {
 		return nil, err
 	}
 
-	_, networkPublicKey := key.OperatorKeyToNetworkKey(privateKey, publicKey)
+	chain := chainLocal.ConnectWithKey(len(signers), threshold, minimumStake, privateKey)
 
-	network := interception.NewNetwork(
-		netLocal.ConnectWithKey(networkPublicKey),
-		rules,
-	)
+	// Every signer has its own network provider so that messages of other
+	// signers go through the signer's network link and link models can
+	// single out signers.
+	networkKeys := make([]*key.NetworkPublic, len(signers))
+	signerIDs := make([]string, len(signers))
+	for i := range networkKeys {
+		_, networkPublicKey, err := key.GenerateStaticNetworkKey()
+		if err != nil {
+			return nil, err
+		}
+
+		networkKeys[i] = networkPublicKey
+		signerIDs[i] = netLocal.TransportID(networkPublicKey)
+	}
 
-	chain := chainLocal.ConnectWithKey(len(signers), threshold, minimumStake, privateKey)
+	networks := make([]interception.Network, len(signers))
+	for i, signer := range signers {
+		var provider netLocal.Provider
+		if linkModels != nil {
+			provider = netLocal.ConnectWithLinkModel(
+				networkKeys[i],
+				linkModels(signer.MemberID(), signerIDs),
+			)
+		} else {
+			provider = netLocal.ConnectWithKey(networkKeys[i])
+		}
+
+		networks[i] = interception.NewNetwork(provider, rules)
+	}
 
-	return executeSigning(signers, threshold, chain, network, previousEntry)
+	return executeSigning(signers, threshold, chain, networks, previousEntry)
 }
 
 func executeSigning(
 	signers []*dkg.ThresholdSigner,
 	threshold int,
 	chain chainLocal.Chain,
-	network interception.Network,
+	networks []interception.Network,
 	previousEntry []byte,
 ) (*Result, error) {
 	blockCounter, err := chain.BlockCounter()
@@ -99,11 +149,20 @@ func executeSigning(
 	if err != nil {
 		return nil, err
 	}
-	broadcastChannel, err := network.BroadcastChannelFor(
-		fmt.Sprintf("entry-test-%v", randomSelector),
-	)
-	if err != nil {
-		return nil, err
+
+	broadcastChannels := make([]net.BroadcastChannel, len(signers))
+	for i := range broadcastChannels {
+		broadcastChannel, err := networks[i].BroadcastChannelFor(
+			fmt.Sprintf("entry-test-%v", randomSelector),
+		)
+		if err != nil {
+			return nil, err
+		}
+		defer broadcastChannel.Close()
+
+		entry.RegisterUnmarshallers(broadcastChannel)
+
+		broadcastChannels[i] = broadcastChannel
 	}
 
 	entrySubmissionChan := make(chan *event.EntrySubmitted)
@@ -127,14 +186,17 @@ func executeSigning(
 	// Wait for 3 blocks before starting signing to
 	// make sure all signers are ready
 	startBlockHeight := currentBlockHeight + 3
+	chain.StartRelayRequest(startBlockHeight)
 
-	entry.RegisterUnmarshallers(broadcastChannel)
+	peerReputation := reputation.NewService()
 
-	for _, signer := range signers {
-		go func(signer *dkg.ThresholdSigner) {
+	for i, signer := range signers {
+		go func(signer *dkg.ThresholdSigner, broadcastChannel net.BroadcastChannel) {
 			err := entry.SignAndSubmit(
+				context.Background(),
 				blockCounter,
 				broadcastChannel,
+				peerReputation,
 				chain.ThresholdRelay(),
 				previousEntry,
 				threshold,
@@ -148,7 +210,7 @@ func executeSigning(
 				signerFailuresMutex.Unlock()
 			}
 			wg.Done()
-		}(signer)
+		}(signer, broadcastChannels[i])
 	}
 	wg.Wait()
 
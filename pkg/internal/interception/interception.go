@@ -56,14 +56,18 @@ func (c *channel) Name() string {
 	return c.delegate.Name()
 }
 
-func (c *channel) Send(ctx context.Context, m net.TaggedMarshaler) error {
+func (c *channel) Send(
+	ctx context.Context,
+	m net.TaggedMarshaler,
+	strategy ...net.RetransmissionStrategy,
+) error {
 	altered := c.rules(m)
 	if altered == nil {
 		// drop the message
 		return nil
 	}
 
-	return c.delegate.Send(ctx, c.rules(m))
+	return c.delegate.Send(ctx, c.rules(m), strategy...)
 }
 
 func (c *channel) Recv(ctx context.Context, handler func(m net.Message)) {
@@ -77,3 +81,14 @@ func (c *channel) SetUnmarshaler(unmarshaler func() net.TaggedUnmarshaler) {
 func (c *channel) SetFilter(filter net.BroadcastChannelFilter) error {
 	return nil // no-op
 }
+
+func (c *channel) RequestMissedMessages(
+	ctx context.Context,
+	filter net.MissedMessagesFilter,
+) error {
+	return c.delegate.RequestMissedMessages(ctx, filter)
+}
+
+func (c *channel) Close() error {
+	return c.delegate.Close()
+}
@@ -0,0 +1,90 @@
+package logging
+
+import (
+	"context"
+	"sort"
+
+	"github.com/ipfs/go-log"
+)
+
+// Names of structured fields attached to log messages of protocol
+// executions.
+const (
+	// MemberField is the index of the group member executing the protocol.
+	MemberField = "member"
+	// GroupField is the public key of the group as registered on chain.
+	GroupField = "group"
+	// ChannelField is the name of the group's broadcast channel.
+	ChannelField = "channel"
+	// StateField is the state of the protocol state machine.
+	StateField = "state"
+	// BlockField is the block number the logged event relates to.
+	BlockField = "block"
+	// ClientField is the network identifier of the client.
+	ClientField = "client"
+	// PeerField is the network identifier of the remote peer.
+	PeerField = "peer"
+)
+
+// Fields are structured fields attached to log messages.
+type Fields map[string]interface{}
+
+type contextKey struct{}
+
+// WithFields returns a copy of the context with the given fields added to
+// the fields already in the context. Fields of the same name are replaced.
+func WithFields(ctx context.Context, fields Fields) context.Context {
+	merged := make(Fields)
+	for name, value := range FieldsFrom(ctx) {
+		merged[name] = value
+	}
+	for name, value := range fields {
+		merged[name] = value
+	}
+
+	return context.WithValue(ctx, contextKey{}, merged)
+}
+
+// FieldsFrom returns fields in the given context.
+func FieldsFrom(ctx context.Context) Fields {
+	if fields, ok := ctx.Value(contextKey{}).(Fields); ok {
+		return fields
+	}
+
+	return Fields{}
+}
+
+// ForContext returns the given logger annotated with fields in the context.
+// Fields are printed in a sorted order after the message and are top-level
+// keys of each entry in the JSON output format.
+func ForContext(
+	ctx context.Context,
+	logger *log.ZapEventLogger,
+) *log.ZapEventLogger {
+	return WithLogFields(logger, FieldsFrom(ctx))
+}
+
+// WithLogFields returns the given logger annotated with the given fields.
+func WithLogFields(
+	logger *log.ZapEventLogger,
+	fields Fields,
+) *log.ZapEventLogger {
+	if len(fields) == 0 {
+		return logger
+	}
+
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	keysAndValues := make([]interface{}, 0, 2*len(fields))
+	for _, name := range names {
+		keysAndValues = append(keysAndValues, name, fields[name])
+	}
+
+	return &log.ZapEventLogger{
+		SugaredLogger: *logger.With(keysAndValues...),
+	}
+}
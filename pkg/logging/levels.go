@@ -0,0 +1,72 @@
+package logging
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	log2 "github.com/ipfs/go-log/v2"
+	"go.uber.org/zap/zapcore"
+)
+
+// Levels returns the current log level of each logger of the client.
+func Levels() map[string]string {
+	levels := make(map[string]string)
+	for _, name := range log2.GetSubsystems() {
+		levels[name] = levelOf(name)
+	}
+
+	return levels
+}
+
+// levelOf returns the lowest level at which the logger with the given name
+// prints messages.
+func levelOf(name string) string {
+	core := log2.Logger(name).Desugar().Core()
+	for level := zapcore.DebugLevel; level < zapcore.FatalLevel; level++ {
+		if core.Enabled(level) {
+			return level.String()
+		}
+	}
+
+	return zapcore.FatalLevel.String()
+}
+
+// SetLevel changes the log level of the given logger on a running client.
+// The logger name can be `*` to change the level of all loggers or end with
+// `*` to change the level of all loggers whose names start with the given
+// prefix, e.g. `keep*`. It returns names of the loggers whose levels were
+// changed.
+func SetLevel(name string, level string) ([]string, error) {
+	if _, err := log2.LevelFromString(level); err != nil {
+		return nil, fmt.Errorf("invalid log level [%v]: [%v]", level, err)
+	}
+
+	prefix := strings.TrimSuffix(name, "*")
+	isPattern := prefix != name
+
+	var changed []string
+	for _, subsystem := range log2.GetSubsystems() {
+		if subsystem != name && !(isPattern && strings.HasPrefix(subsystem, prefix)) {
+			continue
+		}
+
+		if err := log2.SetLogLevel(subsystem, level); err != nil {
+			return changed, fmt.Errorf(
+				"could not set level of logger [%v]: [%v]",
+				subsystem,
+				err,
+			)
+		}
+
+		changed = append(changed, subsystem)
+	}
+
+	if len(changed) == 0 {
+		return nil, fmt.Errorf("no logger matches [%v]", name)
+	}
+
+	sort.Strings(changed)
+
+	return changed, nil
+}
@@ -0,0 +1,62 @@
+// Package logging configures logging of the client: the output format, log
+// levels of loggers changed at runtime and structured fields attached to log
+// messages through the context of a protocol execution.
+package logging
+
+import (
+	"fmt"
+
+	log2 "github.com/ipfs/go-log/v2"
+	"github.com/keep-network/keep-common/pkg/logging"
+)
+
+// Supported log output formats.
+const (
+	// FormatColor prints human-readable, colorized log lines. It is the
+	// default format.
+	FormatColor = "color"
+	// FormatText prints human-readable log lines without colors.
+	FormatText = "text"
+	// FormatJSON prints each log entry as a single JSON object. Structured
+	// fields of the entry are top-level keys of the object.
+	FormatJSON = "json"
+)
+
+// Configure sets up the log output format and log levels. The format is one
+// of FormatColor, FormatText and FormatJSON; if empty, the output is left
+// as configured by the go-log environment variables, colorized by default.
+// Level directives are a space-delimited set of directives as accepted by the
+// keep-common logging package, e.g. `keep*=info keep-relay=debug`.
+func Configure(levelDirectives string, format string) error {
+	if format == "" {
+		return logging.Configure(levelDirectives)
+	}
+
+	var outputFormat log2.LogFormat
+	switch format {
+	case FormatColor:
+		outputFormat = log2.ColorizedOutput
+	case FormatText:
+		outputFormat = log2.PlaintextOutput
+	case FormatJSON:
+		outputFormat = log2.JSONOutput
+	default:
+		return fmt.Errorf(
+			"unsupported log format [%v]; use one of [%v], [%v] or [%v]",
+			format,
+			FormatColor,
+			FormatText,
+			FormatJSON,
+		)
+	}
+
+	// Setting up the output resets levels of all loggers, so it has to be
+	// done before the level directives are evaluated.
+	log2.SetupLogging(log2.Config{
+		Format: outputFormat,
+		Level:  log2.LevelError,
+		Stderr: true,
+	})
+
+	return logging.Configure(levelDirectives)
+}
@@ -0,0 +1,120 @@
+package logging
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/ipfs/go-log"
+)
+
+func TestWithFields(t *testing.T) {
+	ctx := WithFields(
+		context.Background(),
+		Fields{MemberField: 1, ChannelField: "channel-1"},
+	)
+	childCtx := WithFields(ctx, Fields{ChannelField: "channel-2", BlockField: 10})
+
+	expectedParentFields := Fields{MemberField: 1, ChannelField: "channel-1"}
+	if !reflect.DeepEqual(expectedParentFields, FieldsFrom(ctx)) {
+		t.Errorf(
+			"unexpected parent fields\nexpected: [%v]\nactual:   [%v]",
+			expectedParentFields,
+			FieldsFrom(ctx),
+		)
+	}
+
+	expectedChildFields := Fields{
+		MemberField:  1,
+		ChannelField: "channel-2",
+		BlockField:   10,
+	}
+	if !reflect.DeepEqual(expectedChildFields, FieldsFrom(childCtx)) {
+		t.Errorf(
+			"unexpected child fields\nexpected: [%v]\nactual:   [%v]",
+			expectedChildFields,
+			FieldsFrom(childCtx),
+		)
+	}
+
+	if len(FieldsFrom(context.Background())) != 0 {
+		t.Errorf("expected no fields in an empty context")
+	}
+}
+
+func TestSetLevel(t *testing.T) {
+	log.Logger("keep-test-first")
+	log.Logger("keep-test-second")
+	log.Logger("other-test")
+
+	if _, err := SetLevel("*", "error"); err != nil {
+		t.Fatal(err)
+	}
+
+	var tests = map[string]struct {
+		name            string
+		level           string
+		expectedChanged []string
+		expectedError   bool
+	}{
+		"single logger": {
+			name:            "keep-test-first",
+			level:           "debug",
+			expectedChanged: []string{"keep-test-first"},
+		},
+		"loggers with prefix": {
+			name:            "keep-test*",
+			level:           "warn",
+			expectedChanged: []string{"keep-test-first", "keep-test-second"},
+		},
+		"unknown logger": {
+			name:          "keep-test-unknown",
+			level:         "debug",
+			expectedError: true,
+		},
+		"invalid level": {
+			name:          "keep-test-first",
+			level:         "verbose",
+			expectedError: true,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			changed, err := SetLevel(test.name, test.level)
+			if test.expectedError {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(test.expectedChanged, changed) {
+				t.Errorf(
+					"unexpected changed loggers\nexpected: [%v]\nactual:   [%v]",
+					test.expectedChanged,
+					changed,
+				)
+			}
+
+			levels := Levels()
+			for _, name := range test.expectedChanged {
+				if levels[name] != test.level {
+					t.Errorf(
+						"unexpected level of logger [%v]\n"+
+							"expected: [%v]\nactual:   [%v]",
+						name,
+						test.level,
+						levels[name],
+					)
+				}
+			}
+			if levels["other-test"] != "error" {
+				t.Errorf("expected unchanged level of other loggers")
+			}
+		})
+	}
+}
@@ -6,7 +6,9 @@ import (
 
 	"github.com/ipfs/go-log"
 	"github.com/keep-network/keep-common/pkg/metrics"
+	"github.com/keep-network/keep-core/pkg/beacon"
 	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/chain/watchdog"
 	"github.com/keep-network/keep-core/pkg/net"
 )
 
@@ -24,12 +26,12 @@ const (
 // Initialize set up the metrics registry and enables metrics server.
 func Initialize(
 	port int,
-) (*metrics.Registry, bool) {
+) (*Registry, bool) {
 	if port == 0 {
 		return nil, false
 	}
 
-	registry := metrics.NewRegistry()
+	registry := NewRegistry()
 
 	registry.EnableServer(port)
 
@@ -40,7 +42,7 @@ func Initialize(
 // connected_peers_count metric.
 func ObserveConnectedPeersCount(
 	ctx context.Context,
-	registry *metrics.Registry,
+	registry *Registry,
 	netProvider net.Provider,
 	tick time.Duration,
 ) {
@@ -62,7 +64,7 @@ func ObserveConnectedPeersCount(
 // connected_bootstrap_count metric.
 func ObserveConnectedBootstrapCount(
 	ctx context.Context,
-	registry *metrics.Registry,
+	registry *Registry,
 	netProvider net.Provider,
 	bootstraps []string,
 	tick time.Duration,
@@ -92,7 +94,7 @@ func ObserveConnectedBootstrapCount(
 // eth_connectivity metric.
 func ObserveEthConnectivity(
 	ctx context.Context,
-	registry *metrics.Registry,
+	registry *Registry,
 	stakeMonitor chain.StakeMonitor,
 	address string,
 	tick time.Duration,
@@ -116,14 +118,101 @@ func ObserveEthConnectivity(
 	)
 }
 
+// ObserveOperator triggers an observation process of the metrics of the given
+// operator run by the client: the number of groups the operator is a member
+// of, the number of group selections the operator takes part in, and the
+// number of in-flight DKG and relay entry signing executions. Metrics of all
+// operators have the same names and are told apart by the operator label.
+func ObserveOperator(
+	ctx context.Context,
+	registry *Registry,
+	operatorAddress string,
+	randomBeacon *beacon.Beacon,
+	tick time.Duration,
+) {
+	operatorLabel := NewLabel("operator", operatorAddress)
+	tick = validateTick(tick, DefaultNetworkMetricsTick)
+
+	observe(
+		ctx,
+		"groups_count",
+		func() float64 { return float64(randomBeacon.GroupCount()) },
+		registry,
+		tick,
+		operatorLabel,
+	)
+
+	observe(
+		ctx,
+		"group_selections_count",
+		func() float64 { return float64(randomBeacon.GroupSelectionsCount()) },
+		registry,
+		tick,
+		operatorLabel,
+	)
+
+	observe(
+		ctx,
+		"in_flight_protocols_count",
+		func() float64 { return float64(randomBeacon.InFlightProtocols()) },
+		registry,
+		tick,
+		operatorLabel,
+	)
+}
+
+// ObserveChainWatchdog triggers an observation process of the chain_stalled,
+// chain_stalls_count and latest_block_age_seconds metrics.
+func ObserveChainWatchdog(
+	ctx context.Context,
+	registry *Registry,
+	chainWatchdog *watchdog.Watchdog,
+	tick time.Duration,
+) {
+	tick = validateTick(tick, DefaultNetworkMetricsTick)
+
+	observe(
+		ctx,
+		"chain_stalled",
+		func() float64 {
+			if chainWatchdog.Status().Stalled {
+				return 1
+			}
+
+			return 0
+		},
+		registry,
+		tick,
+	)
+
+	observe(
+		ctx,
+		"chain_stalls_count",
+		func() float64 { return float64(chainWatchdog.Status().Stalls) },
+		registry,
+		tick,
+	)
+
+	observe(
+		ctx,
+		"latest_block_age_seconds",
+		func() float64 {
+			return chainWatchdog.Status().LatestBlockAge.Seconds()
+		},
+		registry,
+		tick,
+	)
+}
+
 func observe(
 	ctx context.Context,
 	name string,
 	input metrics.ObserverInput,
-	registry *metrics.Registry,
+	registry *Registry,
 	tick time.Duration,
+	labels ...Label,
 ) {
-	observer, err := registry.NewGaugeObserver(name, input)
+	observer, err := registry.NewGaugeObserver(name, input, labels...)
 	if err != nil {
 		logger.Warningf("could not create gauge observer [%v]", name)
 		return
@@ -0,0 +1,277 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/keep-network/keep-common/pkg/metrics"
+)
+
+// Registry performs all management of metrics exposed by the metrics server.
+// It mirrors the registry of the keep-common metrics package, with the same
+// constructors, metric types and exposition format, and differs only in how
+// metrics are identified: the keep-common registry identifies them by name
+// while this one identifies them by name and labels. This way, metrics of all
+// operators run by the client have stable names and are told apart by the
+// operator label. The keep-common registry keeps its metrics and exposition
+// unexported, so it can not be extended with such metrics; this registry
+// should be replaced with it once it identifies metrics by labels as well.
+type Registry struct {
+	metricsMutex sync.RWMutex
+	metrics      map[string][]metric
+}
+
+type metric interface {
+	labels() string
+	expose(name string) string
+}
+
+// Label represents an arbitrary information attached to the metrics.
+type Label struct {
+	name  string
+	value string
+}
+
+// NewLabel creates a new label using the given name and value.
+func NewLabel(name, value string) Label {
+	return Label{name, value}
+}
+
+// labelValueEscaper escapes label values as required by the Prometheus text
+// exposition format.
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// NewRegistry creates a new metrics registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		metrics: make(map[string][]metric),
+	}
+}
+
+// EnableServer enables the metrics server on the given port. Data will
+// be exposed on `/metrics` path of the default HTTP mux.
+func (r *Registry) EnableServer(port int) {
+	server := &http.Server{Addr: ":" + strconv.Itoa(port)}
+
+	http.HandleFunc("/metrics", func(response http.ResponseWriter, _ *http.Request) {
+		if _, err := io.WriteString(response, r.exposeMetrics()); err != nil {
+			logger.Errorf("could not write response: [%v]", err)
+		}
+	})
+
+	go func() {
+		if err := server.ListenAndServe(); err != http.ErrServerClosed {
+			logger.Errorf("metrics server error: [%v]", err)
+		}
+	}()
+}
+
+// NewGauge creates and registers a new gauge metric which will be exposed
+// through the metrics server. In case a metric with the same name and labels
+// already exists, an error will be returned.
+func (r *Registry) NewGauge(
+	name string,
+	labels ...Label,
+) (*Gauge, error) {
+	gauge := &Gauge{labelsString: processLabels(labels)}
+
+	if err := r.register(name, gauge); err != nil {
+		return nil, err
+	}
+
+	return gauge, nil
+}
+
+// NewGaugeObserver creates and registers a gauge just like `NewGauge` method
+// and wrap it with a ready to use observer of the provided input. This allows
+// to easily create self-refreshing metrics.
+func (r *Registry) NewGaugeObserver(
+	name string,
+	input metrics.ObserverInput,
+	labels ...Label,
+) (*Observer, error) {
+	gauge, err := r.NewGauge(name, labels...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Observer{
+		input:  input,
+		output: gauge,
+	}, nil
+}
+
+// NewInfo creates and registers a new info metric which will be exposed
+// through the metrics server. In case a metric with the same name and labels
+// already exists, an error will be returned.
+func (r *Registry) NewInfo(
+	name string,
+	labels []Label,
+) (*Info, error) {
+	labelsString := processLabels(labels)
+	if len(labelsString) == 0 {
+		return nil, fmt.Errorf("at least one label should be set")
+	}
+
+	info := &Info{labelsString: labelsString}
+
+	if err := r.register(name, info); err != nil {
+		return nil, err
+	}
+
+	return info, nil
+}
+
+func (r *Registry) register(name string, newMetric metric) error {
+	r.metricsMutex.Lock()
+	defer r.metricsMutex.Unlock()
+
+	for _, existingMetric := range r.metrics[name] {
+		if existingMetric.labels() == newMetric.labels() {
+			return fmt.Errorf(
+				"metric [%v] with labels [%v] already exists",
+				name,
+				newMetric.labels(),
+			)
+		}
+	}
+
+	r.metrics[name] = append(r.metrics[name], newMetric)
+
+	return nil
+}
+
+// Exposes all registered metrics in their text format. Metrics of the same
+// name are exposed together.
+func (r *Registry) exposeMetrics() string {
+	r.metricsMutex.RLock()
+	defer r.metricsMutex.RUnlock()
+
+	names := make([]string, 0, len(r.metrics))
+	for name := range r.metrics {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	exposed := make([]string, 0, len(names))
+	for _, name := range names {
+		lines := make([]string, 0, len(r.metrics[name])+1)
+		if _, isGauge := r.metrics[name][0].(*Gauge); isGauge {
+			lines = append(lines, fmt.Sprintf("# TYPE %v gauge", name))
+		}
+		for _, metric := range r.metrics[name] {
+			lines = append(lines, metric.expose(name))
+		}
+
+		exposed = append(exposed, strings.Join(lines, "\n"))
+	}
+
+	return strings.Join(exposed, "\n\n")
+}
+
+func processLabels(labels []Label) string {
+	labelsStrings := make([]string, 0, len(labels))
+	for _, label := range labels {
+		if label.name == "" || label.value == "" {
+			continue
+		}
+
+		labelsStrings = append(
+			labelsStrings,
+			fmt.Sprintf(
+				"%v=\"%v\"",
+				label.name,
+				labelValueEscaper.Replace(label.value),
+			),
+		)
+	}
+	sort.Strings(labelsStrings)
+
+	return strings.Join(labelsStrings, ",")
+}
+
+// Gauge is a metric type that represents a single numerical value that can
+// arbitrarily go up and down.
+type Gauge struct {
+	labelsString string
+
+	value     float64
+	timestamp int64 // timestamp expressed as milliseconds
+	mutex     sync.RWMutex
+}
+
+// Set allows setting the gauge to an arbitrary value.
+func (g *Gauge) Set(value float64) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	g.value = value
+	g.timestamp = time.Now().UnixNano() / 1e6
+}
+
+func (g *Gauge) labels() string {
+	return g.labelsString
+}
+
+// Exposes the gauge in the text-based exposition format.
+func (g *Gauge) expose(name string) string {
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+
+	labels := g.labelsString
+	if len(labels) > 0 {
+		labels = "{" + labels + "}"
+	}
+
+	return fmt.Sprintf("%v%v %v %v", name, labels, g.value, g.timestamp)
+}
+
+// Info is a metric type that represents a constant information
+// that cannot change in the time.
+type Info struct {
+	labelsString string
+}
+
+func (i *Info) labels() string {
+	return i.labelsString
+}
+
+// Exposes the info in the text-based exposition format.
+func (i *Info) expose(name string) string {
+	return fmt.Sprintf("%v{%v} %v", name, i.labelsString, "1")
+}
+
+// Observer represent a definition of a cyclic metric observation process.
+type Observer struct {
+	input  metrics.ObserverInput
+	output metrics.ObserverOutput
+}
+
+// Observe triggers a cyclic metric observation process.
+func (o *Observer) Observe(
+	ctx context.Context,
+	tick time.Duration,
+) {
+	go func() {
+		o.output.Set(o.input()) // execute the first check immediately
+
+		ticker := time.NewTicker(tick)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				o.output.Set(o.input())
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
@@ -0,0 +1,100 @@
+package metrics
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestRegistryExposesGaugesOfTheSameNameWithDifferentLabels(t *testing.T) {
+	registry := NewRegistry()
+
+	firstGauge, err := registry.NewGauge("groups_count", NewLabel("operator", "0xA"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	secondGauge, err := registry.NewGauge("groups_count", NewLabel("operator", "0xB"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherGauge, err := registry.NewGauge("chain_stalled")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	firstGauge.Set(1)
+	secondGauge.Set(2)
+	otherGauge.Set(0)
+
+	expectedExposition := regexp.MustCompile(
+		`^# TYPE chain_stalled gauge\n` +
+			`chain_stalled 0 \d+\n` +
+			`\n` +
+			`# TYPE groups_count gauge\n` +
+			`groups_count\{operator="0xA"\} 1 \d+\n` +
+			`groups_count\{operator="0xB"\} 2 \d+$`,
+	)
+
+	exposition := registry.exposeMetrics()
+	if !expectedExposition.MatchString(exposition) {
+		t.Errorf("unexpected exposition:\n%v", exposition)
+	}
+}
+
+func TestRegistryRejectsDuplicateGauge(t *testing.T) {
+	registry := NewRegistry()
+
+	if _, err := registry.NewGauge("groups_count", NewLabel("operator", "0xA")); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := registry.NewGauge("groups_count", NewLabel("operator", "0xA")); err == nil {
+		t.Fatal("expected an error for a duplicate gauge")
+	}
+}
+
+func TestRegistryEscapesLabelValues(t *testing.T) {
+	registry := NewRegistry()
+
+	if _, err := registry.NewGauge("info", NewLabel("value", "a\"b\\c\nd")); err != nil {
+		t.Fatal(err)
+	}
+
+	expectedExposition := regexp.MustCompile(`info\{value="a\\"b\\\\c\\nd"\} 0 0`)
+
+	exposition := registry.exposeMetrics()
+	if !expectedExposition.MatchString(exposition) {
+		t.Errorf("unexpected exposition:\n%v", exposition)
+	}
+}
+
+func TestRegistryExposesInfoOfTheSameNameWithDifferentLabels(t *testing.T) {
+	registry := NewRegistry()
+
+	if _, err := registry.NewInfo(
+		"operator_info",
+		[]Label{NewLabel("operator", "0xA")},
+	); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := registry.NewInfo(
+		"operator_info",
+		[]Label{NewLabel("operator", "0xB")},
+	); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := registry.NewInfo("empty_info", []Label{}); err == nil {
+		t.Fatal("expected an error for an info without labels")
+	}
+
+	expectedExposition := "operator_info{operator=\"0xA\"} 1\n" +
+		"operator_info{operator=\"0xB\"} 1"
+
+	exposition := registry.exposeMetrics()
+	if exposition != expectedExposition {
+		t.Errorf(
+			"unexpected exposition\nexpected: [%v]\nactual:   [%v]",
+			expectedExposition,
+			exposition,
+		)
+	}
+}
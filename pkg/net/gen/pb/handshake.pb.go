@@ -100,6 +100,15 @@ type Act1Message struct {
 	Nonce []byte `protobuf:"bytes,1,opt,name=nonce,proto3" json:"nonce,omitempty"`
 	// the identifier of the protocol the initiator is executing
 	Protocol string `protobuf:"bytes,2,opt,name=protocol,proto3" json:"protocol,omitempty"`
+	// marshaled certificate binding initiator's network key to its operator
+	// key; empty if the network key is the operator key
+	Certificate []byte `protobuf:"bytes,3,opt,name=certificate,proto3" json:"certificate,omitempty"`
+	// capabilities of the initiator; missing if the initiator does not
+	// support capability negotiation
+	Capabilities *Capabilities `protobuf:"bytes,4,opt,name=capabilities,proto3" json:"capabilities,omitempty"`
+	// marshaled certificates binding initiator's network key to the keys of
+	// additional operators run by the initiator
+	OperatorCertificates [][]byte `protobuf:"bytes,5,rep,name=operatorCertificates,proto3" json:"operatorCertificates,omitempty"`
 }
 
 func (m *Act1Message) Reset()      { *m = Act1Message{} }
@@ -148,6 +157,27 @@ func (m *Act1Message) GetProtocol() string {
 	return ""
 }
 
+func (m *Act1Message) GetCertificate() []byte {
+	if m != nil {
+		return m.Certificate
+	}
+	return nil
+}
+
+func (m *Act1Message) GetCapabilities() *Capabilities {
+	if m != nil {
+		return m.Capabilities
+	}
+	return nil
+}
+
+func (m *Act1Message) GetOperatorCertificates() [][]byte {
+	if m != nil {
+		return m.OperatorCertificates
+	}
+	return nil
+}
+
 // Act2Message is sent in the second handshake act by the responder to the
 // initiator. It contains randomly generated `nonce2`, an 8-byte unsigned
 // integer and `challenge` which is a result of SHA256 on the concatenated
@@ -161,6 +191,15 @@ type Act2Message struct {
 	Challenge []byte `protobuf:"bytes,2,opt,name=challenge,proto3" json:"challenge,omitempty"`
 	// the identifier of the protocol the responder is executing
 	Protocol string `protobuf:"bytes,3,opt,name=protocol,proto3" json:"protocol,omitempty"`
+	// marshaled certificate binding responder's network key to its operator
+	// key; empty if the network key is the operator key
+	Certificate []byte `protobuf:"bytes,4,opt,name=certificate,proto3" json:"certificate,omitempty"`
+	// capabilities of the responder; missing if the responder does not
+	// support capability negotiation
+	Capabilities *Capabilities `protobuf:"bytes,5,opt,name=capabilities,proto3" json:"capabilities,omitempty"`
+	// marshaled certificates binding responder's network key to the keys of
+	// additional operators run by the responder
+	OperatorCertificates [][]byte `protobuf:"bytes,6,rep,name=operatorCertificates,proto3" json:"operatorCertificates,omitempty"`
 }
 
 func (m *Act2Message) Reset()      { *m = Act2Message{} }
@@ -216,6 +255,27 @@ func (m *Act2Message) GetProtocol() string {
 	return ""
 }
 
+func (m *Act2Message) GetCertificate() []byte {
+	if m != nil {
+		return m.Certificate
+	}
+	return nil
+}
+
+func (m *Act2Message) GetCapabilities() *Capabilities {
+	if m != nil {
+		return m.Capabilities
+	}
+	return nil
+}
+
+func (m *Act2Message) GetOperatorCertificates() [][]byte {
+	if m != nil {
+		return m.OperatorCertificates
+	}
+	return nil
+}
+
 // Act1Message is sent in the first handshake act by the initiator to the
 // responder. It contains randomly generated `nonce1`, an 8-byte (64-bit)
 // unsigned integer.
@@ -265,34 +325,135 @@ func (m *Act3Message) GetChallenge() []byte {
 	return nil
 }
 
+// Capabilities describes the client version, the ranges of supported protocol
+// and message versions, and the optional features of a handshake party.
+type Capabilities struct {
+	// version of the client software
+	ClientVersion string `protobuf:"bytes,1,opt,name=clientVersion,proto3" json:"clientVersion,omitempty"`
+	// the lowest and the highest supported network protocol version
+	MinProtocolVersion uint32 `protobuf:"varint,2,opt,name=minProtocolVersion,proto3" json:"minProtocolVersion,omitempty"`
+	MaxProtocolVersion uint32 `protobuf:"varint,3,opt,name=maxProtocolVersion,proto3" json:"maxProtocolVersion,omitempty"`
+	// the lowest and the highest supported protocol message version
+	MinMessageVersion uint32 `protobuf:"varint,4,opt,name=minMessageVersion,proto3" json:"minMessageVersion,omitempty"`
+	MaxMessageVersion uint32 `protobuf:"varint,5,opt,name=maxMessageVersion,proto3" json:"maxMessageVersion,omitempty"`
+	// identifiers of supported optional features
+	Features []string `protobuf:"bytes,6,rep,name=features,proto3" json:"features,omitempty"`
+}
+
+func (m *Capabilities) Reset()      { *m = Capabilities{} }
+func (*Capabilities) ProtoMessage() {}
+func (*Capabilities) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73dffe19bde0f856, []int{4}
+}
+func (m *Capabilities) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Capabilities) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Capabilities.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Capabilities) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Capabilities.Merge(m, src)
+}
+func (m *Capabilities) XXX_Size() int {
+	return m.Size()
+}
+func (m *Capabilities) XXX_DiscardUnknown() {
+	xxx_messageInfo_Capabilities.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Capabilities proto.InternalMessageInfo
+
+func (m *Capabilities) GetClientVersion() string {
+	if m != nil {
+		return m.ClientVersion
+	}
+	return ""
+}
+
+func (m *Capabilities) GetMinProtocolVersion() uint32 {
+	if m != nil {
+		return m.MinProtocolVersion
+	}
+	return 0
+}
+
+func (m *Capabilities) GetMaxProtocolVersion() uint32 {
+	if m != nil {
+		return m.MaxProtocolVersion
+	}
+	return 0
+}
+
+func (m *Capabilities) GetMinMessageVersion() uint32 {
+	if m != nil {
+		return m.MinMessageVersion
+	}
+	return 0
+}
+
+func (m *Capabilities) GetMaxMessageVersion() uint32 {
+	if m != nil {
+		return m.MaxMessageVersion
+	}
+	return 0
+}
+
+func (m *Capabilities) GetFeatures() []string {
+	if m != nil {
+		return m.Features
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*HandshakeEnvelope)(nil), "net.HandshakeEnvelope")
 	proto.RegisterType((*Act1Message)(nil), "net.Act1Message")
 	proto.RegisterType((*Act2Message)(nil), "net.Act2Message")
 	proto.RegisterType((*Act3Message)(nil), "net.Act3Message")
+	proto.RegisterType((*Capabilities)(nil), "net.Capabilities")
 }
 
 func init() { proto.RegisterFile("pb/handshake.proto", fileDescriptor_73dffe19bde0f856) }
 
 var fileDescriptor_73dffe19bde0f856 = []byte{
-	// 262 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x12, 0x2a, 0x48, 0xd2, 0xcf,
-	0x48, 0xcc, 0x4b, 0x29, 0xce, 0x48, 0xcc, 0x4e, 0xd5, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62,
-	0xce, 0x4b, 0x2d, 0x51, 0x4a, 0xe6, 0x12, 0xf4, 0x80, 0x89, 0xbb, 0xe6, 0x95, 0xa5, 0xe6, 0xe4,
-	0x17, 0xa4, 0x0a, 0x49, 0x70, 0xb1, 0xe7, 0xa6, 0x16, 0x17, 0x27, 0xa6, 0xa7, 0x4a, 0x30, 0x2a,
-	0x30, 0x6a, 0xf0, 0x04, 0xc1, 0xb8, 0x42, 0x32, 0x5c, 0x9c, 0xc5, 0x99, 0xe9, 0x79, 0x89, 0x25,
-	0xa5, 0x45, 0xa9, 0x12, 0x4c, 0x60, 0x39, 0x84, 0x80, 0x90, 0x18, 0x17, 0x5b, 0x41, 0x6a, 0x6a,
-	0x91, 0xa7, 0x8b, 0x04, 0x33, 0x58, 0x0a, 0xca, 0x53, 0xb2, 0xe7, 0xe2, 0x76, 0x4c, 0x2e, 0x31,
-	0xf4, 0x85, 0x1a, 0x22, 0xc2, 0xc5, 0x9a, 0x97, 0x9f, 0x97, 0x0c, 0x33, 0x1c, 0xc2, 0x11, 0x92,
-	0xe2, 0xe2, 0x00, 0xbb, 0x2b, 0x39, 0x3f, 0x07, 0x6c, 0x32, 0x67, 0x10, 0x9c, 0xaf, 0x14, 0x0b,
-	0x36, 0xc0, 0x08, 0xbf, 0x01, 0x32, 0x5c, 0x9c, 0xc9, 0x19, 0x89, 0x39, 0x39, 0xa9, 0x79, 0xe9,
-	0x70, 0xb7, 0xc1, 0x05, 0x50, 0x8c, 0x67, 0x46, 0x33, 0x5e, 0x1b, 0x6c, 0xbc, 0xb1, 0x2f, 0xc2,
-	0x93, 0x08, 0x83, 0x18, 0xd1, 0x0c, 0x72, 0xb2, 0xb8, 0xf0, 0x50, 0x8e, 0xe1, 0xc6, 0x43, 0x39,
-	0x86, 0x0f, 0x0f, 0xe5, 0x18, 0x1b, 0x1e, 0xc9, 0x31, 0xae, 0x78, 0x24, 0xc7, 0x78, 0xe2, 0x91,
-	0x1c, 0xe3, 0x85, 0x47, 0x72, 0x8c, 0x0f, 0x1e, 0xc9, 0x31, 0xbe, 0x78, 0x24, 0xc7, 0xf0, 0xe1,
-	0x91, 0x1c, 0xe3, 0x84, 0xc7, 0x72, 0x0c, 0x17, 0x1e, 0xcb, 0x31, 0xdc, 0x78, 0x2c, 0xc7, 0x10,
-	0xc5, 0x54, 0x90, 0x94, 0xc4, 0x06, 0xb6, 0xd0, 0x18, 0x10, 0x00, 0x00, 0xff, 0xff, 0xa1, 0x59,
-	0x1f, 0x3f, 0x8d, 0x01, 0x00, 0x00,
+	// 436 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x92, 0xbf, 0x8e, 0xd3, 0x40,
+	0x10, 0x87, 0xbd, 0x71, 0x12, 0xf0, 0x24, 0x57, 0x64, 0x75, 0x42, 0x16, 0x3a, 0xad, 0x22, 0x8b,
+	0x22, 0x12, 0xc8, 0x88, 0x9c, 0x90, 0x68, 0xe1, 0x40, 0x82, 0xe2, 0x24, 0xe4, 0x82, 0x82, 0x6e,
+	0xbd, 0xcc, 0x25, 0x2b, 0x9c, 0x5d, 0xcb, 0x5e, 0xd0, 0x95, 0x3c, 0x00, 0x05, 0x8f, 0xc1, 0xa3,
+	0x50, 0x50, 0xa4, 0xbc, 0x92, 0x38, 0x0d, 0xa2, 0xba, 0x47, 0x40, 0xb7, 0xf1, 0x9f, 0xc4, 0x17,
+	0x85, 0x82, 0x72, 0xe6, 0xf7, 0x79, 0xec, 0x6f, 0x3c, 0x40, 0xd3, 0xf8, 0xf1, 0x9c, 0xab, 0x0f,
+	0xf9, 0x9c, 0x7f, 0xc4, 0x30, 0xcd, 0xb4, 0xd1, 0xd4, 0x55, 0x68, 0x02, 0x01, 0xa3, 0xd7, 0x55,
+	0xff, 0x95, 0xfa, 0x8c, 0x89, 0x4e, 0x91, 0xfa, 0x70, 0x67, 0x81, 0x79, 0xce, 0x67, 0xe8, 0x93,
+	0x31, 0x99, 0x0c, 0xa3, 0xaa, 0xa4, 0x27, 0xe0, 0xe5, 0x72, 0xa6, 0xb8, 0xf9, 0x94, 0xa1, 0xdf,
+	0xb1, 0x59, 0xd3, 0xa0, 0xf7, 0xa0, 0x9f, 0x22, 0x66, 0x6f, 0x5e, 0xfa, 0xae, 0x8d, 0xca, 0x2a,
+	0xf8, 0x49, 0x60, 0xf0, 0x5c, 0x98, 0x27, 0xe7, 0xe5, 0x94, 0x63, 0xe8, 0x29, 0xad, 0x44, 0x35,
+	0x7d, 0x53, 0xd0, 0xfb, 0x70, 0xd7, 0x7e, 0x98, 0xd0, 0x89, 0x1d, 0xed, 0x45, 0x75, 0x4d, 0xc7,
+	0x30, 0x10, 0x98, 0x19, 0x79, 0x21, 0x05, 0x37, 0x58, 0x8e, 0xdf, 0x6e, 0xd1, 0xa7, 0x30, 0x14,
+	0x3c, 0xe5, 0xb1, 0x4c, 0xa4, 0x91, 0x98, 0xfb, 0xdd, 0x31, 0x99, 0x0c, 0xa6, 0xa3, 0x50, 0xa1,
+	0x09, 0xcf, 0xb6, 0x82, 0x68, 0x07, 0xa3, 0x53, 0x38, 0xd6, 0x29, 0x66, 0xdc, 0xe8, 0xec, 0xac,
+	0x99, 0x96, 0xfb, 0xbd, 0xb1, 0x3b, 0x19, 0x46, 0x7b, 0xb3, 0xe0, 0xcf, 0x46, 0x67, 0x7a, 0x58,
+	0xe7, 0x04, 0x3c, 0x31, 0xe7, 0x49, 0x82, 0x6a, 0x56, 0xaf, 0xaa, 0x6e, 0xec, 0xc8, 0xba, 0x87,
+	0x65, 0xbb, 0xff, 0x96, 0xed, 0xfd, 0x9f, 0x6c, 0xff, 0x80, 0xec, 0x43, 0xeb, 0x7a, 0x7a, 0xde,
+	0x1c, 0x40, 0x63, 0x45, 0x5a, 0x56, 0xc1, 0xd7, 0x0e, 0x0c, 0xb7, 0xdf, 0x4f, 0x1f, 0xc0, 0x91,
+	0x48, 0x24, 0x2a, 0xf3, 0x0e, 0xb3, 0x5c, 0x6a, 0x65, 0x1f, 0xf1, 0xa2, 0xdd, 0x26, 0x0d, 0x81,
+	0x2e, 0xa4, 0x7a, 0x5b, 0xfa, 0x57, 0xe8, 0xcd, 0xce, 0x8e, 0xa2, 0x3d, 0x89, 0xe5, 0xf9, 0x65,
+	0x9b, 0x77, 0x4b, 0xfe, 0x56, 0x42, 0x1f, 0xc1, 0x68, 0x21, 0x55, 0xa9, 0x50, 0xe1, 0x5d, 0x8b,
+	0xdf, 0x0e, 0x2c, 0xcd, 0x2f, 0x5b, 0x74, 0xaf, 0xa4, 0xdb, 0xc1, 0xcd, 0x8f, 0xbc, 0x40, 0x7b,
+	0xfe, 0x9b, 0x3d, 0x7a, 0x51, 0x5d, 0xbf, 0x78, 0xb6, 0x5c, 0x31, 0xe7, 0x6a, 0xc5, 0x9c, 0xeb,
+	0x15, 0x23, 0x5f, 0x0a, 0x46, 0xbe, 0x17, 0x8c, 0xfc, 0x28, 0x18, 0x59, 0x16, 0x8c, 0xfc, 0x2a,
+	0x18, 0xf9, 0x5d, 0x30, 0xe7, 0xba, 0x60, 0xe4, 0xdb, 0x9a, 0x39, 0xcb, 0x35, 0x73, 0xae, 0xd6,
+	0xcc, 0x79, 0xdf, 0x49, 0xe3, 0xb8, 0x6f, 0x8f, 0xe1, 0xf4, 0xef, 0x00, 0xeb, 0xcc, 0xc8, 0xc0,
+	0xb8, 0x03, 0x00, 0x00,
 }
 
 func (this *HandshakeEnvelope) Equal(that interface{}) bool {
@@ -350,6 +511,20 @@ func (this *Act1Message) Equal(that interface{}) bool {
 	if this.Protocol != that1.Protocol {
 		return false
 	}
+	if !bytes.Equal(this.Certificate, that1.Certificate) {
+		return false
+	}
+	if !this.Capabilities.Equal(that1.Capabilities) {
+		return false
+	}
+	if len(this.OperatorCertificates) != len(that1.OperatorCertificates) {
+		return false
+	}
+	for i := range this.OperatorCertificates {
+		if !bytes.Equal(this.OperatorCertificates[i], that1.OperatorCertificates[i]) {
+			return false
+		}
+	}
 	return true
 }
 func (this *Act2Message) Equal(that interface{}) bool {
@@ -380,6 +555,20 @@ func (this *Act2Message) Equal(that interface{}) bool {
 	if this.Protocol != that1.Protocol {
 		return false
 	}
+	if !bytes.Equal(this.Certificate, that1.Certificate) {
+		return false
+	}
+	if !this.Capabilities.Equal(that1.Capabilities) {
+		return false
+	}
+	if len(this.OperatorCertificates) != len(that1.OperatorCertificates) {
+		return false
+	}
+	for i := range this.OperatorCertificates {
+		if !bytes.Equal(this.OperatorCertificates[i], that1.OperatorCertificates[i]) {
+			return false
+		}
+	}
 	return true
 }
 func (this *Act3Message) Equal(that interface{}) bool {
@@ -406,6 +595,50 @@ func (this *Act3Message) Equal(that interface{}) bool {
 	}
 	return true
 }
+func (this *Capabilities) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*Capabilities)
+	if !ok {
+		that2, ok := that.(Capabilities)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.ClientVersion != that1.ClientVersion {
+		return false
+	}
+	if this.MinProtocolVersion != that1.MinProtocolVersion {
+		return false
+	}
+	if this.MaxProtocolVersion != that1.MaxProtocolVersion {
+		return false
+	}
+	if this.MinMessageVersion != that1.MinMessageVersion {
+		return false
+	}
+	if this.MaxMessageVersion != that1.MaxMessageVersion {
+		return false
+	}
+	if len(this.Features) != len(that1.Features) {
+		return false
+	}
+	for i := range this.Features {
+		if this.Features[i] != that1.Features[i] {
+			return false
+		}
+	}
+	return true
+}
 func (this *HandshakeEnvelope) GoString() string {
 	if this == nil {
 		return "nil"
@@ -422,10 +655,15 @@ func (this *Act1Message) GoString() string {
 	if this == nil {
 		return "nil"
 	}
-	s := make([]string, 0, 6)
+	s := make([]string, 0, 9)
 	s = append(s, "&pb.Act1Message{")
 	s = append(s, "Nonce: "+fmt.Sprintf("%#v", this.Nonce)+",\n")
 	s = append(s, "Protocol: "+fmt.Sprintf("%#v", this.Protocol)+",\n")
+	s = append(s, "Certificate: "+fmt.Sprintf("%#v", this.Certificate)+",\n")
+	if this.Capabilities != nil {
+		s = append(s, "Capabilities: "+fmt.Sprintf("%#v", this.Capabilities)+",\n")
+	}
+	s = append(s, "OperatorCertificates: "+fmt.Sprintf("%#v", this.OperatorCertificates)+",\n")
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
@@ -433,11 +671,16 @@ func (this *Act2Message) GoString() string {
 	if this == nil {
 		return "nil"
 	}
-	s := make([]string, 0, 7)
+	s := make([]string, 0, 10)
 	s = append(s, "&pb.Act2Message{")
 	s = append(s, "Nonce: "+fmt.Sprintf("%#v", this.Nonce)+",\n")
 	s = append(s, "Challenge: "+fmt.Sprintf("%#v", this.Challenge)+",\n")
 	s = append(s, "Protocol: "+fmt.Sprintf("%#v", this.Protocol)+",\n")
+	s = append(s, "Certificate: "+fmt.Sprintf("%#v", this.Certificate)+",\n")
+	if this.Capabilities != nil {
+		s = append(s, "Capabilities: "+fmt.Sprintf("%#v", this.Capabilities)+",\n")
+	}
+	s = append(s, "OperatorCertificates: "+fmt.Sprintf("%#v", this.OperatorCertificates)+",\n")
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
@@ -451,6 +694,21 @@ func (this *Act3Message) GoString() string {
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
+func (this *Capabilities) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 10)
+	s = append(s, "&pb.Capabilities{")
+	s = append(s, "ClientVersion: "+fmt.Sprintf("%#v", this.ClientVersion)+",\n")
+	s = append(s, "MinProtocolVersion: "+fmt.Sprintf("%#v", this.MinProtocolVersion)+",\n")
+	s = append(s, "MaxProtocolVersion: "+fmt.Sprintf("%#v", this.MaxProtocolVersion)+",\n")
+	s = append(s, "MinMessageVersion: "+fmt.Sprintf("%#v", this.MinMessageVersion)+",\n")
+	s = append(s, "MaxMessageVersion: "+fmt.Sprintf("%#v", this.MaxMessageVersion)+",\n")
+	s = append(s, "Features: "+fmt.Sprintf("%#v", this.Features)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
 func valueToGoStringHandshake(v interface{}, typ string) string {
 	rv := reflect.ValueOf(v)
 	if rv.IsNil() {
@@ -523,6 +781,34 @@ func (m *Act1Message) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.OperatorCertificates) > 0 {
+		for iNdEx := len(m.OperatorCertificates) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.OperatorCertificates[iNdEx])
+			copy(dAtA[i:], m.OperatorCertificates[iNdEx])
+			i = encodeVarintHandshake(dAtA, i, uint64(len(m.OperatorCertificates[iNdEx])))
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
+	if m.Capabilities != nil {
+		{
+			size, err := m.Capabilities.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintHandshake(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Certificate) > 0 {
+		i -= len(m.Certificate)
+		copy(dAtA[i:], m.Certificate)
+		i = encodeVarintHandshake(dAtA, i, uint64(len(m.Certificate)))
+		i--
+		dAtA[i] = 0x1a
+	}
 	if len(m.Protocol) > 0 {
 		i -= len(m.Protocol)
 		copy(dAtA[i:], m.Protocol)
@@ -560,6 +846,34 @@ func (m *Act2Message) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.OperatorCertificates) > 0 {
+		for iNdEx := len(m.OperatorCertificates) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.OperatorCertificates[iNdEx])
+			copy(dAtA[i:], m.OperatorCertificates[iNdEx])
+			i = encodeVarintHandshake(dAtA, i, uint64(len(m.OperatorCertificates[iNdEx])))
+			i--
+			dAtA[i] = 0x32
+		}
+	}
+	if m.Capabilities != nil {
+		{
+			size, err := m.Capabilities.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintHandshake(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.Certificate) > 0 {
+		i -= len(m.Certificate)
+		copy(dAtA[i:], m.Certificate)
+		i = encodeVarintHandshake(dAtA, i, uint64(len(m.Certificate)))
+		i--
+		dAtA[i] = 0x22
+	}
 	if len(m.Protocol) > 0 {
 		i -= len(m.Protocol)
 		copy(dAtA[i:], m.Protocol)
@@ -614,6 +928,65 @@ func (m *Act3Message) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *Capabilities) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Capabilities) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Capabilities) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Features) > 0 {
+		for iNdEx := len(m.Features) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Features[iNdEx])
+			copy(dAtA[i:], m.Features[iNdEx])
+			i = encodeVarintHandshake(dAtA, i, uint64(len(m.Features[iNdEx])))
+			i--
+			dAtA[i] = 0x32
+		}
+	}
+	if m.MaxMessageVersion != 0 {
+		i = encodeVarintHandshake(dAtA, i, uint64(m.MaxMessageVersion))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.MinMessageVersion != 0 {
+		i = encodeVarintHandshake(dAtA, i, uint64(m.MinMessageVersion))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.MaxProtocolVersion != 0 {
+		i = encodeVarintHandshake(dAtA, i, uint64(m.MaxProtocolVersion))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.MinProtocolVersion != 0 {
+		i = encodeVarintHandshake(dAtA, i, uint64(m.MinProtocolVersion))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.ClientVersion) > 0 {
+		i -= len(m.ClientVersion)
+		copy(dAtA[i:], m.ClientVersion)
+		i = encodeVarintHandshake(dAtA, i, uint64(len(m.ClientVersion)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintHandshake(dAtA []byte, offset int, v uint64) int {
 	offset -= sovHandshake(v)
 	base := offset
@@ -660,6 +1033,20 @@ func (m *Act1Message) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovHandshake(uint64(l))
 	}
+	l = len(m.Certificate)
+	if l > 0 {
+		n += 1 + l + sovHandshake(uint64(l))
+	}
+	if m.Capabilities != nil {
+		l = m.Capabilities.Size()
+		n += 1 + l + sovHandshake(uint64(l))
+	}
+	if len(m.OperatorCertificates) > 0 {
+		for _, b := range m.OperatorCertificates {
+			l = len(b)
+			n += 1 + l + sovHandshake(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -681,6 +1068,20 @@ func (m *Act2Message) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovHandshake(uint64(l))
 	}
+	l = len(m.Certificate)
+	if l > 0 {
+		n += 1 + l + sovHandshake(uint64(l))
+	}
+	if m.Capabilities != nil {
+		l = m.Capabilities.Size()
+		n += 1 + l + sovHandshake(uint64(l))
+	}
+	if len(m.OperatorCertificates) > 0 {
+		for _, b := range m.OperatorCertificates {
+			l = len(b)
+			n += 1 + l + sovHandshake(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -697,6 +1098,37 @@ func (m *Act3Message) Size() (n int) {
 	return n
 }
 
+func (m *Capabilities) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ClientVersion)
+	if l > 0 {
+		n += 1 + l + sovHandshake(uint64(l))
+	}
+	if m.MinProtocolVersion != 0 {
+		n += 1 + sovHandshake(uint64(m.MinProtocolVersion))
+	}
+	if m.MaxProtocolVersion != 0 {
+		n += 1 + sovHandshake(uint64(m.MaxProtocolVersion))
+	}
+	if m.MinMessageVersion != 0 {
+		n += 1 + sovHandshake(uint64(m.MinMessageVersion))
+	}
+	if m.MaxMessageVersion != 0 {
+		n += 1 + sovHandshake(uint64(m.MaxMessageVersion))
+	}
+	if len(m.Features) > 0 {
+		for _, s := range m.Features {
+			l = len(s)
+			n += 1 + l + sovHandshake(uint64(l))
+		}
+	}
+	return n
+}
+
 func sovHandshake(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
 }
@@ -722,6 +1154,9 @@ func (this *Act1Message) String() string {
 	s := strings.Join([]string{`&Act1Message{`,
 		`Nonce:` + fmt.Sprintf("%v", this.Nonce) + `,`,
 		`Protocol:` + fmt.Sprintf("%v", this.Protocol) + `,`,
+		`Certificate:` + fmt.Sprintf("%v", this.Certificate) + `,`,
+		`Capabilities:` + strings.Replace(this.Capabilities.String(), "Capabilities", "Capabilities", 1) + `,`,
+		`OperatorCertificates:` + fmt.Sprintf("%v", this.OperatorCertificates) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -734,6 +1169,9 @@ func (this *Act2Message) String() string {
 		`Nonce:` + fmt.Sprintf("%v", this.Nonce) + `,`,
 		`Challenge:` + fmt.Sprintf("%v", this.Challenge) + `,`,
 		`Protocol:` + fmt.Sprintf("%v", this.Protocol) + `,`,
+		`Certificate:` + fmt.Sprintf("%v", this.Certificate) + `,`,
+		`Capabilities:` + strings.Replace(this.Capabilities.String(), "Capabilities", "Capabilities", 1) + `,`,
+		`OperatorCertificates:` + fmt.Sprintf("%v", this.OperatorCertificates) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -748,6 +1186,21 @@ func (this *Act3Message) String() string {
 	}, "")
 	return s
 }
+func (this *Capabilities) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&Capabilities{`,
+		`ClientVersion:` + fmt.Sprintf("%v", this.ClientVersion) + `,`,
+		`MinProtocolVersion:` + fmt.Sprintf("%v", this.MinProtocolVersion) + `,`,
+		`MaxProtocolVersion:` + fmt.Sprintf("%v", this.MaxProtocolVersion) + `,`,
+		`MinMessageVersion:` + fmt.Sprintf("%v", this.MinMessageVersion) + `,`,
+		`MaxMessageVersion:` + fmt.Sprintf("%v", this.MaxMessageVersion) + `,`,
+		`Features:` + fmt.Sprintf("%v", this.Features) + `,`,
+		`}`,
+	}, "")
+	return s
+}
 func valueToStringHandshake(v interface{}) string {
 	rv := reflect.ValueOf(v)
 	if rv.IsNil() {
@@ -1003,17 +1456,119 @@ func (m *Act1Message) Unmarshal(dAtA []byte) error {
 			}
 			m.Protocol = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipHandshake(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Certificate", wireType)
 			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandshake
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
 				return ErrInvalidLengthHandshake
 			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHandshake
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Certificate = append(m.Certificate[:0], dAtA[iNdEx:postIndex]...)
+			if m.Certificate == nil {
+				m.Certificate = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Capabilities", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandshake
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHandshake
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthHandshake
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Capabilities == nil {
+				m.Capabilities = &Capabilities{}
+			}
+			if err := m.Capabilities.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OperatorCertificates", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandshake
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthHandshake
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHandshake
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.OperatorCertificates = append(m.OperatorCertificates, make([]byte, postIndex-iNdEx))
+			copy(m.OperatorCertificates[len(m.OperatorCertificates)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHandshake(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthHandshake
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
 			}
 			iNdEx += skippy
 		}
@@ -1153,6 +1708,108 @@ func (m *Act2Message) Unmarshal(dAtA []byte) error {
 			}
 			m.Protocol = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Certificate", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandshake
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthHandshake
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHandshake
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Certificate = append(m.Certificate[:0], dAtA[iNdEx:postIndex]...)
+			if m.Certificate == nil {
+				m.Certificate = []byte{}
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Capabilities", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandshake
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHandshake
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthHandshake
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Capabilities == nil {
+				m.Capabilities = &Capabilities{}
+			}
+			if err := m.Capabilities.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OperatorCertificates", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandshake
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthHandshake
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHandshake
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.OperatorCertificates = append(m.OperatorCertificates, make([]byte, postIndex-iNdEx))
+			copy(m.OperatorCertificates[len(m.OperatorCertificates)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipHandshake(dAtA[iNdEx:])
@@ -1258,6 +1915,196 @@ func (m *Act3Message) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *Capabilities) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHandshake
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Capabilities: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Capabilities: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ClientVersion", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandshake
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHandshake
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHandshake
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ClientVersion = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinProtocolVersion", wireType)
+			}
+			m.MinProtocolVersion = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandshake
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MinProtocolVersion |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxProtocolVersion", wireType)
+			}
+			m.MaxProtocolVersion = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandshake
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxProtocolVersion |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinMessageVersion", wireType)
+			}
+			m.MinMessageVersion = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandshake
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MinMessageVersion |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxMessageVersion", wireType)
+			}
+			m.MaxMessageVersion = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandshake
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxMessageVersion |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Features", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandshake
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHandshake
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHandshake
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Features = append(m.Features, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHandshake(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthHandshake
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipHandshake(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -174,6 +174,9 @@ func (m *UnicastNetworkMessage) GetSignature() []byte {
 
 type Identity struct {
 	PubKey []byte `protobuf:"bytes,1,opt,name=pub_key,json=pubKey,proto3" json:"pub_key,omitempty"`
+	// Marshaled NetworkKeyCertificate authorizing the network key to act on
+	// behalf of an operator. Empty if the network key is the operator key.
+	Certificate []byte `protobuf:"bytes,2,opt,name=certificate,proto3" json:"certificate,omitempty"`
 }
 
 func (m *Identity) Reset()      { *m = Identity{} }
@@ -215,33 +218,272 @@ func (m *Identity) GetPubKey() []byte {
 	return nil
 }
 
+func (m *Identity) GetCertificate() []byte {
+	if m != nil {
+		return m.Certificate
+	}
+	return nil
+}
+
+// NetworkKeyCertificate binds a network key to an operator key. It is signed
+// with the operator key so the network key may be used as the peer's identity
+// without exposing the operator key to the network.
+type NetworkKeyCertificate struct {
+	// Uncompressed public key of the operator.
+	OperatorPublicKey []byte `protobuf:"bytes,1,opt,name=operatorPublicKey,proto3" json:"operatorPublicKey,omitempty"`
+	// Uncompressed network public key authorized by the operator.
+	NetworkPublicKey []byte `protobuf:"bytes,2,opt,name=networkPublicKey,proto3" json:"networkPublicKey,omitempty"`
+	// Operator's signature over the network public key, the serial and the
+	// expiry, in the Ethereum-specific format.
+	Signature []byte `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
+	// Serial number of the certificate. Certificates of the operator with
+	// higher serial numbers supersede the ones with lower serial numbers.
+	Serial uint64 `protobuf:"varint,4,opt,name=serial,proto3" json:"serial,omitempty"`
+	// UNIX timestamp, in seconds, after which the certificate is no longer
+	// valid.
+	ExpiresAt int64 `protobuf:"varint,5,opt,name=expiresAt,proto3" json:"expiresAt,omitempty"`
+}
+
+func (m *NetworkKeyCertificate) Reset()      { *m = NetworkKeyCertificate{} }
+func (*NetworkKeyCertificate) ProtoMessage() {}
+func (*NetworkKeyCertificate) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8447775385e7eb85, []int{3}
+}
+func (m *NetworkKeyCertificate) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *NetworkKeyCertificate) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_NetworkKeyCertificate.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *NetworkKeyCertificate) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NetworkKeyCertificate.Merge(m, src)
+}
+func (m *NetworkKeyCertificate) XXX_Size() int {
+	return m.Size()
+}
+func (m *NetworkKeyCertificate) XXX_DiscardUnknown() {
+	xxx_messageInfo_NetworkKeyCertificate.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NetworkKeyCertificate proto.InternalMessageInfo
+
+func (m *NetworkKeyCertificate) GetOperatorPublicKey() []byte {
+	if m != nil {
+		return m.OperatorPublicKey
+	}
+	return nil
+}
+
+func (m *NetworkKeyCertificate) GetNetworkPublicKey() []byte {
+	if m != nil {
+		return m.NetworkPublicKey
+	}
+	return nil
+}
+
+func (m *NetworkKeyCertificate) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+func (m *NetworkKeyCertificate) GetSerial() uint64 {
+	if m != nil {
+		return m.Serial
+	}
+	return 0
+}
+
+func (m *NetworkKeyCertificate) GetExpiresAt() int64 {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return 0
+}
+
+// MissedMessagesRequest asks a peer for broadcast channel messages the
+// requester has missed.
+type MissedMessagesRequest struct {
+	// Name of the broadcast channel.
+	Channel string `protobuf:"bytes,1,opt,name=channel,proto3" json:"channel,omitempty"`
+	// Marshaled operator public key of the message sender. Empty for messages
+	// of all senders.
+	Sender []byte `protobuf:"bytes,2,opt,name=sender,proto3" json:"sender,omitempty"`
+	// Type of the message as registered by the protocol. Empty for messages
+	// of all types.
+	Type string `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	// Maximum age of the message in milliseconds, counted from the moment the
+	// peer received it. Zero for messages of any age.
+	MaxAge uint64 `protobuf:"varint,4,opt,name=maxAge,proto3" json:"maxAge,omitempty"`
+	// Identifiers of messages the requester has already received.
+	KnownMessages [][]byte `protobuf:"bytes,5,rep,name=knownMessages,proto3" json:"knownMessages,omitempty"`
+}
+
+func (m *MissedMessagesRequest) Reset()      { *m = MissedMessagesRequest{} }
+func (*MissedMessagesRequest) ProtoMessage() {}
+func (*MissedMessagesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8447775385e7eb85, []int{4}
+}
+func (m *MissedMessagesRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *MissedMessagesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_MissedMessagesRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *MissedMessagesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MissedMessagesRequest.Merge(m, src)
+}
+func (m *MissedMessagesRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *MissedMessagesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_MissedMessagesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MissedMessagesRequest proto.InternalMessageInfo
+
+func (m *MissedMessagesRequest) GetChannel() string {
+	if m != nil {
+		return m.Channel
+	}
+	return ""
+}
+
+func (m *MissedMessagesRequest) GetSender() []byte {
+	if m != nil {
+		return m.Sender
+	}
+	return nil
+}
+
+func (m *MissedMessagesRequest) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *MissedMessagesRequest) GetMaxAge() uint64 {
+	if m != nil {
+		return m.MaxAge
+	}
+	return 0
+}
+
+func (m *MissedMessagesRequest) GetKnownMessages() [][]byte {
+	if m != nil {
+		return m.KnownMessages
+	}
+	return nil
+}
+
+// MissedMessagesResponse carries broadcast channel messages matching the
+// MissedMessagesRequest. Messages are marshaled pubsub messages signed by
+// their authors.
+type MissedMessagesResponse struct {
+	Messages [][]byte `protobuf:"bytes,1,rep,name=messages,proto3" json:"messages,omitempty"`
+}
+
+func (m *MissedMessagesResponse) Reset()      { *m = MissedMessagesResponse{} }
+func (*MissedMessagesResponse) ProtoMessage() {}
+func (*MissedMessagesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8447775385e7eb85, []int{5}
+}
+func (m *MissedMessagesResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *MissedMessagesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_MissedMessagesResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *MissedMessagesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MissedMessagesResponse.Merge(m, src)
+}
+func (m *MissedMessagesResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *MissedMessagesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_MissedMessagesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MissedMessagesResponse proto.InternalMessageInfo
+
+func (m *MissedMessagesResponse) GetMessages() [][]byte {
+	if m != nil {
+		return m.Messages
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*BroadcastNetworkMessage)(nil), "net.BroadcastNetworkMessage")
 	proto.RegisterType((*UnicastNetworkMessage)(nil), "net.UnicastNetworkMessage")
 	proto.RegisterType((*Identity)(nil), "net.Identity")
+	proto.RegisterType((*NetworkKeyCertificate)(nil), "net.NetworkKeyCertificate")
+	proto.RegisterType((*MissedMessagesRequest)(nil), "net.MissedMessagesRequest")
+	proto.RegisterType((*MissedMessagesResponse)(nil), "net.MissedMessagesResponse")
 }
 
 func init() { proto.RegisterFile("pb/message.proto", fileDescriptor_8447775385e7eb85) }
 
 var fileDescriptor_8447775385e7eb85 = []byte{
-	// 272 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x90, 0xb1, 0x4a, 0x03, 0x41,
-	0x10, 0x86, 0x77, 0x92, 0x90, 0xe8, 0x12, 0x44, 0x16, 0x34, 0x57, 0xc8, 0x10, 0x4e, 0x90, 0x54,
-	0x5a, 0xd8, 0x58, 0xa7, 0x13, 0x31, 0xc5, 0x81, 0x8d, 0x8d, 0xec, 0xe6, 0x86, 0x70, 0xc4, 0xec,
-	0xad, 0xbb, 0x7b, 0xc8, 0x61, 0x63, 0x67, 0xeb, 0x63, 0xf8, 0x28, 0x96, 0x57, 0xa6, 0xf4, 0xf6,
-	0x1a, 0xcb, 0x3c, 0x82, 0x70, 0x1a, 0x04, 0x7b, 0xbb, 0xf9, 0xbf, 0x19, 0x98, 0x8f, 0x9f, 0xef,
-	0x1b, 0x75, 0xb6, 0x22, 0xe7, 0xe4, 0x82, 0x4e, 0x8d, 0xcd, 0x7d, 0x2e, 0xba, 0x9a, 0x7c, 0xfc,
-	0x02, 0x7c, 0x34, 0xb5, 0xb9, 0x4c, 0xe7, 0xd2, 0xf9, 0x19, 0xf9, 0xc7, 0xdc, 0x2e, 0xaf, 0xbf,
-	0xcf, 0xc4, 0x21, 0xef, 0x3b, 0xd2, 0x29, 0xd9, 0x08, 0xc6, 0x30, 0x19, 0x26, 0x3f, 0x49, 0x44,
-	0x7c, 0x60, 0x64, 0x79, 0x9f, 0xcb, 0x34, 0xea, 0xb4, 0x8b, 0x6d, 0x14, 0x82, 0xf7, 0x7c, 0x69,
-	0x28, 0xea, 0xb6, 0xb8, 0x9d, 0xc5, 0x09, 0xdf, 0x73, 0xf4, 0x50, 0x90, 0x9e, 0xd3, 0xac, 0x58,
-	0x29, 0xb2, 0x51, 0x6f, 0x0c, 0x93, 0x5e, 0xf2, 0x87, 0xc6, 0x4f, 0xfc, 0xe0, 0x46, 0x67, 0xff,
-	0xa6, 0x71, 0xc4, 0x77, 0x5d, 0xb6, 0xd0, 0xd2, 0x17, 0x96, 0x5a, 0x83, 0x61, 0xf2, 0x0b, 0xe2,
-	0x63, 0xbe, 0x73, 0x99, 0x92, 0xf6, 0x99, 0x2f, 0xc5, 0x88, 0x0f, 0x4c, 0xa1, 0xee, 0x96, 0x54,
-	0x6e, 0x1f, 0x9a, 0x42, 0x5d, 0x51, 0x39, 0xbd, 0xa8, 0x6a, 0x64, 0xeb, 0x1a, 0xd9, 0xa6, 0x46,
-	0x78, 0x0e, 0x08, 0x6f, 0x01, 0xe1, 0x3d, 0x20, 0x54, 0x01, 0xe1, 0x23, 0x20, 0x7c, 0x06, 0x64,
-	0x9b, 0x80, 0xf0, 0xda, 0x20, 0xab, 0x1a, 0x64, 0xeb, 0x06, 0xd9, 0x6d, 0xc7, 0x28, 0xd5, 0x6f,
-	0x1b, 0x3f, 0xff, 0x0a, 0x00, 0x00, 0xff, 0xff, 0x67, 0x49, 0x47, 0x0c, 0x85, 0x01, 0x00, 0x00,
+	// 455 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x53, 0x3d, 0x6f, 0x13, 0x41,
+	0x10, 0xbd, 0xf5, 0x39, 0x4e, 0x3c, 0x18, 0x14, 0x56, 0xb2, 0x73, 0x42, 0x68, 0x75, 0x3a, 0x21,
+	0x64, 0x21, 0x04, 0x05, 0x14, 0xb4, 0x09, 0xa2, 0x40, 0x56, 0x22, 0x74, 0x12, 0x0d, 0x0d, 0xda,
+	0xbb, 0x1b, 0xcc, 0xc9, 0xf6, 0xee, 0xb2, 0xbb, 0xa7, 0xe4, 0x44, 0x43, 0x47, 0x4b, 0xcf, 0x1f,
+	0xe0, 0x8f, 0x20, 0x51, 0xba, 0x4c, 0x89, 0xcf, 0x0d, 0x65, 0x7e, 0x02, 0xf2, 0x7d, 0xc4, 0x1f,
+	0xa9, 0xd3, 0xdd, 0x7b, 0xb3, 0x33, 0xef, 0xcd, 0x3c, 0x1d, 0x1c, 0xaa, 0xe8, 0xf9, 0x0c, 0x8d,
+	0xe1, 0x63, 0x7c, 0xa6, 0xb4, 0xb4, 0x92, 0xba, 0x02, 0x6d, 0xf0, 0x9d, 0xc0, 0xd1, 0x89, 0x96,
+	0x3c, 0x89, 0xb9, 0xb1, 0x67, 0x68, 0xcf, 0xa5, 0x9e, 0x9c, 0x56, 0xcf, 0xe8, 0x00, 0x3a, 0x06,
+	0x45, 0x82, 0xda, 0x23, 0x3e, 0x19, 0xf6, 0xc2, 0x1a, 0x51, 0x0f, 0xf6, 0x15, 0xcf, 0xa7, 0x92,
+	0x27, 0x5e, 0xab, 0x2c, 0x34, 0x90, 0x52, 0x68, 0xdb, 0x5c, 0xa1, 0xe7, 0x96, 0x74, 0xf9, 0x4d,
+	0x1f, 0xc3, 0x3d, 0x83, 0x5f, 0x32, 0x14, 0x31, 0x9e, 0x65, 0xb3, 0x08, 0xb5, 0xd7, 0xf6, 0xc9,
+	0xb0, 0x1d, 0xee, 0xb0, 0xc1, 0x57, 0xe8, 0xbf, 0x17, 0xe9, 0xad, 0xd9, 0x78, 0x08, 0x5d, 0x93,
+	0x8e, 0x05, 0xb7, 0x99, 0xc6, 0xd2, 0x41, 0x2f, 0x5c, 0x13, 0xc1, 0x1b, 0x38, 0x78, 0x9b, 0xa0,
+	0xb0, 0xa9, 0xcd, 0xe9, 0x11, 0xec, 0xab, 0x2c, 0xfa, 0x38, 0xc1, 0xbc, 0x11, 0x54, 0x59, 0x34,
+	0xc2, 0x9c, 0xfa, 0x70, 0x27, 0x46, 0x6d, 0xd3, 0x4f, 0x69, 0xcc, 0x2d, 0xd6, 0xa2, 0x9b, 0x54,
+	0xf0, 0x9b, 0x40, 0xbf, 0x76, 0x3f, 0xc2, 0xfc, 0xf5, 0xba, 0x42, 0x9f, 0xc2, 0x7d, 0xa9, 0x50,
+	0x73, 0x2b, 0xf5, 0xbb, 0x2c, 0x9a, 0xa6, 0xf1, 0xe8, 0x7a, 0xfc, 0xcd, 0x02, 0x7d, 0x02, 0x87,
+	0xa2, 0x1a, 0xb3, 0x7e, 0x5c, 0xc9, 0xdd, 0xe0, 0xb7, 0x17, 0x73, 0x77, 0x16, 0xab, 0x8e, 0xa7,
+	0x53, 0x3e, 0xad, 0xaf, 0x5e, 0xa3, 0x55, 0x17, 0x5e, 0xa8, 0x54, 0xa3, 0x39, 0xb6, 0xde, 0x9e,
+	0x4f, 0x86, 0x6e, 0xb8, 0x26, 0x82, 0x9f, 0x04, 0xfa, 0xa7, 0xa9, 0x31, 0x98, 0xd4, 0x21, 0x98,
+	0x70, 0x15, 0x96, 0xb1, 0xab, 0xa3, 0xc7, 0x9f, 0xb9, 0x10, 0x38, 0x2d, 0xdd, 0x77, 0xc3, 0x06,
+	0x6e, 0xc4, 0xd4, 0xda, 0x8a, 0x69, 0x33, 0x8c, 0x6e, 0x1d, 0xc6, 0x00, 0x3a, 0x33, 0x7e, 0x71,
+	0x3c, 0xc6, 0xc6, 0x55, 0x85, 0xe8, 0x23, 0xb8, 0x3b, 0x11, 0xf2, 0x5c, 0x34, 0xaa, 0xde, 0x9e,
+	0xef, 0x0e, 0x7b, 0xe1, 0x36, 0x19, 0xbc, 0x84, 0xc1, 0xae, 0x39, 0xa3, 0xa4, 0x30, 0x48, 0x1f,
+	0xc0, 0xc1, 0xac, 0x69, 0x25, 0x65, 0xeb, 0x35, 0x3e, 0x79, 0x35, 0x5f, 0x30, 0xe7, 0x72, 0xc1,
+	0x9c, 0xab, 0x05, 0x23, 0xdf, 0x0a, 0x46, 0x7e, 0x15, 0x8c, 0xfc, 0x29, 0x18, 0x99, 0x17, 0x8c,
+	0xfc, 0x2d, 0x18, 0xf9, 0x57, 0x30, 0xe7, 0xaa, 0x60, 0xe4, 0xc7, 0x92, 0x39, 0xf3, 0x25, 0x73,
+	0x2e, 0x97, 0xcc, 0xf9, 0xd0, 0x52, 0x51, 0xd4, 0x29, 0xff, 0x97, 0x17, 0xff, 0x07, 0x00, 0x02,
+	0x14, 0xfe, 0xaa, 0x43, 0x03, 0x00, 0x00,
 }
 
 func (this *BroadcastNetworkMessage) Equal(that interface{}) bool {
@@ -332,6 +574,115 @@ func (this *Identity) Equal(that interface{}) bool {
 	if !bytes.Equal(this.PubKey, that1.PubKey) {
 		return false
 	}
+	if !bytes.Equal(this.Certificate, that1.Certificate) {
+		return false
+	}
+	return true
+}
+func (this *NetworkKeyCertificate) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*NetworkKeyCertificate)
+	if !ok {
+		that2, ok := that.(NetworkKeyCertificate)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !bytes.Equal(this.OperatorPublicKey, that1.OperatorPublicKey) {
+		return false
+	}
+	if !bytes.Equal(this.NetworkPublicKey, that1.NetworkPublicKey) {
+		return false
+	}
+	if !bytes.Equal(this.Signature, that1.Signature) {
+		return false
+	}
+	if this.Serial != that1.Serial {
+		return false
+	}
+	if this.ExpiresAt != that1.ExpiresAt {
+		return false
+	}
+	return true
+}
+func (this *MissedMessagesRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*MissedMessagesRequest)
+	if !ok {
+		that2, ok := that.(MissedMessagesRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Channel != that1.Channel {
+		return false
+	}
+	if !bytes.Equal(this.Sender, that1.Sender) {
+		return false
+	}
+	if this.Type != that1.Type {
+		return false
+	}
+	if this.MaxAge != that1.MaxAge {
+		return false
+	}
+	if len(this.KnownMessages) != len(that1.KnownMessages) {
+		return false
+	}
+	for i := range this.KnownMessages {
+		if !bytes.Equal(this.KnownMessages[i], that1.KnownMessages[i]) {
+			return false
+		}
+	}
+	return true
+}
+func (this *MissedMessagesResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*MissedMessagesResponse)
+	if !ok {
+		that2, ok := that.(MissedMessagesResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if len(this.Messages) != len(that1.Messages) {
+		return false
+	}
+	for i := range this.Messages {
+		if !bytes.Equal(this.Messages[i], that1.Messages[i]) {
+			return false
+		}
+	}
 	return true
 }
 func (this *BroadcastNetworkMessage) GoString() string {
@@ -364,9 +715,48 @@ func (this *Identity) GoString() string {
 	if this == nil {
 		return "nil"
 	}
-	s := make([]string, 0, 5)
+	s := make([]string, 0, 6)
 	s = append(s, "&pb.Identity{")
 	s = append(s, "PubKey: "+fmt.Sprintf("%#v", this.PubKey)+",\n")
+	s = append(s, "Certificate: "+fmt.Sprintf("%#v", this.Certificate)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *NetworkKeyCertificate) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 9)
+	s = append(s, "&pb.NetworkKeyCertificate{")
+	s = append(s, "OperatorPublicKey: "+fmt.Sprintf("%#v", this.OperatorPublicKey)+",\n")
+	s = append(s, "NetworkPublicKey: "+fmt.Sprintf("%#v", this.NetworkPublicKey)+",\n")
+	s = append(s, "Signature: "+fmt.Sprintf("%#v", this.Signature)+",\n")
+	s = append(s, "Serial: "+fmt.Sprintf("%#v", this.Serial)+",\n")
+	s = append(s, "ExpiresAt: "+fmt.Sprintf("%#v", this.ExpiresAt)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *MissedMessagesRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 9)
+	s = append(s, "&pb.MissedMessagesRequest{")
+	s = append(s, "Channel: "+fmt.Sprintf("%#v", this.Channel)+",\n")
+	s = append(s, "Sender: "+fmt.Sprintf("%#v", this.Sender)+",\n")
+	s = append(s, "Type: "+fmt.Sprintf("%#v", this.Type)+",\n")
+	s = append(s, "MaxAge: "+fmt.Sprintf("%#v", this.MaxAge)+",\n")
+	s = append(s, "KnownMessages: "+fmt.Sprintf("%#v", this.KnownMessages)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *MissedMessagesResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&pb.MissedMessagesResponse{")
+	s = append(s, "Messages: "+fmt.Sprintf("%#v", this.Messages)+",\n")
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
@@ -498,6 +888,13 @@ func (m *Identity) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.Certificate) > 0 {
+		i -= len(m.Certificate)
+		copy(dAtA[i:], m.Certificate)
+		i = encodeVarintMessage(dAtA, i, uint64(len(m.Certificate)))
+		i--
+		dAtA[i] = 0x12
+	}
 	if len(m.PubKey) > 0 {
 		i -= len(m.PubKey)
 		copy(dAtA[i:], m.PubKey)
@@ -508,22 +905,166 @@ func (m *Identity) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func encodeVarintMessage(dAtA []byte, offset int, v uint64) int {
-	offset -= sovMessage(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func (m *NetworkKeyCertificate) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	dAtA[offset] = uint8(v)
-	return base
+	return dAtA[:n], nil
 }
-func (m *BroadcastNetworkMessage) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
+
+func (m *NetworkKeyCertificate) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *NetworkKeyCertificate) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.ExpiresAt != 0 {
+		i = encodeVarintMessage(dAtA, i, uint64(m.ExpiresAt))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.Serial != 0 {
+		i = encodeVarintMessage(dAtA, i, uint64(m.Serial))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.Signature) > 0 {
+		i -= len(m.Signature)
+		copy(dAtA[i:], m.Signature)
+		i = encodeVarintMessage(dAtA, i, uint64(len(m.Signature)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.NetworkPublicKey) > 0 {
+		i -= len(m.NetworkPublicKey)
+		copy(dAtA[i:], m.NetworkPublicKey)
+		i = encodeVarintMessage(dAtA, i, uint64(len(m.NetworkPublicKey)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.OperatorPublicKey) > 0 {
+		i -= len(m.OperatorPublicKey)
+		copy(dAtA[i:], m.OperatorPublicKey)
+		i = encodeVarintMessage(dAtA, i, uint64(len(m.OperatorPublicKey)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *MissedMessagesRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MissedMessagesRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *MissedMessagesRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.KnownMessages) > 0 {
+		for iNdEx := len(m.KnownMessages) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.KnownMessages[iNdEx])
+			copy(dAtA[i:], m.KnownMessages[iNdEx])
+			i = encodeVarintMessage(dAtA, i, uint64(len(m.KnownMessages[iNdEx])))
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
+	if m.MaxAge != 0 {
+		i = encodeVarintMessage(dAtA, i, uint64(m.MaxAge))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.Type) > 0 {
+		i -= len(m.Type)
+		copy(dAtA[i:], m.Type)
+		i = encodeVarintMessage(dAtA, i, uint64(len(m.Type)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Sender) > 0 {
+		i -= len(m.Sender)
+		copy(dAtA[i:], m.Sender)
+		i = encodeVarintMessage(dAtA, i, uint64(len(m.Sender)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Channel) > 0 {
+		i -= len(m.Channel)
+		copy(dAtA[i:], m.Channel)
+		i = encodeVarintMessage(dAtA, i, uint64(len(m.Channel)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *MissedMessagesResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MissedMessagesResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *MissedMessagesResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Messages) > 0 {
+		for iNdEx := len(m.Messages) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Messages[iNdEx])
+			copy(dAtA[i:], m.Messages[iNdEx])
+			i = encodeVarintMessage(dAtA, i, uint64(len(m.Messages[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintMessage(dAtA []byte, offset int, v uint64) int {
+	offset -= sovMessage(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *BroadcastNetworkMessage) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
 	_ = l
 	l = len(m.Sender)
 	if l > 0 {
@@ -578,60 +1119,650 @@ func (m *Identity) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovMessage(uint64(l))
 	}
-	return n
-}
+	l = len(m.Certificate)
+	if l > 0 {
+		n += 1 + l + sovMessage(uint64(l))
+	}
+	return n
+}
+
+func (m *NetworkKeyCertificate) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.OperatorPublicKey)
+	if l > 0 {
+		n += 1 + l + sovMessage(uint64(l))
+	}
+	l = len(m.NetworkPublicKey)
+	if l > 0 {
+		n += 1 + l + sovMessage(uint64(l))
+	}
+	l = len(m.Signature)
+	if l > 0 {
+		n += 1 + l + sovMessage(uint64(l))
+	}
+	if m.Serial != 0 {
+		n += 1 + sovMessage(uint64(m.Serial))
+	}
+	if m.ExpiresAt != 0 {
+		n += 1 + sovMessage(uint64(m.ExpiresAt))
+	}
+	return n
+}
+
+func (m *MissedMessagesRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Channel)
+	if l > 0 {
+		n += 1 + l + sovMessage(uint64(l))
+	}
+	l = len(m.Sender)
+	if l > 0 {
+		n += 1 + l + sovMessage(uint64(l))
+	}
+	l = len(m.Type)
+	if l > 0 {
+		n += 1 + l + sovMessage(uint64(l))
+	}
+	if m.MaxAge != 0 {
+		n += 1 + sovMessage(uint64(m.MaxAge))
+	}
+	if len(m.KnownMessages) > 0 {
+		for _, b := range m.KnownMessages {
+			l = len(b)
+			n += 1 + l + sovMessage(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *MissedMessagesResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Messages) > 0 {
+		for _, b := range m.Messages {
+			l = len(b)
+			n += 1 + l + sovMessage(uint64(l))
+		}
+	}
+	return n
+}
+
+func sovMessage(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozMessage(x uint64) (n int) {
+	return sovMessage(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (this *BroadcastNetworkMessage) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&BroadcastNetworkMessage{`,
+		`Sender:` + fmt.Sprintf("%v", this.Sender) + `,`,
+		`Payload:` + fmt.Sprintf("%v", this.Payload) + `,`,
+		`Type:` + fmt.Sprintf("%v", this.Type) + `,`,
+		`SequenceNumber:` + fmt.Sprintf("%v", this.SequenceNumber) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *UnicastNetworkMessage) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&UnicastNetworkMessage{`,
+		`Sender:` + fmt.Sprintf("%v", this.Sender) + `,`,
+		`Payload:` + fmt.Sprintf("%v", this.Payload) + `,`,
+		`Type:` + fmt.Sprintf("%v", this.Type) + `,`,
+		`Signature:` + fmt.Sprintf("%v", this.Signature) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *Identity) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&Identity{`,
+		`PubKey:` + fmt.Sprintf("%v", this.PubKey) + `,`,
+		`Certificate:` + fmt.Sprintf("%v", this.Certificate) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *NetworkKeyCertificate) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&NetworkKeyCertificate{`,
+		`OperatorPublicKey:` + fmt.Sprintf("%v", this.OperatorPublicKey) + `,`,
+		`NetworkPublicKey:` + fmt.Sprintf("%v", this.NetworkPublicKey) + `,`,
+		`Signature:` + fmt.Sprintf("%v", this.Signature) + `,`,
+		`Serial:` + fmt.Sprintf("%v", this.Serial) + `,`,
+		`ExpiresAt:` + fmt.Sprintf("%v", this.ExpiresAt) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *MissedMessagesRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&MissedMessagesRequest{`,
+		`Channel:` + fmt.Sprintf("%v", this.Channel) + `,`,
+		`Sender:` + fmt.Sprintf("%v", this.Sender) + `,`,
+		`Type:` + fmt.Sprintf("%v", this.Type) + `,`,
+		`MaxAge:` + fmt.Sprintf("%v", this.MaxAge) + `,`,
+		`KnownMessages:` + fmt.Sprintf("%v", this.KnownMessages) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *MissedMessagesResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&MissedMessagesResponse{`,
+		`Messages:` + fmt.Sprintf("%v", this.Messages) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func valueToStringMessage(v interface{}) string {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return "nil"
+	}
+	pv := reflect.Indirect(rv).Interface()
+	return fmt.Sprintf("*%v", pv)
+}
+func (m *BroadcastNetworkMessage) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMessage
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: BroadcastNetworkMessage: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: BroadcastNetworkMessage: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sender", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthMessage
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Sender = append(m.Sender[:0], dAtA[iNdEx:postIndex]...)
+			if m.Sender == nil {
+				m.Sender = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Payload", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthMessage
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Payload = append(m.Payload[:0], dAtA[iNdEx:postIndex]...)
+			if m.Payload == nil {
+				m.Payload = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthMessage
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Type = append(m.Type[:0], dAtA[iNdEx:postIndex]...)
+			if m.Type == nil {
+				m.Type = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SequenceNumber", wireType)
+			}
+			m.SequenceNumber = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SequenceNumber |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMessage(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *UnicastNetworkMessage) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMessage
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: UnicastNetworkMessage: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: UnicastNetworkMessage: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sender", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthMessage
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Sender = append(m.Sender[:0], dAtA[iNdEx:postIndex]...)
+			if m.Sender == nil {
+				m.Sender = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Payload", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthMessage
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Payload = append(m.Payload[:0], dAtA[iNdEx:postIndex]...)
+			if m.Payload == nil {
+				m.Payload = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthMessage
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Type = append(m.Type[:0], dAtA[iNdEx:postIndex]...)
+			if m.Type == nil {
+				m.Type = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Signature", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthMessage
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Signature = append(m.Signature[:0], dAtA[iNdEx:postIndex]...)
+			if m.Signature == nil {
+				m.Signature = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMessage(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
 
-func sovMessage(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
-}
-func sozMessage(x uint64) (n int) {
-	return sovMessage(uint64((x << 1) ^ uint64((int64(x) >> 63))))
-}
-func (this *BroadcastNetworkMessage) String() string {
-	if this == nil {
-		return "nil"
-	}
-	s := strings.Join([]string{`&BroadcastNetworkMessage{`,
-		`Sender:` + fmt.Sprintf("%v", this.Sender) + `,`,
-		`Payload:` + fmt.Sprintf("%v", this.Payload) + `,`,
-		`Type:` + fmt.Sprintf("%v", this.Type) + `,`,
-		`SequenceNumber:` + fmt.Sprintf("%v", this.SequenceNumber) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *UnicastNetworkMessage) String() string {
-	if this == nil {
-		return "nil"
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	s := strings.Join([]string{`&UnicastNetworkMessage{`,
-		`Sender:` + fmt.Sprintf("%v", this.Sender) + `,`,
-		`Payload:` + fmt.Sprintf("%v", this.Payload) + `,`,
-		`Type:` + fmt.Sprintf("%v", this.Type) + `,`,
-		`Signature:` + fmt.Sprintf("%v", this.Signature) + `,`,
-		`}`,
-	}, "")
-	return s
+	return nil
 }
-func (this *Identity) String() string {
-	if this == nil {
-		return "nil"
+func (m *Identity) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMessage
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Identity: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Identity: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PubKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthMessage
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PubKey = append(m.PubKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.PubKey == nil {
+				m.PubKey = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Certificate", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthMessage
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Certificate = append(m.Certificate[:0], dAtA[iNdEx:postIndex]...)
+			if m.Certificate == nil {
+				m.Certificate = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMessage(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
 	}
-	s := strings.Join([]string{`&Identity{`,
-		`PubKey:` + fmt.Sprintf("%v", this.PubKey) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func valueToStringMessage(v interface{}) string {
-	rv := reflect.ValueOf(v)
-	if rv.IsNil() {
-		return "nil"
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	pv := reflect.Indirect(rv).Interface()
-	return fmt.Sprintf("*%v", pv)
+	return nil
 }
-func (m *BroadcastNetworkMessage) Unmarshal(dAtA []byte) error {
+func (m *NetworkKeyCertificate) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -654,15 +1785,15 @@ func (m *BroadcastNetworkMessage) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: BroadcastNetworkMessage: wiretype end group for non-group")
+			return fmt.Errorf("proto: NetworkKeyCertificate: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: BroadcastNetworkMessage: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: NetworkKeyCertificate: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Sender", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field OperatorPublicKey", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -689,14 +1820,14 @@ func (m *BroadcastNetworkMessage) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Sender = append(m.Sender[:0], dAtA[iNdEx:postIndex]...)
-			if m.Sender == nil {
-				m.Sender = []byte{}
+			m.OperatorPublicKey = append(m.OperatorPublicKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.OperatorPublicKey == nil {
+				m.OperatorPublicKey = []byte{}
 			}
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Payload", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field NetworkPublicKey", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -723,14 +1854,14 @@ func (m *BroadcastNetworkMessage) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Payload = append(m.Payload[:0], dAtA[iNdEx:postIndex]...)
-			if m.Payload == nil {
-				m.Payload = []byte{}
+			m.NetworkPublicKey = append(m.NetworkPublicKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.NetworkPublicKey == nil {
+				m.NetworkPublicKey = []byte{}
 			}
 			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Signature", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -757,16 +1888,16 @@ func (m *BroadcastNetworkMessage) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Type = append(m.Type[:0], dAtA[iNdEx:postIndex]...)
-			if m.Type == nil {
-				m.Type = []byte{}
+			m.Signature = append(m.Signature[:0], dAtA[iNdEx:postIndex]...)
+			if m.Signature == nil {
+				m.Signature = []byte{}
 			}
 			iNdEx = postIndex
 		case 4:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SequenceNumber", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Serial", wireType)
 			}
-			m.SequenceNumber = 0
+			m.Serial = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowMessage
@@ -776,7 +1907,26 @@ func (m *BroadcastNetworkMessage) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.SequenceNumber |= uint64(b&0x7F) << shift
+				m.Serial |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExpiresAt", wireType)
+			}
+			m.ExpiresAt = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ExpiresAt |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -802,7 +1952,7 @@ func (m *BroadcastNetworkMessage) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *UnicastNetworkMessage) Unmarshal(dAtA []byte) error {
+func (m *MissedMessagesRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -825,17 +1975,17 @@ func (m *UnicastNetworkMessage) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: UnicastNetworkMessage: wiretype end group for non-group")
+			return fmt.Errorf("proto: MissedMessagesRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: UnicastNetworkMessage: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: MissedMessagesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Sender", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Channel", wireType)
 			}
-			var byteLen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowMessage
@@ -845,29 +1995,27 @@ func (m *UnicastNetworkMessage) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthMessage
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthMessage
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Sender = append(m.Sender[:0], dAtA[iNdEx:postIndex]...)
-			if m.Sender == nil {
-				m.Sender = []byte{}
-			}
+			m.Channel = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Payload", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Sender", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -894,16 +2042,16 @@ func (m *UnicastNetworkMessage) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Payload = append(m.Payload[:0], dAtA[iNdEx:postIndex]...)
-			if m.Payload == nil {
-				m.Payload = []byte{}
+			m.Sender = append(m.Sender[:0], dAtA[iNdEx:postIndex]...)
+			if m.Sender == nil {
+				m.Sender = []byte{}
 			}
 			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
 			}
-			var byteLen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowMessage
@@ -913,29 +2061,46 @@ func (m *UnicastNetworkMessage) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthMessage
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthMessage
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Type = append(m.Type[:0], dAtA[iNdEx:postIndex]...)
-			if m.Type == nil {
-				m.Type = []byte{}
-			}
+			m.Type = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxAge", wireType)
+			}
+			m.MaxAge = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxAge |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Signature", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field KnownMessages", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -962,10 +2127,8 @@ func (m *UnicastNetworkMessage) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Signature = append(m.Signature[:0], dAtA[iNdEx:postIndex]...)
-			if m.Signature == nil {
-				m.Signature = []byte{}
-			}
+			m.KnownMessages = append(m.KnownMessages, make([]byte, postIndex-iNdEx))
+			copy(m.KnownMessages[len(m.KnownMessages)-1], dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -988,7 +2151,7 @@ func (m *UnicastNetworkMessage) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Identity) Unmarshal(dAtA []byte) error {
+func (m *MissedMessagesResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1011,15 +2174,15 @@ func (m *Identity) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Identity: wiretype end group for non-group")
+			return fmt.Errorf("proto: MissedMessagesResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Identity: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: MissedMessagesResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PubKey", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Messages", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -1046,10 +2209,8 @@ func (m *Identity) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.PubKey = append(m.PubKey[:0], dAtA[iNdEx:postIndex]...)
-			if m.PubKey == nil {
-				m.PubKey = []byte{}
-			}
+			m.Messages = append(m.Messages, make([]byte, postIndex-iNdEx))
+			copy(m.Messages[len(m.Messages)-1], dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -11,6 +11,7 @@ func BasicMessage(
 	payload interface{},
 	messageType string,
 	senderPublicKey []byte,
+	senderCapabilities *net.PeerCapabilities,
 	seqno uint64,
 ) net.Message {
 	return &basicMessage{
@@ -18,6 +19,7 @@ func BasicMessage(
 		payload,
 		messageType,
 		senderPublicKey,
+		senderCapabilities,
 		seqno,
 	}
 }
@@ -25,11 +27,12 @@ func BasicMessage(
 // basicMessage is a struct-based trivial implementation of the net.Message
 // interface for use by packages that don't need any frills.
 type basicMessage struct {
-	transportSenderID net.TransportIdentifier
-	payload           interface{}
-	messageType       string
-	senderPublicKey   []byte
-	seqno             uint64
+	transportSenderID  net.TransportIdentifier
+	payload            interface{}
+	messageType        string
+	senderPublicKey    []byte
+	senderCapabilities *net.PeerCapabilities
+	seqno              uint64
 }
 
 func (m *basicMessage) TransportSenderID() net.TransportIdentifier {
@@ -48,6 +51,10 @@ func (m *basicMessage) SenderPublicKey() []byte {
 	return m.senderPublicKey
 }
 
+func (m *basicMessage) SenderCapabilities() *net.PeerCapabilities {
+	return m.senderCapabilities
+}
+
 func (m *basicMessage) Seqno() uint64 {
 	return m.seqno
 }
@@ -0,0 +1,273 @@
+package key
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/keep-network/keep-core/pkg/net/gen/pb"
+	"github.com/keep-network/keep-core/pkg/operator"
+)
+
+// certificatePrefix is prepended to the network public key before it is
+// signed by the operator so that the certificate signature can not be
+// confused with a signature over any other message.
+const certificatePrefix = "keep-network-key:"
+
+// DefaultCertificateValidity is the period for which certificates issued by
+// the client are valid unless another period is requested.
+const DefaultCertificateValidity = 365 * 24 * time.Hour
+
+// signatureSize is the byte size of an Ethereum-specific signature with the
+// recovery id included.
+const signatureSize = 65
+
+// Certificate binds a network key to an operator key. The certificate is
+// signed with the operator key and allows the network key to be used as the
+// peer's identity in the network without exposing the operator key. Since
+// the operator key is used only to issue the certificate, the network key
+// can be rotated at any time by issuing a new certificate.
+//
+// A certificate is valid until it expires or until the operator issues
+// another certificate with a higher serial number, which supersedes it.
+// Peers keep track of the highest serial number seen for each operator and
+// reject superseded certificates, so a rotated network key stops being
+// accepted as soon as peers see the new one.
+type Certificate struct {
+	OperatorPublicKey *operator.PublicKey
+	NetworkPublicKey  *NetworkPublic
+	Serial            uint64
+	ExpiresAt         time.Time
+	Signature         []byte
+}
+
+// IssueCertificate creates a new certificate for the given network public
+// key, valid for the given period. The sign function has to sign the message
+// with the operator key using the Ethereum-specific format, like
+// chain.Signing.Sign does. The serial number of the certificate is the
+// issuance time in nanoseconds, so certificates issued later supersede the
+// earlier ones without the operator keeping track of issued serial numbers.
+func IssueCertificate(
+	operatorPublicKey *operator.PublicKey,
+	networkPublicKey *NetworkPublic,
+	validity time.Duration,
+	sign func(message []byte) ([]byte, error),
+) (*Certificate, error) {
+	now := time.Now()
+	serial := uint64(now.UnixNano())
+	expiresAt := time.Unix(now.Add(validity).Unix(), 0)
+
+	signature, err := sign(
+		certificateMessage(networkPublicKey, serial, expiresAt),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("could not sign the certificate: [%v]", err)
+	}
+
+	certificate := &Certificate{
+		OperatorPublicKey: operatorPublicKey,
+		NetworkPublicKey:  networkPublicKey,
+		Serial:            serial,
+		ExpiresAt:         expiresAt,
+		Signature:         signature,
+	}
+
+	if err := certificate.Verify(); err != nil {
+		return nil, fmt.Errorf("issued certificate is invalid: [%v]", err)
+	}
+
+	return certificate, nil
+}
+
+// Verify checks if the certificate has been signed by the operator key it
+// carries and if it has not expired yet.
+func (c *Certificate) Verify() error {
+	if err := c.verifySignature(); err != nil {
+		return err
+	}
+
+	if c.IsExpired(time.Now()) {
+		return fmt.Errorf("certificate expired at [%v]", c.ExpiresAt)
+	}
+
+	return nil
+}
+
+// IsExpired returns true if the certificate is no longer valid at the given
+// time.
+func (c *Certificate) IsExpired(at time.Time) bool {
+	return !at.Before(c.ExpiresAt)
+}
+
+// Supersedes returns true if the certificate has been issued by the same
+// operator as the other certificate and has a higher serial number.
+func (c *Certificate) Supersedes(other *Certificate) bool {
+	return c.OperatorAddress() == other.OperatorAddress() &&
+		c.Serial > other.Serial
+}
+
+// verifySignature checks if the certificate has been signed by the operator
+// key it carries.
+func (c *Certificate) verifySignature() error {
+	if len(c.Signature) != signatureSize {
+		return fmt.Errorf(
+			"certificate signature has invalid length [%v]",
+			len(c.Signature),
+		)
+	}
+
+	// Ethereum-specific signatures have the recovery id in {27, 28}
+	// while go-ethereum/crypto expects it to be in {0, 1}.
+	signature := make([]byte, signatureSize)
+	copy(signature, c.Signature)
+	if signature[signatureSize-1] >= 27 {
+		signature[signatureSize-1] -= 27
+	}
+
+	signerPublicKey, err := crypto.SigToPub(
+		accounts.TextHash(
+			certificateMessage(c.NetworkPublicKey, c.Serial, c.ExpiresAt),
+		),
+		signature,
+	)
+	if err != nil {
+		return fmt.Errorf("could not recover certificate signer: [%v]", err)
+	}
+
+	if !bytes.Equal(
+		crypto.FromECDSAPub(signerPublicKey),
+		crypto.FromECDSAPub(c.OperatorPublicKey),
+	) {
+		return fmt.Errorf("certificate is not signed by the operator key")
+	}
+
+	return nil
+}
+
+// VerifyFor checks if the certificate has been signed by the operator key it
+// carries, if it has not expired yet and if it has been issued for the given
+// network public key.
+func (c *Certificate) VerifyFor(networkPublicKey *NetworkPublic) error {
+	if err := c.checkNetworkKey(networkPublicKey); err != nil {
+		return err
+	}
+
+	return c.Verify()
+}
+
+func (c *Certificate) checkNetworkKey(networkPublicKey *NetworkPublic) error {
+	if !bytes.Equal(Marshal(c.NetworkPublicKey), Marshal(networkPublicKey)) {
+		return fmt.Errorf("certificate has been issued for another network key")
+	}
+
+	return nil
+}
+
+// OperatorAddress returns the Ethereum address of the operator the network
+// key has been authorized by, in a string format.
+func (c *Certificate) OperatorAddress() string {
+	return crypto.PubkeyToAddress(*c.OperatorPublicKey).String()
+}
+
+// Marshal converts this Certificate to a byte array suitable for network
+// communication.
+func (c *Certificate) Marshal() ([]byte, error) {
+	return (&pb.NetworkKeyCertificate{
+		OperatorPublicKey: crypto.FromECDSAPub(c.OperatorPublicKey),
+		NetworkPublicKey:  Marshal(c.NetworkPublicKey),
+		Signature:         c.Signature,
+		Serial:            c.Serial,
+		ExpiresAt:         c.ExpiresAt.Unix(),
+	}).Marshal()
+}
+
+// Unmarshal converts a byte array produced by Marshal to a Certificate.
+// It does not verify the certificate.
+func (c *Certificate) Unmarshal(bytes []byte) error {
+	pbCertificate := pb.NetworkKeyCertificate{}
+	if err := pbCertificate.Unmarshal(bytes); err != nil {
+		return err
+	}
+
+	operatorPublicKey, err := crypto.UnmarshalPubkey(
+		pbCertificate.OperatorPublicKey,
+	)
+	if err != nil {
+		return fmt.Errorf("invalid operator public key: [%v]", err)
+	}
+
+	networkPublicKey, err := btcec.ParsePubKey(
+		pbCertificate.NetworkPublicKey,
+		btcec.S256(),
+	)
+	if err != nil {
+		return fmt.Errorf("invalid network public key: [%v]", err)
+	}
+
+	c.OperatorPublicKey = operatorPublicKey
+	c.NetworkPublicKey = (*NetworkPublic)(networkPublicKey)
+	c.Serial = pbCertificate.Serial
+	c.ExpiresAt = time.Unix(pbCertificate.ExpiresAt, 0)
+	c.Signature = pbCertificate.Signature
+
+	return nil
+}
+
+// UnmarshalCertificate converts a byte array produced by Certificate.Marshal
+// to a Certificate. Empty byte array means there is no certificate and the
+// network key is the operator key; nil is returned in this case.
+func UnmarshalCertificate(bytes []byte) (*Certificate, error) {
+	if len(bytes) == 0 {
+		return nil, nil
+	}
+
+	certificate := &Certificate{}
+	if err := certificate.Unmarshal(bytes); err != nil {
+		return nil, fmt.Errorf("could not unmarshal certificate: [%v]", err)
+	}
+
+	return certificate, nil
+}
+
+// MarshalCertificate converts the certificate to a byte array. A nil
+// certificate is marshaled to an empty byte array.
+func MarshalCertificate(certificate *Certificate) ([]byte, error) {
+	if certificate == nil {
+		return nil, nil
+	}
+
+	return certificate.Marshal()
+}
+
+// OperatorPublicKey returns the public key of the operator the network key
+// acts on behalf of. If there is no certificate, the network key is the
+// operator key. The certificate is expected to be already verified against
+// the network key.
+func OperatorPublicKey(
+	networkPublicKey *NetworkPublic,
+	certificate *Certificate,
+) *operator.PublicKey {
+	if certificate != nil {
+		return certificate.OperatorPublicKey
+	}
+
+	return NetworkKeyToECDSAKey(networkPublicKey)
+}
+
+func certificateMessage(
+	networkPublicKey *NetworkPublic,
+	serial uint64,
+	expiresAt time.Time,
+) []byte {
+	message := append([]byte(certificatePrefix), Marshal(networkPublicKey)...)
+
+	validity := make([]byte, 16)
+	binary.BigEndian.PutUint64(validity[:8], serial)
+	binary.BigEndian.PutUint64(validity[8:], uint64(expiresAt.Unix()))
+
+	return append(message, validity...)
+}
@@ -0,0 +1,333 @@
+package key
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/keep-network/keep-common/pkg/chain/ethereum/ethutil"
+	"github.com/keep-network/keep-core/pkg/operator"
+)
+
+func TestIssueAndVerifyCertificate(t *testing.T) {
+	operatorPrivateKey, operatorPublicKey, err := operator.GenerateKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, networkPublicKey, err := GenerateStaticNetworkKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certificate, err := IssueCertificate(
+		operatorPublicKey,
+		networkPublicKey,
+		DefaultCertificateValidity,
+		ethutil.NewSigner(operatorPrivateKey).Sign,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := certificate.VerifyFor(networkPublicKey); err != nil {
+		t.Fatal(err)
+	}
+
+	_, anotherNetworkPublicKey, err := GenerateStaticNetworkKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := certificate.VerifyFor(anotherNetworkPublicKey); err == nil {
+		t.Errorf("expected error for another network key")
+	}
+}
+
+func TestIssueCertificateSignedByAnotherKey(t *testing.T) {
+	_, operatorPublicKey, err := operator.GenerateKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	anotherPrivateKey, _, err := operator.GenerateKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, networkPublicKey, err := GenerateStaticNetworkKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = IssueCertificate(
+		operatorPublicKey,
+		networkPublicKey,
+		DefaultCertificateValidity,
+		ethutil.NewSigner(anotherPrivateKey).Sign,
+	)
+	if err == nil {
+		t.Fatal("expected error for certificate signed by another key")
+	}
+}
+
+func TestCertificateMarshalRoundTrip(t *testing.T) {
+	certificate := newTestCertificate(t)
+
+	marshaled, err := certificate.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	unmarshaled, err := UnmarshalCertificate(marshaled)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(certificate.OperatorPublicKey, unmarshaled.OperatorPublicKey) {
+		t.Errorf("unexpected operator public key")
+	}
+	if !bytes.Equal(
+		Marshal(certificate.NetworkPublicKey),
+		Marshal(unmarshaled.NetworkPublicKey),
+	) {
+		t.Errorf("unexpected network public key")
+	}
+	if !bytes.Equal(certificate.Signature, unmarshaled.Signature) {
+		t.Errorf("unexpected signature")
+	}
+	if certificate.Serial != unmarshaled.Serial {
+		t.Errorf(
+			"unexpected serial\nexpected: [%v]\nactual:   [%v]",
+			certificate.Serial,
+			unmarshaled.Serial,
+		)
+	}
+	if !certificate.ExpiresAt.Equal(unmarshaled.ExpiresAt) {
+		t.Errorf(
+			"unexpected expiry\nexpected: [%v]\nactual:   [%v]",
+			certificate.ExpiresAt,
+			unmarshaled.ExpiresAt,
+		)
+	}
+
+	if err := unmarshaled.Verify(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCertificateExpiry(t *testing.T) {
+	certificate := newTestCertificate(t)
+
+	if certificate.IsExpired(time.Now()) {
+		t.Errorf("expected certificate not to be expired yet")
+	}
+
+	expiredAt := time.Now().Add(DefaultCertificateValidity + time.Minute)
+	if !certificate.IsExpired(expiredAt) {
+		t.Errorf("expected certificate to be expired after its validity")
+	}
+
+	operatorPrivateKey, operatorPublicKey, err := operator.GenerateKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, networkPublicKey, err := GenerateStaticNetworkKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = IssueCertificate(
+		operatorPublicKey,
+		networkPublicKey,
+		-time.Minute,
+		ethutil.NewSigner(operatorPrivateKey).Sign,
+	)
+	if err == nil {
+		t.Fatal("expected error for already expired certificate")
+	}
+}
+
+func TestCertificateValidityIsSigned(t *testing.T) {
+	var tests = map[string]func(certificate *Certificate){
+		"serial changed": func(certificate *Certificate) {
+			certificate.Serial++
+		},
+		"expiry extended": func(certificate *Certificate) {
+			certificate.ExpiresAt = certificate.ExpiresAt.Add(time.Hour)
+		},
+	}
+
+	for testName, tamper := range tests {
+		t.Run(testName, func(t *testing.T) {
+			certificate := newTestCertificate(t)
+			tamper(certificate)
+
+			if err := certificate.Verify(); err == nil {
+				t.Fatal("expected error for tampered certificate")
+			}
+		})
+	}
+}
+
+func TestCertificateSupersedes(t *testing.T) {
+	operatorPrivateKey, operatorPublicKey, err := operator.GenerateKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	issue := func() *Certificate {
+		_, networkPublicKey, err := GenerateStaticNetworkKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		certificate, err := IssueCertificate(
+			operatorPublicKey,
+			networkPublicKey,
+			DefaultCertificateValidity,
+			ethutil.NewSigner(operatorPrivateKey).Sign,
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return certificate
+	}
+
+	first := issue()
+	second := issue()
+
+	if !second.Supersedes(first) {
+		t.Errorf("expected later certificate to supersede the earlier one")
+	}
+	if first.Supersedes(second) {
+		t.Errorf("expected earlier certificate not to supersede the later one")
+	}
+	if second.Supersedes(second) {
+		t.Errorf("expected certificate not to supersede itself")
+	}
+
+	if newTestCertificate(t).Supersedes(first) {
+		t.Errorf("expected certificate of another operator not to supersede")
+	}
+}
+
+func TestUnmarshalEmptyCertificate(t *testing.T) {
+	certificate, err := UnmarshalCertificate(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if certificate != nil {
+		t.Errorf("expected no certificate")
+	}
+}
+
+func TestNetworkKeyFileRoundTrip(t *testing.T) {
+	dir, err := ioutil.TempDir("", "network-key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	operatorPrivateKey, operatorPublicKey, err := operator.GenerateKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	networkPrivateKey, networkPublicKey, err := GenerateStaticNetworkKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certificate, err := IssueCertificate(
+		operatorPublicKey,
+		networkPublicKey,
+		DefaultCertificateValidity,
+		ethutil.NewSigner(operatorPrivateKey).Sign,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(dir, "network-key.json")
+
+	if err := WriteNetworkKeyFile(path, networkPrivateKey, certificate); err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("unexpected file permissions [%v]", info.Mode().Perm())
+	}
+
+	readPrivateKey, readCertificate, err := ReadNetworkKeyFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !readPrivateKey.Equals(networkPrivateKey) {
+		t.Errorf("unexpected network private key")
+	}
+	if readCertificate.OperatorAddress() != certificate.OperatorAddress() {
+		t.Errorf(
+			"unexpected operator address\nexpected: [%v]\nactual:   [%v]",
+			certificate.OperatorAddress(),
+			readCertificate.OperatorAddress(),
+		)
+	}
+}
+
+func TestWriteNetworkKeyFileWithCertificateForAnotherKey(t *testing.T) {
+	dir, err := ioutil.TempDir("", "network-key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	networkPrivateKey, _, err := GenerateStaticNetworkKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = WriteNetworkKeyFile(
+		filepath.Join(dir, "network-key.json"),
+		networkPrivateKey,
+		newTestCertificate(t),
+	)
+	if err == nil {
+		t.Fatal("expected error for certificate issued for another key")
+	}
+}
+
+func newTestCertificate(t *testing.T) *Certificate {
+	operatorPrivateKey, operatorPublicKey, err := operator.GenerateKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, networkPublicKey, err := GenerateStaticNetworkKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certificate, err := IssueCertificate(
+		operatorPublicKey,
+		networkPublicKey,
+		DefaultCertificateValidity,
+		ethutil.NewSigner(operatorPrivateKey).Sign,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return certificate
+}
@@ -0,0 +1,134 @@
+package key
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	libp2pcrypto "github.com/libp2p/go-libp2p-core/crypto"
+)
+
+// networkKeyFile is the JSON representation of the network key file.
+type networkKeyFile struct {
+	PrivateKey  hexutil.Bytes `json:"privateKey"`
+	Certificate hexutil.Bytes `json:"certificate"`
+}
+
+// WriteNetworkKeyFile stores the network private key along with the
+// certificate authorizing it in the file under the given path. The file is
+// replaced atomically if it already exists so a running client never
+// observes a partially written key.
+//
+// The network key is stored unencrypted and is readable only by the owner
+// of the file. Unlike the operator key, it gives no control over the stake
+// and a compromised network key can be replaced by issuing a new one.
+func WriteNetworkKeyFile(
+	path string,
+	privateKey *NetworkPrivate,
+	certificate *Certificate,
+) error {
+	if err := certificate.VerifyFor(privateKey.GetPublic().(*NetworkPublic)); err != nil {
+		return fmt.Errorf("invalid network key certificate: [%v]", err)
+	}
+
+	privateKeyBytes, err := libp2pcrypto.MarshalPrivateKey(privateKey)
+	if err != nil {
+		return fmt.Errorf("could not marshal network private key: [%v]", err)
+	}
+
+	certificateBytes, err := certificate.Marshal()
+	if err != nil {
+		return fmt.Errorf("could not marshal certificate: [%v]", err)
+	}
+
+	content, err := json.MarshalIndent(
+		&networkKeyFile{
+			PrivateKey:  privateKeyBytes,
+			Certificate: certificateBytes,
+		},
+		"",
+		"  ",
+	)
+	if err != nil {
+		return err
+	}
+
+	tempFile, err := ioutil.TempFile(filepath.Dir(path), ".network-key-*")
+	if err != nil {
+		return fmt.Errorf("could not create temporary key file: [%v]", err)
+	}
+	defer os.Remove(tempFile.Name())
+
+	if _, err := tempFile.Write(content); err != nil {
+		tempFile.Close()
+		return fmt.Errorf("could not write temporary key file: [%v]", err)
+	}
+	if err := tempFile.Close(); err != nil {
+		return fmt.Errorf("could not close temporary key file: [%v]", err)
+	}
+
+	// ioutil.TempFile creates files with 0600 permissions already; we make
+	// sure it stays that way regardless of the platform.
+	if err := os.Chmod(tempFile.Name(), 0600); err != nil {
+		return err
+	}
+
+	return os.Rename(tempFile.Name(), path)
+}
+
+// ReadNetworkKeyFile reads the network private key and the certificate
+// authorizing it from the file under the given path. The certificate is
+// verified against the private key before it is returned. An expired
+// certificate is returned as well so that the key can be rotated; it is up to
+// the caller to check the expiry.
+func ReadNetworkKeyFile(path string) (*NetworkPrivate, *Certificate, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf(
+			"could not read network key file [%v]: [%v]",
+			path,
+			err,
+		)
+	}
+
+	var file networkKeyFile
+	if err := json.Unmarshal(content, &file); err != nil {
+		return nil, nil, fmt.Errorf(
+			"could not parse network key file [%v]: [%v]",
+			path,
+			err,
+		)
+	}
+
+	libp2pPrivateKey, err := libp2pcrypto.UnmarshalPrivateKey(file.PrivateKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf(
+			"could not unmarshal network private key: [%v]",
+			err,
+		)
+	}
+
+	privateKey, ok := libp2pPrivateKey.(*NetworkPrivate)
+	if !ok {
+		return nil, nil, fmt.Errorf("network private key is of unexpected type")
+	}
+
+	certificate := &Certificate{}
+	if err := certificate.Unmarshal(file.Certificate); err != nil {
+		return nil, nil, fmt.Errorf("could not unmarshal certificate: [%v]", err)
+	}
+
+	if err := certificate.checkNetworkKey(
+		privateKey.GetPublic().(*NetworkPublic),
+	); err != nil {
+		return nil, nil, fmt.Errorf("invalid network key certificate: [%v]", err)
+	}
+	if err := certificate.verifySignature(); err != nil {
+		return nil, nil, fmt.Errorf("invalid network key certificate: [%v]", err)
+	}
+
+	return privateKey, certificate, nil
+}
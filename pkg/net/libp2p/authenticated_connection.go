@@ -16,7 +16,9 @@ import (
 )
 
 // Enough space for a proto-encoded envelope with a message, peer.ID, and sig.
-const maxFrameSize = 1024
+// The message carries a network key certificate of each operator run by the
+// peer, about 200 bytes each.
+const maxFrameSize = 8192
 
 // authenticatedConnection turns inbound and outbound unauthenticated,
 // plain-text connections into authenticated, plain-text connections. Noticeably,
@@ -27,12 +29,24 @@ type authenticatedConnection struct {
 	localPeerID         peer.ID
 	localPeerPrivateKey libp2pcrypto.PrivKey
 
-	remotePeerID        peer.ID
-	remotePeerPublicKey libp2pcrypto.PubKey
+	localCertificate          *key.Certificate
+	localOperatorCertificates []*key.Certificate
 
-	firewall keepNet.Firewall
+	remotePeerID                   peer.ID
+	remotePeerPublicKey            libp2pcrypto.PubKey
+	remoteCertificateData          []byte
+	remoteOperatorCertificatesData [][]byte
+
+	firewall     keepNet.Firewall
+	certificates *certificateStore
+	reputation   keepNet.Reputation
 
 	protocol string
+
+	localCapabilities *handshake.Capabilities
+	capabilities      *capabilitiesStore
+	// negotiated is set once the handshake completes.
+	negotiated *handshake.Negotiated
 }
 
 // newAuthenticatedInboundConnection is the connection that's formed by
@@ -45,18 +59,42 @@ func newAuthenticatedInboundConnection(
 	unauthenticatedConn net.Conn,
 	localPeerID peer.ID,
 	privateKey libp2pcrypto.PrivKey,
+	localCertificate *key.Certificate,
+	localOperatorCertificates []*key.Certificate,
 	firewall keepNet.Firewall,
+	certificates *certificateStore,
+	reputation keepNet.Reputation,
 	protocol string,
+	localCapabilities *handshake.Capabilities,
+	capabilities *capabilitiesStore,
 ) (*authenticatedConnection, error) {
 	ac := &authenticatedConnection{
-		Conn:                unauthenticatedConn,
-		localPeerID:         localPeerID,
-		localPeerPrivateKey: privateKey,
-		firewall:            firewall,
-		protocol:            protocol,
+		Conn:                      unauthenticatedConn,
+		localPeerID:               localPeerID,
+		localPeerPrivateKey:       privateKey,
+		localCertificate:          localCertificate,
+		localOperatorCertificates: localOperatorCertificates,
+		firewall:                  firewall,
+		certificates:              certificates,
+		reputation:                reputation,
+		protocol:                  protocol,
+		localCapabilities:         localCapabilities,
+		capabilities:              capabilities,
 	}
 
 	if err := ac.runHandshakeAsResponder(); err != nil {
+		// Only failures of handshakes initiated by the remote peer are
+		// reported. The remote peer could have a good reason to abort the
+		// handshake initiated by us, e.g. because of its firewall rules.
+		// Peers running an incompatible client version are not misbehaving.
+		_, incompatible := err.(*handshake.IncompatibleError)
+		if ac.remotePeerID != "" && !incompatible {
+			ac.reputation.ReportMisbehavior(
+				ac.remotePeerID,
+				keepNet.HandshakeFailure,
+			)
+		}
+
 		// close the conn before returning (if it hasn't already)
 		// otherwise we leak.
 		if closeErr := ac.Close(); closeErr != nil {
@@ -66,6 +104,17 @@ func newAuthenticatedInboundConnection(
 		return nil, fmt.Errorf("connection handshake failed: [%v]", err)
 	}
 
+	if ac.reputation.IsBanned(ac.remotePeerID.String()) {
+		if closeErr := ac.Close(); closeErr != nil {
+			logger.Debugf("could not close the connection: [%v]", closeErr)
+		}
+
+		return nil, fmt.Errorf(
+			"connection handshake failed: remote peer [%v] is banned",
+			ac.remotePeerID,
+		)
+	}
+
 	if err := ac.checkFirewallRules(); err != nil {
 		if closeErr := ac.Close(); closeErr != nil {
 			logger.Debugf("could not close the connection: [%v]", closeErr)
@@ -86,9 +135,15 @@ func newAuthenticatedOutboundConnection(
 	unauthenticatedConn net.Conn,
 	localPeerID peer.ID,
 	privateKey libp2pcrypto.PrivKey,
+	localCertificate *key.Certificate,
+	localOperatorCertificates []*key.Certificate,
 	remotePeerID peer.ID,
 	firewall keepNet.Firewall,
+	certificates *certificateStore,
+	reputation keepNet.Reputation,
 	protocol string,
+	localCapabilities *handshake.Capabilities,
+	capabilities *capabilitiesStore,
 ) (*authenticatedConnection, error) {
 	remotePublicKey, err := remotePeerID.ExtractPublicKey()
 	if err != nil {
@@ -99,13 +154,19 @@ func newAuthenticatedOutboundConnection(
 	}
 
 	ac := &authenticatedConnection{
-		Conn:                unauthenticatedConn,
-		localPeerID:         localPeerID,
-		localPeerPrivateKey: privateKey,
-		remotePeerID:        remotePeerID,
-		remotePeerPublicKey: remotePublicKey,
-		firewall:            firewall,
-		protocol:            protocol,
+		Conn:                      unauthenticatedConn,
+		localPeerID:               localPeerID,
+		localPeerPrivateKey:       privateKey,
+		localCertificate:          localCertificate,
+		localOperatorCertificates: localOperatorCertificates,
+		remotePeerID:              remotePeerID,
+		remotePeerPublicKey:       remotePublicKey,
+		firewall:                  firewall,
+		certificates:              certificates,
+		reputation:                reputation,
+		protocol:                  protocol,
+		localCapabilities:         localCapabilities,
+		capabilities:              capabilities,
 	}
 
 	if err := ac.runHandshakeAsInitiator(); err != nil {
@@ -116,6 +177,17 @@ func newAuthenticatedOutboundConnection(
 		return nil, fmt.Errorf("connection handshake failed: [%v]", err)
 	}
 
+	if ac.reputation.IsBanned(ac.remotePeerID.String()) {
+		if closeErr := ac.Close(); closeErr != nil {
+			logger.Debugf("could not close the connection: [%v]", closeErr)
+		}
+
+		return nil, fmt.Errorf(
+			"connection handshake failed: remote peer [%v] is banned",
+			ac.remotePeerID,
+		)
+	}
+
 	if err := ac.checkFirewallRules(); err != nil {
 		if closeErr := ac.Close(); closeErr != nil {
 			logger.Debugf("could not close the connection: [%v]", closeErr)
@@ -127,13 +199,109 @@ func newAuthenticatedOutboundConnection(
 	return ac, nil
 }
 
+// checkFirewallRules validates the remote peer against the firewall rules
+// using the network key certificate the remote peer presented during the
+// handshake. Certificates of additional operators run by the remote peer
+// have to be valid for the peer's network key as well, though the firewall
+// rules are applied to the operator of the network key certificate only. If
+// the remote peer passed the rules, its certificates are stored so that they
+// can be used later to resolve the peer's operator and to detect superseded
+// certificates.
 func (ac *authenticatedConnection) checkFirewallRules() error {
 	networkKey, ok := ac.remotePeerPublicKey.(*key.NetworkPublic)
 	if !ok {
 		return fmt.Errorf("unexpected type of remote peer's public key")
 	}
 
-	return ac.firewall.Validate(key.NetworkKeyToECDSAKey(networkKey))
+	certificate, err := key.UnmarshalCertificate(ac.remoteCertificateData)
+	if err != nil {
+		return err
+	}
+
+	if certificate != nil {
+		if err := certificate.VerifyFor(networkKey); err != nil {
+			return fmt.Errorf(
+				"invalid remote peer's network key certificate: [%v]",
+				err,
+			)
+		}
+	}
+
+	operatorCertificates := make(
+		[]*key.Certificate,
+		0,
+		len(ac.remoteOperatorCertificatesData),
+	)
+	for _, operatorCertificateData := range ac.remoteOperatorCertificatesData {
+		operatorCertificate, err := key.UnmarshalCertificate(
+			operatorCertificateData,
+		)
+		if err != nil {
+			return err
+		}
+
+		if operatorCertificate == nil {
+			return fmt.Errorf("empty remote peer's operator certificate")
+		}
+
+		if err := operatorCertificate.VerifyFor(networkKey); err != nil {
+			return fmt.Errorf(
+				"invalid remote peer's operator certificate: [%v]",
+				err,
+			)
+		}
+
+		operatorCertificates = append(operatorCertificates, operatorCertificate)
+	}
+
+	if err := ac.firewall.Validate(
+		key.NetworkKeyToECDSAKey(networkKey),
+		certificate,
+	); err != nil {
+		return err
+	}
+
+	if ac.certificates != nil {
+		if err := ac.certificates.add(
+			ac.remotePeerID,
+			certificate,
+			operatorCertificates...,
+		); err != nil {
+			return err
+		}
+	}
+
+	if ac.capabilities != nil && ac.negotiated != nil {
+		ac.capabilities.add(ac.remotePeerID, ac.negotiated)
+	}
+
+	return nil
+}
+
+func (ac *authenticatedConnection) localCertificateData() ([]byte, error) {
+	return key.MarshalCertificate(ac.localCertificate)
+}
+
+func (ac *authenticatedConnection) localOperatorCertificatesData() (
+	[][]byte,
+	error,
+) {
+	operatorCertificatesData := make(
+		[][]byte,
+		len(ac.localOperatorCertificates),
+	)
+	for i, operatorCertificate := range ac.localOperatorCertificates {
+		operatorCertificateData, err := key.MarshalCertificate(
+			operatorCertificate,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		operatorCertificatesData[i] = operatorCertificateData
+	}
+
+	return operatorCertificatesData, nil
 }
 
 func (ac *authenticatedConnection) runHandshakeAsInitiator() error {
@@ -146,7 +314,22 @@ func (ac *authenticatedConnection) runHandshakeAsInitiator() error {
 	// Act 1
 	//
 
-	initiatorAct1, err := handshake.InitiateHandshake(ac.protocol)
+	localCertificateData, err := ac.localCertificateData()
+	if err != nil {
+		return err
+	}
+
+	localOperatorCertificatesData, err := ac.localOperatorCertificatesData()
+	if err != nil {
+		return err
+	}
+
+	initiatorAct1, err := handshake.InitiateHandshake(
+		ac.protocol,
+		localCertificateData,
+		localOperatorCertificatesData,
+		ac.localCapabilities,
+	)
 	if err != nil {
 		return err
 	}
@@ -176,6 +359,10 @@ func (ac *authenticatedConnection) runHandshakeAsInitiator() error {
 		return err
 	}
 
+	ac.remoteCertificateData = act2Message.Certificate()
+	ac.remoteOperatorCertificatesData = act2Message.OperatorCertificates()
+	ac.negotiated = initiatorAct3.Negotiated()
+
 	//
 	// Act 3
 	//
@@ -287,11 +474,31 @@ func (ac *authenticatedConnection) runHandshakeAsResponder() error {
 		return err
 	}
 
-	responderAct2, err := handshake.AnswerHandshake(act1Message, ac.protocol)
+	localCertificateData, err := ac.localCertificateData()
 	if err != nil {
 		return err
 	}
 
+	localOperatorCertificatesData, err := ac.localOperatorCertificatesData()
+	if err != nil {
+		return err
+	}
+
+	responderAct2, err := handshake.AnswerHandshake(
+		act1Message,
+		ac.protocol,
+		localCertificateData,
+		localOperatorCertificatesData,
+		ac.localCapabilities,
+	)
+	if err != nil {
+		return err
+	}
+
+	ac.remoteCertificateData = act1Message.Certificate()
+	ac.remoteOperatorCertificatesData = act1Message.OperatorCertificates()
+	ac.negotiated = responderAct2.Negotiated()
+
 	//
 	// Act 2
 	//
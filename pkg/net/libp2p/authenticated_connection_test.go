@@ -11,10 +11,13 @@ import (
 	"time"
 
 	protoio "github.com/gogo/protobuf/io"
+	"github.com/keep-network/keep-common/pkg/chain/ethereum/ethutil"
 	keepNet "github.com/keep-network/keep-core/pkg/net"
 	"github.com/keep-network/keep-core/pkg/net/gen/pb"
 	"github.com/keep-network/keep-core/pkg/net/key"
+	"github.com/keep-network/keep-core/pkg/net/reputation"
 	"github.com/keep-network/keep-core/pkg/net/security/handshake"
+	"github.com/keep-network/keep-core/pkg/operator"
 	libp2pcrypto "github.com/libp2p/go-libp2p-core/crypto"
 	peer "github.com/libp2p/go-libp2p-core/peer"
 )
@@ -52,8 +55,14 @@ func TestPinnedAndMessageKeyMismatch(t *testing.T) {
 		responderConn,
 		responder.peerID,
 		responder.privKey,
+		responder.certificate,
+		responder.operatorCertificates,
 		firewall,
+		responder.certificates,
+		responder.reputation,
 		ProtocolBeacon,
+		responder.localCapabilities,
+		responder.capabilities,
 	)
 	if err == nil {
 		t.Fatal("should not have successfully completed handshake")
@@ -68,7 +77,7 @@ func maliciousInitiatorHijacksHonestRun(t *testing.T, ac *authenticatedConnectio
 	initiatorConnectionReader := protoio.NewDelimitedReader(ac.Conn, maxFrameSize)
 	initiatorConnectionWriter := protoio.NewDelimitedWriter(ac.Conn)
 
-	initiatorAct1, err := handshake.InitiateHandshake(ProtocolBeacon)
+	initiatorAct1, err := handshake.InitiateHandshake(ProtocolBeacon, nil, nil, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -154,6 +163,166 @@ func TestHandshake(t *testing.T) {
 	}
 }
 
+func TestHandshakeWithCertificates(t *testing.T) {
+	_, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	initiator, initiatorOperator := createCertifiedTestConnectionConfig(t)
+	responder, responderOperator := createCertifiedTestConnectionConfig(t)
+
+	// Only operators meet firewall rules, network keys on their own do not.
+	firewall := newMockFirewall()
+	firewall.updateOperator(initiatorOperator, true)
+	firewall.updateOperator(responderOperator, true)
+
+	_, _, inboundError, outboundError :=
+		connectInitiatorAndResponder(initiator, responder, firewall, t)
+	if inboundError != nil {
+		t.Fatal(inboundError)
+	}
+	if outboundError != nil {
+		t.Fatal(outboundError)
+	}
+
+	initiatorCertificate, ok := responder.certificates.get(initiator.peerID)
+	if !ok {
+		t.Fatal("responder does not know initiator's certificate")
+	}
+	if initiatorCertificate.OperatorAddress() != initiator.certificate.OperatorAddress() {
+		t.Errorf(
+			"unexpected initiator's operator\nexpected: [%v]\nactual:   [%v]",
+			initiator.certificate.OperatorAddress(),
+			initiatorCertificate.OperatorAddress(),
+		)
+	}
+
+	responderCertificate, ok := initiator.certificates.get(responder.peerID)
+	if !ok {
+		t.Fatal("initiator does not know responder's certificate")
+	}
+	if responderCertificate.OperatorAddress() != responder.certificate.OperatorAddress() {
+		t.Errorf(
+			"unexpected responder's operator\nexpected: [%v]\nactual:   [%v]",
+			responder.certificate.OperatorAddress(),
+			responderCertificate.OperatorAddress(),
+		)
+	}
+
+	initiatorCapabilities := responder.capabilities.get(initiator.peerID)
+	if !initiatorCapabilities.Supports(FeatureMissedMessages) {
+		t.Errorf(
+			"unexpected initiator's capabilities: [%+v]",
+			initiatorCapabilities,
+		)
+	}
+
+	responderCapabilities := initiator.capabilities.get(responder.peerID)
+	if !responderCapabilities.Supports(FeatureMissedMessages) {
+		t.Errorf(
+			"unexpected responder's capabilities: [%+v]",
+			responderCapabilities,
+		)
+	}
+}
+
+func TestHandshakeWithCertificateForAnotherKey(t *testing.T) {
+	_, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	initiator, initiatorOperator := createCertifiedTestConnectionConfig(t)
+	responder := createTestConnectionConfig(t)
+
+	// The initiator presents a certificate issued for another network key.
+	anotherInitiator, _ := createCertifiedTestConnectionConfig(t)
+	initiator.certificate = anotherInitiator.certificate
+
+	firewall := newMockFirewall()
+	firewall.updateOperator(initiatorOperator, true)
+	firewall.updatePeer(responder.pubKey, true)
+
+	_, _, _, inboundError :=
+		connectInitiatorAndResponder(initiator, responder, firewall, t)
+	if inboundError == nil {
+		t.Fatal("responder should reject certificate issued for another key")
+	}
+}
+
+func TestHandshakeWithOperatorCertificates(t *testing.T) {
+	initiator, initiatorOperator := createCertifiedTestConnectionConfig(t)
+	responder := createTestConnectionConfig(t)
+
+	// More additional operators than fit in the legacy 1 KiB frame.
+	for i := 0; i < 5; i++ {
+		addOperatorCertificate(t, initiator)
+	}
+	addOperatorCertificate(t, responder)
+
+	firewall := newMockFirewall()
+	firewall.updateOperator(initiatorOperator, true)
+	firewall.updatePeer(responder.pubKey, true)
+
+	_, _, inboundError, outboundError :=
+		connectInitiatorAndResponder(initiator, responder, firewall, t)
+	if inboundError != nil {
+		t.Fatal(inboundError)
+	}
+	if outboundError != nil {
+		t.Fatal(outboundError)
+	}
+
+	assertOperatorCertificates := func(
+		store *certificateStore,
+		peer *testConnectionConfig,
+	) {
+		storedCertificates := store.operatorCertificates[peer.peerID]
+		if len(storedCertificates) != len(peer.operatorCertificates) {
+			t.Fatalf(
+				"unexpected number of operator certificates\n"+
+					"expected: [%v]\nactual:   [%v]",
+				len(peer.operatorCertificates),
+				len(storedCertificates),
+			)
+		}
+
+		for i, certificate := range peer.operatorCertificates {
+			if storedCertificates[i].OperatorAddress() !=
+				certificate.OperatorAddress() {
+				t.Errorf(
+					"unexpected operator of certificate [%v]\n"+
+						"expected: [%v]\nactual:   [%v]",
+					i,
+					certificate.OperatorAddress(),
+					storedCertificates[i].OperatorAddress(),
+				)
+			}
+		}
+	}
+
+	assertOperatorCertificates(responder.certificates, initiator)
+	assertOperatorCertificates(initiator.certificates, responder)
+}
+
+func TestHandshakeWithOperatorCertificateForAnotherKey(t *testing.T) {
+	initiator := createTestConnectionConfig(t)
+	responder := createTestConnectionConfig(t)
+
+	// The initiator presents an operator certificate issued for another
+	// network key.
+	anotherInitiator := createTestConnectionConfig(t)
+	addOperatorCertificate(t, anotherInitiator)
+	initiator.operatorCertificates = anotherInitiator.operatorCertificates
+
+	firewall := newMockFirewall()
+	firewall.updatePeer(initiator.pubKey, true)
+	firewall.updatePeer(responder.pubKey, true)
+
+	_, _, _, inboundError :=
+		connectInitiatorAndResponder(initiator, responder, firewall, t)
+	if inboundError == nil {
+		t.Fatal("responder should reject certificate issued for another key")
+	}
+}
+
 func TestHandshakeInitiatorBlockedByFirewallRules(t *testing.T) {
 	_, cancel := context.WithTimeout(context.Background(), 2*time.Second)
 	defer cancel()
@@ -210,6 +379,39 @@ func TestHandshakeResponderBlockedByFirewallRules(t *testing.T) {
 	}
 }
 
+func TestHandshakeWithBannedPeer(t *testing.T) {
+	_, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	initiator := createTestConnectionConfig(t)
+	responder := createTestConnectionConfig(t)
+
+	firewall := newMockFirewall()
+	firewall.updatePeer(initiator.pubKey, true)
+	firewall.updatePeer(responder.pubKey, true)
+
+	responder.reputation.Ban(initiator.peerID.String(), time.Minute)
+
+	_, _, outboundError, inboundError :=
+		connectInitiatorAndResponder(initiator, responder, firewall, t)
+
+	expectedInboundError := fmt.Errorf(
+		"connection handshake failed: remote peer [%v] is banned",
+		initiator.peerID,
+	)
+	if !reflect.DeepEqual(expectedInboundError, inboundError) {
+		t.Fatalf(
+			"unexpected inbound connection error\nexpected: %v\nactual: %v",
+			expectedInboundError,
+			inboundError,
+		)
+	}
+
+	if outboundError != nil {
+		t.Fatal(outboundError)
+	}
+}
+
 func connectInitiatorAndResponder(
 	initiator *testConnectionConfig,
 	responder *testConnectionConfig,
@@ -236,9 +438,15 @@ func connectInitiatorAndResponder(
 			initiatorConn,
 			initiatorPeerID,
 			initiatorPrivKey,
+			initiator.certificate,
+			initiator.operatorCertificates,
 			responderPeerID,
 			firewall,
+			initiator.certificates,
+			initiator.reputation,
 			ProtocolBeacon,
+			initiator.localCapabilities,
+			initiator.capabilities,
 		)
 		done <- struct{}{}
 	}(initiatorConn, initiator.peerID, initiator.privKey, responder.peerID)
@@ -247,8 +455,14 @@ func connectInitiatorAndResponder(
 		responderConn,
 		responder.peerID,
 		responder.privKey,
+		responder.certificate,
+		responder.operatorCertificates,
 		firewall,
+		responder.certificates,
+		responder.reputation,
 		ProtocolBeacon,
+		responder.localCapabilities,
+		responder.capabilities,
 	)
 
 	<-done // handshake is done
@@ -257,9 +471,17 @@ func connectInitiatorAndResponder(
 }
 
 type testConnectionConfig struct {
-	privKey *key.NetworkPrivate
-	pubKey  *key.NetworkPublic
-	peerID  peer.ID
+	privKey      *key.NetworkPrivate
+	pubKey       *key.NetworkPublic
+	peerID       peer.ID
+	certificate  *key.Certificate
+	certificates *certificateStore
+	reputation   *reputation.Service
+
+	operatorCertificates []*key.Certificate
+
+	localCapabilities *handshake.Capabilities
+	capabilities      *capabilitiesStore
 }
 
 func createTestConnectionConfig(t *testing.T) *testConnectionConfig {
@@ -272,7 +494,65 @@ func createTestConnectionConfig(t *testing.T) *testConnectionConfig {
 		t.Fatal(err)
 	}
 
-	return &testConnectionConfig{privKey, pubKey, peerID}
+	return &testConnectionConfig{
+		privKey:      privKey,
+		pubKey:       pubKey,
+		peerID:       peerID,
+		certificates: newCertificateStore(),
+		reputation:   reputation.NewService(),
+
+		localCapabilities: localCapabilities("test"),
+		capabilities:      newCapabilitiesStore(),
+	}
+}
+
+// createCertifiedTestConnectionConfig creates a connection config with
+// a network key authorized by a separate operator key.
+func createCertifiedTestConnectionConfig(
+	t *testing.T,
+) (*testConnectionConfig, *operator.PublicKey) {
+	config := createTestConnectionConfig(t)
+
+	operatorPrivateKey, operatorPublicKey, err := operator.GenerateKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config.certificate, err = key.IssueCertificate(
+		operatorPublicKey,
+		config.pubKey,
+		key.DefaultCertificateValidity,
+		ethutil.NewSigner(operatorPrivateKey).Sign,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return config, operatorPublicKey
+}
+
+// addOperatorCertificate authorizes the network key of the connection config
+// to act on behalf of a new additional operator.
+func addOperatorCertificate(t *testing.T, config *testConnectionConfig) {
+	operatorPrivateKey, operatorPublicKey, err := operator.GenerateKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certificate, err := key.IssueCertificate(
+		operatorPublicKey,
+		config.pubKey,
+		key.DefaultCertificateValidity,
+		ethutil.NewSigner(operatorPrivateKey).Sign,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config.operatorCertificates = append(
+		config.operatorCertificates,
+		certificate,
+	)
 }
 
 // Connect an initiator and responder via a full duplex network connection (reads
@@ -291,13 +571,27 @@ type mockFirewall struct {
 	meetsCriteria map[uint64]bool
 }
 
-func (mf *mockFirewall) Validate(remotePeerPublicKey *ecdsa.PublicKey) error {
+func (mf *mockFirewall) Validate(
+	remotePeerPublicKey *ecdsa.PublicKey,
+	certificate *key.Certificate,
+) error {
+	if certificate != nil {
+		remotePeerPublicKey = certificate.OperatorPublicKey
+	}
+
 	if !mf.meetsCriteria[remotePeerPublicKey.X.Uint64()] {
 		return fmt.Errorf("remote peer does not meet firewall criteria")
 	}
 	return nil
 }
 
+func (mf *mockFirewall) updateOperator(
+	operatorPublicKey *operator.PublicKey,
+	meetsCriteria bool,
+) {
+	mf.meetsCriteria[operatorPublicKey.X.Uint64()] = meetsCriteria
+}
+
 func (mf *mockFirewall) updatePeer(
 	remotePeerPublicKey *key.NetworkPublic,
 	meetsCriteria bool,
@@ -0,0 +1,90 @@
+package libp2p
+
+import (
+	"sync"
+
+	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/net/security/handshake"
+	peer "github.com/libp2p/go-libp2p-core/peer"
+)
+
+const (
+	// minProtocolVersion and maxProtocolVersion are the lowest and the
+	// highest network protocol versions supported by the client.
+	minProtocolVersion = handshake.LegacyVersion
+	maxProtocolVersion = handshake.LegacyVersion
+	// minMessageVersion and maxMessageVersion are the lowest and the highest
+	// protocol message versions supported by the client.
+	minMessageVersion = handshake.LegacyVersion
+	maxMessageVersion = handshake.LegacyVersion
+)
+
+// FeatureMissedMessages denotes the peer serves broadcast channel messages
+// missed by other peers.
+const FeatureMissedMessages = "missed-messages"
+
+// localCapabilities returns capabilities offered by the client to remote
+// peers during the connection handshake.
+func localCapabilities(clientVersion string) *handshake.Capabilities {
+	return &handshake.Capabilities{
+		ClientVersion: clientVersion,
+		ProtocolVersions: handshake.VersionRange{
+			Min: minProtocolVersion,
+			Max: maxProtocolVersion,
+		},
+		MessageVersions: handshake.VersionRange{
+			Min: minMessageVersion,
+			Max: maxMessageVersion,
+		},
+		Features: []string{FeatureMissedMessages},
+	}
+}
+
+// capabilitiesStore keeps capabilities negotiated with remote peers during
+// the connection handshake. Only capabilities of peers that passed the
+// firewall rules are stored.
+type capabilitiesStore struct {
+	mutex        sync.RWMutex
+	capabilities map[peer.ID]*net.PeerCapabilities
+}
+
+func newCapabilitiesStore() *capabilitiesStore {
+	return &capabilitiesStore{
+		capabilities: make(map[peer.ID]*net.PeerCapabilities),
+	}
+}
+
+func (cs *capabilitiesStore) add(
+	peerID peer.ID,
+	negotiated *handshake.Negotiated,
+) {
+	cs.mutex.Lock()
+	defer cs.mutex.Unlock()
+
+	cs.capabilities[peerID] = &net.PeerCapabilities{
+		ClientVersion:   negotiated.RemoteClientVersion,
+		ProtocolVersion: negotiated.ProtocolVersion,
+		MessageVersion:  negotiated.MessageVersion,
+		Features:        negotiated.Features,
+	}
+}
+
+// get returns capabilities negotiated with the given peer or nil if they are
+// not known.
+func (cs *capabilitiesStore) get(peerID peer.ID) *net.PeerCapabilities {
+	if cs == nil {
+		return nil
+	}
+
+	cs.mutex.RLock()
+	defer cs.mutex.RUnlock()
+
+	return cs.capabilities[peerID]
+}
+
+func (cs *capabilitiesStore) remove(peerID peer.ID) {
+	cs.mutex.Lock()
+	defer cs.mutex.Unlock()
+
+	delete(cs.capabilities, peerID)
+}
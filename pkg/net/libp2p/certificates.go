@@ -0,0 +1,162 @@
+package libp2p
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/keep-network/keep-core/pkg/net/key"
+	peer "github.com/libp2p/go-libp2p-core/peer"
+)
+
+// certificateStore keeps network key certificates presented by remote peers
+// during the connection handshake. Only certificates of peers that passed
+// the firewall rules are stored.
+//
+// The store keeps track of the latest certificate seen for each operator,
+// including additional operators run by remote peers. A certificate
+// superseded by a certificate with a higher serial number is rejected and
+// peers still holding superseded certificates are disconnected, so a rotated
+// network key can no longer be used to act on behalf of the operator.
+type certificateStore struct {
+	mutex        sync.RWMutex
+	certificates map[peer.ID]*key.Certificate
+	// operatorCertificates holds certificates of additional operators run
+	// by each peer.
+	operatorCertificates map[peer.ID][]*key.Certificate
+	// latest holds the certificate with the highest serial number seen for
+	// each operator address.
+	latest map[string]*key.Certificate
+
+	disconnect func(peer.ID)
+}
+
+func newCertificateStore() *certificateStore {
+	return &certificateStore{
+		certificates:         make(map[peer.ID]*key.Certificate),
+		operatorCertificates: make(map[peer.ID][]*key.Certificate),
+		latest:               make(map[string]*key.Certificate),
+		disconnect:           func(peer.ID) {},
+	}
+}
+
+// onSuperseded sets the function called for each peer whose certificate has
+// been superseded by a certificate presented by another peer.
+func (cs *certificateStore) onSuperseded(disconnect func(peer.ID)) {
+	cs.mutex.Lock()
+	defer cs.mutex.Unlock()
+
+	cs.disconnect = disconnect
+}
+
+// add stores the certificate of the given peer along with the certificates
+// of additional operators run by the peer. A nil certificate is stored as
+// well and denotes the peer's network key is its operator key. An error is
+// returned if any of the certificates has been superseded by another
+// certificate of the same operator.
+func (cs *certificateStore) add(
+	peerID peer.ID,
+	certificate *key.Certificate,
+	operatorCertificates ...*key.Certificate,
+) error {
+	cs.mutex.Lock()
+	defer cs.mutex.Unlock()
+
+	presented := append([]*key.Certificate{certificate}, operatorCertificates...)
+
+	for _, presentedCertificate := range presented {
+		if presentedCertificate == nil {
+			continue
+		}
+
+		operator := presentedCertificate.OperatorAddress()
+
+		latest, ok := cs.latest[operator]
+		if ok && latest.Supersedes(presentedCertificate) {
+			return fmt.Errorf(
+				"certificate with serial [%v] of operator [%v] has been "+
+					"superseded by certificate with serial [%v]",
+				presentedCertificate.Serial,
+				operator,
+				latest.Serial,
+			)
+		}
+	}
+
+	for _, presentedCertificate := range presented {
+		if presentedCertificate == nil {
+			continue
+		}
+
+		operator := presentedCertificate.OperatorAddress()
+
+		latest, ok := cs.latest[operator]
+		if ok && !presentedCertificate.Supersedes(latest) {
+			continue
+		}
+
+		cs.latest[operator] = presentedCertificate
+
+		for otherPeerID := range cs.certificates {
+			if otherPeerID != peerID &&
+				cs.holdsSuperseded(otherPeerID, presentedCertificate) {
+				logger.Warningf(
+					"disconnecting peer [%v] with certificate superseded "+
+						"by a newer certificate of operator [%v]",
+					otherPeerID,
+					operator,
+				)
+
+				delete(cs.certificates, otherPeerID)
+				delete(cs.operatorCertificates, otherPeerID)
+				go cs.disconnect(otherPeerID)
+			}
+		}
+	}
+
+	cs.certificates[peerID] = certificate
+	if len(operatorCertificates) > 0 {
+		cs.operatorCertificates[peerID] = operatorCertificates
+	} else {
+		delete(cs.operatorCertificates, peerID)
+	}
+
+	return nil
+}
+
+// holdsSuperseded returns true if any of the certificates of the given peer
+// is superseded by the given certificate.
+func (cs *certificateStore) holdsSuperseded(
+	peerID peer.ID,
+	certificate *key.Certificate,
+) bool {
+	held := append(
+		[]*key.Certificate{cs.certificates[peerID]},
+		cs.operatorCertificates[peerID]...,
+	)
+
+	for _, heldCertificate := range held {
+		if heldCertificate != nil && certificate.Supersedes(heldCertificate) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// get returns the certificate of the given peer. The second returned value
+// is false if the peer's certificate is unknown.
+func (cs *certificateStore) get(peerID peer.ID) (*key.Certificate, bool) {
+	cs.mutex.RLock()
+	defer cs.mutex.RUnlock()
+
+	certificate, ok := cs.certificates[peerID]
+	return certificate, ok
+}
+
+func (cs *certificateStore) remove(peerID peer.ID) {
+	cs.mutex.Lock()
+	defer cs.mutex.Unlock()
+
+	delete(cs.certificates, peerID)
+	delete(cs.operatorCertificates, peerID)
+}
@@ -0,0 +1,146 @@
+package libp2p
+
+import (
+	"testing"
+	"time"
+
+	"github.com/keep-network/keep-common/pkg/chain/ethereum/ethutil"
+	"github.com/keep-network/keep-core/pkg/net/key"
+	"github.com/keep-network/keep-core/pkg/operator"
+	peer "github.com/libp2p/go-libp2p-core/peer"
+)
+
+func TestCertificateStoreRejectsSupersededCertificate(t *testing.T) {
+	issue := newTestCertificateIssuer(t)
+	oldCertificate := issue()
+	newCertificate := issue()
+
+	store := newCertificateStore()
+
+	if err := store.add(peer.ID("new-peer"), newCertificate); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := store.add(peer.ID("old-peer"), oldCertificate); err == nil {
+		t.Fatal("expected error for superseded certificate")
+	}
+
+	if _, ok := store.get(peer.ID("old-peer")); ok {
+		t.Errorf("expected superseded certificate not to be stored")
+	}
+}
+
+func TestCertificateStoreDisconnectsSupersededPeers(t *testing.T) {
+	issue := newTestCertificateIssuer(t)
+	oldCertificate := issue()
+	newCertificate := issue()
+
+	disconnected := make(chan peer.ID, 1)
+	store := newCertificateStore()
+	store.onSuperseded(func(peerID peer.ID) {
+		disconnected <- peerID
+	})
+
+	if err := store.add(peer.ID("old-peer"), oldCertificate); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.add(peer.ID("other-peer"), nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.add(peer.ID("new-peer"), newCertificate); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case peerID := <-disconnected:
+		if peerID != peer.ID("old-peer") {
+			t.Errorf("unexpected disconnected peer [%v]", peerID)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected peer with superseded certificate to be disconnected")
+	}
+
+	if _, ok := store.get(peer.ID("old-peer")); ok {
+		t.Errorf("expected superseded certificate to be removed")
+	}
+	if _, ok := store.get(peer.ID("other-peer")); !ok {
+		t.Errorf("expected certificate of another peer to be kept")
+	}
+
+	// The peer with the old certificate can not come back after it has been
+	// disconnected.
+	if err := store.add(peer.ID("old-peer"), oldCertificate); err == nil {
+		t.Fatal("expected error for superseded certificate")
+	}
+}
+
+func TestCertificateStoreDisconnectsPeersWithSupersededOperatorCertificates(
+	t *testing.T,
+) {
+	issue := newTestCertificateIssuer(t)
+	oldCertificate := issue()
+	newCertificate := issue()
+
+	disconnected := make(chan peer.ID, 1)
+	store := newCertificateStore()
+	store.onSuperseded(func(peerID peer.ID) {
+		disconnected <- peerID
+	})
+
+	// The old certificate is presented as a certificate of an additional
+	// operator and the new one as the network key certificate.
+	if err := store.add(peer.ID("old-peer"), nil, oldCertificate); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.add(peer.ID("new-peer"), newCertificate); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case peerID := <-disconnected:
+		if peerID != peer.ID("old-peer") {
+			t.Errorf("unexpected disconnected peer [%v]", peerID)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected peer with superseded certificate to be disconnected")
+	}
+
+	if _, ok := store.get(peer.ID("old-peer")); ok {
+		t.Errorf("expected peer with superseded certificate to be removed")
+	}
+
+	// The peer with the old certificate can not come back after it has been
+	// disconnected.
+	if err := store.add(peer.ID("old-peer"), nil, oldCertificate); err == nil {
+		t.Fatal("expected error for superseded certificate")
+	}
+}
+
+// newTestCertificateIssuer returns a function issuing certificates of the
+// same operator for new network keys. Each certificate supersedes the ones
+// issued before it.
+func newTestCertificateIssuer(t *testing.T) func() *key.Certificate {
+	operatorPrivateKey, operatorPublicKey, err := operator.GenerateKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return func() *key.Certificate {
+		_, networkPublicKey, err := key.GenerateStaticNetworkKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		certificate, err := key.IssueCertificate(
+			operatorPublicKey,
+			networkPublicKey,
+			key.DefaultCertificateValidity,
+			ethutil.NewSigner(operatorPrivateKey).Sign,
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return certificate
+	}
+}
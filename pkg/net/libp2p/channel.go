@@ -7,17 +7,20 @@ import (
 	"runtime"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/gogo/protobuf/proto"
+	"github.com/ipfs/go-log"
+	"github.com/keep-network/keep-core/pkg/logging"
 	"github.com/keep-network/keep-core/pkg/net"
 	"github.com/keep-network/keep-core/pkg/net/gen/pb"
 	"github.com/keep-network/keep-core/pkg/net/internal"
-	"github.com/keep-network/keep-core/pkg/net/key"
 	"github.com/keep-network/keep-core/pkg/net/retransmission"
-	crypto "github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/keep-network/keep-core/pkg/operator"
 	peer "github.com/libp2p/go-libp2p-core/peer"
 	peerstore "github.com/libp2p/go-libp2p-peerstore"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pubsubpb "github.com/libp2p/go-libp2p-pubsub/pb"
 )
 
 var (
@@ -44,6 +47,12 @@ type channel struct {
 
 	pubsubMutex sync.Mutex
 	pubsub      *pubsub.PubSub
+	// filter is nil if no filter has been set. It is guarded by pubsubMutex.
+	filter net.BroadcastChannelFilter
+	// peerScoreTracker is nil if the router does not support peer scoring.
+	peerScoreTracker *peerScoreTracker
+	rateLimiter      *rateLimiter
+	reputation       net.Reputation
 
 	subscription         *pubsub.Subscription
 	incomingMessageQueue chan *pubsub.Message
@@ -55,23 +64,83 @@ type channel struct {
 	unmarshalersByType map[string]func() net.TaggedUnmarshaler
 
 	retransmissionTicker *retransmission.Ticker
+
+	history        *messageHistory
+	missedMessages *missedMessagesService
+	// capabilities is nil if capabilities of peers are not tracked.
+	capabilities *capabilitiesStore
+
+	// references is the number of references to the channel handed out by
+	// the channel manager. It is guarded by the channel manager.
+	references int
+	// done is closed when the channel gets closed.
+	done    <-chan struct{}
+	cancel  context.CancelFunc
+	release func(channel *channel) error
 }
 
 type messageHandler struct {
 	ctx     context.Context
 	channel chan net.Message
+
+	// registeredAt is the sequence of the last entry in the channel history
+	// at the moment the handler was registered. Messages recorded with
+	// greater sequences are passed to the handler as they are received.
+	registeredAt uint64
+
+	redeliveredMutex sync.Mutex
+	redelivered      map[string]bool
+}
+
+// markRedelivered records the message with the given messageID has been
+// redelivered to the handler from the channel history. It returns false if
+// the message had been redelivered to the handler before.
+func (mh *messageHandler) markRedelivered(messageID string) bool {
+	mh.redeliveredMutex.Lock()
+	defer mh.redeliveredMutex.Unlock()
+
+	if mh.redelivered[messageID] {
+		return false
+	}
+
+	mh.redelivered[messageID] = true
+	return true
 }
 
 func (c *channel) nextSeqno() uint64 {
 	return atomic.AddUint64(&c.counter, 1)
 }
 
+// logger returns the package logger annotated with the channel name.
+func (c *channel) logger() *log.ZapEventLogger {
+	return logging.WithLogFields(
+		logger,
+		logging.Fields{logging.ChannelField: c.name},
+	)
+}
+
 func (c *channel) Name() string {
 	return c.name
 }
 
-func (c *channel) Send(ctx context.Context, message net.TaggedMarshaler) error {
-	messageProto, err := c.messageProto(message)
+func (c *channel) Send(
+	ctx context.Context,
+	message net.TaggedMarshaler,
+	strategy ...net.RetransmissionStrategy,
+) error {
+	return c.send(ctx, c.clientIdentity, message, strategy...)
+}
+
+// send publishes the message on behalf of the given sender identity. The
+// identity has to share the network key of the client identity but may
+// carry a certificate of a different operator.
+func (c *channel) send(
+	ctx context.Context,
+	sender *identity,
+	message net.TaggedMarshaler,
+	strategy ...net.RetransmissionStrategy,
+) error {
+	messageProto, err := c.messageProto(sender, message)
 	if err != nil {
 		return err
 	}
@@ -82,28 +151,44 @@ func (c *channel) Send(ctx context.Context, message net.TaggedMarshaler) error {
 		return c.publishToPubSub(messageProto)
 	}
 
-	retransmission.ScheduleRetransmissions(ctx, c.retransmissionTicker, doSend)
+	retransmission.ScheduleRetransmissions(
+		ctx,
+		c.retransmissionTicker,
+		doSend,
+		strategy...,
+	)
 
 	return doSend()
 }
 
 func (c *channel) Recv(ctx context.Context, handler func(m net.Message)) {
 	messageHandler := &messageHandler{
-		ctx:     ctx,
-		channel: make(chan net.Message, messageHandlerThrottle),
+		ctx:         ctx,
+		channel:     make(chan net.Message, messageHandlerThrottle),
+		redelivered: make(map[string]bool),
 	}
 
 	c.messageHandlersMutex.Lock()
+	messageHandler.registeredAt = c.history.lastSequence()
 	c.messageHandlers = append(c.messageHandlers, messageHandler)
 	c.messageHandlersMutex.Unlock()
 
-	handleWithRetransmissions := retransmission.WithRetransmissionSupport(handler)
+	handleWithRetransmissions := retransmission.WithRetransmissionSupport(
+		ctx,
+		c.retransmissionTicker,
+		handler,
+	)
 
 	go func() {
 		for {
 			select {
 			case <-ctx.Done():
-				logger.Debug("context is done; removing message handler")
+				c.logger().Debug("context is done; removing message handler")
+				c.removeHandler(messageHandler)
+				return
+
+			case <-c.done:
+				c.logger().Debug("channel is closed; removing message handler")
 				c.removeHandler(messageHandler)
 				return
 
@@ -150,6 +235,7 @@ func (c *channel) SetUnmarshaler(unmarshaler func() net.TaggedUnmarshaler) {
 }
 
 func (c *channel) messageProto(
+	sender *identity,
 	message net.TaggedMarshaler,
 ) (*pb.BroadcastNetworkMessage, error) {
 	payloadBytes, err := message.Marshal()
@@ -157,7 +243,7 @@ func (c *channel) messageProto(
 		return nil, err
 	}
 
-	senderIdentityBytes, err := c.clientIdentity.Marshal()
+	senderIdentityBytes, err := sender.Marshal()
 	if err != nil {
 		return nil, err
 	}
@@ -182,12 +268,12 @@ func (c *channel) publishToPubSub(message *pb.BroadcastNetworkMessage) error {
 }
 
 func (c *channel) handleMessages(ctx context.Context) {
-	logger.Debugf("creating [%v] subscription workers", subscriptionWorkers)
+	c.logger().Debugf("creating [%v] subscription workers", subscriptionWorkers)
 	for i := 0; i < subscriptionWorkers; i++ {
 		go c.subscriptionWorker(ctx)
 	}
 
-	logger.Debugf("creating [%v] message workers", messageWorkers)
+	c.logger().Debugf("creating [%v] message workers", messageWorkers)
 	for i := 0; i < messageWorkers; i++ {
 		go c.incomingMessageWorker(ctx)
 	}
@@ -197,19 +283,20 @@ func (c *channel) subscriptionWorker(ctx context.Context) {
 	for {
 		select {
 		case <-ctx.Done():
-			c.subscription.Cancel()
 			return
 		default:
 			message, err := c.subscription.Next(ctx)
 			if err != nil {
-				logger.Error(err)
+				if ctx.Err() == nil {
+					c.logger().Error(err)
+				}
 				continue
 			}
 
 			select {
 			case c.incomingMessageQueue <- message:
 			default:
-				logger.Warningf("message workers are too slow; dropping message")
+				c.logger().Warningf("message workers are too slow; dropping message")
 			}
 		}
 	}
@@ -222,7 +309,7 @@ func (c *channel) incomingMessageWorker(ctx context.Context) {
 			return
 		case msg := <-c.incomingMessageQueue:
 			if err := c.processPubsubMessage(msg); err != nil {
-				logger.Error(err)
+				c.logger().Error(err)
 			}
 		}
 	}
@@ -231,64 +318,177 @@ func (c *channel) incomingMessageWorker(ctx context.Context) {
 func (c *channel) processPubsubMessage(pubsubMessage *pubsub.Message) error {
 	var messageProto pb.BroadcastNetworkMessage
 	if err := proto.Unmarshal(pubsubMessage.Data, &messageProto); err != nil {
+		c.reputation.ReportMisbehavior(
+			pubsubMessage.GetFrom(),
+			net.MalformedMessage,
+		)
+		return err
+	}
+
+	sequence := c.record(pubsubMessage.Message, messageProto)
+
+	netMessage, err := c.processContainerMessage(
+		pubsubMessage.GetFrom(),
+		messageProto,
+	)
+	if err != nil {
+		return err
+	}
+
+	c.deliver(netMessage, sequence)
+
+	return nil
+}
+
+// record adds the message to the channel history so that it can be
+// redelivered or served to peers which missed it and returns the sequence
+// assigned to it. Messages of unknown authors are not recorded; zero is
+// returned for them.
+func (c *channel) record(
+	message *pubsubpb.Message,
+	messageProto pb.BroadcastNetworkMessage,
+) uint64 {
+	authorIdentifier := &identity{}
+	if err := authorIdentifier.Unmarshal(messageProto.Sender); err != nil {
+		return 0
+	}
+
+	if authorIdentifier.id != peer.ID(message.GetFrom()) {
+		return 0
+	}
+
+	operatorPublicKey, err := authorIdentifier.operatorPublicKey()
+	if err != nil {
+		return 0
+	}
+
+	return c.history.add(&historyEntry{
+		id: pubsub.DefaultMsgIdFn(message),
+		messageID: broadcastMessageID(
+			authorIdentifier.id,
+			messageProto.SequenceNumber,
+		),
+		senderPublicKey: operator.Marshal(operatorPublicKey),
+		messageType:     string(messageProto.Type),
+		received:        time.Now(),
+		message:         message,
+	})
+}
+
+// processMissedMessage processes the marshaled message served by the given
+// peer as missed by this client. The message is processed only if it has
+// been signed by its author, it has been published to the channel topic,
+// it passes the channel filter and neither it nor any of its
+// retransmissions is in the channel history yet.
+func (c *channel) processMissedMessage(from peer.ID, messageBytes []byte) error {
+	message := &pubsubpb.Message{}
+	if err := message.Unmarshal(messageBytes); err != nil {
+		c.reputation.ReportMisbehavior(from, net.MalformedMessage)
 		return err
 	}
 
-	return c.processContainerMessage(pubsubMessage.GetFrom(), messageProto)
+	if err := verifyPubsubMessageSignature(message); err != nil {
+		c.reputation.ReportMisbehavior(from, net.MalformedMessage)
+		return err
+	}
+
+	if !hasTopic(message, c.name) {
+		return fmt.Errorf(
+			"message topics %v do not include channel [%v]",
+			message.TopicIDs,
+			c.name,
+		)
+	}
+
+	if c.history.has(pubsub.DefaultMsgIdFn(message)) {
+		return nil
+	}
+
+	var messageProto pb.BroadcastNetworkMessage
+	if err := proto.Unmarshal(message.Data, &messageProto); err == nil &&
+		c.history.hasMessage(broadcastMessageID(
+			peer.ID(message.GetFrom()),
+			messageProto.SequenceNumber,
+		)) {
+		return nil
+	}
+
+	pubsubMessage := &pubsub.Message{Message: message, ReceivedFrom: from}
+
+	c.pubsubMutex.Lock()
+	filter := c.filter
+	c.pubsubMutex.Unlock()
+
+	if filter != nil {
+		authorPublicKey, err := extractOperatorPublicKey(pubsubMessage)
+		if err != nil {
+			return err
+		}
+
+		if !filter(authorPublicKey) {
+			return fmt.Errorf("message author rejected by channel filter")
+		}
+	}
+
+	return c.processPubsubMessage(pubsubMessage)
+}
+
+func hasTopic(message *pubsubpb.Message, topic string) bool {
+	for _, messageTopic := range message.TopicIDs {
+		if messageTopic == topic {
+			return true
+		}
+	}
+	return false
 }
 
 func (c *channel) processContainerMessage(
 	proposedSender peer.ID,
 	message pb.BroadcastNetworkMessage,
-) error {
+) (net.Message, error) {
 	// The protocol type is on the envelope; let's pull that type
 	// from our map of unmarshallers.
 	unmarshaled, err := c.getUnmarshalingContainerByType(string(message.Type))
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if err := unmarshaled.Unmarshal(message.GetPayload()); err != nil {
-		return err
+		c.reputation.ReportMisbehavior(proposedSender, net.MalformedMessage)
+		return nil, err
 	}
 
 	// Construct an identifier from the sender.
 	senderIdentifier := &identity{}
 	if err := senderIdentifier.Unmarshal(message.Sender); err != nil {
-		return err
+		c.reputation.ReportMisbehavior(proposedSender, net.MalformedMessage)
+		return nil, err
 	}
 
 	// Ensure the sender wasn't tampered by:
 	//     Test that the proposed sender (outer layer) matches the
 	//     sender identifier we grab from the message (inner layer).
 	if proposedSender != senderIdentifier.id {
-		return fmt.Errorf(
+		return nil, fmt.Errorf(
 			"outer layer sender [%v] does not match inner layer sender [%v]",
 			proposedSender,
 			senderIdentifier,
 		)
 	}
 
-	networkKey := key.Libp2pKeyToNetworkKey(senderIdentifier.pubKey)
-	if networkKey == nil {
-		return fmt.Errorf(
-			"sender [%v] with key [%v] is not of correct type",
-			senderIdentifier.id,
-			senderIdentifier.pubKey,
-		)
+	operatorPublicKey, err := senderIdentifier.operatorPublicKey()
+	if err != nil {
+		return nil, err
 	}
 
-	netMessage := internal.BasicMessage(
+	return internal.BasicMessage(
 		senderIdentifier.id,
 		unmarshaled,
 		string(message.Type),
-		key.Marshal(networkKey),
+		operator.Marshal(operatorPublicKey),
+		c.capabilities.get(senderIdentifier.id),
 		message.SequenceNumber,
-	)
-
-	c.deliver(netMessage)
-
-	return nil
+	), nil
 }
 
 func (c *channel) getUnmarshalingContainerByType(messageType string) (net.TaggedUnmarshaler, error) {
@@ -306,19 +506,34 @@ func (c *channel) getUnmarshalingContainerByType(messageType string) (net.Tagged
 	return unmarshaler(), nil
 }
 
-func (c *channel) deliver(message net.Message) {
+// deliver passes the message recorded in the channel history with the given
+// sequence to handlers registered before it was recorded. Handlers
+// registered later can have it redelivered with RequestMissedMessages.
+// Messages which have not been recorded, with zero sequence, are passed to
+// all handlers.
+func (c *channel) deliver(message net.Message, sequence uint64) {
+	for _, handler := range c.handlers() {
+		if sequence == 0 || handler.registeredAt < sequence {
+			c.deliverTo(handler, message)
+		}
+	}
+}
+
+func (c *channel) deliverTo(handler *messageHandler, message net.Message) {
+	select {
+	case handler.channel <- message:
+	default:
+		c.logger().Warningf("message handler is too slow; dropping message")
+	}
+}
+
+func (c *channel) handlers() []*messageHandler {
 	c.messageHandlersMutex.Lock()
+	defer c.messageHandlersMutex.Unlock()
+
 	snapshot := make([]*messageHandler, len(c.messageHandlers))
 	copy(snapshot, c.messageHandlers)
-	c.messageHandlersMutex.Unlock()
-
-	for _, handler := range snapshot {
-		select {
-		case handler.channel <- message:
-		default:
-			logger.Warningf("message handler is too slow; dropping message")
-		}
-	}
+	return snapshot
 }
 
 func (c *channel) SetFilter(filter net.BroadcastChannelFilter) error {
@@ -329,19 +544,149 @@ func (c *channel) SetFilter(filter net.BroadcastChannelFilter) error {
 	if err != nil {
 		// That error can occur when the filter is set for the first time
 		// and no prior filter exists.
-		logger.Debugf(
-			"could not unregister topic validator for channel [%v]: [%v]",
-			c.name,
+		c.logger().Debugf(
+			"could not unregister topic validator: [%v]",
+			err,
+		)
+	}
+
+	err = c.pubsub.RegisterTopicValidator(
+		c.name,
+		c.topicValidator(createTopicValidator(filter)),
+	)
+	if err != nil {
+		return err
+	}
+
+	c.filter = filter
+
+	return nil
+}
+
+// RequestMissedMessages redelivers messages matching the filter from the
+// channel history to handlers which missed them and requests those the
+// channel has missed from random peers subscribed to the channel topic.
+// Messages received from peers are processed only if they have been signed
+// by their authors.
+//
+// Catch-up does not reach beyond the channel history. Once messages have been
+// evicted from the history, the channel can not tell whether it has received
+// them, so messages received before the oldest message in the history are
+// not requested from peers.
+func (c *channel) RequestMissedMessages(
+	ctx context.Context,
+	filter net.MissedMessagesFilter,
+) error {
+	if horizon := c.history.horizon(); filter.Since.Before(horizon) {
+		filter.Since = horizon
+	}
+
+	for _, handler := range c.handlers() {
+		for _, entry := range c.history.missedBy(filter, handler.registeredAt) {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			if !handler.markRedelivered(entry.messageID) {
+				continue
+			}
+
+			if err := c.redeliver(handler, entry); err != nil {
+				c.logger().Debugf(
+					"could not redeliver message: [%v]",
+					err,
+				)
+			}
+		}
+	}
+
+	return c.missedMessages.request(ctx, c, filter)
+}
+
+func (c *channel) redeliver(handler *messageHandler, entry *historyEntry) error {
+	var messageProto pb.BroadcastNetworkMessage
+	if err := proto.Unmarshal(entry.message.Data, &messageProto); err != nil {
+		return err
+	}
+
+	netMessage, err := c.processContainerMessage(
+		peer.ID(entry.message.GetFrom()),
+		messageProto,
+	)
+	if err != nil {
+		return err
+	}
+
+	c.deliverTo(handler, netMessage)
+
+	return nil
+}
+
+// topicValidator wraps the validator of the channel topic so that messages
+// exceeding the rate limits are ignored and, if the router supports peer
+// scoring, peers forwarding invalid messages are penalized. The validator may
+// be nil if the channel has no filter.
+func (c *channel) topicValidator(validator pubsub.Validator) pubsub.ValidatorEx {
+	validatorEx := func(
+		ctx context.Context,
+		from peer.ID,
+		message *pubsub.Message,
+	) pubsub.ValidationResult {
+		if validator != nil && !validator(ctx, from, message) {
+			return pubsub.ValidationReject
+		}
+		return pubsub.ValidationAccept
+	}
+	if c.peerScoreTracker != nil {
+		validatorEx = c.peerScoreTracker.topicValidator(c.name, validator)
+	}
+
+	return func(
+		ctx context.Context,
+		from peer.ID,
+		message *pubsub.Message,
+	) pubsub.ValidationResult {
+		// Limits are enforced against the message author and not the peer
+		// forwarding the message so that peers relaying the topic traffic
+		// are not held responsible for it.
+		if !c.rateLimiter.allow(c.name, message.GetFrom(), len(message.Data)) {
+			return pubsub.ValidationIgnore
+		}
+
+		return validatorEx(ctx, from, message)
+	}
+}
+
+func (c *channel) Close() error {
+	return c.release(c)
+}
+
+// close leaves the channel topic and stops all channel workers and message
+// handlers. It is called by the channel manager once all references to the
+// channel are released.
+func (c *channel) close() {
+	c.cancel()
+	c.subscription.Cancel()
+
+	c.pubsubMutex.Lock()
+	if err := c.pubsub.UnregisterTopicValidator(c.name); err != nil {
+		c.logger().Debugf(
+			"could not unregister topic validator: [%v]",
 			err,
 		)
 	}
+	c.pubsubMutex.Unlock()
 
-	return c.pubsub.RegisterTopicValidator(c.name, createTopicValidator(filter))
+	c.rateLimiter.removeTopic(c.name)
+
+	c.messageHandlersMutex.Lock()
+	c.messageHandlers = nil
+	c.messageHandlersMutex.Unlock()
 }
 
 func createTopicValidator(filter net.BroadcastChannelFilter) pubsub.Validator {
 	return func(_ context.Context, _ peer.ID, message *pubsub.Message) bool {
-		authorPublicKey, err := extractPublicKey(message.GetFrom())
+		authorPublicKey, err := extractOperatorPublicKey(message)
 		if err != nil {
 			logger.Warningf(
 				"could not retrieve message author public key: [%v]",
@@ -353,16 +698,29 @@ func createTopicValidator(filter net.BroadcastChannelFilter) pubsub.Validator {
 	}
 }
 
-func extractPublicKey(peer peer.ID) (*ecdsa.PublicKey, error) {
-	publicKey, err := peer.ExtractPublicKey()
-	if err != nil {
+// extractOperatorPublicKey returns the operator public key of the message
+// author. The author's identity is read from the message and has to match
+// the identity of the peer who signed the message.
+func extractOperatorPublicKey(
+	message *pubsub.Message,
+) (*ecdsa.PublicKey, error) {
+	var messageProto pb.BroadcastNetworkMessage
+	if err := proto.Unmarshal(message.Data, &messageProto); err != nil {
 		return nil, err
 	}
 
-	secp256k1PublicKey, ok := publicKey.(*crypto.Secp256k1PublicKey)
-	if !ok {
-		return nil, fmt.Errorf("public key is of type other than Secp256k1")
+	authorIdentifier := &identity{}
+	if err := authorIdentifier.Unmarshal(messageProto.Sender); err != nil {
+		return nil, err
+	}
+
+	if authorIdentifier.id != message.GetFrom() {
+		return nil, fmt.Errorf(
+			"message author [%v] does not match sender identifier [%v]",
+			message.GetFrom(),
+			authorIdentifier.id,
+		)
 	}
 
-	return key.NetworkKeyToECDSAKey(secp256k1PublicKey), nil
+	return authorIdentifier.operatorPublicKey()
 }
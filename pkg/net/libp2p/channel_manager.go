@@ -2,6 +2,7 @@ package libp2p
 
 import (
 	"context"
+	"fmt"
 	"sync"
 	"time"
 
@@ -19,6 +20,17 @@ const (
 	libp2pValidationQueueSize         = 4096
 )
 
+// Pubsub routers which can be used by broadcast channels.
+const (
+	// FloodSubRouter floods every message to all peers subscribed to the
+	// topic. It is the default router.
+	FloodSubRouter = "floodsub"
+	// GossipSubRouter forwards messages to a subset of peers subscribed to
+	// the topic and gossips about the rest. Peers misbehaving in topics are
+	// scored down and eventually ignored.
+	GossipSubRouter = "gossipsub"
+)
+
 type channelManager struct {
 	ctx context.Context
 
@@ -29,8 +41,16 @@ type channelManager struct {
 	channels      map[string]*channel
 
 	pubsub *pubsub.PubSub
+	// peerScoreTracker is nil if the router does not support peer scoring.
+	peerScoreTracker *peerScoreTracker
+	rateLimiter      *rateLimiter
+	reputation       net.Reputation
 
 	retransmissionTicker *retransmission.Ticker
+	// missedMessages is set once the unicast channel manager is available.
+	missedMessages *missedMessagesService
+	// capabilities is nil if capabilities of peers are not tracked.
+	capabilities *capabilitiesStore
 
 	forwarderSubscriptionsMutex sync.Mutex
 	forwarderSubscriptions      map[string]*pubsub.Subscription
@@ -41,22 +61,60 @@ func newChannelManager(
 	identity *identity,
 	p2phost host.Host,
 	retransmissionTicker *retransmission.Ticker,
+	router string,
+	rateLimiter *rateLimiter,
+	reputation net.Reputation,
 ) (*channelManager, error) {
-	floodsub, err := pubsub.NewFloodSub(
-		ctx,
-		p2phost,
+	options := []pubsub.Option{
 		pubsub.WithMessageAuthor(identity.id),
 		pubsub.WithMessageSigning(libp2pMessageSigning),
 		pubsub.WithStrictSignatureVerification(libp2pStrictSignatureVerification),
 		pubsub.WithPeerOutboundQueueSize(libp2pPeerOutboundQueueSize),
 		pubsub.WithValidateQueueSize(libp2pValidationQueueSize),
+	}
+
+	var (
+		ps               *pubsub.PubSub
+		peerScoreTracker *peerScoreTracker
+		err              error
 	)
+
+	switch router {
+	case "", FloodSubRouter:
+		ps, err = pubsub.NewFloodSub(ctx, p2phost, options...)
+	case GossipSubRouter:
+		peerScoreTracker = newPeerScoreTracker(
+			identity.id,
+			floodWindow,
+			floodMessageLimit,
+		)
+
+		options = append(
+			options,
+			pubsub.WithEventTracer(peerScoreTracker),
+			pubsub.WithPeerScore(
+				peerScoreTracker.peerScoreParams(),
+				peerScoreThresholds(),
+			),
+		)
+
+		ps, err = pubsub.NewGossipSub(ctx, p2phost, options...)
+		if err == nil {
+			go peerScoreTracker.run(ctx)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported pubsub router [%v]", router)
+	}
 	if err != nil {
 		return nil, err
 	}
+
 	return &channelManager{
 		channels:               make(map[string]*channel),
-		pubsub:                 floodsub,
+		pubsub:                 ps,
+		peerScoreTracker:       peerScoreTracker,
+		rateLimiter:            rateLimiter,
+		reputation:             reputation,
 		peerStore:              p2phost.Peerstore(),
 		identity:               identity,
 		ctx:                    ctx,
@@ -66,26 +124,12 @@ func newChannelManager(
 }
 
 func (cm *channelManager) getChannel(name string) (*channel, error) {
-	var (
-		channel *channel
-		exists  bool
-		err     error
-	)
-
 	cm.channelsMutex.Lock()
-	channel, exists = cm.channels[name]
-	cm.channelsMutex.Unlock()
+	defer cm.channelsMutex.Unlock()
 
+	channel, exists := cm.channels[name]
 	if !exists {
-		// Ensure we update our cache of known channels
-		cm.channelsMutex.Lock()
-		defer cm.channelsMutex.Unlock()
-
-		channel, exists = cm.channels[name]
-		if exists {
-			return channel, nil
-		}
-
+		var err error
 		channel, err = cm.newChannel(name)
 		if err != nil {
 			return nil, err
@@ -94,28 +138,90 @@ func (cm *channelManager) getChannel(name string) (*channel, error) {
 		cm.channels[name] = channel
 	}
 
+	// Every caller gets a reference to the same channel which is closed
+	// once all references are released.
+	channel.references++
+
 	return channel, nil
 }
 
+// existingChannel returns the channel with the given name if it is open.
+// Unlike getChannel, it neither opens the channel nor takes a reference to it.
+func (cm *channelManager) existingChannel(name string) (*channel, bool) {
+	cm.channelsMutex.Lock()
+	defer cm.channelsMutex.Unlock()
+
+	channel, exists := cm.channels[name]
+	return channel, exists
+}
+
+// releaseChannel releases a single reference to the channel. Once there are no
+// more references, the channel is removed from the cache and closed.
+func (cm *channelManager) releaseChannel(channel *channel) error {
+	cm.channelsMutex.Lock()
+	defer cm.channelsMutex.Unlock()
+
+	if cm.channels[channel.name] != channel {
+		return fmt.Errorf("channel [%v] is already closed", channel.name)
+	}
+
+	channel.references--
+	if channel.references > 0 {
+		return nil
+	}
+
+	logger.Debugf("closing channel [%v]", channel.name)
+
+	delete(cm.channels, channel.name)
+	channel.close()
+
+	return nil
+}
+
 func (cm *channelManager) newChannel(name string) (*channel, error) {
 	sub, err := cm.pubsub.Subscribe(name)
 	if err != nil {
 		return nil, err
 	}
 
+	ctx, cancelCtx := context.WithCancel(cm.ctx)
+
 	channel := &channel{
 		name:                 name,
 		clientIdentity:       cm.identity,
 		peerStore:            cm.peerStore,
 		pubsub:               cm.pubsub,
+		peerScoreTracker:     cm.peerScoreTracker,
+		rateLimiter:          cm.rateLimiter,
+		reputation:           cm.reputation,
 		subscription:         sub,
 		incomingMessageQueue: make(chan *pubsub.Message, incomingMessageThrottle),
 		messageHandlers:      make([]*messageHandler, 0),
 		unmarshalersByType:   make(map[string]func() net.TaggedUnmarshaler),
 		retransmissionTicker: cm.retransmissionTicker,
+		history:              newMessageHistory(),
+		missedMessages:       cm.missedMessages,
+		capabilities:         cm.capabilities,
+		done:                 ctx.Done(),
+		cancel:               cancelCtx,
+		release:              cm.releaseChannel,
+	}
+
+	if cm.peerScoreTracker != nil || cm.rateLimiter.enabled() {
+		// Messages are counted against the flood and rate limits even if
+		// no filter has been set for the channel.
+		err = cm.pubsub.RegisterTopicValidator(
+			name,
+			channel.topicValidator(nil),
+		)
+		if err != nil {
+			cancelCtx()
+			sub.Cancel()
+			return nil, err
+		}
 	}
 
-	go channel.handleMessages(cm.ctx)
+	go channel.handleMessages(ctx)
 
 	return channel, nil
 }
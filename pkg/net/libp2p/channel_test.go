@@ -13,7 +13,13 @@ import (
 	"time"
 
 	"github.com/btcsuite/btcd/btcec"
+	"github.com/keep-network/keep-common/pkg/chain/ethereum/ethutil"
 	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/net/gen/pb"
+	"github.com/keep-network/keep-core/pkg/net/key"
+	"github.com/keep-network/keep-core/pkg/net/reputation"
+	"github.com/keep-network/keep-core/pkg/operator"
+	libp2p "github.com/libp2p/go-libp2p"
 	crypto "github.com/libp2p/go-libp2p-core/crypto"
 	peer "github.com/libp2p/go-libp2p-core/peer"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
@@ -24,14 +30,17 @@ func TestRegisterAndFireHandler(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
 	defer cancel()
 
-	channel := &channel{}
+	channel := &channel{
+		retransmissionTicker: idleTicker(),
+		history:              newMessageHistory(),
+	}
 
 	handlerFiredChan := make(chan struct{})
 	channel.Recv(ctx, func(msg net.Message) {
 		handlerFiredChan <- struct{}{}
 	})
 
-	channel.deliver(&mockNetMessage{})
+	channel.deliver(&mockNetMessage{}, 0)
 
 	select {
 	case <-handlerFiredChan:
@@ -77,7 +86,10 @@ func TestUnregisterHandler(t *testing.T) {
 	for testName, test := range tests {
 		test := test
 		t.Run(testName, func(t *testing.T) {
-			channel := &channel{}
+			channel := &channel{
+				retransmissionTicker: idleTicker(),
+				history:              newMessageHistory(),
+			}
 
 			handlersFiredMutex := &sync.Mutex{}
 			handlersFired := []string{}
@@ -107,7 +119,7 @@ func TestUnregisterHandler(t *testing.T) {
 			}
 
 			// Deliver message, all handlers should be called
-			channel.deliver(&mockNetMessage{})
+			channel.deliver(&mockNetMessage{}, 0)
 
 			// Handlers are fired asynchronously; wait for them
 			time.Sleep(500 * time.Millisecond)
@@ -125,7 +137,10 @@ func TestUnregisterHandler(t *testing.T) {
 }
 
 func TestUnregisterWhenHandling(t *testing.T) {
-	channel := &channel{}
+	channel := &channel{
+		retransmissionTicker: idleTicker(),
+		history:              newMessageHistory(),
+	}
 
 	ctx, cancel := context.WithCancel(context.Background())
 
@@ -142,7 +157,7 @@ func TestUnregisterWhenHandling(t *testing.T) {
 
 	go func() {
 		for i := 0; i < 300; i++ {
-			channel.deliver(&mockNetMessage{seqno: uint64(i)})
+			channel.deliver(&mockNetMessage{seqno: uint64(i)}, 0)
 		}
 	}()
 
@@ -174,11 +189,9 @@ func TestCreateTopicValidator(t *testing.T) {
 
 	expectedResults := []bool{true, false, false, true, false}
 	for i, publicKey := range publicKeys {
-		authorID, _ := peer.IDFromPublicKey(publicKey)
-		authorIDBytes, _ := authorID.Marshal()
-		message := &pubsubpb.Message{From: authorIDBytes}
+		message := newTestPubsubMessage(t, publicKey, nil)
 
-		actualResult := validator(nil, peer.ID(i), &pubsub.Message{Message: message})
+		actualResult := validator(nil, peer.ID(i), message)
 
 		if expectedResults[i] != actualResult {
 			t.Errorf(
@@ -192,6 +205,184 @@ func TestCreateTopicValidator(t *testing.T) {
 	}
 }
 
+func TestCreateTopicValidatorWithCertificate(t *testing.T) {
+	operatorPrivateKey, operatorPublicKey, err := operator.GenerateKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, networkPublicKey, err := key.GenerateStaticNetworkKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certificate, err := key.IssueCertificate(
+		operatorPublicKey,
+		networkPublicKey,
+		key.DefaultCertificateValidity,
+		ethutil.NewSigner(operatorPrivateKey).Sign,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Only the operator is authorized, the network key is not.
+	filter := func(publicKey *ecdsa.PublicKey) bool {
+		return toEncodedBytes(publicKey) == toEncodedBytes(operatorPublicKey)
+	}
+
+	validator := createTopicValidator(filter)
+
+	if !validator(nil, "", newTestPubsubMessage(t, networkPublicKey, certificate)) {
+		t.Errorf("message with certificate should pass the filter")
+	}
+
+	if validator(nil, "", newTestPubsubMessage(t, networkPublicKey, nil)) {
+		t.Errorf("message without certificate should not pass the filter")
+	}
+
+	// The certificate does not authorize this network key so the message
+	// has to be rejected, even though the certificate itself is valid.
+	_, anotherNetworkPublicKey, err := key.GenerateStaticNetworkKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	forgedMessage := newTestPubsubMessage(
+		t,
+		anotherNetworkPublicKey,
+		certificate,
+	)
+
+	if validator(nil, "", forgedMessage) {
+		t.Errorf("message with certificate of another key should not pass")
+	}
+}
+
+// newTestPubsubMessage creates a broadcast channel pubsub message authored
+// by the peer with the given public key and, optionally, certificate.
+func TestChannelManagerReleasesChannel(t *testing.T) {
+	ctx, cancel := newTestContext()
+	defer cancel()
+
+	privateKey, _, err := key.GenerateStaticNetworkKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	identity, err := createIdentity(privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	host, err := libp2p.New(
+		ctx,
+		libp2p.Identity(privateKey),
+		libp2p.ListenAddrStrings("/ip4/127.0.0.1/tcp/0"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer host.Close()
+
+	manager, err := newChannelManager(
+		ctx,
+		identity,
+		host,
+		idleTicker(),
+		"",
+		newRateLimiter(identity.id, RateLimits{}),
+		reputation.NewService(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	channel1, err := manager.getChannel("test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	channel2, err := manager.getChannel("test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if channel1 != channel2 {
+		t.Fatalf("expected the same channel for the same name")
+	}
+
+	if err := channel1.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := manager.channels["test"]; !ok {
+		t.Fatalf("channel should not be closed while it is referenced")
+	}
+
+	if err := channel2.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := manager.channels["test"]; ok {
+		t.Fatalf("channel should be closed once all references are released")
+	}
+	select {
+	case <-channel1.done:
+	default:
+		t.Fatalf("channel workers should be stopped")
+	}
+
+	expectedError := "channel [test] is already closed"
+	if err := channel1.Close(); err == nil || err.Error() != expectedError {
+		t.Errorf(
+			"unexpected error\nexpected: [%v]\nactual:   [%v]",
+			expectedError,
+			err,
+		)
+	}
+
+	channel3, err := manager.getChannel("test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if channel3 == channel1 {
+		t.Errorf("expected a new channel after the previous one was closed")
+	}
+}
+
+func newTestPubsubMessage(
+	t *testing.T,
+	publicKey crypto.PubKey,
+	certificate *key.Certificate,
+) *pubsub.Message {
+	authorID, err := peer.IDFromPublicKey(publicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	authorIDBytes, err := authorID.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	authorIdentity := &identity{
+		id:          authorID,
+		pubKey:      publicKey,
+		certificate: certificate,
+	}
+	authorIdentityBytes, err := authorIdentity.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := (&pb.BroadcastNetworkMessage{
+		Sender: authorIdentityBytes,
+	}).Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &pubsub.Message{
+		Message: &pubsubpb.Message{From: authorIDBytes, Data: data},
+	}
+}
+
 func toEcdsaPublicKey(publicKey crypto.PubKey) *ecdsa.PublicKey {
 	secp256k1PublicKey, _ := publicKey.(*crypto.Secp256k1PublicKey)
 	return (*btcec.PublicKey)(secp256k1PublicKey).ToECDSA()
@@ -223,6 +414,10 @@ func (mnm *mockNetMessage) SenderPublicKey() []byte {
 	panic("not implemented in mock")
 }
 
+func (mnm *mockNetMessage) SenderCapabilities() *net.PeerCapabilities {
+	panic("not implemented in mock")
+}
+
 func (mnm *mockNetMessage) Seqno() uint64 {
 	return mnm.seqno
 }
@@ -0,0 +1,189 @@
+package libp2p
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	dstore "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+	dssync "github.com/ipfs/go-datastore/sync"
+	"github.com/libp2p/go-libp2p-core/peer"
+	corepeerstore "github.com/libp2p/go-libp2p-core/peerstore"
+	"github.com/libp2p/go-libp2p-core/record"
+	peerstore "github.com/libp2p/go-libp2p-peerstore"
+	"github.com/libp2p/go-libp2p-peerstore/pstoreds"
+	"github.com/libp2p/go-libp2p-peerstore/pstoremem"
+)
+
+// DefaultDatastoreSaveInterval is the default interval in which the content
+// of the persistent datastore is saved to the disk.
+const DefaultDatastoreSaveInterval = time.Minute
+
+// persistentDatastore is an in-memory datastore whose content is periodically
+// saved to a file so that it survives client restarts. It is used to keep DHT
+// records, peer addresses and known peers. The content is saved one last time
+// when the datastore is closed.
+type persistentDatastore struct {
+	*dssync.MutexDatastore
+
+	path         string
+	saveInterval time.Duration
+
+	closeOnce sync.Once
+	closeErr  error
+}
+
+// newPersistentDatastore creates a datastore backed by the file with the
+// given path and saved to it in the given interval. If the file exists, the
+// datastore is initialized with its content.
+func newPersistentDatastore(
+	path string,
+	saveInterval time.Duration,
+) (*persistentDatastore, error) {
+	datastore := &persistentDatastore{
+		MutexDatastore: dssync.MutexWrap(dstore.NewMapDatastore()),
+		path:           path,
+		saveInterval:   saveInterval,
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return datastore, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not read datastore file: [%v]", err)
+	}
+
+	entries := make(map[string][]byte)
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("could not unmarshal datastore file: [%v]", err)
+	}
+
+	for key, value := range entries {
+		if err := datastore.Put(dstore.NewKey(key), value); err != nil {
+			return nil, err
+		}
+	}
+
+	logger.Infof(
+		"loaded [%v] network datastore entries from [%v]",
+		len(entries),
+		path,
+	)
+
+	return datastore, nil
+}
+
+// run saves the datastore periodically until the context is done. The last
+// save happens once the datastore is closed.
+func (pd *persistentDatastore) run(ctx context.Context) {
+	ticker := time.NewTicker(pd.saveInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := pd.save(); err != nil {
+				logger.Warningf("could not save network datastore: [%v]", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Close flushes the content of the datastore to the file. Only the first
+// call saves the datastore; subsequent calls return the result of the first
+// one.
+func (pd *persistentDatastore) Close() error {
+	pd.closeOnce.Do(func() {
+		pd.closeErr = pd.save()
+		if pd.closeErr == nil {
+			logger.Infof("saved network datastore to [%v]", pd.path)
+		}
+	})
+
+	return pd.closeErr
+}
+
+// save writes the content of the datastore to the file. The file is replaced
+// atomically so a crash during the save does not corrupt the previous state.
+func (pd *persistentDatastore) save() error {
+	results, err := pd.Query(query.Query{})
+	if err != nil {
+		return err
+	}
+
+	resultEntries, err := results.Rest()
+	if err != nil {
+		return err
+	}
+
+	entries := make(map[string][]byte, len(resultEntries))
+	for _, entry := range resultEntries {
+		entries[entry.Key] = entry.Value
+	}
+
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(pd.path), 0700); err != nil {
+		return err
+	}
+
+	temporaryPath := pd.path + ".tmp"
+	if err := ioutil.WriteFile(temporaryPath, data, 0600); err != nil {
+		return err
+	}
+
+	return os.Rename(temporaryPath, pd.path)
+}
+
+// newPersistentPeerstore creates a peerstore keeping peer addresses in the
+// given datastore. Keys and metadata of peers are kept in memory only so
+// the private network key is never written to the datastore.
+func newPersistentPeerstore(
+	ctx context.Context,
+	datastore dstore.Batching,
+) (corepeerstore.Peerstore, error) {
+	addrBook, err := pstoreds.NewAddrBook(ctx, datastore, pstoreds.DefaultOpts())
+	if err != nil {
+		return nil, err
+	}
+
+	return &persistentPeerstore{
+		Peerstore: peerstore.NewPeerstore(
+			pstoremem.NewKeyBook(),
+			addrBook,
+			pstoremem.NewProtoBook(),
+			pstoremem.NewPeerMetadata(),
+		),
+		certifiedAddrBook: addrBook,
+	}, nil
+}
+
+// persistentPeerstore exposes the certified address book of the peerstore,
+// which is required by the host to exchange signed peer records.
+type persistentPeerstore struct {
+	corepeerstore.Peerstore
+	certifiedAddrBook corepeerstore.CertifiedAddrBook
+}
+
+func (pp *persistentPeerstore) ConsumePeerRecord(
+	envelope *record.Envelope,
+	ttl time.Duration,
+) (bool, error) {
+	return pp.certifiedAddrBook.ConsumePeerRecord(envelope, ttl)
+}
+
+func (pp *persistentPeerstore) GetPeerRecord(peerID peer.ID) *record.Envelope {
+	return pp.certifiedAddrBook.GetPeerRecord(peerID)
+}
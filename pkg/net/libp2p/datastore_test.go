@@ -0,0 +1,144 @@
+package libp2p
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	dstore "github.com/ipfs/go-datastore"
+	dssync "github.com/ipfs/go-datastore/sync"
+	corepeerstore "github.com/libp2p/go-libp2p-core/peerstore"
+)
+
+func TestPersistentDatastoreSaveAndLoad(t *testing.T) {
+	dataDir, err := ioutil.TempDir("", "datastore")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dataDir)
+
+	path := filepath.Join(dataDir, "network", "datastore.json")
+
+	datastore, err := newPersistentDatastore(path, DefaultDatastoreSaveInterval)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entries := map[string][]byte{
+		"/dht/record":      {1, 2, 3},
+		"/known-peers/abc": []byte("{}"),
+	}
+	for key, value := range entries {
+		if err := datastore.Put(dstore.NewKey(key), value); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := datastore.save(); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := newPersistentDatastore(path, DefaultDatastoreSaveInterval)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for key, expectedValue := range entries {
+		value, err := loaded.Get(dstore.NewKey(key))
+		if err != nil {
+			t.Fatalf("could not get [%v]: [%v]", key, err)
+		}
+
+		if !bytes.Equal(expectedValue, value) {
+			t.Errorf(
+				"unexpected value of [%v]\nexpected: [%v]\nactual:   [%v]",
+				key,
+				expectedValue,
+				value,
+			)
+		}
+	}
+}
+
+func TestPersistentDatastoreWithoutFile(t *testing.T) {
+	dataDir, err := ioutil.TempDir("", "datastore")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dataDir)
+
+	datastore, err := newPersistentDatastore(
+		filepath.Join(dataDir, "datastore.json"),
+		DefaultDatastoreSaveInterval,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = datastore.Get(dstore.NewKey("/any"))
+	if err != dstore.ErrNotFound {
+		t.Errorf("unexpected error: [%v]", err)
+	}
+}
+
+func TestPersistentDatastoreSavedOnClose(t *testing.T) {
+	dataDir, err := ioutil.TempDir("", "datastore")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dataDir)
+
+	path := filepath.Join(dataDir, "datastore.json")
+
+	// The save interval is long enough for the datastore not to be saved
+	// periodically during the test.
+	datastore, err := newPersistentDatastore(path, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	go datastore.run(ctx)
+
+	key := dstore.NewKey("/known-peers/abc")
+	if err := datastore.Put(key, []byte("{}")); err != nil {
+		t.Fatal(err)
+	}
+
+	cancelCtx()
+	if err := datastore.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := newPersistentDatastore(path, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := loaded.Get(key); err != nil {
+		t.Errorf("could not get [%v]: [%v]", key, err)
+	}
+}
+
+func TestPersistentPeerstoreIsCertifiedAddrBook(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	peerStore, err := newPersistentPeerstore(
+		ctx,
+		dssync.MutexWrap(dstore.NewMapDatastore()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The host refuses to start with a peerstore which can not hold signed
+	// peer records.
+	if _, ok := corepeerstore.GetCertifiedAddrBook(peerStore); !ok {
+		t.Errorf("expected peerstore to be a certified address book")
+	}
+}
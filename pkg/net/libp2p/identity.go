@@ -1,9 +1,11 @@
 package libp2p
 
 import (
+	"crypto/ecdsa"
 	"fmt"
 
 	"github.com/keep-network/keep-core/pkg/net/gen/pb"
+	"github.com/keep-network/keep-core/pkg/net/key"
 
 	libp2pcrypto "github.com/libp2p/go-libp2p-core/crypto"
 	peer "github.com/libp2p/go-libp2p-core/peer"
@@ -15,10 +17,15 @@ import (
 //
 // Consumers of the net package require an ID to register with protocol level
 // IDs, as well as a public key for authentication.
+//
+// The identity may carry a certificate binding the network key to the
+// operator key. If there is no certificate, the network key is the operator
+// key.
 type identity struct {
-	id      peer.ID
-	pubKey  libp2pcrypto.PubKey
-	privKey libp2pcrypto.PrivKey
+	id          peer.ID
+	pubKey      libp2pcrypto.PubKey
+	privKey     libp2pcrypto.PrivKey
+	certificate *key.Certificate
 }
 
 type networkIdentity peer.ID
@@ -32,7 +39,11 @@ func createIdentity(privateKey libp2pcrypto.PrivKey) (*identity, error) {
 		)
 	}
 
-	return &identity{peerID, privateKey.GetPublic(), privateKey}, nil
+	return &identity{
+		id:      peerID,
+		pubKey:  privateKey.GetPublic(),
+		privKey: privateKey,
+	}, nil
 }
 
 func (ni networkIdentity) String() string {
@@ -56,7 +67,16 @@ func (i *identity) Marshal() ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
-	return (&pb.Identity{PubKey: pubKeyBytes}).Marshal()
+
+	certificateBytes, err := key.MarshalCertificate(i.certificate)
+	if err != nil {
+		return nil, err
+	}
+
+	return (&pb.Identity{
+		PubKey:      pubKeyBytes,
+		Certificate: certificateBytes,
+	}).Marshal()
 }
 
 func (i *identity) Unmarshal(bytes []byte) error {
@@ -82,5 +102,44 @@ func (i *identity) Unmarshal(bytes []byte) error {
 	}
 	i.id = pid
 
+	certificate, err := key.UnmarshalCertificate(pbIdentity.Certificate)
+	if err != nil {
+		return err
+	}
+
+	if certificate != nil {
+		networkKey := key.Libp2pKeyToNetworkKey(i.pubKey)
+		if networkKey == nil {
+			return fmt.Errorf(
+				"certified key [%v] is not of correct type",
+				i.pubKey,
+			)
+		}
+
+		if err := certificate.VerifyFor(networkKey); err != nil {
+			return fmt.Errorf(
+				"invalid certificate of identity [%v]: [%v]",
+				i.id,
+				err,
+			)
+		}
+	}
+	i.certificate = certificate
+
 	return nil
 }
+
+// operatorPublicKey returns the public key of the operator this identity
+// acts on behalf of.
+func (i *identity) operatorPublicKey() (*ecdsa.PublicKey, error) {
+	networkKey := key.Libp2pKeyToNetworkKey(i.pubKey)
+	if networkKey == nil {
+		return nil, fmt.Errorf(
+			"identity [%v] with key [%v] is not of correct type",
+			i.id,
+			i.pubKey,
+		)
+	}
+
+	return key.OperatorPublicKey(networkKey, i.certificate), nil
+}
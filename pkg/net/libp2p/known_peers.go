@@ -0,0 +1,383 @@
+package libp2p
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"sync"
+	"time"
+
+	dstore "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/net/key"
+	host "github.com/libp2p/go-libp2p-core/host"
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+const (
+	// knownPeersRecordTick is the amount of time between periodic records of
+	// peers the client is connected to.
+	knownPeersRecordTick = time.Minute * 5
+	// knownPeersConnectTimeout is the maximum amount of time the client waits
+	// for connections with known peers established on startup.
+	knownPeersConnectTimeout = time.Second * 30
+	// maxFirewallFailures is the number of consecutive firewall failures
+	// after which the peer is no longer considered as known.
+	maxFirewallFailures = 3
+	// maxDialFailures is the number of consecutive failed attempts to
+	// connect to the peer after which the peer is no longer considered as
+	// known.
+	maxDialFailures = 3
+	// knownPeerMaxAge is the amount of time since the client was last
+	// connected to the peer after which the peer is no longer considered as
+	// known.
+	knownPeerMaxAge = 14 * 24 * time.Hour
+)
+
+// knownPeer is the record of a peer the client has been connected to.
+type knownPeer struct {
+	Addresses        []string
+	LastSeen         time.Time
+	FirewallFailures int
+	DialFailures     int
+}
+
+// knownPeers keeps records of peers the client has been connected to so that
+// the client can reconnect to them after a restart without depending on
+// bootstrap nodes only. Peers which repeatedly fail the firewall, peers which
+// repeatedly can not be connected to and peers the client has not been
+// connected to for a long time are pruned.
+type knownPeers struct {
+	mutex     sync.Mutex
+	datastore dstore.Datastore
+}
+
+func newKnownPeers(datastore dstore.Datastore) *knownPeers {
+	return &knownPeers{datastore: datastore}
+}
+
+func knownPeerKey(peerID peer.ID) dstore.Key {
+	return dstore.NewKey(peerID.Pretty())
+}
+
+func (kp *knownPeers) get(peerID peer.ID) (*knownPeer, error) {
+	data, err := kp.datastore.Get(knownPeerKey(peerID))
+	if err == dstore.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	record := &knownPeer{}
+	if err := json.Unmarshal(data, record); err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+func (kp *knownPeers) put(peerID peer.ID, record *knownPeer) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	return kp.datastore.Put(knownPeerKey(peerID), data)
+}
+
+// record stores addresses of the peer, marks it as seen now and resets its
+// failures.
+func (kp *knownPeers) record(peerID peer.ID, addresses []ma.Multiaddr) error {
+	kp.mutex.Lock()
+	defer kp.mutex.Unlock()
+
+	record := &knownPeer{LastSeen: time.Now()}
+	for _, address := range addresses {
+		record.Addresses = append(record.Addresses, address.String())
+	}
+
+	return kp.put(peerID, record)
+}
+
+// recordFirewallFailure increments the number of firewall failures of the
+// peer and prunes the peer once it reaches the maximum number of failures.
+// Peers which are not known are ignored.
+func (kp *knownPeers) recordFirewallFailure(peerID peer.ID) error {
+	return kp.recordFailure(peerID, "firewall", func(record *knownPeer) bool {
+		record.FirewallFailures++
+		return record.FirewallFailures >= maxFirewallFailures
+	})
+}
+
+// recordDialFailure increments the number of failed attempts to connect to
+// the peer and prunes the peer once it reaches the maximum number of
+// failures. Peers which are not known are ignored.
+func (kp *knownPeers) recordDialFailure(peerID peer.ID) error {
+	return kp.recordFailure(peerID, "dial", func(record *knownPeer) bool {
+		record.DialFailures++
+		return record.DialFailures >= maxDialFailures
+	})
+}
+
+// recordFailure updates the record of the peer with the given function and
+// prunes the peer if the function returns true.
+func (kp *knownPeers) recordFailure(
+	peerID peer.ID,
+	failureType string,
+	update func(record *knownPeer) bool,
+) error {
+	kp.mutex.Lock()
+	defer kp.mutex.Unlock()
+
+	record, err := kp.get(peerID)
+	if err != nil || record == nil {
+		return err
+	}
+
+	if update(record) {
+		logger.Infof(
+			"pruning known peer [%v] after repeated %v failures",
+			peerID,
+			failureType,
+		)
+		return kp.datastore.Delete(knownPeerKey(peerID))
+	}
+
+	return kp.put(peerID, record)
+}
+
+// pruneStale removes peers the client has not been connected to for longer
+// than the maximum age.
+func (kp *knownPeers) pruneStale(now time.Time) error {
+	kp.mutex.Lock()
+	defer kp.mutex.Unlock()
+
+	entries, err := kp.entries()
+	if err != nil {
+		return err
+	}
+
+	for peerID, record := range entries {
+		if now.Sub(record.LastSeen) <= knownPeerMaxAge {
+			continue
+		}
+
+		logger.Infof(
+			"pruning known peer [%v] last seen at [%v]",
+			peerID,
+			record.LastSeen,
+		)
+		if err := kp.datastore.Delete(knownPeerKey(peerID)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// list returns address information of all known peers.
+func (kp *knownPeers) list() ([]peer.AddrInfo, error) {
+	kp.mutex.Lock()
+	defer kp.mutex.Unlock()
+
+	entries, err := kp.entries()
+	if err != nil {
+		return nil, err
+	}
+
+	var peerInfos []peer.AddrInfo
+	for peerID, record := range entries {
+		peerInfos = append(peerInfos, peer.AddrInfo{
+			ID:    peerID,
+			Addrs: parseMultiaddresses(record.Addresses),
+		})
+	}
+
+	return peerInfos, nil
+}
+
+// entries returns records of all known peers. Records which can not be
+// decoded are skipped. The caller must hold the mutex.
+func (kp *knownPeers) entries() (map[peer.ID]*knownPeer, error) {
+	results, err := kp.datastore.Query(query.Query{})
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := results.Rest()
+	if err != nil {
+		return nil, err
+	}
+
+	records := make(map[peer.ID]*knownPeer, len(entries))
+	for _, entry := range entries {
+		peerID, err := peer.IDB58Decode(dstore.RawKey(entry.Key).Name())
+		if err != nil {
+			logger.Warningf(
+				"could not decode known peer [%v]: [%v]",
+				entry.Key,
+				err,
+			)
+			continue
+		}
+
+		record := &knownPeer{}
+		if err := json.Unmarshal(entry.Value, record); err != nil {
+			logger.Warningf(
+				"could not unmarshal known peer [%v]: [%v]",
+				peerID,
+				err,
+			)
+			continue
+		}
+
+		records[peerID] = record
+	}
+
+	return records, nil
+}
+
+// connect tries to connect to all known peers in parallel and waits until
+// all connection attempts complete or the timeout is hit. Stale peers are
+// pruned before connecting and failed connection attempts are recorded.
+func (kp *knownPeers) connect(ctx context.Context, host host.Host) {
+	if err := kp.pruneStale(time.Now()); err != nil {
+		logger.Warningf("could not prune known peers: [%v]", err)
+	}
+
+	peerInfos, err := kp.list()
+	if err != nil {
+		logger.Warningf("could not list known peers: [%v]", err)
+		return
+	}
+
+	if len(peerInfos) == 0 {
+		return
+	}
+
+	logger.Infof("connecting to [%v] known peers", len(peerInfos))
+
+	ctx, cancel := context.WithTimeout(ctx, knownPeersConnectTimeout)
+	defer cancel()
+
+	wg := sync.WaitGroup{}
+	for _, peerInfo := range peerInfos {
+		if peerInfo.ID == host.ID() || len(peerInfo.Addrs) == 0 {
+			continue
+		}
+
+		wg.Add(1)
+		go func(peerInfo peer.AddrInfo) {
+			defer wg.Done()
+
+			if err := host.Connect(ctx, peerInfo); err != nil {
+				logger.Debugf(
+					"could not connect to known peer [%v]: [%v]",
+					peerInfo.ID,
+					err,
+				)
+
+				// The whole attempt may have been cancelled, in which case
+				// the failure says nothing about the peer.
+				if ctx.Err() != context.Canceled {
+					if err := kp.recordDialFailure(peerInfo.ID); err != nil {
+						logger.Warningf(
+							"could not record dial failure of peer [%v]: [%v]",
+							peerInfo.ID,
+							err,
+						)
+					}
+				}
+				return
+			}
+
+			if err := kp.record(peerInfo.ID, peerInfo.Addrs); err != nil {
+				logger.Warningf(
+					"could not record known peer [%v]: [%v]",
+					peerInfo.ID,
+					err,
+				)
+			}
+		}(peerInfo)
+	}
+
+	wg.Wait()
+}
+
+// recordConnectedPeers stores addresses of all peers the host is connected
+// to.
+func (kp *knownPeers) recordConnectedPeers(host host.Host) {
+	for _, peerID := range host.Network().Peers() {
+		addresses := host.Peerstore().Addrs(peerID)
+		if len(addresses) == 0 {
+			continue
+		}
+
+		if err := kp.record(peerID, addresses); err != nil {
+			logger.Warningf(
+				"could not record known peer [%v]: [%v]",
+				peerID,
+				err,
+			)
+		}
+	}
+}
+
+// run periodically records peers the host is connected to and prunes stale
+// peers until the context is done.
+func (kp *knownPeers) run(ctx context.Context, host host.Host) {
+	ticker := time.NewTicker(knownPeersRecordTick)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			kp.recordConnectedPeers(host)
+			if err := kp.pruneStale(time.Now()); err != nil {
+				logger.Warningf("could not prune known peers: [%v]", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// knownPeersFirewall records firewall failures of known peers so that peers
+// which are no longer staked eventually stop being considered as known.
+type knownPeersFirewall struct {
+	net.Firewall
+
+	knownPeers *knownPeers
+}
+
+func (kpf *knownPeersFirewall) Validate(
+	remotePeerPublicKey *ecdsa.PublicKey,
+	certificate *key.Certificate,
+) error {
+	err := kpf.Firewall.Validate(remotePeerPublicKey, certificate)
+	if err == nil {
+		return nil
+	}
+
+	networkPublicKey := key.NetworkPublic(*remotePeerPublicKey)
+	peerID, idErr := peer.IDFromPublicKey(&networkPublicKey)
+	if idErr != nil {
+		logger.Warningf("could not determine peer ID: [%v]", idErr)
+		return err
+	}
+
+	if failureErr := kpf.knownPeers.recordFirewallFailure(
+		peerID,
+	); failureErr != nil {
+		logger.Warningf(
+			"could not record firewall failure of peer [%v]: [%v]",
+			peerID,
+			failureErr,
+		)
+	}
+
+	return err
+}
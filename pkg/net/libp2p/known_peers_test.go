@@ -0,0 +1,182 @@
+package libp2p
+
+import (
+	"testing"
+	"time"
+
+	dstore "github.com/ipfs/go-datastore"
+	dssync "github.com/ipfs/go-datastore/sync"
+	"github.com/keep-network/keep-core/pkg/net/key"
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+func TestKnownPeersRecordAndList(t *testing.T) {
+	knownPeers := newKnownPeers(dssync.MutexWrap(dstore.NewMapDatastore()))
+
+	peerID, _ := generateTestPeer(t)
+	address, err := ma.NewMultiaddr("/ip4/127.0.0.1/tcp/3919")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := knownPeers.record(peerID, []ma.Multiaddr{address}); err != nil {
+		t.Fatal(err)
+	}
+
+	peerInfos, err := knownPeers.list()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(peerInfos) != 1 {
+		t.Fatalf("unexpected number of known peers: [%v]", len(peerInfos))
+	}
+	if peerInfos[0].ID != peerID {
+		t.Errorf("unexpected known peer: [%v]", peerInfos[0].ID)
+	}
+	if len(peerInfos[0].Addrs) != 1 || !peerInfos[0].Addrs[0].Equal(address) {
+		t.Errorf("unexpected known peer addresses: [%v]", peerInfos[0].Addrs)
+	}
+}
+
+func TestKnownPeersPrunedAfterFirewallFailures(t *testing.T) {
+	knownPeers := newKnownPeers(dssync.MutexWrap(dstore.NewMapDatastore()))
+
+	peerID, peerPublicKey := generateTestPeer(t)
+	if err := knownPeers.record(peerID, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	firewall := &knownPeersFirewall{newMockFirewall(), knownPeers}
+	ecdsaKey := key.NetworkKeyToECDSAKey(peerPublicKey)
+
+	for i := 1; i <= maxFirewallFailures; i++ {
+		if err := firewall.Validate(ecdsaKey, nil); err == nil {
+			t.Fatal("expected firewall error")
+		}
+
+		peerInfos, err := knownPeers.list()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expectedKnownPeers := 1
+		if i == maxFirewallFailures {
+			expectedKnownPeers = 0
+		}
+		if len(peerInfos) != expectedKnownPeers {
+			t.Errorf(
+				"unexpected number of known peers after [%v] failures\n"+
+					"expected: [%v]\nactual:   [%v]",
+				i,
+				expectedKnownPeers,
+				len(peerInfos),
+			)
+		}
+	}
+}
+
+func TestKnownPeersRecordResetsFirewallFailures(t *testing.T) {
+	knownPeers := newKnownPeers(dssync.MutexWrap(dstore.NewMapDatastore()))
+
+	peerID, _ := generateTestPeer(t)
+	if err := knownPeers.record(peerID, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < maxFirewallFailures-1; i++ {
+		if err := knownPeers.recordFirewallFailure(peerID); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := knownPeers.record(peerID, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := knownPeers.recordFirewallFailure(peerID); err != nil {
+		t.Fatal(err)
+	}
+
+	record, err := knownPeers.get(peerID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if record == nil || record.FirewallFailures != 1 {
+		t.Errorf("unexpected known peer record: [%+v]", record)
+	}
+}
+
+func TestKnownPeersPrunedAfterDialFailures(t *testing.T) {
+	knownPeers := newKnownPeers(dssync.MutexWrap(dstore.NewMapDatastore()))
+
+	peerID, _ := generateTestPeer(t)
+	if err := knownPeers.record(peerID, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 1; i <= maxDialFailures; i++ {
+		if err := knownPeers.recordDialFailure(peerID); err != nil {
+			t.Fatal(err)
+		}
+
+		record, err := knownPeers.get(peerID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if i < maxDialFailures && record == nil {
+			t.Fatalf("known peer pruned after [%v] dial failures", i)
+		}
+		if i == maxDialFailures && record != nil {
+			t.Fatalf("known peer not pruned after [%v] dial failures", i)
+		}
+	}
+}
+
+func TestKnownPeersPruneStale(t *testing.T) {
+	knownPeers := newKnownPeers(dssync.MutexWrap(dstore.NewMapDatastore()))
+
+	stalePeerID, _ := generateTestPeer(t)
+	if err := knownPeers.record(stalePeerID, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// Make the other peer seen a day after the first one.
+	recentPeerID, _ := generateTestPeer(t)
+	if err := knownPeers.put(recentPeerID, &knownPeer{
+		LastSeen: time.Now().Add(24 * time.Hour),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := knownPeers.pruneStale(
+		time.Now().Add(knownPeerMaxAge + time.Hour),
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	peerInfos, err := knownPeers.list()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(peerInfos) != 1 || peerInfos[0].ID != recentPeerID {
+		t.Errorf("unexpected known peers: [%v]", peerInfos)
+	}
+}
+
+func generateTestPeer(t *testing.T) (peer.ID, *key.NetworkPublic) {
+	_, publicKey, err := key.GenerateStaticNetworkKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	peerID, err := peer.IDFromPublicKey(publicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return peerID, publicKey
+}
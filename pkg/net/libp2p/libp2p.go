@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/ecdsa"
 	"fmt"
+	"path/filepath"
 	"sync"
 	"time"
 
@@ -11,10 +12,13 @@ import (
 
 	"github.com/keep-network/keep-core/pkg/net"
 	"github.com/keep-network/keep-core/pkg/net/key"
+	"github.com/keep-network/keep-core/pkg/net/reputation"
 	"github.com/keep-network/keep-core/pkg/net/retransmission"
+	"github.com/keep-network/keep-core/pkg/net/security/handshake"
 	"github.com/keep-network/keep-core/pkg/net/watchtower"
 
 	dstore "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/namespace"
 	dssync "github.com/ipfs/go-datastore/sync"
 	addrutil "github.com/libp2p/go-addr-util"
 	libp2p "github.com/libp2p/go-libp2p"
@@ -22,6 +26,7 @@ import (
 	host "github.com/libp2p/go-libp2p-core/host"
 	libp2pnet "github.com/libp2p/go-libp2p-core/network"
 	peer "github.com/libp2p/go-libp2p-core/peer"
+	corepeerstore "github.com/libp2p/go-libp2p-core/peerstore"
 	dht "github.com/libp2p/go-libp2p-kad-dht"
 	dhtopts "github.com/libp2p/go-libp2p-kad-dht/opts"
 	peerstore "github.com/libp2p/go-libp2p-peerstore"
@@ -77,6 +82,24 @@ type Config struct {
 	Port               int
 	AnnouncedAddresses []string
 	DisseminationTime  int
+	// Router is the pubsub router used by broadcast channels, either
+	// "floodsub" or "gossipsub". Floodsub is used if the router is not set.
+	Router string
+	// RateLimits are limits of messages received from peers over broadcast
+	// and unicast channels.
+	RateLimits RateLimits
+	// NAT configures detecting whether the client is publicly reachable and
+	// making it reachable if it is behind a NAT or a firewall.
+	NAT NATConfig
+	// KeyFile is the path to the file with the network key and the
+	// certificate authorizing it. It is read by the client before connecting
+	// and is not used by Connect itself.
+	KeyFile string
+	// DatastoreSaveInterval is the interval, in seconds, in which DHT records
+	// and known peers are saved to the data directory. The datastore is also
+	// saved when the provider is closed. DefaultDatastoreSaveInterval is used
+	// if the interval is not set.
+	DatastoreSaveInterval int
 }
 
 type provider struct {
@@ -87,9 +110,16 @@ type provider struct {
 	identity          *identity
 	host              host.Host
 	routing           *dht.IpfsDHT
+	datastore         dstore.Batching
 	disseminationTime int
+	// closed is closed once the provider has been closed.
+	closed chan struct{}
+	// operatorCertificates authorize the network key to act on behalf of
+	// additional operators run by the client.
+	operatorCertificates []*key.Certificate
 
 	connectionManager *connectionManager
+	reputation        *reputation.Service
 }
 
 func (p *provider) UnicastChannelWith(
@@ -130,6 +160,10 @@ func (p *provider) CreateTransportIdentifier(publicKey ecdsa.PublicKey) (
 	return peer.IDFromPublicKey(&networkPublicKey)
 }
 
+func (p *provider) Reputation() net.Reputation {
+	return p.reputation
+}
+
 func (p *provider) BroadcastChannelForwarderFor(name string) {
 	if p.disseminationTime == 0 {
 		return
@@ -149,10 +183,28 @@ func (p *provider) BroadcastChannelForwarderFor(name string) {
 
 type connectionManager struct {
 	host.Host
+
+	localCertificate *key.Certificate
+	certificates     *certificateStore
+	capabilities     *capabilitiesStore
+	reachability     *reachabilityMonitor
 }
 
-func newConnectionManager(ctx context.Context, host host.Host) *connectionManager {
-	connectionManager := &connectionManager{host}
+func newConnectionManager(
+	ctx context.Context,
+	host host.Host,
+	localCertificate *key.Certificate,
+	certificates *certificateStore,
+	capabilities *capabilitiesStore,
+	reachability *reachabilityMonitor,
+) *connectionManager {
+	connectionManager := &connectionManager{
+		host,
+		localCertificate,
+		certificates,
+		capabilities,
+		reachability,
+	}
 
 	go connectionManager.monitorConnectedPeers(ctx)
 
@@ -189,6 +241,49 @@ func (cm *connectionManager) GetPeerPublicKey(connectedPeer string) (*key.Networ
 	return key.Libp2pKeyToNetworkKey(peerPublicKey), nil
 }
 
+func (cm *connectionManager) GetPeerCertificate(
+	connectedPeer string,
+) (*key.Certificate, error) {
+	peerID, err := peer.IDB58Decode(connectedPeer)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to decode peer ID from [%s]: [%v]",
+			connectedPeer,
+			err,
+		)
+	}
+
+	if peerID == cm.ID() {
+		return cm.localCertificate, nil
+	}
+
+	certificate, ok := cm.certificates.get(peerID)
+	if !ok {
+		return nil, fmt.Errorf(
+			"no certificate known for peer [%s]",
+			connectedPeer,
+		)
+	}
+
+	return certificate, nil
+}
+
+func (cm *connectionManager) GetPeerCapabilities(
+	connectedPeer string,
+) *net.PeerCapabilities {
+	peerID, err := peer.IDB58Decode(connectedPeer)
+	if err != nil {
+		logger.Warningf(
+			"failed to decode peer ID from [%s]: [%v]",
+			connectedPeer,
+			err,
+		)
+		return nil
+	}
+
+	return cm.capabilities.get(peerID)
+}
+
 func (cm *connectionManager) DisconnectPeer(peerHash string) {
 	peerID, err := peer.IDB58Decode(peerHash)
 	if err != nil {
@@ -216,6 +311,10 @@ func (cm *connectionManager) AddrStrings() []string {
 	return multiaddrStrings
 }
 
+func (cm *connectionManager) Reachability() net.Reachability {
+	return cm.reachability.get()
+}
+
 func (cm *connectionManager) IsConnected(address string) bool {
 	peerInfos, err := extractMultiAddrFromPeers([]string{address})
 	if err != nil {
@@ -245,6 +344,10 @@ func (cm *connectionManager) monitorConnectedPeers(ctx context.Context) {
 // ConnectOptions allows to set various options used by libp2p.
 type ConnectOptions struct {
 	RoutingTableRefreshPeriod time.Duration
+	Certificate               *key.Certificate
+	OperatorCertificates      []*key.Certificate
+	DataDir                   string
+	ClientVersion             string
 }
 
 func defaultConnectOptions() *ConnectOptions {
@@ -272,9 +375,53 @@ func WithRoutingTableRefreshPeriod(period time.Duration) ConnectOption {
 	}
 }
 
+// WithCertificate sets the certificate binding the network key to the
+// operator key. The certificate is presented to remote peers during the
+// connection handshake and attached to all outgoing messages. If no
+// certificate is set, the network key has to be the operator key.
+func WithCertificate(certificate *key.Certificate) ConnectOption {
+	return func(options *ConnectOptions) {
+		options.Certificate = certificate
+	}
+}
+
+// WithOperatorCertificates sets the certificates binding the network key to
+// the keys of additional operators run by the client. The certificates are
+// presented to remote peers during the connection handshake along with the
+// certificate set with WithCertificate, and views of the provider acting on
+// behalf of these operators can be obtained with OperatorProvider. Peers
+// running client versions which do not know about additional operators
+// accept handshake messages of up to 1 KiB, which leaves room for the
+// certificates of two additional operators.
+func WithOperatorCertificates(certificates ...*key.Certificate) ConnectOption {
+	return func(options *ConnectOptions) {
+		options.OperatorCertificates = certificates
+	}
+}
+
+// WithDataDir sets the directory under which DHT records and addresses of
+// known peers are persisted. Known peers are connected on startup, before
+// bootstrapping. Peers repeatedly failing the firewall or the connection
+// attempts and peers not seen for a long time are pruned. If no directory
+// is set, nothing is persisted.
+func WithDataDir(dataDir string) ConnectOption {
+	return func(options *ConnectOptions) {
+		options.DataDir = dataDir
+	}
+}
+
+// WithClientVersion sets the client version announced to remote peers
+// during the connection handshake.
+func WithClientVersion(clientVersion string) ConnectOption {
+	return func(options *ConnectOptions) {
+		options.ClientVersion = clientVersion
+	}
+}
+
 // Connect connects to a libp2p network based on the provided config. The
 // connection is managed in part by the passed context, and provides access to
-// the functionality specified in the net.Provider interface.
+// the functionality specified in the net.Provider interface. Once the context
+// is done, the host is closed along with all its connections.
 //
 // An error is returned if any part of the connection or bootstrap process
 // fails.
@@ -294,6 +441,32 @@ func Connect(
 		)
 	}
 
+	switch config.Router {
+	case "", FloodSubRouter, GossipSubRouter:
+	default:
+		return nil, fmt.Errorf(
+			"unsupported pubsub router [%v]; must be one of [%v, %v]",
+			config.Router,
+			FloodSubRouter,
+			GossipSubRouter,
+		)
+	}
+
+	if err := validateRateLimits(config.RateLimits); err != nil {
+		return nil, err
+	}
+
+	if err := validateNATConfig(config.NAT); err != nil {
+		return nil, err
+	}
+
+	if config.DatastoreSaveInterval < 0 {
+		return nil, fmt.Errorf(
+			"datastore save interval must not be negative; got [%v]",
+			config.DatastoreSaveInterval,
+		)
+	}
+
 	connectOptions := defaultConnectOptions()
 	connectOptions.apply(options...)
 
@@ -302,32 +475,136 @@ func Connect(
 		return nil, err
 	}
 
+	if certificate := connectOptions.Certificate; certificate != nil {
+		if err := certificate.VerifyFor(staticKey.GetPublic().(*key.NetworkPublic)); err != nil {
+			return nil, fmt.Errorf("invalid network key certificate: [%v]", err)
+		}
+
+		identity.certificate = certificate
+	}
+
+	for _, certificate := range connectOptions.OperatorCertificates {
+		if err := certificate.VerifyFor(staticKey.GetPublic().(*key.NetworkPublic)); err != nil {
+			return nil, fmt.Errorf("invalid operator certificate: [%v]", err)
+		}
+	}
+
+	certificates := newCertificateStore()
+	capabilities := newCapabilitiesStore()
+	peerReputation := reputation.NewService()
+
+	var (
+		datastore  dstore.Batching = dssync.MutexWrap(dstore.NewMapDatastore())
+		peerStore  corepeerstore.Peerstore
+		knownPeers *knownPeers
+	)
+	if connectOptions.DataDir != "" {
+		saveInterval := DefaultDatastoreSaveInterval
+		if config.DatastoreSaveInterval > 0 {
+			saveInterval = time.Duration(config.DatastoreSaveInterval) *
+				time.Second
+		}
+
+		persistentDatastore, err := newPersistentDatastore(
+			filepath.Join(connectOptions.DataDir, "network", "datastore.json"),
+			saveInterval,
+		)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"could not create network datastore: [%v]",
+				err,
+			)
+		}
+		go persistentDatastore.run(ctx)
+
+		datastore = persistentDatastore
+
+		peerStore, err = newPersistentPeerstore(
+			ctx,
+			namespace.Wrap(datastore, dstore.NewKey("/peers")),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("could not create peerstore: [%v]", err)
+		}
+
+		knownPeers = newKnownPeers(
+			namespace.Wrap(datastore, dstore.NewKey("/known-peers")),
+		)
+		firewall = &knownPeersFirewall{firewall, knownPeers}
+	}
+
 	host, err := discoverAndListen(
 		ctx,
 		identity,
+		connectOptions.OperatorCertificates,
 		config.Port,
 		protocol,
+		localCapabilities(connectOptions.ClientVersion),
 		config.AnnouncedAddresses,
+		config.NAT,
 		firewall,
+		certificates,
+		capabilities,
+		peerReputation,
+		peerStore,
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	host.Network().Notify(buildNotifiee())
+	host.Network().Notify(buildNotifiee(certificates, capabilities))
+	certificates.onSuperseded(func(peerID peer.ID) {
+		if err := host.Network().ClosePeer(peerID); err != nil {
+			logger.Warningf(
+				"could not disconnect peer [%v]: [%v]",
+				peerID,
+				err,
+			)
+		}
+	})
 
-	broadcastChannelManager, err := newChannelManager(ctx, identity, host, ticker)
+	reachability := newReachabilityMonitor(len(config.NAT.Relays) > 0)
+	if err := reachability.run(ctx, host.EventBus()); err != nil {
+		return nil, err
+	}
+
+	rateLimiter := newRateLimiter(identity.id, config.RateLimits)
+	go rateLimiter.run(ctx)
+
+	broadcastChannelManager, err := newChannelManager(
+		ctx,
+		identity,
+		host,
+		ticker,
+		config.Router,
+		rateLimiter,
+		peerReputation,
+	)
 	if err != nil {
 		return nil, err
 	}
 
-	unicastChannelManager := newUnicastChannelManager(ctx, identity, host)
+	unicastChannelManager := newUnicastChannelManager(
+		ctx,
+		identity,
+		host,
+		rateLimiter,
+		peerReputation,
+		capabilities,
+	)
+
+	broadcastChannelManager.capabilities = capabilities
+	broadcastChannelManager.missedMessages = newMissedMessagesService(
+		ctx,
+		broadcastChannelManager,
+		unicastChannelManager,
+		rateLimiter,
+	)
 
-	dhtDatastore := dssync.MutexWrap(dstore.NewMapDatastore())
 	router, err := dht.New(
 		ctx,
 		host,
-		dhtopts.Datastore(dhtDatastore),
+		dhtopts.Datastore(namespace.Wrap(datastore, dstore.NewKey("/dht"))),
 		dhtopts.RoutingTableRefreshPeriod(
 			connectOptions.RoutingTableRefreshPeriod,
 		),
@@ -341,39 +618,105 @@ func Connect(
 		broadcastChannelManager: broadcastChannelManager,
 		unicastChannelManager:   unicastChannelManager,
 		identity:                identity,
+		operatorCertificates:    connectOptions.OperatorCertificates,
 		host:                    rhost.Wrap(host, router),
 		routing:                 router,
+		datastore:               datastore,
 		disseminationTime:       config.DisseminationTime,
+		reputation:              peerReputation,
+		closed:                  make(chan struct{}),
 	}
 
 	if len(config.Peers) == 0 {
 		logger.Infof("bootstrap peers list is empty")
 	}
 
+	if knownPeers != nil {
+		knownPeers.connect(ctx, provider.host)
+		go knownPeers.run(ctx, provider.host)
+	}
+
 	if err := provider.bootstrap(ctx, config.Peers); err != nil {
 		return nil, fmt.Errorf("bootstrap failed: [%v]", err)
 	}
 
-	provider.connectionManager = newConnectionManager(ctx, provider.host)
+	provider.connectionManager = newConnectionManager(
+		ctx,
+		provider.host,
+		identity.certificate,
+		certificates,
+		capabilities,
+		reachability,
+	)
 
 	// Instantiates and starts the connection management background process.
-	watchtower.NewGuard(
+	guard := watchtower.NewGuard(
 		ctx,
 		FirewallCheckTick,
 		firewall,
 		provider.connectionManager,
 	)
 
+	rateLimiter.setViolationHandler(func(peerID peer.ID, reason string) {
+		guard.ReportViolation(peerID.String(), reason)
+	})
+
+	guard.EnforceReputation(ctx, peerReputation)
+
+	go func() {
+		<-ctx.Done()
+		provider.close()
+	}()
+
 	return provider, nil
 }
 
+// close stops the DHT, closes the host along with all its connections and
+// then closes the datastore, which saves it if it is persistent. It is called
+// once the context the provider has been connected with is done.
+func (p *provider) close() {
+	if err := p.routing.Close(); err != nil {
+		logger.Warningf("could not close the DHT: [%v]", err)
+	}
+
+	if err := p.host.Close(); err != nil {
+		logger.Warningf("could not close the host: [%v]", err)
+	}
+
+	if err := p.datastore.Close(); err != nil {
+		logger.Warningf("could not close the datastore: [%v]", err)
+	}
+
+	logger.Infof("network provider closed")
+
+	close(p.closed)
+}
+
+// Closed returns a channel which is closed once the provider returned by
+// Connect has closed its host and saved its datastore, after the context it
+// has been connected with is done. It returns nil, a channel which is never
+// closed, for providers not returned by Connect.
+func Closed(netProvider net.Provider) <-chan struct{} {
+	if p, ok := netProvider.(*provider); ok {
+		return p.closed
+	}
+	return nil
+}
+
 func discoverAndListen(
 	ctx context.Context,
 	identity *identity,
+	operatorCertificates []*key.Certificate,
 	port int,
 	protocol string,
+	localCapabilities *handshake.Capabilities,
 	announcedAddresses []string,
+	natConfig NATConfig,
 	firewall net.Firewall,
+	certificates *certificateStore,
+	capabilities *capabilitiesStore,
+	reputation net.Reputation,
+	peerStore corepeerstore.Peerstore,
 ) (host.Host, error) {
 	var err error
 
@@ -385,8 +728,14 @@ func discoverAndListen(
 
 	transport, err := newEncryptedAuthenticatedTransport(
 		identity.privKey,
+		identity.certificate,
+		operatorCertificates,
 		protocol,
+		localCapabilities,
 		firewall,
+		certificates,
+		capabilities,
+		reputation,
 	)
 	if err != nil {
 		return nil, fmt.Errorf(
@@ -420,6 +769,16 @@ func discoverAndListen(
 		options = append(options, libp2p.AddrsFactory(addressFactory))
 	}
 
+	if peerStore != nil {
+		options = append(options, libp2p.Peerstore(peerStore))
+	}
+
+	natOptions, err := natOptions(natConfig)
+	if err != nil {
+		return nil, err
+	}
+	options = append(options, natOptions...)
+
 	return libp2p.New(ctx, options...)
 }
 
@@ -497,7 +856,10 @@ func extractMultiAddrFromPeers(peers []string) ([]peerstore.PeerInfo, error) {
 	return peerInfos, nil
 }
 
-func buildNotifiee() libp2pnet.Notifiee {
+func buildNotifiee(
+	certificates *certificateStore,
+	capabilities *capabilitiesStore,
+) libp2pnet.Notifiee {
 	notifyBundle := &libp2pnet.NotifyBundle{}
 
 	notifyBundle.ConnectedF = func(_ libp2pnet.Network, connection libp2pnet.Conn) {
@@ -509,7 +871,10 @@ func buildNotifiee() libp2pnet.Notifiee {
 			),
 		)
 	}
-	notifyBundle.DisconnectedF = func(_ libp2pnet.Network, connection libp2pnet.Conn) {
+	notifyBundle.DisconnectedF = func(
+		network libp2pnet.Network,
+		connection libp2pnet.Conn,
+	) {
 		logger.Infof(
 			"disconnected from [%v]",
 			multiaddressWithIdentity(
@@ -517,6 +882,14 @@ func buildNotifiee() libp2pnet.Notifiee {
 				connection.RemotePeer(),
 			),
 		)
+
+		// The certificate and capabilities are presented again on the next
+		// handshake with the peer so there is no reason to keep them after
+		// the last connection with the peer is closed.
+		if network.Connectedness(connection.RemotePeer()) != libp2pnet.Connected {
+			certificates.remove(connection.RemotePeer())
+			capabilities.remove(connection.RemotePeer())
+		}
 	}
 
 	return notifyBundle
@@ -1,17 +1,23 @@
 package libp2p
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/keep-network/keep-common/pkg/chain/ethereum/ethutil"
 	"github.com/keep-network/keep-core/pkg/firewall"
 	"github.com/keep-network/keep-core/pkg/net"
 	"github.com/keep-network/keep-core/pkg/net/key"
 	"github.com/keep-network/keep-core/pkg/net/retransmission"
+	"github.com/keep-network/keep-core/pkg/operator"
 )
 
 func TestProviderReturnsType(t *testing.T) {
@@ -44,6 +50,48 @@ func TestProviderReturnsType(t *testing.T) {
 	}
 }
 
+func TestProviderClosedSavesDatastore(t *testing.T) {
+	ctx, cancel := newTestContext()
+	defer cancel()
+
+	dataDir, err := ioutil.TempDir("", "provider")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dataDir)
+
+	privKey, _, err := key.GenerateStaticNetworkKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	provider, err := Connect(
+		ctx,
+		generateDeterministicNetworkConfig(),
+		privKey,
+		ProtocolBeacon,
+		firewall.Disabled,
+		idleTicker(),
+		WithDataDir(dataDir),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cancel()
+
+	select {
+	case <-Closed(provider):
+	case <-time.After(10 * time.Second):
+		t.Fatal("provider has not been closed")
+	}
+
+	datastoreFile := filepath.Join(dataDir, "network", "datastore.json")
+	if _, err := os.Stat(datastoreFile); err != nil {
+		t.Errorf("expected datastore to be saved: [%v]", err)
+	}
+}
+
 func TestProviderReturnsChannel(t *testing.T) {
 	ctx, cancel := newTestContext()
 	defer cancel()
@@ -152,6 +200,125 @@ func TestSendReceive(t *testing.T) {
 	}
 }
 
+func TestOperatorProviderSendsOnBehalfOfOperator(t *testing.T) {
+	ctx, cancel := newTestContext()
+	defer cancel()
+
+	name := "testchannel"
+
+	networkPrivateKey, networkPublicKey, err := key.GenerateStaticNetworkKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	operatorPrivateKey, operatorPublicKey, err := operator.GenerateKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certificate, err := key.IssueCertificate(
+		operatorPublicKey,
+		networkPublicKey,
+		key.DefaultCertificateValidity,
+		ethutil.NewSigner(operatorPrivateKey).Sign,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	provider, err := Connect(
+		ctx,
+		generateDeterministicNetworkConfig(),
+		networkPrivateKey,
+		ProtocolBeacon,
+		firewall.Disabled,
+		idleTicker(),
+		WithOperatorCertificates(certificate),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	operatorProvider, err := OperatorProvider(provider, certificate)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	broadcastChannel, err := provider.BroadcastChannelFor(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	broadcastChannel.SetUnmarshaler(
+		func() net.TaggedUnmarshaler { return &testMessage{} },
+	)
+
+	operatorChannel, err := operatorProvider.BroadcastChannelFor(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	recvChan := make(chan net.Message, 1)
+	broadcastChannel.Recv(ctx, func(msg net.Message) {
+		recvChan <- msg
+	})
+
+	if err := operatorChannel.Send(
+		ctx,
+		&testMessage{Payload: "some text"},
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case msg := <-recvChan:
+		expectedSender := operator.Marshal(operatorPublicKey)
+		if !bytes.Equal(expectedSender, msg.SenderPublicKey()) {
+			t.Errorf(
+				"unexpected sender public key\nexpected: [%x]\nactual:   [%x]",
+				expectedSender,
+				msg.SenderPublicKey(),
+			)
+		}
+	case <-ctx.Done():
+		t.Fatal("message has not been received")
+	}
+
+	// The certificate does not authorize the network key of the provider.
+	_, anotherNetworkPublicKey, err := key.GenerateStaticNetworkKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	anotherCertificate, err := key.IssueCertificate(
+		operatorPublicKey,
+		anotherNetworkPublicKey,
+		key.DefaultCertificateValidity,
+		ethutil.NewSigner(operatorPrivateKey).Sign,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := OperatorProvider(provider, anotherCertificate); err == nil {
+		t.Errorf("expected certificate of another network key to be rejected")
+	}
+
+	// The certificate has not been presented by the provider.
+	notPresentedCertificate, err := key.IssueCertificate(
+		operatorPublicKey,
+		networkPublicKey,
+		key.DefaultCertificateValidity,
+		ethutil.NewSigner(operatorPrivateKey).Sign,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := OperatorProvider(provider, notPresentedCertificate); err == nil {
+		t.Errorf("expected certificate not presented by provider to be rejected")
+	}
+}
+
 func TestProviderSetAnnouncedAddresses(t *testing.T) {
 	ctx, cancel := newTestContext()
 	defer cancel()
@@ -0,0 +1,517 @@
+package libp2p
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/net/gen/pb"
+	"github.com/keep-network/keep-core/pkg/net/rpc"
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/peer"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pubsubpb "github.com/libp2p/go-libp2p-pubsub/pb"
+)
+
+const (
+	// messageHistorySize is the number of most recent messages kept by every
+	// broadcast channel so that they can be redelivered or served to peers
+	// which missed them.
+	messageHistorySize = 1024
+	// missedMessagesPeers is the number of peers subscribed to the channel
+	// which are asked for missed messages.
+	missedMessagesPeers = 3
+)
+
+// historyEntry is a broadcast channel message kept in the channel history.
+// The message is kept in the form signed by its author so that peers it is
+// served to can verify it has not been tampered with.
+//
+// Every retransmission of a message is published to the topic as a separate
+// pubsub message with its own id. All of them share the same messageID.
+type historyEntry struct {
+	id              string
+	messageID       string
+	sequence        uint64
+	senderPublicKey []byte
+	messageType     string
+	received        time.Time
+	message         *pubsubpb.Message
+}
+
+// messageHistory holds the most recent messages received by a broadcast
+// channel. Once the history is full, the oldest messages are evicted.
+// Entries are numbered with consecutive sequences, starting from 1, in the
+// order they have been added.
+type messageHistory struct {
+	mutex    sync.Mutex
+	entries  []*historyEntry
+	ids      map[string]bool
+	sequence uint64
+	evicted  bool
+}
+
+func newMessageHistory() *messageHistory {
+	return &messageHistory{
+		entries: make([]*historyEntry, 0),
+		ids:     make(map[string]bool),
+	}
+}
+
+// add adds the entry to the history and returns the sequence assigned to it.
+// It returns zero if the entry is already in the history.
+func (mh *messageHistory) add(entry *historyEntry) uint64 {
+	mh.mutex.Lock()
+	defer mh.mutex.Unlock()
+
+	if mh.ids[entry.id] {
+		return 0
+	}
+
+	mh.sequence++
+	entry.sequence = mh.sequence
+
+	mh.entries = append(mh.entries, entry)
+	mh.ids[entry.id] = true
+
+	if len(mh.entries) > messageHistorySize {
+		delete(mh.ids, mh.entries[0].id)
+		mh.entries = mh.entries[1:]
+		mh.evicted = true
+	}
+
+	return entry.sequence
+}
+
+// lastSequence returns the sequence of the entry added to the history most
+// recently or zero if no entry has been added yet.
+func (mh *messageHistory) lastSequence() uint64 {
+	mh.mutex.Lock()
+	defer mh.mutex.Unlock()
+
+	return mh.sequence
+}
+
+// horizon returns the time the oldest entry in the history has been received
+// at if older entries have been evicted. It returns zero time if the history
+// holds all messages received by the channel.
+func (mh *messageHistory) horizon() time.Time {
+	mh.mutex.Lock()
+	defer mh.mutex.Unlock()
+
+	if !mh.evicted || len(mh.entries) == 0 {
+		return time.Time{}
+	}
+
+	return mh.entries[0].received
+}
+
+func (mh *messageHistory) has(id string) bool {
+	mh.mutex.Lock()
+	defer mh.mutex.Unlock()
+
+	return mh.ids[id]
+}
+
+// hasMessage returns true if any copy of the message with the given
+// messageID is in the history.
+func (mh *messageHistory) hasMessage(messageID string) bool {
+	mh.mutex.Lock()
+	defer mh.mutex.Unlock()
+
+	for _, entry := range mh.entries {
+		if entry.messageID == messageID {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (mh *messageHistory) matching(
+	filter net.MissedMessagesFilter,
+) []*historyEntry {
+	mh.mutex.Lock()
+	defer mh.mutex.Unlock()
+
+	entries := make([]*historyEntry, 0)
+	for _, entry := range mh.entries {
+		if filter.Matches(
+			entry.senderPublicKey,
+			entry.messageType,
+			entry.received,
+		) {
+			entries = append(entries, entry)
+		}
+	}
+
+	return entries
+}
+
+// missedBy returns entries matching the filter which have been missed by
+// a message handler registered when the last entry in the history had the
+// given sequence. Those are entries added before the handler was registered
+// unless another copy of the same message has been added afterwards and
+// passed to the handler along with new messages. Only one copy of every
+// message is returned.
+func (mh *messageHistory) missedBy(
+	filter net.MissedMessagesFilter,
+	registeredAt uint64,
+) []*historyEntry {
+	mh.mutex.Lock()
+	defer mh.mutex.Unlock()
+
+	received := make(map[string]bool)
+	for _, entry := range mh.entries {
+		if entry.sequence > registeredAt {
+			received[entry.messageID] = true
+		}
+	}
+
+	entries := make([]*historyEntry, 0)
+	for _, entry := range mh.entries {
+		if entry.sequence > registeredAt {
+			break
+		}
+
+		if received[entry.messageID] {
+			continue
+		}
+
+		if filter.Matches(
+			entry.senderPublicKey,
+			entry.messageType,
+			entry.received,
+		) {
+			entries = append(entries, entry)
+			received[entry.messageID] = true
+		}
+	}
+
+	return entries
+}
+
+// broadcastMessageID identifies the message published by the given author
+// with the given sequence number along with all its retransmissions.
+func broadcastMessageID(author peer.ID, sequenceNumber uint64) string {
+	return fmt.Sprintf("%v-%v", author, sequenceNumber)
+}
+
+// missedMessagesService serves broadcast channel messages to peers which
+// missed them and requests messages missed by this client. Requests are
+// made with RPC calls over unicast channels, one RPC channel per peer.
+type missedMessagesService struct {
+	ctx context.Context
+
+	channelManager        *channelManager
+	unicastChannelManager *unicastChannelManager
+	rateLimiter           *rateLimiter
+
+	rpcChannelsMutex sync.Mutex
+	rpcChannels      map[peer.ID]*rpc.Channel
+}
+
+func newMissedMessagesService(
+	ctx context.Context,
+	channelManager *channelManager,
+	unicastChannelManager *unicastChannelManager,
+	rateLimiter *rateLimiter,
+) *missedMessagesService {
+	service := &missedMessagesService{
+		ctx:                   ctx,
+		channelManager:        channelManager,
+		unicastChannelManager: unicastChannelManager,
+		rateLimiter:           rateLimiter,
+		rpcChannels:           make(map[peer.ID]*rpc.Channel),
+	}
+
+	unicastChannelManager.onChannelCreated(func(channel *unicastChannel) {
+		service.serve(channel)
+	})
+
+	return service
+}
+
+// serve makes the service handle missed messages requests received over the
+// given unicast channel and returns the RPC channel used to make requests to
+// the remote peer.
+func (mms *missedMessagesService) serve(channel *unicastChannel) *rpc.Channel {
+	mms.rpcChannelsMutex.Lock()
+	defer mms.rpcChannelsMutex.Unlock()
+
+	rpcChannel, ok := mms.rpcChannels[channel.remotePeerID]
+	if !ok {
+		rpcChannel = rpc.NewChannel(mms.ctx, channel)
+		rpcChannel.Handle(
+			func() net.TaggedUnmarshaler { return &missedMessagesRequest{} },
+			mms.handleRequest,
+		)
+		mms.rpcChannels[channel.remotePeerID] = rpcChannel
+	}
+
+	return rpcChannel
+}
+
+func (mms *missedMessagesService) handleRequest(
+	ctx context.Context,
+	message net.Message,
+) (net.TaggedMarshaler, error) {
+	request, ok := message.Payload().(*missedMessagesRequest)
+	if !ok {
+		return nil, fmt.Errorf("unexpected request type")
+	}
+
+	channel, ok := mms.channelManager.existingChannel(request.Channel)
+	if !ok {
+		return nil, fmt.Errorf("channel [%v] is not open", request.Channel)
+	}
+
+	filter := net.MissedMessagesFilter{
+		SenderPublicKey: request.Sender,
+		Type:            request.MissedMessagesRequest.Type,
+	}
+	if request.MaxAge > 0 {
+		filter.Since = time.Now().Add(
+			-time.Duration(request.MaxAge) * time.Millisecond,
+		)
+	}
+
+	known := make(map[string]bool, len(request.KnownMessages))
+	for _, id := range request.KnownMessages {
+		known[string(id)] = true
+	}
+
+	// The response has to fit in a single unicast message along with its
+	// RPC envelope.
+	maxSize := mms.rateLimiter.maxMessageSize() * 3 / 4
+
+	response := &missedMessagesResponse{}
+	size := 0
+	for _, entry := range channel.history.matching(filter) {
+		if known[entry.id] {
+			continue
+		}
+
+		messageBytes, err := entry.message.Marshal()
+		if err != nil {
+			return nil, err
+		}
+
+		if size+len(messageBytes) > maxSize {
+			logger.Debugf(
+				"not all missed messages of channel [%v] fit in the response",
+				request.Channel,
+			)
+			break
+		}
+
+		size += len(messageBytes)
+		response.Messages = append(response.Messages, messageBytes)
+	}
+
+	return response, nil
+}
+
+// request asks random peers subscribed to the channel for messages matching
+// the filter which are not in the channel history yet. Received messages are
+// verified and processed by the channel as if they were received from the
+// channel topic. It returns an error only if no peer could be asked.
+func (mms *missedMessagesService) request(
+	ctx context.Context,
+	channel *channel,
+	filter net.MissedMessagesFilter,
+) error {
+	peers := mms.supportingPeers(channel, channel.pubsub.ListPeers(channel.name))
+	if len(peers) == 0 {
+		logger.Debugf(
+			"no peers to request missed messages of channel [%v] from",
+			channel.name,
+		)
+		return nil
+	}
+
+	rand.Shuffle(len(peers), func(i, j int) {
+		peers[i], peers[j] = peers[j], peers[i]
+	})
+	if len(peers) > missedMessagesPeers {
+		peers = peers[:missedMessagesPeers]
+	}
+
+	request := &missedMessagesRequest{pb.MissedMessagesRequest{
+		Channel: channel.name,
+		Sender:  filter.SenderPublicKey,
+		Type:    filter.Type,
+	}}
+	if !filter.Since.IsZero() {
+		maxAge := time.Since(filter.Since) / time.Millisecond
+		if maxAge < 1 {
+			maxAge = 1
+		}
+		request.MaxAge = uint64(maxAge)
+	}
+	for _, entry := range channel.history.matching(filter) {
+		request.KnownMessages = append(request.KnownMessages, []byte(entry.id))
+	}
+
+	var (
+		wg     sync.WaitGroup
+		failed int
+		mutex  sync.Mutex
+	)
+
+	for _, peerID := range peers {
+		wg.Add(1)
+		go func(peerID peer.ID) {
+			defer wg.Done()
+
+			if err := mms.requestFrom(ctx, peerID, channel, request); err != nil {
+				logger.Warningf(
+					"could not request missed messages of channel [%v] "+
+						"from peer [%v]: [%v]",
+					channel.name,
+					peerID,
+					err,
+				)
+
+				mutex.Lock()
+				failed++
+				mutex.Unlock()
+			}
+		}(peerID)
+	}
+
+	wg.Wait()
+
+	if failed == len(peers) {
+		return fmt.Errorf(
+			"could not request missed messages from any of [%v] peers",
+			len(peers),
+		)
+	}
+
+	return nil
+}
+
+// supportingPeers returns peers which negotiated the missed messages feature
+// during the connection handshake. All peers are returned if capabilities
+// of peers are not tracked.
+func (mms *missedMessagesService) supportingPeers(
+	channel *channel,
+	peers []peer.ID,
+) []peer.ID {
+	if channel.capabilities == nil {
+		return peers
+	}
+
+	supporting := make([]peer.ID, 0, len(peers))
+	for _, peerID := range peers {
+		if channel.capabilities.get(peerID).Supports(FeatureMissedMessages) {
+			supporting = append(supporting, peerID)
+		}
+	}
+
+	return supporting
+}
+
+func (mms *missedMessagesService) requestFrom(
+	ctx context.Context,
+	peerID peer.ID,
+	channel *channel,
+	request *missedMessagesRequest,
+) error {
+	// Unicast channels are cached by peer ID so the ID must not be wrapped.
+	unicastChannel, err := mms.unicastChannelManager.getUnicastChannelWithHandshake(
+		peerID,
+	)
+	if err != nil {
+		return err
+	}
+
+	response := &missedMessagesResponse{}
+	if err := mms.serve(unicastChannel).Call(ctx, request, response); err != nil {
+		return err
+	}
+
+	for _, messageBytes := range response.Messages {
+		if err := channel.processMissedMessage(peerID, messageBytes); err != nil {
+			logger.Warningf(
+				"could not process missed message of channel [%v] "+
+					"received from peer [%v]: [%v]",
+				channel.name,
+				peerID,
+				err,
+			)
+		}
+	}
+
+	return nil
+}
+
+// verifyPubsubMessageSignature checks the message has been signed by its author.
+// Pubsub verifies signatures of messages received from the topic; messages
+// served by peers which missed them have to be verified the same way.
+func verifyPubsubMessageSignature(message *pubsubpb.Message) error {
+	author, err := peer.IDFromBytes(message.From)
+	if err != nil {
+		return err
+	}
+
+	var publicKey crypto.PubKey
+	if message.Key == nil {
+		publicKey, err = author.ExtractPublicKey()
+		if err != nil {
+			return fmt.Errorf("cannot extract signing key: [%v]", err)
+		}
+		if publicKey == nil {
+			return fmt.Errorf("cannot extract signing key")
+		}
+	} else {
+		publicKey, err = crypto.UnmarshalPublicKey(message.Key)
+		if err != nil {
+			return fmt.Errorf("cannot unmarshal signing key: [%v]", err)
+		}
+		if !author.MatchesPublicKey(publicKey) {
+			return fmt.Errorf("signing key does not match author [%v]", author)
+		}
+	}
+
+	unsigned := *message
+	unsigned.Signature = nil
+	unsigned.Key = nil
+	unsignedBytes, err := unsigned.Marshal()
+	if err != nil {
+		return err
+	}
+
+	valid, err := publicKey.Verify(
+		append([]byte(pubsub.SignPrefix), unsignedBytes...),
+		message.Signature,
+	)
+	if err != nil {
+		return err
+	}
+	if !valid {
+		return fmt.Errorf("invalid signature")
+	}
+
+	return nil
+}
+
+type missedMessagesRequest struct {
+	pb.MissedMessagesRequest
+}
+
+func (mmr *missedMessagesRequest) Type() string {
+	return "net/missed_messages_request"
+}
+
+type missedMessagesResponse struct {
+	pb.MissedMessagesResponse
+}
+
+func (mmr *missedMessagesResponse) Type() string {
+	return "net/missed_messages_response"
+}
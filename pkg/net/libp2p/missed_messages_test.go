@@ -0,0 +1,257 @@
+package libp2p
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/operator"
+)
+
+func TestRequestMissedMessages(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	withNetwork(ctx, t, 9600, func(
+		identity1 *identity,
+		identity2 *identity,
+		provider1 net.Provider,
+		provider2 net.Provider,
+	) {
+		channelName := "missed-messages"
+
+		channel1, err := provider1.BroadcastChannelFor(channelName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		channel1.SetUnmarshaler(func() net.TaggedUnmarshaler {
+			return &testMessage{}
+		})
+
+		err = channel1.Send(ctx, &testMessage{Payload: "missed"})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// The message is in the history of the sender's channel once it
+		// has been delivered to the sender's own subscription.
+		err = withRetry(func() error {
+			if len(channel1.(*channel).history.matching(
+				net.MissedMessagesFilter{},
+			)) == 0 {
+				return fmt.Errorf("message not recorded yet")
+			}
+			return nil
+		}, 10, 100*time.Millisecond)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// The second peer subscribes to the channel after the message has
+		// been published.
+		channel2, err := provider2.BroadcastChannelFor(channelName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		channel2.SetUnmarshaler(func() net.TaggedUnmarshaler {
+			return &testMessage{}
+		})
+
+		receivedMessages := make(chan net.Message, 2)
+		channel2.Recv(ctx, func(message net.Message) {
+			receivedMessages <- message
+		})
+
+		err = withRetry(func() error {
+			if len(channel2.(*channel).pubsub.ListPeers(channelName)) == 0 {
+				return fmt.Errorf("no peers subscribed to the channel")
+			}
+			return nil
+		}, 10, 100*time.Millisecond)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		operatorPublicKey1, err := identity1.operatorPublicKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = channel2.RequestMissedMessages(
+			ctx,
+			net.MissedMessagesFilter{
+				SenderPublicKey: operator.Marshal(operatorPublicKey1),
+				Type:            (&testMessage{}).Type(),
+				Since:           time.Now().Add(-time.Minute),
+			},
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		select {
+		case message := <-receivedMessages:
+			payload := message.Payload().(*testMessage).Payload
+			if payload != "missed" {
+				t.Errorf("unexpected message payload [%v]", payload)
+			}
+			if message.TransportSenderID() != identity1.id {
+				t.Errorf(
+					"unexpected message sender [%v]",
+					message.TransportSenderID(),
+				)
+			}
+		case <-ctx.Done():
+			t.Fatal("expected missed message")
+		}
+
+		// Requesting again redelivers the message from the history but the
+		// handler has already received it.
+		err = channel2.RequestMissedMessages(ctx, net.MissedMessagesFilter{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		select {
+		case <-receivedMessages:
+			t.Errorf("unexpected duplicate message")
+		case <-time.After(500 * time.Millisecond):
+		}
+	})
+}
+
+func TestProcessTamperedMissedMessage(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	withNetwork(ctx, t, 9610, func(
+		identity1 *identity,
+		identity2 *identity,
+		provider1 net.Provider,
+		provider2 net.Provider,
+	) {
+		channel1, err := provider1.BroadcastChannelFor("tampered-1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		channel1.SetUnmarshaler(func() net.TaggedUnmarshaler {
+			return &testMessage{}
+		})
+
+		err = channel1.Send(ctx, &testMessage{Payload: "original"})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var entries []*historyEntry
+		err = withRetry(func() error {
+			entries = channel1.(*channel).history.matching(
+				net.MissedMessagesFilter{},
+			)
+			if len(entries) == 0 {
+				return fmt.Errorf("message not recorded yet")
+			}
+			return nil
+		}, 10, 100*time.Millisecond)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		channel2, err := provider2.BroadcastChannelFor("tampered-2")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		tampered := *entries[0].message
+		tampered.TopicIDs = []string{"tampered-2"}
+		tamperedBytes, err := tampered.Marshal()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = channel2.(*channel).processMissedMessage(
+			identity1.id,
+			tamperedBytes,
+		)
+		if err == nil || err.Error() != "invalid signature" {
+			t.Errorf("expected invalid signature error; has [%v]", err)
+		}
+
+		originalBytes, err := entries[0].message.Marshal()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = channel2.(*channel).processMissedMessage(
+			identity1.id,
+			originalBytes,
+		)
+		expectedError := "message topics [tampered-1] do not include " +
+			"channel [tampered-2]"
+		if err == nil || err.Error() != expectedError {
+			t.Errorf(
+				"unexpected error\nexpected: [%v]\nactual:   [%v]",
+				expectedError,
+				err,
+			)
+		}
+	})
+}
+
+func TestMessageHistoryMissedBy(t *testing.T) {
+	history := newMessageHistory()
+
+	add := func(id string, messageID string) uint64 {
+		return history.add(&historyEntry{
+			id:        id,
+			messageID: messageID,
+			received:  time.Now(),
+		})
+	}
+
+	add("1", "message-1")
+	add("2", "message-2")
+	add("3", "message-1") // retransmission of message-1
+	registeredAt := history.lastSequence()
+	add("4", "message-2") // retransmission received by the handler
+	add("5", "message-3")
+
+	if sequence := add("5", "message-3"); sequence != 0 {
+		t.Errorf("expected zero sequence for a duplicate, got [%v]", sequence)
+	}
+
+	missed := history.missedBy(net.MissedMessagesFilter{}, registeredAt)
+
+	if len(missed) != 1 {
+		t.Fatalf("expected one missed message, got [%v]", len(missed))
+	}
+	if missed[0].messageID != "message-1" {
+		t.Errorf("unexpected missed message [%v]", missed[0].messageID)
+	}
+}
+
+func TestMessageHistoryHorizon(t *testing.T) {
+	history := newMessageHistory()
+
+	for i := 0; i < messageHistorySize; i++ {
+		history.add(&historyEntry{
+			id:       fmt.Sprintf("%v", i),
+			received: time.Unix(int64(i), 0),
+		})
+	}
+
+	if horizon := history.horizon(); !horizon.IsZero() {
+		t.Errorf("expected no horizon before eviction, got [%v]", horizon)
+	}
+
+	history.add(&historyEntry{
+		id:       "evicting",
+		received: time.Unix(messageHistorySize, 0),
+	})
+
+	if horizon := history.horizon(); !horizon.Equal(time.Unix(1, 0)) {
+		t.Errorf("unexpected horizon [%v]", horizon)
+	}
+}
@@ -0,0 +1,169 @@
+package libp2p
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	libp2p "github.com/libp2p/go-libp2p"
+	circuit "github.com/libp2p/go-libp2p-circuit"
+	"github.com/libp2p/go-libp2p-core/event"
+	libp2pnet "github.com/libp2p/go-libp2p-core/network"
+
+	"github.com/keep-network/keep-core/pkg/net"
+)
+
+// NATConfig defines how the client deals with being behind a NAT or
+// a firewall. Whether the client is publicly reachable is always detected
+// with AutoNAT, by asking connected peers running the AutoNAT service to dial
+// the client back.
+type NATConfig struct {
+	// PortMapping enables mapping the listen port on the NAT device with
+	// UPnP or NAT-PMP so that remote peers can connect to the client
+	// directly. The libp2p version in use does not support hole punching
+	// so port mapping is the only way to get direct connections through
+	// a NAT.
+	PortMapping bool
+	// Service enables the AutoNAT service letting other peers find out
+	// whether they are publicly reachable. It should be enabled only on
+	// publicly reachable peers.
+	Service bool
+	// Relays are multiaddresses of designated relay peers. Once the client
+	// detects it is not publicly reachable, it obtains circuit-relay
+	// addresses through those peers and announces them instead of its
+	// private addresses. Relay peers have to meet the firewall criteria just
+	// as any other peer.
+	Relays []string
+	// RelayHop enables relaying connections for peers not publicly
+	// reachable. It should be enabled only on designated relay peers.
+	RelayHop bool
+}
+
+func validateNATConfig(config NATConfig) error {
+	if config.RelayHop && len(config.Relays) > 0 {
+		return fmt.Errorf(
+			"relay peer must be publicly reachable and can not use relays",
+		)
+	}
+
+	if _, err := extractMultiAddrFromPeers(config.Relays); err != nil {
+		return fmt.Errorf("could not parse relay address: [%v]", err)
+	}
+
+	return nil
+}
+
+// natOptions returns libp2p host options enabling NAT traversal features
+// according to the config.
+func natOptions(config NATConfig) ([]libp2p.Option, error) {
+	options := make([]libp2p.Option, 0)
+
+	if config.PortMapping {
+		options = append(options, libp2p.NATPortMap())
+	}
+
+	if config.Service {
+		options = append(options, libp2p.EnableNATService())
+	}
+
+	if config.RelayHop {
+		options = append(options, libp2p.EnableRelay(circuit.OptHop))
+	}
+
+	if len(config.Relays) > 0 {
+		relays, err := extractMultiAddrFromPeers(config.Relays)
+		if err != nil {
+			return nil, err
+		}
+
+		options = append(
+			options,
+			libp2p.EnableRelay(),
+			libp2p.EnableAutoRelay(),
+			libp2p.StaticRelays(relays),
+		)
+	}
+
+	return options, nil
+}
+
+// reachabilityMonitor tracks whether the client is reachable by remote peers
+// based on AutoNAT reachability events.
+type reachabilityMonitor struct {
+	relaysConfigured bool
+
+	mutex        sync.RWMutex
+	reachability net.Reachability
+}
+
+func newReachabilityMonitor(relaysConfigured bool) *reachabilityMonitor {
+	return &reachabilityMonitor{
+		relaysConfigured: relaysConfigured,
+		reachability:     net.ReachabilityUnknown,
+	}
+}
+
+// run updates the reachability with events emitted on the bus until the
+// context is done.
+func (rm *reachabilityMonitor) run(ctx context.Context, bus event.Bus) error {
+	subscription, err := bus.Subscribe(new(event.EvtLocalReachabilityChanged))
+	if err != nil {
+		return fmt.Errorf(
+			"could not subscribe for reachability events: [%v]",
+			err,
+		)
+	}
+
+	go func() {
+		defer subscription.Close()
+
+		for {
+			select {
+			case e, ok := <-subscription.Out():
+				if !ok {
+					return
+				}
+				rm.update(
+					e.(event.EvtLocalReachabilityChanged).Reachability,
+				)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return nil
+}
+
+func (rm *reachabilityMonitor) update(reachability libp2pnet.Reachability) {
+	var updated net.Reachability
+	switch reachability {
+	case libp2pnet.ReachabilityPublic:
+		updated = net.ReachabilityPublic
+	case libp2pnet.ReachabilityPrivate:
+		updated = net.ReachabilityPrivate
+	default:
+		updated = net.ReachabilityUnknown
+	}
+
+	rm.mutex.Lock()
+	rm.reachability = updated
+	rm.mutex.Unlock()
+
+	logger.Infof("local reachability changed to [%v]", updated)
+
+	if updated == net.ReachabilityPrivate && !rm.relaysConfigured {
+		logger.Warningf(
+			"client is not publicly reachable and no relays are " +
+				"configured; consider enabling port mapping or " +
+				"configuring relays",
+		)
+	}
+}
+
+func (rm *reachabilityMonitor) get() net.Reachability {
+	rm.mutex.RLock()
+	defer rm.mutex.RUnlock()
+
+	return rm.reachability
+}
@@ -0,0 +1,216 @@
+package libp2p
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/net/key"
+	libp2p "github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p-core/event"
+	"github.com/libp2p/go-libp2p-core/host"
+	libp2pnet "github.com/libp2p/go-libp2p-core/network"
+	peerstore "github.com/libp2p/go-libp2p-peerstore"
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+func TestValidateNATConfig(t *testing.T) {
+	relayPeerID, _ := generateTestPeer(t)
+	relayAddress := "/ip4/127.0.0.1/tcp/3919/ipfs/" + relayPeerID.Pretty()
+
+	var tests = map[string]struct {
+		config        NATConfig
+		expectedError bool
+	}{
+		"empty config": {
+			config: NATConfig{},
+		},
+		"relays": {
+			config: NATConfig{PortMapping: true, Relays: []string{relayAddress}},
+		},
+		"relay hop": {
+			config: NATConfig{Service: true, RelayHop: true},
+		},
+		"relay hop using relays": {
+			config:        NATConfig{RelayHop: true, Relays: []string{relayAddress}},
+			expectedError: true,
+		},
+		"malformed relay address": {
+			config:        NATConfig{Relays: []string{"/ip4/127.0.0.1/tcp"}},
+			expectedError: true,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			err := validateNATConfig(test.config)
+			if test.expectedError != (err != nil) {
+				t.Errorf(
+					"unexpected error\nexpected error: [%v]\nactual error:   [%v]",
+					test.expectedError,
+					err,
+				)
+			}
+		})
+	}
+}
+
+func TestConnectThroughRelay(t *testing.T) {
+	ctx, cancel := newTestContext()
+	defer cancel()
+
+	relayHost := newTestNATHost(t, ctx, NATConfig{RelayHop: true})
+	defer relayHost.Close()
+
+	relayAddress := multiaddressWithIdentity(
+		relayHost.Addrs()[0],
+		relayHost.ID(),
+	)
+	config := NATConfig{Relays: []string{relayAddress}}
+
+	privateHost := newTestNATHost(
+		t,
+		ctx,
+		config,
+		libp2p.ForceReachabilityPrivate(),
+	)
+	defer privateHost.Close()
+
+	// The private host should connect to the relay once it learns it is
+	// not publicly reachable.
+	deadline := time.Now().Add(10 * time.Second)
+	for privateHost.Network().Connectedness(relayHost.ID()) != libp2pnet.Connected {
+		if time.Now().After(deadline) {
+			t.Fatal("private host should connect to the relay")
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	remoteHost := newTestNATHost(t, ctx, config)
+	defer remoteHost.Close()
+
+	circuitAddress, err := ma.NewMultiaddr(
+		relayAddress + "/p2p-circuit/ipfs/" + privateHost.ID().Pretty(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	privateHostInfo, err := peerstore.InfoFromP2pAddr(circuitAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Only the relayed address is known so the connection has to go
+	// through the relay.
+	if err := remoteHost.Connect(ctx, *privateHostInfo); err != nil {
+		t.Fatal(err)
+	}
+
+	connections := remoteHost.Network().ConnsToPeer(privateHost.ID())
+	if len(connections) != 1 {
+		t.Fatalf("unexpected number of connections [%v]", len(connections))
+	}
+
+	if _, err := connections[0].RemoteMultiaddr().ValueForProtocol(
+		ma.P_CIRCUIT,
+	); err != nil {
+		t.Fatalf(
+			"connection should be relayed; remote address: [%v]",
+			connections[0].RemoteMultiaddr(),
+		)
+	}
+}
+
+func newTestNATHost(
+	t *testing.T,
+	ctx context.Context,
+	config NATConfig,
+	additionalOptions ...libp2p.Option,
+) host.Host {
+	privateKey, _, err := key.GenerateStaticNetworkKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	options, err := natOptions(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	options = append(
+		options,
+		libp2p.Identity(privateKey),
+		libp2p.ListenAddrStrings("/ip4/127.0.0.1/tcp/0"),
+	)
+	options = append(options, additionalOptions...)
+
+	host, err := libp2p.New(ctx, options...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return host
+}
+
+func TestReachabilityMonitor(t *testing.T) {
+	ctx, cancel := newTestContext()
+	defer cancel()
+
+	host, err := libp2p.New(ctx, libp2p.NoListenAddrs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer host.Close()
+
+	monitor := newReachabilityMonitor(false)
+	if err := monitor.run(ctx, host.EventBus()); err != nil {
+		t.Fatal(err)
+	}
+
+	if reachability := monitor.get(); reachability != net.ReachabilityUnknown {
+		t.Fatalf(
+			"unexpected reachability\nexpected: [%v]\nactual:   [%v]",
+			net.ReachabilityUnknown,
+			reachability,
+		)
+	}
+
+	emitter, err := host.EventBus().Emitter(
+		new(event.EvtLocalReachabilityChanged),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer emitter.Close()
+
+	for _, reachability := range []struct {
+		libp2p libp2pnet.Reachability
+		net    net.Reachability
+	}{
+		{libp2pnet.ReachabilityPrivate, net.ReachabilityPrivate},
+		{libp2pnet.ReachabilityPublic, net.ReachabilityPublic},
+		{libp2pnet.ReachabilityUnknown, net.ReachabilityUnknown},
+	} {
+		err := emitter.Emit(event.EvtLocalReachabilityChanged{
+			Reachability: reachability.libp2p,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		deadline := time.Now().Add(5 * time.Second)
+		for monitor.get() != reachability.net && time.Now().Before(deadline) {
+			time.Sleep(10 * time.Millisecond)
+		}
+
+		if actual := monitor.get(); actual != reachability.net {
+			t.Fatalf(
+				"unexpected reachability\nexpected: [%v]\nactual:   [%v]",
+				reachability.net,
+				actual,
+			)
+		}
+	}
+}
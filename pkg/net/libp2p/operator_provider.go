@@ -0,0 +1,115 @@
+package libp2p
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/net/key"
+)
+
+// operatorProvider is a view of the provider acting on behalf of one of the
+// operators run by the client. All operators share the host, the network key
+// and the broadcast channels of the provider.
+type operatorProvider struct {
+	*provider
+
+	identity *identity
+}
+
+// OperatorProvider returns a view of the network provider which acts on
+// behalf of the operator authorized by the given certificate to use the
+// provider's network key. Messages sent over broadcast channels of the view
+// carry the certificate, so that remote peers attribute them to that
+// operator. It lets a single client run several operators with one host.
+//
+// The certificate has to be one of the operator certificates the provider has
+// been connected with, see WithOperatorCertificates. The connection handshake
+// presents all of them, though remote peers apply the firewall rules to the
+// operator of the certificate set with WithCertificate only.
+func OperatorProvider(
+	netProvider net.Provider,
+	certificate *key.Certificate,
+) (net.Provider, error) {
+	p, ok := netProvider.(*provider)
+	if !ok {
+		return nil, fmt.Errorf(
+			"unsupported network provider type [%v]",
+			netProvider.Type(),
+		)
+	}
+
+	networkKey := key.Libp2pKeyToNetworkKey(p.identity.pubKey)
+	if networkKey == nil {
+		return nil, fmt.Errorf(
+			"network key [%v] is not of correct type",
+			p.identity.pubKey,
+		)
+	}
+
+	if err := certificate.VerifyFor(networkKey); err != nil {
+		return nil, fmt.Errorf("invalid network key certificate: [%v]", err)
+	}
+
+	if !p.presents(certificate) {
+		return nil, fmt.Errorf(
+			"certificate of operator [%v] is not presented by the provider",
+			certificate.OperatorAddress(),
+		)
+	}
+
+	return &operatorProvider{
+		provider: p,
+		identity: &identity{
+			id:          p.identity.id,
+			pubKey:      p.identity.pubKey,
+			privKey:     p.identity.privKey,
+			certificate: certificate,
+		},
+	}, nil
+}
+
+func (op *operatorProvider) BroadcastChannelFor(
+	name string,
+) (net.BroadcastChannel, error) {
+	op.channelManagerMutex.Lock()
+	defer op.channelManagerMutex.Unlock()
+
+	channel, err := op.broadcastChannelManager.getChannel(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &operatorChannel{channel, op.identity}, nil
+}
+
+// operatorChannel is a broadcast channel sending messages on behalf of one of
+// the operators run by the client. Incoming messages are delivered the same
+// way as for the underlying channel.
+type operatorChannel struct {
+	*channel
+
+	identity *identity
+}
+
+func (oc *operatorChannel) Send(
+	ctx context.Context,
+	message net.TaggedMarshaler,
+	strategy ...net.RetransmissionStrategy,
+) error {
+	return oc.send(ctx, oc.identity, message, strategy...)
+}
+
+// presents returns true if the given certificate is one of the operator
+// certificates presented by the provider during the connection handshake.
+func (p *provider) presents(certificate *key.Certificate) bool {
+	for _, operatorCertificate := range p.operatorCertificates {
+		if operatorCertificate.OperatorAddress() ==
+			certificate.OperatorAddress() &&
+			operatorCertificate.Serial == certificate.Serial {
+			return true
+		}
+	}
+
+	return false
+}
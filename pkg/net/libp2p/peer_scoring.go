@@ -0,0 +1,295 @@
+package libp2p
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pubsubpb "github.com/libp2p/go-libp2p-pubsub/pb"
+)
+
+// Penalties applied to the peer score of a peer misbehaving in a topic.
+const (
+	// invalidSignaturePenalty is applied for every message with an invalid
+	// or missing signature forwarded by the peer.
+	invalidSignaturePenalty = 10
+	// invalidMessagePenalty is applied for every message forwarded by the
+	// peer which has been rejected by the topic validator.
+	invalidMessagePenalty = 10
+	// floodPenalty is applied for every message forwarded by the peer above
+	// the flood message limit.
+	floodPenalty = 1
+)
+
+// Peer scoring parameters.
+const (
+	// peerScoreDecayInterval is the interval in which penalties decay.
+	peerScoreDecayInterval = time.Minute
+	// peerScoreDecay is the factor by which penalties are multiplied every
+	// decay interval.
+	peerScoreDecay = 0.9
+	// peerScoreDecayToZero is the penalty value below which the penalty is
+	// considered to be zero and it is forgotten.
+	peerScoreDecayToZero = 0.1
+	// peerScoreRetainTime is the time scores of disconnected peers are
+	// retained by the router so that peers can not clear their score by
+	// reconnecting.
+	peerScoreRetainTime = time.Hour
+
+	// floodWindow is the period of time in which messages forwarded by the
+	// peer in the given topic are counted.
+	floodWindow = 10 * time.Second
+	// floodMessageLimit is the maximum number of messages the peer can
+	// forward in the given topic within the flood window. Messages above the
+	// limit are ignored and penalized.
+	floodMessageLimit = 2000
+)
+
+// Peer score thresholds. A peer with a score below the gossip threshold does
+// not exchange gossip with us, a peer with a score below the publish
+// threshold does not receive messages we publish and all messages from a peer
+// with a score below the graylist threshold are ignored.
+const (
+	peerScoreGossipThreshold   = -50
+	peerScorePublishThreshold  = -100
+	peerScoreGraylistThreshold = -200
+)
+
+// signatureTopic is the key under which penalties for invalid signatures are
+// stored. Messages with invalid signatures are rejected by pubsub before
+// their topic is validated, so those penalties are not bound to any topic.
+const signatureTopic = ""
+
+// peerScoreTracker tracks penalties of peers misbehaving in broadcast channel
+// topics and provides the application specific score used by the GossipSub
+// router. The score of a peer is the negated sum of penalties the peer
+// received in all topics. Penalties decay over time so that a peer which
+// stopped misbehaving eventually gets back its neutral score.
+//
+// GossipSub topic score parameters can be set only when the router is
+// created and broadcast channels are created dynamically, so the topic-level
+// penalties are tracked here instead of the router.
+type peerScoreTracker struct {
+	self peer.ID
+
+	floodWindow       time.Duration
+	floodMessageLimit int
+
+	mutex           sync.Mutex
+	penalties       map[peer.ID]map[string]float64
+	messageCounters map[peer.ID]map[string]*messageCounter
+}
+
+type messageCounter struct {
+	windowStart time.Time
+	count       int
+}
+
+func newPeerScoreTracker(
+	self peer.ID,
+	floodWindow time.Duration,
+	floodMessageLimit int,
+) *peerScoreTracker {
+	return &peerScoreTracker{
+		self:              self,
+		floodWindow:       floodWindow,
+		floodMessageLimit: floodMessageLimit,
+		penalties:         make(map[peer.ID]map[string]float64),
+		messageCounters:   make(map[peer.ID]map[string]*messageCounter),
+	}
+}
+
+// run periodically decays penalties until the context is done.
+func (pst *peerScoreTracker) run(ctx context.Context) {
+	ticker := time.NewTicker(peerScoreDecayInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			pst.decay()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// score returns the application specific score of the peer.
+func (pst *peerScoreTracker) score(peerID peer.ID) float64 {
+	pst.mutex.Lock()
+	defer pst.mutex.Unlock()
+
+	score := 0.0
+	for _, penalty := range pst.penalties[peerID] {
+		score -= penalty
+	}
+
+	return score
+}
+
+func (pst *peerScoreTracker) penalize(
+	peerID peer.ID,
+	topic string,
+	penalty float64,
+) {
+	pst.mutex.Lock()
+	defer pst.mutex.Unlock()
+
+	topicPenalties, ok := pst.penalties[peerID]
+	if !ok {
+		topicPenalties = make(map[string]float64)
+		pst.penalties[peerID] = topicPenalties
+	}
+
+	topicPenalties[topic] += penalty
+}
+
+func (pst *peerScoreTracker) decay() {
+	pst.mutex.Lock()
+	defer pst.mutex.Unlock()
+
+	for peerID, topicPenalties := range pst.penalties {
+		for topic, penalty := range topicPenalties {
+			penalty *= peerScoreDecay
+			if penalty < peerScoreDecayToZero {
+				delete(topicPenalties, topic)
+				continue
+			}
+			topicPenalties[topic] = penalty
+		}
+
+		if len(topicPenalties) == 0 {
+			delete(pst.penalties, peerID)
+		}
+	}
+
+	now := time.Now()
+	for peerID, topicCounters := range pst.messageCounters {
+		for topic, counter := range topicCounters {
+			if now.Sub(counter.windowStart) > pst.floodWindow {
+				delete(topicCounters, topic)
+			}
+		}
+
+		if len(topicCounters) == 0 {
+			delete(pst.messageCounters, peerID)
+		}
+	}
+}
+
+// countMessage counts the message forwarded by the peer in the given topic.
+// It returns false and penalizes the peer if the peer exceeded the flood
+// message limit.
+func (pst *peerScoreTracker) countMessage(peerID peer.ID, topic string) bool {
+	pst.mutex.Lock()
+
+	topicCounters, ok := pst.messageCounters[peerID]
+	if !ok {
+		topicCounters = make(map[string]*messageCounter)
+		pst.messageCounters[peerID] = topicCounters
+	}
+
+	now := time.Now()
+	counter, ok := topicCounters[topic]
+	if !ok || now.Sub(counter.windowStart) > pst.floodWindow {
+		counter = &messageCounter{windowStart: now}
+		topicCounters[topic] = counter
+	}
+
+	counter.count++
+	flooding := counter.count > pst.floodMessageLimit
+
+	pst.mutex.Unlock()
+
+	if flooding {
+		logger.Warningf(
+			"peer [%v] exceeded the limit of [%v] messages in topic [%v]",
+			peerID,
+			pst.floodMessageLimit,
+			topic,
+		)
+		pst.penalize(peerID, topic, floodPenalty)
+		return false
+	}
+
+	return true
+}
+
+// topicValidator wraps the topic validator so that messages forwarded by
+// peers are counted against the flood limit and peers forwarding messages
+// rejected by the validator are penalized. The validator may be nil if the
+// topic has no filter.
+func (pst *peerScoreTracker) topicValidator(
+	topic string,
+	validator pubsub.Validator,
+) pubsub.ValidatorEx {
+	return func(
+		ctx context.Context,
+		from peer.ID,
+		message *pubsub.Message,
+	) pubsub.ValidationResult {
+		if from == pst.self {
+			if validator != nil && !validator(ctx, from, message) {
+				return pubsub.ValidationReject
+			}
+			return pubsub.ValidationAccept
+		}
+
+		if !pst.countMessage(from, topic) {
+			return pubsub.ValidationIgnore
+		}
+
+		if validator != nil && !validator(ctx, from, message) {
+			pst.penalize(from, topic, invalidMessagePenalty)
+			return pubsub.ValidationReject
+		}
+
+		return pubsub.ValidationAccept
+	}
+}
+
+// Trace implements pubsub.EventTracer. It penalizes peers forwarding messages
+// with invalid or missing signatures.
+func (pst *peerScoreTracker) Trace(event *pubsubpb.TraceEvent) {
+	if event.GetType() != pubsubpb.TraceEvent_REJECT_MESSAGE {
+		return
+	}
+
+	rejectMessage := event.GetRejectMessage()
+	switch rejectMessage.GetReason() {
+	case "invalid signature", "missing signature":
+		peerID, err := peer.IDFromBytes(rejectMessage.GetReceivedFrom())
+		if err != nil {
+			logger.Warningf(
+				"could not decode peer of rejected message: [%v]",
+				err,
+			)
+			return
+		}
+
+		pst.penalize(peerID, signatureTopic, invalidSignaturePenalty)
+	}
+}
+
+// peerScoreParams returns GossipSub peer score parameters using only the
+// application specific score provided by the tracker.
+func (pst *peerScoreTracker) peerScoreParams() *pubsub.PeerScoreParams {
+	return &pubsub.PeerScoreParams{
+		Topics:            make(map[string]*pubsub.TopicScoreParams),
+		AppSpecificScore:  pst.score,
+		AppSpecificWeight: 1,
+		DecayInterval:     peerScoreDecayInterval,
+		DecayToZero:       peerScoreDecayToZero,
+		RetainScore:       peerScoreRetainTime,
+	}
+}
+
+func peerScoreThresholds() *pubsub.PeerScoreThresholds {
+	return &pubsub.PeerScoreThresholds{
+		GossipThreshold:   peerScoreGossipThreshold,
+		PublishThreshold:  peerScorePublishThreshold,
+		GraylistThreshold: peerScoreGraylistThreshold,
+	}
+}
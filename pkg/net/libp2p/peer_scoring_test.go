@@ -0,0 +1,410 @@
+package libp2p
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/net/key"
+	"github.com/keep-network/keep-core/pkg/net/reputation"
+	libp2p "github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p-core/host"
+	"github.com/libp2p/go-libp2p-core/metrics"
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pubsubpb "github.com/libp2p/go-libp2p-pubsub/pb"
+)
+
+func TestPeerScoreTrackerPenalizesInvalidMessages(t *testing.T) {
+	tracker := newPeerScoreTracker("self", floodWindow, floodMessageLimit)
+
+	accepting := func(context.Context, peer.ID, *pubsub.Message) bool {
+		return true
+	}
+	rejecting := func(context.Context, peer.ID, *pubsub.Message) bool {
+		return false
+	}
+
+	result := tracker.topicValidator("topic", accepting)(
+		context.Background(),
+		"honest",
+		&pubsub.Message{},
+	)
+	if result != pubsub.ValidationAccept {
+		t.Errorf("unexpected validation result: [%v]", result)
+	}
+
+	result = tracker.topicValidator("topic", rejecting)(
+		context.Background(),
+		"malicious",
+		&pubsub.Message{},
+	)
+	if result != pubsub.ValidationReject {
+		t.Errorf("unexpected validation result: [%v]", result)
+	}
+
+	assertPeerScore(t, tracker, "honest", 0)
+	assertPeerScore(t, tracker, "malicious", -invalidMessagePenalty)
+
+	// our own messages are never penalized
+	result = tracker.topicValidator("topic", rejecting)(
+		context.Background(),
+		"self",
+		&pubsub.Message{},
+	)
+	if result != pubsub.ValidationReject {
+		t.Errorf("unexpected validation result: [%v]", result)
+	}
+	assertPeerScore(t, tracker, "self", 0)
+}
+
+func TestPeerScoreTrackerPenalizesFloods(t *testing.T) {
+	tracker := newPeerScoreTracker("self", time.Minute, 3)
+	validator := tracker.topicValidator("topic", nil)
+
+	var results []pubsub.ValidationResult
+	for i := 0; i < 5; i++ {
+		results = append(
+			results,
+			validator(context.Background(), "flooding", &pubsub.Message{}),
+		)
+	}
+
+	expectedResults := []pubsub.ValidationResult{
+		pubsub.ValidationAccept,
+		pubsub.ValidationAccept,
+		pubsub.ValidationAccept,
+		pubsub.ValidationIgnore,
+		pubsub.ValidationIgnore,
+	}
+	if fmt.Sprint(expectedResults) != fmt.Sprint(results) {
+		t.Errorf(
+			"unexpected validation results\nexpected: [%v]\nactual:   [%v]",
+			expectedResults,
+			results,
+		)
+	}
+
+	assertPeerScore(t, tracker, "flooding", -2*floodPenalty)
+
+	// the limit is counted separately for every topic
+	result := tracker.topicValidator("another", nil)(
+		context.Background(),
+		"flooding",
+		&pubsub.Message{},
+	)
+	if result != pubsub.ValidationAccept {
+		t.Errorf("unexpected validation result: [%v]", result)
+	}
+}
+
+func TestPeerScoreTrackerPenalizesInvalidSignatures(t *testing.T) {
+	tracker := newPeerScoreTracker("self", floodWindow, floodMessageLimit)
+
+	_, publicKey, err := key.GenerateStaticNetworkKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	peerID, err := peer.IDFromPublicKey(publicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, reason := range []string{
+		"invalid signature",
+		"missing signature",
+		"validation throttled",
+	} {
+		reason := reason
+		eventType := pubsubpb.TraceEvent_REJECT_MESSAGE
+		tracker.Trace(&pubsubpb.TraceEvent{
+			Type: &eventType,
+			RejectMessage: &pubsubpb.TraceEvent_RejectMessage{
+				ReceivedFrom: []byte(peerID),
+				Reason:       &reason,
+			},
+		})
+	}
+
+	assertPeerScore(t, tracker, peerID, -2*invalidSignaturePenalty)
+}
+
+func TestPeerScoreTrackerDecay(t *testing.T) {
+	tracker := newPeerScoreTracker("self", floodWindow, floodMessageLimit)
+
+	tracker.penalize("peer", "topic", 10)
+	tracker.penalize("peer", "another", 0.1)
+
+	tracker.decay()
+
+	assertPeerScore(t, tracker, "peer", -10*peerScoreDecay)
+
+	for i := 0; i < 100; i++ {
+		tracker.decay()
+	}
+
+	assertPeerScore(t, tracker, "peer", 0)
+	if len(tracker.penalties) != 0 {
+		t.Errorf("expected all penalties to be forgotten")
+	}
+}
+
+func TestConnectWithUnsupportedRouter(t *testing.T) {
+	ctx, cancel := newTestContext()
+	defer cancel()
+
+	privateKey, _, err := key.GenerateStaticNetworkKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config := generateDeterministicNetworkConfig()
+	config.Router = "randomsub"
+
+	_, err = Connect(
+		ctx,
+		config,
+		privateKey,
+		ProtocolBeacon,
+		nil,
+		idleTicker(),
+	)
+
+	expectedError := "unsupported pubsub router [randomsub]; " +
+		"must be one of [floodsub, gossipsub]"
+	if err == nil || err.Error() != expectedError {
+		t.Errorf(
+			"unexpected error\nexpected: [%v]\nactual:   [%v]",
+			expectedError,
+			err,
+		)
+	}
+}
+
+// TestBroadcastRoutersDeliveryAndBandwidth publishes the same messages on
+// a fully connected network of in-process hosts using floodsub and gossipsub
+// and compares delivery and the number of bytes sent by all hosts.
+func TestBroadcastRoutersDeliveryAndBandwidth(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping multi-host network test in short mode")
+	}
+
+	const (
+		hostsCount      = 16
+		messagesPerHost = 5
+		payloadSize     = 1024
+	)
+
+	bandwidth := make(map[string]int64)
+	for _, router := range []string{FloodSubRouter, GossipSubRouter} {
+		bytesSent, delivered := publishOnTestNetwork(
+			t,
+			router,
+			hostsCount,
+			messagesPerHost,
+			payloadSize,
+		)
+
+		expectedDelivered := hostsCount * messagesPerHost
+		for i, count := range delivered {
+			if count != expectedDelivered {
+				t.Errorf(
+					"[%v] host [%v] received unexpected number of messages\n"+
+						"expected: [%v]\nactual:   [%v]",
+					router,
+					i,
+					expectedDelivered,
+					count,
+				)
+			}
+		}
+
+		t.Logf("[%v] bytes sent by all hosts: [%v]", router, bytesSent)
+		bandwidth[router] = bytesSent
+	}
+
+	if bandwidth[GossipSubRouter] >= bandwidth[FloodSubRouter] {
+		t.Errorf(
+			"expected gossipsub to use less bandwidth than floodsub\n"+
+				"gossipsub: [%v]\nfloodsub:  [%v]",
+			bandwidth[GossipSubRouter],
+			bandwidth[FloodSubRouter],
+		)
+	}
+}
+
+// publishOnTestNetwork creates a fully connected network of hosts using the
+// given router, publishes messages from every host and returns the number of
+// bytes sent by all hosts while publishing along with the number of messages
+// delivered to every host.
+func publishOnTestNetwork(
+	t *testing.T,
+	router string,
+	hostsCount int,
+	messagesPerHost int,
+	payloadSize int,
+) (int64, []int) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	const (
+		channelName                = "test-channel"
+		bandwidthCounterUpdateTime = 2 * time.Second
+	)
+
+	hosts := make([]host.Host, hostsCount)
+	counters := make([]*metrics.BandwidthCounter, hostsCount)
+	channels := make([]*channel, hostsCount)
+	identities := make([]*identity, hostsCount)
+
+	for i := 0; i < hostsCount; i++ {
+		privateKey, _, err := key.GenerateStaticNetworkKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		identities[i], err = createIdentity(privateKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		counters[i] = metrics.NewBandwidthCounter()
+		hosts[i], err = libp2p.New(
+			ctx,
+			libp2p.Identity(privateKey),
+			libp2p.ListenAddrStrings("/ip4/127.0.0.1/tcp/0"),
+			libp2p.BandwidthReporter(counters[i]),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer hosts[i].Close()
+	}
+
+	deliveredMutex := sync.Mutex{}
+	delivered := make([]int, hostsCount)
+	allDelivered := make(chan struct{})
+	remaining := hostsCount * hostsCount * messagesPerHost
+
+	for i := 0; i < hostsCount; i++ {
+		manager, err := newChannelManager(
+			ctx,
+			identities[i],
+			hosts[i],
+			idleTicker(),
+			router,
+			newRateLimiter(identities[i].id, RateLimits{}),
+			reputation.NewService(),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		channels[i], err = manager.getChannel(channelName)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		channels[i].SetUnmarshaler(
+			func() net.TaggedUnmarshaler { return &testMessage{} },
+		)
+
+		hostIndex := i
+		channels[i].Recv(ctx, func(message net.Message) {
+			deliveredMutex.Lock()
+			defer deliveredMutex.Unlock()
+
+			delivered[hostIndex]++
+			remaining--
+			if remaining == 0 {
+				close(allDelivered)
+			}
+		})
+	}
+
+	// Pubsub is not notified about connections established before it has
+	// been created so hosts are connected once all channels exist.
+	for i := 0; i < hostsCount; i++ {
+		for j := i + 1; j < hostsCount; j++ {
+			err := hosts[i].Connect(ctx, peer.AddrInfo{
+				ID:    hosts[j].ID(),
+				Addrs: hosts[j].Addrs(),
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	// Wait for subscriptions to propagate and for the mesh to settle.
+	for _, channel := range channels {
+		for len(channel.pubsub.ListPeers(channelName)) < hostsCount-1 {
+			select {
+			case <-ctx.Done():
+				t.Fatal("subscriptions did not propagate in time")
+			case <-time.After(10 * time.Millisecond):
+			}
+		}
+	}
+	time.Sleep(time.Second)
+
+	// Bandwidth counters are updated in the background every second.
+	time.Sleep(bandwidthCounterUpdateTime)
+	bytesSentBefore := totalBytesSent(counters)
+
+	payload := strings.Repeat("x", payloadSize)
+	for i := 0; i < messagesPerHost; i++ {
+		for j, channel := range channels {
+			err := channel.Send(
+				ctx,
+				&testMessage{Sender: identities[j], Payload: payload},
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		// Give hosts a moment so that subscribers are not overwhelmed.
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	select {
+	case <-allDelivered:
+	case <-ctx.Done():
+	}
+
+	time.Sleep(bandwidthCounterUpdateTime)
+
+	deliveredMutex.Lock()
+	defer deliveredMutex.Unlock()
+
+	return totalBytesSent(counters) - bytesSentBefore, append([]int{}, delivered...)
+}
+
+func totalBytesSent(counters []*metrics.BandwidthCounter) int64 {
+	var total int64
+	for _, counter := range counters {
+		total += counter.GetBandwidthTotals().TotalOut
+	}
+	return total
+}
+
+func assertPeerScore(
+	t *testing.T,
+	tracker *peerScoreTracker,
+	peerID peer.ID,
+	expectedScore float64,
+) {
+	if score := tracker.score(peerID); score != expectedScore {
+		t.Errorf(
+			"unexpected score of peer [%v]\nexpected: [%v]\nactual:   [%v]",
+			peerID,
+			expectedScore,
+			score,
+		)
+	}
+}
@@ -0,0 +1,333 @@
+package libp2p
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	peer "github.com/libp2p/go-libp2p-core/peer"
+)
+
+// MaximumMessageSize is the maximum size of a single network message in
+// bytes. It is also the default message size limit.
+const MaximumMessageSize = 1 << 20
+
+// rateLimiterCleanupTick is the amount of time between periodic removals of
+// rate limits of peers which have not sent anything recently.
+const rateLimiterCleanupTick = time.Minute
+
+// unicastTopic is the name under which rate limits of messages received over
+// unicast channels are tracked.
+const unicastTopic = "unicast"
+
+// RateLimits defines limits of messages received from peers. Limits are
+// tracked separately for every broadcast channel topic and for all unicast
+// channels. Rate limits set to zero are not enforced.
+type RateLimits struct {
+	// MaxMessageSize is the maximum size of a single message in bytes.
+	// Defaults to MaximumMessageSize.
+	MaxMessageSize int
+	// PeerMessagesPerSecond is the maximum number of messages per second
+	// a single peer can send in the topic.
+	PeerMessagesPerSecond int
+	// PeerBytesPerSecond is the maximum number of bytes per second a single
+	// peer can send in the topic.
+	PeerBytesPerSecond int
+	// TopicMessagesPerSecond is the maximum number of messages per second
+	// all peers together can send in the topic.
+	TopicMessagesPerSecond int
+	// TopicBytesPerSecond is the maximum number of bytes per second all
+	// peers together can send in the topic.
+	TopicBytesPerSecond int
+}
+
+func validateRateLimits(limits RateLimits) error {
+	if limits.MaxMessageSize < 0 || limits.MaxMessageSize > MaximumMessageSize {
+		return fmt.Errorf(
+			"max message size must be in range [0, %v]",
+			MaximumMessageSize,
+		)
+	}
+
+	maxMessageSize := limits.MaxMessageSize
+	if maxMessageSize == 0 {
+		maxMessageSize = MaximumMessageSize
+	}
+
+	// Bursts are limited to one second worth of bytes so a message larger
+	// than that could never be received.
+	for _, bytesPerSecond := range []int{
+		limits.PeerBytesPerSecond,
+		limits.TopicBytesPerSecond,
+	} {
+		if bytesPerSecond > 0 && bytesPerSecond < maxMessageSize {
+			return fmt.Errorf(
+				"bytes per second limits must not be lower than "+
+					"the max message size [%v]",
+				maxMessageSize,
+			)
+		}
+	}
+
+	return nil
+}
+
+// tokenBucket allows for the given rate of events per second with bursts of
+// up to one second worth of events. A nil bucket allows for any rate.
+type tokenBucket struct {
+	rate       float64
+	tokens     float64
+	lastUpdate time.Time
+}
+
+func newTokenBucket(rate int, now time.Time) *tokenBucket {
+	if rate <= 0 {
+		return nil
+	}
+
+	return &tokenBucket{
+		rate:       float64(rate),
+		tokens:     float64(rate),
+		lastUpdate: now,
+	}
+}
+
+func (tb *tokenBucket) refill(now time.Time) {
+	if tb == nil {
+		return
+	}
+
+	tb.tokens += now.Sub(tb.lastUpdate).Seconds() * tb.rate
+	if tb.tokens > tb.rate {
+		tb.tokens = tb.rate
+	}
+	tb.lastUpdate = now
+}
+
+func (tb *tokenBucket) has(tokens float64) bool {
+	return tb == nil || tb.tokens >= tokens
+}
+
+func (tb *tokenBucket) take(tokens float64) {
+	if tb != nil {
+		tb.tokens -= tokens
+	}
+}
+
+func (tb *tokenBucket) full() bool {
+	return tb == nil || tb.tokens >= tb.rate
+}
+
+// rateBuckets limits both the number of messages and the number of bytes.
+type rateBuckets struct {
+	messages *tokenBucket
+	bytes    *tokenBucket
+}
+
+func newRateBuckets(
+	messagesPerSecond int,
+	bytesPerSecond int,
+	now time.Time,
+) *rateBuckets {
+	return &rateBuckets{
+		messages: newTokenBucket(messagesPerSecond, now),
+		bytes:    newTokenBucket(bytesPerSecond, now),
+	}
+}
+
+func (rb *rateBuckets) refill(now time.Time) {
+	rb.messages.refill(now)
+	rb.bytes.refill(now)
+}
+
+func (rb *rateBuckets) has(size int) bool {
+	return rb.messages.has(1) && rb.bytes.has(float64(size))
+}
+
+func (rb *rateBuckets) take(size int) {
+	rb.messages.take(1)
+	rb.bytes.take(float64(size))
+}
+
+func (rb *rateBuckets) full() bool {
+	return rb.messages.full() && rb.bytes.full()
+}
+
+type topicRateBuckets struct {
+	total *rateBuckets
+	peers map[peer.ID]*rateBuckets
+}
+
+// rateLimiter enforces rate limits of messages received from peers. Peers
+// sending messages above the per-peer limits are reported to the violation
+// handler.
+type rateLimiter struct {
+	self   peer.ID
+	limits RateLimits
+
+	mutex            sync.Mutex
+	topics           map[string]*topicRateBuckets
+	violationHandler func(peerID peer.ID, reason string)
+}
+
+func newRateLimiter(self peer.ID, limits RateLimits) *rateLimiter {
+	if limits.MaxMessageSize == 0 {
+		limits.MaxMessageSize = MaximumMessageSize
+	}
+
+	return &rateLimiter{
+		self:   self,
+		limits: limits,
+		topics: make(map[string]*topicRateBuckets),
+	}
+}
+
+// enabled returns true if any limits are enforced on top of the default
+// message size limit.
+func (rl *rateLimiter) enabled() bool {
+	return rl.limits.MaxMessageSize != MaximumMessageSize ||
+		rl.limits.PeerMessagesPerSecond > 0 ||
+		rl.limits.PeerBytesPerSecond > 0 ||
+		rl.limits.TopicMessagesPerSecond > 0 ||
+		rl.limits.TopicBytesPerSecond > 0
+}
+
+func (rl *rateLimiter) maxMessageSize() int {
+	return rl.limits.MaxMessageSize
+}
+
+// setViolationHandler sets the handler called every time a peer violates
+// the limits.
+func (rl *rateLimiter) setViolationHandler(
+	handler func(peerID peer.ID, reason string),
+) {
+	rl.mutex.Lock()
+	defer rl.mutex.Unlock()
+
+	rl.violationHandler = handler
+}
+
+// allow checks whether the message of the given size sent by the peer in the
+// given topic is within the limits. If the peer exceeded the message size or
+// its own rate limits, it is reported to the violation handler. Messages
+// exceeding the topic rate limits are not allowed but their senders are not
+// reported as they may not be responsible for the whole topic traffic.
+func (rl *rateLimiter) allow(topic string, peerID peer.ID, size int) bool {
+	if peerID == rl.self {
+		return true
+	}
+
+	if size > rl.limits.MaxMessageSize {
+		rl.reportViolation(peerID, "message size limit exceeded")
+		return false
+	}
+
+	rl.mutex.Lock()
+
+	now := time.Now()
+
+	topicBuckets, ok := rl.topics[topic]
+	if !ok {
+		topicBuckets = &topicRateBuckets{
+			total: newRateBuckets(
+				rl.limits.TopicMessagesPerSecond,
+				rl.limits.TopicBytesPerSecond,
+				now,
+			),
+			peers: make(map[peer.ID]*rateBuckets),
+		}
+		rl.topics[topic] = topicBuckets
+	}
+
+	peerBuckets, ok := topicBuckets.peers[peerID]
+	if !ok {
+		peerBuckets = newRateBuckets(
+			rl.limits.PeerMessagesPerSecond,
+			rl.limits.PeerBytesPerSecond,
+			now,
+		)
+		topicBuckets.peers[peerID] = peerBuckets
+	}
+
+	topicBuckets.total.refill(now)
+	peerBuckets.refill(now)
+
+	peerAllowed := peerBuckets.has(size)
+	topicAllowed := topicBuckets.total.has(size)
+
+	if peerAllowed && topicAllowed {
+		peerBuckets.take(size)
+		topicBuckets.total.take(size)
+	}
+
+	rl.mutex.Unlock()
+
+	if !peerAllowed {
+		rl.reportViolation(peerID, "rate limit exceeded in topic "+topic)
+		return false
+	}
+
+	if !topicAllowed {
+		logger.Warningf(
+			"rate limit of topic [%v] exceeded; dropping message from [%v]",
+			topic,
+			peerID,
+		)
+		return false
+	}
+
+	return true
+}
+
+func (rl *rateLimiter) reportViolation(peerID peer.ID, reason string) {
+	logger.Warningf("peer [%v] violated the limits: [%v]", peerID, reason)
+
+	rl.mutex.Lock()
+	handler := rl.violationHandler
+	rl.mutex.Unlock()
+
+	if handler != nil {
+		handler(peerID, reason)
+	}
+}
+
+// removeTopic removes all rate limits tracked for the topic.
+func (rl *rateLimiter) removeTopic(topic string) {
+	rl.mutex.Lock()
+	defer rl.mutex.Unlock()
+
+	delete(rl.topics, topic)
+}
+
+// cleanup removes rate limits of peers which have not sent anything
+// recently so that the tracked state does not grow indefinitely.
+func (rl *rateLimiter) cleanup() {
+	rl.mutex.Lock()
+	defer rl.mutex.Unlock()
+
+	now := time.Now()
+	for _, topicBuckets := range rl.topics {
+		for peerID, peerBuckets := range topicBuckets.peers {
+			peerBuckets.refill(now)
+			if peerBuckets.full() {
+				delete(topicBuckets.peers, peerID)
+			}
+		}
+	}
+}
+
+// run periodically cleans up the rate limits until the context is done.
+func (rl *rateLimiter) run(ctx context.Context) {
+	ticker := time.NewTicker(rateLimiterCleanupTick)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			rl.cleanup()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,209 @@
+package libp2p
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	peer "github.com/libp2p/go-libp2p-core/peer"
+)
+
+func TestRateLimiterMessageSize(t *testing.T) {
+	limiter, violations := newTestRateLimiter(RateLimits{MaxMessageSize: 100})
+
+	if !limiter.allow("topic", "peer", 100) {
+		t.Errorf("message within the size limit should be allowed")
+	}
+	if limiter.allow("topic", "peer", 101) {
+		t.Errorf("message above the size limit should not be allowed")
+	}
+
+	assertViolations(t, map[peer.ID]int{"peer": 1}, violations)
+}
+
+func TestRateLimiterPeerMessagesPerSecond(t *testing.T) {
+	limiter, violations := newTestRateLimiter(
+		RateLimits{PeerMessagesPerSecond: 3},
+	)
+
+	for i := 0; i < 3; i++ {
+		if !limiter.allow("topic", "peer", 10) {
+			t.Fatalf("message [%v] should be allowed", i)
+		}
+	}
+
+	if limiter.allow("topic", "peer", 10) {
+		t.Errorf("message above the peer rate limit should not be allowed")
+	}
+
+	// limits are tracked separately for every peer and every topic
+	if !limiter.allow("topic", "another-peer", 10) {
+		t.Errorf("message from another peer should be allowed")
+	}
+	if !limiter.allow("another-topic", "peer", 10) {
+		t.Errorf("message in another topic should be allowed")
+	}
+
+	assertViolations(t, map[peer.ID]int{"peer": 1}, violations)
+}
+
+func TestRateLimiterPeerBytesPerSecond(t *testing.T) {
+	limiter, violations := newTestRateLimiter(
+		RateLimits{MaxMessageSize: 100, PeerBytesPerSecond: 250},
+	)
+
+	if !limiter.allow("topic", "peer", 100) {
+		t.Errorf("first message should be allowed")
+	}
+	if !limiter.allow("topic", "peer", 100) {
+		t.Errorf("second message should be allowed")
+	}
+	if limiter.allow("topic", "peer", 100) {
+		t.Errorf("message above the peer rate limit should not be allowed")
+	}
+	if !limiter.allow("topic", "peer", 50) {
+		t.Errorf("message within the peer rate limit should be allowed")
+	}
+
+	assertViolations(t, map[peer.ID]int{"peer": 1}, violations)
+}
+
+func TestRateLimiterTopicMessagesPerSecond(t *testing.T) {
+	limiter, violations := newTestRateLimiter(
+		RateLimits{TopicMessagesPerSecond: 2},
+	)
+
+	if !limiter.allow("topic", "peer-1", 10) {
+		t.Errorf("first message should be allowed")
+	}
+	if !limiter.allow("topic", "peer-2", 10) {
+		t.Errorf("second message should be allowed")
+	}
+	if limiter.allow("topic", "peer-3", 10) {
+		t.Errorf("message above the topic rate limit should not be allowed")
+	}
+
+	// peers are not held responsible for the topic traffic
+	assertViolations(t, map[peer.ID]int{}, violations)
+}
+
+func TestRateLimiterIgnoresOwnMessages(t *testing.T) {
+	limiter, violations := newTestRateLimiter(
+		RateLimits{MaxMessageSize: 10, PeerMessagesPerSecond: 1},
+	)
+
+	for i := 0; i < 3; i++ {
+		if !limiter.allow("topic", "self", 100) {
+			t.Fatalf("own message [%v] should be allowed", i)
+		}
+	}
+
+	assertViolations(t, map[peer.ID]int{}, violations)
+}
+
+func TestRateLimiterCleanup(t *testing.T) {
+	limiter, _ := newTestRateLimiter(RateLimits{PeerMessagesPerSecond: 1000})
+
+	limiter.allow("topic", "active-peer", 10)
+	limiter.allow("topic", "idle-peer", 10)
+
+	// pretend the idle peer sent its message a second ago
+	limiter.topics["topic"].peers["idle-peer"].messages.lastUpdate =
+		time.Now().Add(-time.Second)
+	limiter.topics["topic"].peers["active-peer"].messages.lastUpdate =
+		time.Now().Add(time.Second)
+
+	limiter.cleanup()
+
+	if _, ok := limiter.topics["topic"].peers["idle-peer"]; ok {
+		t.Errorf("idle peer should be removed")
+	}
+	if _, ok := limiter.topics["topic"].peers["active-peer"]; !ok {
+		t.Errorf("active peer should not be removed")
+	}
+
+	limiter.removeTopic("topic")
+	if _, ok := limiter.topics["topic"]; ok {
+		t.Errorf("topic should be removed")
+	}
+}
+
+func TestValidateRateLimits(t *testing.T) {
+	var tests = map[string]struct {
+		limits        RateLimits
+		expectedError string
+	}{
+		"no limits": {
+			limits: RateLimits{},
+		},
+		"all limits": {
+			limits: RateLimits{
+				MaxMessageSize:         1000,
+				PeerMessagesPerSecond:  10,
+				PeerBytesPerSecond:     1000,
+				TopicMessagesPerSecond: 100,
+				TopicBytesPerSecond:    10000,
+			},
+		},
+		"max message size too high": {
+			limits:        RateLimits{MaxMessageSize: MaximumMessageSize + 1},
+			expectedError: "max message size must be in range [0, 1048576]",
+		},
+		"bytes per second lower than max message size": {
+			limits: RateLimits{MaxMessageSize: 1000, PeerBytesPerSecond: 999},
+			expectedError: "bytes per second limits must not be lower than " +
+				"the max message size [1000]",
+		},
+		"bytes per second lower than default max message size": {
+			limits: RateLimits{TopicBytesPerSecond: 1000},
+			expectedError: "bytes per second limits must not be lower than " +
+				"the max message size [1048576]",
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			err := validateRateLimits(test.limits)
+
+			if test.expectedError == "" {
+				if err != nil {
+					t.Errorf("unexpected error: [%v]", err)
+				}
+				return
+			}
+
+			if err == nil || err.Error() != test.expectedError {
+				t.Errorf(
+					"unexpected error\nexpected: [%v]\nactual:   [%v]",
+					test.expectedError,
+					err,
+				)
+			}
+		})
+	}
+}
+
+func newTestRateLimiter(limits RateLimits) (*rateLimiter, map[peer.ID]int) {
+	violations := make(map[peer.ID]int)
+
+	limiter := newRateLimiter("self", limits)
+	limiter.setViolationHandler(func(peerID peer.ID, reason string) {
+		violations[peerID]++
+	})
+
+	return limiter, violations
+}
+
+func assertViolations(
+	t *testing.T,
+	expected map[peer.ID]int,
+	actual map[peer.ID]int,
+) {
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf(
+			"unexpected violations\nexpected: [%v]\nactual:   [%v]",
+			expected,
+			actual,
+		)
+	}
+}
@@ -0,0 +1,83 @@
+package libp2p
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/net/rpc"
+)
+
+func TestRPCOverUnicastChannel(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	withNetwork(ctx, t, 9500, func(
+		identity1 *identity,
+		identity2 *identity,
+		provider1 net.Provider,
+		provider2 net.Provider,
+	) {
+		provider2.OnUnicastChannelOpened(func(channel net.UnicastChannel) {
+			callee := rpc.NewChannel(ctx, channel)
+			callee.Handle(
+				func() net.TaggedUnmarshaler {
+					return &testMessage{}
+				},
+				func(
+					ctx context.Context,
+					request net.Message,
+				) (net.TaggedMarshaler, error) {
+					if request.TransportSenderID() != identity1.id {
+						t.Errorf(
+							"unexpected sender [%v]",
+							request.TransportSenderID(),
+						)
+					}
+
+					message := request.Payload().(*testMessage)
+					return &testMessage{
+						Sender:    identity2,
+						Recipient: identity1,
+						Payload:   strings.ToUpper(message.Payload),
+					}, nil
+				},
+			)
+		})
+
+		var unicastChannel net.UnicastChannel
+		err := withRetry(func() (err error) {
+			unicastChannel, err = provider1.UnicastChannelWith(identity2.id)
+			return err
+		}, 3, 5*time.Second)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		caller := rpc.NewChannel(ctx, unicastChannel)
+
+		response := &testMessage{}
+		err = caller.Call(
+			ctx,
+			&testMessage{
+				Sender:    identity1,
+				Recipient: identity2,
+				Payload:   "hello",
+			},
+			response,
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if response.Payload != "HELLO" {
+			t.Errorf(
+				"unexpected response\nexpected: [%v]\nactual:   [%v]",
+				"HELLO",
+				response.Payload,
+			)
+		}
+	})
+}
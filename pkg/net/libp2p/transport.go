@@ -7,6 +7,8 @@ import (
 	secio "github.com/libp2p/go-libp2p-secio"
 
 	keepNet "github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/net/key"
+	"github.com/keep-network/keep-core/pkg/net/security/handshake"
 	libp2pcrypto "github.com/libp2p/go-libp2p-core/crypto"
 	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/libp2p/go-libp2p-core/sec"
@@ -22,17 +24,31 @@ var _ sec.SecureConn = (*authenticatedConnection)(nil)
 
 // transport constructs an encrypted and authenticated connection for a peer.
 type transport struct {
-	localPeerID     peer.ID
-	privateKey      libp2pcrypto.PrivKey
-	protocol        string
-	firewall        keepNet.Firewall
-	encryptionLayer sec.SecureTransport
+	localPeerID      peer.ID
+	privateKey       libp2pcrypto.PrivKey
+	localCertificate *key.Certificate
+	// localOperatorCertificates are certificates of additional operators
+	// run by the client, presented along with the local certificate.
+	localOperatorCertificates []*key.Certificate
+	protocol                  string
+	localCapabilities         *handshake.Capabilities
+	firewall                  keepNet.Firewall
+	certificates              *certificateStore
+	capabilities              *capabilitiesStore
+	reputation                keepNet.Reputation
+	encryptionLayer           sec.SecureTransport
 }
 
 func newEncryptedAuthenticatedTransport(
 	pk libp2pcrypto.PrivKey,
+	localCertificate *key.Certificate,
+	localOperatorCertificates []*key.Certificate,
 	protocol string,
+	localCapabilities *handshake.Capabilities,
 	firewall keepNet.Firewall,
+	certificates *certificateStore,
+	capabilities *capabilitiesStore,
+	reputation keepNet.Reputation,
 ) (*transport, error) {
 	id, err := peer.IDFromPrivateKey(pk)
 	if err != nil {
@@ -45,11 +61,17 @@ func newEncryptedAuthenticatedTransport(
 	}
 
 	return &transport{
-		localPeerID:     id,
-		privateKey:      pk,
-		firewall:        firewall,
-		encryptionLayer: encryptionLayer,
-		protocol:        protocol,
+		localPeerID:               id,
+		privateKey:                pk,
+		localCertificate:          localCertificate,
+		localOperatorCertificates: localOperatorCertificates,
+		firewall:                  firewall,
+		certificates:              certificates,
+		capabilities:              capabilities,
+		reputation:                reputation,
+		encryptionLayer:           encryptionLayer,
+		protocol:                  protocol,
+		localCapabilities:         localCapabilities,
 	}, nil
 }
 
@@ -67,8 +89,14 @@ func (t *transport) SecureInbound(
 		encryptedConnection,
 		t.localPeerID,
 		t.privateKey,
+		t.localCertificate,
+		t.localOperatorCertificates,
 		t.firewall,
+		t.certificates,
+		t.reputation,
 		t.protocol,
+		t.localCapabilities,
+		t.capabilities,
 	)
 }
 
@@ -91,8 +119,14 @@ func (t *transport) SecureOutbound(
 		encryptedConnection,
 		t.localPeerID,
 		t.privateKey,
+		t.localCertificate,
+		t.localOperatorCertificates,
 		remotePeerID,
 		t.firewall,
+		t.certificates,
+		t.reputation,
 		t.protocol,
+		t.localCapabilities,
+		t.capabilities,
 	)
 }
@@ -8,8 +8,10 @@ import (
 	"sync"
 	"time"
 
+	"github.com/ipfs/go-log"
+	"github.com/keep-network/keep-core/pkg/logging"
 	"github.com/keep-network/keep-core/pkg/net/internal"
-	"github.com/keep-network/keep-core/pkg/net/key"
+	"github.com/keep-network/keep-core/pkg/operator"
 
 	"github.com/libp2p/go-libp2p-core/helpers"
 	"github.com/libp2p/go-libp2p-core/peer"
@@ -21,10 +23,7 @@ import (
 	"github.com/libp2p/go-libp2p-core/network"
 )
 
-const (
-	readerMaxSize = 1 << 20
-	sendTimeout   = 10 * time.Second
-)
+const sendTimeout = 10 * time.Second
 
 type streamFactory func(ctx context.Context, peerID peer.ID) (network.Stream, error)
 
@@ -34,6 +33,9 @@ type unicastChannel struct {
 	remotePeerID peer.ID
 
 	streamFactory streamFactory
+	rateLimiter   *rateLimiter
+	reputation    net.Reputation
+	capabilities  *capabilitiesStore
 
 	messageHandlersMutex sync.Mutex
 	messageHandlers      []*unicastMessageHandler
@@ -47,15 +49,20 @@ type unicastMessageHandler struct {
 	channel chan net.Message
 }
 
+// logger returns the package logger annotated with the identifiers of the
+// client and the remote peer.
+func (uc *unicastChannel) logger() *log.ZapEventLogger {
+	return logging.WithLogFields(logger, logging.Fields{
+		logging.ClientField: uc.clientIdentity.id.String(),
+		logging.PeerField:   uc.remotePeerID.String(),
+	})
+}
+
 func (uc *unicastChannel) Send(message net.TaggedMarshaler) error {
 	ctx, cancel := context.WithTimeout(context.Background(), sendTimeout)
 	defer cancel()
 
-	logger.Debugf(
-		"[%v] sending message to peer [%v]",
-		uc.clientIdentity.id,
-		uc.remotePeerID,
-	)
+	uc.logger().Debugf("sending message")
 
 	streamSuccess := make(chan network.Stream)
 	streamError := make(chan error)
@@ -107,7 +114,7 @@ func (uc *unicastChannel) send(stream network.Stream, message proto.Message) err
 	if err != nil {
 		resetErr := stream.Reset()
 		if resetErr != nil {
-			logger.Errorf("could not reset stream: [%v]", resetErr)
+			uc.logger().Errorf("could not reset stream: [%v]", resetErr)
 		}
 		return err
 	}
@@ -149,7 +156,7 @@ func (uc *unicastChannel) Recv(ctx context.Context, handler func(m net.Message))
 		for {
 			select {
 			case <-ctx.Done():
-				logger.Debug("context is done, removing handler")
+				uc.logger().Debug("context is done, removing handler")
 				uc.removeHandler(messageHandler)
 				return
 
@@ -203,12 +210,22 @@ func (uc *unicastChannel) handleStream(stream network.Stream) {
 	}
 
 	go func() {
-		reader := protoio.NewDelimitedReader(stream, readerMaxSize)
+		reader := protoio.NewDelimitedReader(
+			stream,
+			uc.rateLimiter.maxMessageSize(),
+		)
 
 		for {
 			messageProto := new(pb.UnicastNetworkMessage)
 			err := reader.ReadMsg(messageProto)
 			if err != nil {
+				if err == io.ErrShortBuffer {
+					uc.rateLimiter.reportViolation(
+						uc.remotePeerID,
+						"message size limit exceeded",
+					)
+				}
+
 				if err != io.EOF {
 					_ = stream.Reset()
 				} else {
@@ -217,16 +234,20 @@ func (uc *unicastChannel) handleStream(stream network.Stream) {
 				return
 			}
 
-			logger.Debugf(
-				"[%v] received message from peer [%v]",
-				uc.clientIdentity.id,
+			uc.logger().Debugf("received message")
+
+			if !uc.rateLimiter.allow(
+				unicastTopic,
 				uc.remotePeerID,
-			)
+				messageProto.Size(),
+			) {
+				continue
+			}
 
 			// Every message should be independent from any other message.
 			go func(message *pb.UnicastNetworkMessage) {
 				if err := uc.processMessage(message); err != nil {
-					logger.Error(err)
+					uc.logger().Error(err)
 					return
 				}
 			}(messageProto)
@@ -243,12 +264,14 @@ func (uc *unicastChannel) processMessage(message *pb.UnicastNetworkMessage) erro
 	}
 
 	if err := unmarshaled.Unmarshal(message.GetPayload()); err != nil {
+		uc.reputation.ReportMisbehavior(uc.remotePeerID, net.MalformedMessage)
 		return err
 	}
 
 	// Construct an identifier from the sender.
 	senderIdentifier := &identity{}
 	if err := senderIdentifier.Unmarshal(message.Sender); err != nil {
+		uc.reputation.ReportMisbehavior(uc.remotePeerID, net.MalformedMessage)
 		return err
 	}
 
@@ -265,26 +288,29 @@ func (uc *unicastChannel) processMessage(message *pb.UnicastNetworkMessage) erro
 		return err
 	}
 
-	networkKey := key.Libp2pKeyToNetworkKey(senderIdentifier.pubKey)
-	if networkKey == nil {
-		return fmt.Errorf(
-			"sender [%v] with key [%v] is not of correct type",
-			senderIdentifier.id,
-			senderIdentifier.pubKey,
-		)
+	operatorPublicKey, err := senderIdentifier.operatorPublicKey()
+	if err != nil {
+		return err
 	}
 
 	uc.deliver(internal.BasicMessage(
 		senderIdentifier.id,
 		unmarshaled,
 		string(message.Type),
-		key.Marshal(networkKey),
+		operator.Marshal(operatorPublicKey),
+		uc.RemoteCapabilities(),
 		uint64(0),
 	))
 
 	return err
 }
 
+// RemoteCapabilities returns the capabilities negotiated with the remote peer
+// during the connection handshake or nil if they are not known.
+func (uc *unicastChannel) RemoteCapabilities() *net.PeerCapabilities {
+	return uc.capabilities.get(uc.remotePeerID)
+}
+
 func (uc *unicastChannel) getUnmarshalingContainerByType(messageType string) (
 	net.TaggedUnmarshaler,
 	error,
@@ -21,25 +21,38 @@ const (
 type unicastChannelManager struct {
 	ctx context.Context
 
-	identity *identity
-	p2phost  host.Host
+	identity     *identity
+	p2phost      host.Host
+	rateLimiter  *rateLimiter
+	reputation   net.Reputation
+	capabilities *capabilitiesStore
 
 	channelsMutex sync.Mutex
 	channels      map[net.TransportIdentifier]*unicastChannel
 
 	channelOpenedHandler func(channel net.UnicastChannel)
+	// channelCreatedHandler is called for every new channel, no matter which
+	// peer opened it, before the channel receives any message. It is guarded
+	// by channelsMutex.
+	channelCreatedHandler func(channel *unicastChannel)
 }
 
 func newUnicastChannelManager(
 	ctx context.Context,
 	identity *identity,
 	p2phost host.Host,
+	rateLimiter *rateLimiter,
+	reputation net.Reputation,
+	capabilities *capabilitiesStore,
 ) *unicastChannelManager {
 	manager := &unicastChannelManager{
-		ctx:      ctx,
-		identity: identity,
-		p2phost:  p2phost,
-		channels: make(map[net.TransportIdentifier]*unicastChannel),
+		ctx:          ctx,
+		identity:     identity,
+		p2phost:      p2phost,
+		rateLimiter:  rateLimiter,
+		reputation:   reputation,
+		capabilities: capabilities,
+		channels:     make(map[net.TransportIdentifier]*unicastChannel),
 	}
 
 	p2phost.SetStreamHandlerMatch(
@@ -57,6 +70,15 @@ func (ucm *unicastChannelManager) onChannelOpened(
 	ucm.channelOpenedHandler = handler
 }
 
+func (ucm *unicastChannelManager) onChannelCreated(
+	handler func(channel *unicastChannel),
+) {
+	ucm.channelsMutex.Lock()
+	defer ucm.channelsMutex.Unlock()
+
+	ucm.channelCreatedHandler = handler
+}
+
 func (ucm *unicastChannelManager) handleIncomingStream(stream network.Stream) {
 	logger.Debugf(
 		"[%v] processing incoming stream [%v] from peer [%v]",
@@ -128,6 +150,10 @@ func (ucm *unicastChannelManager) getUnicastChannel(
 		if !exists {
 			channel = newChannel
 			ucm.channels[peerID] = newChannel
+
+			if ucm.channelCreatedHandler != nil {
+				ucm.channelCreatedHandler(newChannel)
+			}
 		}
 		ucm.channelsMutex.Unlock()
 	}
@@ -151,6 +177,9 @@ func (ucm *unicastChannelManager) newUnicastChannel(
 		clientIdentity:     ucm.identity,
 		remotePeerID:       remotePeer,
 		streamFactory:      streamFactory,
+		rateLimiter:        ucm.rateLimiter,
+		reputation:         ucm.reputation,
+		capabilities:       ucm.capabilities,
 		messageHandlers:    make([]*unicastMessageHandler, 0),
 		unmarshalersByType: make(map[string]func() net.TaggedUnmarshaler),
 	}
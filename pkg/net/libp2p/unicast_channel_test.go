@@ -99,6 +99,44 @@ func TestSendReceiveUnicastChannel(t *testing.T) {
 	})
 }
 
+func TestUnicastChannelRemoteCapabilities(t *testing.T) {
+	ctx := context.Background()
+
+	withNetwork(ctx, t, 9100, func(
+		identity1 *identity,
+		identity2 *identity,
+		provider1 net.Provider,
+		provider2 net.Provider,
+	) {
+		channel, err := provider1.UnicastChannelWith(identity2.id)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		capabilities := channel.RemoteCapabilities()
+		if capabilities == nil {
+			t.Fatal("remote capabilities not negotiated")
+		}
+		if capabilities.ProtocolVersion != maxProtocolVersion {
+			t.Errorf(
+				"unexpected protocol version\nexpected: [%v]\nactual:   [%v]",
+				maxProtocolVersion,
+				capabilities.ProtocolVersion,
+			)
+		}
+		if !capabilities.Supports(FeatureMissedMessages) {
+			t.Errorf("expected remote peer to support missed messages")
+		}
+
+		peerCapabilities := provider2.ConnectionManager().GetPeerCapabilities(
+			identity1.id.String(),
+		)
+		if !peerCapabilities.Supports(FeatureMissedMessages) {
+			t.Errorf("expected connected peer to support missed messages")
+		}
+	})
+}
+
 func withRetry(function func() error, retryCount int, waitTime time.Duration) error {
 	var err error
 
@@ -3,8 +3,10 @@ package local
 import (
 	"context"
 	"fmt"
+	"math"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/keep-network/keep-core/pkg/net"
 	"github.com/keep-network/keep-core/pkg/net/internal"
@@ -12,11 +14,48 @@ import (
 	"github.com/keep-network/keep-core/pkg/net/retransmission"
 )
 
-const messageHandlerThrottle = 256
+const (
+	messageHandlerThrottle = 256
+	// messageHistorySize is the number of most recent messages kept by the
+	// channel so that they can be redelivered when requested.
+	messageHistorySize = 1024
+)
 
 type messageHandler struct {
 	ctx     context.Context
 	channel chan net.Message
+
+	// registeredAt is the history sequence at the moment the handler was
+	// registered. Messages received with greater sequences are passed to
+	// the handler as they are received.
+	registeredAt uint64
+
+	redeliveredMutex sync.Mutex
+	redelivered      map[string]bool
+}
+
+// markRedelivered records the message with the given ID has been redelivered
+// to the handler. It returns false if the message had been redelivered to the
+// handler before.
+func (mh *messageHandler) markRedelivered(id string) bool {
+	mh.redeliveredMutex.Lock()
+	defer mh.redeliveredMutex.Unlock()
+
+	if mh.redelivered[id] {
+		return false
+	}
+
+	mh.redelivered[id] = true
+	return true
+}
+
+// receivedMessage is a message kept in the channel history. Its sequence is
+// the history sequence at which the last copy of the message, either the
+// original or one of its retransmissions, has been received.
+type receivedMessage struct {
+	message  net.Message
+	received time.Time
+	sequence uint64
 }
 
 type localChannel struct {
@@ -24,22 +63,38 @@ type localChannel struct {
 	name                 string
 	identifier           net.TransportIdentifier
 	staticKey            *key.NetworkPublic
+	link                 *link
 	messageHandlersMutex sync.Mutex
 	messageHandlers      []*messageHandler
 	unmarshalersMutex    sync.Mutex
 	unmarshalersByType   map[string]func() net.TaggedUnmarshaler
 	retransmissionTicker *retransmission.Ticker
+	historyMutex         sync.Mutex
+	history              []*receivedMessage
+	historySequence      uint64
+	historyEvicted       bool
+	// done is closed when the channel gets closed.
+	done chan struct{}
 }
 
 func (lc *localChannel) nextSeqno() uint64 {
 	return atomic.AddUint64(&lc.counter, 1)
 }
 
+// providerID returns the transport ID of the provider the channel belongs to.
+func (lc *localChannel) providerID() string {
+	return createLocalIdentifier(lc.staticKey).String()
+}
+
 func (lc *localChannel) Name() string {
 	return lc.name
 }
 
-func (lc *localChannel) Send(ctx context.Context, message net.TaggedMarshaler) error {
+func (lc *localChannel) Send(
+	ctx context.Context,
+	message net.TaggedMarshaler,
+	strategy ...net.RetransmissionStrategy,
+) error {
 	bytes, err := message.Marshal()
 	if err != nil {
 		return err
@@ -61,6 +116,7 @@ func (lc *localChannel) Send(ctx context.Context, message net.TaggedMarshaler) e
 		unmarshaled,
 		"local",
 		key.Marshal(lc.staticKey),
+		nil,
 		lc.nextSeqno(),
 	)
 
@@ -68,39 +124,61 @@ func (lc *localChannel) Send(ctx context.Context, message net.TaggedMarshaler) e
 		ctx,
 		lc.retransmissionTicker,
 		func() error {
-			return broadcastMessage(lc.name, netMessage)
+			return broadcastMessage(lc, netMessage)
 		},
+		strategy...,
 	)
 
-	return broadcastMessage(lc.name, netMessage)
+	return broadcastMessage(lc, netMessage)
 }
 
+// deliver records the message in the channel history and passes it to
+// handlers registered before it was recorded. Handlers registered later can
+// have it redelivered with RequestMissedMessages.
 func (lc *localChannel) deliver(message net.Message) {
-	lc.messageHandlersMutex.Lock()
-	snapshot := make([]*messageHandler, len(lc.messageHandlers))
-	copy(snapshot, lc.messageHandlers)
-	lc.messageHandlersMutex.Unlock()
+	sequence := lc.record(message)
 
-	for _, handler := range snapshot {
-		select {
-		case handler.channel <- message:
-		default:
-			logger.Warningf("handler too slow, dropping message")
+	for _, handler := range lc.handlers() {
+		if handler.registeredAt < sequence {
+			deliverTo(handler, message)
 		}
 	}
 }
 
+func deliverTo(handler *messageHandler, message net.Message) {
+	select {
+	case handler.channel <- message:
+	default:
+		logger.Warningf("handler too slow, dropping message")
+	}
+}
+
+func (lc *localChannel) handlers() []*messageHandler {
+	lc.messageHandlersMutex.Lock()
+	defer lc.messageHandlersMutex.Unlock()
+
+	snapshot := make([]*messageHandler, len(lc.messageHandlers))
+	copy(snapshot, lc.messageHandlers)
+	return snapshot
+}
+
 func (lc *localChannel) Recv(ctx context.Context, handler func(m net.Message)) {
 	messageHandler := &messageHandler{
-		ctx:     ctx,
-		channel: make(chan net.Message, messageHandlerThrottle),
+		ctx:         ctx,
+		channel:     make(chan net.Message, messageHandlerThrottle),
+		redelivered: make(map[string]bool),
 	}
 
 	lc.messageHandlersMutex.Lock()
+	messageHandler.registeredAt = lc.lastSequence()
 	lc.messageHandlers = append(lc.messageHandlers, messageHandler)
 	lc.messageHandlersMutex.Unlock()
 
-	handleWithRetransmissions := retransmission.WithRetransmissionSupport(handler)
+	handleWithRetransmissions := retransmission.WithRetransmissionSupport(
+		ctx,
+		lc.retransmissionTicker,
+		handler,
+	)
 
 	go func() {
 		for {
@@ -110,6 +188,11 @@ func (lc *localChannel) Recv(ctx context.Context, handler func(m net.Message)) {
 				lc.removeHandler(messageHandler)
 				return
 
+			case <-lc.done:
+				logger.Debug("channel is closed, removing handler")
+				lc.removeHandler(messageHandler)
+				return
+
 			case msg := <-messageHandler.channel:
 				// Go language specification says that if one or more of the
 				// communications in the select statement can proceed, a single
@@ -155,3 +238,181 @@ func (lc *localChannel) SetUnmarshaler(unmarshaler func() net.TaggedUnmarshaler)
 func (lc *localChannel) SetFilter(filter net.BroadcastChannelFilter) error {
 	return nil // no-op
 }
+
+// RequestMissedMessages redelivers messages matching the filter which have
+// been received by this channel to handlers registered before they were
+// received and delivers those received only by other local channels with the
+// same name, unless their provider is currently partitioned from this one.
+// Messages received by other channels before the oldest message in the
+// history of this channel are not delivered.
+func (lc *localChannel) RequestMissedMessages(
+	ctx context.Context,
+	filter net.MissedMessagesFilter,
+) error {
+	for _, handler := range lc.handlers() {
+		for _, message := range lc.missedBy(filter, handler.registeredAt) {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			if handler.markRedelivered(messageID(message)) {
+				deliverTo(handler, message)
+			}
+		}
+	}
+
+	if horizon := lc.horizon(); filter.Since.Before(horizon) {
+		filter.Since = horizon
+	}
+
+	broadcastChannelsMutex.Lock()
+	peerChannels := broadcastChannels[lc.name]
+	broadcastChannelsMutex.Unlock()
+
+	for _, peerChannel := range peerChannels {
+		if peerChannel == lc || lc.link.partitioned(peerChannel.providerID()) {
+			continue
+		}
+
+		for _, message := range peerChannel.matchingMessages(filter) {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			if !lc.hasMessage(messageID(message)) {
+				lc.deliver(message)
+			}
+		}
+	}
+
+	return nil
+}
+
+// record adds the message to the channel history and returns the history
+// sequence assigned to it. Retransmissions of a message which is already in
+// the history are not added again; the sequence of the existing entry is
+// updated instead.
+func (lc *localChannel) record(message net.Message) uint64 {
+	lc.historyMutex.Lock()
+	defer lc.historyMutex.Unlock()
+
+	lc.historySequence++
+
+	id := messageID(message)
+	for _, entry := range lc.history {
+		if messageID(entry.message) == id {
+			entry.sequence = lc.historySequence
+			return entry.sequence
+		}
+	}
+
+	lc.history = append(
+		lc.history,
+		&receivedMessage{message, time.Now(), lc.historySequence},
+	)
+	if len(lc.history) > messageHistorySize {
+		lc.history = lc.history[len(lc.history)-messageHistorySize:]
+		lc.historyEvicted = true
+	}
+
+	return lc.historySequence
+}
+
+func (lc *localChannel) lastSequence() uint64 {
+	lc.historyMutex.Lock()
+	defer lc.historyMutex.Unlock()
+
+	return lc.historySequence
+}
+
+// horizon returns the time the oldest message in the history has been
+// received at if older messages have been evicted. It returns zero time if
+// the history holds all messages received by the channel.
+func (lc *localChannel) horizon() time.Time {
+	lc.historyMutex.Lock()
+	defer lc.historyMutex.Unlock()
+
+	if !lc.historyEvicted || len(lc.history) == 0 {
+		return time.Time{}
+	}
+
+	return lc.history[0].received
+}
+
+func (lc *localChannel) hasMessage(id string) bool {
+	lc.historyMutex.Lock()
+	defer lc.historyMutex.Unlock()
+
+	for _, entry := range lc.history {
+		if messageID(entry.message) == id {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (lc *localChannel) matchingMessages(
+	filter net.MissedMessagesFilter,
+) []net.Message {
+	return lc.missedBy(filter, math.MaxUint64)
+}
+
+// missedBy returns messages matching the filter which have been missed by
+// a handler registered at the given history sequence, that is, messages no
+// copy of which has been received after the handler was registered.
+func (lc *localChannel) missedBy(
+	filter net.MissedMessagesFilter,
+	registeredAt uint64,
+) []net.Message {
+	lc.historyMutex.Lock()
+	defer lc.historyMutex.Unlock()
+
+	messages := make([]net.Message, 0)
+	for _, entry := range lc.history {
+		if entry.sequence > registeredAt {
+			continue
+		}
+
+		if filter.Matches(
+			entry.message.SenderPublicKey(),
+			payloadType(entry.message),
+			entry.received,
+		) {
+			messages = append(messages, entry.message)
+		}
+	}
+
+	return messages
+}
+
+func messageID(message net.Message) string {
+	return fmt.Sprintf(
+		"%v-%v",
+		message.TransportSenderID(),
+		message.Seqno(),
+	)
+}
+
+// payloadType returns the type of the message as registered by the protocol.
+// Local messages carry a generic type so the type is taken from the payload.
+func payloadType(message net.Message) string {
+	if payload, ok := message.Payload().(net.TaggedUnmarshaler); ok {
+		return payload.Type()
+	}
+	return message.Type()
+}
+
+func (lc *localChannel) Close() error {
+	if err := removeBroadcastChannel(lc); err != nil {
+		return err
+	}
+
+	close(lc.done)
+
+	lc.messageHandlersMutex.Lock()
+	lc.messageHandlers = nil
+	lc.messageHandlersMutex.Unlock()
+
+	return nil
+}
@@ -2,6 +2,7 @@ package local
 
 import (
 	"context"
+	"fmt"
 	"sync"
 	"time"
 
@@ -17,8 +18,13 @@ var broadcastChannels map[string][]*localChannel
 // participants. It delivers all messages sent to the channel through its
 // receive channels. RecvChan on a LocalChannel creates a new receive channel
 // that is returned to the caller, so that all receive channels can receive
-// the message.
-func getBroadcastChannel(name string, staticKey *key.NetworkPublic) net.BroadcastChannel {
+// the message. Messages from other channels are received through the provided
+// link.
+func getBroadcastChannel(
+	name string,
+	staticKey *key.NetworkPublic,
+	link *link,
+) net.BroadcastChannel {
 	broadcastChannelsMutex.Lock()
 	defer broadcastChannelsMutex.Unlock()
 	if broadcastChannels == nil {
@@ -36,6 +42,7 @@ func getBroadcastChannel(name string, staticKey *key.NetworkPublic) net.Broadcas
 		name:                 name,
 		identifier:           &identifier,
 		staticKey:            staticKey,
+		link:                 link,
 		messageHandlersMutex: sync.Mutex{},
 		messageHandlers:      make([]*messageHandler, 0),
 		unmarshalersMutex:    sync.Mutex{},
@@ -43,19 +50,55 @@ func getBroadcastChannel(name string, staticKey *key.NetworkPublic) net.Broadcas
 		retransmissionTicker: retransmission.NewTimeTicker(
 			context.Background(), 50*time.Millisecond,
 		),
+		done: make(chan struct{}),
 	}
 	broadcastChannels[name] = append(broadcastChannels[name], channel)
 
 	return channel
 }
 
-func broadcastMessage(name string, message net.Message) error {
+// removeBroadcastChannel removes the closed channel so that it no longer
+// receives messages.
+func removeBroadcastChannel(channel *localChannel) error {
 	broadcastChannelsMutex.Lock()
-	targetChannels := broadcastChannels[name]
+	defer broadcastChannelsMutex.Unlock()
+
+	localChannels := broadcastChannels[channel.name]
+	for i, existing := range localChannels {
+		if existing == channel {
+			remaining := make([]*localChannel, 0, len(localChannels)-1)
+			remaining = append(remaining, localChannels[:i]...)
+			remaining = append(remaining, localChannels[i+1:]...)
+
+			if len(remaining) == 0 {
+				delete(broadcastChannels, channel.name)
+			} else {
+				broadcastChannels[channel.name] = remaining
+			}
+
+			return nil
+		}
+	}
+
+	return fmt.Errorf("channel [%v] is already closed", channel.name)
+}
+
+func broadcastMessage(sender *localChannel, message net.Message) error {
+	broadcastChannelsMutex.Lock()
+	targetChannels := broadcastChannels[sender.name]
 	broadcastChannelsMutex.Unlock()
 
+	senderID := sender.providerID()
 	for _, targetChannel := range targetChannels {
-		targetChannel.deliver(message)
+		if targetChannel == sender {
+			targetChannel.deliver(message)
+			continue
+		}
+
+		targetChannel := targetChannel // capture for closure
+		targetChannel.link.transmit(senderID, func() {
+			targetChannel.deliver(message)
+		})
 	}
 
 	return nil
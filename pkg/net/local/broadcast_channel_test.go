@@ -230,6 +230,199 @@ loop:
 	}
 }
 
+func TestCloseChannel(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	channelName := "closed channel"
+
+	_, localChannel1, err := initTestChannel(channelName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, localChannel2, err := initTestChannel(channelName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	inMsgChan := make(chan net.Message, 1)
+	localChannel2.Recv(ctx, func(msg net.Message) {
+		inMsgChan <- msg
+	})
+
+	if err := localChannel2.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := localChannel1.Send(ctx, &mockNetMessage{}); err != nil {
+		t.Fatalf("failed to send message: [%v]", err)
+	}
+
+	select {
+	case <-inMsgChan:
+		t.Errorf("closed channel should not receive messages")
+	case <-ctx.Done():
+	}
+
+	expectedError := "channel [closed channel] is already closed"
+	if err := localChannel2.Close(); err == nil || err.Error() != expectedError {
+		t.Errorf(
+			"unexpected error\nexpected: [%v]\nactual:   [%v]",
+			expectedError,
+			err,
+		)
+	}
+}
+
+func TestRequestMissedMessages(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	channelName := "missed messages channel"
+
+	staticKey1, localChannel1, err := initTestChannel(channelName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, localChannel2, err := initTestChannel(channelName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	receivedChan := make(chan net.Message, 1)
+	localChannel2.Recv(ctx, func(msg net.Message) {
+		receivedChan <- msg
+	})
+
+	sendCtx, cancelSend := context.WithCancel(ctx)
+	cancelSend() // no retransmissions
+	if err := localChannel1.Send(sendCtx, &mockNetMessage{}); err != nil {
+		t.Fatal(err)
+	}
+
+	<-receivedChan
+
+	// The third channel is opened after the message has been sent.
+	_, localChannel3, err := initTestChannel(channelName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	missedChan := make(chan net.Message, 2)
+	localChannel3.Recv(ctx, func(msg net.Message) {
+		missedChan <- msg
+	})
+
+	_, otherKey, err := key.GenerateStaticNetworkKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = localChannel3.RequestMissedMessages(
+		ctx,
+		net.MissedMessagesFilter{SenderPublicKey: key.Marshal(otherKey)},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = localChannel3.RequestMissedMessages(
+		ctx,
+		net.MissedMessagesFilter{
+			SenderPublicKey: key.Marshal(staticKey1),
+			Type:            "mock_message",
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The message has been already received by the second channel's
+	// handler so it should not be passed to it again.
+	err = localChannel2.RequestMissedMessages(ctx, net.MissedMessagesFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case msg := <-missedChan:
+		testutils.AssertBytesEqual(
+			t,
+			key.Marshal(staticKey1),
+			msg.SenderPublicKey(),
+		)
+	case <-ctx.Done():
+		t.Fatal("expected missed message")
+	}
+
+	select {
+	case <-missedChan:
+		t.Errorf("unexpected missed message")
+	case <-receivedChan:
+		t.Errorf("unexpected redelivered message")
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestRequestMissedMessagesRedeliversOnlyOnce(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	channelName := "redelivery channel"
+
+	_, localChannel1, err := initTestChannel(channelName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, localChannel2, err := initTestChannel(channelName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	receivedChan := make(chan net.Message, 2)
+	localChannel2.Recv(ctx, func(msg net.Message) {
+		receivedChan <- msg
+	})
+
+	sendCtx, cancelSend := context.WithCancel(ctx)
+	cancelSend() // no retransmissions
+	if err := localChannel1.Send(sendCtx, &mockNetMessage{}); err != nil {
+		t.Fatal(err)
+	}
+
+	<-receivedChan
+
+	// The handler is registered after the message has been received by
+	// the channel.
+	lateChan := make(chan net.Message, 2)
+	localChannel2.Recv(ctx, func(msg net.Message) {
+		lateChan <- msg
+	})
+
+	for i := 0; i < 2; i++ {
+		err = localChannel2.RequestMissedMessages(
+			ctx,
+			net.MissedMessagesFilter{},
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	select {
+	case <-lateChan:
+	case <-ctx.Done():
+		t.Fatal("expected missed message")
+	}
+
+	select {
+	case <-lateChan:
+		t.Errorf("unexpected second redelivery")
+	case <-receivedChan:
+		t.Errorf("unexpected redelivered message")
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
 func initTestChannel(channelName string) (*key.NetworkPublic, net.BroadcastChannel, error) {
 	_, staticKey, err := key.GenerateStaticNetworkKey()
 	if err != nil {
@@ -0,0 +1,203 @@
+package local
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// reorderDelay is the minimum additional delay of a reordered message. It
+// lets messages sent after the reordered one overtake it even on links with
+// no latency.
+const reorderDelay = 10 * time.Millisecond
+
+// LatencyDistribution returns the latency of a single message delivery. The
+// provided source of randomness is the only one the distribution should use
+// so that simulations with a fixed seed are reproducible.
+type LatencyDistribution func(random *rand.Rand) time.Duration
+
+// ConstantLatency delays every message by the same duration.
+func ConstantLatency(latency time.Duration) LatencyDistribution {
+	return func(random *rand.Rand) time.Duration {
+		return latency
+	}
+}
+
+// UniformLatency delays messages by a duration drawn uniformly from the
+// [min, max) range.
+func UniformLatency(min, max time.Duration) LatencyDistribution {
+	return func(random *rand.Rand) time.Duration {
+		if max <= min {
+			return min
+		}
+		return min + time.Duration(random.Int63n(int64(max-min)))
+	}
+}
+
+// NormalLatency delays messages by a duration drawn from the normal
+// distribution with the given mean and standard deviation. Negative samples
+// are treated as no latency.
+func NormalLatency(mean, stddev time.Duration) LatencyDistribution {
+	return func(random *rand.Rand) time.Duration {
+		latency := time.Duration(random.NormFloat64()*float64(stddev)) + mean
+		if latency < 0 {
+			return 0
+		}
+		return latency
+	}
+}
+
+// Link describes the conditions of the network link messages are received
+// through. All rates are probabilities from the [0, 1] range.
+type Link struct {
+	// Latency is the distribution of the message delivery latency. If not
+	// set, messages are delivered with no latency.
+	Latency LatencyDistribution
+	// DropRate is the probability of a message being lost.
+	DropRate float64
+	// DuplicateRate is the probability of a message being delivered twice.
+	DuplicateRate float64
+	// ReorderRate is the probability of a message being held back long
+	// enough to be overtaken by messages sent after it.
+	ReorderRate float64
+}
+
+// Partition cuts the provider off from the given peers for the given period
+// of time, measured from the moment the provider has been connected.
+type Partition struct {
+	Start time.Duration
+	End   time.Duration
+	// Peers are transport IDs of peers the provider is cut off from. If
+	// empty, the provider is cut off from all its peers.
+	Peers []string
+}
+
+// LinkModel describes the conditions of network links through which a local
+// provider receives messages from its peers. Messages a provider's channel
+// sends to itself are not subject to the link model.
+type LinkModel struct {
+	// Default describes links with peers not listed in Peers.
+	Default Link
+	// Peers describes links with specific peers, by their transport ID.
+	Peers map[string]Link
+	// Partitions are the periods of time during which the provider does not
+	// receive any messages from some or all of its peers.
+	Partitions []Partition
+	// Seed initializes the source of randomness of the simulation. If zero,
+	// a random seed is used.
+	Seed int64
+}
+
+// link applies the link model to messages received by a local provider.
+// A nil link delivers all messages immediately.
+type link struct {
+	model LinkModel
+	start time.Time
+
+	randomMutex sync.Mutex
+	random      *rand.Rand
+}
+
+func newLink(model LinkModel) *link {
+	seed := model.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+
+	return &link{
+		model: model,
+		start: time.Now(),
+		// #nosec G404 (insecure random number source (rand))
+		// Network simulation doesn't require secure randomness.
+		random: rand.New(rand.NewSource(seed)),
+	}
+}
+
+// transmit delivers the message received from the given peer according to
+// the link model. The deliver function may be called asynchronously, more
+// than once or not at all. Copies of the message with no delay are delivered
+// before transmit returns so that they keep the order they were sent in.
+func (l *link) transmit(sender string, deliver func()) {
+	if l == nil {
+		deliver()
+		return
+	}
+
+	if l.partitioned(sender) {
+		return
+	}
+
+	conditions, ok := l.model.Peers[sender]
+	if !ok {
+		conditions = l.model.Default
+	}
+
+	for _, delay := range l.delays(conditions) {
+		if delay == 0 {
+			deliver()
+			continue
+		}
+
+		time.AfterFunc(delay, deliver)
+	}
+}
+
+// delays draws the delays of all copies of a message delivered over a link
+// with the given conditions. No delays are returned for a dropped message.
+func (l *link) delays(conditions Link) []time.Duration {
+	l.randomMutex.Lock()
+	defer l.randomMutex.Unlock()
+
+	if l.random.Float64() < conditions.DropRate {
+		return nil
+	}
+
+	copies := 1
+	if l.random.Float64() < conditions.DuplicateRate {
+		copies++
+	}
+
+	delays := make([]time.Duration, copies)
+	for i := range delays {
+		delays[i] = l.latency(conditions)
+		if l.random.Float64() < conditions.ReorderRate {
+			delays[i] += reorderDelay + l.latency(conditions)
+		}
+	}
+
+	return delays
+}
+
+func (l *link) latency(conditions Link) time.Duration {
+	if conditions.Latency == nil {
+		return 0
+	}
+	return conditions.Latency(l.random)
+}
+
+// partitioned returns true if the provider is currently cut off from the
+// given peer.
+func (l *link) partitioned(peer string) bool {
+	if l == nil {
+		return false
+	}
+
+	elapsed := time.Since(l.start)
+	for _, partition := range l.model.Partitions {
+		if elapsed < partition.Start || elapsed >= partition.End {
+			continue
+		}
+
+		if len(partition.Peers) == 0 {
+			return true
+		}
+
+		for _, partitionedPeer := range partition.Peers {
+			if partitionedPeer == peer {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,295 @@
+package local
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/net/key"
+)
+
+func TestLinkTransmit(t *testing.T) {
+	var tests = map[string]struct {
+		model             LinkModel
+		expectedDelivered int
+	}{
+		"no conditions": {
+			model:             LinkModel{},
+			expectedDelivered: 1,
+		},
+		"message dropped": {
+			model:             LinkModel{Default: Link{DropRate: 1}},
+			expectedDelivered: 0,
+		},
+		"message duplicated": {
+			model:             LinkModel{Default: Link{DuplicateRate: 1}},
+			expectedDelivered: 2,
+		},
+		"message from another peer dropped": {
+			model: LinkModel{
+				Peers: map[string]Link{"peer-2": {DropRate: 1}},
+			},
+			expectedDelivered: 1,
+		},
+		"message from peer dropped": {
+			model: LinkModel{
+				Peers: map[string]Link{"peer-1": {DropRate: 1}},
+			},
+			expectedDelivered: 0,
+		},
+		"peer partitioned": {
+			model: LinkModel{
+				Partitions: []Partition{
+					{Start: 0, End: time.Minute, Peers: []string{"peer-1"}},
+				},
+			},
+			expectedDelivered: 0,
+		},
+		"another peer partitioned": {
+			model: LinkModel{
+				Partitions: []Partition{
+					{Start: 0, End: time.Minute, Peers: []string{"peer-2"}},
+				},
+			},
+			expectedDelivered: 1,
+		},
+		"all peers partitioned": {
+			model: LinkModel{
+				Partitions: []Partition{{Start: 0, End: time.Minute}},
+			},
+			expectedDelivered: 0,
+		},
+		"partition not started yet": {
+			model: LinkModel{
+				Partitions: []Partition{{Start: time.Minute, End: time.Hour}},
+			},
+			expectedDelivered: 1,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			link := newLink(test.model)
+
+			var mutex sync.Mutex
+			delivered := 0
+			link.transmit("peer-1", func() {
+				mutex.Lock()
+				delivered++
+				mutex.Unlock()
+			})
+
+			time.Sleep(50 * time.Millisecond)
+
+			mutex.Lock()
+			defer mutex.Unlock()
+			if delivered != test.expectedDelivered {
+				t.Errorf(
+					"unexpected number of deliveries\nexpected: [%v]\nactual:   [%v]",
+					test.expectedDelivered,
+					delivered,
+				)
+			}
+		})
+	}
+}
+
+func TestLinkLatency(t *testing.T) {
+	latency := 200 * time.Millisecond
+	link := newLink(LinkModel{Default: Link{Latency: ConstantLatency(latency)}})
+
+	delivered := make(chan time.Time, 1)
+	sent := time.Now()
+	link.transmit("peer-1", func() {
+		delivered <- time.Now()
+	})
+
+	select {
+	case deliveredAt := <-delivered:
+		if deliveredAt.Sub(sent) < latency {
+			t.Errorf(
+				"message delivered too early: [%v] after sending",
+				deliveredAt.Sub(sent),
+			)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("message not delivered")
+	}
+}
+
+func TestLinkDeliversInOrderWithoutLatency(t *testing.T) {
+	link := newLink(LinkModel{Default: Link{DuplicateRate: 0.5}})
+
+	// Messages are delivered synchronously, so no synchronization is
+	// needed.
+	delivered := make([]int, 0)
+	for i := 0; i < 100; i++ {
+		i := i
+		link.transmit("peer-1", func() { delivered = append(delivered, i) })
+	}
+
+	if len(delivered) < 100 {
+		t.Fatalf("expected at least 100 deliveries, has [%v]", len(delivered))
+	}
+
+	for i := 1; i < len(delivered); i++ {
+		if delivered[i] < delivered[i-1] {
+			t.Fatalf(
+				"message [%v] delivered after message [%v]",
+				delivered[i],
+				delivered[i-1],
+			)
+		}
+	}
+}
+
+func TestLinkReorder(t *testing.T) {
+	link := newLink(LinkModel{
+		Peers: map[string]Link{"peer-1": {ReorderRate: 1}},
+	})
+
+	delivered := make(chan string, 2)
+	link.transmit("peer-1", func() { delivered <- "first" })
+	link.transmit("peer-2", func() { delivered <- "second" })
+
+	for _, expected := range []string{"second", "first"} {
+		select {
+		case actual := <-delivered:
+			if actual != expected {
+				t.Errorf(
+					"unexpected message delivered\nexpected: [%v]\nactual:   [%v]",
+					expected,
+					actual,
+				)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("message not delivered")
+		}
+	}
+}
+
+func TestLatencyDistributions(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+
+	var tests = map[string]struct {
+		distribution LatencyDistribution
+		min          time.Duration
+		max          time.Duration
+	}{
+		"constant": {
+			distribution: ConstantLatency(10 * time.Millisecond),
+			min:          10 * time.Millisecond,
+			max:          10 * time.Millisecond,
+		},
+		"uniform": {
+			distribution: UniformLatency(10*time.Millisecond, 20*time.Millisecond),
+			min:          10 * time.Millisecond,
+			max:          20 * time.Millisecond,
+		},
+		"normal": {
+			distribution: NormalLatency(10*time.Millisecond, 50*time.Millisecond),
+			min:          0,
+			max:          time.Second,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			for i := 0; i < 1000; i++ {
+				latency := test.distribution(random)
+				if latency < test.min || latency > test.max {
+					t.Fatalf(
+						"latency [%v] out of the [%v, %v] range",
+						latency,
+						test.min,
+						test.max,
+					)
+				}
+			}
+		})
+	}
+}
+
+func TestBroadcastThroughPartitionedLink(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	channelName := "partitioned channel"
+
+	_, senderKey, err := key.GenerateStaticNetworkKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sender := ConnectWithKey(senderKey)
+
+	_, partitionedKey, err := key.GenerateStaticNetworkKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	partitioned := ConnectWithLinkModel(partitionedKey, LinkModel{
+		Partitions: []Partition{{
+			Start: 0,
+			End:   time.Hour,
+			Peers: []string{sender.ID().String()},
+		}},
+	})
+
+	_, receiverKey, err := key.GenerateStaticNetworkKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	receiver := ConnectWithLinkModel(receiverKey, LinkModel{
+		Default: Link{Latency: ConstantLatency(100 * time.Millisecond)},
+	})
+
+	received := make(chan string, 10)
+	channels := make(map[string]net.BroadcastChannel)
+	for name, provider := range map[string]net.Provider{
+		"sender":      sender,
+		"partitioned": partitioned,
+		"receiver":    receiver,
+	} {
+		name := name
+
+		channel, err := provider.BroadcastChannelFor(channelName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer channel.Close()
+
+		channel.SetUnmarshaler(func() net.TaggedUnmarshaler {
+			return &mockNetMessage{}
+		})
+		channel.Recv(ctx, func(message net.Message) {
+			received <- name
+		})
+
+		channels[name] = channel
+	}
+
+	if err := channels["sender"].Send(ctx, &mockNetMessage{}); err != nil {
+		t.Fatal(err)
+	}
+
+	receivedBy := make(map[string]int)
+	for ctx.Err() == nil {
+		select {
+		case name := <-received:
+			receivedBy[name]++
+		case <-ctx.Done():
+		}
+	}
+
+	if receivedBy["sender"] != 1 {
+		t.Errorf("expected sender to receive its own message")
+	}
+	if receivedBy["receiver"] != 1 {
+		t.Errorf("expected receiver to receive the message")
+	}
+	if receivedBy["partitioned"] != 0 {
+		t.Errorf("expected partitioned provider to not receive the message")
+	}
+}
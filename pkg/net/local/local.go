@@ -11,6 +11,7 @@ import (
 	"github.com/ipfs/go-log"
 	"github.com/keep-network/keep-core/pkg/net"
 	"github.com/keep-network/keep-core/pkg/net/key"
+	"github.com/keep-network/keep-core/pkg/net/reputation"
 )
 
 var logger = log.Logger("keep-net-local")
@@ -30,6 +31,8 @@ type localProvider struct {
 	staticKey             *key.NetworkPublic
 	connectionManager     *localConnectionManager
 	unicastChannelManager *unicastChannelManager
+	reputation            *reputation.Service
+	link                  *link
 }
 
 func (lp *localProvider) ID() net.TransportIdentifier {
@@ -50,7 +53,7 @@ func (lp *localProvider) OnUnicastChannelOpened(
 }
 
 func (lp *localProvider) BroadcastChannelFor(name string) (net.BroadcastChannel, error) {
-	return getBroadcastChannel(name, lp.staticKey), nil
+	return getBroadcastChannel(name, lp.staticKey, lp.link), nil
 }
 
 func (lp *localProvider) Type() string {
@@ -73,6 +76,10 @@ func (lp *localProvider) BroadcastChannelForwarderFor(name string) {
 	//no-op
 }
 
+func (lp *localProvider) Reputation() net.Reputation {
+	return lp.reputation
+}
+
 // Connect returns a local instance of a net provider that does not go over the
 // network.
 func Connect() Provider {
@@ -88,11 +95,35 @@ func Connect() Provider {
 // over the network. The returned instance uses the provided network key to
 // identify network messages.
 func ConnectWithKey(staticKey *key.NetworkPublic) Provider {
+	return connect(staticKey, nil)
+}
+
+// ConnectWithLinkModel returns a local instance of net provider that does not
+// go over the network but simulates the network conditions described by the
+// provided link model for all messages it receives from its peers. Peers are
+// identified in the link model by their transport IDs, as returned by
+// Provider.ID or TransportID.
+func ConnectWithLinkModel(
+	staticKey *key.NetworkPublic,
+	model LinkModel,
+) Provider {
+	return connect(staticKey, newLink(model))
+}
+
+// TransportID returns the transport ID of the local provider using the given
+// network key. It lets link models refer to peers which are not connected yet.
+func TransportID(staticKey *key.NetworkPublic) string {
+	return createLocalIdentifier(staticKey).String()
+}
+
+func connect(staticKey *key.NetworkPublic, link *link) Provider {
 	return &localProvider{
-		id:                    randomLocalIdentifier(),
+		id:                    createLocalIdentifier(staticKey),
 		staticKey:             staticKey,
 		connectionManager:     &localConnectionManager{peers: make(map[string]*key.NetworkPublic)},
-		unicastChannelManager: newUnicastChannelManager(staticKey),
+		unicastChannelManager: newUnicastChannelManager(staticKey, link),
+		reputation:            reputation.NewService(),
+		link:                  link,
 	}
 }
 
@@ -125,6 +156,22 @@ func (lcm *localConnectionManager) GetPeerPublicKey(
 	return lcm.peers[connectedPeer], nil
 }
 
+// GetPeerCertificate always returns nil. Local peers are identified with
+// their operator keys and do not use network key certificates.
+func (lcm *localConnectionManager) GetPeerCertificate(
+	connectedPeer string,
+) (*key.Certificate, error) {
+	return nil, nil
+}
+
+// GetPeerCapabilities always returns nil. Local peers do not negotiate
+// capabilities.
+func (lcm *localConnectionManager) GetPeerCapabilities(
+	connectedPeer string,
+) *net.PeerCapabilities {
+	return nil
+}
+
 func (lcm *localConnectionManager) DisconnectPeer(connectedPeer string) {
 	lcm.mutex.Lock()
 	defer lcm.mutex.Unlock()
@@ -136,6 +183,10 @@ func (lcm *localConnectionManager) AddrStrings() []string {
 	return make([]string, 0)
 }
 
+func (lcm *localConnectionManager) Reachability() net.Reachability {
+	return net.ReachabilityPublic
+}
+
 func (lcm *localConnectionManager) IsConnected(address string) bool {
 	panic("not implemented")
 }
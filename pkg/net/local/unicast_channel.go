@@ -58,6 +58,7 @@ func (uc *unicastChannel) Send(message net.TaggedMarshaler) error {
 
 	return deliverMessage(
 		uc.senderTransportID,
+		uc.senderStaticKey,
 		uc.receiverTransportID,
 		marshalled,
 		message.Type(),
@@ -86,7 +87,14 @@ func (uc *unicastChannel) SetUnmarshaler(
 	uc.unmarshalersByType[unmarshaler().Type()] = unmarshaler
 }
 
+// RemoteCapabilities always returns nil. Local peers do not negotiate
+// capabilities.
+func (uc *unicastChannel) RemoteCapabilities() *net.PeerCapabilities {
+	return nil
+}
+
 func (uc *unicastChannel) receiveMessage(
+	senderStaticKey *key.NetworkPublic,
 	messagePayload []byte,
 	messageType string,
 ) error {
@@ -107,11 +115,13 @@ func (uc *unicastChannel) receiveMessage(
 		return err
 	}
 
+	// The channel receives messages sent by its remote peer.
 	message := internal.BasicMessage(
-		uc.senderTransportID,
+		uc.receiverTransportID,
 		unmarshaled,
 		messageType,
-		key.Marshal(uc.senderStaticKey),
+		key.Marshal(senderStaticKey),
+		nil,
 		uc.nextSeqno(),
 	)
 
@@ -16,6 +16,7 @@ var unicastChannelManagers = make(map[string]*unicastChannelManager)
 type unicastChannelManager struct {
 	transportID net.TransportIdentifier
 	staticKey   *key.NetworkPublic
+	link        *link
 
 	channelsMutex *sync.RWMutex
 	channels      map[net.TransportIdentifier]*unicastChannel
@@ -31,6 +32,7 @@ type onChannelOpenedHandler struct {
 
 func newUnicastChannelManager(
 	staticKey *key.NetworkPublic,
+	link *link,
 ) *unicastChannelManager {
 	unicastChannelManagersMutex.Lock()
 	defer unicastChannelManagersMutex.Unlock()
@@ -39,12 +41,14 @@ func newUnicastChannelManager(
 
 	existingChannelManager, ok := unicastChannelManagers[transportID.String()]
 	if ok {
+		existingChannelManager.link = link
 		return existingChannelManager
 	}
 
 	channelManager := &unicastChannelManager{
 		transportID:                  transportID,
 		staticKey:                    staticKey,
+		link:                         link,
 		channelsMutex:                &sync.RWMutex{},
 		channels:                     make(map[net.TransportIdentifier]*unicastChannel),
 		onChannelOpenedHandlersMutex: &sync.RWMutex{},
@@ -154,12 +158,17 @@ func (up *unicastChannelManager) notifyNewChannel(channel net.UnicastChannel) {
 
 func deliverMessage(
 	sender net.TransportIdentifier,
+	senderStaticKey *key.NetworkPublic,
 	receiver net.TransportIdentifier,
 	messagePayload []byte,
 	messageType string,
 ) error {
 	unicastChannelManagersMutex.RLock()
 	receiverChannelManager, ok := unicastChannelManagers[receiver.String()]
+	var receiverLink *link
+	if ok {
+		receiverLink = receiverChannelManager.link
+	}
 	unicastChannelManagersMutex.RUnlock()
 
 	if !ok {
@@ -171,5 +180,23 @@ func deliverMessage(
 		return fmt.Errorf("peer [%v] could not find channel for [%v]", receiver, sender)
 	}
 
-	return channel.receiveMessage(messagePayload, messageType)
+	if receiverLink == nil {
+		return channel.receiveMessage(senderStaticKey, messagePayload, messageType)
+	}
+
+	// Messages going through a simulated link may be delivered after this
+	// function returns so delivery errors can only be logged.
+	receiverLink.transmit(sender.String(), func() {
+		err := channel.receiveMessage(senderStaticKey, messagePayload, messageType)
+		if err != nil {
+			logger.Warningf(
+				"could not deliver message from [%v] to [%v]: [%v]",
+				sender,
+				receiver,
+				err,
+			)
+		}
+	})
+
+	return nil
 }
@@ -1,6 +1,7 @@
 package local
 
 import (
+	"bytes"
 	"context"
 	"testing"
 	"time"
@@ -264,6 +265,7 @@ func TestTimedOutHandlerNotReceiveUnicastMessage(t *testing.T) {
 	_, peer1StaticKey, _ := key.GenerateStaticNetworkKey()
 
 	peer2ID := localIdentifier("peer-0x121211")
+	_, peer2StaticKey, _ := key.GenerateStaticNetworkKey()
 
 	unicastChannel := newUnicastChannel(peer1ID, peer1StaticKey, peer2ID)
 	unicastChannel.SetUnmarshaler(func() net.TaggedUnmarshaler {
@@ -288,7 +290,7 @@ func TestTimedOutHandlerNotReceiveUnicastMessage(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	unicastChannel.receiveMessage(marshaled, message.Type())
+	unicastChannel.receiveMessage(peer2StaticKey, marshaled, message.Type())
 
 	select {
 	case <-received:
@@ -305,6 +307,52 @@ func TestTimedOutHandlerNotReceiveUnicastMessage(t *testing.T) {
 	}
 }
 
+func TestReceivedMessageSender(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	peer1Provider, peer1StaticKey := initTestProvider()
+	peer2Provider, _ := initTestProvider()
+
+	received := make(chan net.Message)
+	peer2Provider.OnUnicastChannelOpened(func(channel net.UnicastChannel) {
+		channel.SetUnmarshaler(func() net.TaggedUnmarshaler {
+			return &mockMessage{}
+		})
+		channel.Recv(ctx, func(message net.Message) {
+			received <- message
+		})
+	})
+
+	channel, err := peer1Provider.UnicastChannelWith(peer2Provider.ID())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// give some time for the channel opened notification
+	time.Sleep(50 * time.Millisecond)
+
+	if err := channel.Send(&mockMessage{"hello"}); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case message := <-received:
+		if message.TransportSenderID().String() != peer1Provider.ID().String() {
+			t.Errorf(
+				"unexpected sender\nexpected: [%v]\nactual:   [%v]",
+				peer1Provider.ID(),
+				message.TransportSenderID(),
+			)
+		}
+		if !bytes.Equal(message.SenderPublicKey(), key.Marshal(peer1StaticKey)) {
+			t.Errorf("unexpected sender public key")
+		}
+	case <-ctx.Done():
+		t.Fatal("expected message not received")
+	}
+}
+
 func initTestProvider() (net.Provider, *key.NetworkPublic) {
 	_, staticKey, _ := key.GenerateStaticNetworkKey()
 	provider := ConnectWithKey(staticKey)
@@ -1,8 +1,10 @@
 package net
 
 import (
+	"bytes"
 	"context"
 	"crypto/ecdsa"
+	"time"
 
 	"github.com/gogo/protobuf/proto"
 	"github.com/keep-network/keep-core/pkg/net/key"
@@ -19,7 +21,13 @@ type TransportIdentifier interface {
 // layer. It also carries an unmarshaled payload.
 type Message interface {
 	TransportSenderID() TransportIdentifier
+	// SenderPublicKey returns the marshaled operator public key of the
+	// sender, as authorized by the sender's network key certificate.
 	SenderPublicKey() []byte
+	// SenderCapabilities returns the capabilities negotiated with the
+	// sender during the connection handshake. It returns nil if they are not
+	// known, e.g. because the client is not directly connected to the sender.
+	SenderCapabilities() *PeerCapabilities
 
 	Payload() interface{}
 
@@ -27,6 +35,39 @@ type Message interface {
 	Seqno() uint64
 }
 
+// PeerCapabilities are the capabilities negotiated with a remote peer during
+// the connection handshake.
+type PeerCapabilities struct {
+	// ClientVersion is the version of the peer's client. It is empty if the
+	// peer does not support capability negotiation.
+	ClientVersion string
+	// ProtocolVersion is the highest network protocol version supported by
+	// both the client and the peer.
+	ProtocolVersion uint32
+	// MessageVersion is the highest protocol message version supported by
+	// both the client and the peer.
+	MessageVersion uint32
+	// Features are the optional features supported by both the client and
+	// the peer.
+	Features []string
+}
+
+// Supports returns true if the given optional feature is supported by both
+// the client and the peer. Nothing is supported if capabilities are unknown.
+func (pc *PeerCapabilities) Supports(feature string) bool {
+	if pc == nil {
+		return false
+	}
+
+	for _, supported := range pc.Features {
+		if supported == feature {
+			return true
+		}
+	}
+
+	return false
+}
+
 // TaggedMarshaler is an interface that includes the proto.Marshaler interface,
 // but also provides a string type for the marshalable object.
 type TaggedMarshaler interface {
@@ -60,11 +101,48 @@ type Provider interface {
 	ConnectionManager() ConnectionManager
 
 	// CreateTransportIdentifier creates a transport identifier based on the
-	// provided public key.
+	// provided network public key.
 	CreateTransportIdentifier(publicKey ecdsa.PublicKey) (TransportIdentifier, error)
 
 	// BroadcastChannelForwarderFor creates a message relay for given channel name.
 	BroadcastChannelForwarderFor(name string)
+
+	// Reputation returns the reputation of remote peers tracked by the
+	// provider.
+	Reputation() Reputation
+}
+
+// Misbehavior is a kind of misbehavior of a remote peer observed by the
+// client. Every reported misbehavior lowers the reputation of the peer.
+type Misbehavior string
+
+const (
+	// InvalidSignatureShare is reported when the peer sends an invalid
+	// relay entry signature share.
+	InvalidSignatureShare Misbehavior = "invalid signature share"
+	// Disqualification is reported when the peer gets disqualified during
+	// the distributed key generation.
+	Disqualification Misbehavior = "disqualification"
+	// MalformedMessage is reported when a message sent by the peer can not
+	// be unmarshaled.
+	MalformedMessage Misbehavior = "malformed message"
+	// HandshakeFailure is reported when the connection handshake with the
+	// peer fails.
+	HandshakeFailure Misbehavior = "handshake failure"
+)
+
+// Reputation accumulates misbehaviors of remote peers reported by the
+// protocol and network layers. Peers with a low reputation are temporarily
+// banned.
+type Reputation interface {
+	// ReportMisbehavior lowers the reputation of the given peer.
+	ReportMisbehavior(peer TransportIdentifier, misbehavior Misbehavior)
+	// Scores returns current reputation scores of all peers with a lowered
+	// reputation. The neutral score is 0 and it decreases with every
+	// misbehavior.
+	Scores() map[string]float64
+	// IsBanned returns true if the given peer is temporarily banned.
+	IsBanned(peer string) bool
 }
 
 // ConnectionManager is an interface which exposes peers a client is connected
@@ -73,14 +151,38 @@ type Provider interface {
 type ConnectionManager interface {
 	ConnectedPeers() []string
 	GetPeerPublicKey(connectedPeer string) (*key.NetworkPublic, error)
+	// GetPeerCertificate returns the certificate binding the connected peer's
+	// network key to its operator key. It returns nil if the peer's network
+	// key is its operator key.
+	GetPeerCertificate(connectedPeer string) (*key.Certificate, error)
+	// GetPeerCapabilities returns the capabilities negotiated with the
+	// connected peer. It returns nil if they are not known.
+	GetPeerCapabilities(connectedPeer string) *PeerCapabilities
 	DisconnectPeer(connectedPeer string)
 
 	// AddrStrings returns all listen addresses of the provider.
 	AddrStrings() []string
 
+	// Reachability returns whether the provider is reachable by remote peers.
+	Reachability() Reachability
+
 	IsConnected(address string) bool
 }
 
+// Reachability describes whether the provider is reachable by remote peers.
+type Reachability string
+
+const (
+	// ReachabilityUnknown means the reachability has not been determined yet.
+	ReachabilityUnknown Reachability = "unknown"
+	// ReachabilityPublic means remote peers can connect to the provider
+	// directly.
+	ReachabilityPublic Reachability = "public"
+	// ReachabilityPrivate means the provider is behind a NAT or a firewall
+	// and remote peers can not connect to it directly.
+	ReachabilityPrivate Reachability = "private"
+)
+
 // TaggedUnmarshaler is an interface that includes the proto.Unmarshaler
 // interface, but also provides a string type for the unmarshalable object. The
 // Type() method is expected to be invokable on a just-initialized instance of
@@ -117,6 +219,9 @@ type UnicastChannel interface {
 	// The string type associated with the unmarshaler is the result of calling
 	// Type() on a raw unmarshaler.
 	SetUnmarshaler(unmarshaler func() TaggedUnmarshaler)
+	// RemoteCapabilities returns the capabilities negotiated with the remote
+	// peer of the channel. It returns nil if they are not known.
+	RemoteCapabilities() *PeerCapabilities
 }
 
 // BroadcastChannel represents a named pubsub channel. It allows group members
@@ -129,7 +234,14 @@ type BroadcastChannel interface {
 	// Send function publishes a message m to the channel. Message m needs to
 	// conform to the marshalling interface. Message will be periodically
 	// retransmitted by the channel for the lifetime of the provided context.
-	Send(ctx context.Context, m TaggedMarshaler) error
+	// Retransmissions follow the optional strategy; if none is passed, the
+	// message is retransmitted at every tick of the channel's
+	// retransmission ticker.
+	Send(
+		ctx context.Context,
+		m TaggedMarshaler,
+		strategy ...RetransmissionStrategy,
+	) error
 	// Recv installs a message handler that will receive messages from the
 	// channel for the entire lifetime of the provided context.
 	// When the context is done, handler is automatically unregistered and
@@ -149,22 +261,79 @@ type BroadcastChannel interface {
 	// to determine if given broadcast channel message should be processed
 	// by the receivers.
 	SetFilter(filter BroadcastChannelFilter) error
+	// RequestMissedMessages redelivers messages matching the filter which
+	// have been already received by the channel and requests those the
+	// channel has missed from peers subscribed to it. Messages received by
+	// the channel are redelivered only to handlers registered after they
+	// were received, and only once. Messages obtained from peers are passed
+	// to all handlers registered at the moment of the call. Catch-up is
+	// bounded by the channel message history; messages older than the
+	// oldest message in the history are not requested.
+	RequestMissedMessages(ctx context.Context, filter MissedMessagesFilter) error
+	// Close releases the channel obtained from the provider. Every successful
+	// call to BroadcastChannelFor should be paired with a single call to
+	// Close once the channel is no longer needed. When the last reference to
+	// the channel is released, the channel leaves the underlying topic and
+	// all message handlers are unregistered. The caller must not use the
+	// channel after closing it.
+	Close() error
 }
 
+// RetransmissionStrategy decides whether a message sent to a broadcast
+// channel should be retransmitted at the given tick of the channel's
+// retransmission ticker. Ticks are counted from 1 since the message was sent.
+type RetransmissionStrategy func(tick uint64) bool
+
 // BroadcastChannelFilter represents a filter which determine if the incoming
 // message should be processed by the receivers. It takes the message author's
-// public key as its argument and returns true if the message should be
-// processed or false otherwise.
+// operator public key as its argument and returns true if the message should
+// be processed or false otherwise.
 type BroadcastChannelFilter func(*ecdsa.PublicKey) bool
 
+// MissedMessagesFilter selects messages requested with
+// RequestMissedMessages. Fields left empty match all messages.
+type MissedMessagesFilter struct {
+	// SenderPublicKey is the marshaled operator public key of the message
+	// sender.
+	SenderPublicKey []byte
+	// Type is the type of the message as registered by the protocol.
+	Type string
+	// Since excludes messages received before the given time.
+	Since time.Time
+}
+
+// Matches returns true if the message of the given sender and type, received
+// at the given time, is selected by the filter.
+func (mmf MissedMessagesFilter) Matches(
+	senderPublicKey []byte,
+	messageType string,
+	received time.Time,
+) bool {
+	if len(mmf.SenderPublicKey) > 0 &&
+		!bytes.Equal(mmf.SenderPublicKey, senderPublicKey) {
+		return false
+	}
+
+	if mmf.Type != "" && mmf.Type != messageType {
+		return false
+	}
+
+	return !received.Before(mmf.Since)
+}
+
 // Firewall represents a set of rules the remote peer has to conform to so that
 // a connection with that peer can be approved.
 type Firewall interface {
 
-	// Validate takes the remote peer public key and executes all the checks
-	// needed to decide whether the connection with the remote peer can be
-	// approved.
+	// Validate takes the remote peer public key along with the certificate
+	// binding it to the remote peer's operator key and executes all the
+	// checks needed to decide whether the connection with the remote peer can
+	// be approved. The certificate is nil if the remote peer's network key is
+	// its operator key.
 	// If expectations are not met, this function should return an error
 	// describing what is wrong.
-	Validate(remotePeerPublicKey *ecdsa.PublicKey) error
+	Validate(
+		remotePeerPublicKey *ecdsa.PublicKey,
+		certificate *key.Certificate,
+	) error
 }
@@ -0,0 +1,160 @@
+// Package reputation accumulates misbehaviors of remote peers reported by the
+// protocol and network layers into reputation scores decaying over time.
+package reputation
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/ipfs/go-log"
+
+	"github.com/keep-network/keep-core/pkg/net"
+)
+
+var logger = log.Logger("keep-net-reputation")
+
+const (
+	// DecayInterval is the interval in which scores decay by DecayFactor.
+	DecayInterval = time.Minute
+	// DecayFactor is the factor by which scores are multiplied every decay
+	// interval.
+	DecayFactor = 0.95
+	// decayToZero is the absolute score value below which the score is
+	// considered to be neutral and it is forgotten.
+	decayToZero = 0.1
+)
+
+// penalties define how much the given misbehavior lowers the reputation
+// score of the peer.
+var penalties = map[net.Misbehavior]float64{
+	net.InvalidSignatureShare: 20,
+	net.Disqualification:      50,
+	net.MalformedMessage:      5,
+	net.HandshakeFailure:      10,
+}
+
+type score struct {
+	value      float64
+	lastUpdate time.Time
+}
+
+// valueAt returns the value of the score decayed up to the given time.
+func (s *score) valueAt(now time.Time) float64 {
+	intervals := float64(now.Sub(s.lastUpdate)) / float64(DecayInterval)
+	return s.value * math.Pow(DecayFactor, intervals)
+}
+
+// Service tracks reputation scores and bans of remote peers. It implements
+// net.Reputation.
+type Service struct {
+	mutex  sync.Mutex
+	scores map[string]*score
+	bans   map[string]time.Time
+
+	now func() time.Time
+}
+
+// NewService creates a new reputation service with neutral scores of all
+// peers.
+func NewService() *Service {
+	return &Service{
+		scores: make(map[string]*score),
+		bans:   make(map[string]time.Time),
+		now:    time.Now,
+	}
+}
+
+// ReportMisbehavior lowers the reputation score of the given peer by the
+// penalty of the misbehavior.
+func (s *Service) ReportMisbehavior(
+	peer net.TransportIdentifier,
+	misbehavior net.Misbehavior,
+) {
+	penalty, ok := penalties[misbehavior]
+	if !ok {
+		logger.Warningf("unknown misbehavior [%v]", misbehavior)
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := s.now()
+	peerScore, ok := s.scores[peer.String()]
+	if !ok {
+		peerScore = &score{lastUpdate: now}
+		s.scores[peer.String()] = peerScore
+	}
+
+	peerScore.value = peerScore.valueAt(now) - penalty
+	peerScore.lastUpdate = now
+
+	logger.Infof(
+		"peer [%v] misbehaved: [%v]; current reputation score: [%.2f]",
+		peer,
+		misbehavior,
+		peerScore.value,
+	)
+}
+
+// Score returns the current reputation score of the given peer.
+func (s *Service) Score(peer string) float64 {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	peerScore, ok := s.scores[peer]
+	if !ok {
+		return 0
+	}
+
+	return peerScore.valueAt(s.now())
+}
+
+// Scores returns current reputation scores of all peers with a lowered
+// reputation. Scores which decayed to neutral are forgotten.
+func (s *Service) Scores() map[string]float64 {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := s.now()
+	scores := make(map[string]float64, len(s.scores))
+	for peer, peerScore := range s.scores {
+		value := peerScore.valueAt(now)
+		if math.Abs(value) < decayToZero {
+			delete(s.scores, peer)
+			continue
+		}
+
+		scores[peer] = value
+	}
+
+	return scores
+}
+
+// Ban bans the given peer for the given duration.
+func (s *Service) Ban(peer string, duration time.Duration) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.bans[peer] = s.now().Add(duration)
+}
+
+// IsBanned returns true if the given peer is banned. Expired bans are
+// forgotten.
+func (s *Service) IsBanned(peer string) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	bannedUntil, ok := s.bans[peer]
+	if !ok {
+		return false
+	}
+
+	if !s.now().Before(bannedUntil) {
+		delete(s.bans, peer)
+		return false
+	}
+
+	return true
+}
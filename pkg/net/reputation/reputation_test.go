@@ -0,0 +1,132 @@
+package reputation
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/keep-network/keep-core/pkg/net"
+)
+
+type testTransportIdentifier string
+
+func (tti testTransportIdentifier) String() string {
+	return string(tti)
+}
+
+func TestReportMisbehavior(t *testing.T) {
+	service := NewService()
+	now := time.Now()
+	service.now = func() time.Time { return now }
+
+	peer := testTransportIdentifier("peer-1")
+
+	service.ReportMisbehavior(peer, net.InvalidSignatureShare)
+	service.ReportMisbehavior(peer, net.MalformedMessage)
+
+	if score := service.Score(peer.String()); score != -25 {
+		t.Errorf("unexpected score\nexpected: [%v]\nactual:   [%v]", -25, score)
+	}
+
+	if score := service.Score("peer-2"); score != 0 {
+		t.Errorf("unexpected score\nexpected: [%v]\nactual:   [%v]", 0, score)
+	}
+}
+
+func TestReportUnknownMisbehavior(t *testing.T) {
+	service := NewService()
+
+	peer := testTransportIdentifier("peer-1")
+
+	service.ReportMisbehavior(peer, net.Misbehavior("unknown"))
+
+	if scores := service.Scores(); len(scores) != 0 {
+		t.Errorf("unexpected scores [%v]", scores)
+	}
+}
+
+func TestScoreDecay(t *testing.T) {
+	service := NewService()
+	now := time.Now()
+	service.now = func() time.Time { return now }
+
+	peer := testTransportIdentifier("peer-1")
+
+	service.ReportMisbehavior(peer, net.Disqualification)
+
+	now = now.Add(10 * DecayInterval)
+
+	expectedScore := -50 * math.Pow(DecayFactor, 10)
+	if score := service.Score(peer.String()); math.Abs(score-expectedScore) > 1e-9 {
+		t.Errorf(
+			"unexpected score\nexpected: [%v]\nactual:   [%v]",
+			expectedScore,
+			score,
+		)
+	}
+
+	// penalty is applied on top of the decayed score
+	service.ReportMisbehavior(peer, net.HandshakeFailure)
+
+	expectedScore -= 10
+	if score := service.Score(peer.String()); math.Abs(score-expectedScore) > 1e-9 {
+		t.Errorf(
+			"unexpected score\nexpected: [%v]\nactual:   [%v]",
+			expectedScore,
+			score,
+		)
+	}
+}
+
+func TestScoresForgetNeutralScores(t *testing.T) {
+	service := NewService()
+	now := time.Now()
+	service.now = func() time.Time { return now }
+
+	service.ReportMisbehavior(testTransportIdentifier("peer-1"), net.MalformedMessage)
+	service.ReportMisbehavior(testTransportIdentifier("peer-2"), net.Disqualification)
+
+	if scores := service.Scores(); len(scores) != 2 {
+		t.Fatalf("unexpected number of scores [%v]", len(scores))
+	}
+
+	// after 80 intervals the first score is around -0.08 and the second one
+	// is around -0.83
+	now = now.Add(80 * DecayInterval)
+
+	scores := service.Scores()
+	if len(scores) != 1 {
+		t.Fatalf("unexpected number of scores [%v]", len(scores))
+	}
+	if _, ok := scores["peer-2"]; !ok {
+		t.Errorf("score of peer-2 should not be forgotten")
+	}
+	if _, ok := service.scores["peer-1"]; ok {
+		t.Errorf("score of peer-1 should be forgotten")
+	}
+}
+
+func TestBan(t *testing.T) {
+	service := NewService()
+	now := time.Now()
+	service.now = func() time.Time { return now }
+
+	if service.IsBanned("peer-1") {
+		t.Fatal("peer should not be banned")
+	}
+
+	service.Ban("peer-1", time.Hour)
+
+	if !service.IsBanned("peer-1") {
+		t.Fatal("peer should be banned")
+	}
+
+	now = now.Add(time.Hour)
+
+	if service.IsBanned("peer-1") {
+		t.Fatal("ban should expire")
+	}
+	if _, ok := service.bans["peer-1"]; ok {
+		t.Fatal("expired ban should be forgotten")
+	}
+}
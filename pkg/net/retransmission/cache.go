@@ -0,0 +1,91 @@
+package retransmission
+
+import (
+	"container/list"
+	"sync"
+)
+
+const (
+	// cacheTTL is the number of ticks after which a message is removed from
+	// the deduplication cache if no retransmission of it has been received in
+	// the meantime. It has to be longer than the interval between two
+	// retransmissions of the same message.
+	cacheTTL = 20
+	// cacheSize is the maximum number of messages kept in the deduplication
+	// cache. Once it is exceeded, the least recently seen messages are
+	// removed.
+	cacheSize = 10000
+)
+
+// cache remembers IDs of seen messages so that their retransmissions can be
+// filtered out. Every ID is kept until no retransmission of the message has
+// been seen for the TTL of the cache, measured in ticks, or until the cache
+// exceeds its size.
+type cache struct {
+	mutex sync.Mutex
+
+	size int
+	ttl  uint64
+
+	currentTick uint64
+	// entries are ordered from the most recently to the least recently seen.
+	entries  *list.List
+	elements map[string]*list.Element
+}
+
+type cacheEntry struct {
+	id       string
+	lastSeen uint64
+}
+
+func newCache(size int, ttl uint64) *cache {
+	return &cache{
+		size:     size,
+		ttl:      ttl,
+		entries:  list.New(),
+		elements: make(map[string]*list.Element),
+	}
+}
+
+// seen records the message ID and returns true if it has been already
+// recorded before.
+func (c *cache) seen(id string) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if element, ok := c.elements[id]; ok {
+		element.Value.(*cacheEntry).lastSeen = c.currentTick
+		c.entries.MoveToFront(element)
+		return true
+	}
+
+	c.elements[id] = c.entries.PushFront(&cacheEntry{id, c.currentTick})
+
+	if c.entries.Len() > c.size {
+		c.remove(c.entries.Back())
+	}
+
+	return false
+}
+
+// tick advances the cache time and removes IDs which have not been seen for
+// the TTL of the cache.
+func (c *cache) tick() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.currentTick++
+
+	for element := c.entries.Back(); element != nil; element = c.entries.Back() {
+		if element.Value.(*cacheEntry).lastSeen+c.ttl > c.currentTick {
+			return
+		}
+
+		c.remove(element)
+	}
+}
+
+func (c *cache) remove(element *list.Element) {
+	c.entries.Remove(element)
+	delete(c.elements, element.Value.(*cacheEntry).id)
+}
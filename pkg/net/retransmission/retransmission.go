@@ -7,7 +7,6 @@ package retransmission
 import (
 	"context"
 	"fmt"
-	"sync"
 
 	"github.com/ipfs/go-log"
 
@@ -17,17 +16,31 @@ import (
 var logger = log.Logger("keep-net-retransmission")
 
 // ScheduleRetransmissions takes the provided message and retransmits it
-// for every new tick received from the provided Ticker for the entire lifetime
-// of the Context calling the provided retransmit function. The retransmit
-// function has to guarantee that every call from this function sends a message
-// with the same sequence number.
+// according to the optional strategy for ticks received from the provided
+// Ticker for the entire lifetime of the Context calling the provided
+// retransmit function. If no strategy is passed, the message is retransmitted
+// at every tick. The retransmit function has to guarantee that every call
+// from this function sends a message with the same sequence number.
 func ScheduleRetransmissions(
 	ctx context.Context,
 	ticker *Ticker,
 	retransmit func() error,
+	strategy ...net.RetransmissionStrategy,
 ) {
+	shouldRetransmit := Fixed()
+	if len(strategy) > 0 && strategy[0] != nil {
+		shouldRetransmit = strategy[0]
+	}
+
 	go func() {
+		var tick uint64
+
 		ticker.onTick(ctx, func() {
+			tick++
+			if !shouldRetransmit(tick) {
+				return
+			}
+
 			go func() {
 				if err := retransmit(); err != nil {
 					logger.Errorf("could not retransmit message: [%v]", err)
@@ -48,9 +61,18 @@ func ScheduleRetransmissions(
 // number. Two messages with the same sender ID and sequence number are
 // considered the same. Handler can not be reused between channels if sequence
 // number of message is local for channel.
-func WithRetransmissionSupport(delegate func(m net.Message)) func(m net.Message) {
-	mutex := &sync.Mutex{}
-	cache := make(map[string]bool)
+//
+// Seen messages are remembered for the lifetime of the provided context as
+// long as their retransmissions keep arriving. A message is forgotten once no
+// retransmission of it has been received for cacheTTL ticks of the provided
+// Ticker or when more than cacheSize other messages have been seen since.
+func WithRetransmissionSupport(
+	ctx context.Context,
+	ticker *Ticker,
+	delegate func(m net.Message),
+) func(m net.Message) {
+	cache := newCache(cacheSize, cacheTTL)
+	ticker.onTick(ctx, cache.tick)
 
 	return func(message net.Message) {
 		messageID := fmt.Sprintf(
@@ -59,14 +81,7 @@ func WithRetransmissionSupport(delegate func(m net.Message)) func(m net.Message)
 			message.Seqno(),
 		)
 
-		mutex.Lock()
-		_, seen := cache[messageID]
-		if !seen {
-			cache[messageID] = true
-		}
-		mutex.Unlock()
-
-		if !seen {
+		if !cache.seen(messageID) {
 			delegate(message)
 		}
 	}
@@ -2,6 +2,7 @@ package retransmission
 
 import (
 	"context"
+	"reflect"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -33,9 +34,13 @@ func TestRetransmitExpectedNumberOfTimes(t *testing.T) {
 func TestHandlerReceiveUniqueMessages(t *testing.T) {
 	var received []net.Message
 
-	handler := WithRetransmissionSupport(func(message net.Message) {
-		received = append(received, message)
-	})
+	handler := WithRetransmissionSupport(
+		context.Background(),
+		NewTicker(make(chan uint64)),
+		func(message net.Message) {
+			received = append(received, message)
+		},
+	)
 
 	handler(&mockNetworkMessage{senderID: "a", seqno: 1})
 	handler(&mockNetworkMessage{senderID: "a", seqno: 2})
@@ -54,9 +59,13 @@ func TestHandlerReceiveUniqueMessages(t *testing.T) {
 func TestHandlerReceiveRetransmissions(t *testing.T) {
 	var received []net.Message
 
-	handler := WithRetransmissionSupport(func(message net.Message) {
-		received = append(received, message)
-	})
+	handler := WithRetransmissionSupport(
+		context.Background(),
+		NewTicker(make(chan uint64)),
+		func(message net.Message) {
+			received = append(received, message)
+		},
+	)
 
 	handler(&mockNetworkMessage{senderID: "a", seqno: 1})
 	handler(&mockNetworkMessage{senderID: "a", seqno: 2})
@@ -74,6 +83,150 @@ func TestHandlerReceiveRetransmissions(t *testing.T) {
 	}
 }
 
+func TestRetransmitWithStrategy(t *testing.T) {
+	var tests = map[string]struct {
+		strategy              net.RetransmissionStrategy
+		ticks                 uint64
+		expectedRetransmitted []uint64
+	}{
+		"fixed": {
+			strategy:              Fixed(),
+			ticks:                 5,
+			expectedRetransmitted: []uint64{1, 2, 3, 4, 5},
+		},
+		"stop after 3": {
+			strategy:              StopAfter(3),
+			ticks:                 5,
+			expectedRetransmitted: []uint64{1, 2, 3},
+		},
+		"exponential backoff": {
+			strategy:              ExponentialBackoff(),
+			ticks:                 64,
+			expectedRetransmitted: []uint64{1, 2, 4, 8, 16, 32, 48, 64},
+		},
+	}
+
+	for testName, test := range tests {
+		test := test
+		t.Run(testName, func(t *testing.T) {
+			var retransmitted []uint64
+			for tick := uint64(1); tick <= test.ticks; tick++ {
+				if test.strategy(tick) {
+					retransmitted = append(retransmitted, tick)
+				}
+			}
+
+			if !reflect.DeepEqual(test.expectedRetransmitted, retransmitted) {
+				t.Errorf(
+					"unexpected retransmissions\nexpected: %v\nactual:   %v",
+					test.expectedRetransmitted,
+					retransmitted,
+				)
+			}
+		})
+	}
+}
+
+func TestScheduleRetransmissionsWithStrategy(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ticks := make(chan uint64)
+	ticker := NewTicker(ticks)
+
+	var retransmissionsCount uint64
+	ScheduleRetransmissions(
+		ctx,
+		ticker,
+		func() error {
+			atomic.AddUint64(&retransmissionsCount, 1)
+			return nil
+		},
+		StopAfter(2),
+	)
+
+	// Let the retransmissions get scheduled before ticking.
+	time.Sleep(10 * time.Millisecond)
+	for i := uint64(1); i <= 5; i++ {
+		ticks <- i
+	}
+	time.Sleep(10 * time.Millisecond)
+
+	if count := atomic.LoadUint64(&retransmissionsCount); count != 2 {
+		t.Errorf("expected [2] retransmissions, has [%v]", count)
+	}
+}
+
+func TestHandlerForgetsExpiredMessages(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ticks := make(chan uint64)
+	ticker := NewTicker(ticks)
+
+	var received []net.Message
+	handler := WithRetransmissionSupport(
+		ctx,
+		ticker,
+		func(message net.Message) {
+			received = append(received, message)
+		},
+	)
+
+	handler(&mockNetworkMessage{senderID: "a", seqno: 1})
+	handler(&mockNetworkMessage{senderID: "b", seqno: 1})
+
+	// The first message keeps being retransmitted so it is not forgotten.
+	for i := uint64(1); i <= cacheTTL; i++ {
+		ticks <- i
+		handler(&mockNetworkMessage{senderID: "a", seqno: 1})
+	}
+	// Let the ticker handle the last tick.
+	time.Sleep(10 * time.Millisecond)
+
+	handler(&mockNetworkMessage{senderID: "a", seqno: 1})
+	handler(&mockNetworkMessage{senderID: "b", seqno: 1})
+
+	if len(received) != 3 {
+		t.Fatalf(
+			"unexpected number of accepted messages\nactual:   [%v]\nexpected: [3]",
+			len(received),
+		)
+	}
+}
+
+func TestCacheSize(t *testing.T) {
+	cache := newCache(2, cacheTTL)
+
+	cache.seen("a")
+	cache.seen("b")
+	cache.seen("a")
+	cache.seen("c") // b is the least recently seen one
+
+	if !cache.seen("a") {
+		t.Errorf("expected [a] to be remembered")
+	}
+	if cache.seen("b") {
+		t.Errorf("expected [b] to be forgotten")
+	}
+}
+
+func TestCacheTTL(t *testing.T) {
+	cache := newCache(cacheSize, 2)
+
+	cache.seen("a")
+	cache.tick()
+	cache.seen("b")
+	cache.tick()
+
+	if cache.seen("a") {
+		t.Errorf("expected [a] to be forgotten")
+	}
+	if !cache.seen("b") {
+		t.Errorf("expected [b] to be remembered")
+	}
+}
+
 type mockNetworkMessage struct {
 	senderID string
 	seqno    uint64
@@ -95,6 +248,10 @@ func (mnm *mockNetworkMessage) SenderPublicKey() []byte {
 	panic("not implemented")
 }
 
+func (mnm *mockNetworkMessage) SenderCapabilities() *net.PeerCapabilities {
+	panic("not implemented")
+}
+
 func (mnm *mockNetworkMessage) Seqno() uint64 {
 	return mnm.seqno
 }
@@ -0,0 +1,37 @@
+package retransmission
+
+import "github.com/keep-network/keep-core/pkg/net"
+
+// maxBackoffInterval is the maximum number of ticks between two
+// retransmissions of the ExponentialBackoff strategy. It is shorter than
+// cacheTTL so that receivers keep recognizing retransmissions of messages
+// they have already seen.
+const maxBackoffInterval = 16
+
+// Fixed retransmits the message at every tick. It is the default strategy.
+func Fixed() net.RetransmissionStrategy {
+	return func(tick uint64) bool {
+		return true
+	}
+}
+
+// ExponentialBackoff retransmits the message at ticks 1, 2, 4, 8 and so on,
+// doubling the interval between retransmissions until it reaches
+// maxBackoffInterval. From then on, the message is retransmitted every
+// maxBackoffInterval ticks.
+func ExponentialBackoff() net.RetransmissionStrategy {
+	return func(tick uint64) bool {
+		if tick <= maxBackoffInterval {
+			return tick&(tick-1) == 0
+		}
+		return tick%maxBackoffInterval == 0
+	}
+}
+
+// StopAfter retransmits the message at every tick until it has been
+// retransmitted the given number of times.
+func StopAfter(retransmissions uint64) net.RetransmissionStrategy {
+	return func(tick uint64) bool {
+		return tick <= retransmissions
+	}
+}
@@ -10,7 +10,12 @@ import (
 type Ticker struct {
 	ticks         <-chan uint64
 	handlersMutex sync.Mutex
-	handlers      map[context.Context]func()
+	handlers      []*tickHandler
+}
+
+type tickHandler struct {
+	ctx    context.Context
+	handle func()
 }
 
 // NewTicker creates and starts a new Ticker for the provided channel.
@@ -19,7 +24,7 @@ type Ticker struct {
 func NewTicker(ticks <-chan uint64) *Ticker {
 	ticker := &Ticker{
 		ticks:    ticks,
-		handlers: make(map[context.Context]func()),
+		handlers: make([]*tickHandler, 0),
 	}
 
 	go ticker.start()
@@ -56,25 +61,30 @@ func (t *Ticker) start() {
 	for range t.ticks {
 		t.handlersMutex.Lock()
 
-		for ctx, handler := range t.handlers {
-			if ctx.Err() != nil {
-				delete(t.handlers, ctx)
+		active := t.handlers[:0]
+		for _, handler := range t.handlers {
+			if handler.ctx.Err() != nil {
 				continue
 			}
 
-			handler()
+			handler.handle()
+			active = append(active, handler)
 		}
+		t.handlers = active
 
 		t.handlersMutex.Unlock()
 	}
 
-	for ctx := range t.handlers {
-		delete(t.handlers, ctx)
-	}
+	t.handlersMutex.Lock()
+	t.handlers = nil
+	t.handlersMutex.Unlock()
 }
 
+// onTick registers the handler called for every tick for the entire lifetime
+// of the provided context. Any number of handlers can be registered with the
+// same context.
 func (t *Ticker) onTick(ctx context.Context, handler func()) {
 	t.handlersMutex.Lock()
-	t.handlers[ctx] = handler
+	t.handlers = append(t.handlers, &tickHandler{ctx, handler})
 	t.handlersMutex.Unlock()
 }
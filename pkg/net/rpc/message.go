@@ -0,0 +1,48 @@
+package rpc
+
+import (
+	"github.com/keep-network/keep-core/pkg/net/rpc/gen/pb"
+)
+
+// message is a request or a response exchanged by RPC channels over
+// a unicast channel.
+type message struct {
+	requestID   uint64
+	response    bool
+	payloadType string
+	payload     []byte
+	err         string
+}
+
+// Type returns a string describing a message's type.
+func (m *message) Type() string {
+	return "net/rpc/message"
+}
+
+// Marshal converts this message to a byte array suitable for network
+// communication.
+func (m *message) Marshal() ([]byte, error) {
+	return (&pb.RPCMessage{
+		RequestID:   m.requestID,
+		Response:    m.response,
+		PayloadType: m.payloadType,
+		Payload:     m.payload,
+		Error:       m.err,
+	}).Marshal()
+}
+
+// Unmarshal converts a byte array produced by Marshal to a message.
+func (m *message) Unmarshal(bytes []byte) error {
+	pbMessage := pb.RPCMessage{}
+	if err := pbMessage.Unmarshal(bytes); err != nil {
+		return err
+	}
+
+	m.requestID = pbMessage.RequestID
+	m.response = pbMessage.Response
+	m.payloadType = pbMessage.PayloadType
+	m.payload = pbMessage.Payload
+	m.err = pbMessage.Error
+
+	return nil
+}
@@ -0,0 +1,304 @@
+// Package rpc implements request/response calls on top of unicast channels.
+// Requests are correlated with their responses so that any number of calls
+// can be in flight at the same time.
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ipfs/go-log"
+
+	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/net/internal"
+)
+
+var logger = log.Logger("keep-net-rpc")
+
+// DefaultTimeout is the time after which a call is abandoned if the context
+// passed to Call has no deadline. It is also the time a handler has to
+// handle a single request.
+const DefaultTimeout = 10 * time.Second
+
+// Handler handles a request received from the remote peer and returns the
+// response sent back to the caller. If the handler returns an error, the
+// caller receives a RemoteError with the error message.
+type Handler func(
+	ctx context.Context,
+	request net.Message,
+) (net.TaggedMarshaler, error)
+
+// RemoteError is returned by Call when the remote peer could not handle the
+// request.
+type RemoteError struct {
+	Message string
+}
+
+func (re *RemoteError) Error() string {
+	return fmt.Sprintf(
+		"remote peer could not handle the request: [%v]",
+		re.Message,
+	)
+}
+
+type requestHandler struct {
+	unmarshaler func() net.TaggedUnmarshaler
+	handle      Handler
+}
+
+// channels holds RPC channels of unicast channels so that there is only one
+// RPC channel for the given unicast channel at a time; requests the channel
+// has no handler for are responded with an error.
+var (
+	channelsMutex sync.Mutex
+	channels      = make(map[net.UnicastChannel]*Channel)
+)
+
+// Channel makes calls to the remote peer of the unicast channel and handles
+// calls made by that peer.
+type Channel struct {
+	ctx            context.Context
+	unicastChannel net.UnicastChannel
+
+	lastRequestID uint64
+
+	pendingCallsMutex sync.Mutex
+	pendingCalls      map[uint64]chan *message
+
+	handlersMutex sync.RWMutex
+	handlers      map[string]*requestHandler
+}
+
+// NewChannel creates an RPC channel on top of the given unicast channel. The
+// channel makes and handles calls for the entire lifetime of the provided
+// context. If the unicast channel already has an RPC channel, that channel is
+// returned so that independent users of the unicast channel can handle
+// requests of different types. Its lifetime is bound to the context it has
+// been created with.
+func NewChannel(
+	ctx context.Context,
+	unicastChannel net.UnicastChannel,
+) *Channel {
+	channelsMutex.Lock()
+	defer channelsMutex.Unlock()
+
+	if channel, ok := channels[unicastChannel]; ok && channel.ctx.Err() == nil {
+		return channel
+	}
+
+	channel := &Channel{
+		ctx:            ctx,
+		unicastChannel: unicastChannel,
+		pendingCalls:   make(map[uint64]chan *message),
+		handlers:       make(map[string]*requestHandler),
+	}
+
+	unicastChannel.SetUnmarshaler(func() net.TaggedUnmarshaler {
+		return &message{}
+	})
+	unicastChannel.Recv(ctx, channel.handleMessage)
+
+	channels[unicastChannel] = channel
+	go func() {
+		<-ctx.Done()
+
+		channelsMutex.Lock()
+		if channels[unicastChannel] == channel {
+			delete(channels, unicastChannel)
+		}
+		channelsMutex.Unlock()
+	}()
+
+	return channel
+}
+
+// Handle registers the handler for requests of the type returned by the
+// unmarshaler. Registering a handler for a type which already has one
+// replaces the previous handler.
+func (c *Channel) Handle(
+	unmarshaler func() net.TaggedUnmarshaler,
+	handler Handler,
+) {
+	c.handlersMutex.Lock()
+	defer c.handlersMutex.Unlock()
+
+	c.handlers[unmarshaler().Type()] = &requestHandler{unmarshaler, handler}
+}
+
+// Call sends the request to the remote peer and waits for the response which
+// is unmarshaled into the passed response. Call returns an error if the
+// response does not arrive before the context is done or, if the context has
+// no deadline, within DefaultTimeout.
+func (c *Channel) Call(
+	ctx context.Context,
+	request net.TaggedMarshaler,
+	response net.TaggedUnmarshaler,
+) error {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, DefaultTimeout)
+		defer cancel()
+	}
+
+	payload, err := request.Marshal()
+	if err != nil {
+		return fmt.Errorf("could not marshal request: [%v]", err)
+	}
+
+	requestID := atomic.AddUint64(&c.lastRequestID, 1)
+	responseChannel := make(chan *message, 1)
+
+	c.pendingCallsMutex.Lock()
+	c.pendingCalls[requestID] = responseChannel
+	c.pendingCallsMutex.Unlock()
+
+	defer func() {
+		c.pendingCallsMutex.Lock()
+		delete(c.pendingCalls, requestID)
+		c.pendingCallsMutex.Unlock()
+	}()
+
+	err = c.unicastChannel.Send(&message{
+		requestID:   requestID,
+		payloadType: request.Type(),
+		payload:     payload,
+	})
+	if err != nil {
+		return fmt.Errorf("could not send request: [%v]", err)
+	}
+
+	select {
+	case responseMessage := <-responseChannel:
+		if responseMessage.err != "" {
+			return &RemoteError{responseMessage.err}
+		}
+
+		if responseMessage.payloadType != response.Type() {
+			return fmt.Errorf(
+				"unexpected response type [%v]; expected [%v]",
+				responseMessage.payloadType,
+				response.Type(),
+			)
+		}
+
+		if err := response.Unmarshal(responseMessage.payload); err != nil {
+			return fmt.Errorf("could not unmarshal response: [%v]", err)
+		}
+
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf(
+			"no response for request [%v]: [%v]",
+			request.Type(),
+			ctx.Err(),
+		)
+	case <-c.ctx.Done():
+		return fmt.Errorf("channel is closed")
+	}
+}
+
+func (c *Channel) handleMessage(netMessage net.Message) {
+	rpcMessage, ok := netMessage.Payload().(*message)
+	if !ok {
+		return
+	}
+
+	if rpcMessage.response {
+		c.handleResponse(rpcMessage)
+		return
+	}
+
+	// Requests are handled concurrently so a slow handler does not block
+	// other requests and responses.
+	go c.handleRequest(netMessage, rpcMessage)
+}
+
+func (c *Channel) handleResponse(response *message) {
+	c.pendingCallsMutex.Lock()
+	responseChannel, ok := c.pendingCalls[response.requestID]
+	delete(c.pendingCalls, response.requestID)
+	c.pendingCallsMutex.Unlock()
+
+	if !ok {
+		logger.Debugf(
+			"ignoring response for unknown request [%v]",
+			response.requestID,
+		)
+		return
+	}
+
+	responseChannel <- response
+}
+
+func (c *Channel) handleRequest(netMessage net.Message, request *message) {
+	response := &message{
+		requestID: request.requestID,
+		response:  true,
+	}
+
+	responsePayload, err := c.callHandler(netMessage, request)
+	if err == nil {
+		response.payloadType = responsePayload.Type()
+		response.payload, err = responsePayload.Marshal()
+	}
+	if err != nil {
+		logger.Warningf(
+			"could not handle request [%v] of type [%v] from [%v]: [%v]",
+			request.requestID,
+			request.payloadType,
+			netMessage.TransportSenderID(),
+			err,
+		)
+		response.payloadType = ""
+		response.payload = nil
+		response.err = err.Error()
+	}
+
+	if err := c.unicastChannel.Send(response); err != nil {
+		logger.Warningf(
+			"could not send response for request [%v] to [%v]: [%v]",
+			request.requestID,
+			netMessage.TransportSenderID(),
+			err,
+		)
+	}
+}
+
+func (c *Channel) callHandler(
+	netMessage net.Message,
+	request *message,
+) (net.TaggedMarshaler, error) {
+	c.handlersMutex.RLock()
+	handler, ok := c.handlers[request.payloadType]
+	c.handlersMutex.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf(
+			"no handler for request type [%v]",
+			request.payloadType,
+		)
+	}
+
+	payload := handler.unmarshaler()
+	if err := payload.Unmarshal(request.payload); err != nil {
+		return nil, fmt.Errorf("could not unmarshal request: [%v]", err)
+	}
+
+	ctx, cancel := context.WithTimeout(c.ctx, DefaultTimeout)
+	defer cancel()
+
+	return handler.handle(
+		ctx,
+		internal.BasicMessage(
+			netMessage.TransportSenderID(),
+			payload,
+			request.payloadType,
+			netMessage.SenderPublicKey(),
+			netMessage.SenderCapabilities(),
+			netMessage.Seqno(),
+		),
+	)
+}
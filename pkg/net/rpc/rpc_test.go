@@ -0,0 +1,290 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/net/key"
+	"github.com/keep-network/keep-core/pkg/net/local"
+)
+
+func TestCall(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	caller := newTestChannels(ctx, t, func(callee *Channel) {
+		callee.Handle(newTestRequest, upperCaseHandler)
+	})
+
+	response := &testResponse{}
+	err := caller.Call(ctx, &testRequest{"hello"}, response)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if response.content != "HELLO" {
+		t.Errorf(
+			"unexpected response\nexpected: [%v]\nactual:   [%v]",
+			"HELLO",
+			response.content,
+		)
+	}
+}
+
+func TestConcurrentCalls(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	caller := newTestChannels(ctx, t, func(callee *Channel) {
+		callee.Handle(
+			newTestRequest,
+			func(
+				ctx context.Context,
+				request net.Message,
+			) (net.TaggedMarshaler, error) {
+				// Earlier requests are handled longer so that responses
+				// arrive in the reverse order.
+				content := request.Payload().(*testRequest).content
+				time.Sleep(time.Duration(20-len(content)) * 10 * time.Millisecond)
+				return upperCaseHandler(ctx, request)
+			},
+		)
+	})
+
+	var wg sync.WaitGroup
+	errors := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(content string) {
+			defer wg.Done()
+
+			response := &testResponse{}
+			if err := caller.Call(ctx, &testRequest{content}, response); err != nil {
+				errors <- err
+				return
+			}
+
+			if response.content != strings.ToUpper(content) {
+				errors <- fmt.Errorf(
+					"unexpected response [%v] for request [%v]",
+					response.content,
+					content,
+				)
+			}
+		}(strings.Repeat("a", i+1))
+	}
+
+	wg.Wait()
+	close(errors)
+
+	for err := range errors {
+		t.Error(err)
+	}
+}
+
+func TestCallTimeout(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	caller := newTestChannels(ctx, t, func(callee *Channel) {
+		callee.Handle(
+			newTestRequest,
+			func(
+				ctx context.Context,
+				request net.Message,
+			) (net.TaggedMarshaler, error) {
+				<-ctx.Done()
+				return nil, ctx.Err()
+			},
+		)
+	})
+
+	callCtx, cancelCall := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancelCall()
+
+	err := caller.Call(callCtx, &testRequest{"hello"}, &testResponse{})
+	if err == nil {
+		t.Fatal("expected call timeout")
+	}
+	if _, ok := err.(*RemoteError); ok {
+		t.Fatalf("unexpected remote error [%v]", err)
+	}
+}
+
+func TestRemoteError(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	caller := newTestChannels(ctx, t, func(callee *Channel) {
+		callee.Handle(
+			newTestRequest,
+			func(
+				ctx context.Context,
+				request net.Message,
+			) (net.TaggedMarshaler, error) {
+				return nil, fmt.Errorf("not today")
+			},
+		)
+	})
+
+	err := caller.Call(ctx, &testRequest{"hello"}, &testResponse{})
+
+	remoteError, ok := err.(*RemoteError)
+	if !ok {
+		t.Fatalf("expected remote error; has [%v]", err)
+	}
+	if remoteError.Message != "not today" {
+		t.Errorf("unexpected remote error message [%v]", remoteError.Message)
+	}
+}
+
+func TestCallWithNoHandler(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	caller := newTestChannels(ctx, t, func(callee *Channel) {})
+
+	err := caller.Call(ctx, &testRequest{"hello"}, &testResponse{})
+
+	if _, ok := err.(*RemoteError); !ok {
+		t.Fatalf("expected remote error; has [%v]", err)
+	}
+}
+
+func TestUnexpectedResponseType(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	caller := newTestChannels(ctx, t, func(callee *Channel) {
+		callee.Handle(
+			newTestRequest,
+			func(
+				ctx context.Context,
+				request net.Message,
+			) (net.TaggedMarshaler, error) {
+				return request.Payload().(*testRequest), nil
+			},
+		)
+	})
+
+	err := caller.Call(ctx, &testRequest{"hello"}, &testResponse{})
+	if err == nil {
+		t.Fatal("expected unexpected response type error")
+	}
+}
+
+func TestSharedChannel(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	caller := newTestChannels(ctx, t, func(callee *Channel) {
+		shared := NewChannel(ctx, callee.unicastChannel)
+		if shared != callee {
+			t.Error("expected the existing channel of the unicast channel")
+		}
+
+		shared.Handle(newTestRequest, upperCaseHandler)
+	})
+
+	response := &testResponse{}
+	err := caller.Call(ctx, &testRequest{"hello"}, response)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if response.content != "HELLO" {
+		t.Errorf("unexpected response [%v]", response.content)
+	}
+}
+
+// newTestChannels connects two local providers and returns the RPC channel
+// of the caller once the RPC channel of the callee has been set up by the
+// passed function.
+func newTestChannels(
+	ctx context.Context,
+	t *testing.T,
+	setupCallee func(callee *Channel),
+) *Channel {
+	_, callerKey, err := key.GenerateStaticNetworkKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, calleeKey, err := key.GenerateStaticNetworkKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	callerProvider := local.ConnectWithKey(callerKey)
+	calleeProvider := local.ConnectWithKey(calleeKey)
+
+	calleeReady := make(chan struct{})
+	calleeProvider.OnUnicastChannelOpened(func(channel net.UnicastChannel) {
+		setupCallee(NewChannel(ctx, channel))
+		close(calleeReady)
+	})
+
+	unicastChannel, err := callerProvider.UnicastChannelWith(calleeProvider.ID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	caller := NewChannel(ctx, unicastChannel)
+
+	select {
+	case <-calleeReady:
+	case <-ctx.Done():
+		t.Fatal("callee channel not opened")
+	}
+
+	return caller
+}
+
+func upperCaseHandler(
+	ctx context.Context,
+	request net.Message,
+) (net.TaggedMarshaler, error) {
+	content := request.Payload().(*testRequest).content
+	return &testResponse{strings.ToUpper(content)}, nil
+}
+
+type testRequest struct {
+	content string
+}
+
+func newTestRequest() net.TaggedUnmarshaler {
+	return &testRequest{}
+}
+
+func (tr *testRequest) Type() string {
+	return "test_request"
+}
+
+func (tr *testRequest) Marshal() ([]byte, error) {
+	return []byte(tr.content), nil
+}
+
+func (tr *testRequest) Unmarshal(bytes []byte) error {
+	tr.content = string(bytes)
+	return nil
+}
+
+type testResponse struct {
+	content string
+}
+
+func (tr *testResponse) Type() string {
+	return "test_response"
+}
+
+func (tr *testResponse) Marshal() ([]byte, error) {
+	return []byte(tr.content), nil
+}
+
+func (tr *testResponse) Unmarshal(bytes []byte) error {
+	tr.content = string(bytes)
+	return nil
+}
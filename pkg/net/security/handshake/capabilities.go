@@ -0,0 +1,134 @@
+package handshake
+
+import (
+	"fmt"
+	"sort"
+)
+
+// LegacyVersion is the protocol and message version assumed for peers which
+// do not support capability negotiation.
+const LegacyVersion = 1
+
+// VersionRange is the range of versions supported by a handshake party,
+// inclusive on both ends.
+type VersionRange struct {
+	Min uint32
+	Max uint32
+}
+
+// orLegacy returns the legacy version range if the range is not set, meaning
+// the party does not support capability negotiation.
+func (vr VersionRange) orLegacy() VersionRange {
+	if vr.Min == 0 && vr.Max == 0 {
+		return VersionRange{LegacyVersion, LegacyVersion}
+	}
+	return vr
+}
+
+// highestCommon returns the highest version from both ranges. The second
+// returned value is false if the ranges do not overlap.
+func (vr VersionRange) highestCommon(other VersionRange) (uint32, bool) {
+	min, max := vr.Min, vr.Max
+	if other.Min > min {
+		min = other.Min
+	}
+	if other.Max < max {
+		max = other.Max
+	}
+
+	if min > max {
+		return 0, false
+	}
+
+	return max, true
+}
+
+// Capabilities describes the client version, the supported protocol and
+// message versions, and the optional features of a handshake party.
+type Capabilities struct {
+	ClientVersion    string
+	ProtocolVersions VersionRange
+	MessageVersions  VersionRange
+	Features         []string
+}
+
+// Negotiated is the outcome of the capability negotiation between two
+// handshake parties.
+type Negotiated struct {
+	// RemoteClientVersion is the version of the remote party's client. It is
+	// empty if the remote party does not support capability negotiation.
+	RemoteClientVersion string
+	// ProtocolVersion is the highest protocol version supported by both
+	// parties.
+	ProtocolVersion uint32
+	// MessageVersion is the highest message version supported by both
+	// parties.
+	MessageVersion uint32
+	// Features are the optional features supported by both parties.
+	Features []string
+}
+
+// Negotiate determines the highest protocol and message versions and the
+// optional features supported by both the local and the remote party.
+// A party which does not support capability negotiation is assumed to
+// support only the legacy versions and no optional features. An error is
+// returned if the parties have no protocol or message version in common.
+func Negotiate(local, remote *Capabilities) (*Negotiated, error) {
+	if local == nil {
+		local = &Capabilities{}
+	}
+	if remote == nil {
+		remote = &Capabilities{}
+	}
+
+	localProtocolVersions := local.ProtocolVersions.orLegacy()
+	remoteProtocolVersions := remote.ProtocolVersions.orLegacy()
+	protocolVersion, ok := localProtocolVersions.highestCommon(
+		remoteProtocolVersions,
+	)
+	if !ok {
+		return nil, fmt.Errorf(
+			"no common protocol version; local: [%v-%v], remote: [%v-%v]",
+			localProtocolVersions.Min,
+			localProtocolVersions.Max,
+			remoteProtocolVersions.Min,
+			remoteProtocolVersions.Max,
+		)
+	}
+
+	localMessageVersions := local.MessageVersions.orLegacy()
+	remoteMessageVersions := remote.MessageVersions.orLegacy()
+	messageVersion, ok := localMessageVersions.highestCommon(
+		remoteMessageVersions,
+	)
+	if !ok {
+		return nil, fmt.Errorf(
+			"no common message version; local: [%v-%v], remote: [%v-%v]",
+			localMessageVersions.Min,
+			localMessageVersions.Max,
+			remoteMessageVersions.Min,
+			remoteMessageVersions.Max,
+		)
+	}
+
+	remoteFeatures := make(map[string]bool, len(remote.Features))
+	for _, feature := range remote.Features {
+		remoteFeatures[feature] = true
+	}
+
+	features := make([]string, 0)
+	for _, feature := range local.Features {
+		if remoteFeatures[feature] {
+			features = append(features, feature)
+			delete(remoteFeatures, feature)
+		}
+	}
+	sort.Strings(features)
+
+	return &Negotiated{
+		RemoteClientVersion: remote.ClientVersion,
+		ProtocolVersion:     protocolVersion,
+		MessageVersion:      messageVersion,
+		Features:            features,
+	}, nil
+}
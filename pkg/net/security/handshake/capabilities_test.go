@@ -0,0 +1,96 @@
+package handshake
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNegotiate(t *testing.T) {
+	var tests = map[string]struct {
+		local              *Capabilities
+		remote             *Capabilities
+		expectedNegotiated *Negotiated
+		expectedError      string
+	}{
+		"highest common versions and common features": {
+			local: &Capabilities{
+				ClientVersion:    "v1.1.0",
+				ProtocolVersions: VersionRange{1, 3},
+				MessageVersions:  VersionRange{1, 1},
+				Features:         []string{"c", "a", "b"},
+			},
+			remote: &Capabilities{
+				ClientVersion:    "v1.2.0",
+				ProtocolVersions: VersionRange{2, 5},
+				MessageVersions:  VersionRange{1, 2},
+				Features:         []string{"b", "c", "d"},
+			},
+			expectedNegotiated: &Negotiated{
+				RemoteClientVersion: "v1.2.0",
+				ProtocolVersion:     3,
+				MessageVersion:      1,
+				Features:            []string{"b", "c"},
+			},
+		},
+		"legacy remote": {
+			local: &Capabilities{
+				ClientVersion:    "v1.1.0",
+				ProtocolVersions: VersionRange{1, 2},
+				MessageVersions:  VersionRange{1, 2},
+				Features:         []string{"a"},
+			},
+			remote: nil,
+			expectedNegotiated: &Negotiated{
+				ProtocolVersion: LegacyVersion,
+				MessageVersion:  LegacyVersion,
+				Features:        []string{},
+			},
+		},
+		"legacy remote not supported": {
+			local: &Capabilities{
+				ProtocolVersions: VersionRange{2, 2},
+			},
+			remote:        nil,
+			expectedError: "no common protocol version; local: [2-2], remote: [1-1]",
+		},
+		"no common message version": {
+			local: &Capabilities{
+				MessageVersions: VersionRange{1, 2},
+			},
+			remote: &Capabilities{
+				MessageVersions: VersionRange{3, 4},
+			},
+			expectedError: "no common message version; local: [1-2], remote: [3-4]",
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			negotiated, err := Negotiate(test.local, test.remote)
+
+			if test.expectedError != "" {
+				if err == nil || err.Error() != test.expectedError {
+					t.Fatalf(
+						"unexpected error\nexpected: [%v]\nactual:   [%v]",
+						test.expectedError,
+						err,
+					)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(test.expectedNegotiated, negotiated) {
+				t.Errorf(
+					"unexpected negotiated capabilities\n"+
+						"expected: [%+v]\nactual:   [%+v]",
+					test.expectedNegotiated,
+					negotiated,
+				)
+			}
+		})
+	}
+}
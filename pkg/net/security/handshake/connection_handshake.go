@@ -18,13 +18,15 @@
 //
 // [Act 1]
 // nonce1 = random_nonce()
-// act1Message{nonce1, protocol_id1} ---->
+// act1Message{nonce1, protocol_id1, certificate1, operator_certificates1, capabilities1} ---->
 //                                       [Act 2]
 //                                       nonce2 = random_nonce()
 //                                       challenge = sha256(nonce1 || nonce2)
-//                                       <---- act2Message{challenge, nonce2, protocol_id2}
+//                                       negotiated = negotiate(capabilities2, capabilities1)
+//                                       <---- act2Message{challenge, nonce2, protocol_id2, certificate2, operator_certificates2, capabilities2}
 // [Act 3]
 // challenge = sha256(nonce1 || nonce2)
+// negotiated = negotiate(capabilities1, capabilities2)
 // act3Message{challenge} ---->
 //
 //
@@ -32,6 +34,24 @@
 // initiator and responder in acts one, two, and three of the handshake,
 // respectively.
 //
+// certificate1 and certificate2 are marshaled certificates binding the
+// network keys of the initiator and the responder to their operator keys.
+// The handshake carries them but does not interpret them; it is up to the
+// firewall to verify them. A certificate is empty if the peer's network key
+// is its operator key.
+//
+// operator_certificates1 and operator_certificates2 are marshaled
+// certificates binding the network keys of the initiator and the responder
+// to the keys of additional operators run by them, if any. Like certificate1
+// and certificate2, they are carried but not interpreted by the handshake.
+//
+// capabilities1 and capabilities2 describe client versions, ranges of
+// supported protocol and message versions, and optional features of the
+// initiator and the responder. Both parties negotiate the highest protocol and
+// message versions they have in common along with the common features, and
+// abort the handshake if there is no common version. Peers which do not send
+// capabilities are assumed to support only the legacy versions.
+//
 // initiatorAct1, initiatorAct2, and initiatorAct3 represent the state of the
 // initiator in rounds one, two, and three of the handshake, respectively.
 //
@@ -54,24 +74,32 @@ import (
 
 // Act1Message is sent in the first handshake act by the initiator to the
 // responder. It contains randomly generated `nonce1`, an 8-byte (64-bit)
-// unsigned integer as well as the protocol identifier.
+// unsigned integer as well as the protocol identifier, the initiator's
+// network key certificates, and the initiator's capabilities.
 //
 // act1Message should be signed with initiator's static private key.
 type Act1Message struct {
-	nonce1    uint64
-	protocol1 string
+	nonce1                uint64
+	protocol1             string
+	certificate1          []byte
+	operatorCertificates1 [][]byte
+	capabilities1         *Capabilities
 }
 
 // Act2Message is sent in the second handshake act by the responder to the
 // initiator. It contains randomly generated `nonce2`, which is an 8-byte
 // unsigned integer, `challenge`, which is the result of SHA256 on the
-// concatenated bytes of `nonce1` and `nonce2`, and the protocol identifier.
+// concatenated bytes of `nonce1` and `nonce2`, the protocol identifier,
+// the responder's network key certificates, and the responder's capabilities.
 //
 // act2Message should be signed with responder's static private key.
 type Act2Message struct {
-	nonce2    uint64
-	challenge [sha256.Size]byte
-	protocol2 string
+	nonce2                uint64
+	challenge             [sha256.Size]byte
+	protocol2             string
+	certificate2          []byte
+	operatorCertificates2 [][]byte
+	capabilities2         *Capabilities
 }
 
 // Act3Message is sent in the third handshake act by the initiator to the
@@ -86,44 +114,89 @@ type Act3Message struct {
 // initiatorAct1 represents the state of the initiator in the first act of the
 // handshake protocol.
 type initiatorAct1 struct {
-	nonce1    uint64
-	protocol1 string
+	nonce1                uint64
+	protocol1             string
+	certificate1          []byte
+	operatorCertificates1 [][]byte
+	capabilities1         *Capabilities
 }
 
 // InitiateHandshake function allows to initiate a handshake by creating
 // and initializing a state machine representing initiator in the first round
-// of the handshake, ready to execute the protocol.
-func InitiateHandshake(protocol string) (*initiatorAct1, error) {
+// of the handshake, ready to execute the protocol. The certificate is the
+// initiator's marshaled network key certificate; it may be empty. The
+// operator certificates are the initiator's marshaled network key
+// certificates of additional operators it runs. The capabilities are the
+// initiator's capabilities offered to the responder.
+func InitiateHandshake(
+	protocol string,
+	certificate []byte,
+	operatorCertificates [][]byte,
+	capabilities *Capabilities,
+) (*initiatorAct1, error) {
 	nonce1, err := randomNonce()
 	if err != nil {
 		return nil, fmt.Errorf("could not initiate the handshake: [%v]", err)
 	}
 
-	return &initiatorAct1{nonce1, protocol}, nil
+	return &initiatorAct1{
+		nonce1:                nonce1,
+		protocol1:             protocol,
+		certificate1:          certificate,
+		operatorCertificates1: operatorCertificates,
+		capabilities1:         capabilities,
+	}, nil
 }
 
 // Message returns the message sent by initiator to the responder in the first
 // act of the handshake protocol.
 func (ia1 *initiatorAct1) Message() *Act1Message {
-	return &Act1Message{nonce1: ia1.nonce1, protocol1: ia1.protocol1}
+	return &Act1Message{
+		nonce1:                ia1.nonce1,
+		protocol1:             ia1.protocol1,
+		certificate1:          ia1.certificate1,
+		operatorCertificates1: ia1.operatorCertificates1,
+		capabilities1:         ia1.capabilities1,
+	}
 }
 
 // Next performs a state transition and returns initiator in a state ready to
 // execute the second act of the handshake protocol.
 func (ia1 *initiatorAct1) Next() *initiatorAct2 {
-	return &initiatorAct2{nonce1: ia1.nonce1, protocol1: ia1.protocol1}
+	return &initiatorAct2{
+		nonce1:        ia1.nonce1,
+		protocol1:     ia1.protocol1,
+		capabilities1: ia1.capabilities1,
+	}
 }
 
 // AnswerHandshake is used to initiate a responder as a result of receiving
 // message from initiator in the first act of the handshake protocol.
 // The returned responder is in a state ready to execute the second act of the
 // handshake protocol.
-// The function also validates if both parties run the same protocol.
-func AnswerHandshake(message *Act1Message, protocol string) (*responderAct2, error) {
+// The function also validates if both parties run the same protocol and
+// negotiates capabilities of both parties. If the parties have no protocol or
+// message version in common, IncompatibleError is returned.
+// The certificate is the responder's marshaled network key certificate; it
+// may be empty. The operator certificates are the responder's marshaled
+// network key certificates of additional operators it runs. The capabilities
+// are the responder's capabilities.
+func AnswerHandshake(
+	message *Act1Message,
+	protocol string,
+	certificate []byte,
+	operatorCertificates [][]byte,
+	capabilities *Capabilities,
+) (*responderAct2, error) {
 	if message.protocol1 != protocol {
 		return nil, fmt.Errorf("unsupported protocol: [%v]", message.protocol1)
 	}
 
+	negotiated, err := Negotiate(capabilities, message.capabilities1)
+	if err != nil {
+		return nil, &IncompatibleError{err}
+	}
+
 	nonce1 := message.nonce1
 	nonce2, err := randomNonce()
 	if err != nil {
@@ -131,34 +204,89 @@ func AnswerHandshake(message *Act1Message, protocol string) (*responderAct2, err
 	}
 	challenge := hashToChallenge(nonce1, nonce2)
 
-	return &responderAct2{nonce2, challenge, protocol}, nil
+	return &responderAct2{
+		nonce2:                nonce2,
+		challenge:             challenge,
+		protocol2:             protocol,
+		certificate2:          certificate,
+		operatorCertificates2: operatorCertificates,
+		capabilities2:         capabilities,
+		negotiated:            negotiated,
+	}, nil
+}
+
+// Certificate returns the initiator's marshaled network key certificate.
+// It is empty if the initiator's network key is its operator key.
+func (am *Act1Message) Certificate() []byte {
+	return am.certificate1
+}
+
+// Certificate returns the responder's marshaled network key certificate.
+// It is empty if the responder's network key is its operator key.
+func (am *Act2Message) Certificate() []byte {
+	return am.certificate2
+}
+
+// OperatorCertificates returns the initiator's marshaled network key
+// certificates of additional operators run by the initiator.
+func (am *Act1Message) OperatorCertificates() [][]byte {
+	return am.operatorCertificates1
+}
+
+// OperatorCertificates returns the responder's marshaled network key
+// certificates of additional operators run by the responder.
+func (am *Act2Message) OperatorCertificates() [][]byte {
+	return am.operatorCertificates2
+}
+
+// IncompatibleError is returned when the handshake parties have no protocol
+// or message version in common.
+type IncompatibleError struct {
+	err error
+}
+
+func (ie *IncompatibleError) Error() string {
+	return fmt.Sprintf("incompatible peer: [%v]", ie.err)
 }
 
 // initiatorAct2 represents the state of the initiator in the second act of the
 // handshake protocol.
 type initiatorAct2 struct {
-	nonce1    uint64
-	protocol1 string
+	nonce1        uint64
+	protocol1     string
+	capabilities1 *Capabilities
 }
 
 // responderAct2 represents the state of the responder in the second act of the
 // handshake protocol.
 type responderAct2 struct {
-	nonce2    uint64
-	challenge [sha256.Size]byte
-	protocol2 string
+	nonce2                uint64
+	challenge             [sha256.Size]byte
+	protocol2             string
+	certificate2          []byte
+	operatorCertificates2 [][]byte
+	capabilities2         *Capabilities
+	negotiated            *Negotiated
 }
 
 // Message returns the message sent by responder to the initiator in the second
 // act of the handshake protocol.
 func (ra2 *responderAct2) Message() *Act2Message {
 	return &Act2Message{
-		nonce2:    ra2.nonce2,
-		challenge: ra2.challenge,
-		protocol2: ra2.protocol2,
+		nonce2:                ra2.nonce2,
+		challenge:             ra2.challenge,
+		protocol2:             ra2.protocol2,
+		certificate2:          ra2.certificate2,
+		operatorCertificates2: ra2.operatorCertificates2,
+		capabilities2:         ra2.capabilities2,
 	}
 }
 
+// Negotiated returns capabilities negotiated with the initiator.
+func (ra2 *responderAct2) Negotiated() *Negotiated {
+	return ra2.negotiated
+}
+
 // Next performs a state transition and returns responder in a state ready to
 // execute the third act of the handshake protocol.
 func (ra2 *responderAct2) Next() *responderAct3 {
@@ -173,24 +301,35 @@ func (ra2 *responderAct2) Next() *responderAct3 {
 // initiator is returned. Otherwise, function reports an error and handshake
 // protocol should be immediately aborted.
 //
-// The function also validates if both parties run the same protocol.
+// The function also validates if both parties run the same protocol and
+// negotiates capabilities of both parties. If the parties have no protocol or
+// message version in common, IncompatibleError is returned.
 func (ia2 *initiatorAct2) Next(message *Act2Message) (*initiatorAct3, error) {
 	if message.protocol2 != ia2.protocol1 {
 		return nil, fmt.Errorf("unsupported protocol: [%v]", message.protocol2)
 	}
 
+	negotiated, err := Negotiate(ia2.capabilities1, message.capabilities2)
+	if err != nil {
+		return nil, &IncompatibleError{err}
+	}
+
 	expectedChallenge := hashToChallenge(ia2.nonce1, message.nonce2)
 	if expectedChallenge != message.challenge {
 		return nil, fmt.Errorf("unexpected responder's challenge")
 	}
 
-	return &initiatorAct3{challenge: message.challenge}, nil
+	return &initiatorAct3{
+		challenge:  message.challenge,
+		negotiated: negotiated,
+	}, nil
 }
 
 // initiatorAct3 represents the state of the initiator in the third act of the
 // handshake protocol.
 type initiatorAct3 struct {
-	challenge [sha256.Size]byte
+	challenge  [sha256.Size]byte
+	negotiated *Negotiated
 }
 
 // responderAct3 represents the state of the responder in the third act of the
@@ -205,6 +344,11 @@ func (ia3 *initiatorAct3) Message() *Act3Message {
 	return &Act3Message{challenge: ia3.challenge}
 }
 
+// Negotiated returns capabilities negotiated with the responder.
+func (ia3 *initiatorAct3) Negotiated() *Negotiated {
+	return ia3.negotiated
+}
+
 // FinalizeHandshake is used in the third act of the handshake protocol to
 // inform responder about a message sent by initiator. Responder validates
 // the challenge in the message comparing it with the one expected.
@@ -1,6 +1,7 @@
 package handshake
 
 import (
+	"bytes"
 	"errors"
 	"math/rand"
 	"reflect"
@@ -13,11 +14,11 @@ const (
 )
 
 func TestInitiateHanshakeWithUniqueNonce(t *testing.T) {
-	initiator1, err := InitiateHandshake(protocol)
+	initiator1, err := InitiateHandshake(protocol, nil, nil, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
-	initiator2, err := InitiateHandshake(protocol)
+	initiator2, err := InitiateHandshake(protocol, nil, nil, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -38,14 +39,14 @@ func TestAnswerHandshakeWithChallenge(t *testing.T) {
 	//
 
 	// initiator station
-	initiator, err := InitiateHandshake(protocol)
+	initiator, err := InitiateHandshake(protocol, nil, nil, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
 	act1Msg := initiator.Message()
 
 	// responder station
-	responder, err := AnswerHandshake(act1Msg, protocol)
+	responder, err := AnswerHandshake(act1Msg, protocol, nil, nil, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -79,10 +80,10 @@ func TestRepeatChallengeToFinalize(t *testing.T) {
 	//
 
 	// responder station
-	act2Msg := &Act2Message{nonce2, expectedChallenge, protocol}
+	act2Msg := &Act2Message{nonce2, expectedChallenge, protocol, nil, nil, nil}
 
 	// initiator station
-	initiatorAct2 := &initiatorAct2{nonce1, protocol}
+	initiatorAct2 := &initiatorAct2{nonce1, protocol, nil}
 	initiatorAct3, err := initiatorAct2.Next(act2Msg)
 	if err != nil {
 		t.Fatal(err)
@@ -112,14 +113,14 @@ func TestFailAct1ForUnexpectedProtocol(t *testing.T) {
 	//
 
 	// initiator station
-	initiator, err := InitiateHandshake(protocol)
+	initiator, err := InitiateHandshake(protocol, nil, nil, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
 	act1Msg := initiator.Message()
 
 	// responder station
-	_, err = AnswerHandshake(act1Msg, protocol2)
+	_, err = AnswerHandshake(act1Msg, protocol2, nil, nil, nil)
 
 	expectedErr := "unsupported protocol: [keep-beacon]"
 	if err.Error() != expectedErr {
@@ -141,10 +142,10 @@ func TestFailAct2ForUnexpectedProtocol(t *testing.T) {
 	//
 
 	// responder station
-	act2Msg := &Act2Message{nonce2, expectedChallenge, protocol2}
+	act2Msg := &Act2Message{nonce2, expectedChallenge, protocol2, nil, nil, nil}
 
 	// initiator station
-	initiatorAct2 := &initiatorAct2{nonce1, protocol}
+	initiatorAct2 := &initiatorAct2{nonce1, protocol, nil}
 	_, err := initiatorAct2.Next(act2Msg)
 
 	expectedErr := "unsupported protocol: [keep-ecdsa]"
@@ -167,10 +168,10 @@ func TestFailAct2ForInvalidChallenge(t *testing.T) {
 
 	// responder station
 	invalidChallenge := [32]byte{0xff, 0xfa}
-	act2Msg := &Act2Message{nonce2, invalidChallenge, protocol}
+	act2Msg := &Act2Message{nonce2, invalidChallenge, protocol, nil, nil, nil}
 
 	// initiator station
-	initiatorAct2 := &initiatorAct2{nonce1, protocol}
+	initiatorAct2 := &initiatorAct2{nonce1, protocol, nil}
 	_, err := initiatorAct2.Next(act2Msg)
 
 	// assert if initiator detects invalid challenge sent by responder
@@ -189,7 +190,7 @@ func TestFailAct3ForInvalidChallenge(t *testing.T) {
 	responderAct3 := &responderAct3{expectedChallenge}
 
 	invalidChallenge := hashToChallenge(rand.Uint64(), rand.Uint64())
-	initiatorAct3 := &initiatorAct3{invalidChallenge}
+	initiatorAct3 := &initiatorAct3{invalidChallenge, nil}
 
 	//
 	// Act 3
@@ -214,7 +215,7 @@ func TestFullHandshake(t *testing.T) {
 	//
 
 	// initiator station
-	initiatorAct1, err := InitiateHandshake(protocol)
+	initiatorAct1, err := InitiateHandshake(protocol, nil, nil, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -222,7 +223,7 @@ func TestFullHandshake(t *testing.T) {
 	initiatorAct2 := initiatorAct1.Next()
 
 	// responder station
-	responderAct2, err := AnswerHandshake(act1Message, protocol)
+	responderAct2, err := AnswerHandshake(act1Message, protocol, nil, nil, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -254,3 +255,182 @@ func TestFullHandshake(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestHandshakeCarriesCertificates(t *testing.T) {
+	initiatorCertificate := []byte{1, 2, 3}
+	initiatorOperatorCertificates := [][]byte{{7, 8, 9}, {10, 11, 12}}
+	responderCertificate := []byte{4, 5, 6}
+	responderOperatorCertificates := [][]byte{{13, 14, 15}}
+
+	initiatorAct1, err := InitiateHandshake(
+		protocol,
+		initiatorCertificate,
+		initiatorOperatorCertificates,
+		nil,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	act1Message := initiatorAct1.Message()
+
+	if !bytes.Equal(act1Message.Certificate(), initiatorCertificate) {
+		t.Errorf(
+			"unexpected initiator's certificate\nexpected: [%v]\nactual:   [%v]",
+			initiatorCertificate,
+			act1Message.Certificate(),
+		)
+	}
+	if !reflect.DeepEqual(
+		act1Message.OperatorCertificates(),
+		initiatorOperatorCertificates,
+	) {
+		t.Errorf(
+			"unexpected initiator's operator certificates\n"+
+				"expected: [%v]\nactual:   [%v]",
+			initiatorOperatorCertificates,
+			act1Message.OperatorCertificates(),
+		)
+	}
+
+	responderAct2, err := AnswerHandshake(
+		act1Message,
+		protocol,
+		responderCertificate,
+		responderOperatorCertificates,
+		nil,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	act2Message := responderAct2.Message()
+
+	if !bytes.Equal(act2Message.Certificate(), responderCertificate) {
+		t.Errorf(
+			"unexpected responder's certificate\nexpected: [%v]\nactual:   [%v]",
+			responderCertificate,
+			act2Message.Certificate(),
+		)
+	}
+	if !reflect.DeepEqual(
+		act2Message.OperatorCertificates(),
+		responderOperatorCertificates,
+	) {
+		t.Errorf(
+			"unexpected responder's operator certificates\n"+
+				"expected: [%v]\nactual:   [%v]",
+			responderOperatorCertificates,
+			act2Message.OperatorCertificates(),
+		)
+	}
+}
+
+func TestHandshakeNegotiatesCapabilities(t *testing.T) {
+	initiatorCapabilities := &Capabilities{
+		ClientVersion:    "v1.3.0",
+		ProtocolVersions: VersionRange{1, 3},
+		MessageVersions:  VersionRange{1, 2},
+		Features:         []string{"a", "b"},
+	}
+	responderCapabilities := &Capabilities{
+		ClientVersion:    "v1.2.0",
+		ProtocolVersions: VersionRange{1, 2},
+		MessageVersions:  VersionRange{2, 4},
+		Features:         []string{"b", "c"},
+	}
+
+	initiatorAct1, err := InitiateHandshake(protocol, nil, nil, initiatorCapabilities)
+	if err != nil {
+		t.Fatal(err)
+	}
+	initiatorAct2 := initiatorAct1.Next()
+
+	responderAct2, err := AnswerHandshake(
+		initiatorAct1.Message(),
+		protocol,
+		nil,
+		nil,
+		responderCapabilities,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	initiatorAct3, err := initiatorAct2.Next(responderAct2.Message())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedResponderNegotiated := &Negotiated{
+		RemoteClientVersion: "v1.3.0",
+		ProtocolVersion:     2,
+		MessageVersion:      2,
+		Features:            []string{"b"},
+	}
+	if !reflect.DeepEqual(expectedResponderNegotiated, responderAct2.Negotiated()) {
+		t.Errorf(
+			"unexpected responder's negotiated capabilities\n"+
+				"expected: [%+v]\nactual:   [%+v]",
+			expectedResponderNegotiated,
+			responderAct2.Negotiated(),
+		)
+	}
+
+	expectedInitiatorNegotiated := &Negotiated{
+		RemoteClientVersion: "v1.2.0",
+		ProtocolVersion:     2,
+		MessageVersion:      2,
+		Features:            []string{"b"},
+	}
+	if !reflect.DeepEqual(expectedInitiatorNegotiated, initiatorAct3.Negotiated()) {
+		t.Errorf(
+			"unexpected initiator's negotiated capabilities\n"+
+				"expected: [%+v]\nactual:   [%+v]",
+			expectedInitiatorNegotiated,
+			initiatorAct3.Negotiated(),
+		)
+	}
+}
+
+func TestFailAct1ForIncompatibleCapabilities(t *testing.T) {
+	initiator, err := InitiateHandshake(
+		protocol,
+		nil,
+		nil,
+		&Capabilities{ProtocolVersions: VersionRange{3, 4}},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = AnswerHandshake(
+		initiator.Message(),
+		protocol,
+		nil,
+		nil,
+		&Capabilities{ProtocolVersions: VersionRange{1, 2}},
+	)
+	if _, ok := err.(*IncompatibleError); !ok {
+		t.Fatalf("expected incompatible error; has [%v]", err)
+	}
+}
+
+func TestFailAct2ForIncompatibleCapabilities(t *testing.T) {
+	nonce1 := rand.Uint64()
+	nonce2 := rand.Uint64()
+	expectedChallenge := hashToChallenge(nonce1, nonce2)
+
+	act2Msg := &Act2Message{
+		nonce2,
+		expectedChallenge,
+		protocol,
+		nil,
+		nil,
+		&Capabilities{MessageVersions: VersionRange{2, 2}},
+	}
+
+	initiatorAct2 := &initiatorAct2{nonce1, protocol, nil}
+	_, err := initiatorAct2.Next(act2Msg)
+	if _, ok := err.(*IncompatibleError); !ok {
+		t.Fatalf("expected incompatible error; has [%v]", err)
+	}
+}
@@ -17,7 +17,13 @@ const (
 func (am *Act1Message) Marshal() ([]byte, error) {
 	nonceBytes := make([]byte, nonceByteLength)
 	binary.LittleEndian.PutUint64(nonceBytes, am.nonce1)
-	return (&pb.Act1Message{Nonce: nonceBytes, Protocol: am.protocol1}).Marshal()
+	return (&pb.Act1Message{
+		Nonce:                nonceBytes,
+		Protocol:             am.protocol1,
+		Certificate:          am.certificate1,
+		OperatorCertificates: am.operatorCertificates1,
+		Capabilities:         marshalCapabilities(am.capabilities1),
+	}).Marshal()
 }
 
 // Unmarshal converts a byte array produced by Marshal to a Act1Message.
@@ -36,6 +42,12 @@ func (am *Act1Message) Unmarshal(bytes []byte) error {
 
 	am.protocol1 = pbAct1.Protocol
 
+	am.certificate1 = pbAct1.Certificate
+
+	am.operatorCertificates1 = pbAct1.OperatorCertificates
+
+	am.capabilities1 = unmarshalCapabilities(pbAct1.Capabilities)
+
 	return nil
 }
 
@@ -45,9 +57,12 @@ func (am *Act2Message) Marshal() ([]byte, error) {
 	nonceBytes := make([]byte, nonceByteLength)
 	binary.LittleEndian.PutUint64(nonceBytes, am.nonce2)
 	return (&pb.Act2Message{
-		Nonce:     nonceBytes,
-		Challenge: am.challenge[:],
-		Protocol:  am.protocol2,
+		Nonce:                nonceBytes,
+		Challenge:            am.challenge[:],
+		Protocol:             am.protocol2,
+		Certificate:          am.certificate2,
+		OperatorCertificates: am.operatorCertificates2,
+		Capabilities:         marshalCapabilities(am.capabilities2),
 	}).Marshal()
 }
 
@@ -74,6 +89,12 @@ func (am *Act2Message) Unmarshal(bytes []byte) error {
 
 	am.protocol2 = pbAct2.Protocol
 
+	am.certificate2 = pbAct2.Certificate
+
+	am.operatorCertificates2 = pbAct2.OperatorCertificates
+
+	am.capabilities2 = unmarshalCapabilities(pbAct2.Capabilities)
+
 	return nil
 }
 
@@ -99,3 +120,39 @@ func (am *Act3Message) Unmarshal(bytes []byte) error {
 
 	return nil
 }
+
+func marshalCapabilities(capabilities *Capabilities) *pb.Capabilities {
+	if capabilities == nil {
+		return nil
+	}
+
+	return &pb.Capabilities{
+		ClientVersion:      capabilities.ClientVersion,
+		MinProtocolVersion: capabilities.ProtocolVersions.Min,
+		MaxProtocolVersion: capabilities.ProtocolVersions.Max,
+		MinMessageVersion:  capabilities.MessageVersions.Min,
+		MaxMessageVersion:  capabilities.MessageVersions.Max,
+		Features:           capabilities.Features,
+	}
+}
+
+// unmarshalCapabilities returns nil if the message carries no capabilities,
+// meaning the sender does not support capability negotiation.
+func unmarshalCapabilities(pbCapabilities *pb.Capabilities) *Capabilities {
+	if pbCapabilities == nil {
+		return nil
+	}
+
+	return &Capabilities{
+		ClientVersion: pbCapabilities.ClientVersion,
+		ProtocolVersions: VersionRange{
+			Min: pbCapabilities.MinProtocolVersion,
+			Max: pbCapabilities.MaxProtocolVersion,
+		},
+		MessageVersions: VersionRange{
+			Min: pbCapabilities.MinMessageVersion,
+			Max: pbCapabilities.MaxMessageVersion,
+		},
+		Features: pbCapabilities.Features,
+	}
+}
@@ -11,8 +11,19 @@ import (
 
 func TestAct1MessageRoundTrip(t *testing.T) {
 	message := &Act1Message{
-		nonce1:    100,
-		protocol1: "keep-beacon",
+		nonce1:       100,
+		protocol1:    "keep-beacon",
+		certificate1: []byte{1, 2, 3},
+		operatorCertificates1: [][]byte{
+			{7, 8, 9},
+			{10, 11, 12},
+		},
+		capabilities1: &Capabilities{
+			ClientVersion:    "v1.0.0",
+			ProtocolVersions: VersionRange{1, 2},
+			MessageVersions:  VersionRange{1, 1},
+			Features:         []string{"missed-messages"},
+		},
 	}
 
 	unmarshaler := &Act1Message{}
@@ -60,9 +71,19 @@ func TestAct2MessageRoundTrip(t *testing.T) {
 	}
 
 	message := &Act2Message{
-		nonce2:    100,
-		challenge: challenge,
-		protocol2: "keep-ecdsa",
+		nonce2:       100,
+		challenge:    challenge,
+		protocol2:    "keep-ecdsa",
+		certificate2: []byte{4, 5, 6},
+		operatorCertificates2: [][]byte{
+			{13, 14, 15},
+		},
+		capabilities2: &Capabilities{
+			ClientVersion:    "v1.1.0",
+			ProtocolVersions: VersionRange{1, 3},
+			MessageVersions:  VersionRange{1, 2},
+			Features:         []string{"missed-messages", "rpc"},
+		},
 	}
 
 	unmarshaler := &Act2Message{}
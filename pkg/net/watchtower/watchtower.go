@@ -13,10 +13,29 @@ import (
 
 	"github.com/keep-network/keep-core/pkg/net"
 	"github.com/keep-network/keep-core/pkg/net/key"
+	"github.com/keep-network/keep-core/pkg/net/reputation"
 )
 
 var logger = log.Logger("keep-net-watchtower")
 
+// MaxViolations is the number of violations of network rules, such as message
+// rate limits, reported for a peer within a single guard round after which
+// the peer gets disconnected.
+const MaxViolations = 10
+
+// Reputation enforcement constants.
+const (
+	// ReputationCheckTick is the amount of time between periodic checks of
+	// reputation scores of all connected peers.
+	ReputationCheckTick = time.Second * 10
+	// BanThreshold is the reputation score at or below which the peer gets
+	// temporarily banned.
+	BanThreshold = -100
+	// BanDuration is the amount of time for which peers with low reputation
+	// are banned.
+	BanDuration = time.Hour
+)
+
 // Guard contains the state necessary to make connection pruning decisions.
 type Guard struct {
 	duration time.Duration
@@ -27,6 +46,9 @@ type Guard struct {
 
 	peerCrossListLock sync.Mutex
 	peerCrossList     map[string]bool
+
+	violationsLock sync.Mutex
+	violations     map[string]int
 }
 
 // NewGuard returns a new instance of Guard. Should only be called once per
@@ -43,6 +65,7 @@ func NewGuard(
 		firewall:          firewall,
 		connectionManager: connectionManager,
 		peerCrossList:     make(map[string]bool),
+		violations:        make(map[string]int),
 	}
 	go guard.start(ctx)
 	return guard
@@ -80,6 +103,10 @@ func (g *Guard) start(ctx context.Context) {
 		case <-ticker.C:
 			logger.Debugf("starting firewall guard round")
 
+			g.violationsLock.Lock()
+			g.violations = make(map[string]int)
+			g.violationsLock.Unlock()
+
 			connectedPeers := g.connectionManager.ConnectedPeers()
 
 			for _, connectedPeer := range connectedPeers {
@@ -96,6 +123,78 @@ func (g *Guard) start(ctx context.Context) {
 	}
 }
 
+// ReportViolation reports that the connected peer violated network rules,
+// for example exceeded message rate limits. The peer is disconnected once it
+// reaches MaxViolations within a single guard round.
+func (g *Guard) ReportViolation(peer string, reason string) {
+	g.violationsLock.Lock()
+	g.violations[peer]++
+	violations := g.violations[peer]
+	if violations >= MaxViolations {
+		delete(g.violations, peer)
+	}
+	g.violationsLock.Unlock()
+
+	if violations >= MaxViolations {
+		logger.Warningf(
+			"dropping the connection; "+
+				"peer [%v] reached [%v] violations; last one: [%v]",
+			peer,
+			violations,
+			reason,
+		)
+		g.connectionManager.DisconnectPeer(peer)
+	}
+}
+
+// EnforceReputation starts a background process which periodically checks
+// reputation scores of connected peers. Peers whose score dropped to
+// BanThreshold or below are banned for BanDuration and disconnected. Banned
+// peers are also disconnected if they manage to connect again.
+func (g *Guard) EnforceReputation(
+	ctx context.Context,
+	reputation *reputation.Service,
+) {
+	go func() {
+		ticker := time.NewTicker(ReputationCheckTick)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				g.checkReputation(reputation)
+			}
+		}
+	}()
+}
+
+func (g *Guard) checkReputation(reputation *reputation.Service) {
+	for _, connectedPeer := range g.connectionManager.ConnectedPeers() {
+		if reputation.IsBanned(connectedPeer) {
+			logger.Warningf(
+				"dropping the connection; peer [%v] is banned",
+				connectedPeer,
+			)
+			g.connectionManager.DisconnectPeer(connectedPeer)
+			continue
+		}
+
+		if score := reputation.Score(connectedPeer); score <= BanThreshold {
+			logger.Warningf(
+				"dropping the connection; banning peer [%v] for [%v] "+
+					"due to low reputation score [%.2f]",
+				connectedPeer,
+				BanDuration,
+				score,
+			)
+			reputation.Ban(connectedPeer, BanDuration)
+			g.connectionManager.DisconnectPeer(connectedPeer)
+		}
+	}
+}
+
 func (g *Guard) checkFirewallRules(peer string) {
 	defer g.completedCheck(peer)
 
@@ -113,7 +212,19 @@ func (g *Guard) checkFirewallRules(peer string) {
 		return
 	}
 
-	if err := g.firewall.Validate(peerPublicKey); err != nil {
+	peerCertificate, err := g.connectionManager.GetPeerCertificate(peer)
+	if err != nil {
+		logger.Errorf(
+			"dropping the connection; "+
+				"could not get certificate for peer [%v]: [%v]",
+			peer,
+			err,
+		)
+		g.connectionManager.DisconnectPeer(peer)
+		return
+	}
+
+	if err := g.firewall.Validate(peerPublicKey, peerCertificate); err != nil {
 
 		logger.Warningf(
 			"dropping the connection; "+
@@ -7,8 +7,10 @@ import (
 	"testing"
 	"time"
 
+	"github.com/keep-network/keep-core/pkg/net"
 	"github.com/keep-network/keep-core/pkg/net/key"
 	localNetwork "github.com/keep-network/keep-core/pkg/net/local"
+	"github.com/keep-network/keep-core/pkg/net/reputation"
 )
 
 func TestDisconnect(t *testing.T) {
@@ -60,6 +62,89 @@ func TestDisconnect(t *testing.T) {
 	}
 }
 
+func TestDisconnectAfterViolations(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	_, peer2PublicKey, err := key.GenerateStaticNetworkKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	firewall := newMockFirewall()
+	firewall.updatePeer(peer2PublicKey, true)
+
+	peer1Provider := localNetwork.Connect()
+	guard := NewGuard(ctx, time.Hour, firewall, peer1Provider.ConnectionManager())
+
+	peer2Provider := localNetwork.Connect()
+	peer2 := peer2Provider.ID().String()
+	peer1Provider.AddPeer(peer2, peer2PublicKey)
+
+	for i := 0; i < MaxViolations-1; i++ {
+		guard.ReportViolation(peer2, "rate limit exceeded")
+	}
+
+	if len(peer1Provider.ConnectionManager().ConnectedPeers()) != 1 {
+		t.Fatal("peer 1 should stay connected with peer 2")
+	}
+
+	guard.ReportViolation(peer2, "rate limit exceeded")
+
+	if len(peer1Provider.ConnectionManager().ConnectedPeers()) != 0 {
+		t.Fatal("peer 1 should drop the connection with peer 2")
+	}
+}
+
+func TestDisconnectAndBanLowReputationPeer(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	_, peer2PublicKey, err := key.GenerateStaticNetworkKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	firewall := newMockFirewall()
+	firewall.updatePeer(peer2PublicKey, true)
+
+	peer1Provider := localNetwork.Connect()
+	guard := NewGuard(ctx, time.Hour, firewall, peer1Provider.ConnectionManager())
+
+	peer2Provider := localNetwork.Connect()
+	peer2 := peer2Provider.ID().String()
+	peer1Provider.AddPeer(peer2, peer2PublicKey)
+
+	peerReputation := reputation.NewService()
+
+	// one disqualification is not enough to get banned
+	peerReputation.ReportMisbehavior(peer2Provider.ID(), net.Disqualification)
+	guard.checkReputation(peerReputation)
+
+	if len(peer1Provider.ConnectionManager().ConnectedPeers()) != 1 {
+		t.Fatal("peer 1 should stay connected with peer 2")
+	}
+
+	peerReputation.ReportMisbehavior(peer2Provider.ID(), net.Disqualification)
+	peerReputation.ReportMisbehavior(peer2Provider.ID(), net.InvalidSignatureShare)
+	guard.checkReputation(peerReputation)
+
+	if len(peer1Provider.ConnectionManager().ConnectedPeers()) != 0 {
+		t.Fatal("peer 1 should drop the connection with peer 2")
+	}
+	if !peerReputation.IsBanned(peer2) {
+		t.Fatal("peer 2 should be banned")
+	}
+
+	// banned peer is disconnected again even if its score recovers
+	peer1Provider.AddPeer(peer2, peer2PublicKey)
+	guard.checkReputation(peerReputation)
+
+	if len(peer1Provider.ConnectionManager().ConnectedPeers()) != 0 {
+		t.Fatal("peer 1 should drop the connection with banned peer 2")
+	}
+}
+
 func newMockFirewall() *mockFirewall {
 	return &mockFirewall{
 		meetsCriteria: make(map[uint64]bool),
@@ -70,7 +155,10 @@ type mockFirewall struct {
 	meetsCriteria map[uint64]bool
 }
 
-func (mf *mockFirewall) Validate(remotePeerPublicKey *ecdsa.PublicKey) error {
+func (mf *mockFirewall) Validate(
+	remotePeerPublicKey *ecdsa.PublicKey,
+	certificate *key.Certificate,
+) error {
 	if !mf.meetsCriteria[remotePeerPublicKey.X.Uint64()] {
 		return fmt.Errorf("remote peer does not meet firewall criteria")
 	}
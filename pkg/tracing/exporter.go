@@ -0,0 +1,345 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// exportQueueSize is the number of ended spans waiting for the export.
+	// Spans ended while the queue is full are dropped.
+	exportQueueSize = 2048
+	// exportBatchSize is the maximum number of spans exported in a single
+	// request to the collector.
+	exportBatchSize = 256
+	// exportInterval is the period after which ended spans are exported even
+	// if the batch is not full.
+	exportInterval = 5 * time.Second
+	// exportTimeout is the timeout of a single request to the collector.
+	exportTimeout = 10 * time.Second
+)
+
+var (
+	exporterMutex   sync.RWMutex
+	currentExporter *exporter
+)
+
+// Initialize enables tracing. Spans are exported to the collector accepting
+// the OpenTelemetry protocol over HTTP with JSON encoding at the given
+// endpoint, e.g. http://localhost:4318/v1/traces. The service name and
+// instance identify the client in the collector.
+func Initialize(endpoint string, serviceName string, instance string) error {
+	if _, err := url.ParseRequestURI(endpoint); err != nil {
+		return fmt.Errorf("invalid tracing endpoint [%v]: [%v]", endpoint, err)
+	}
+
+	newExporter := &exporter{
+		endpoint: endpoint,
+		client:   &http.Client{Timeout: exportTimeout},
+		resource: otlpAttributes(map[string]interface{}{
+			"service.name":        serviceName,
+			"service.instance.id": instance,
+		}),
+		queue:  make(chan *finishedSpan, exportQueueSize),
+		done:   make(chan struct{}),
+		closed: make(chan struct{}),
+	}
+
+	exporterMutex.Lock()
+	previousExporter := currentExporter
+	currentExporter = newExporter
+	exporterMutex.Unlock()
+
+	if previousExporter != nil {
+		previousExporter.close()
+	}
+
+	go newExporter.run()
+
+	return nil
+}
+
+// Close disables tracing and exports spans ended so far.
+func Close() {
+	exporterMutex.Lock()
+	closedExporter := currentExporter
+	currentExporter = nil
+	exporterMutex.Unlock()
+
+	if closedExporter != nil {
+		closedExporter.close()
+	}
+}
+
+func isEnabled() bool {
+	exporterMutex.RLock()
+	defer exporterMutex.RUnlock()
+
+	return currentExporter != nil
+}
+
+func export(span *finishedSpan) {
+	exporterMutex.RLock()
+	defer exporterMutex.RUnlock()
+
+	if currentExporter == nil {
+		return
+	}
+
+	select {
+	case currentExporter.queue <- span:
+	default:
+		logger.Debugf("export queue is full; dropping span [%v]", span.name)
+	}
+}
+
+type exporter struct {
+	endpoint string
+	client   *http.Client
+	resource []*otlpAttribute
+
+	queue  chan *finishedSpan
+	done   chan struct{}
+	closed chan struct{}
+}
+
+func (e *exporter) run() {
+	defer close(e.closed)
+
+	ticker := time.NewTicker(exportInterval)
+	defer ticker.Stop()
+
+	batch := make([]*finishedSpan, 0, exportBatchSize)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+
+		if err := e.send(batch); err != nil {
+			logger.Warningf(
+				"could not export [%v] spans: [%v]",
+				len(batch),
+				err,
+			)
+		}
+
+		batch = make([]*finishedSpan, 0, exportBatchSize)
+	}
+
+	for {
+		select {
+		case span := <-e.queue:
+			batch = append(batch, span)
+			if len(batch) == exportBatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		case <-e.done:
+			for {
+				select {
+				case span := <-e.queue:
+					batch = append(batch, span)
+					if len(batch) == exportBatchSize {
+						flush()
+					}
+				default:
+					flush()
+					return
+				}
+			}
+		}
+	}
+}
+
+func (e *exporter) close() {
+	close(e.done)
+	<-e.closed
+}
+
+func (e *exporter) send(spans []*finishedSpan) error {
+	request := &otlpTraceRequest{
+		ResourceSpans: []*otlpResourceSpans{
+			{
+				Resource: &otlpResource{Attributes: e.resource},
+				ScopeSpans: []*otlpScopeSpans{
+					{
+						Scope: &otlpScope{Name: "keep-core"},
+						Spans: otlpSpans(spans),
+					},
+				},
+			},
+		},
+	}
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		return fmt.Errorf("could not marshal spans: [%v]", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), exportTimeout)
+	defer cancel()
+
+	httpRequest, err := http.NewRequest(
+		http.MethodPost,
+		e.endpoint,
+		bytes.NewReader(body),
+	)
+	if err != nil {
+		return err
+	}
+	httpRequest = httpRequest.WithContext(ctx)
+	httpRequest.Header.Set("Content-Type", "application/json")
+
+	response, err := e.client.Do(httpRequest)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode/100 != 2 {
+		return fmt.Errorf("collector responded with [%v]", response.Status)
+	}
+
+	return nil
+}
+
+// The types below follow the JSON encoding of the OpenTelemetry protocol
+// trace export request.
+
+type otlpTraceRequest struct {
+	ResourceSpans []*otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   *otlpResource     `json:"resource"`
+	ScopeSpans []*otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []*otlpAttribute `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope *otlpScope  `json:"scope"`
+	Spans []*otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpSpan struct {
+	TraceID           string           `json:"traceId"`
+	SpanID            string           `json:"spanId"`
+	ParentSpanID      string           `json:"parentSpanId,omitempty"`
+	Name              string           `json:"name"`
+	Kind              int              `json:"kind"`
+	StartTimeUnixNano string           `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string           `json:"endTimeUnixNano"`
+	Attributes        []*otlpAttribute `json:"attributes,omitempty"`
+	Status            *otlpStatus      `json:"status"`
+}
+
+type otlpAttribute struct {
+	Key   string     `json:"key"`
+	Value *otlpValue `json:"value"`
+}
+
+type otlpValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	IntValue    *string  `json:"intValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+const (
+	otlpSpanKindInternal = 1
+
+	otlpStatusCodeOk    = 1
+	otlpStatusCodeError = 2
+)
+
+func otlpSpans(spans []*finishedSpan) []*otlpSpan {
+	converted := make([]*otlpSpan, len(spans))
+	for i, span := range spans {
+		converted[i] = &otlpSpan{
+			TraceID:           span.traceID.String(),
+			SpanID:            span.spanID.String(),
+			Name:              span.name,
+			Kind:              otlpSpanKindInternal,
+			StartTimeUnixNano: strconv.FormatInt(span.start.UnixNano(), 10),
+			EndTimeUnixNano:   strconv.FormatInt(span.end.UnixNano(), 10),
+			Attributes:        otlpAttributes(span.attributes),
+			Status:            &otlpStatus{Code: otlpStatusCodeOk},
+		}
+
+		if span.parentSpanID != nil {
+			converted[i].ParentSpanID = span.parentSpanID.String()
+		}
+
+		if span.err != nil {
+			converted[i].Status = &otlpStatus{
+				Code:    otlpStatusCodeError,
+				Message: span.err.Error(),
+			}
+		}
+	}
+
+	return converted
+}
+
+func otlpAttributes(attributes map[string]interface{}) []*otlpAttribute {
+	keys := make([]string, 0, len(attributes))
+	for key := range attributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	converted := make([]*otlpAttribute, len(keys))
+	for i, key := range keys {
+		converted[i] = &otlpAttribute{
+			Key:   key,
+			Value: otlpAttributeValue(attributes[key]),
+		}
+	}
+
+	return converted
+}
+
+func otlpAttributeValue(value interface{}) *otlpValue {
+	var integer int64
+	switch v := value.(type) {
+	case bool:
+		return &otlpValue{BoolValue: &v}
+	case float64:
+		return &otlpValue{DoubleValue: &v}
+	case int:
+		integer = int64(v)
+	case int64:
+		integer = v
+	case uint8:
+		integer = int64(v)
+	case uint64:
+		integer = int64(v)
+	default:
+		stringValue := fmt.Sprintf("%v", v)
+		return &otlpValue{StringValue: &stringValue}
+	}
+
+	intValue := strconv.FormatInt(integer, 10)
+	return &otlpValue{IntValue: &intValue}
+}
@@ -0,0 +1,210 @@
+// Package tracing records spans of protocol executions: group selection,
+// phases of distributed key generation, DKG result publication and relay
+// entry signing. Spans are exported in the OpenTelemetry protocol format to
+// a collector.
+//
+// Spans of the same protocol execution recorded by different clients share
+// the trace identifier derived from the protocol seed, so a collector
+// receiving spans from all clients joins them into a single trace.
+//
+// Until the exporter is initialized, tracing is disabled and spans are not
+// recorded.
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/ipfs/go-log"
+)
+
+var logger = log.Logger("keep-tracing")
+
+// TraceID identifies all spans of a single protocol execution.
+type TraceID [16]byte
+
+// String returns the hex representation of the trace identifier.
+func (ti TraceID) String() string {
+	return hex.EncodeToString(ti[:])
+}
+
+// SpanID identifies a single span.
+type SpanID [8]byte
+
+// String returns the hex representation of the span identifier.
+func (si SpanID) String() string {
+	return hex.EncodeToString(si[:])
+}
+
+// NewTraceID derives the trace identifier of a protocol execution of the
+// given kind, e.g. GroupCreation or RelayEntry, from the seed of the
+// execution. All clients taking part in the execution derive the same
+// identifier.
+func NewTraceID(kind string, seed []byte) TraceID {
+	hash := sha256.Sum256(append([]byte(kind+":"), seed...))
+
+	var traceID TraceID
+	copy(traceID[:], hash[:])
+	return traceID
+}
+
+// Kinds of traced protocol executions.
+const (
+	// GroupCreation covers group selection, distributed key generation and
+	// DKG result publication for a group selection seed.
+	GroupCreation = "group-creation"
+	// RelayEntry covers signing and submission of a relay entry for the
+	// previous entry.
+	RelayEntry = "relay-entry"
+)
+
+type contextKey int
+
+const (
+	traceIDKey contextKey = iota
+	spanKey
+)
+
+// WithTraceID returns a copy of the context in which spans without a parent
+// span are started in the trace with the given identifier.
+func WithTraceID(ctx context.Context, traceID TraceID) context.Context {
+	return context.WithValue(ctx, traceIDKey, traceID)
+}
+
+// Span represents a single operation within a protocol execution. Methods of
+// a nil span are no-ops, so a span returned when tracing is disabled can be
+// used as any other span.
+type Span struct {
+	traceID      TraceID
+	spanID       SpanID
+	parentSpanID *SpanID
+	name         string
+	start        time.Time
+
+	mutex      sync.Mutex
+	attributes map[string]interface{}
+	err        error
+	ended      bool
+}
+
+// Start starts a new span with the given name. The span is a child of the
+// span in the given context. If there is no span in the context, the span is
+// started in the trace set with WithTraceID or, if there is none, in a new
+// random trace. The returned context contains the new span.
+//
+// Start returns a nil span and the given context if tracing is disabled.
+func Start(
+	ctx context.Context,
+	name string,
+	attributes map[string]interface{},
+) (context.Context, *Span) {
+	if !isEnabled() {
+		return ctx, nil
+	}
+
+	span := &Span{
+		spanID:     newSpanID(),
+		name:       name,
+		start:      time.Now(),
+		attributes: make(map[string]interface{}),
+	}
+
+	if parent, ok := ctx.Value(spanKey).(*Span); ok && parent != nil {
+		span.traceID = parent.traceID
+		span.parentSpanID = &parent.spanID
+	} else if traceID, ok := ctx.Value(traceIDKey).(TraceID); ok {
+		span.traceID = traceID
+	} else {
+		rand.Read(span.traceID[:])
+	}
+
+	for key, value := range attributes {
+		span.attributes[key] = value
+	}
+
+	return context.WithValue(ctx, spanKey, span), span
+}
+
+func newSpanID() SpanID {
+	var spanID SpanID
+	rand.Read(spanID[:])
+	return spanID
+}
+
+// SetAttribute sets the attribute of the span.
+func (s *Span) SetAttribute(key string, value interface{}) {
+	if s == nil {
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.attributes[key] = value
+}
+
+// End ends the span and passes it to the exporter. The span is marked as
+// failed if the given error is not nil. Calls after the first one have no
+// effect.
+func (s *Span) End(err error) {
+	if s == nil {
+		return
+	}
+
+	s.mutex.Lock()
+	if s.ended {
+		s.mutex.Unlock()
+		return
+	}
+	s.ended = true
+	s.err = err
+	s.mutex.Unlock()
+
+	export(s.finish(time.Now()))
+}
+
+// TraceID returns the identifier of the trace of the span.
+func (s *Span) TraceID() TraceID {
+	if s == nil {
+		return TraceID{}
+	}
+
+	return s.traceID
+}
+
+func (s *Span) finish(end time.Time) *finishedSpan {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	attributes := make(map[string]interface{}, len(s.attributes))
+	for key, value := range s.attributes {
+		attributes[key] = value
+	}
+
+	return &finishedSpan{
+		traceID:      s.traceID,
+		spanID:       s.spanID,
+		parentSpanID: s.parentSpanID,
+		name:         s.name,
+		start:        s.start,
+		end:          end,
+		attributes:   attributes,
+		err:          s.err,
+	}
+}
+
+// finishedSpan is an immutable copy of an ended span.
+type finishedSpan struct {
+	traceID      TraceID
+	spanID       SpanID
+	parentSpanID *SpanID
+	name         string
+	start        time.Time
+	end          time.Time
+	attributes   map[string]interface{}
+	err          error
+}
@@ -0,0 +1,162 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestNewTraceID(t *testing.T) {
+	seed := []byte{1, 2, 3}
+
+	if NewTraceID("dkg", seed) != NewTraceID("dkg", seed) {
+		t.Errorf("expected the same trace ID for the same seed")
+	}
+	if NewTraceID("dkg", seed) == NewTraceID("relay-entry", seed) {
+		t.Errorf("expected different trace IDs for different kinds")
+	}
+	if NewTraceID("dkg", seed) == NewTraceID("dkg", []byte{1, 2, 4}) {
+		t.Errorf("expected different trace IDs for different seeds")
+	}
+}
+
+func TestStartNotInitialized(t *testing.T) {
+	ctx := context.Background()
+
+	spanCtx, span := Start(ctx, "test", nil)
+	if span != nil {
+		t.Fatalf("expected no span when tracing is disabled")
+	}
+	if spanCtx != ctx {
+		t.Errorf("expected unchanged context when tracing is disabled")
+	}
+
+	// Methods of a nil span are no-ops.
+	span.SetAttribute("key", "value")
+	span.End(nil)
+}
+
+func TestExport(t *testing.T) {
+	collector := newTestCollector()
+	defer collector.server.Close()
+
+	if err := Initialize(collector.server.URL, "keep-client", "node-1"); err != nil {
+		t.Fatal(err)
+	}
+
+	traceID := NewTraceID("dkg", []byte{1})
+	ctx := WithTraceID(context.Background(), traceID)
+
+	parentCtx, parent := Start(ctx, "dkg", map[string]interface{}{"member": 3})
+	_, child := Start(parentCtx, "phase", nil)
+	child.SetAttribute("block", uint64(10))
+	child.End(fmt.Errorf("phase failed"))
+	parent.End(nil)
+
+	Close()
+
+	spans := collector.spans()
+	if len(spans) != 2 {
+		t.Fatalf("unexpected number of spans\nexpected: [2]\nactual:   [%v]", len(spans))
+	}
+
+	childSpan, parentSpan := spans[0], spans[1]
+
+	if parentSpan["traceId"] != traceID.String() ||
+		childSpan["traceId"] != traceID.String() {
+		t.Errorf("expected spans in trace [%v]", traceID)
+	}
+	if _, ok := parentSpan["parentSpanId"]; ok {
+		t.Errorf("expected no parent of the parent span")
+	}
+	if childSpan["parentSpanId"] != parentSpan["spanId"] {
+		t.Errorf(
+			"unexpected parent span\nexpected: [%v]\nactual:   [%v]",
+			parentSpan["spanId"],
+			childSpan["parentSpanId"],
+		)
+	}
+
+	expectedChildStatus := map[string]interface{}{
+		"code":    float64(2),
+		"message": "phase failed",
+	}
+	if !reflect.DeepEqual(expectedChildStatus, childSpan["status"]) {
+		t.Errorf(
+			"unexpected child status\nexpected: [%v]\nactual:   [%v]",
+			expectedChildStatus,
+			childSpan["status"],
+		)
+	}
+
+	expectedParentAttributes := []interface{}{
+		map[string]interface{}{
+			"key":   "member",
+			"value": map[string]interface{}{"intValue": "3"},
+		},
+	}
+	if !reflect.DeepEqual(expectedParentAttributes, parentSpan["attributes"]) {
+		t.Errorf(
+			"unexpected parent attributes\nexpected: [%v]\nactual:   [%v]",
+			expectedParentAttributes,
+			parentSpan["attributes"],
+		)
+	}
+
+	// Spans are not recorded once tracing is closed.
+	if _, span := Start(ctx, "after-close", nil); span != nil {
+		t.Errorf("expected no span after closing")
+	}
+}
+
+type testCollector struct {
+	server *httptest.Server
+
+	mutex    sync.Mutex
+	received []map[string]interface{}
+}
+
+func newTestCollector() *testCollector {
+	collector := &testCollector{}
+	collector.server = httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			body, _ := ioutil.ReadAll(r.Body)
+
+			raw := map[string][]struct {
+				ScopeSpans []struct {
+					Spans []map[string]interface{} `json:"spans"`
+				} `json:"scopeSpans"`
+			}{}
+			if err := json.Unmarshal(body, &raw); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			collector.mutex.Lock()
+			defer collector.mutex.Unlock()
+			for _, resourceSpans := range raw["resourceSpans"] {
+				for _, scopeSpans := range resourceSpans.ScopeSpans {
+					collector.received = append(
+						collector.received,
+						scopeSpans.Spans...,
+					)
+				}
+			}
+		},
+	))
+
+	return collector
+}
+
+func (tc *testCollector) spans() []map[string]interface{} {
+	tc.mutex.Lock()
+	defer tc.mutex.Unlock()
+
+	return tc.received
+}